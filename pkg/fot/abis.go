@@ -1,4 +1,4 @@
-package fulcrom
+package fot
 
 import (
 	"bytes"
@@ -7,9 +7,8 @@ import (
 )
 
 var (
-	vaultABI          abi.ABI
-	vaultPriceFeedABI abi.ABI
-	erc20ABI          abi.ABI
+	erc20ABI     abi.ABI
+	multicallABI abi.ABI
 )
 
 func init() {
@@ -17,9 +16,8 @@ func init() {
 		ABI  *abi.ABI
 		data []byte
 	}{
-		{&vaultABI, vaultJson},
-		{&vaultPriceFeedABI, vaultPriceFeedJson},
-		{&erc20ABI, erc20Json},
+		{&erc20ABI, erc20Bytes},
+		{&multicallABI, multicallBytes},
 	}
 
 	for _, b := range builder {
@@ -0,0 +1,97 @@
+// Package fot models tokens whose transfers do not move the amount sent: fee-on-transfer tokens taxing transfers and
+// rebasing tokens whose balances change without transfers.
+package fot
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// Behavior is how transfers of a token deviate from a plain ERC20. Taxes are in basis points of the amount sent.
+type Behavior struct {
+	BuyTax      uint64 `json:"buyTax,omitempty"`      // taxed on transfers out of the pairs of the token
+	SellTax     uint64 `json:"sellTax,omitempty"`     // taxed on transfers into the pairs of the token
+	TransferTax uint64 `json:"transferTax,omitempty"` // taxed on other transfers
+	Rebasing    bool   `json:"rebasing,omitempty"`    // balances change without transfers
+}
+
+// IsTaxed returns whether any transfer of the token is taxed
+func (b Behavior) IsTaxed() bool {
+	return b.BuyTax > 0 || b.SellTax > 0 || b.TransferTax > 0
+}
+
+// IsPlain returns whether the token behaves like a plain ERC20
+func (b Behavior) IsPlain() bool {
+	return !b.IsTaxed() && !b.Rebasing
+}
+
+// taxIn is the tax on transfers into a pool. Tokens usually tax either trades with their pairs or all transfers, so
+// the sell tax applies if set and the transfer tax otherwise.
+func (b Behavior) taxIn() uint64 {
+	if b.SellTax > 0 {
+		return b.SellTax
+	}
+	return b.TransferTax
+}
+
+// taxOut is the tax on transfers out of a pool, see taxIn
+func (b Behavior) taxOut() uint64 {
+	if b.BuyTax > 0 {
+		return b.BuyTax
+	}
+	return b.TransferTax
+}
+
+// afterTax returns the amount received when sending amount with a tax, which is rounded down like taxed tokens do
+func afterTax(amount *big.Int, tax uint64) *big.Int {
+	if tax == 0 {
+		return amount
+	}
+	taxAmount := new(big.Int).Mul(amount, new(big.Int).SetUint64(tax))
+	taxAmount.Quo(taxAmount, big.NewInt(BasisPoint))
+	return taxAmount.Sub(amount, taxAmount)
+}
+
+// beforeTax returns the amount to send with a tax for at least amount to be received, which is the exact inverse of
+// the tax rounded up
+func beforeTax(amount *big.Int, tax uint64) *big.Int {
+	if tax == 0 {
+		return amount
+	} else if tax >= BasisPoint {
+		return nil
+	}
+	denominator := big.NewInt(int64(BasisPoint - tax))
+	sent := new(big.Int).Mul(amount, big.NewInt(BasisPoint))
+	sent.Add(sent, denominator).Sub(sent, big.NewInt(1))
+	return sent.Quo(sent, denominator)
+}
+
+// Registry holds the behaviors of tokens that are not plain ERC20s. It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	behaviors map[string]Behavior
+}
+
+func NewRegistry() *Registry {
+	return &Registry{behaviors: make(map[string]Behavior)}
+}
+
+// Set sets the behavior of a token, removing plain tokens from the registry
+func (r *Registry) Set(token string, behavior Behavior) {
+	token = strings.ToLower(token)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if behavior.IsPlain() {
+		delete(r.behaviors, token)
+	} else {
+		r.behaviors[token] = behavior
+	}
+}
+
+// Get returns the behavior of a token, which is plain for unknown tokens
+func (r *Registry) Get(token string) Behavior {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.behaviors[strings.ToLower(token)]
+}
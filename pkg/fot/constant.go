@@ -0,0 +1,22 @@
+package fot
+
+import "github.com/ethereum/go-ethereum/common"
+
+const (
+	erc20MethodBalanceOf     = "balanceOf"
+	erc20MethodTransfer      = "transfer"
+	multicallMethodAggregate = "aggregate"
+
+	// BasisPoint is the denominator of taxes
+	BasisPoint = 10000
+
+	// detectAmountDivisor is the share of the balance of the pool transferred by the detector, kept small so that
+	// transfers stay below the max transaction amount of most taxed tokens
+	detectAmountDivisor = 1000
+)
+
+// probe accounts transferring tokens between themselves in detections, which are not pairs of any token
+var (
+	probeAccount0 = common.HexToAddress("0x000000000000000000000000000000000f07f070")
+	probeAccount1 = common.HexToAddress("0x000000000000000000000000000000000f07f071")
+)
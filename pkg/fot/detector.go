@@ -0,0 +1,118 @@
+package fot
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+)
+
+// Detector detects the taxes of tokens by simulating transfers with an eth_call. The code of a Multicall contract is
+// placed at a pair holding the token and at two probe accounts, so that calls to the pair can transfer the token out
+// of it, between the probe accounts and back into it, reading the balance of each receiver in between.
+type Detector struct {
+	ethrpcClient *ethrpc.Client
+	multicall    common.Address
+}
+
+// NewDetector returns a detector using the code of the Multicall contract at multicallAddress
+func NewDetector(ethrpcClient *ethrpc.Client, multicallAddress string) *Detector {
+	return &Detector{ethrpcClient: ethrpcClient, multicall: common.HexToAddress(multicallAddress)}
+}
+
+// Detect returns the taxes of a token, using a pair of the token holding some of it. Rebasing cannot be detected
+// from transfers, so it is left to be set in the registry.
+func (d *Detector) Detect(ctx context.Context, token, pair string) (Behavior, error) {
+	multicallCode, err := d.ethrpcClient.GetETHClient().CodeAt(ctx, d.multicall, nil)
+	if err != nil {
+		return Behavior{}, err
+	} else if len(multicallCode) == 0 {
+		return Behavior{}, ErrNoMulticallCode
+	}
+
+	var pairBalance *big.Int
+	if _, err := d.ethrpcClient.NewRequest().SetContext(ctx).AddCall(&ethrpc.Call{
+		ABI:    erc20ABI,
+		Target: token,
+		Method: erc20MethodBalanceOf,
+		Params: []any{common.HexToAddress(pair)},
+	}, []any{&pairBalance}).Call(); err != nil {
+		return Behavior{}, err
+	}
+	amount := new(big.Int).Quo(pairBalance, big.NewInt(detectAmountDivisor))
+	if amount.Sign() == 0 {
+		return Behavior{}, ErrPairBalanceTooLow
+	}
+
+	// the pair sends amount to the first probe account, which sends what it received to the second one, which sends
+	// it back to the pair. Calls are replayed in each eth_call to read the amount received by each hop.
+	tokenAddress, pairAddress := common.HexToAddress(token), common.HexToAddress(pair)
+	hops := [3][2]common.Address{{pairAddress, probeAccount0}, {probeAccount0, probeAccount1}, {probeAccount1, pairAddress}}
+	overrides := map[common.Address]gethclient.OverrideAccount{
+		pairAddress:   {Code: multicallCode},
+		probeAccount0: {Code: multicallCode},
+		probeAccount1: {Code: multicallCode},
+	}
+
+	var (
+		calls []ethrpc.MultiCallParam
+		taxes [3]uint64
+		sent  = amount
+	)
+	for i, hop := range hops {
+		from, to := hop[0], hop[1]
+		transfer, err := transferCall(tokenAddress, pairAddress, from, to, sent)
+		if err != nil {
+			return Behavior{}, err
+		}
+		balanceOf, err := erc20ABI.Pack(erc20MethodBalanceOf, to)
+		if err != nil {
+			return Behavior{}, err
+		}
+		calls = append(calls, transfer, ethrpc.MultiCallParam{Target: tokenAddress, CallData: balanceOf})
+
+		var result ethrpc.AggregateResult
+		if _, err := d.ethrpcClient.NewRequest().SetContext(ctx).SetOverrides(overrides).AddCall(&ethrpc.Call{
+			ABI:    multicallABI,
+			Target: pair,
+			Method: multicallMethodAggregate,
+			Params: []any{calls},
+		}, []any{&result}).Call(); err != nil {
+			return Behavior{}, err
+		} else if len(result.ReturnData) != len(calls) {
+			return Behavior{}, ErrDetectTransferFailed
+		}
+
+		received := new(big.Int).SetBytes(result.ReturnData[len(calls)-1])
+		if to == pairAddress {
+			received.Sub(received, new(big.Int).Sub(pairBalance, amount))
+		}
+		taxes[i] = taxOf(sent, received)
+		sent = received
+	}
+
+	return Behavior{BuyTax: taxes[0], TransferTax: taxes[1], SellTax: taxes[2]}, nil
+}
+
+// transferCall returns the call of the pair transferring amount of token from one account to another. The pair calls
+// the token directly, and other accounts through their Multicall code.
+func transferCall(token, pair, from, to common.Address, amount *big.Int) (ethrpc.MultiCallParam, error) {
+	data, err := erc20ABI.Pack(erc20MethodTransfer, to, amount)
+	if err != nil || from == pair {
+		return ethrpc.MultiCallParam{Target: token, CallData: data}, err
+	}
+	data, err = multicallABI.Pack(multicallMethodAggregate, []ethrpc.MultiCallParam{{Target: token, CallData: data}})
+	return ethrpc.MultiCallParam{Target: from, CallData: data}, err
+}
+
+// taxOf returns the tax in basis points taken from sent for received to arrive, rounded up
+func taxOf(sent, received *big.Int) uint64 {
+	if sent.Sign() <= 0 || received.Cmp(sent) >= 0 {
+		return 0
+	}
+	tax := new(big.Int).Sub(sent, received)
+	tax.Mul(tax, big.NewInt(BasisPoint)).Add(tax, sent).Sub(tax, big.NewInt(1))
+	return tax.Quo(tax, sent).Uint64()
+}
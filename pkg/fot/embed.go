@@ -0,0 +1,9 @@
+package fot
+
+import _ "embed"
+
+//go:embed abis/ERC20.json
+var erc20Bytes []byte
+
+//go:embed abis/Multicall.json
+var multicallBytes []byte
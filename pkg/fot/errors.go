@@ -0,0 +1,12 @@
+package fot
+
+import "errors"
+
+var (
+	ErrUnsupportedToken        = errors.New("pool does not support fee-on-transfer or rebasing token")
+	ErrCalcAmountInUnsupported = errors.New("pool does not support CalcAmountIn")
+	ErrNoRegistry              = errors.New("pool has no token registry")
+	ErrNoMulticallCode         = errors.New("no Multicall contract code")
+	ErrPairBalanceTooLow       = errors.New("pair balance too low to detect taxes")
+	ErrDetectTransferFailed    = errors.New("failed to simulate token transfers")
+)
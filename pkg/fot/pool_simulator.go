@@ -0,0 +1,151 @@
+package fot
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
+
+// IPoolMeta is implemented by pool metas reporting whether their pools can swap taxed or rebasing tokens. Pools whose
+// meta does not implement it are assumed to support neither, as most pools check the amounts they receive or track
+// their reserves.
+type IPoolMeta interface {
+	SupportsTaxedTokens() bool
+	SupportsRebasingTokens() bool
+}
+
+// PoolSimulator applies the taxes of the tokens in the registry around a pool simulator: the pool receives the amount
+// in after the tax on transfers into it, and the receiver gets the amount out after the tax on transfers out of it.
+// The registry is shared between pools and left out of snapshots, so pools decoded from a snapshot return
+// ErrNoRegistry until it is set again with SetRegistry.
+type PoolSimulator struct {
+	pool.IPoolSimulator
+	registry *Registry `msgpack:"-"`
+}
+
+// SwapInfo is the swap info of taxed swaps: the swap info of the wrapped simulator with the amounts the pool received
+// and sent. Consumers expecting the swap info of the wrapped simulator should unwrap it with UnwrapSwapInfo.
+type SwapInfo struct {
+	SwapInfo  any      `json:"swapInfo"`
+	AmountIn  *big.Int `json:"amountIn"`  // received by the pool, after the tax on the amount in
+	AmountOut *big.Int `json:"amountOut"` // sent by the pool, before the tax on the amount out
+}
+
+// UnwrapSwapInfo returns the swap info of the wrapped simulator if swapInfo is a SwapInfo, and swapInfo otherwise
+func UnwrapSwapInfo(swapInfo any) any {
+	if si, ok := swapInfo.(SwapInfo); ok {
+		return si.SwapInfo
+	}
+	return swapInfo
+}
+
+func NewPoolSimulator(p pool.IPoolSimulator, registry *Registry) *PoolSimulator {
+	return &PoolSimulator{IPoolSimulator: p, registry: registry}
+}
+
+// SetRegistry sets the registry of a pool, e.g. after decoding it from a snapshot
+func (p *PoolSimulator) SetRegistry(registry *Registry) {
+	p.registry = registry
+}
+
+// Unwrap returns the wrapped pool simulator
+func (p *PoolSimulator) Unwrap() pool.IPoolSimulator {
+	return p.IPoolSimulator
+}
+
+func (p *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	tokenIn, tokenOut := params.TokenAmountIn.Token, params.TokenOut
+	behaviorIn, behaviorOut, err := p.behaviors(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	amountIn := afterTax(params.TokenAmountIn.Amount, behaviorIn.taxIn())
+	params.TokenAmountIn.Amount = amountIn
+	result, err := p.IPoolSimulator.CalcAmountOut(params)
+	if err != nil || behaviorIn.IsPlain() && behaviorOut.IsPlain() {
+		return result, err
+	}
+
+	taxed := *result
+	taxed.TokenAmountOut = &pool.TokenAmount{
+		Token:     result.TokenAmountOut.Token,
+		Amount:    afterTax(result.TokenAmountOut.Amount, behaviorOut.taxOut()),
+		AmountUsd: result.TokenAmountOut.AmountUsd,
+	}
+	taxed.SwapInfo = SwapInfo{SwapInfo: result.SwapInfo, AmountIn: amountIn, AmountOut: result.TokenAmountOut.Amount}
+	return &taxed, nil
+}
+
+func (p *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
+	exactOutPool, ok := p.IPoolSimulator.(pool.IPoolExactOutSimulator)
+	if !ok {
+		return nil, ErrCalcAmountInUnsupported
+	}
+
+	tokenIn, tokenOut := params.TokenIn, params.TokenAmountOut.Token
+	behaviorIn, behaviorOut, err := p.behaviors(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	amountOut := beforeTax(params.TokenAmountOut.Amount, behaviorOut.taxOut())
+	if amountOut == nil {
+		return nil, ErrUnsupportedToken
+	}
+	params.TokenAmountOut.Amount = amountOut
+	result, err := exactOutPool.CalcAmountIn(params)
+	if err != nil || behaviorIn.IsPlain() && behaviorOut.IsPlain() {
+		return result, err
+	}
+
+	amountIn := beforeTax(result.TokenAmountIn.Amount, behaviorIn.taxIn())
+	if amountIn == nil {
+		return nil, ErrUnsupportedToken
+	}
+	taxed := *result
+	taxed.TokenAmountIn = &pool.TokenAmount{
+		Token:     result.TokenAmountIn.Token,
+		Amount:    amountIn,
+		AmountUsd: result.TokenAmountIn.AmountUsd,
+	}
+	taxed.SwapInfo = SwapInfo{SwapInfo: result.SwapInfo, AmountIn: result.TokenAmountIn.Amount, AmountOut: amountOut}
+	return &taxed, nil
+}
+
+func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := p.IPoolSimulator.CloneState()
+	if cloned == nil {
+		return nil
+	}
+	return NewPoolSimulator(cloned, p.registry)
+}
+
+// UpdateBalance updates the wrapped pool with the amounts it received and sent
+func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
+	if si, ok := params.SwapInfo.(SwapInfo); ok {
+		params.TokenAmountIn.Amount = si.AmountIn
+		params.TokenAmountOut.Amount = si.AmountOut
+		params.SwapInfo = si.SwapInfo
+	}
+	p.IPoolSimulator.UpdateBalance(params)
+}
+
+// behaviors returns the behaviors of the tokens of a swap, or ErrUnsupportedToken if the pool cannot swap them
+func (p *PoolSimulator) behaviors(tokenIn, tokenOut string) (Behavior, Behavior, error) {
+	if p.registry == nil {
+		return Behavior{}, Behavior{}, ErrNoRegistry
+	}
+	behaviorIn, behaviorOut := p.registry.Get(tokenIn), p.registry.Get(tokenOut)
+	if behaviorIn.IsPlain() && behaviorOut.IsPlain() {
+		return behaviorIn, behaviorOut, nil
+	}
+
+	meta, _ := p.GetMetaInfo(tokenIn, tokenOut).(IPoolMeta)
+	if meta == nil ||
+		(behaviorIn.IsTaxed() || behaviorOut.IsTaxed()) && !meta.SupportsTaxedTokens() ||
+		(behaviorIn.Rebasing || behaviorOut.Rebasing) && !meta.SupportsRebasingTokens() {
+		return behaviorIn, behaviorOut, ErrUnsupportedToken
+	}
+	return behaviorIn, behaviorOut, nil
+}
@@ -0,0 +1,227 @@
+package fot
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	uniswapv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/uniswap-v2"
+	velodromev1 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/velodrome-v1"
+	velodromev2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/velodrome-v2"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/camelot"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/fraxswap"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/uniswap"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+const (
+	token0 = "0x6d5ad1592ed9d6d1df9b93c793ab759573ed6714"
+	token1 = "0xbb4cdb9cbd36b01bd1cbaebf2de08d9173bc095c"
+)
+
+func newUniswapV2PoolSimulator(t *testing.T, exchange string) *uniswapv2.PoolSimulator {
+	p, err := uniswapv2.NewPoolSimulator(entity.Pool{
+		Address:  "0x9eb0bc7a207f77811ee365729d00152622a745b7",
+		Exchange: exchange,
+		Type:     uniswapv2.DexType,
+		Reserves: []string{"1000000000000000000000", "2000000000000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: token0, Swappable: true}, {Address: token1, Swappable: true}},
+		Extra:    `{"fee":30,"feePrecision":10000}`,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+// assertSameState asserts that a wrapped simulator quotes the same as an untaxed one, so that they have the same state
+func assertSameState(t *testing.T, expected pool.IPoolSimulator, actual *PoolSimulator) {
+	for _, tokens := range [][2]string{{token0, token1}, {token1, token0}} {
+		params := pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: tokens[0], Amount: big.NewInt(1e16)},
+			TokenOut:      tokens[1],
+		}
+		expectedResult, err := expected.CalcAmountOut(params)
+		require.NoError(t, err)
+		actualResult, err := actual.Unwrap().CalcAmountOut(params)
+		require.NoError(t, err)
+		assert.Equal(t, expectedResult.TokenAmountOut.Amount, actualResult.TokenAmountOut.Amount)
+	}
+}
+
+func TestTax(t *testing.T) {
+	assert.Equal(t, big.NewInt(9500), afterTax(big.NewInt(10000), 500))
+	assert.Equal(t, big.NewInt(10), afterTax(big.NewInt(10), 500))
+	assert.Equal(t, big.NewInt(10000), beforeTax(big.NewInt(9500), 500))
+	assert.Equal(t, big.NewInt(10), beforeTax(big.NewInt(9), 500))
+	assert.Nil(t, beforeTax(big.NewInt(1), BasisPoint))
+
+	for _, amount := range []int64{1, 7, 999, 123456789} {
+		gross := beforeTax(big.NewInt(amount), 333)
+		assert.GreaterOrEqual(t, afterTax(gross, 333).Int64(), amount)
+	}
+
+	assert.EqualValues(t, 500, taxOf(big.NewInt(10000), big.NewInt(9500)))
+	assert.EqualValues(t, 1, taxOf(big.NewInt(10000), big.NewInt(9999)))
+	assert.EqualValues(t, 0, taxOf(big.NewInt(10000), big.NewInt(10000)))
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	registry.Set("0xAbC", Behavior{BuyTax: 100, SellTax: 200})
+	assert.Equal(t, Behavior{BuyTax: 100, SellTax: 200}, registry.Get("0xabc"))
+	assert.True(t, registry.Get("0xabc").IsTaxed())
+	assert.True(t, registry.Get("0xdef").IsPlain())
+
+	registry.Set("0xabc", Behavior{})
+	assert.True(t, registry.Get("0xabc").IsPlain())
+}
+
+func TestPoolMetas(t *testing.T) {
+	for _, meta := range []any{uniswapv2.PoolMeta{}, uniswap.Meta{}, camelot.Meta{}, fraxswap.Meta{},
+		velodromev1.PoolMeta{}, velodromev2.PoolMeta{}} {
+		poolMeta, ok := meta.(IPoolMeta)
+		require.Truef(t, ok, "%T does not implement IPoolMeta", meta)
+		assert.Truef(t, poolMeta.SupportsTaxedTokens(), "%T", meta)
+		assert.Falsef(t, poolMeta.SupportsRebasingTokens(), "%T", meta)
+	}
+}
+
+func TestPoolSimulator(t *testing.T) {
+	registry := NewRegistry()
+	registry.Set(token0, Behavior{BuyTax: 500, SellTax: 1000})
+	amountIn := big.NewInt(1e18)
+
+	t.Run("taxed amount in", func(t *testing.T) {
+		inner := newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap)
+		expected, err := inner.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: big.NewInt(9e17)},
+			TokenOut:      token1,
+		})
+		require.NoError(t, err)
+
+		sim := NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap), registry)
+		// the registry is left out of snapshots, pkg/msgpack checks that decoded pools quote the same once it is set
+		testutil.TestPoolSimulator(t, sim, testutil.CheckMsgpack)
+		result, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected.TokenAmountOut.Amount, result.TokenAmountOut.Amount)
+		assert.Equal(t, big.NewInt(1e18), amountIn, "amount in should not be mutated")
+		assert.Equal(t, expected.SwapInfo, UnwrapSwapInfo(result.SwapInfo))
+
+		// the pool receives the amount in after the sell tax
+		inner.UpdateBalance(pool.UpdateBalanceParams{
+			TokenAmountIn:  pool.TokenAmount{Token: token0, Amount: big.NewInt(9e17)},
+			TokenAmountOut: *expected.TokenAmountOut,
+			SwapInfo:       expected.SwapInfo,
+		})
+		sim.UpdateBalance(pool.UpdateBalanceParams{
+			TokenAmountIn:  pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenAmountOut: *result.TokenAmountOut,
+			SwapInfo:       result.SwapInfo,
+		})
+		assertSameState(t, inner, sim)
+	})
+
+	t.Run("taxed amount out", func(t *testing.T) {
+		inner := newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap)
+		untaxed, err := inner.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token1, Amount: amountIn},
+			TokenOut:      token0,
+		})
+		require.NoError(t, err)
+
+		sim := NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap), registry)
+		result, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token1, Amount: amountIn},
+			TokenOut:      token0,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, afterTax(untaxed.TokenAmountOut.Amount, 500), result.TokenAmountOut.Amount)
+
+		// the pool sends the amount out before the buy tax
+		inner.UpdateBalance(pool.UpdateBalanceParams{
+			TokenAmountIn:  pool.TokenAmount{Token: token1, Amount: amountIn},
+			TokenAmountOut: *untaxed.TokenAmountOut,
+			SwapInfo:       untaxed.SwapInfo,
+		})
+		sim.UpdateBalance(pool.UpdateBalanceParams{
+			TokenAmountIn:  pool.TokenAmount{Token: token1, Amount: amountIn},
+			TokenAmountOut: *result.TokenAmountOut,
+			SwapInfo:       result.SwapInfo,
+		})
+		assertSameState(t, inner, sim)
+	})
+
+	t.Run("taxed exact output", func(t *testing.T) {
+		sim := NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap), registry)
+		result, err := sim.CalcAmountIn(pool.CalcAmountInParams{
+			TokenAmountOut: pool.TokenAmount{Token: token1, Amount: big.NewInt(1e17)},
+			TokenIn:        token0,
+		})
+		require.NoError(t, err)
+
+		out, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: *result.TokenAmountIn,
+			TokenOut:      token1,
+		})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, out.TokenAmountOut.Amount.Cmp(big.NewInt(1e17)), 0)
+	})
+
+	t.Run("plain tokens", func(t *testing.T) {
+		inner := newUniswapV2PoolSimulator(t, valueobject.ExchangeMeshSwap)
+		expected, err := inner.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		require.NoError(t, err)
+
+		result, err := NewPoolSimulator(inner, NewRegistry()).CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("no registry", func(t *testing.T) {
+		sim := NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap), nil)
+		_, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		assert.ErrorIs(t, err, ErrNoRegistry)
+
+		sim.SetRegistry(registry)
+		_, err = sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unsupported pools", func(t *testing.T) {
+		sim := NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeMeshSwap), registry)
+		_, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token0, Amount: amountIn},
+			TokenOut:      token1,
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedToken)
+
+		rebasing := NewRegistry()
+		rebasing.Set(token0, Behavior{Rebasing: true})
+		sim = NewPoolSimulator(newUniswapV2PoolSimulator(t, valueobject.ExchangeUniSwap), rebasing)
+		_, err = sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: token1, Amount: amountIn},
+			TokenOut:      token0,
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedToken)
+	})
+}
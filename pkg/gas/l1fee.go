@@ -0,0 +1,117 @@
+package gas
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// L1Params are the L1 pricing parameters of a rollup as read from its gas price oracle. Each L1FeeFunc only uses the
+// fields of its stack; nil fields are treated as zero.
+type L1Params struct {
+	L1BaseFee     *big.Int // OP stack, Scroll: L1 base fee in wei
+	L1BlobBaseFee *big.Int // OP stack, Scroll: L1 blob base fee in wei
+
+	BaseFeeScalar     *big.Int // OP stack: baseFeeScalar, Scroll: commitScalar
+	BlobBaseFeeScalar *big.Int // OP stack: blobBaseFeeScalar, Scroll: blobScalar
+
+	L1PricePerUnit *big.Int // Arbitrum: ArbGasInfo.getL1BaseFeeEstimate, wei per calldata unit
+	VariableCost   *big.Int // Linea: variable cost in wei per calldata byte
+	GasPerPubdata  *big.Int // zkSync: L2 gas per pubdata byte
+	GasPrice       *big.Int // zkSync: L2 gas price in wei
+}
+
+// L1FeeFunc computes the L1 data fee in wei of calldataSize bytes of transaction data. Estimated calldata sizes do not
+// tell zero bytes apart, so all bytes are priced as non-zero, an upper bound.
+type L1FeeFunc func(params *L1Params, calldataSize int) *big.Int
+
+const (
+	// nonZeroByteGas is the L1 calldata gas of a non-zero byte (EIP-2028)
+	nonZeroByteGas = 16
+)
+
+var (
+	opStackScalarPrecision = big.NewInt(1e6)
+	scrollScalarPrecision  = big.NewInt(1e9)
+
+	l1FeeFuncMu sync.RWMutex
+	// l1FeeFuncByChain leaves out on purpose:
+	//   - Polygon zkEVM, which has no separate L1 data fee: the sequencer prices data availability into the L2 gas
+	//     price, so it is already part of the execution gas cost.
+	//   - Mantle, which charges its L1 fee in MNT at the ETH/MNT token ratio of its gas price oracle. L1Params has no
+	//     token ratio, so the fee is left to callers that read it, with RegisterL1FeeFunc.
+	l1FeeFuncByChain = map[valueobject.ChainID]L1FeeFunc{
+		valueobject.ChainIDOptimism:    OPStackL1Fee,
+		valueobject.ChainIDBase:        OPStackL1Fee,
+		valueobject.ChainIDBlast:       OPStackL1Fee,
+		valueobject.ChainIDUnichain:    OPStackL1Fee,
+		valueobject.ChainIDArbitrumOne: ArbitrumL1Fee,
+		valueobject.ChainIDScroll:      ScrollL1Fee,
+		valueobject.ChainIDLinea:       LineaL1Fee,
+		valueobject.ChainIDZKSync:      ZKSyncL1Fee,
+	}
+)
+
+// RegisterL1FeeFunc sets the L1 fee formula of a chain, replacing the default one if any. A nil fn removes it.
+func RegisterL1FeeFunc(chainID valueobject.ChainID, fn L1FeeFunc) {
+	l1FeeFuncMu.Lock()
+	defer l1FeeFuncMu.Unlock()
+	if fn == nil {
+		delete(l1FeeFuncByChain, chainID)
+		return
+	}
+	l1FeeFuncByChain[chainID] = fn
+}
+
+// GetL1FeeFunc returns the L1 fee formula of a chain, or false if the chain has no L1 data fee
+func GetL1FeeFunc(chainID valueobject.ChainID) (L1FeeFunc, bool) {
+	l1FeeFuncMu.RLock()
+	defer l1FeeFuncMu.RUnlock()
+	fn, ok := l1FeeFuncByChain[chainID]
+	return fn, ok
+}
+
+// OPStackL1Fee implements the Ecotone GasPriceOracle.getL1Fee:
+// size * (16 * baseFeeScalar * l1BaseFee + blobBaseFeeScalar * l1BlobBaseFee) / 1e6
+func OPStackL1Fee(params *L1Params, calldataSize int) *big.Int {
+	weightedGasPrice := mul(big.NewInt(nonZeroByteGas), params.BaseFeeScalar, params.L1BaseFee)
+	weightedGasPrice.Add(weightedGasPrice, mul(params.BlobBaseFeeScalar, params.L1BlobBaseFee))
+	fee := weightedGasPrice.Mul(weightedGasPrice, big.NewInt(int64(calldataSize)))
+	return fee.Quo(fee, opStackScalarPrecision)
+}
+
+// ArbitrumL1Fee prices the poster fee: 16 calldata units per byte at the L1 price per unit
+func ArbitrumL1Fee(params *L1Params, calldataSize int) *big.Int {
+	return mul(big.NewInt(int64(calldataSize*nonZeroByteGas)), params.L1PricePerUnit)
+}
+
+// ScrollL1Fee implements the Curie L1GasPriceOracle.getL1Fee:
+// (commitScalar * l1BaseFee + blobScalar * size * l1BlobBaseFee) / 1e9
+func ScrollL1Fee(params *L1Params, calldataSize int) *big.Int {
+	fee := mul(params.BaseFeeScalar, params.L1BaseFee)
+	fee.Add(fee, mul(params.BlobBaseFeeScalar, big.NewInt(int64(calldataSize)), params.L1BlobBaseFee))
+	return fee.Quo(fee, scrollScalarPrecision)
+}
+
+// LineaL1Fee prices the data availability part of the Linea gas price: the variable cost per byte
+func LineaL1Fee(params *L1Params, calldataSize int) *big.Int {
+	return mul(big.NewInt(int64(calldataSize)), params.VariableCost)
+}
+
+// ZKSyncL1Fee prices published data: gasPerPubdata L2 gas per byte at the L2 gas price
+func ZKSyncL1Fee(params *L1Params, calldataSize int) *big.Int {
+	return mul(big.NewInt(int64(calldataSize)), params.GasPerPubdata, params.GasPrice)
+}
+
+// mul multiplies its arguments into a new big.Int, treating nil as zero
+func mul(xs ...*big.Int) *big.Int {
+	res := big.NewInt(1)
+	for _, x := range xs {
+		if x == nil {
+			return new(big.Int)
+		}
+		res.Mul(res, x)
+	}
+	return res
+}
@@ -0,0 +1,89 @@
+package gas
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// TxCalldataSize is the estimated calldata size of a swap transaction excluding its hops: the router call, the
+// executor description and the signature of an RLP-encoded transaction.
+const TxCalldataSize = 600
+
+// Model estimates the cost of swaps on a chain: execution gas at the L2 gas price plus, on rollups, the L1 data fee of
+// their calldata.
+type Model struct {
+	ChainID  valueobject.ChainID
+	GasPrice *big.Int // execution gas price in wei
+	L1Params *L1Params
+}
+
+// Hop is a swap through a single pool as quoted by its simulator
+type Hop struct {
+	Pool     pool.IPoolSimulator
+	TokenIn  string
+	TokenOut string
+	Gas      int64 // execution gas from CalcAmountOutResult.Gas or CalcAmountInResult.Gas
+}
+
+// Cost is the estimated cost of one or more hops
+type Cost struct {
+	Gas          int64    // execution gas
+	CalldataSize int      // calldata bytes
+	ExecutionFee *big.Int // Gas * GasPrice in wei
+	L1Fee        *big.Int // L1 data fee in wei, zero on chains without one
+}
+
+// NewModel returns the gas model of a chain
+func NewModel(chainID valueobject.ChainID, gasPrice *big.Int, l1Params *L1Params) *Model {
+	return &Model{ChainID: chainID, GasPrice: gasPrice, L1Params: l1Params}
+}
+
+// Total returns the total cost in wei
+func (c Cost) Total() *big.Int {
+	return new(big.Int).Add(c.ExecutionFee, c.L1Fee)
+}
+
+// GasEquivalent returns the total cost in execution gas units at the given gas price, for comparing routes by gas
+func (c Cost) GasEquivalent(gasPrice *big.Int) int64 {
+	if gasPrice == nil || gasPrice.Sign() <= 0 {
+		return c.Gas
+	}
+	return c.Gas + new(big.Int).Quo(c.L1Fee, gasPrice).Int64()
+}
+
+// HopCost estimates the marginal cost of a hop, i.e. excluding the calldata of the transaction itself
+func (m *Model) HopCost(hop Hop) Cost {
+	return m.cost(hop.Gas, pool.CalldataSize(hop.Pool, hop.TokenIn, hop.TokenOut), false)
+}
+
+// RouteCost estimates the cost of a swap transaction executing all hops, including TxCalldataSize
+func (m *Model) RouteCost(hops []Hop) Cost {
+	var gas int64
+	var calldataSize int
+	for _, hop := range hops {
+		gas += hop.Gas
+		calldataSize += pool.CalldataSize(hop.Pool, hop.TokenIn, hop.TokenOut)
+	}
+	return m.cost(gas, calldataSize, true)
+}
+
+func (m *Model) cost(gas int64, calldataSize int, withTx bool) Cost {
+	cost := Cost{
+		Gas:          gas,
+		CalldataSize: calldataSize,
+		ExecutionFee: mul(big.NewInt(gas), m.GasPrice),
+		L1Fee:        new(big.Int),
+	}
+	l1FeeFunc, ok := GetL1FeeFunc(m.ChainID)
+	if !ok || m.L1Params == nil {
+		return cost
+	}
+	cost.L1Fee = l1FeeFunc(m.L1Params, TxCalldataSize+calldataSize)
+	if !withTx {
+		// formulas may have fixed per-transaction terms, so price a hop by the difference it makes to a transaction
+		cost.L1Fee.Sub(cost.L1Fee, l1FeeFunc(m.L1Params, TxCalldataSize))
+	}
+	return cost
+}
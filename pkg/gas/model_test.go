@@ -0,0 +1,111 @@
+package gas
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	uniswapv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/uniswap-v2"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+var (
+	token0 = "0x4200000000000000000000000000000000000006"
+	token1 = "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+
+	v2Pool = lo.Must(uniswapv2.NewPoolSimulator(entity.Pool{
+		Address:  "0x88a43bbdf9d098eec7bceda4e2494615dfd9bb9c",
+		Exchange: "uniswap-v2",
+		Type:     uniswapv2.DexType,
+		Reserves: entity.PoolReserves{"1000000000000000000000", "3000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: token0, Swappable: true}, {Address: token1, Swappable: true}},
+		Extra:    `{"fee":3,"feePrecision":1000}`,
+	}))
+
+	opStackParams = &L1Params{
+		L1BaseFee:         big.NewInt(10e9),
+		L1BlobBaseFee:     big.NewInt(1),
+		BaseFeeScalar:     big.NewInt(1368),
+		BlobBaseFeeScalar: big.NewInt(810949),
+	}
+)
+
+func TestL1FeeFuncs(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		fn     L1FeeFunc
+		params *L1Params
+		size   int
+		want   string
+	}{
+		{"op stack", OPStackL1Fee, opStackParams, 256, "56033280207"},
+		{"op stack nil params", OPStackL1Fee, &L1Params{}, 256, "0"},
+		{"arbitrum", ArbitrumL1Fee, &L1Params{L1PricePerUnit: big.NewInt(1e9)}, 256, "4096000000000"},
+		{"scroll", ScrollL1Fee, &L1Params{
+			L1BaseFee:         big.NewInt(10e9),
+			L1BlobBaseFee:     big.NewInt(1e6),
+			BaseFeeScalar:     big.NewInt(1e9),
+			BlobBaseFeeScalar: big.NewInt(2e9),
+		}, 256, "10512000000"},
+		{"linea", LineaL1Fee, &L1Params{VariableCost: big.NewInt(1e8)}, 256, "25600000000"},
+		{"zksync", ZKSyncL1Fee, &L1Params{GasPerPubdata: big.NewInt(800), GasPrice: big.NewInt(45e6)}, 256,
+			"9216000000000"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.fn(tc.params, tc.size).String())
+		})
+	}
+}
+
+func TestGetL1FeeFunc(t *testing.T) {
+	t.Parallel()
+	_, ok := GetL1FeeFunc(valueobject.ChainIDBase)
+	assert.True(t, ok)
+	for _, chainID := range []valueobject.ChainID{valueobject.ChainIDEthereum, valueobject.ChainIDMantle,
+		valueobject.ChainIDPolygonZkEVM} {
+		_, ok := GetL1FeeFunc(chainID)
+		assert.False(t, ok, chainID)
+	}
+}
+
+func TestModel(t *testing.T) {
+	t.Parallel()
+	hop := Hop{Pool: v2Pool, TokenIn: token0, TokenOut: token1, Gas: 76562}
+	assert.Equal(t, 192, pool.CalldataSize(v2Pool, token0, token1))
+
+	t.Run("l1 chain", func(t *testing.T) {
+		cost := NewModel(valueobject.ChainIDEthereum, big.NewInt(1e9), opStackParams).HopCost(hop)
+		assert.Equal(t, "76562000000000", cost.ExecutionFee.String())
+		assert.Zero(t, cost.L1Fee.Sign())
+		assert.Equal(t, int64(76562), cost.GasEquivalent(big.NewInt(1e9)))
+	})
+
+	t.Run("op stack hop", func(t *testing.T) {
+		cost := NewModel(valueobject.ChainIDBase, big.NewInt(1e6), opStackParams).HopCost(hop)
+		assert.Equal(t, 192, cost.CalldataSize)
+		assert.Equal(t, "42024960156", cost.L1Fee.String())
+		assert.Equal(t, int64(76562+42024), cost.GasEquivalent(big.NewInt(1e6)))
+	})
+
+	t.Run("scroll hop excludes fixed cost", func(t *testing.T) {
+		params := &L1Params{L1BaseFee: big.NewInt(10e9), BaseFeeScalar: big.NewInt(1e9)}
+		model := NewModel(valueobject.ChainIDScroll, big.NewInt(1e6), params)
+		assert.Zero(t, model.HopCost(hop).L1Fee.Sign())
+		assert.Equal(t, "10000000000", model.RouteCost([]Hop{hop}).L1Fee.String())
+	})
+
+	t.Run("route", func(t *testing.T) {
+		model := NewModel(valueobject.ChainIDOptimism, big.NewInt(1e6), opStackParams)
+		cost := model.RouteCost([]Hop{hop, hop})
+		assert.Equal(t, int64(2*76562), cost.Gas)
+		assert.Equal(t, 2*192, cost.CalldataSize)
+		assert.Equal(t, OPStackL1Fee(opStackParams, TxCalldataSize+2*192).String(), cost.L1Fee.String())
+		assert.Equal(t, new(big.Int).Add(cost.ExecutionFee, cost.L1Fee).String(), cost.Total().String())
+	})
+}
@@ -0,0 +1,56 @@
+package aavev3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	aUSDC = "0x98c23e9d8f34fefb1b7bd6a91b7ff122f4e16f5c"
+	usdc  = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+)
+
+func newPoolSimulator(t *testing.T, extra string) *PoolSimulator {
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:     aUSDC,
+		Exchange:    "aave-v3",
+		Type:        DexType,
+		Reserves:    entity.PoolReserves{"1000000000000", "1000000000000"},
+		Tokens:      []*entity.PoolToken{{Address: aUSDC, Decimals: 6}, {Address: usdc, Decimals: 6}},
+		Extra:       extra,
+		StaticExtra: `{"aavePoolAddress":"0x87870bca3f3fd6335c3f4ce8392d69350b4fa4e2"}`,
+	})
+	require.NoError(t, err)
+	return poolSim
+}
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim := newPoolSimulator(t, `{"isActive":true}`)
+	testutil.TestPoolSimulator(t, poolSim)
+
+	result, err := poolSim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: usdc, Amount: big.NewInt(1e6)},
+		TokenOut:      aUSDC,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e6), result.TokenAmountOut.Amount)
+	assert.True(t, result.SwapInfo.(*SwapInfo).IsSupply)
+}
+
+func TestPoolSimulator_CanSwap(t *testing.T) {
+	t.Parallel()
+	// a frozen reserve can still be withdrawn from, but not supplied to
+	poolSim := newPoolSimulator(t, `{"isActive":true,"isFrozen":true}`)
+	assert.Equal(t, []string{usdc}, poolSim.CanSwapFrom(aUSDC))
+	assert.Empty(t, poolSim.CanSwapFrom(usdc))
+	assert.Equal(t, []string{aUSDC}, poolSim.CanSwapTo(usdc))
+	assert.Empty(t, poolSim.CanSwapTo(aUSDC))
+}
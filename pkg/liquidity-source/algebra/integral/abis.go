@@ -8,8 +8,12 @@ import (
 
 var (
 	erc20ABI               abi.ABI
+	algebraFactoryABI      abi.ABI
 	algebraIntegralPoolABI abi.ABI
 	algebraBasePluginV2ABI abi.ABI
+	securityPluginABI      abi.ABI
+	securityRegistryABI    abi.ABI
+	limitOrderPluginABI    abi.ABI
 	ticklensABI            abi.ABI
 )
 
@@ -19,8 +23,12 @@ func init() {
 		data []byte
 	}{
 		{&erc20ABI, erc20Json},
+		{&algebraFactoryABI, algebraFactoryJson},
 		{&algebraIntegralPoolABI, algebraIntegralPoolJson},
 		{&algebraBasePluginV2ABI, algebraBasePluginV2Json},
+		{&securityPluginABI, algebraSecurityPluginJson},
+		{&securityRegistryABI, algebraSecurityRegistryJson},
+		{&limitOrderPluginABI, algebraLimitOrderPluginJson},
 		{&ticklensABI, ticklenJson},
 	}
 
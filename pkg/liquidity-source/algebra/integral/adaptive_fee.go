@@ -92,7 +92,8 @@ func expXg4(x *uint256.Int, g uint64) *uint256.Int {
 	if x.CmpUint64(g/2) >= 0 {
 		// (x - closestValue) >= 0.5, so closestValue := closestValue * e^0.5
 		x.SubUint64(x, g/2)
-		closestValue.Mul(closestValue, E_HALF_MULTIPLIER).Div(closestValue, E_MULTIPLIER_BIG)
+		closestValue = new(uint256.Int).Mul(closestValue, E_HALF_MULTIPLIER)
+		closestValue.Div(closestValue, E_MULTIPLIER_BIG)
 	}
 
 	// After calculating the closestValue x/g is <= 0.5, so that the series in the neighborhood of zero converges with sufficient speed
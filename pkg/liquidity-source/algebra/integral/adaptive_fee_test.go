@@ -0,0 +1,20 @@
+package integral
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFee(t *testing.T) {
+	config := &DynamicFeeConfig{Alpha1: 2900, Alpha2: 12000, Beta1: 360, Beta2: 60000, Gamma1: 59, Gamma2: 8500,
+		BaseFee: 100}
+	closestValue1 := CLOSEST_VALUE_1.Clone()
+
+	// normalized volatility 459 is 1.68 gamma1 past beta1, which rounds e^1 up by e^0.5
+	for range 3 {
+		assert.EqualValues(t, 2546, getFee(uint256.NewInt(459*15), config))
+	}
+	assert.Equal(t, closestValue1, CLOSEST_VALUE_1, "shared e-power constants must not be scaled")
+}
@@ -12,4 +12,10 @@ type Config struct {
 	TickLensAddress   string
 
 	UseBasePluginV2 bool `json:"useBasePluginV2"`
+
+	// FactoryAddress is the factory whose logs pools are discovered from
+	FactoryAddress string `json:"factoryAddress"`
+
+	// Plugins maps plugin addresses to their module types, taking precedence over registered plugin addresses
+	Plugins map[string][]PluginType `json:"plugins"`
 }
@@ -10,7 +10,8 @@ const (
 	graphFirstLimit      = 1000
 	defaultTokenDecimals = 18
 
-	maxSwapLoop = 1000000
+	// maxLimitOrderEpochs is the number of latest limit order epochs fetched from limit order plugins
+	maxLimitOrderEpochs = 200
 
 	WINDOW        = 86400 // 1 day in seconds
 	UINT16_MODULO = 65536
@@ -35,10 +36,19 @@ const (
 	slidingFeePluginBaseFeeMethod           = "s_baseFee"
 	slidingFeePluginFeeTypeMethod           = "feeType"
 
+	securityPluginSecurityRegistryMethod = "securityRegistry"
+	securityRegistryGetPoolStatusMethod  = "getPoolStatus"
+
+	limitOrderPluginEpochNextMethod  = "epochNext"
+	limitOrderPluginEpochInfosMethod = "epochInfos"
+
 	erc20BalanceOfMethod = "balanceOf"
 
+	factoryPoolEvent       = "Pool"
+	factoryCustomPoolEvent = "CustomPool"
+
 	BEFORE_SWAP_FLAG = 1
-	RESOLUTION       = 96
+	AFTER_SWAP_FLAG  = 1 << 1
 
 	FEE_FACTOR_SHIFT = 96
 
@@ -47,7 +57,6 @@ const (
 )
 
 var (
-	FEE_DENOMINATOR           = uint256.NewInt(1e6)
 	COMMUNITY_FEE_DENOMINATOR = uint256.NewInt(1e3)
 	FACTOR_DENOMINATOR        = uint256.NewInt(1e3)
 
@@ -58,11 +67,6 @@ var (
 	uFIFTEEN    = uint256.NewInt(15)
 	uTWENTYFOUR = uint256.NewInt(24)
 
-	MIN_SQRT_RATIO    = uint256.NewInt(4295128739)
-	MAX_SQRT_RATIO, _ = uint256.FromDecimal("1461446703485210103287273052203988822378723970342")
-
-	Q96 = new(uint256.Int).Lsh(uONE, 96) // 1 << 96
-
 	FEE_FACTOR_MULTIPLIER = new(uint256.Int).Lsh(uONE, FEE_FACTOR_SHIFT) // 1 << 96
 	DOUBLE_FEE_MULTIPLIER = new(uint256.Int).Lsh(uTWO, FEE_FACTOR_SHIFT) // 2 << 96
 
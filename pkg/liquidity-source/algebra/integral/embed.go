@@ -7,11 +7,23 @@ import (
 //go:embed abis/ERC20.json
 var erc20Json []byte
 
+//go:embed abis/AlgebraFactory.json
+var algebraFactoryJson []byte
+
 //go:embed abis/AlgebraPool.json
 var algebraIntegralPoolJson []byte
 
 //go:embed abis/AlgebraBasePluginV2.json
 var algebraBasePluginV2Json []byte
 
+//go:embed abis/AlgebraSecurityPlugin.json
+var algebraSecurityPluginJson []byte
+
+//go:embed abis/AlgebraSecurityRegistry.json
+var algebraSecurityRegistryJson []byte
+
+//go:embed abis/AlgebraLimitOrderPlugin.json
+var algebraLimitOrderPluginJson []byte
+
 //go:embed abis/TickLens.json
 var ticklenJson []byte
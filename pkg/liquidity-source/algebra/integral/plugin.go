@@ -449,156 +449,6 @@ func (p *PoolSimulator) calculateFeeFactors(currentTick, lastTick int32, priceCh
 	return feeFactors, nil
 }
 
-func getInputTokenDelta01(to, from, liquidity *uint256.Int) (*uint256.Int, error) {
-	return getToken0Delta(to, from, liquidity, true)
-}
-
-func getInputTokenDelta10(to, from, liquidity *uint256.Int) (*uint256.Int, error) {
-	return getToken1Delta(from, to, liquidity, true)
-}
-
-func getOutputTokenDelta01(to, from, liquidity *uint256.Int) (*uint256.Int, error) {
-	return getToken1Delta(to, from, liquidity, false)
-}
-
-func getOutputTokenDelta10(to, from, liquidity *uint256.Int) (*uint256.Int, error) {
-	return getToken0Delta(from, to, liquidity, false)
-}
-
-// https://github.com/cryptoalgebra/Algebra/blob/357ae6b/src/core/contracts/libraries/TokenDeltaMath.sol#L20
-func getToken0Delta(priceLower, priceUpper, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error) {
-	if priceLower.Sign() < 0 {
-		return nil, ErrInvalidPriceLower
-	}
-	priceDelta := new(uint256.Int).Sub(priceUpper, priceLower)
-	liquidityShifted := new(uint256.Int).Lsh(liquidity, RESOLUTION)
-
-	if roundUp {
-		delta, err := v3Utils.MulDivRoundingUp(priceDelta, liquidityShifted, priceUpper)
-		if err != nil {
-			return nil, err
-		}
-
-		v3Utils.DivRoundingUp(delta, priceLower, delta)
-		return delta, nil
-	}
-
-	mulDivResult, overflow := priceDelta.MulDivOverflow(priceDelta, liquidityShifted, priceUpper)
-	if overflow {
-		return nil, ErrOverflow
-	}
-	return mulDivResult.Div(mulDivResult, priceLower), nil
-}
-
-// https://github.com/cryptoalgebra/Algebra/blob/357ae6b/src/core/contracts/libraries/TokenDeltaMath.sol#L39
-func getToken1Delta(priceLower, priceUpper, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error) {
-	if priceUpper.Cmp(priceLower) < 0 {
-		return nil, ErrInvalidPriceUpperLower
-	}
-	priceDelta := new(uint256.Int).Sub(priceUpper, priceLower)
-
-	if roundUp {
-		return v3Utils.MulDivRoundingUp(priceDelta, liquidity, Q96)
-	}
-
-	token1Delta, overflow := priceDelta.MulDivOverflow(priceDelta, liquidity, Q96)
-	if overflow {
-		return nil, ErrOverflow
-	}
-	return token1Delta, nil
-}
-
-func getNewPriceAfterInput(price, liquidity, input *uint256.Int, zeroToOne bool) (*uint256.Int, error) {
-	return getNewPrice(price, liquidity, input, zeroToOne, true)
-}
-
-func getNewPriceAfterOutput(price, liquidity, output *uint256.Int, zeroToOne bool) (*uint256.Int, error) {
-	return getNewPrice(price, liquidity, output, zeroToOne, false)
-}
-
-func getNewPrice(
-	price, liquidity *uint256.Int,
-	amount *uint256.Int,
-	zeroToOne, fromInput bool,
-) (*uint256.Int, error) {
-	if price.IsZero() {
-		return nil, ErrZeroPrice
-	} else if liquidity.IsZero() {
-		return nil, ErrZeroLiquidity
-	} else if amount.IsZero() {
-		return price.Clone(), nil
-	}
-
-	liquidityShifted := new(uint256.Int).Lsh(liquidity, RESOLUTION)
-
-	if zeroToOne == fromInput {
-		product, overflow := new(uint256.Int).MulOverflow(amount, price)
-		if overflow {
-			return nil, ErrOverflow
-		}
-
-		denominator := new(uint256.Int)
-		if fromInput {
-			if denominator, overflow = denominator.AddOverflow(liquidityShifted, product); overflow {
-				return nil, ErrOverflow
-			}
-		} else {
-			if denominator, overflow = denominator.SubOverflow(liquidityShifted, product); overflow {
-				return nil, ErrUnderflow
-			}
-		}
-		resultPrice, err := v3Utils.MulDivRoundingUp(liquidityShifted, price, denominator)
-		if err != nil {
-			return nil, err
-		} else if resultPrice.BitLen() > 160 {
-			return nil, ErrOverflow
-		}
-
-		return resultPrice, nil
-	} else {
-		if fromInput {
-			shiftedAmount := new(uint256.Int)
-			if amount.BitLen() < 160 {
-				shiftedAmount.Lsh(amount, RESOLUTION)
-				shiftedAmount.Div(shiftedAmount, liquidity)
-			} else {
-				shiftedAmount.Lsh(uONE, RESOLUTION)
-				if _, overflow := shiftedAmount.MulDivOverflow(amount, shiftedAmount, liquidity); overflow {
-					return nil, ErrOverflow
-				}
-			}
-
-			resultPrice, overflow := shiftedAmount.AddOverflow(price, shiftedAmount)
-			if overflow || resultPrice.BitLen() > 160 {
-				return nil, ErrOverflow
-			}
-			return resultPrice, nil
-		} else {
-			var (
-				shiftedAmount *uint256.Int
-				err           error
-			)
-			if amount.BitLen() < 160 {
-				shiftedAmount = new(uint256.Int).Lsh(amount, RESOLUTION)
-				v3Utils.DivRoundingUp(shiftedAmount, liquidity, shiftedAmount)
-			} else {
-				shiftedAmount, err = v3Utils.MulDivRoundingUp(amount, new(uint256.Int).Lsh(uONE, RESOLUTION), liquidity)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			resultPrice, overflow := shiftedAmount.SubOverflow(price, shiftedAmount)
-			if overflow {
-				return nil, ErrUnderflow
-			} else if resultPrice.BitLen() > 160 {
-				return nil, ErrOverflow
-			}
-			return resultPrice, nil
-		}
-	}
-}
-
 // lteConsideringOverflow returns true if a <= b with c as greatest value anchor for overflow checking.
 // a <= b <= c | true
 // b <= c <  a | true
@@ -0,0 +1,149 @@
+package integral
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/int256"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+)
+
+var _ = RegisterPlugin(PluginTypeLimitOrder, func(state PluginState) (IPlugin, error) {
+	return &LimitOrderPlugin{orders: state.(*LimitOrderPluginState).Orders}, nil
+}, func() PluginState { return &LimitOrderPluginState{} })
+
+// LimitOrder is the unfilled liquidity of a limit order epoch, a position between tickLower and
+// tickLower + tickSpacing selling token0 if ZeroForOne and token1 otherwise.
+type LimitOrder struct {
+	TickLower  int32        `json:"tL"`
+	ZeroForOne bool         `json:"z,omitempty"`
+	Liquidity  *uint256.Int `json:"l"`
+}
+
+// EpochInfoRPC is the result of epochInfos of a limit order plugin.
+type EpochInfoRPC struct {
+	Filled         bool
+	Pool           common.Address
+	TickLower      *big.Int
+	ZeroForOne     bool
+	LiquidityTotal *big.Int
+}
+
+// LimitOrderPluginState is the unfilled limit orders of a pool in its limit order plugin.
+type LimitOrderPluginState struct {
+	EpochNext *big.Int     `json:"-"`
+	Orders    []LimitOrder `json:"o,omitempty"`
+}
+
+func (s *LimitOrderPluginState) AddCalls(req *ethrpc.Request, plugin, _ string) {
+	req.AddCall(&ethrpc.Call{
+		ABI:    limitOrderPluginABI,
+		Target: plugin,
+		Method: limitOrderPluginEpochNextMethod,
+	}, []any{&s.EpochNext})
+}
+
+func (s *LimitOrderPluginState) AddChainedCalls(req *ethrpc.Request, plugin, pool string) func() {
+	if s.EpochNext == nil || !s.EpochNext.IsUint64() {
+		return nil
+	}
+	// epochs start from 1, and only the latest ones are likely to be unfilled
+	epochNext := s.EpochNext.Uint64()
+	epochFrom := uint64(1)
+	if epochNext > maxLimitOrderEpochs {
+		epochFrom = epochNext - maxLimitOrderEpochs
+	}
+	epochInfos := make([]EpochInfoRPC, epochNext-min(epochFrom, epochNext))
+	for i := range epochInfos {
+		req.AddCall(&ethrpc.Call{
+			ABI:    limitOrderPluginABI,
+			Target: plugin,
+			Method: limitOrderPluginEpochInfosMethod,
+			Params: []any{new(big.Int).SetUint64(epochFrom + uint64(i))},
+		}, []any{&epochInfos[i]})
+	}
+
+	return func() {
+		for _, info := range epochInfos {
+			if info.Filled || info.LiquidityTotal == nil || info.LiquidityTotal.Sign() == 0 ||
+				!strings.EqualFold(info.Pool.Hex(), pool) {
+				continue
+			}
+			s.Orders = append(s.Orders, LimitOrder{
+				TickLower:  int32(info.TickLower.Int64()),
+				ZeroForOne: info.ZeroForOne,
+				Liquidity:  uint256.MustFromBig(info.LiquidityTotal),
+			})
+		}
+	}
+}
+
+// LimitOrderPlugin withdraws the liquidity of limit orders after swaps crossing their whole range, so that filled
+// orders do not provide liquidity to subsequent swaps.
+type LimitOrderPlugin struct {
+	orders []LimitOrder
+}
+
+func (m *LimitOrderPlugin) BeforeSwap(*PoolSimulator, bool) (uint32, uint32, error) {
+	return 0, 0, nil
+}
+
+func (m *LimitOrderPlugin) AfterSwap(p *PoolSimulator, zeroForOne bool, su *StateUpdate) (IPlugin, error) {
+	if p.globalState.PluginConfig&AFTER_SWAP_FLAG == 0 {
+		return m, nil
+	}
+
+	tickSpacing := int32(p.TickSpacing())
+	var filled []LimitOrder
+	remaining := make([]LimitOrder, 0, len(m.orders))
+	for _, order := range m.orders {
+		// orders selling token0 are filled when the price rises above their range, and vice versa
+		if order.ZeroForOne != zeroForOne && (order.ZeroForOne && su.Tick >= order.TickLower+tickSpacing ||
+			!order.ZeroForOne && su.Tick < order.TickLower) {
+			filled = append(filled, order)
+		} else {
+			remaining = append(remaining, order)
+		}
+	}
+	if len(filled) == 0 {
+		return m, nil
+	}
+
+	ticks := su.ticks
+	if ticks == nil {
+		ticks = slices.Clone(p.tickList)
+	}
+	for _, order := range filled {
+		ticks = removeLiquidity(ticks, int(order.TickLower), int(order.TickLower+tickSpacing), order.Liquidity)
+	}
+	su.ticks = ticks
+	return &LimitOrderPlugin{orders: remaining}, nil
+}
+
+// removeLiquidity removes the liquidity of a position from its lower and upper ticks, dropping ticks left without
+// liquidity. Positions whose ticks are missing or lack the liquidity are skipped.
+func removeLiquidity(ticks []v3Entities.Tick, tickLower, tickUpper int, liquidity *uint256.Int) []v3Entities.Tick {
+	cmp := func(t v3Entities.Tick, tick int) int { return t.Index - tick }
+	lower, okLower := slices.BinarySearchFunc(ticks, tickLower, cmp)
+	upper, okUpper := slices.BinarySearchFunc(ticks, tickUpper, cmp)
+	if !okLower || !okUpper || ticks[lower].LiquidityGross.Lt(liquidity) || ticks[upper].LiquidityGross.Lt(liquidity) {
+		return ticks
+	}
+
+	liquidityNet := int256.MustFromBig(liquidity.ToBig())
+	ticks[lower] = v3Entities.Tick{
+		Index:          tickLower,
+		LiquidityGross: new(uint256.Int).Sub(ticks[lower].LiquidityGross, liquidity),
+		LiquidityNet:   new(int256.Int).Sub(ticks[lower].LiquidityNet, liquidityNet),
+	}
+	ticks[upper] = v3Entities.Tick{
+		Index:          tickUpper,
+		LiquidityGross: new(uint256.Int).Sub(ticks[upper].LiquidityGross, liquidity),
+		LiquidityNet:   new(int256.Int).Add(ticks[upper].LiquidityNet, liquidityNet),
+	}
+	return slices.DeleteFunc(ticks, func(t v3Entities.Tick) bool { return t.LiquidityGross.IsZero() })
+}
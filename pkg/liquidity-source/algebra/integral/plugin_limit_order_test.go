@@ -0,0 +1,129 @@
+package integral
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/KyberNetwork/int256"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+// newLimitOrderPoolSimulator adds a limit order selling token1 just below the current tick of the thena pool, with or
+// without the limit order plugin tracking it.
+func newLimitOrderPoolSimulator(t *testing.T, withPlugin bool) *PoolSimulator {
+	var extra Extra
+	require.NoError(t, json.Unmarshal([]byte(thenaEp.Extra), &extra))
+
+	orderLiquidity := uint256.NewInt(1e18)
+	extra.Ticks = addLiquidity(extra.Ticks, -65460, -65400, orderLiquidity)
+	extra.Plugins = []PluginData{{Type: PluginTypeSlidingFee}}
+	if withPlugin {
+		state, err := json.Marshal(LimitOrderPluginState{Orders: []LimitOrder{
+			{TickLower: -65460, Liquidity: orderLiquidity},
+		}})
+		require.NoError(t, err)
+		extra.Plugins = append(extra.Plugins, PluginData{Type: PluginTypeLimitOrder, State: state})
+	}
+
+	extraBytes, err := json.Marshal(extra)
+	require.NoError(t, err)
+	ep := thenaEp
+	ep.Extra = string(extraBytes)
+	sim, err := NewPoolSimulator(ep)
+	require.NoError(t, err)
+	return sim
+}
+
+func addLiquidity(ticks []v3Entities.Tick, tickLower, tickUpper int, liquidity *uint256.Int) []v3Entities.Tick {
+	for _, tick := range [2]int{tickLower, tickUpper} {
+		liquidityNet := int256.MustFromBig(liquidity.ToBig())
+		if tick == tickUpper {
+			liquidityNet.Neg(liquidityNet)
+		}
+		i, found := slices.BinarySearchFunc(ticks, tick, func(t v3Entities.Tick, tick int) int { return t.Index - tick })
+		if found {
+			ticks[i] = v3Entities.Tick{
+				Index:          tick,
+				LiquidityGross: new(uint256.Int).Add(ticks[i].LiquidityGross, liquidity),
+				LiquidityNet:   liquidityNet.Add(liquidityNet, ticks[i].LiquidityNet),
+			}
+		} else {
+			ticks = slices.Insert(ticks, i, v3Entities.Tick{Index: tick, LiquidityGross: liquidity,
+				LiquidityNet: liquidityNet})
+		}
+	}
+	return ticks
+}
+
+func swapAndUpdate(t *testing.T, sim *PoolSimulator, tokenIn, tokenOut entity.PoolToken,
+	amountIn int64) *pool.CalcAmountOutResult {
+	tokenAmountIn := pool.TokenAmount{Token: tokenIn.Address, Amount: big.NewInt(amountIn)}
+	res, err := sim.CalcAmountOut(pool.CalcAmountOutParams{TokenAmountIn: tokenAmountIn, TokenOut: tokenOut.Address})
+	require.NoError(t, err)
+	sim.UpdateBalance(pool.UpdateBalanceParams{
+		TokenAmountIn:  tokenAmountIn,
+		TokenAmountOut: *res.TokenAmountOut,
+		SwapInfo:       res.SwapInfo,
+	})
+	return res
+}
+
+func TestLimitOrderPlugin_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, newLimitOrderPoolSimulator(t, true))
+}
+
+func TestLimitOrderPlugin(t *testing.T) {
+	token0, token1 := *thenaEp.Tokens[0], *thenaEp.Tokens[1]
+	tickCount := len(newLimitOrderPoolSimulator(t, false).tickList)
+
+	t.Run("unfilled order", func(t *testing.T) {
+		sim := newLimitOrderPoolSimulator(t, true)
+		res := swapAndUpdate(t, sim, token0, token1, 1e15)
+		su := res.SwapInfo.(clcore.SwapInfo).State.(StateUpdate)
+		assert.Nil(t, su.ticks)
+		assert.Nil(t, su.plugins)
+		assert.Len(t, sim.tickList, tickCount)
+	})
+
+	t.Run("filled order", func(t *testing.T) {
+		sim := newLimitOrderPoolSimulator(t, true)
+		res := swapAndUpdate(t, sim, token0, token1, 5e18)
+		require.Less(t, res.SwapInfo.(clcore.SwapInfo).State.(StateUpdate).Tick, int32(-65460))
+
+		assert.Len(t, sim.tickList, tickCount-1)
+		assert.False(t, slices.ContainsFunc(sim.tickList, func(t v3Entities.Tick) bool { return t.Index == -65400 }))
+		tick, err := sim.V3Pool.TickDataProvider.GetTick(-65460)
+		require.NoError(t, err)
+		assert.Equal(t, "830304806719403511", tick.LiquidityNet.Dec())
+		assert.Empty(t, sim.plugins[1].(*LimitOrderPlugin).orders)
+
+		// the filled order no longer provides liquidity when the price moves back
+		withoutPlugin := newLimitOrderPoolSimulator(t, false)
+		swapAndUpdate(t, withoutPlugin, token0, token1, 5e18)
+		assert.Len(t, withoutPlugin.tickList, tickCount)
+
+		amountOut := swapAndUpdate(t, sim, token1, token0, 1e16).TokenAmountOut.Amount
+		amountOutWithoutPlugin := swapAndUpdate(t, withoutPlugin, token1, token0, 1e16).TokenAmountOut.Amount
+		assert.Equal(t, -1, amountOut.Cmp(amountOutWithoutPlugin))
+	})
+
+	t.Run("clone state", func(t *testing.T) {
+		sim := newLimitOrderPoolSimulator(t, true)
+		cloned := sim.CloneState().(*PoolSimulator)
+		swapAndUpdate(t, cloned, token0, token1, 5e18)
+		assert.Len(t, cloned.tickList, tickCount-1)
+		assert.Len(t, sim.tickList, tickCount)
+		assert.Len(t, sim.plugins[1].(*LimitOrderPlugin).orders, 1)
+	})
+}
@@ -0,0 +1,64 @@
+package integral
+
+import (
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SecurityStatus is the status of a pool in the security registry of a security plugin.
+type SecurityStatus uint8
+
+const (
+	SecurityStatusEnabled SecurityStatus = iota
+	SecurityStatusDisabled
+	SecurityStatusBurnOnly
+)
+
+var _ = RegisterPlugin(PluginTypeSecurity, func(state PluginState) (IPlugin, error) {
+	return &SecurityPlugin{status: state.(*SecurityPluginState).Status}, nil
+}, func() PluginState { return &SecurityPluginState{} })
+
+// SecurityPluginState is the status of a pool in the security registry of its security plugin.
+type SecurityPluginState struct {
+	Registry common.Address `json:"-"`
+	Status   SecurityStatus `json:"st,omitempty"`
+}
+
+func (s *SecurityPluginState) AddCalls(req *ethrpc.Request, plugin, _ string) {
+	req.AddCall(&ethrpc.Call{
+		ABI:    securityPluginABI,
+		Target: plugin,
+		Method: securityPluginSecurityRegistryMethod,
+	}, []any{&s.Registry})
+}
+
+func (s *SecurityPluginState) AddChainedCalls(req *ethrpc.Request, _, pool string) func() {
+	// a plugin without a registry does not restrict the pool
+	if s.Registry == (common.Address{}) {
+		return nil
+	}
+	var status uint8
+	req.AddCall(&ethrpc.Call{
+		ABI:    securityRegistryABI,
+		Target: s.Registry.Hex(),
+		Method: securityRegistryGetPoolStatusMethod,
+		Params: []any{common.HexToAddress(pool)},
+	}, []any{&status})
+	return func() { s.Status = SecurityStatus(status) }
+}
+
+// SecurityPlugin blocks swaps of pools that are not enabled in its security registry.
+type SecurityPlugin struct {
+	status SecurityStatus
+}
+
+func (m *SecurityPlugin) BeforeSwap(p *PoolSimulator, _ bool) (uint32, uint32, error) {
+	if p.globalState.PluginConfig&BEFORE_SWAP_FLAG != 0 && m.status != SecurityStatusEnabled {
+		return 0, 0, ErrSwapDisabled
+	}
+	return 0, 0, nil
+}
+
+func (m *SecurityPlugin) AfterSwap(*PoolSimulator, bool, *StateUpdate) (IPlugin, error) {
+	return m, nil
+}
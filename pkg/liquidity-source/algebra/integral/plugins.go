@@ -0,0 +1,212 @@
+package integral
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+type PluginType string
+
+const (
+	PluginTypeBase       PluginType = "BASE"        // volatility oracle and dynamic fee manager (base plugin v1)
+	PluginTypeSlidingFee PluginType = "SLIDING_FEE" // volatility oracle and sliding fee (base plugin v2)
+	PluginTypeSecurity   PluginType = "SECURITY"
+	PluginTypeFarming    PluginType = "FARMING"
+	PluginTypeLimitOrder PluginType = "LIMIT_ORDER"
+)
+
+// IPlugin is a module of the plugin of a pool, called by the pool around swaps.
+type IPlugin interface {
+	// BeforeSwap returns the fee overriding the pool fee and the plugin fee of a swap, or an error if the module
+	// blocks the swap.
+	BeforeSwap(p *PoolSimulator, zeroForOne bool) (overrideFee, pluginFee uint32, err error)
+	// AfterSwap applies the effects of the module after a swap to su and returns the module with its updated state.
+	// Modules are immutable, so it returns itself if its state is unchanged.
+	AfterSwap(p *PoolSimulator, zeroForOne bool, su *StateUpdate) (IPlugin, error)
+}
+
+// PluginState is the on-chain state of a plugin module, fetched by the pool tracker and used to create the module.
+type PluginState interface {
+	// AddCalls adds the calls fetching the state of the module for the pool to req
+	AddCalls(req *ethrpc.Request, plugin, pool string)
+}
+
+// chainedPluginState is a PluginState whose calls depend on the results of its first calls.
+type chainedPluginState interface {
+	PluginState
+	// AddChainedCalls adds the calls depending on the results of AddCalls to req, and returns a function finalizing
+	// the state once req is executed
+	AddChainedCalls(req *ethrpc.Request, plugin, pool string) func()
+}
+
+// PluginFactory creates a plugin module from its on-chain state, which is nil for modules without state.
+type PluginFactory func(state PluginState) (IPlugin, error)
+
+type pluginRegistration struct {
+	newPlugin PluginFactory
+	newState  func() PluginState
+}
+
+var (
+	pluginRegistrations = map[PluginType]pluginRegistration{}
+	pluginTypes         = map[string][]PluginType{}
+)
+
+// RegisterPlugin registers the factory of a plugin module type. newState creates the empty state that the pool
+// tracker fetches for the module, and is nil for modules without on-chain state or whose state is part of Extra.
+func RegisterPlugin(pluginType PluginType, newPlugin PluginFactory, newState func() PluginState) bool {
+	pluginRegistrations[pluginType] = pluginRegistration{newPlugin: newPlugin, newState: newState}
+	return true
+}
+
+// RegisterPluginAddress registers the module types of a deployed plugin contract, in the order the plugin calls them.
+func RegisterPluginAddress(address string, types ...PluginType) bool {
+	pluginTypes[strings.ToLower(address)] = types
+	return true
+}
+
+// getPluginTypes returns the module types of a plugin contract: configured ones take precedence over registered ones,
+// and unknown plugins are assumed to be the base plugin.
+func getPluginTypes(cfg *Config, address string) []PluginType {
+	address = strings.ToLower(address)
+	for configured, types := range cfg.Plugins {
+		if strings.EqualFold(configured, address) {
+			return types
+		}
+	}
+	if types, ok := pluginTypes[address]; ok {
+		return types
+	}
+	return []PluginType{lo.Ternary(cfg.UseBasePluginV2, PluginTypeSlidingFee, PluginTypeBase)}
+}
+
+// PluginData is a plugin module of a pool with its JSON-encoded on-chain state.
+type PluginData struct {
+	Type  PluginType      `json:"t"`
+	State json.RawMessage `json:"s,omitempty"`
+}
+
+func newPluginState(pluginType PluginType) (PluginState, error) {
+	reg, ok := pluginRegistrations[pluginType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPlugin, pluginType)
+	} else if reg.newState == nil {
+		return nil, nil
+	}
+	return reg.newState(), nil
+}
+
+func newPlugins(data []PluginData) ([]IPlugin, error) {
+	plugins := make([]IPlugin, 0, len(data))
+	for _, d := range data {
+		reg, ok := pluginRegistrations[d.Type]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedPlugin, d.Type)
+		}
+
+		var state PluginState
+		if reg.newState != nil {
+			if len(d.State) == 0 {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidPluginState, d.Type)
+			}
+			state = reg.newState()
+			if err := json.Unmarshal(d.State, state); err != nil {
+				return nil, err
+			}
+		}
+
+		plugin, err := reg.newPlugin(state)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
+
+var (
+	_ = RegisterPlugin(PluginTypeBase, func(PluginState) (IPlugin, error) { return BasePlugin{}, nil }, nil)
+	_ = RegisterPlugin(PluginTypeSlidingFee, func(PluginState) (IPlugin, error) { return SlidingFeePlugin{}, nil }, nil)
+	_ = RegisterPlugin(PluginTypeFarming, func(PluginState) (IPlugin, error) { return FarmingPlugin{}, nil }, nil)
+)
+
+// BasePlugin is the volatility oracle and dynamic fee manager, whose state is kept in the pool simulator.
+type BasePlugin struct{}
+
+func (BasePlugin) BeforeSwap(p *PoolSimulator, zeroForOne bool) (uint32, uint32, error) {
+	return p.beforeSwapV1(zeroForOne)
+}
+
+func (m BasePlugin) AfterSwap(*PoolSimulator, bool, *StateUpdate) (IPlugin, error) {
+	return m, nil
+}
+
+// SlidingFeePlugin is the base plugin v2, which uses the sliding fee if its fee type is set and otherwise falls back
+// to the dynamic fee.
+type SlidingFeePlugin struct{}
+
+func (SlidingFeePlugin) BeforeSwap(p *PoolSimulator, zeroForOne bool) (uint32, uint32, error) {
+	if p.slidingFee.FeeType {
+		return p.beforeSwapV2(zeroForOne)
+	}
+	return p.beforeSwapV1(zeroForOne)
+}
+
+func (m SlidingFeePlugin) AfterSwap(*PoolSimulator, bool, *StateUpdate) (IPlugin, error) {
+	return m, nil
+}
+
+// FarmingPlugin only accrues farming incentives and does not affect swaps.
+type FarmingPlugin struct{}
+
+func (FarmingPlugin) BeforeSwap(*PoolSimulator, bool) (uint32, uint32, error) {
+	return 0, 0, nil
+}
+
+func (m FarmingPlugin) AfterSwap(*PoolSimulator, bool, *StateUpdate) (IPlugin, error) {
+	return m, nil
+}
+
+// defaultPlugins are the modules of pools without plugin data, which only run the base plugin.
+var (
+	defaultPlugins   = []IPlugin{BasePlugin{}}
+	defaultPluginsV2 = []IPlugin{SlidingFeePlugin{}}
+)
+
+// beforeSwap calls BeforeSwap of all plugin modules: the first non-zero override fee wins and plugin fees add up.
+func (p *PoolSimulator) beforeSwap(plugins []IPlugin, zeroForOne bool) (overrideFee, pluginFee uint32, err error) {
+	for _, plugin := range plugins {
+		o, f, err := plugin.BeforeSwap(p, zeroForOne)
+		if err != nil {
+			return 0, 0, err
+		}
+		if overrideFee == 0 {
+			overrideFee = o
+		}
+		pluginFee += f
+	}
+	return overrideFee, pluginFee, nil
+}
+
+// afterSwap calls AfterSwap of all plugin modules, setting su.plugins to the updated modules if any changed.
+func (p *PoolSimulator) afterSwap(plugins []IPlugin, zeroForOne bool, su *StateUpdate) error {
+	var updated []IPlugin
+	for i, plugin := range plugins {
+		next, err := plugin.AfterSwap(p, zeroForOne, su)
+		if err != nil {
+			return err
+		}
+		if next != plugin && updated == nil {
+			updated = append(make([]IPlugin, 0, len(plugins)), plugins[:i]...)
+		}
+		if updated != nil {
+			updated = append(updated, next)
+		}
+	}
+	su.plugins = updated
+	return nil
+}
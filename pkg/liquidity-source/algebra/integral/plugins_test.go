@@ -0,0 +1,133 @@
+package integral
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
+
+func newSlidingFeePoolSimulator(feeType bool) *PoolSimulator {
+	return &PoolSimulator{
+		PoolSimulator: &clcore.PoolSimulator{V3Pool: &v3Entities.Pool{TickCurrent: 100}},
+		globalState:   GlobalState{LastFee: 100, PluginConfig: BEFORE_SWAP_FLAG},
+		timepoints: NewTimepointStorage(map[uint16]Timepoint{
+			0: {Initialized: true, BlockTimestamp: blockTimestamp() - 60, VolatilityCumulative: new(uint256.Int), Tick: 100,
+				AverageTick: 100},
+		}),
+		volatilityOracle: &VolatilityOraclePlugin{IsInitialized: true},
+		dynamicFee:       &DynamicFeeConfig{BaseFee: 500},
+		slidingFee: &SlidingFeeConfig{
+			ZeroToOneFeeFactor: new(uint256.Int).Rsh(new(uint256.Int).Mul(FEE_FACTOR_MULTIPLIER, uint256.NewInt(3)), 1),
+			OneToZeroFeeFactor: new(uint256.Int).Rsh(FEE_FACTOR_MULTIPLIER, 1),
+			PriceChangeFactor:  1000,
+			BaseFee:            3000,
+			FeeType:            feeType,
+		},
+		writeTimePointOnce: new(sync.Once),
+	}
+}
+
+func TestSlidingFeePlugin(t *testing.T) {
+	plugins, err := newPlugins([]PluginData{{Type: PluginTypeSlidingFee}})
+	require.NoError(t, err)
+
+	t.Run("sliding fee by direction", func(t *testing.T) {
+		overrideFee, pluginFee, err := newSlidingFeePoolSimulator(true).beforeSwap(plugins, true)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4500, overrideFee)
+		assert.Zero(t, pluginFee)
+
+		overrideFee, _, err = newSlidingFeePoolSimulator(true).beforeSwap(plugins, false)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1500, overrideFee)
+	})
+
+	t.Run("fee factors slide with price", func(t *testing.T) {
+		p := newSlidingFeePoolSimulator(true)
+		p.V3Pool.TickCurrent = 200
+		zeroToOneFee, _, err := p.beforeSwap(plugins, true)
+		require.NoError(t, err)
+
+		p = newSlidingFeePoolSimulator(true)
+		p.V3Pool.TickCurrent = 200
+		oneToZeroFee, _, err := p.beforeSwap(plugins, false)
+		require.NoError(t, err)
+
+		// the price rose by ~1%, so swaps pushing it further up pay more and swaps pushing it back down pay less
+		assert.EqualValues(t, 4469, zeroToOneFee)
+		assert.EqualValues(t, 1530, oneToZeroFee)
+	})
+
+	t.Run("dynamic fee without sliding fee type", func(t *testing.T) {
+		p := newSlidingFeePoolSimulator(false)
+		overrideFee, _, err := p.beforeSwap(plugins, true)
+		require.NoError(t, err)
+		assert.Zero(t, overrideFee)
+		assert.EqualValues(t, 500, p.globalState.LastFee)
+	})
+}
+
+func TestPluginRegistry(t *testing.T) {
+	t.Run("plugin types", func(t *testing.T) {
+		assert.Equal(t, []PluginType{PluginTypeBase}, getPluginTypes(&Config{}, "0x1"))
+		assert.Equal(t, []PluginType{PluginTypeSlidingFee}, getPluginTypes(&Config{UseBasePluginV2: true}, "0x1"))
+
+		RegisterPluginAddress("0xAbC", PluginTypeSlidingFee, PluginTypeLimitOrder)
+		assert.Equal(t, []PluginType{PluginTypeSlidingFee, PluginTypeLimitOrder}, getPluginTypes(&Config{}, "0xabc"))
+		assert.Equal(t, []PluginType{PluginTypeSecurity}, getPluginTypes(&Config{
+			Plugins: map[string][]PluginType{"0xABC": {PluginTypeSecurity}},
+		}, "0xabc"))
+	})
+
+	t.Run("plugins", func(t *testing.T) {
+		_, err := newPlugins([]PluginData{{Type: "UNKNOWN"}})
+		assert.ErrorIs(t, err, ErrUnsupportedPlugin)
+
+		_, err = newPlugins([]PluginData{{Type: PluginTypeSecurity}})
+		assert.ErrorIs(t, err, ErrInvalidPluginState)
+
+		plugins, err := newPlugins([]PluginData{
+			{Type: PluginTypeFarming},
+			{Type: PluginTypeSecurity, State: []byte(`{"st":2}`)},
+		})
+		require.NoError(t, err)
+		require.Len(t, plugins, 2)
+		assert.Equal(t, SecurityStatusBurnOnly, plugins[1].(*SecurityPlugin).status)
+	})
+
+	t.Run("security plugin blocks swaps", func(t *testing.T) {
+		p := &PoolSimulator{globalState: GlobalState{PluginConfig: BEFORE_SWAP_FLAG}}
+		_, _, err := p.beforeSwap([]IPlugin{&SecurityPlugin{status: SecurityStatusDisabled}}, true)
+		assert.ErrorIs(t, err, ErrSwapDisabled)
+
+		_, _, err = p.beforeSwap([]IPlugin{&SecurityPlugin{status: SecurityStatusEnabled}}, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("pool simulator", func(t *testing.T) {
+		var extra Extra
+		require.NoError(t, json.Unmarshal([]byte(p.Extra), &extra))
+		extra.Plugins = []PluginData{{Type: PluginTypeBase}, {Type: PluginTypeSecurity, State: []byte(`{"st":1}`)}}
+		extraBytes, err := json.Marshal(extra)
+		require.NoError(t, err)
+
+		ep := p
+		ep.Extra = string(extraBytes)
+		sim, err := NewPoolSimulator(ep)
+		require.NoError(t, err)
+		_, err = sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: ep.Tokens[0].Address, Amount: big.NewInt(1e12)},
+			TokenOut:      ep.Tokens[1].Address,
+		})
+		assert.ErrorIs(t, err, ErrSwapDisabled)
+	})
+}
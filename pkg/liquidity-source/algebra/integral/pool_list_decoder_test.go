@@ -0,0 +1,89 @@
+package integral
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abipkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/abi"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolsListDecoder(t *testing.T) {
+	var (
+		factory  = common.HexToAddress("0x10253594A832f967994b44f33411940533302ACb")
+		deployer = common.HexToAddress("0x00000000000000000000000000000000000000dd")
+		usdc     = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		weth     = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+		broken   = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		pool     = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		custom   = common.HexToAddress("0x00000000000000000000000000000000000000ab")
+		skipped  = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	)
+	newLog := func(event string, pool common.Address, topics ...common.Address) types.Log {
+		data, err := algebraFactoryABI.Events[event].Inputs.NonIndexed().Pack(pool)
+		require.NoError(t, err)
+		log := types.Log{
+			Address:     factory,
+			Topics:      []common.Hash{algebraFactoryABI.Events[event].ID},
+			Data:        data,
+			BlockNumber: 20000000,
+		}
+		for _, topic := range topics {
+			log.Topics = append(log.Topics, common.BytesToHash(topic[:]))
+		}
+		return log
+	}
+	logs := []types.Log{
+		newLog(factoryPoolEvent, pool, usdc, weth),
+		newLog(factoryCustomPoolEvent, custom, deployer, usdc, weth),
+		newLog(factoryPoolEvent, skipped, broken, weth),
+		{Address: pool, Topics: []common.Hash{algebraFactoryABI.Events[factoryPoolEvent].ID}},
+	}
+
+	ethrpcClient := testutil.NewEthrpcClient(t, func(target common.Address, _ []byte) ([]byte, bool) {
+		decimals := map[common.Address]uint8{usdc: 6, weth: 18}[target]
+		if decimals == 0 {
+			return nil, false
+		}
+		data, err := abipkg.Erc20ABI.Methods[abipkg.Erc20DecimalsMethod].Outputs.Pack(decimals)
+		require.NoError(t, err)
+		return data, true
+	})
+	d := NewPoolsListDecoder(&Config{DexID: "algebra-integral", FactoryAddress: factory.Hex()}, ethrpcClient)
+
+	addressLogs, err := d.Decode(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]types.Log{
+		"0x00000000000000000000000000000000000000aa": logs[:1],
+		"0x00000000000000000000000000000000000000ab": logs[1:2],
+		"0x00000000000000000000000000000000000000cc": logs[2:3],
+	}, addressLogs)
+
+	events, _, err := d.decodeEvents(logs)
+	require.NoError(t, err)
+	assert.Equal(t, []PoolEvent{
+		{Token0: usdc, Token1: weth, Pool: pool},
+		{Deployer: deployer, Token0: usdc, Token1: weth, Pool: custom},
+		{Token0: broken, Token1: weth, Pool: skipped},
+	}, events)
+
+	// the pool of the token without decimals is skipped rather than given guessed decimals
+	pools, err := d.DecodeNewPools(context.Background(), logs)
+	require.NoError(t, err)
+	require.Len(t, pools, 2)
+	for i, address := range []string{
+		"0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000ab",
+	} {
+		assert.Equal(t, address, pools[i].Address)
+		assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", pools[i].Tokens[0].Address)
+		assert.EqualValues(t, 6, pools[i].Tokens[0].Decimals)
+		assert.Equal(t, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", pools[i].Tokens[1].Address)
+		assert.EqualValues(t, 18, pools[i].Tokens[1].Decimals)
+		assert.EqualValues(t, 20000000, pools[i].BlockNumber)
+	}
+}
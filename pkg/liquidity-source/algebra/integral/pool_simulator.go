@@ -1,15 +1,13 @@
 package integral
 
 import (
-	"fmt"
 	"math"
-	"math/big"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/KyberNetwork/int256"
 	"github.com/KyberNetwork/logger"
+	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
 	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
 	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
 	"github.com/goccy/go-json"
@@ -17,20 +15,15 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
-	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
 type PoolSimulator struct {
-	pool.Pool
+	*clcore.PoolSimulator
 
 	globalState GlobalState
-	liquidity   *uint256.Int
-
-	ticks       *v3Entities.TickListDataProvider
-	tickMin     int32
-	tickMax     int32
-	tickSpacing int
+	tickList    []v3Entities.Tick
 
 	timepoints         *TimepointStorage
 	volatilityOracle   *VolatilityOraclePlugin
@@ -39,6 +32,7 @@ type PoolSimulator struct {
 	writeTimePointOnce *sync.Once
 
 	useBasePluginV2 bool
+	plugins         []IPlugin
 }
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
@@ -54,213 +48,176 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		return nil, err
 	}
 
-	tokens := make([]string, 2)
-	reserves := make([]*big.Int, 2)
-	if len(entityPool.Reserves) == 2 && len(entityPool.Tokens) == 2 {
-		tokens[0] = entityPool.Tokens[0].Address
-		reserves[0] = bignumber.NewBig10(entityPool.Reserves[0])
-		tokens[1] = entityPool.Tokens[1].Address
-		reserves[1] = bignumber.NewBig10(entityPool.Reserves[1])
-	} else {
+	if len(entityPool.Reserves) != 2 || len(entityPool.Tokens) != 2 {
 		return nil, ErrInvalidToken
+	} else if len(extra.Ticks) == 0 { // if the tick list is empty, the pool should be ignored
+		return nil, ErrTicksEmpty
+	} else if extra.GlobalState.Price == nil || extra.GlobalState.Price.IsZero() {
+		return nil, ErrNotInitialized
 	}
 
-	// if the tick list is empty, the pool should be ignored
-	if len(extra.Ticks) == 0 {
-		return nil, ErrTicksEmpty
+	var plugins []IPlugin
+	if len(extra.Plugins) > 0 {
+		var err error
+		if plugins, err = newPlugins(extra.Plugins); err != nil {
+			return nil, err
+		}
 	}
 
-	ticks, err := v3Entities.NewTickListDataProvider(extra.Ticks, int(extra.TickSpacing))
+	ticks := make([]clcore.TickU256, 0, len(extra.Ticks))
+	for _, tick := range extra.Ticks {
+		ticks = append(ticks, clcore.TickU256{
+			Index:          tick.Index,
+			LiquidityGross: tick.LiquidityGross,
+			LiquidityNet:   tick.LiquidityNet,
+		})
+	}
+
+	tick := int(extra.GlobalState.Tick)
+	clPool, err := clcore.NewPoolSimulator(entityPool, 0, &clcore.ExtraTickU256{
+		Liquidity:    extra.Liquidity,
+		SqrtPriceX96: extra.GlobalState.Price,
+		TickSpacing:  uint64(extra.TickSpacing),
+		Tick:         &tick,
+		Ticks:        ticks,
+	}, constants.FeeAmount(extra.GlobalState.LastFee), clcore.Config{
+		Gas:                 clcore.Gas{BaseGas: BaseGas, CrossInitTickGas: CrossInitTickGas},
+		ChargeInitTickSteps: true,
+		StaleTick:           true,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	timepoints := NewTimepointStorage(extra.Timepoints)
-
-	tickMin := extra.Ticks[0].Index
-	tickMax := extra.Ticks[len(extra.Ticks)-1].Index
-
-	var info = pool.PoolInfo{
-		Address:     strings.ToLower(entityPool.Address),
-		Exchange:    entityPool.Exchange,
-		Type:        entityPool.Type,
-		Tokens:      tokens,
-		Reserves:    reserves,
-		BlockNumber: entityPool.BlockNumber,
-	}
+	clPool.Info.BlockNumber = entityPool.BlockNumber
 
 	return &PoolSimulator{
-		Pool:               pool.Pool{Info: info},
+		PoolSimulator:      clPool,
 		globalState:        extra.GlobalState,
-		liquidity:          extra.Liquidity,
-		ticks:              ticks,
-		tickMin:            int32(tickMin),
-		tickMax:            int32(tickMax),
-		tickSpacing:        int(extra.TickSpacing),
-		timepoints:         timepoints,
+		tickList:           extra.Ticks,
+		timepoints:         NewTimepointStorage(extra.Timepoints),
 		volatilityOracle:   &extra.VolatilityOracle,
 		dynamicFee:         &extra.DynamicFee,
 		slidingFee:         &extra.SlidingFee,
 		writeTimePointOnce: new(sync.Once),
 		useBasePluginV2:    staticExtra.UseBasePluginV2,
+		plugins:            plugins,
 	}, nil
 }
 
 func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
-	tokenAmtIn, tokenOut := param.TokenAmountIn, param.TokenOut
-	tokenIn := tokenAmtIn.Token
-	amtRequired, overflow := uint256.FromBig(tokenAmtIn.Amount)
-	if overflow {
-		return nil, ErrInvalidAmountRequired
-	}
-
-	amtSpent, amtCalculated, fees, gas, stateUpdate, err := p.swap(tokenIn, tokenOut, amtRequired)
-	if err != nil {
-		return nil, err
-	} else if amtCalculated.IsZero() {
-		return nil, ErrZeroAmountCalculated
-	}
-
-	return &pool.CalcAmountOutResult{
-		TokenAmountOut: &pool.TokenAmount{
-			Token:  tokenOut,
-			Amount: amtCalculated.Neg(amtCalculated).ToBig(),
-		},
-		RemainingTokenAmountIn: &pool.TokenAmount{
-			Token:  tokenIn,
-			Amount: amtRequired.Sub(amtRequired, amtSpent).ToBig(),
-		},
-		Fee: &pool.TokenAmount{
-			Token:  tokenIn,
-			Amount: new(uint256.Int).Add(fees.communityFeeAmount, fees.pluginFeeAmount).ToBig(),
-		},
-		Gas:      gas,
-		SwapInfo: stateUpdate,
-	}, nil
+	return p.CalcAmountOutWith(param, p.swap)
 }
 
 func (p *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
-	tokenIn, tokenAmtOut := param.TokenIn, param.TokenAmountOut
-	tokenOut := tokenAmtOut.Token
-	amtRequired, overflow := uint256.FromBig(tokenAmtOut.Amount)
-	if overflow {
-		return nil, ErrInvalidAmountRequired
-	}
-
-	amtSpent, amtCalculated, fees, gas, stateUpdate, err := p.swap(tokenIn, tokenOut, amtRequired.Neg(amtRequired))
-	if err != nil {
-		return nil, err
-	} else if amtCalculated.IsZero() {
-		return nil, ErrZeroAmountCalculated
-	}
-
-	return &pool.CalcAmountInResult{
-		TokenAmountIn: &pool.TokenAmount{
-			Token:  tokenIn,
-			Amount: amtCalculated.ToBig(),
-		},
-		RemainingTokenAmountOut: &pool.TokenAmount{
-			Token:  tokenOut,
-			Amount: amtRequired.Neg(amtRequired.Sub(amtRequired, amtSpent)).ToBig(),
-		},
-		Fee: &pool.TokenAmount{
-			Token:  tokenIn,
-			Amount: new(uint256.Int).Add(fees.communityFeeAmount, fees.pluginFeeAmount).ToBig(),
-		},
-		Gas:      gas,
-		SwapInfo: stateUpdate,
-	}, nil
+	return p.CalcAmountInWith(param, p.swap)
 }
 
-func (p *PoolSimulator) swap(tokenIn, tokenOut string, amtRequired *uint256.Int) (amtSpent, amtCalculated *uint256.Int,
-	fees FeesAmount, gas int64, stateUpdate StateUpdate, err error) {
+// swap runs the plugins around the clcore swap loop, splitting the plugin and community fees off every step like
+// the pool contract does.
+func (p *PoolSimulator) swap(zeroForOne bool, amountSpecified *v3Utils.Int256,
+	priceLimit *v3Utils.Uint160) (*clcore.SwapResult, error) {
 	if !p.globalState.Unlocked {
-		err = ErrPoolLocked
-		return
+		return nil, ErrPoolLocked
+	} else if amountSpecified.IsZero() {
+		return nil, ErrZeroAmountRequired
 	}
 
-	tokenInIndex, tokenOutIndex := p.GetTokenIndex(tokenIn), p.GetTokenIndex(tokenOut)
-	if tokenInIndex < 0 || tokenOutIndex < 0 {
-		err = ErrInvalidToken
-		return
+	plugins := p.plugins
+	if plugins == nil {
+		plugins = lo.Ternary(p.useBasePluginV2, defaultPluginsV2, defaultPlugins)
 	}
-
-	zeroForOne := tokenInIndex == 0
-	overrideFee, pluginFee, err := lo.Ternary(p.useBasePluginV2 && p.slidingFee.FeeType,
-		p.beforeSwapV2, p.beforeSwapV1)(zeroForOne)
+	overrideFee, pluginFee, err := p.beforeSwap(plugins, zeroForOne)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	priceLimit, err := p.getSqrtPriceLimit(zeroForOne)
+	fee := uint64(lo.Ternary(overrideFee != 0, overrideFee, uint32(p.globalState.LastFee)) + pluginFee)
+	if fee >= 1e6 {
+		return nil, ErrIncorrectPluginFee
+	}
+	feeU, pluginFeeU := uint256.NewInt(fee), uint256.NewInt(uint64(pluginFee))
+	communityFee := uint256.NewInt(uint64(p.globalState.CommunityFee))
+	var pluginFeeAmount, communityFeeAmount uint256.Int
+	result, err := p.Swap(zeroForOne, amountSpecified, priceLimit, constants.FeeAmount(fee),
+		func(feeAmount *v3Utils.Uint256) error {
+			stepFee := feeAmount.Clone()
+			if pluginFee > 0 && fee > 0 {
+				delta, err := v3Utils.MulDiv(stepFee, pluginFeeU, feeU)
+				if err != nil {
+					return err
+				}
+				stepFee.Sub(stepFee, delta)
+				pluginFeeAmount.Add(&pluginFeeAmount, delta)
+			}
+			if communityFee.Sign() > 0 {
+				delta, err := v3Utils.MulDiv(stepFee, communityFee, COMMUNITY_FEE_DENOMINATOR)
+				if err != nil {
+					return err
+				}
+				communityFeeAmount.Add(&communityFeeAmount, delta)
+			}
+			return nil
+		})
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	amtSpent, amtCalculated, currentPrice, currentTick, currentLiquidity, fees, ticksCrossed, err := p.calculateSwap(
-		overrideFee, pluginFee, zeroForOne, amtRequired, priceLimit)
-	if err != nil {
-		return
+	stateUpdate := StateUpdate{Tick: int32(result.CurrentTick)}
+	if err = p.afterSwap(plugins, zeroForOne, &stateUpdate); err != nil {
+		return nil, err
 	}
 
-	return amtSpent, amtCalculated, fees, BaseGas + ticksCrossed*CrossInitTickGas, StateUpdate{
-		Liquidity: currentLiquidity,
-		Price:     currentPrice,
-		Tick:      currentTick,
+	return &clcore.SwapResult{
+		GetAmountResultV2: result,
+		Fee:               pluginFeeAmount.Add(&pluginFeeAmount, &communityFeeAmount),
+		State:             stateUpdate,
 	}, nil
 }
 
 func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
 	cloned := *p
-	cloned.liquidity = p.liquidity.Clone()
-	cloned.globalState.Price = p.globalState.Price.Clone()
+	cloned.PoolSimulator = p.PoolSimulator.Clone()
 	cloned.writeTimePointOnce = new(sync.Once)
 	return &cloned
 }
 
 func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
-	si, ok := params.SwapInfo.(StateUpdate)
+	p.PoolSimulator.UpdateBalance(params)
+	si, ok := params.SwapInfo.(clcore.SwapInfo)
 	if !ok {
-		logger.Warnf("failed to UpdateBalance for Algebra %v %v pool, wrong swapInfo type",
-			p.Info.Address, p.Info.Exchange)
 		return
 	}
-	p.liquidity = si.Liquidity
-	p.globalState.Price = si.Price
-	p.globalState.Tick = si.Tick
-}
-
-func (p *PoolSimulator) GetMetaInfo(tokenIn string, _ string) interface{} {
-	zeroForOne := strings.EqualFold(tokenIn, p.Info.Tokens[0])
-	priceLimit, _ := p.getSqrtPriceLimit(zeroForOne)
-	return PoolMeta{
-		BlockNumber: p.Pool.Info.BlockNumber,
-		PriceLimit:  priceLimit,
+	su, _ := si.State.(StateUpdate)
+	if su.plugins != nil {
+		p.plugins = su.plugins
 	}
-}
-
-/**
- * getSqrtPriceLimit get the price limit of pool based on the initialized ticks that this pool has
- */
-func (p *PoolSimulator) getSqrtPriceLimit(zeroForOne bool) (*uint256.Int, error) {
-	var tickLimit int32
-	if zeroForOne {
-		tickLimit = p.tickMin
-	} else {
-		tickLimit = p.tickMax
+	if su.ticks != nil {
+		p.setTicks(su.ticks)
 	}
+}
 
-	var sqrtPriceX96Limit v3Utils.Uint160
-	err := v3Utils.GetSqrtRatioAtTickV2(int(tickLimit), &sqrtPriceX96Limit)
-	if err != nil {
-		return nil, err
+// setTicks replaces the tick list, e.g. after plugins withdrew liquidity. Tick lists left empty or invalid are
+// ignored.
+func (p *PoolSimulator) setTicks(tickList []v3Entities.Tick) {
+	if err := p.SetTicks(tickList); err != nil {
+		logger.Warnf("failed to set ticks for Algebra %v %v pool: %v", p.Info.Address, p.Info.Exchange, err)
+		return
 	}
+	p.tickList = tickList
+}
 
-	if tickLimit == v3Utils.MinTick || tickLimit == v3Utils.MaxTick {
-		lo.Ternary(zeroForOne, sqrtPriceX96Limit.AddUint64, sqrtPriceX96Limit.SubUint64)(&sqrtPriceX96Limit, 1)
+func (p *PoolSimulator) GetMetaInfo(tokenIn string, _ string) any {
+	var priceLimit v3Utils.Uint160
+	_ = p.GetSqrtPriceLimit(strings.EqualFold(tokenIn, p.Info.Tokens[0]), &priceLimit)
+	return PoolMeta{
+		BlockNumber: p.Info.BlockNumber,
+		PriceLimit:  &priceLimit,
 	}
+}
 
-	return &sqrtPriceX96Limit, nil
+// currentTick returns the tick of the pool price
+func (p *PoolSimulator) currentTick() int32 {
+	return int32(p.V3Pool.TickCurrent)
 }
 
 var blockTimestamp = func() uint32 { return uint32(time.Now().Unix()) }
@@ -277,7 +234,7 @@ func (p *PoolSimulator) writeTimepoint(onWrite func() error) (err error) {
 	p.writeTimePointOnce.Do(func() {
 		volatilityOracle.LastTimepointTimestamp = blockTimestamp()
 		volatilityOracle.TimepointIndex, _, err = p.timepoints.write(
-			volatilityOracle.TimepointIndex, volatilityOracle.LastTimepointTimestamp, p.globalState.Tick)
+			volatilityOracle.TimepointIndex, volatilityOracle.LastTimepointTimestamp, p.currentTick())
 		if err != nil || onWrite == nil {
 			return
 		}
@@ -310,7 +267,7 @@ func (p *PoolSimulator) beforeSwapV1(zeroForOne bool) (uint32, uint32, error) {
 }
 
 func (p *PoolSimulator) beforeSwapV2(zeroToOne bool) (uint32, uint32, error) {
-	currentTick := p.globalState.Tick
+	currentTick := p.currentTick()
 	lastTick := p.getLastTick()
 
 	newFee, err := p.getFeeAndUpdateFactors(zeroToOne, currentTick, lastTick)
@@ -364,7 +321,7 @@ func (p *PoolSimulator) getLastTick() int32 {
 
 func (p *PoolSimulator) getAverageVolatilityLast() (*uint256.Int, error) {
 	currentTimestamp := p.volatilityOracle.LastTimepointTimestamp
-	tick := p.globalState.Tick
+	tick := p.currentTick()
 	lastTimepointIndex := p.volatilityOracle.TimepointIndex
 	oldestIndex := p.timepoints.getOldestIndex(lastTimepointIndex)
 
@@ -375,249 +332,3 @@ func (p *PoolSimulator) getAverageVolatilityLast() (*uint256.Int, error) {
 
 	return volatilityAverage, nil
 }
-
-func (p *PoolSimulator) calculateSwap(overrideFee, pluginFee uint32, zeroToOne bool, amountRequired *uint256.Int,
-	limitSqrtPrice *uint256.Int) (*uint256.Int, *uint256.Int, *uint256.Int, int32, *uint256.Int, FeesAmount, int64, error) {
-	if amountRequired.IsZero() {
-		return nil, nil, nil, 0, nil, FeesAmount{}, 0, ErrZeroAmountRequired
-	}
-
-	var cache SwapCalculationCache
-	var fees = FeesAmount{
-		communityFeeAmount: new(uint256.Int),
-		pluginFeeAmount:    new(uint256.Int),
-	}
-
-	cache.amountRequiredInitial = amountRequired
-	cache.exactInput = amountRequired.Sign() > 0
-	cache.amountCalculated = new(uint256.Int)
-
-	currentLiquidity := p.liquidity
-	currentPrice := p.globalState.Price
-	if currentPrice.IsZero() {
-		return nil, nil, nil, 0, nil, FeesAmount{}, 0, ErrNotInitialized
-	}
-	currentTick := p.globalState.Tick
-
-	if pluginFee > 0 {
-		cache.pluginFee = uint256.NewInt(uint64(pluginFee))
-	}
-	cache.fee = uint64(lo.Ternary(overrideFee != 0, overrideFee, uint32(p.globalState.LastFee)) + pluginFee)
-	if cache.fee >= 1e6 {
-		return nil, nil, nil, 0, nil, FeesAmount{}, 0, ErrIncorrectPluginFee
-	}
-	feeU := uint256.NewInt(cache.fee)
-	cache.communityFee = uint256.NewInt(uint64(p.globalState.CommunityFee))
-
-	if zeroToOne && limitSqrtPrice.Cmp(currentPrice) >= 0 || limitSqrtPrice.Cmp(MIN_SQRT_RATIO) <= 0 ||
-		!zeroToOne && limitSqrtPrice.Cmp(currentPrice) <= 0 || limitSqrtPrice.Cmp(MAX_SQRT_RATIO) >= 0 {
-		return nil, nil, nil, 0, nil, FeesAmount{}, 0, ErrInvalidLimitSqrtPrice
-	}
-
-	step := PriceMovementCache{nextTickPrice: new(uint256.Int)}
-	initializedTick := currentTick
-	var ticksCrossed int64
-	// swap until there is remaining input or output tokens, or we reach the price limit.
-	// limit by maxSwapLoop to make sure we won't loop infinitely because of a bug somewhere
-	for i := 0; i < maxSwapLoop; i++ {
-		var (
-			nextTick int
-			err      error
-		)
-
-		nextTick, step.initialized, err = p.ticks.NextInitializedTickWithinOneWord(int(initializedTick), zeroToOne,
-			p.tickSpacing)
-		if err != nil {
-			return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-		}
-		step.nextTick = int32(nextTick)
-
-		if !step.initialized {
-			if zeroToOne {
-				initializedTick = step.nextTick - 1
-			} else {
-				initializedTick = step.nextTick
-			}
-			continue
-		}
-		ticksCrossed++
-
-		step.stepSqrtPrice = currentPrice
-		if err = v3Utils.GetSqrtRatioAtTickV2(int(step.nextTick), step.nextTickPrice); err != nil {
-			return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-		}
-
-		var targetPrice = step.nextTickPrice
-		if zeroToOne == (step.nextTickPrice.Cmp(limitSqrtPrice) < 0) {
-			targetPrice = limitSqrtPrice
-		}
-
-		currentPrice, step.input, step.output, step.feeAmount, err = movePriceTowardsTarget(zeroToOne, currentPrice,
-			targetPrice, currentLiquidity, amountRequired, cache.fee)
-		if err != nil {
-			return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-		}
-
-		if cache.exactInput {
-			amountRequired.Sub(amountRequired, step.input).Sub(amountRequired, step.feeAmount)
-			cache.amountCalculated.Sub(cache.amountCalculated, step.output)
-		} else {
-			amountRequired.Add(amountRequired, step.output)
-			cache.amountCalculated.Add(cache.amountCalculated, step.input).Add(cache.amountCalculated, step.feeAmount)
-		}
-
-		if cache.pluginFee != nil && cache.fee > 0 {
-			delta, err := v3Utils.MulDiv(step.feeAmount, cache.pluginFee, feeU)
-			if err != nil {
-				return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-			}
-
-			step.feeAmount.Sub(step.feeAmount, delta)
-			fees.pluginFeeAmount.Add(fees.pluginFeeAmount, delta)
-		}
-
-		if cache.communityFee.Sign() > 0 {
-			delta, err := v3Utils.MulDiv(step.feeAmount, cache.communityFee, COMMUNITY_FEE_DENOMINATOR)
-			if err != nil {
-				return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-			}
-
-			step.feeAmount.Sub(step.feeAmount, delta)
-			fees.communityFeeAmount.Add(fees.communityFeeAmount, delta)
-		}
-
-		if currentPrice.Cmp(step.nextTickPrice) == 0 {
-			tickData, err := p.ticks.GetTick(int(step.nextTick))
-			if err != nil {
-				return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-			}
-
-			var liquidityDelta *int256.Int
-			if zeroToOne {
-				currentTick = step.nextTick - 1
-				initializedTick = step.nextTick - 1
-				liquidityDelta = new(int256.Int).Neg(tickData.LiquidityNet)
-			} else {
-				currentTick = step.nextTick
-				initializedTick = step.nextTick
-				liquidityDelta = tickData.LiquidityNet
-			}
-
-			currentLiquidity, err = addDelta(currentLiquidity, liquidityDelta)
-			if err != nil {
-				return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-			}
-
-		} else if currentPrice.Cmp(step.stepSqrtPrice) != 0 {
-			currentTickInt, err := v3Utils.GetTickAtSqrtRatioV2(currentPrice)
-			if err != nil {
-				return nil, nil, nil, 0, nil, FeesAmount{}, 0, err
-			}
-			currentTick = int32(currentTickInt)
-
-			break
-		}
-
-		if amountRequired.IsZero() || currentPrice.Cmp(limitSqrtPrice) == 0 {
-			break
-		}
-	}
-
-	amountSpent := new(uint256.Int).Sub(cache.amountRequiredInitial, amountRequired)
-	return amountSpent, cache.amountCalculated, currentPrice, currentTick, currentLiquidity, fees, ticksCrossed, nil
-}
-
-func movePriceTowardsTarget(zeroToOne bool, currentPrice, targetPrice, liquidity, amountAvailable *uint256.Int,
-	fee uint64) (resultPrice, input, output, feeAmount *uint256.Int, err error) {
-	getInputTokenAmount, getOutputTokenAmount := getInputTokenDelta10, getOutputTokenDelta10
-	if zeroToOne {
-		getInputTokenAmount, getOutputTokenAmount = getInputTokenDelta01, getOutputTokenDelta01
-	}
-
-	feeDenoMinusFee := new(uint256.Int).SubUint64(FEE_DENOMINATOR, fee)
-	if amountAvailable.Sign() >= 0 {
-		amountAvailableAfterFee, overflow := new(uint256.Int).MulDivOverflow(amountAvailable, feeDenoMinusFee,
-			FEE_DENOMINATOR)
-		if overflow {
-			return nil, nil, nil, nil, ErrOverflow
-		}
-
-		input, err = getInputTokenAmount(targetPrice, currentPrice, liquidity)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-
-		if amountAvailableAfterFee.Cmp(input) >= 0 {
-			resultPrice = targetPrice.Clone()
-			feeAmount, err = v3Utils.MulDivRoundingUp(input, uint256.NewInt(fee), feeDenoMinusFee)
-			if err != nil {
-				return nil, nil, nil, nil, err
-			}
-		} else {
-			resultPrice, err = getNewPriceAfterInput(currentPrice, liquidity, amountAvailableAfterFee, zeroToOne)
-			if err != nil {
-				return nil, nil, nil, nil, err
-			}
-
-			if targetPrice.Cmp(resultPrice) == 0 {
-				return nil, nil, nil, nil, fmt.Errorf("target price should not equal result price")
-			}
-
-			input, err = getInputTokenAmount(resultPrice, currentPrice, liquidity)
-			if err != nil {
-				return nil, nil, nil, nil, err
-			}
-
-			feeAmount = new(uint256.Int).Sub(amountAvailable, input)
-		}
-
-		output, err = getOutputTokenAmount(resultPrice, currentPrice, liquidity)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-
-	} else {
-		output, err = getOutputTokenAmount(targetPrice, currentPrice, liquidity)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-
-		amountAvailable = new(uint256.Int).Neg(amountAvailable)
-		if amountAvailable.Sign() < 0 {
-			return nil, nil, nil, nil, ErrInvalidAmountRequired
-		}
-
-		if amountAvailable.Cmp(output) >= 0 {
-			resultPrice = targetPrice.Clone()
-		} else {
-			resultPrice, err = getNewPriceAfterOutput(currentPrice, liquidity, amountAvailable, zeroToOne)
-			if err != nil {
-				return nil, nil, nil, nil, err
-			}
-
-			if targetPrice.Cmp(resultPrice) != 0 {
-				output, err = getOutputTokenAmount(resultPrice, currentPrice, liquidity)
-				if err != nil {
-					return nil, nil, nil, nil, err
-				}
-			}
-
-			if output.Cmp(amountAvailable) > 0 {
-				output.Set(amountAvailable)
-			}
-		}
-
-		input, err = getInputTokenAmount(resultPrice, currentPrice, liquidity)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-
-		feeAmount, err = v3Utils.MulDivRoundingUp(input, uint256.NewInt(fee),
-			feeDenoMinusFee)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-	}
-
-	return resultPrice, input, output, feeAmount, nil
-}
@@ -2,7 +2,6 @@ package integral
 
 import (
 	"math/big"
-	"sync"
 	"testing"
 
 	"github.com/KyberNetwork/int256"
@@ -14,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
@@ -27,11 +27,9 @@ var (
 
 	mockLiquidity = uint256.NewInt(98862330578)
 
-	mockTickSpacing       = 60
-	mockTickmin     int32 = -887220
-	mockTickmax     int32 = 887220
+	mockTickSpacing int32 = 60
 
-	mockTicks, _ = v3Entities.NewTickListDataProvider([]v3Entities.Tick{
+	mockTicks = []v3Entities.Tick{
 		{Index: -887220, LiquidityGross: uint256.NewInt(35733795), LiquidityNet: int256.NewInt(35733795)},
 		{Index: -4500, LiquidityGross: uint256.NewInt(1469002688), LiquidityNet: int256.NewInt(1469002688)},
 		{Index: -1740, LiquidityGross: uint256.NewInt(815264000), LiquidityNet: int256.NewInt(815264000)},
@@ -47,9 +45,9 @@ var (
 		{Index: 1800, LiquidityGross: uint256.NewInt(1976256450), LiquidityNet: int256.NewInt(-1976256450)},
 		{Index: 1860, LiquidityGross: uint256.NewInt(1469002688), LiquidityNet: int256.NewInt(-1469002688)},
 		{Index: 887220, LiquidityGross: uint256.NewInt(35733795), LiquidityNet: int256.NewInt(-35733795)},
-	}, mockTickSpacing)
+	}
 
-	mockTimepoints = NewTimepointStorage(map[uint16]Timepoint{
+	mockTimepoints = map[uint16]Timepoint{
 		0: {
 			Initialized:          true,
 			BlockTimestamp:       1722423991,
@@ -104,7 +102,7 @@ var (
 			AverageTick:          0,
 			WindowStartIndex:     0,
 		},
-	})
+	}
 
 	mockTimepointIndex         uint16 = 19874
 	mockLastTimepointTimestamp uint32 = 1733131721
@@ -120,6 +118,57 @@ var (
 	mockBaseFee     uint16 = 100
 )
 
+// newMockPoolSimulator builds the scribe USDC/USDT pool from the mock state.
+func newMockPoolSimulator(t *testing.T) *PoolSimulator {
+	extra, err := json.Marshal(Extra{
+		Liquidity: mockLiquidity,
+		GlobalState: GlobalState{
+			Price:        uint256.MustFromBig(mockPrice),
+			Tick:         mockTick,
+			LastFee:      mockLastFee,
+			PluginConfig: mockPluginConfig,
+			CommunityFee: mockCommunityFee,
+			Unlocked:     true,
+		},
+		Ticks:          mockTicks,
+		TickSpacing:    mockTickSpacing,
+		ExtraTimepoint: ExtraTimepoint{Timepoints: mockTimepoints},
+		VolatilityOracle: VolatilityOraclePlugin{
+			TimepointIndex:         mockTimepointIndex,
+			LastTimepointTimestamp: mockLastTimepointTimestamp,
+			IsInitialized:          true,
+		},
+		DynamicFee: DynamicFeeConfig{
+			Alpha1:      mockAlpha1,
+			Alpha2:      mockAlpha2,
+			Beta1:       mockBeta1,
+			Beta2:       mockBeta2,
+			Gamma1:      mockGamma1,
+			Gamma2:      mockGamma2,
+			VolumeBeta:  mockVolumeBeta,
+			VolumeGamma: mockVolumeGamma,
+			BaseFee:     mockBaseFee,
+		},
+	})
+	require.NoError(t, err)
+
+	sim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x3b4d8548aa8dccd0ae7643a84049687bf16d1851",
+		Exchange: "scribe",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"817408052", "1425700551"},
+		Tokens: []*entity.PoolToken{
+			{Address: "0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", Decimals: 6}, // USDC
+			{Address: "0xf55bec9cafdbe8730f096aa55dad6d22d44099df", Decimals: 6}, // USDT
+		},
+		Extra:       string(extra),
+		StaticExtra: `{"pluginV2":false}`,
+		BlockNumber: 11587102,
+	})
+	require.NoError(t, err)
+	return sim
+}
+
 func TestCalcAmountOut(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -127,60 +176,13 @@ func TestCalcAmountOut(t *testing.T) {
 		simulator      *PoolSimulator
 		input          pool.CalcAmountOutParams
 		expectedResult *pool.CalcAmountOutResult
+		expectedPrice  string
+		expectedLiq    uint64
 		expectedErr    error
 	}{
 		{
-			name: "valid swap - token 0 to token 1",
-			simulator: &PoolSimulator{
-				Pool: pool.Pool{
-					Info: pool.PoolInfo{
-						Address:  "0x3b4d8548aa8dccd0ae7643a84049687bf16d1851",
-						Exchange: "scribe",
-						Type:     DexType,
-						Reserves: []*big.Int{
-							big.NewInt(817408052),
-							big.NewInt(1425700551),
-						},
-						Tokens: []string{
-							"0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
-							"0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
-						},
-						BlockNumber: 11587102,
-					},
-				},
-				globalState: GlobalState{
-					Price:        uint256.MustFromBig(mockPrice),
-					Tick:         mockTick,
-					LastFee:      mockLastFee,
-					PluginConfig: mockPluginConfig,
-					CommunityFee: mockCommunityFee,
-					Unlocked:     true,
-				},
-				liquidity:   mockLiquidity,
-				ticks:       mockTicks,
-				tickMin:     mockTickmin,
-				tickMax:     mockTickmax,
-				tickSpacing: mockTickSpacing,
-				timepoints:  mockTimepoints,
-				volatilityOracle: &VolatilityOraclePlugin{
-					TimepointIndex:         mockTimepointIndex,
-					LastTimepointTimestamp: mockLastTimepointTimestamp,
-					IsInitialized:          true,
-				},
-				dynamicFee: &DynamicFeeConfig{
-					Alpha1:      mockAlpha1,
-					Alpha2:      mockAlpha2,
-					Beta1:       mockBeta1,
-					Beta2:       mockBeta2,
-					Gamma1:      mockGamma1,
-					Gamma2:      mockGamma2,
-					VolumeBeta:  mockVolumeBeta,
-					VolumeGamma: mockVolumeGamma,
-					BaseFee:     mockBaseFee,
-				},
-				writeTimePointOnce: new(sync.Once),
-				useBasePluginV2:    false,
-			},
+			name:      "valid swap - token 0 to token 1",
+			simulator: newMockPoolSimulator(t),
 			input: pool.CalcAmountOutParams{
 				TokenAmountIn: pool.TokenAmount{
 					Token:  "0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
@@ -197,67 +199,16 @@ func TestCalcAmountOut(t *testing.T) {
 					Token:  "0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4",
 					Amount: big.NewInt(2250),
 				},
-				SwapInfo: StateUpdate{
-					Liquidity: uint256.NewInt(98862330578),
-					Price:     uint256.MustFromDecimal("79214348439875248928556576945"),
-					Tick:      -4,
-				},
-				Gas: 281278,
+				SwapInfo: StateUpdate{Tick: -4},
+				Gas:      281278,
 			},
-			expectedErr: nil,
+			expectedPrice: "79214348439875248928556576945",
+			expectedLiq:   98862330578,
+			expectedErr:   nil,
 		},
 		{
-			name: "valid swap - token 1 to token 0",
-			simulator: &PoolSimulator{
-				Pool: pool.Pool{
-					Info: pool.PoolInfo{
-						Address:  "0x3b4d8548aa8dccd0ae7643a84049687bf16d1851",
-						Exchange: "scribe",
-						Type:     DexType,
-						Reserves: []*big.Int{
-							big.NewInt(817408052),
-							big.NewInt(1425700551),
-						},
-						Tokens: []string{
-							"0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
-							"0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
-						},
-						BlockNumber: 11587102,
-					},
-				},
-				globalState: GlobalState{
-					Price:        uint256.MustFromBig(mockPrice),
-					Tick:         mockTick,
-					LastFee:      mockLastFee,
-					PluginConfig: mockPluginConfig,
-					CommunityFee: mockCommunityFee,
-					Unlocked:     true,
-				},
-				liquidity:   mockLiquidity,
-				ticks:       mockTicks,
-				tickMin:     mockTickmin,
-				tickMax:     mockTickmax,
-				tickSpacing: mockTickSpacing,
-				timepoints:  mockTimepoints,
-				volatilityOracle: &VolatilityOraclePlugin{
-					TimepointIndex:         mockTimepointIndex,
-					LastTimepointTimestamp: mockLastTimepointTimestamp,
-					IsInitialized:          true,
-				},
-				dynamicFee: &DynamicFeeConfig{
-					Alpha1:      mockAlpha1,
-					Alpha2:      mockAlpha2,
-					Beta1:       mockBeta1,
-					Beta2:       mockBeta2,
-					Gamma1:      mockGamma1,
-					Gamma2:      mockGamma2,
-					VolumeBeta:  mockVolumeBeta,
-					VolumeGamma: mockVolumeGamma,
-					BaseFee:     mockBaseFee,
-				},
-				writeTimePointOnce: new(sync.Once),
-				useBasePluginV2:    false,
-			},
+			name:      "valid swap - token 1 to token 0",
+			simulator: newMockPoolSimulator(t),
 			input: pool.CalcAmountOutParams{
 				TokenAmountIn: pool.TokenAmount{
 					Token:  "0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
@@ -274,67 +225,16 @@ func TestCalcAmountOut(t *testing.T) {
 					Token:  "0xf55bec9cafdbe8730f096aa55dad6d22d44099df",
 					Amount: big.NewInt(2250),
 				},
-				SwapInfo: StateUpdate{
-					Liquidity: uint256.NewInt(98862330578),
-					Price:     uint256.MustFromDecimal("79215926928314930666100919082"),
-					Tick:      -4,
-				},
-				Gas: 281278,
+				SwapInfo: StateUpdate{Tick: -4},
+				Gas:      281278,
 			},
-			expectedErr: nil,
+			expectedPrice: "79215926928314930666100919082",
+			expectedLiq:   98862330578,
+			expectedErr:   nil,
 		},
 		{
-			name: "swap token 0 to token 1 with large amount in",
-			simulator: &PoolSimulator{
-				Pool: pool.Pool{
-					Info: pool.PoolInfo{
-						Address:  "0x3b4d8548aa8dccd0ae7643a84049687bf16d1851",
-						Exchange: "scribe",
-						Type:     DexType,
-						Reserves: []*big.Int{
-							big.NewInt(817408052),
-							big.NewInt(1425700551),
-						},
-						Tokens: []string{
-							"0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
-							"0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
-						},
-						BlockNumber: 11587102,
-					},
-				},
-				globalState: GlobalState{
-					Price:        uint256.MustFromBig(mockPrice),
-					Tick:         mockTick,
-					LastFee:      mockLastFee,
-					PluginConfig: mockPluginConfig,
-					CommunityFee: mockCommunityFee,
-					Unlocked:     true,
-				},
-				liquidity:   mockLiquidity,
-				ticks:       mockTicks,
-				tickMin:     mockTickmin,
-				tickMax:     mockTickmax,
-				tickSpacing: mockTickSpacing,
-				timepoints:  mockTimepoints,
-				volatilityOracle: &VolatilityOraclePlugin{
-					TimepointIndex:         mockTimepointIndex,
-					LastTimepointTimestamp: mockLastTimepointTimestamp,
-					IsInitialized:          true,
-				},
-				dynamicFee: &DynamicFeeConfig{
-					Alpha1:      mockAlpha1,
-					Alpha2:      mockAlpha2,
-					Beta1:       mockBeta1,
-					Beta2:       mockBeta2,
-					Gamma1:      mockGamma1,
-					Gamma2:      mockGamma2,
-					VolumeBeta:  mockVolumeBeta,
-					VolumeGamma: mockVolumeGamma,
-					BaseFee:     mockBaseFee,
-				},
-				writeTimePointOnce: new(sync.Once),
-				useBasePluginV2:    false,
-			},
+			name:      "swap token 0 to token 1 with large amount in",
+			simulator: newMockPoolSimulator(t),
 			input: pool.CalcAmountOutParams{
 				TokenAmountIn: pool.TokenAmount{
 					Token:  "0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
@@ -351,67 +251,16 @@ func TestCalcAmountOut(t *testing.T) {
 					Token:  "0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4",
 					Amount: big.NewInt(3207826239749998),
 				},
-				SwapInfo: StateUpdate{
-					Liquidity: uint256.NewInt(35733795),
-					Price:     uint256.MustFromDecimal("2016016943697492749"),
-					Tick:      -487914,
-				},
-				Gas: 344516,
+				SwapInfo: StateUpdate{Tick: -487914},
+				Gas:      344516,
 			},
-			expectedErr: nil,
+			expectedPrice: "2016016943697492749",
+			expectedLiq:   35733795,
+			expectedErr:   nil,
 		},
 		{
-			name: "swap token 1 to token 0 with large amount in",
-			simulator: &PoolSimulator{
-				Pool: pool.Pool{
-					Info: pool.PoolInfo{
-						Address:  "0x3b4d8548aa8dccd0ae7643a84049687bf16d1851",
-						Exchange: "scribe",
-						Type:     DexType,
-						Reserves: []*big.Int{
-							big.NewInt(817408052),
-							big.NewInt(1425700551),
-						},
-						Tokens: []string{
-							"0x06efdbff2a14a7c8e15944d1f4a48f9f95f663a4", // USDC
-							"0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
-						},
-						BlockNumber: 11587102,
-					},
-				},
-				globalState: GlobalState{
-					Price:        uint256.MustFromBig(mockPrice),
-					Tick:         mockTick,
-					LastFee:      mockLastFee,
-					PluginConfig: mockPluginConfig,
-					CommunityFee: mockCommunityFee,
-					Unlocked:     true,
-				},
-				liquidity:   mockLiquidity,
-				ticks:       mockTicks,
-				tickMin:     mockTickmin,
-				tickMax:     mockTickmax,
-				tickSpacing: mockTickSpacing,
-				timepoints:  mockTimepoints,
-				volatilityOracle: &VolatilityOraclePlugin{
-					TimepointIndex:         mockTimepointIndex,
-					LastTimepointTimestamp: mockLastTimepointTimestamp,
-					IsInitialized:          true,
-				},
-				dynamicFee: &DynamicFeeConfig{
-					Alpha1:      mockAlpha1,
-					Alpha2:      mockAlpha2,
-					Beta1:       mockBeta1,
-					Beta2:       mockBeta2,
-					Gamma1:      mockGamma1,
-					Gamma2:      mockGamma2,
-					VolumeBeta:  mockVolumeBeta,
-					VolumeGamma: mockVolumeGamma,
-					BaseFee:     mockBaseFee,
-				},
-				writeTimePointOnce: new(sync.Once),
-				useBasePluginV2:    false,
-			},
+			name:      "swap token 1 to token 0 with large amount in",
+			simulator: newMockPoolSimulator(t),
 			input: pool.CalcAmountOutParams{
 				TokenAmountIn: pool.TokenAmount{
 					Token:  "0xf55bec9cafdbe8730f096aa55dad6d22d44099df", // USDT
@@ -428,14 +277,12 @@ func TestCalcAmountOut(t *testing.T) {
 					Token:  "0xf55bec9cafdbe8730f096aa55dad6d22d44099df",
 					Amount: big.NewInt(1839166),
 				},
-				SwapInfo: StateUpdate{
-					Liquidity: uint256.NewInt(3480992933),
-					Price:     uint256.MustFromDecimal("86350404125395664252363004498"),
-					Tick:      1721,
-				},
-				Gas: 317414,
+				SwapInfo: StateUpdate{Tick: 1721},
+				Gas:      317414,
 			},
-			expectedErr: nil,
+			expectedPrice: "86350404125395664252363004498",
+			expectedLiq:   3480992933,
+			expectedErr:   nil,
 		},
 	}
 
@@ -457,10 +304,13 @@ func TestCalcAmountOut(t *testing.T) {
 
 				require.NotEmpty(t, result.SwapInfo)
 
-				expectedSwapInfo := tt.expectedResult.SwapInfo.(StateUpdate)
-				actualSwapInfo := result.SwapInfo.(StateUpdate)
+				swapInfo := result.SwapInfo.(clcore.SwapInfo)
+				assert.Equal(t, tt.expectedResult.SwapInfo, swapInfo.State)
+				assert.Equal(t, tt.expectedPrice, swapInfo.NextStateSqrtRatioX96.Dec())
 
-				assert.Equal(t, expectedSwapInfo, actualSwapInfo)
+				cloned := tt.simulator.CloneState().(*PoolSimulator)
+				cloned.UpdateBalance(pool.UpdateBalanceParams{SwapInfo: result.SwapInfo})
+				assert.Equal(t, tt.expectedLiq, cloned.V3Pool.Liquidity.Uint64())
 
 				require.NotEmpty(t, result.SwapInfo)
 				assert.Equal(t, tt.expectedResult.TokenAmountOut, result.TokenAmountOut)
@@ -469,7 +319,7 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
-var mockPool = []byte(`{"address":"0xbe9c1d237d002c8d9402f30c16ace1436d008f0c","exchange":"silverswap","type":"algebra-integral","timestamp":1733225338,"reserves":["9999999999999944","2620057588865"],"tokens":[{"address":"0x21be370d5312f44cb42ce377bc9b8a0cef1a4c83","name":"Wrapped Fantom","symbol":"WFTM","decimals":18,"weight":50,"swappable":true},{"address":"0xfe7eda5f2c56160d406869a8aa4b2f365d544c7b","name":"Axelar Wrapped ETH","symbol":"axlETH","decimals":18,"weight":50,"swappable":true}],"extra":"{\"liq\":161865919478591,\"gS\":{\"price\":\"1282433937397070526017841373\",\"tick\":82476,\"lF\":100,\"pC\":193,\"cF\":100,\"un\":true},\"ticks\":[{\"Index\":-887220,\"LiquidityGross\":161865919478591,\"LiquidityNet\":161865919478591},{\"Index\":887220,\"LiquidityGross\":161865919478591,\"LiquidityNet\":-161865919478591}],\"tS\":60,\"tP\":{\"0\":{\"init\":true,\"ts\":1712116096,\"cum\":0,\"vo\":\"0\",\"tick\":-82476,\"avgT\":-82476,\"wsI\":0},\"1\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0},\"2\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0},\"65535\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0}},\"vo\":{\"tpIdx\":0,\"lastTs\":1712116096,\"init\":true},\"sF\":{\"0to1fF\":null,\"1to0fF\":null},\"dF\":{\"a1\":2900,\"a2\":12000,\"b1\":360,\"b2\":60000,\"g1\":59,\"g2\":8500,\"vB\":0,\"vG\":0,\"bF\":100}}","staticExtra":"{\"pluginV2\":false}","blockNumber":99019509}`)
+var mockPool = []byte(`{"address":"0xbe9c1d237d002c8d9402f30c16ace1436d008f0c","exchange":"silverswap","type":"algebra-integral","timestamp":1733225338,"reserves":["9999999999999944","2620057588865"],"tokens":[{"address":"0x21be370d5312f44cb42ce377bc9b8a0cef1a4c83","name":"Wrapped Fantom","symbol":"WFTM","decimals":18,"weight":50,"swappable":true},{"address":"0xfe7eda5f2c56160d406869a8aa4b2f365d544c7b","name":"Axelar Wrapped ETH","symbol":"axlETH","decimals":18,"weight":50,"swappable":true}],"extra":"{\"liq\":161865919478591,\"gS\":{\"price\":\"1282433937397070526017841373\",\"tick\":-82476,\"lF\":100,\"pC\":193,\"cF\":100,\"un\":true},\"ticks\":[{\"Index\":-887220,\"LiquidityGross\":161865919478591,\"LiquidityNet\":161865919478591},{\"Index\":887220,\"LiquidityGross\":161865919478591,\"LiquidityNet\":-161865919478591}],\"tS\":60,\"tP\":{\"0\":{\"init\":true,\"ts\":1712116096,\"cum\":0,\"vo\":\"0\",\"tick\":-82476,\"avgT\":-82476,\"wsI\":0},\"1\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0},\"2\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0},\"65535\":{\"init\":false,\"ts\":0,\"cum\":0,\"vo\":\"0\",\"tick\":0,\"avgT\":0,\"wsI\":0}},\"vo\":{\"tpIdx\":0,\"lastTs\":1712116096,\"init\":true},\"sF\":{\"0to1fF\":null,\"1to0fF\":null},\"dF\":{\"a1\":2900,\"a2\":12000,\"b1\":360,\"b2\":60000,\"g1\":59,\"g2\":8500,\"vB\":0,\"vG\":0,\"bF\":100}}","staticExtra":"{\"pluginV2\":false}","blockNumber":99019509}`)
 
 var (
 	p  entity.Pool
@@ -490,7 +340,7 @@ func TestCalcAmountOut_FromPool(t *testing.T) {
 	})
 
 	require.NoError(t, err)
-	assert.Equal(t, big.NewInt(25555842204), res.TokenAmountOut.Amount)
+	assert.Equal(t, big.NewInt(25938595811), res.TokenAmountOut.Amount)
 }
 
 var (
@@ -522,3 +372,8 @@ func TestPoolSimulator_CalcAmountIn(t *testing.T) {
 	t.Parallel()
 	testutil.TestCalcAmountIn(t, ps)
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, ps)
+}
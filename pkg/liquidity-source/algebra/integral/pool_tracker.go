@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/KyberNetwork/ethrpc"
@@ -127,6 +128,7 @@ func (d *PoolTracker) GetNewPoolState(
 		VolatilityOracle: rpcData.VolatilityOracle,
 		SlidingFee:       rpcData.SlidingFee,
 		DynamicFee:       rpcData.DynamicFee,
+		Plugins:          rpcData.Plugins,
 	})
 	if err != nil {
 		l.WithFields(logger.Fields{
@@ -227,25 +229,37 @@ func (d *PoolTracker) FetchRPCData(ctx context.Context, p *entity.Pool, blockNum
 		Unlocked:     rpcState.Unlocked,
 	}
 
-	timepoints, volatilityOracleData, dynamicFeeData, slidingFeeData, err := d.getPluginData(ctx,
-		p, plugin.Hex(), result.BlockNumber)
+	pluginTypes := getPluginTypes(d.config, plugin.Hex())
+	if slices.Contains(pluginTypes, PluginTypeBase) || slices.Contains(pluginTypes, PluginTypeSlidingFee) {
+		timepoints, volatilityOracleData, dynamicFeeData, slidingFeeData, err := d.getPluginData(ctx,
+			p, plugin.Hex(), slices.Contains(pluginTypes, PluginTypeSlidingFee), result.BlockNumber)
+		if err != nil {
+			l.WithFields(logger.Fields{
+				"error": err,
+			}).Error("failed to fetch plugin data")
+			return res, err
+		}
+
+		res.Timepoints = timepoints
+		res.VolatilityOracle = volatilityOracleData
+		res.SlidingFee = slidingFeeData
+		res.DynamicFee = dynamicFeeData
+	}
+
+	res.Plugins, err = d.getPluginStates(ctx, p, plugin.Hex(), pluginTypes, result.BlockNumber)
 	if err != nil {
 		l.WithFields(logger.Fields{
-			"error": err,
-		}).Error("failed to fetch plugin data")
+			"error":       err,
+			"pluginTypes": pluginTypes,
+		}).Error("failed to fetch plugin states")
 		return res, err
 	}
-
-	res.Timepoints = timepoints
-	res.VolatilityOracle = volatilityOracleData
-	res.SlidingFee = slidingFeeData
-	res.DynamicFee = dynamicFeeData
 	res.BlockNumber = result.BlockNumber
 
 	return res, nil
 }
 
-func (d *PoolTracker) getPluginData(ctx context.Context, p *entity.Pool, plugin string,
+func (d *PoolTracker) getPluginData(ctx context.Context, p *entity.Pool, plugin string, useSlidingFee bool,
 	blockNumber *big.Int) (map[uint16]Timepoint, VolatilityOraclePlugin, DynamicFeeConfig,
 	SlidingFeeConfig, error) {
 	l := logger.WithFields(logger.Fields{
@@ -263,7 +277,7 @@ func (d *PoolTracker) getPluginData(ctx context.Context, p *entity.Pool, plugin
 	dynamicFeeData, dynPost := d.getDynamicFeeData(req, plugin)
 	var slidingFeeData *SlidingFeeConfig
 	var sliPost func(resp *ethrpc.Response) error
-	if d.config.UseBasePluginV2 {
+	if useSlidingFee {
 		slidingFeeData, sliPost = d.getSlidingFeeData(req, plugin)
 	} else {
 		slidingFeeData, sliPost = &SlidingFeeConfig{}, func(*ethrpc.Response) error { return nil }
@@ -299,6 +313,67 @@ func (d *PoolTracker) getPluginData(ctx context.Context, p *entity.Pool, plugin
 	return timepoints, *volatilityOracleData, *dynamicFeeData, *slidingFeeData, nil
 }
 
+// getPluginStates fetches the states declared by the plugin modules of a pool, in up to two rounds for states whose
+// calls depend on the results of their first calls.
+func (d *PoolTracker) getPluginStates(ctx context.Context, p *entity.Pool, plugin string, pluginTypes []PluginType,
+	blockNumber *big.Int) ([]PluginData, error) {
+	newRequest := func() *ethrpc.Request {
+		req := d.EthrpcClient.NewRequest().SetContext(ctx)
+		if blockNumber != nil && blockNumber.Sign() > 0 {
+			req.SetBlockNumber(blockNumber)
+		}
+		return req
+	}
+
+	states := make([]PluginState, len(pluginTypes))
+	req := newRequest()
+	for i, pluginType := range pluginTypes {
+		state, err := newPluginState(pluginType)
+		if err != nil {
+			return nil, err
+		} else if state != nil {
+			state.AddCalls(req, plugin, p.Address)
+			states[i] = state
+		}
+	}
+	if len(req.Calls) > 0 {
+		if _, err := req.Aggregate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var finalizers []func()
+	req = newRequest()
+	for _, state := range states {
+		if chained, ok := state.(chainedPluginState); ok {
+			if finalize := chained.AddChainedCalls(req, plugin, p.Address); finalize != nil {
+				finalizers = append(finalizers, finalize)
+			}
+		}
+	}
+	if len(req.Calls) > 0 {
+		if _, err := req.Aggregate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, finalize := range finalizers {
+		finalize()
+	}
+
+	plugins := make([]PluginData, len(pluginTypes))
+	for i, pluginType := range pluginTypes {
+		plugins[i].Type = pluginType
+		if states[i] != nil {
+			stateBytes, err := json.Marshal(states[i])
+			if err != nil {
+				return nil, err
+			}
+			plugins[i].State = stateBytes
+		}
+	}
+	return plugins, nil
+}
+
 func (d *PoolTracker) getVolatilityOracleData(req *ethrpc.Request, pluginAddress string) (*VolatilityOraclePlugin,
 	func(resp *ethrpc.Response) error) {
 	var result VolatilityOraclePlugin
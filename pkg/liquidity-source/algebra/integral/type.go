@@ -104,6 +104,7 @@ type FetchRPCResult struct {
 	VolatilityOracle VolatilityOraclePlugin
 	SlidingFee       SlidingFeeConfig
 	DynamicFee       DynamicFeeConfig
+	Plugins          []PluginData
 	BlockNumber      *big.Int
 }
 
@@ -166,6 +167,7 @@ type Extra struct {
 	VolatilityOracle VolatilityOraclePlugin `json:"vo"`
 	DynamicFee       DynamicFeeConfig       `json:"dF"`
 	SlidingFee       SlidingFeeConfig       `json:"sF"`
+	Plugins          []PluginData           `json:"plugins,omitempty"`
 }
 
 type ExtraTimepoint struct {
@@ -211,37 +213,13 @@ type StaticExtra struct {
 
 // StateUpdate to be returned instead of updating state when calculating amountOut
 type StateUpdate struct {
-	Liquidity *uint256.Int
-	Price     *uint256.Int
-	Tick      int32
+	Tick int32
+
+	ticks   []v3Entities.Tick // tick list after plugins withdrew liquidity, nil if unchanged
+	plugins []IPlugin         // plugin modules with their state after the swap, nil if unchanged
 }
 
 type PoolMeta struct {
 	BlockNumber uint64       `json:"blockNumber"`
 	PriceLimit  *uint256.Int `json:"priceLimit"`
 }
-
-type FeesAmount struct {
-	communityFeeAmount *uint256.Int
-	pluginFeeAmount    *uint256.Int
-}
-
-type SwapCalculationCache struct {
-	amountRequiredInitial *uint256.Int // The initial value of the exact input/output amount
-	amountCalculated      *uint256.Int // The additive amount of total output/input calculated through the swap
-	pluginFee             *uint256.Int // The plugin fee
-	communityFee          *uint256.Int // The community fee of the selling token, uint256 to minimize casts
-	fee                   uint64       // The current fee value in hundredths of a bip, i.e. 1e-6
-	exactInput            bool         // Whether the exact input or output is specified
-}
-
-type PriceMovementCache struct {
-	stepSqrtPrice *uint256.Int // The Q64.96 sqrt of the price at the start of the step, uint256 to minimize casts
-	nextTickPrice *uint256.Int // The Q64.96 sqrt of the price calculated from the _nextTick_, uint256 to minimize casts
-	input         *uint256.Int // The additive amount of tokens that have been provided
-	output        *uint256.Int // The additive amount of token that have been withdrawn
-	feeAmount     *uint256.Int // The total amount of fee earned within a current step
-
-	nextTick    int32 // The tick till the current step goes
-	initialized bool  // True if the _nextTick is initialized
-}
@@ -4,7 +4,6 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/holiman/uint256"
 )
 
 const (
@@ -26,7 +25,6 @@ const (
 	methodGetTicks               = "ticks"
 	erc20MethodBalanceOf         = "balanceOf"
 
-	maxSwapLoop         = 1000000
 	maxBinarySearchLoop = 1000
 
 	WINDOW        = 86400 // 1 day in seconds
@@ -37,7 +35,6 @@ const (
 )
 
 var (
-	COMMUNITY_FEE_DENOMINATOR        = uint256.NewInt(1000)
 	COMMUNITY_FEE_DENOMINATOR_BIGINT = big.NewInt(1000)
 
 	slot3 = common.BigToHash(big.NewInt(3))
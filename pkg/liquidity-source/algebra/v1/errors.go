@@ -2,6 +2,8 @@ package algebrav1
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 )
 
 var (
@@ -14,7 +16,7 @@ var (
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrZeroAmountIn        = errors.New("amountIn is 0")
 	ErrZeroAmountOut       = errors.New("amountOut is 0")
-	ErrSPL                 = errors.New("invalid sqrt price limit")
+	ErrSPL                 = clcore.ErrInvalidSqrtPriceLimit
 	ErrPoolLocked          = errors.New("pool is locked")
 	ErrOverflow            = errors.New("bigInt overflow int/uint256")
 
@@ -38,6 +38,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		Extra:    `{"liquidity":2822091172725,"globalState":{"price":93065132232889433968150957834858946,"tick":279543,"feeZto":2985,"feeOtz":2985,"timepoint_index":65,"community_fee_token0":0,"community_fee_token1":0,"unlocked":true},"ticks":[{"Index":-887220,"LiquidityGross":2822091172725,"LiquidityNet":2822091172725},{"Index":273540,"LiquidityGross":116315447200034,"LiquidityNet":116315447200034},{"Index":279120,"LiquidityGross":116315447200034,"LiquidityNet":-116315447200034},{"Index":285480,"LiquidityGross":2822091172725,"LiquidityNet":-2822091172725}],"tickSpacing":60}`,
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, []string{"A"}, p.CanSwapTo("B"))
 	assert.Equal(t, []string{"B"}, p.CanSwapTo("A"))
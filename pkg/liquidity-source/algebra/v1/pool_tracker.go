@@ -7,7 +7,7 @@ import (
 
 	"github.com/KyberNetwork/ethrpc"
 	"github.com/KyberNetwork/logger"
-	v3Entities "github.com/daoleno/uniswapv3-sdk/entities"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -17,7 +17,6 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra"
 	sourcePool "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
-	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
@@ -111,7 +110,7 @@ func (d *PoolTracker) GetNewPoolState(
 
 	ticks := make([]v3Entities.Tick, 0, len(poolTicks))
 	for _, tickResp := range poolTicks {
-		tick, err := transformTickRespToTickBigInt(tickResp)
+		tick, err := transformTickRespToTick(tickResp)
 		if err != nil {
 			l.WithFields(logger.Fields{
 				"error": err,
@@ -120,7 +119,7 @@ func (d *PoolTracker) GetNewPoolState(
 		}
 
 		// LiquidityGross = 0 means that the tick is uninitialized
-		if tick.LiquidityGross.Cmp(bignumber.ZeroBI) == 0 {
+		if tick.LiquidityGross.IsZero() {
 			continue
 		}
 
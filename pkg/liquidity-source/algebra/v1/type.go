@@ -5,9 +5,9 @@ import (
 	"math/big"
 	"strconv"
 
+	"github.com/KyberNetwork/int256"
 	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
 	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
-	v3EntitiesBigInt "github.com/daoleno/uniswapv3-sdk/entities"
 	"github.com/holiman/uint256"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/uniswapv3"
@@ -192,16 +192,10 @@ type ExtraUint256 struct {
 }
 
 type Extra struct {
-	Liquidity   *big.Int                `json:"liquidity"`
-	GlobalState GlobalState             `json:"globalState"`
-	Ticks       []v3EntitiesBigInt.Tick `json:"ticks"`
-	TickSpacing int24                   `json:"tickSpacing"`
-}
-
-// StateUpdate should be returned instead since we won't update the state when calculating amountOut
-type StateUpdate struct {
-	Liquidity   *uint256.Int
-	GlobalState GlobalStateUint256
+	Liquidity   *big.Int          `json:"liquidity"`
+	GlobalState GlobalState       `json:"globalState"`
+	Ticks       []v3Entities.Tick `json:"ticks"`
+	TickSpacing int24             `json:"tickSpacing"`
 }
 
 // StateUpdateBigInt should be returned instead since we won't update the state when calculating amountOut
@@ -212,25 +206,25 @@ type StateUpdateBigInt struct {
 
 type PoolMeta = uniswapv3.PoolMeta
 
-func transformTickRespToTickBigInt(tickResp TickResp) (v3EntitiesBigInt.Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return v3EntitiesBigInt.Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v",
+func transformTickRespToTick(tickResp TickResp) (v3Entities.Tick, error) {
+	liquidityGross, err := uint256.FromDecimal(tickResp.LiquidityGross)
+	if err != nil {
+		return v3Entities.Tick{}, fmt.Errorf("can not convert liquidityGross string to uint256, tick: %v",
 			tickResp.TickIdx)
 	}
 
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return v3EntitiesBigInt.Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v",
+	liquidityNet, err := int256.FromDec(tickResp.LiquidityNet)
+	if err != nil {
+		return v3Entities.Tick{}, fmt.Errorf("can not convert liquidityNet string to int256, tick: %v",
 			tickResp.TickIdx)
 	}
 
 	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
 	if err != nil {
-		return v3EntitiesBigInt.Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
+		return v3Entities.Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
 	}
 
-	return v3EntitiesBigInt.Tick{
+	return v3Entities.Tick{
 		Index:          tickIdx,
 		LiquidityGross: liquidityGross,
 		LiquidityNet:   liquidityNet,
@@ -2,6 +2,7 @@ package composablestable
 
 import (
 	"math/big"
+	"slices"
 
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
@@ -283,6 +284,16 @@ func (s *PoolSimulator) GetApprovalAddress(_, _ string) string {
 	return s.vault
 }
 
+func (s *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := *s
+	cloned.Info.Reserves = slices.Clone(s.Info.Reserves)
+	regularSimulator, bptSimulator := *s.regularSimulator, *s.bptSimulator
+	regularSimulator.Info.Reserves, bptSimulator.Info.Reserves = cloned.Info.Reserves, cloned.Info.Reserves
+	bptSimulator.tokenRateCaches = slices.Clone(s.bptSimulator.tokenRateCaches)
+	cloned.regularSimulator, cloned.bptSimulator = &regularSimulator, &bptSimulator
+	return &cloned
+}
+
 func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	if params.TokenAmountIn.Token == s.Info.Address || params.TokenAmountOut.Token == s.Info.Address {
 		s.bptSimulator.updateBalance(params)
@@ -1029,6 +1029,7 @@ func TestPoolSimulator_CalcAmountIn(t *testing.T) {
 
 			simulator, err := NewPoolSimulator(pool)
 			assert.Nil(t, err)
+			testutil.TestPoolSimulator(t, simulator)
 
 			got, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountInResult, error) {
 				return simulator.CalcAmountIn(tt.params)
@@ -28,6 +28,18 @@ type Hop struct {
 	JoinExitIndex *uint256.Int `json:"joinExitIndex,omitempty"`
 }
 
+// CloneBasePools clones the state of the base pools of a pool
+func CloneBasePools(basePools map[string]IBasePool) map[string]IBasePool {
+	if basePools == nil {
+		return nil
+	}
+	cloned := make(map[string]IBasePool, len(basePools))
+	for address, basePool := range basePools {
+		cloned[address] = basePool.CloneState().(IBasePool)
+	}
+	return cloned
+}
+
 // indexes of the pools to exit or join in ascending order,
 // each value is a packed uint256 with the following structure [kind(uint24) 0 for exiting pool 1 for joining pool, pool index(uint232)]
 func PackJoinExitIndex(kind JoinExitKind, poolIndex int) *uint256.Int {
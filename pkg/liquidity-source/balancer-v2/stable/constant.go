@@ -13,6 +13,7 @@ const (
 
 	poolMethodGetSwapFeePercentage      = "getSwapFeePercentage"
 	poolMethodGetPausedState            = "getPausedState"
+	poolMethodInRecoveryMode            = "inRecoveryMode"
 	poolMethodGetAmplificationParameter = "getAmplificationParameter"
 	poolMethodGetVault                  = "getVault"
 	poolMethodGetScalingFactors         = "getScalingFactors"
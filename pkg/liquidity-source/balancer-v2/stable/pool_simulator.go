@@ -2,7 +2,9 @@ package stable
 
 import (
 	"errors"
+	"maps"
 	"math/big"
+	"slices"
 
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
@@ -29,7 +31,8 @@ type PoolSimulator struct {
 	pool.Pool
 	basePools map[string]shared.IBasePool
 
-	paused bool
+	paused         bool
+	inRecoveryMode bool
 
 	protocolSwapFeePercentage *uint256.Int
 	swapFeePercentage         *uint256.Int
@@ -96,6 +99,7 @@ func NewPoolSimulator(entityPool entity.Pool, basePoolMap map[string]pool.IPoolS
 		Pool:                      pool.Pool{Info: poolInfo},
 		basePools:                 basePools,
 		paused:                    extra.Paused,
+		inRecoveryMode:            extra.InRecoveryMode,
 		swapFeePercentage:         extra.SwapFeePercentage,
 		protocolSwapFeePercentage: extra.ProtocolSwapFeePercentage,
 		amp:                       extra.Amp,
@@ -114,6 +118,10 @@ func (s *PoolSimulator) GetPoolId() string {
 }
 
 func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	}
+
 	indexIn := s.GetTokenIndex(tokenIn)
 
 	scaledBalances, err := _upscaleArray(s.GetReserves(), s.scalingFactors)
@@ -139,9 +147,11 @@ func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.
 		return nil, err
 	}
 
-	err = chargeDueProtocolFee(scaledBalances, s.amp, invariant, s.protocolSwapFeePercentage)
-	if err != nil {
-		return nil, err
+	if !s.inRecoveryMode {
+		err = chargeDueProtocolFee(scaledBalances, s.amp, invariant, s.protocolSwapFeePercentage)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	bptAmountOut, err := math.StableMath.CalcBptOutGivenExactTokensIn(s.amp,
@@ -153,7 +163,12 @@ func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.
 	return bptAmountOut, nil
 }
 
+// OnExit quotes a single token exit, which the pool only allows when it is not paused
 func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	}
+
 	indexOut := s.GetTokenIndex(tokenOut)
 
 	scaledBalances, err := _upscaleArray(s.GetReserves(), s.scalingFactors)
@@ -166,9 +181,11 @@ func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint
 		return nil, err
 	}
 
-	err = chargeDueProtocolFee(scaledBalances, s.amp, invariant, s.protocolSwapFeePercentage)
-	if err != nil {
-		return nil, err
+	if !s.inRecoveryMode {
+		err = chargeDueProtocolFee(scaledBalances, s.amp, invariant, s.protocolSwapFeePercentage)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	amountOut, err := math.StableMath.CalcTokenOutGivenExactBptIn(s.amp,
@@ -186,6 +203,10 @@ func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint
 }
 
 func (s *PoolSimulator) OnSwap(tokenIn, tokenOut string, amountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	}
+
 	indexIn, indexOut := s.GetTokenIndex(tokenIn), s.GetTokenIndex(tokenOut)
 
 	feeAmount, err := math.FixedPoint.MulUp(amountIn, s.swapFeePercentage)
@@ -634,6 +655,13 @@ func (s *PoolSimulator) GetApprovalAddress(_, _ string) string {
 	return s.vault
 }
 
+func (s *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := *s
+	cloned.Info.Reserves = slices.Clone(s.Info.Reserves)
+	cloned.basePools = shared.CloneBasePools(s.basePools)
+	return &cloned
+}
+
 func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	if params.SwapInfo == nil {
 		s.updateBalance(params.TokenAmountIn.Token, params.TokenAmountOut.Token,
@@ -742,20 +770,13 @@ func _downscaleUp(amount *uint256.Int, scalingFactor *uint256.Int) (*uint256.Int
 	return math.FixedPoint.DivUp(amount, scalingFactor)
 }
 
+// GetTokens returns the tokens of the pool in order followed by the tokens of its base pools, in a stable order
 func (s *PoolSimulator) GetTokens() []string {
-	tokenSet := make(map[string]struct{})
-
-	for _, basePool := range s.basePools {
-		for _, token := range basePool.GetTokens() {
-			tokenSet[token] = struct{}{}
-		}
+	tokens := slices.Clone(s.GetInfo().Tokens)
+	for _, basePool := range slices.Sorted(maps.Keys(s.basePools)) {
+		tokens = append(tokens, s.basePools[basePool].GetTokens()...)
 	}
-
-	for _, token := range s.GetInfo().Tokens {
-		tokenSet[token] = struct{}{}
-	}
-
-	return lo.Keys(tokenSet)
+	return lo.Uniq(tokens)
 }
 
 func (s *PoolSimulator) CanSwapFrom(address string) []string { return s.CanSwapTo(address) }
@@ -190,6 +190,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 		s, err := NewPoolSimulator(pool, nil)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, s)
 
 		tokenAmountIn := poolpkg.TokenAmount{
 			Token:  "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
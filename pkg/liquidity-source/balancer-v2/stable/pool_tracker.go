@@ -99,7 +99,8 @@ func (t *PoolTracker) getNewPoolState(
 	scalingFactors := oldExtra.ScalingFactors
 
 	// call RPC
-	rpcRes, err := t.queryRPC(ctx, p.Address, staticExtra.PoolID, staticExtra.Vault, staticExtra.PoolType, overrides)
+	rpcRes, err := t.queryRPC(ctx, p.Address, staticExtra.PoolID, staticExtra.Vault, staticExtra.PoolType,
+		staticExtra.PoolTypeVer, overrides)
 	if err != nil {
 		return p, err
 	}
@@ -130,6 +131,7 @@ func (t *PoolTracker) getNewPoolState(
 		ProtocolSwapFeePercentage: protocolSwapFeePercentage,
 		ScalingFactors:            scalingFactors,
 		Paused:                    !isNotPaused(pausedState),
+		InRecoveryMode:            rpcRes.InRecoveryMode,
 	}
 	extraBytes, err := json.Marshal(extra)
 	if err != nil {
@@ -190,12 +192,14 @@ func (t *PoolTracker) queryRPC(
 	poolID string,
 	vault string,
 	poolType string,
+	poolTypeVer int,
 	overrides map[common.Address]gethclient.OverrideAccount,
 ) (*rpcRes, error) {
 	var (
 		poolTokens                                   PoolTokens
 		protocolSwapFeePercentage, swapFeePercentage *big.Int
 		pausedState                                  PausedState
+		inRecoveryMode                               bool
 		ampParams                                    AmplificationParameter
 		scalingFactors                               []*big.Int
 	)
@@ -232,6 +236,15 @@ func (t *PoolTracker) queryRPC(
 		Method: poolMethodGetPausedState,
 	}, []any{&pausedState})
 
+	// recovery mode came with the second version of the pools
+	if poolTypeVer > poolTypeVer1 {
+		req.AddCall(&ethrpc.Call{
+			ABI:    poolABI,
+			Target: poolAddress,
+			Method: poolMethodInRecoveryMode,
+		}, []any{&inRecoveryMode})
+	}
+
 	if poolType == poolTypeMetaStable || poolType == poolTypeLegacyMetaStable {
 		req.AddCall(&ethrpc.Call{
 			ABI:    poolABI,
@@ -266,6 +279,7 @@ func (t *PoolTracker) queryRPC(
 		ProtocolSwapFeePercentage: protocolSwapFeePercentage,
 		ScalingFactors:            scalingFactors,
 		PausedState:               pausedState,
+		InRecoveryMode:            inRecoveryMode,
 		BlockNumber:               res.BlockNumber.Uint64(),
 	}, nil
 }
@@ -17,6 +17,7 @@ type Extra struct {
 	ProtocolSwapFeePercentage *uint256.Int   `json:"protocolSwapFeePercentage"`
 	ScalingFactors            []*uint256.Int `json:"scalingFactors"`
 	Paused                    bool           `json:"paused"`
+	InRecoveryMode            bool           `json:"inRecoveryMode,omitempty"`
 }
 
 type StaticExtra struct {
@@ -62,5 +63,6 @@ type rpcRes struct {
 	ProtocolSwapFeePercentage *big.Int
 	ScalingFactors            []*big.Int
 	PausedState               PausedState
+	InRecoveryMode            bool
 	BlockNumber               uint64
 }
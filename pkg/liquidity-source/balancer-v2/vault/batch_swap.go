@@ -0,0 +1,137 @@
+// Package vault quotes Balancer V2 Vault batch swaps, which route an amount through several pools of the same vault
+// in a single transaction and only settle the net token deltas.
+package vault
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v2/shared"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// Step is a step of a batch swap. TokenOut (or TokenIn) is the pool address to join (or exit) a pool that does not
+// hold its own BPT, as the Vault's batch relayer does.
+type Step struct {
+	Pool     shared.IBasePool
+	TokenIn  string
+	TokenOut string
+}
+
+type BatchSwapResult struct {
+	AmountOut *uint256.Int
+	// Hops are the steps with their amounts, in the format of the pool simulators' SwapInfo
+	Hops []shared.Hop
+	Gas  int64
+}
+
+// CalcBatchSwap quotes swapping amountIn through steps as a single batch swap. Each step uses the OnSwap, OnJoin or
+// OnExit of its pool, which fail if the pool is paused as the Vault would revert, and the gas is charged once for the
+// Vault and per step for the pools. The pools are not updated.
+func CalcBatchSwap(steps []Step, amountIn *uint256.Int) (*BatchSwapResult, error) {
+	if len(steps) == 0 {
+		return nil, ErrEmptyBatchSwap
+	} else if amountIn == nil || amountIn.IsZero() {
+		return nil, ErrInvalidAmountIn
+	}
+
+	var (
+		hops   = make([]shared.Hop, 0, len(steps))
+		gas    = batchSwapGas
+		uses   = lo.CountValuesBy(steps, func(step Step) string { return step.Pool.GetAddress() })
+		clones = make(map[string]shared.IBasePool)
+		vault  = steps[0].Pool.GetApprovalAddress(steps[0].TokenIn, steps[0].TokenOut)
+		amount = amountIn
+	)
+	for i, step := range steps {
+		poolAddress := step.Pool.GetAddress()
+		if i > 0 && step.TokenIn != steps[i-1].TokenOut {
+			return nil, ErrDisconnectedStep
+		} else if step.Pool.GetApprovalAddress(step.TokenIn, step.TokenOut) != vault {
+			return nil, ErrVaultMismatch
+		}
+		// the Vault updates the balances of a pool after each step, so a pool used by more than one step is quoted
+		// against a clone updated the same way
+		p := step.Pool
+		if uses[poolAddress] > 1 {
+			if clones[poolAddress] == nil {
+				clone, _ := step.Pool.CloneState().(shared.IBasePool)
+				if clone == nil {
+					return nil, ErrPoolNotCloneable
+				}
+				clones[poolAddress] = clone
+			}
+			p = clones[poolAddress]
+		}
+
+		var (
+			amountOut     *uint256.Int
+			joinExitIndex *uint256.Int
+			err           error
+		)
+		switch indexIn, indexOut := p.GetTokenIndex(step.TokenIn), p.GetTokenIndex(step.TokenOut); {
+		case indexIn >= 0 && indexOut >= 0:
+			amountOut, err = p.OnSwap(step.TokenIn, step.TokenOut, amount)
+			gas += swapStepGas
+		case indexIn >= 0 && step.TokenOut == poolAddress:
+			amountOut, err = p.OnJoin(step.TokenIn, amount)
+			joinExitIndex = shared.PackJoinExitIndex(shared.PoolJoin, i)
+			gas += shared.JoinExitGasUsage
+		case step.TokenIn == poolAddress && indexOut >= 0:
+			amountOut, err = p.OnExit(step.TokenOut, amount)
+			joinExitIndex = shared.PackJoinExitIndex(shared.PoolExit, i)
+			gas += shared.JoinExitGasUsage
+		default:
+			return nil, ErrTokenNotInPool
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p != step.Pool {
+			p.UpdateBalance(pool.UpdateBalanceParams{
+				TokenAmountIn:  pool.TokenAmount{Token: step.TokenIn, Amount: amount.ToBig()},
+				TokenAmountOut: pool.TokenAmount{Token: step.TokenOut, Amount: amountOut.ToBig()},
+			})
+		}
+
+		hops = append(hops, shared.Hop{
+			PoolId:        step.Pool.GetPoolId(),
+			Pool:          poolAddress,
+			TokenIn:       step.TokenIn,
+			TokenOut:      step.TokenOut,
+			AmountIn:      amount,
+			AmountOut:     amountOut,
+			JoinExitIndex: joinExitIndex,
+		})
+		amount = amountOut
+	}
+
+	return &BatchSwapResult{
+		AmountOut: amount,
+		Hops:      hops,
+		Gas:       gas,
+	}, nil
+}
+
+// UpdateBalance applies a quoted batch swap to the pools of its steps
+func UpdateBalance(steps []Step, result *BatchSwapResult) {
+	for i, step := range steps {
+		hop := result.Hops[i]
+		step.Pool.UpdateBalance(pool.UpdateBalanceParams{
+			TokenAmountIn:  pool.TokenAmount{Token: hop.TokenIn, Amount: hop.AmountIn.ToBig()},
+			TokenAmountOut: pool.TokenAmount{Token: hop.TokenOut, Amount: hop.AmountOut.ToBig()},
+		})
+	}
+}
+
+// ToCalcAmountOutResult converts a batch swap result to a pool quote, with the hops as SwapInfo
+func (r *BatchSwapResult) ToCalcAmountOutResult() *pool.CalcAmountOutResult {
+	tokenOut := r.Hops[len(r.Hops)-1].TokenOut
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: &pool.TokenAmount{Token: tokenOut, Amount: r.AmountOut.ToBig()},
+		Fee:            &pool.TokenAmount{Token: tokenOut, Amount: bignumber.ZeroBI},
+		Gas:            r.Gas,
+		SwapInfo:       shared.SwapInfo{Hops: r.Hops},
+	}
+}
@@ -0,0 +1,257 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v2/stable"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v2/weighted"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+const (
+	vaultAddress = "0xba12222222228d8ba445958a75a0704d566bf2c8"
+	tokenA       = "0x000000000000000000000000000000000000000a"
+	tokenB       = "0x000000000000000000000000000000000000000b"
+	tokenC       = "0x000000000000000000000000000000000000000c"
+)
+
+func newWeightedPool(t *testing.T, address, vault string, tokens []string, reserves []string) *weighted.PoolSimulator {
+	return newWeightedPoolPaused(t, address, vault, tokens, reserves, false)
+}
+
+func newWeightedPoolPaused(t *testing.T, address, vault string, tokens []string, reserves []string,
+	paused bool) *weighted.PoolSimulator {
+	extraBytes, err := json.Marshal(weighted.Extra{
+		SwapFeePercentage:         uint256.NewInt(3e15),
+		ProtocolSwapFeePercentage: uint256.NewInt(0),
+		LastInvariant:             uint256.NewInt(0),
+		TotalSupply:               uint256.MustFromDecimal("1000000000000000000000"),
+		Paused:                    paused,
+	})
+	require.NoError(t, err)
+	staticExtraBytes, err := json.Marshal(weighted.StaticExtra{
+		PoolID:            address + "000200000000000000000001",
+		PoolTypeVer:       2,
+		ScalingFactors:    []*uint256.Int{uint256.NewInt(1e18), uint256.NewInt(1e18)},
+		NormalizedWeights: []*uint256.Int{uint256.NewInt(5e17), uint256.NewInt(5e17)},
+		Vault:             vault,
+	})
+	require.NoError(t, err)
+
+	p, err := weighted.NewPoolSimulator(entity.Pool{
+		Address:     address,
+		Exchange:    "balancer-v2-weighted",
+		Type:        weighted.DexType,
+		Tokens:      []*entity.PoolToken{{Address: tokens[0]}, {Address: tokens[1]}},
+		Reserves:    reserves,
+		Extra:       string(extraBytes),
+		StaticExtra: string(staticExtraBytes),
+	}, nil)
+	require.NoError(t, err)
+	return p
+}
+
+func newStablePool(t *testing.T, address, vault string, tokens []string, reserves []string,
+	paused bool) *stable.PoolSimulator {
+	extraBytes, err := json.Marshal(stable.Extra{
+		Amp:                       uint256.NewInt(200000),
+		SwapFeePercentage:         uint256.NewInt(1e14),
+		ProtocolSwapFeePercentage: uint256.NewInt(0),
+		ScalingFactors:            []*uint256.Int{uint256.NewInt(1e18), uint256.NewInt(1e18)},
+		Paused:                    paused,
+	})
+	require.NoError(t, err)
+	staticExtraBytes, err := json.Marshal(stable.StaticExtra{
+		PoolID:             address + "000000000000000000000002",
+		PoolType:           "STABLE",
+		PoolTypeVer:        2,
+		PoolSpecialization: 2,
+		Vault:              vault,
+	})
+	require.NoError(t, err)
+
+	p, err := stable.NewPoolSimulator(entity.Pool{
+		Address:     address,
+		Exchange:    "balancer-v2-stable",
+		Type:        stable.DexType,
+		Tokens:      []*entity.PoolToken{{Address: tokens[0]}, {Address: tokens[1]}},
+		Reserves:    reserves,
+		Extra:       string(extraBytes),
+		StaticExtra: string(staticExtraBytes),
+	}, nil)
+	require.NoError(t, err)
+	return p
+}
+
+func TestCalcBatchSwap(t *testing.T) {
+	t.Parallel()
+	newPools := func() (*weighted.PoolSimulator, *weighted.PoolSimulator) {
+		return newWeightedPool(t, "0x00000000000000000000000000000000000000ab", vaultAddress, []string{tokenA, tokenB},
+				[]string{"1000000000000000000000", "2000000000000000000000"}),
+			newWeightedPool(t, "0x00000000000000000000000000000000000000bc", vaultAddress, []string{tokenB, tokenC},
+				[]string{"3000000000000000000000", "1000000000000000000000"})
+	}
+	amountIn := uint256.NewInt(1e18)
+
+	t.Run("swaps", func(t *testing.T) {
+		poolAB, poolBC := newPools()
+		res, err := CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: poolBC, TokenIn: tokenB, TokenOut: tokenC},
+		}, amountIn)
+		require.NoError(t, err)
+
+		// the amounts are the same as swapping through each pool separately, for less gas
+		resAB, err := poolAB.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: tokenA, Amount: amountIn.ToBig()}, TokenOut: tokenB})
+		require.NoError(t, err)
+		resBC, err := poolBC.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: tokenB, Amount: resAB.TokenAmountOut.Amount}, TokenOut: tokenC})
+		require.NoError(t, err)
+
+		require.Len(t, res.Hops, 2)
+		assert.Equal(t, resAB.TokenAmountOut.Amount, res.Hops[0].AmountOut.ToBig())
+		assert.Equal(t, res.Hops[0].AmountOut, res.Hops[1].AmountIn)
+		assert.Equal(t, resBC.TokenAmountOut.Amount, res.AmountOut.ToBig())
+		assert.Nil(t, res.Hops[0].JoinExitIndex)
+		assert.Less(t, res.Gas, resAB.Gas+resBC.Gas)
+
+		quote := res.ToCalcAmountOutResult()
+		assert.Equal(t, tokenC, quote.TokenAmountOut.Token)
+		assert.Equal(t, res.Gas, quote.Gas)
+
+		UpdateBalance([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: poolBC, TokenIn: tokenB, TokenOut: tokenC},
+		}, res)
+		assert.Equal(t, bignumber.NewBig10("1001000000000000000000"), poolAB.GetReserves()[0])
+	})
+
+	t.Run("join then swap", func(t *testing.T) {
+		poolAB, _ := newPools()
+		bptPool := newWeightedPool(t, "0x00000000000000000000000000000000000000cd", vaultAddress,
+			[]string{poolAB.GetAddress(), tokenC}, []string{"1000000000000000000000", "1000000000000000000000"})
+		res, err := CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: poolAB.GetAddress()},
+			{Pool: bptPool, TokenIn: poolAB.GetAddress(), TokenOut: tokenC},
+		}, amountIn)
+		require.NoError(t, err)
+
+		bptAmount, err := poolAB.OnJoin(tokenA, amountIn)
+		require.NoError(t, err)
+		assert.Equal(t, bptAmount, res.Hops[0].AmountOut)
+		assert.NotNil(t, res.Hops[0].JoinExitIndex)
+		assert.Nil(t, res.Hops[1].JoinExitIndex)
+	})
+
+	t.Run("pool used twice", func(t *testing.T) {
+		poolAB, poolBC := newPools()
+		steps := []Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: poolBC, TokenIn: tokenB, TokenOut: tokenC},
+			{Pool: poolBC, TokenIn: tokenC, TokenOut: tokenB},
+			{Pool: poolAB, TokenIn: tokenB, TokenOut: tokenA},
+		}
+		res, err := CalcBatchSwap(steps, amountIn)
+		require.NoError(t, err)
+		assert.Equal(t, bignumber.NewBig10("1000000000000000000000"), poolAB.GetReserves()[0],
+			"the pools should not be updated")
+
+		// the second use of a pool sees the balances left by the first one
+		for i, step := range steps {
+			hop, err := step.Pool.CalcAmountOut(pool.CalcAmountOutParams{
+				TokenAmountIn: pool.TokenAmount{Token: step.TokenIn, Amount: res.Hops[i].AmountIn.ToBig()},
+				TokenOut:      step.TokenOut,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, hop.TokenAmountOut.Amount, res.Hops[i].AmountOut.ToBig())
+			step.Pool.UpdateBalance(pool.UpdateBalanceParams{
+				TokenAmountIn:  pool.TokenAmount{Token: step.TokenIn, Amount: res.Hops[i].AmountIn.ToBig()},
+				TokenAmountOut: *hop.TokenAmountOut,
+			})
+		}
+		// a round trip loses the fees
+		assert.True(t, res.AmountOut.Lt(amountIn))
+		assert.Equal(t, batchSwapGas+4*swapStepGas, res.Gas)
+	})
+
+	t.Run("invalid steps", func(t *testing.T) {
+		poolAB, poolBC := newPools()
+		otherVaultPool := newWeightedPool(t, "0x00000000000000000000000000000000000000bd", tokenA,
+			[]string{tokenB, tokenC}, []string{"1000000000000000000000", "1000000000000000000000"})
+
+		_, err := CalcBatchSwap(nil, amountIn)
+		assert.ErrorIs(t, err, ErrEmptyBatchSwap)
+
+		_, err = CalcBatchSwap([]Step{{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB}}, uint256.NewInt(0))
+		assert.ErrorIs(t, err, ErrInvalidAmountIn)
+
+		_, err = CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: poolBC, TokenIn: tokenC, TokenOut: tokenB},
+		}, amountIn)
+		assert.ErrorIs(t, err, ErrDisconnectedStep)
+
+		_, err = CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: otherVaultPool, TokenIn: tokenB, TokenOut: tokenC},
+		}, amountIn)
+		assert.ErrorIs(t, err, ErrVaultMismatch)
+
+		_, err = CalcBatchSwap([]Step{{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenC}}, amountIn)
+		assert.ErrorIs(t, err, ErrTokenNotInPool)
+	})
+
+	// the Vault reverts a batch swap through a paused pool, whether the pool is swapped, joined or exited
+	t.Run("paused weighted pool", func(t *testing.T) {
+		poolAB, _ := newPools()
+		pausedPool := newWeightedPoolPaused(t, "0x00000000000000000000000000000000000000bc", vaultAddress,
+			[]string{tokenB, tokenC}, []string{"3000000000000000000000", "1000000000000000000000"}, true)
+		bptPool := newWeightedPoolPaused(t, "0x00000000000000000000000000000000000000cd", vaultAddress,
+			[]string{tokenA, tokenB}, []string{"1000000000000000000000", "1000000000000000000000"}, true)
+
+		_, err := CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: pausedPool, TokenIn: tokenB, TokenOut: tokenC},
+		}, amountIn)
+		assert.ErrorIs(t, err, weighted.ErrPoolPaused)
+
+		_, err = CalcBatchSwap([]Step{{Pool: bptPool, TokenIn: tokenA, TokenOut: bptPool.GetAddress()}}, amountIn)
+		assert.ErrorIs(t, err, weighted.ErrPoolPaused)
+
+		_, err = CalcBatchSwap([]Step{{Pool: bptPool, TokenIn: bptPool.GetAddress(), TokenOut: tokenA}}, amountIn)
+		assert.ErrorIs(t, err, weighted.ErrPoolPaused)
+	})
+
+	t.Run("paused stable pool", func(t *testing.T) {
+		poolAB, _ := newPools()
+		reserves := []string{"3000000000000000000000", "3000000000000000000000"}
+		stablePool := newStablePool(t, "0x00000000000000000000000000000000000000be", vaultAddress,
+			[]string{tokenB, tokenC}, reserves, false)
+		pausedPool := newStablePool(t, "0x00000000000000000000000000000000000000bf", vaultAddress,
+			[]string{tokenB, tokenC}, reserves, true)
+
+		_, err := CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: stablePool, TokenIn: tokenB, TokenOut: tokenC},
+		}, amountIn)
+		require.NoError(t, err)
+
+		_, err = CalcBatchSwap([]Step{
+			{Pool: poolAB, TokenIn: tokenA, TokenOut: tokenB},
+			{Pool: pausedPool, TokenIn: tokenB, TokenOut: tokenC},
+		}, amountIn)
+		assert.ErrorIs(t, err, stable.ErrPoolPaused)
+
+		_, err = CalcBatchSwap([]Step{{Pool: pausedPool, TokenIn: tokenB, TokenOut: pausedPool.GetAddress()}},
+			amountIn)
+		assert.ErrorIs(t, err, stable.ErrPoolPaused)
+	})
+}
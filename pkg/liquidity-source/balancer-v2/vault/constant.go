@@ -0,0 +1,23 @@
+package vault
+
+import "errors"
+
+const (
+	// singleSwapGas is the gas of a single Vault swap, as charged by the weighted and stable pool simulators
+	singleSwapGas int64 = 80535
+	// batchSwapGas is the gas of the Vault's batchSwap paid once per batch, estimated as the settlement of the net
+	// token deltas: pulling the token in and sending the token out, about 22500 gas for each ERC20 transfer
+	batchSwapGas int64 = 45000
+	// swapStepGas is the gas of each swap step of a batch swap on top of batchSwapGas, so that a batch of one step
+	// costs a single swap
+	swapStepGas = singleSwapGas - batchSwapGas
+)
+
+var (
+	ErrEmptyBatchSwap   = errors.New("batch swap has no steps")
+	ErrInvalidAmountIn  = errors.New("invalid amount in")
+	ErrDisconnectedStep = errors.New("step token in is not the previous step token out")
+	ErrPoolNotCloneable = errors.New("pool used by more than one step can not be cloned")
+	ErrVaultMismatch    = errors.New("pools are not in the same vault")
+	ErrTokenNotInPool   = errors.New("token is not in pool")
+)
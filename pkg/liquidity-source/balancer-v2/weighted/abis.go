@@ -7,7 +7,9 @@ import (
 )
 
 var (
-	poolABI abi.ABI
+	poolABI                       abi.ABI
+	liquidityBootstrappingPoolABI abi.ABI
+	managedPoolABI                abi.ABI
 )
 
 func init() {
@@ -16,6 +18,8 @@ func init() {
 		data []byte
 	}{
 		{&poolABI, poolJson},
+		{&liquidityBootstrappingPoolABI, liquidityBootstrappingPoolJson},
+		{&managedPoolABI, managedPoolJson},
 	}
 
 	for _, b := range builder {
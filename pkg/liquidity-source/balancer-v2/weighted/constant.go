@@ -3,19 +3,28 @@ package weighted
 const (
 	DexType = "balancer-v2-weighted"
 
-	poolTypeLegacyWeighted = "Weighted"
-	poolTypeWeighted       = "WEIGHTED"
+	poolTypeLegacyWeighted               = "Weighted"
+	poolTypeWeighted                     = "WEIGHTED"
+	poolTypeLegacyLiquidityBootstrapping = "LiquidityBootstrapping"
+	poolTypeLiquidityBootstrapping       = "LIQUIDITY_BOOTSTRAPPING"
+	poolTypeLegacyManaged                = "Managed"
+	poolTypeManaged                      = "MANAGED"
 
 	poolTypeVer1 = 1
 
 	poolMethodGetSwapFeePercentage = "getSwapFeePercentage"
 	poolMethodGetPausedState       = "getPausedState"
+	poolMethodInRecoveryMode       = "inRecoveryMode"
 	poolMethodGetVault             = "getVault"
 
 	poolMethodTotalSupply      = "totalSupply"
 	poolMethodGetInvariant     = "getInvariant"
 	poolMethodGetLastInvariant = "getLastInvariant"
 
+	poolMethodGetGradualWeightUpdateParams = "getGradualWeightUpdateParams"
+	poolMethodGetNormalizedWeights         = "getNormalizedWeights"
+	poolMethodGetSwapEnabled               = "getSwapEnabled"
+
 	protocolMethodGetSwapFeePercentage = "getSwapFeePercentage"
 )
 
@@ -4,3 +4,9 @@ import _ "embed"
 
 //go:embed abis/WeightedPool.json
 var poolJson []byte
+
+//go:embed abis/LiquidityBootstrappingPool.json
+var liquidityBootstrappingPoolJson []byte
+
+//go:embed abis/ManagedPool.json
+var managedPoolJson []byte
@@ -2,7 +2,10 @@ package weighted
 
 import (
 	"errors"
+	"maps"
 	"math/big"
+	"slices"
+	"time"
 
 	"github.com/KyberNetwork/blockchain-toolkit/number"
 	"github.com/goccy/go-json"
@@ -27,18 +30,23 @@ var (
 	ErrMaxTotalOutRatio           = errors.New("MAX_TOTAL_OUT_RATIO")
 	ErrOverflow                   = errors.New("OVERFLOW")
 	ErrBatchSwapDisabled          = errors.New("batch swap is disabled")
+	ErrSwapDisabled               = errors.New("SWAPS_DISABLED")
+	ErrInvalidWeightUpdate        = errors.New("invalid gradual weight update")
 )
 
 type (
 	PoolSimulator struct {
 		pool.Pool
-		basePools map[string]shared.IBasePool
-		paused    bool
+		basePools      map[string]shared.IBasePool
+		paused         bool
+		inRecoveryMode bool
+		swapDisabled   bool
 
 		swapFeePercentage         *uint256.Int
 		protocolSwapFeePercentage *uint256.Int
 		scalingFactors            []*uint256.Int
 		normalizedWeights         []*uint256.Int
+		gradualWeightUpdate       *GradualWeightUpdate
 		lastInvariant             *uint256.Int
 		totalSupply               *uint256.Int
 
@@ -87,6 +95,11 @@ func NewPoolSimulator(entityPool entity.Pool, basePoolMap map[string]pool.IPoolS
 		return nil, err
 	}
 
+	if gwu := extra.GradualWeightUpdate; gwu != nil &&
+		(len(gwu.StartWeights) != len(entityPool.Tokens) || len(gwu.EndWeights) != len(entityPool.Tokens)) {
+		return nil, ErrInvalidWeightUpdate
+	}
+
 	var basePools = make(map[string]shared.IBasePool, len(staticExtra.BasePools))
 	if basePoolMap != nil {
 		for basePool := range staticExtra.BasePools {
@@ -135,12 +148,15 @@ func NewPoolSimulator(entityPool entity.Pool, basePoolMap map[string]pool.IPoolS
 		Pool:                      pool.Pool{Info: poolInfo},
 		basePools:                 basePools,
 		paused:                    extra.Paused,
+		inRecoveryMode:            extra.InRecoveryMode,
+		swapDisabled:              extra.SwapDisabled,
 		swapFeePercentage:         extra.SwapFeePercentage,
 		protocolSwapFeePercentage: extra.ProtocolSwapFeePercentage,
 		totalSupply:               extra.TotalSupply,
 		lastInvariant:             extra.LastInvariant,
 		scalingFactors:            staticExtra.ScalingFactors,
 		normalizedWeights:         staticExtra.NormalizedWeights,
+		gradualWeightUpdate:       extra.GradualWeightUpdate,
 		vault:                     staticExtra.Vault,
 		poolID:                    staticExtra.PoolID,
 		poolTypeVer:               staticExtra.PoolTypeVer,
@@ -159,6 +175,12 @@ func (s *PoolSimulator) GetPoolId() string {
 }
 
 func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	} else if s.swapDisabled {
+		return nil, ErrSwapDisabled
+	}
+
 	var scaledAmountsIn = make([]*uint256.Int, len(s.GetReserves()))
 
 	indexIn := s.GetTokenIndex(tokenIn)
@@ -179,12 +201,15 @@ func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.
 		}
 	}
 
-	err = chargeDueProtocolFee(s.poolTypeVer, scaledBalances, s.normalizedWeights, s.lastInvariant, s.protocolSwapFeePercentage)
-	if err != nil {
-		return nil, err
+	normalizedWeights := s.getNormalizedWeights()
+	if !s.inRecoveryMode {
+		err = chargeDueProtocolFee(s.poolTypeVer, scaledBalances, normalizedWeights, s.lastInvariant, s.protocolSwapFeePercentage)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	bptAmountOut, err := calcBptOutGivenExactTokensIn(s.poolTypeVer, scaledAmountsIn, scaledBalances, s.normalizedWeights, s.totalSupply, s.swapFeePercentage)
+	bptAmountOut, err := calcBptOutGivenExactTokensIn(s.poolTypeVer, scaledAmountsIn, scaledBalances, normalizedWeights, s.totalSupply, s.swapFeePercentage)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +217,12 @@ func (s *PoolSimulator) OnJoin(tokenIn string, amountIn *uint256.Int) (*uint256.
 	return bptAmountOut, nil
 }
 
+// OnExit quotes a single token exit, which the pool only allows when it is not paused
 func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	}
+
 	indexOut := s.GetTokenIndex(tokenOut)
 
 	scaledBalances, err := _upscaleArray(s.poolTypeVer, s.GetReserves(), s.scalingFactors)
@@ -200,15 +230,16 @@ func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint
 		return nil, err
 	}
 
-	if !s.paused {
-		err = chargeDueProtocolFee(s.poolTypeVer, scaledBalances, s.normalizedWeights, s.lastInvariant, s.protocolSwapFeePercentage)
+	normalizedWeights := s.getNormalizedWeights()
+	if !s.inRecoveryMode {
+		err = chargeDueProtocolFee(s.poolTypeVer, scaledBalances, normalizedWeights, s.lastInvariant, s.protocolSwapFeePercentage)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	amountOut, err := calcTokenOutGivenExactBptIn(s.poolTypeVer, bptAmountIn, scaledBalances[indexOut],
-		s.normalizedWeights[indexOut], s.totalSupply, s.swapFeePercentage)
+		normalizedWeights[indexOut], s.totalSupply, s.swapFeePercentage)
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +253,12 @@ func (s *PoolSimulator) OnExit(tokenOut string, bptAmountIn *uint256.Int) (*uint
 }
 
 func (s *PoolSimulator) OnSwap(tokenIn, tokenOut string, amountIn *uint256.Int) (*uint256.Int, error) {
+	if s.paused {
+		return nil, ErrPoolPaused
+	} else if s.swapDisabled {
+		return nil, ErrSwapDisabled
+	}
+
 	indexIn, indexOut := s.GetTokenIndex(tokenIn), s.GetTokenIndex(tokenOut)
 
 	balanceIn, overflow := uint256.FromBig(s.Pool.Info.Reserves[indexIn])
@@ -263,11 +300,12 @@ func (s *PoolSimulator) OnSwap(tokenIn, tokenOut string, amountIn *uint256.Int)
 		return nil, err
 	}
 
+	normalizedWeightIn, normalizedWeightOut := s.getNormalizedWeightPair(indexIn, indexOut)
 	amountOut, err := s._onSwapGivenIn(
 		scaledBalanceIn,
-		s.normalizedWeights[indexIn],
+		normalizedWeightIn,
 		scaledBalanceOut,
-		s.normalizedWeights[indexOut],
+		normalizedWeightOut,
 		scaledAmountIn,
 	)
 	if err != nil {
@@ -557,6 +595,8 @@ func (s *PoolSimulator) getBasePool(token string) (shared.IBasePool, error) {
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
 	if s.paused {
 		return nil, ErrPoolPaused
+	} else if s.swapDisabled {
+		return nil, ErrSwapDisabled
 	}
 
 	tokenAmountIn, tokenOut := params.TokenAmountIn, params.TokenOut
@@ -589,6 +629,8 @@ func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 func (s *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
 	if s.paused {
 		return nil, ErrPoolPaused
+	} else if s.swapDisabled {
+		return nil, ErrSwapDisabled
 	}
 
 	tokenAmountOut := params.TokenAmountOut
@@ -621,8 +663,7 @@ func (s *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.Calc
 
 	scalingFactorTokenIn := s.scalingFactors[indexIn]
 	scalingFactorTokenOut := s.scalingFactors[indexOut]
-	normalizedWeightIn := s.normalizedWeights[indexIn]
-	normalizedWeightOut := s.normalizedWeights[indexOut]
+	normalizedWeightIn, normalizedWeightOut := s.getNormalizedWeightPair(indexIn, indexOut)
 
 	balanceTokenIn, err := _upscale(s.poolTypeVer, reserveIn, scalingFactorTokenIn)
 	if err != nil {
@@ -743,6 +784,61 @@ func (s *PoolSimulator) _addSwapFeeAmount(amount *uint256.Int) (*uint256.Int, er
 	return math.FixedPoint.DivUp(amount, math.FixedPoint.Complement(s.swapFeePercentage))
 }
 
+// getNormalizedWeights returns the weights of the pool at the current time
+func (s *PoolSimulator) getNormalizedWeights() []*uint256.Int {
+	if s.gradualWeightUpdate == nil {
+		return s.normalizedWeights
+	}
+
+	pctProgress := s.gradualWeightUpdate.progress(uint64(time.Now().Unix()))
+	normalizedWeights := make([]*uint256.Int, len(s.gradualWeightUpdate.StartWeights))
+	for i := range normalizedWeights {
+		normalizedWeights[i] = s.gradualWeightUpdate.interpolateWeight(i, pctProgress)
+	}
+	return normalizedWeights
+}
+
+func (s *PoolSimulator) getNormalizedWeightPair(indexIn, indexOut int) (*uint256.Int, *uint256.Int) {
+	if s.gradualWeightUpdate == nil {
+		return s.normalizedWeights[indexIn], s.normalizedWeights[indexOut]
+	}
+
+	pctProgress := s.gradualWeightUpdate.progress(uint64(time.Now().Unix()))
+	return s.gradualWeightUpdate.interpolateWeight(indexIn, pctProgress),
+		s.gradualWeightUpdate.interpolateWeight(indexOut, pctProgress)
+}
+
+// progress returns the progress of the weight change at currentTime, as a fixed point percentage.
+//
+// https://github.com/balancer/balancer-v2-monorepo/blob/master/pkg/pool-weighted/contracts/lib/GradualValueChange.sol
+func (u *GradualWeightUpdate) progress(currentTime uint64) *uint256.Int {
+	if currentTime >= u.EndTime {
+		return math.FixedPoint.ONE
+	} else if currentTime <= u.StartTime {
+		return number.Zero
+	}
+
+	pctProgress, _ := math.FixedPoint.DivDown(uint256.NewInt(currentTime-u.StartTime),
+		uint256.NewInt(u.EndTime-u.StartTime))
+	return pctProgress
+}
+
+func (u *GradualWeightUpdate) interpolateWeight(index int, pctProgress *uint256.Int) *uint256.Int {
+	startWeight, endWeight := u.StartWeights[index], u.EndWeights[index]
+	if !pctProgress.Lt(math.FixedPoint.ONE) || startWeight.Eq(endWeight) {
+		return endWeight
+	} else if pctProgress.IsZero() {
+		return startWeight
+	}
+
+	if startWeight.Gt(endWeight) {
+		delta, _ := math.FixedPoint.MulDown(pctProgress, new(uint256.Int).Sub(startWeight, endWeight))
+		return delta.Sub(startWeight, delta)
+	}
+	delta, _ := math.FixedPoint.MulDown(pctProgress, new(uint256.Int).Sub(endWeight, startWeight))
+	return delta.Add(startWeight, delta)
+}
+
 func (s *PoolSimulator) validateMaxInRatio(tokenIndex int, amountIn *uint256.Int) error {
 	sum := new(uint256.Int).Add(s.totalAmountsIn[tokenIndex], amountIn)
 	upscaledSum, err := _upscale(s.poolTypeVer, sum, s.scalingFactors[tokenIndex])
@@ -771,6 +867,15 @@ func (s *PoolSimulator) validateMaxOutRatio(tokenIndex int, amountOut *uint256.I
 	return nil
 }
 
+func (s *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := *s
+	cloned.Info.Reserves = slices.Clone(s.Info.Reserves)
+	cloned.basePools = shared.CloneBasePools(s.basePools)
+	cloned.totalAmountsIn = slices.Clone(s.totalAmountsIn)
+	cloned.totalAmountsOut = slices.Clone(s.totalAmountsOut)
+	return &cloned
+}
+
 func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	if params.SwapInfo == nil {
 		s.updateBalance(params.TokenAmountIn.Token, params.TokenAmountOut.Token,
@@ -895,20 +1000,13 @@ func (s *PoolSimulator) CanSwapTo(address string) []string {
 	return lo.Keys(result)
 }
 
+// GetTokens returns the tokens of the pool in order followed by the tokens of its base pools, in a stable order
 func (s *PoolSimulator) GetTokens() []string {
-	tokenSet := make(map[string]struct{})
-
-	for _, basePool := range s.basePools {
-		for _, token := range basePool.GetTokens() {
-			tokenSet[token] = struct{}{}
-		}
+	tokens := slices.Clone(s.GetInfo().Tokens)
+	for _, basePool := range slices.Sorted(maps.Keys(s.basePools)) {
+		tokens = append(tokens, s.basePools[basePool].GetTokens()...)
 	}
-
-	for _, token := range s.GetInfo().Tokens {
-		tokenSet[token] = struct{}{}
-	}
-
-	return lo.Keys(tokenSet)
+	return lo.Uniq(tokens)
 }
 
 func (s *PoolSimulator) GetBasePools() []pool.IPoolSimulator {
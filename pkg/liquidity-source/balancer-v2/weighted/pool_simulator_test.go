@@ -3,10 +3,12 @@ package weighted
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
@@ -238,6 +240,7 @@ func Test_CalcAmountOut(t *testing.T) {
 		// calculation
 		simulator, err := NewPoolSimulator(pool, nil)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 		amountIn, _ := new(big.Int).SetString("2000000000000000000000", 10)
 		result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 			return simulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
@@ -767,3 +770,103 @@ func TestPoolSimulator_CalcAmountIn(t *testing.T) {
 		})
 	}
 }
+
+func TestGradualWeightUpdate_Interpolate(t *testing.T) {
+	t.Parallel()
+	u := GradualWeightUpdate{
+		StartTime:    1000,
+		EndTime:      2000,
+		StartWeights: []*uint256.Int{uint256.NewInt(9e17), uint256.NewInt(1e17)},
+		EndWeights:   []*uint256.Int{uint256.NewInt(3e17), uint256.NewInt(7e17)},
+	}
+
+	for _, tc := range []struct {
+		currentTime uint64
+		want        []uint64
+	}{
+		{500, []uint64{9e17, 1e17}},
+		{1000, []uint64{9e17, 1e17}},
+		{1250, []uint64{75e16, 25e16}},
+		{1500, []uint64{6e17, 4e17}},
+		{2000, []uint64{3e17, 7e17}},
+		{3000, []uint64{3e17, 7e17}},
+	} {
+		pctProgress := u.progress(tc.currentTime)
+		for i, want := range tc.want {
+			assert.Equal(t, want, u.interpolateWeight(i, pctProgress).Uint64(), "time %d token %d", tc.currentTime, i)
+		}
+	}
+}
+
+func TestPoolSimulator_GradualWeightUpdate(t *testing.T) {
+	t.Parallel()
+	now := uint64(time.Now().Unix())
+	newPool := func(extra Extra, normalizedWeights []*uint256.Int) *PoolSimulator {
+		extra.SwapFeePercentage = uint256.NewInt(1e16)
+		extraBytes, _ := json.Marshal(extra)
+		staticExtraBytes, _ := json.Marshal(StaticExtra{
+			PoolType:          poolTypeLiquidityBootstrapping,
+			PoolTypeVer:       2,
+			ScalingFactors:    []*uint256.Int{uint256.NewInt(1e18), uint256.NewInt(1e18)},
+			NormalizedWeights: normalizedWeights,
+		})
+		p, err := NewPoolSimulator(entity.Pool{
+			Address:     "0x4ea95c7b76ad8ee1d4cc4b23a3a0a0c9f3c1e4f0",
+			Tokens:      []*entity.PoolToken{{Address: "a"}, {Address: "b"}},
+			Reserves:    []string{"1000000000000000000000", "2000000000000000000000"},
+			Extra:       string(extraBytes),
+			StaticExtra: string(staticExtraBytes),
+		}, nil)
+		require.NoError(t, err)
+		return p
+	}
+	quote := func(p *PoolSimulator) (*big.Int, error) {
+		res, err := p.CalcAmountOut(poolpkg.CalcAmountOutParams{
+			TokenAmountIn: poolpkg.TokenAmount{Token: "a", Amount: big.NewInt(1e18)},
+			TokenOut:      "b",
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.TokenAmountOut.Amount, nil
+	}
+
+	startWeights := []*uint256.Int{uint256.NewInt(9e17), uint256.NewInt(1e17)}
+	endWeights := []*uint256.Int{uint256.NewInt(2e17), uint256.NewInt(8e17)}
+	staleWeights := []*uint256.Int{uint256.NewInt(5e17), uint256.NewInt(5e17)}
+
+	t.Run("not started", func(t *testing.T) {
+		got, err := quote(newPool(Extra{GradualWeightUpdate: &GradualWeightUpdate{
+			StartTime: now + 3600, EndTime: now + 7200, StartWeights: startWeights, EndWeights: endWeights,
+		}}, staleWeights))
+		require.NoError(t, err)
+		want, err := quote(newPool(Extra{}, startWeights))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("ended", func(t *testing.T) {
+		got, err := quote(newPool(Extra{GradualWeightUpdate: &GradualWeightUpdate{
+			StartTime: now - 7200, EndTime: now - 3600, StartWeights: startWeights, EndWeights: endWeights,
+		}}, staleWeights))
+		require.NoError(t, err)
+		want, err := quote(newPool(Extra{}, endWeights))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		got, err := quote(newPool(Extra{GradualWeightUpdate: &GradualWeightUpdate{
+			StartTime: now - 3600, EndTime: now + 3600, StartWeights: startWeights, EndWeights: endWeights,
+		}}, staleWeights))
+		require.NoError(t, err)
+		atStart, _ := quote(newPool(Extra{}, startWeights))
+		atEnd, _ := quote(newPool(Extra{}, endWeights))
+		assert.True(t, got.Cmp(atStart) < 0 && got.Cmp(atEnd) > 0, "%s not between %s and %s", got, atStart, atEnd)
+	})
+
+	t.Run("swap disabled", func(t *testing.T) {
+		_, err := quote(newPool(Extra{SwapDisabled: true}, staleWeights))
+		assert.ErrorIs(t, err, ErrSwapDisabled)
+	})
+}
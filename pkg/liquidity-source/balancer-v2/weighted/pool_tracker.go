@@ -19,7 +19,10 @@ import (
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 )
 
-var ErrReserveNotFound = errors.New("reserve not found")
+var (
+	ErrReserveNotFound = errors.New("reserve not found")
+	ErrWeightNotFound  = errors.New("weight not found")
+)
 
 type PoolTracker struct {
 	config       *shared.Config
@@ -69,8 +72,20 @@ func (t *PoolTracker) GetNewPoolState(
 	}
 
 	// call RPC
-	rpcRes, err := t.queryRPC(ctx, p.Address, staticExtra.PoolTypeVer, staticExtra.PoolID, staticExtra.Vault)
+	rpcRes, err := t.queryRPC(ctx, p.Address, staticExtra.PoolType, staticExtra.PoolTypeVer, staticExtra.PoolID,
+		staticExtra.Vault)
+	if err != nil {
+		return p, err
+	}
+
+	gradualWeightUpdate, err := t.initGradualWeightUpdate(p, rpcRes)
 	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexId":       t.config.DexID,
+			"dexType":     DexType,
+			"poolAddress": p.Address,
+		}).Error(err.Error())
+
 		return p, err
 	}
 
@@ -81,6 +96,9 @@ func (t *PoolTracker) GetNewPoolState(
 		LastInvariant:             rpcRes.LastInvariant,
 		TotalSupply:               rpcRes.TotalSupply,
 		Paused:                    !isNotPaused(rpcRes.PausedState),
+		InRecoveryMode:            rpcRes.InRecoveryMode,
+		SwapDisabled:              !rpcRes.SwapEnabled,
+		GradualWeightUpdate:       gradualWeightUpdate,
 	}
 	extraBytes, err := json.Marshal(extra)
 	if err != nil {
@@ -135,9 +153,53 @@ func (t *PoolTracker) initReserves(
 	return reserves, nil
 }
 
+// initGradualWeightUpdate aligns the weight change schedule of the pool with its tokens. Liquidity Bootstrapping pools
+// do not expose their start weights, so their schedule is restarted from the current weights, which is equivalent as
+// weights change linearly.
+func (t *PoolTracker) initGradualWeightUpdate(p entity.Pool, rpcRes *rpcRes) (*GradualWeightUpdate, error) {
+	params := rpcRes.GradualWeightUpdateParams
+	if params == nil {
+		return nil, nil
+	}
+
+	startTime, endTime := params.StartTime.Uint64(), params.EndTime.Uint64()
+	startWeights := params.StartWeights
+	if startWeights == nil {
+		now := uint64(time.Now().Unix())
+		startTime = max(startTime, min(now, endTime))
+		startWeights = rpcRes.NormalizedWeights
+	}
+
+	// weights are ordered as the tokens registered in the vault, excluding the pool's own BPT
+	weightIndexByToken := make(map[string]int, len(rpcRes.PoolTokens.Tokens))
+	for _, token := range rpcRes.PoolTokens.Tokens {
+		if addr := strings.ToLower(token.Hex()); addr != p.Address {
+			weightIndexByToken[addr] = len(weightIndexByToken)
+		}
+	}
+
+	gradualWeightUpdate := GradualWeightUpdate{
+		StartTime:    startTime,
+		EndTime:      endTime,
+		StartWeights: make([]*uint256.Int, len(p.Tokens)),
+		EndWeights:   make([]*uint256.Int, len(p.Tokens)),
+	}
+	for idx, token := range p.Tokens {
+		weightIdx, ok := weightIndexByToken[token.Address]
+		if !ok || weightIdx >= len(startWeights) || weightIdx >= len(params.EndWeights) {
+			return nil, ErrWeightNotFound
+		}
+		gradualWeightUpdate.StartWeights[idx] = uint256.MustFromBig(startWeights[weightIdx])
+		gradualWeightUpdate.EndWeights[idx] = uint256.MustFromBig(params.EndWeights[weightIdx])
+	}
+
+	return &gradualWeightUpdate, nil
+}
+
 func (t *PoolTracker) queryRPC(
 	ctx context.Context,
 	poolAddress string,
+	poolType string,
 	poolTypeVer int,
 	poolID string,
 	vault string,
@@ -147,8 +209,12 @@ func (t *PoolTracker) queryRPC(
 		swapFeePercentage         *big.Int
 		protocolSwapFeePercentage = poolpkg.ZeroBI
 		pausedState               PausedState
+		inRecoveryMode            bool
 		lastInvariant             *big.Int
 		totalSupply               *big.Int
+		swapEnabled               = true
+		gradualWeightUpdateParams *GradualWeightUpdateParams
+		normalizedWeights         []*big.Int
 	)
 
 	req := t.ethrpcClient.R().
@@ -186,6 +252,12 @@ func (t *PoolTracker) queryRPC(
 			Target: poolAddress,
 			Method: poolMethodGetInvariant,
 		}, []any{&lastInvariant})
+		// recovery mode came with the second version of the pools
+		req.AddCall(&ethrpc.Call{
+			ABI:    poolABI,
+			Target: poolAddress,
+			Method: poolMethodInRecoveryMode,
+		}, []any{&inRecoveryMode})
 	}
 
 	req.AddCall(&ethrpc.Call{
@@ -194,6 +266,39 @@ func (t *PoolTracker) queryRPC(
 		Method: poolMethodTotalSupply,
 	}, []any{&totalSupply})
 
+	switch poolType {
+	case poolTypeLiquidityBootstrapping, poolTypeLegacyLiquidityBootstrapping:
+		gradualWeightUpdateParams = &GradualWeightUpdateParams{}
+		req.AddCall(&ethrpc.Call{
+			ABI:    liquidityBootstrappingPoolABI,
+			Target: poolAddress,
+			Method: poolMethodGetGradualWeightUpdateParams,
+		}, []any{gradualWeightUpdateParams})
+		req.AddCall(&ethrpc.Call{
+			ABI:    liquidityBootstrappingPoolABI,
+			Target: poolAddress,
+			Method: poolMethodGetNormalizedWeights,
+		}, []any{&normalizedWeights})
+		req.AddCall(&ethrpc.Call{
+			ABI:    liquidityBootstrappingPoolABI,
+			Target: poolAddress,
+			Method: poolMethodGetSwapEnabled,
+		}, []any{&swapEnabled})
+
+	case poolTypeManaged, poolTypeLegacyManaged:
+		gradualWeightUpdateParams = &GradualWeightUpdateParams{}
+		req.AddCall(&ethrpc.Call{
+			ABI:    managedPoolABI,
+			Target: poolAddress,
+			Method: poolMethodGetGradualWeightUpdateParams,
+		}, []any{gradualWeightUpdateParams})
+		req.AddCall(&ethrpc.Call{
+			ABI:    managedPoolABI,
+			Target: poolAddress,
+			Method: poolMethodGetSwapEnabled,
+		}, []any{&swapEnabled})
+	}
+
 	if t.config.ProtocolFeesCollector != "" {
 		req.AddCall(&ethrpc.Call{
 			ABI:    shared.ProtocolFeesCollectorABI,
@@ -218,8 +323,12 @@ func (t *PoolTracker) queryRPC(
 		SwapFeePercentage:         uint256.MustFromBig(swapFeePercentage),
 		ProtocolSwapFeePercentage: uint256.MustFromBig(protocolSwapFeePercentage),
 		PausedState:               pausedState,
+		InRecoveryMode:            inRecoveryMode,
 		LastInvariant:             uint256.MustFromBig(lastInvariant),
 		TotalSupply:               uint256.MustFromBig(totalSupply),
+		SwapEnabled:               swapEnabled,
+		GradualWeightUpdateParams: gradualWeightUpdateParams,
+		NormalizedWeights:         normalizedWeights,
 		BlockNumber:               res.BlockNumber.Uint64(),
 	}, nil
 }
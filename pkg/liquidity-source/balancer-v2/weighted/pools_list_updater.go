@@ -36,9 +36,10 @@ func NewPoolsListUpdater(
 	graphqlClient *graphqlpkg.Client,
 ) *PoolsListUpdater {
 	if config.UseSubgraphV1 {
-		config.SubgraphPoolTypes = []string{poolTypeLegacyWeighted}
+		config.SubgraphPoolTypes = []string{poolTypeLegacyWeighted, poolTypeLegacyLiquidityBootstrapping,
+			poolTypeLegacyManaged}
 	} else {
-		config.SubgraphPoolTypes = []string{poolTypeWeighted}
+		config.SubgraphPoolTypes = []string{poolTypeWeighted, poolTypeLiquidityBootstrapping, poolTypeManaged}
 	}
 
 	sharedUpdater := shared.NewPoolsListUpdater(config, graphqlClient)
@@ -13,6 +13,19 @@ type Extra struct {
 	LastInvariant             *uint256.Int `json:"lastInvariant"`
 	TotalSupply               *uint256.Int `json:"totalSupply"`
 	Paused                    bool         `json:"paused"`
+	InRecoveryMode            bool         `json:"inRecoveryMode,omitempty"`
+	SwapDisabled              bool         `json:"swapDisabled,omitempty"`
+
+	GradualWeightUpdate *GradualWeightUpdate `json:"gradualWeightUpdate,omitempty"`
+}
+
+// GradualWeightUpdate is the linear weight change schedule of Liquidity Bootstrapping and Managed pools, with weights
+// ordered as the pool tokens
+type GradualWeightUpdate struct {
+	StartTime    uint64         `json:"startTime"`
+	EndTime      uint64         `json:"endTime"`
+	StartWeights []*uint256.Int `json:"startWeights"`
+	EndWeights   []*uint256.Int `json:"endWeights"`
 }
 
 type StaticExtra struct {
@@ -40,6 +53,15 @@ type PausedState struct {
 	BufferPeriodEndTime *big.Int
 }
 
+// GradualWeightUpdateParams is the result of getGradualWeightUpdateParams. Liquidity Bootstrapping pools do not return
+// start weights.
+type GradualWeightUpdateParams struct {
+	StartTime    *big.Int
+	EndTime      *big.Int
+	StartWeights []*big.Int
+	EndWeights   []*big.Int
+}
+
 type PoolMetaInfo struct {
 	Vault           string `json:"vault"`
 	PoolID          string `json:"poolId"`
@@ -53,7 +75,11 @@ type rpcRes struct {
 	SwapFeePercentage         *uint256.Int
 	ProtocolSwapFeePercentage *uint256.Int
 	PausedState               PausedState
+	InRecoveryMode            bool
 	LastInvariant             *uint256.Int
 	TotalSupply               *uint256.Int
+	SwapEnabled               bool
+	GradualWeightUpdateParams *GradualWeightUpdateParams
+	NormalizedWeights         []*big.Int
 	BlockNumber               uint64
 }
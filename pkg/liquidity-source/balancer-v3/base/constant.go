@@ -2,4 +2,7 @@ package base
 
 const (
 	bufferGas int64 = 120534
+	// erc4626Gas is the extra gas of wrapping or unwrapping through the ERC4626 vault when the vault buffer lacks
+	// liquidity
+	erc4626Gas int64 = 100000
 )
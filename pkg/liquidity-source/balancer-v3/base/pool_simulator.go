@@ -2,6 +2,7 @@ package base
 
 import (
 	"math/big"
+	"slices"
 
 	"github.com/KyberNetwork/logger"
 	"github.com/holiman/uint256"
@@ -30,26 +31,23 @@ type swapper interface {
 	OnSwap(param shared.PoolSwapParams) (*uint256.Int, error)
 }
 
-func NewPoolSimulator(entityPool entity.Pool, extra *shared.Extra, staticExtra *shared.StaticExtra, swapper swapper,
-	hook hooks.IHook) (*PoolSimulator,
-	error) {
+func NewPoolSimulator(entityPool entity.Pool, extra *shared.Extra, staticExtra *shared.StaticExtra,
+	swapper swapper) (*PoolSimulator, error) {
 	if extra == nil {
 		return nil, shared.ErrInvalidExtra
 	} else if extra.Buffers == nil {
 		extra.Buffers = make([]*shared.ExtraBuffer, len(entityPool.Tokens))
 	}
 
-	if hook == nil {
-		switch staticExtra.HookType {
-		case shared.DirectionalFeeHookType:
-			hook = hooks.NewDirectionalFeeHook()
-		case shared.FeeTakingHookType:
-			hook = hooks.NewFeeTakingHook()
-		case shared.VeBALFeeDiscountHookType:
-			hook = hooks.NewVeBALFeeDiscountHook()
-		default:
-			hook = hooks.NewNoOpHook()
-		}
+	hook, err := hooks.New(staticExtra, extra.HooksConfig, extra.HookState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"poolID":      entityPool.Address,
+			"hook":        staticExtra.Hook,
+			"hookType":    staticExtra.HookType,
+			"hooksConfig": extra.HooksConfig,
+		}).Errorf("failed to create hook: %v", err)
+		return nil, err
 	}
 
 	return &PoolSimulator{
@@ -86,10 +84,12 @@ func (p *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 		return nil, shared.ErrInvalidAmountIn
 	}
 
+	var swapInfo shared.SwapInfo
 	gas := p.BaseGas()
 	if bufferIn := p.buffers[indexIn]; bufferIn != nil {
+		assetsIn := amountIn.Clone()
 		amountIn = bufferIn.ConvertToShares(amountIn)
-		gas += bufferGas
+		gas += p.useBuffer(indexIn, true, assetsIn, amountIn, &swapInfo)
 	}
 
 	amountOut, totalSwapFee, aggregateFee, err := p.vault.Swap(shared.VaultSwapParams{
@@ -103,9 +103,11 @@ func (p *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 	}
 
 	if bufferOut := p.buffers[indexOut]; bufferOut != nil {
+		sharesOut := amountOut.Clone()
 		amountOut = bufferOut.ConvertToAssets(amountOut)
-		gas += bufferGas
+		gas += p.useBuffer(indexOut, false, amountOut, sharesOut, &swapInfo)
 	}
+	swapInfo.AggregateFee = aggregateFee.ToBig()
 
 	return &pool.CalcAmountOutResult{
 		TokenAmountOut: &pool.TokenAmount{
@@ -116,10 +118,8 @@ func (p *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 			Token:  tokenAmountIn.Token,
 			Amount: totalSwapFee.ToBig(),
 		},
-		SwapInfo: shared.SwapInfo{
-			AggregateFee: aggregateFee.ToBig(),
-		},
-		Gas: gas,
+		SwapInfo: swapInfo,
+		Gas:      gas,
 	}, nil
 }
 
@@ -136,10 +136,12 @@ func (p *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.Calc
 		return nil, shared.ErrInvalidAmountOut
 	}
 
+	var swapInfo shared.SwapInfo
 	gas := p.BaseGas()
 	if bufferOut := p.buffers[indexOut]; bufferOut != nil {
+		assetsOut := amountOut.Clone()
 		amountOut = bufferOut.ConvertToShares(amountOut)
-		gas += bufferGas
+		gas += p.useBuffer(indexOut, false, assetsOut, amountOut, &swapInfo)
 	}
 
 	amountIn, totalSwapFee, aggregateSwapFee, err := p.vault.Swap(shared.VaultSwapParams{
@@ -153,9 +155,11 @@ func (p *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.Calc
 	}
 
 	if bufferIn := p.buffers[indexIn]; bufferIn != nil {
+		sharesIn := amountIn.Clone()
 		amountIn = bufferIn.ConvertToAssets(amountIn)
-		gas += bufferGas
+		gas += p.useBuffer(indexIn, true, amountIn, sharesIn, &swapInfo)
 	}
+	swapInfo.AggregateFee = aggregateSwapFee.ToBig()
 
 	return &pool.CalcAmountInResult{
 		TokenAmountIn: &pool.TokenAmount{
@@ -166,16 +170,43 @@ func (p *PoolSimulator) CalcAmountIn(params pool.CalcAmountInParams) (*pool.Calc
 			Token:  tokenIn,
 			Amount: totalSwapFee.ToBig(),
 		},
-		SwapInfo: shared.SwapInfo{
-			AggregateFee: aggregateSwapFee.ToBig(),
-		},
-		Gas: gas,
+		SwapInfo: swapInfo,
+		Gas:      gas,
 	}, nil
 }
 
+// useBuffer wraps assets into shares (or unwraps shares into assets) with the vault buffer of the token at index and
+// returns the gas used. If the buffer lacks liquidity, the vault wraps through the ERC4626 vault at the same convert
+// rate for more gas, and rebalances the buffer with it.
+func (p *PoolSimulator) useBuffer(index int, isWrap bool, assets, shares *uint256.Int, swapInfo *shared.SwapInfo) int64 {
+	var (
+		next     *shared.ExtraBuffer
+		buffered bool
+	)
+	if isWrap {
+		next, buffered = p.buffers[index].Wrap(assets, shares)
+	} else {
+		next, buffered = p.buffers[index].Unwrap(shares, assets)
+	}
+	gas := bufferGas
+	if !buffered {
+		gas += erc4626Gas
+	}
+	if next == p.buffers[index] {
+		return gas
+	}
+
+	if swapInfo.Buffers == nil {
+		swapInfo.Buffers = make([]*shared.ExtraBuffer, len(p.buffers))
+	}
+	swapInfo.Buffers[index] = next
+	return gas
+}
+
 func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
 	cloned := *p
 	cloned.vault = p.vault.CloneState()
+	cloned.buffers = slices.Clone(p.buffers)
 	cloned.Info.Reserves = lo.Map(p.Info.Reserves, func(v *big.Int, i int) *big.Int {
 		return new(big.Int).Set(v)
 	})
@@ -192,6 +223,12 @@ func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 		return
 	}
 
+	for i, buffer := range swapInfo.Buffers {
+		if buffer != nil {
+			p.buffers[i] = buffer
+		}
+	}
+
 	updatedRawBalanceIn := new(big.Int)
 	updatedRawBalanceIn.Add(p.Info.Reserves[tokenIndexIn], params.TokenAmountIn.Amount)
 	updatedRawBalanceIn.Sub(updatedRawBalanceIn, swapInfo.AggregateFee)
@@ -31,7 +31,7 @@ func NewPoolSimulator(entityPool entity.Pool) (*base.PoolSimulator, error) {
 
 	return base.NewPoolSimulator(entityPool, extra.Extra, &staticExtra, &PoolSimulator{
 		eclpParams: extra.ECLPParams,
-	}, nil)
+	})
 }
 
 type PoolSimulator struct {
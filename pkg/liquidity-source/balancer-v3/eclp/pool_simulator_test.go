@@ -122,3 +122,8 @@ func TestPoolSimulator_CalcAmountIn(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, pool1())
+}
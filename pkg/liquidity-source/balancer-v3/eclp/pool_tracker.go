@@ -15,6 +15,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/hooks"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
@@ -91,18 +92,10 @@ func (t *PoolTracker) getNewPoolState(
 	extra.BalancesLiveScaled18 = shared.FromBigs(res.PoolData.BalancesLiveScaled18)
 	extra.DecimalScalingFactors = shared.FromBigs(res.PoolData.DecimalScalingFactors)
 	extra.TokenRates = shared.FromBigs(res.PoolData.TokenRates)
-	extra.Buffers = lo.Map(res.Buffers, func(v *shared.ExtraBufferRPC, _ int) *shared.ExtraBuffer {
-		if v == nil {
-			return nil
-		}
-		var totalAssets, totalSupply uint256.Int
-		totalAssets.SetFromBig(v.TotalAssets)
-		totalSupply.SetFromBig(v.TotalSupply)
-		return &shared.ExtraBuffer{
-			TotalAssets: totalAssets.AddUint64(&totalAssets, 1),
-			TotalSupply: totalSupply.Add(&totalSupply, shared.DecimalsOffsetPow),
-		}
-	})
+	extra.Buffers = lo.Map(res.Buffers, shared.ToExtraBuffer)
+	if res.HookState != nil {
+		extra.HookState, _ = json.Marshal(res.HookState)
+	}
 	extra.ECLPParams = res.ECLPParamsRpc.toInt256()
 
 	extraBytes, err := json.Marshal(extra)
@@ -115,7 +108,7 @@ func (t *PoolTracker) getNewPoolState(
 	p.Extra = string(extraBytes)
 	p.Timestamp = time.Now().Unix()
 
-	if !res.IsPoolDisabled && shared.IsHookSupported(staticExtra.HookType) {
+	if !res.IsPoolDisabled && hooks.IsSupported(&staticExtra, extra.HooksConfig) {
 		p.Reserves = lo.Map(res.PoolData.BalancesRaw, func(v *big.Int, _ int) string { return v.String() })
 	} else { // set all reserves to 0 to disable pool temporarily
 		p.Reserves = lo.Map(p.Reserves, func(_ string, _ int) string { return "0" })
@@ -179,6 +172,10 @@ func (t *PoolTracker) queryRPCData(ctx context.Context, poolAddress string, stat
 		Method: poolMethodGetECLPParams,
 	}, []any{&rpcRes.ECLPParamsRpc})
 
+	if rpcRes.HookState = hooks.NewState(&staticExtra); rpcRes.HookState != nil {
+		rpcRes.HookState.AddCalls(req, staticExtra.Hook, poolAddress)
+	}
+
 	for i, token := range staticExtra.BufferTokens {
 		if token != "" {
 			rpcRes.Buffers[i] = &shared.ExtraBufferRPC{}
@@ -190,7 +187,12 @@ func (t *PoolTracker) queryRPCData(ctx context.Context, poolAddress string, stat
 				ABI:    shared.ERC4626ABI,
 				Target: token,
 				Method: shared.ERC4626MethodTotalSupply,
-			}, []any{&rpcRes.Buffers[i].TotalSupply})
+			}, []any{&rpcRes.Buffers[i].TotalSupply}).AddCall(&ethrpc.Call{
+				ABI:    shared.VaultExplorerABI,
+				Target: t.config.VaultExplorer,
+				Method: shared.VaultMethodGetBufferBalance,
+				Params: []any{common.HexToAddress(token)},
+			}, []any{&rpcRes.Buffers[i].BufferBalance})
 		}
 	}
 
@@ -0,0 +1,6 @@
+package hooks
+
+const (
+	stableSurgeHookMethodGetMaxSurgeFeePercentage    = "getMaxSurgeFeePercentage"
+	stableSurgeHookMethodGetSurgeThresholdPercentage = "getSurgeThresholdPercentage"
+)
@@ -7,6 +7,10 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
 )
 
+var _ = Register(shared.DirectionalFeeHookType, func(shared.HookState) (IHook, error) {
+	return NewDirectionalFeeHook(), nil
+}, nil)
+
 type DirectionalFeeHook struct {
 	NoOpHook
 }
@@ -0,0 +1,6 @@
+package hooks
+
+import _ "embed"
+
+//go:embed abis/StableSurgeHook.json
+var stableSurgeJson []byte
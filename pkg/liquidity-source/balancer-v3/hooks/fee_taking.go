@@ -1,5 +1,11 @@
 package hooks
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
+
+var _ = Register(shared.FeeTakingHookType, func(shared.HookState) (IHook, error) {
+	return NewFeeTakingHook(), nil
+}, nil)
+
 type FeeTakingHook struct {
 	NoOpHook
 }
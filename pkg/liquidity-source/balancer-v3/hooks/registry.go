@@ -0,0 +1,96 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
+)
+
+var (
+	ErrUnsupportedHook  = errors.New("unsupported hook")
+	ErrInvalidHookState = errors.New("invalid hook state")
+)
+
+// Factory creates a hook from its on-chain state, which is nil for hooks without state.
+type Factory func(state shared.HookState) (IHook, error)
+
+type registration struct {
+	newHook  Factory
+	newState func() shared.HookState
+}
+
+var (
+	registrations = map[shared.HookType]registration{}
+	hookTypes     = map[string]shared.HookType{}
+)
+
+// Register registers the factory of a hook type. newState creates the empty state that pool trackers fetch for the
+// hook, and is nil for hooks without on-chain state.
+func Register(hookType shared.HookType, newHook Factory, newState func() shared.HookState) bool {
+	registrations[hookType] = registration{newHook: newHook, newState: newState}
+	return true
+}
+
+// RegisterAddress registers a deployed hook contract as a hook type, taking precedence over the hook type reported by
+// the subgraph.
+func RegisterAddress(address string, hookType shared.HookType) bool {
+	hookTypes[strings.ToLower(address)] = hookType
+	return true
+}
+
+func lookup(staticExtra *shared.StaticExtra) (registration, bool) {
+	hookType := staticExtra.HookType
+	if t, ok := hookTypes[strings.ToLower(staticExtra.Hook)]; ok {
+		hookType = t
+	}
+	reg, ok := registrations[hookType]
+	return reg, ok
+}
+
+// affectsSwaps returns whether the vault calls the hook during swaps.
+func affectsSwaps(hooksConfig shared.HooksConfig) bool {
+	return hooksConfig.ShouldCallComputeDynamicSwapFee || hooksConfig.ShouldCallBeforeSwap ||
+		hooksConfig.ShouldCallAfterSwap
+}
+
+// IsSupported returns whether swaps of a pool can be simulated with its hook. Unregistered hooks are only supported if
+// the vault does not call them during swaps.
+func IsSupported(staticExtra *shared.StaticExtra, hooksConfig shared.HooksConfig) bool {
+	_, ok := lookup(staticExtra)
+	return ok || !affectsSwaps(hooksConfig)
+}
+
+// NewState returns the empty state of the hook of a pool for pool trackers to fetch, or nil if the hook has none.
+func NewState(staticExtra *shared.StaticExtra) shared.HookState {
+	if reg, ok := lookup(staticExtra); ok && reg.newState != nil {
+		return reg.newState()
+	}
+	return nil
+}
+
+// New creates the hook of a pool from its JSON-encoded state fetched by the pool tracker.
+func New(staticExtra *shared.StaticExtra, hooksConfig shared.HooksConfig, state []byte) (IHook, error) {
+	reg, ok := lookup(staticExtra)
+	if !ok {
+		if affectsSwaps(hooksConfig) {
+			return nil, fmt.Errorf("%w: %s %s", ErrUnsupportedHook, staticExtra.HookType, staticExtra.Hook)
+		}
+		return NewNoOpHook(), nil
+	}
+
+	var hookState shared.HookState
+	if reg.newState != nil {
+		if len(state) == 0 {
+			return nil, ErrInvalidHookState
+		}
+		hookState = reg.newState()
+		if err := json.Unmarshal(state, hookState); err != nil {
+			return nil, err
+		}
+	}
+	return reg.newHook(hookState)
+}
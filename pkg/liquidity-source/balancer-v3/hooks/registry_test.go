@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
+)
+
+func TestNew(t *testing.T) {
+	swapHooksConfig := shared.HooksConfig{ShouldCallComputeDynamicSwapFee: true}
+
+	t.Run("no hook", func(t *testing.T) {
+		hook, err := New(&shared.StaticExtra{}, shared.HooksConfig{}, nil)
+		require.NoError(t, err)
+		assert.IsType(t, &NoOpHook{}, hook)
+	})
+
+	t.Run("stateless hook", func(t *testing.T) {
+		staticExtra := &shared.StaticExtra{Hook: "0x1", HookType: shared.DirectionalFeeHookType}
+		assert.Nil(t, NewState(staticExtra))
+		hook, err := New(staticExtra, swapHooksConfig, nil)
+		require.NoError(t, err)
+		assert.IsType(t, &DirectionalFeeHook{}, hook)
+	})
+
+	t.Run("hook with state", func(t *testing.T) {
+		staticExtra := &shared.StaticExtra{Hook: "0x2", HookType: shared.StableSurgeHookType}
+		assert.IsType(t, &StableSurgeHookState{}, NewState(staticExtra))
+
+		_, err := New(staticExtra, swapHooksConfig, nil)
+		assert.ErrorIs(t, err, ErrInvalidHookState)
+
+		hook, err := New(staticExtra, swapHooksConfig, []byte(`{"max":50000000000000000,"thres":20000000000000000}`))
+		require.NoError(t, err)
+		assert.Equal(t, "50000000000000000", hook.(*StableSurgeHook).MaxSurgeFeePercentage.Dec())
+		assert.Equal(t, "20000000000000000", hook.(*StableSurgeHook).ThresholdPercentage.Dec())
+	})
+
+	t.Run("unknown hook", func(t *testing.T) {
+		staticExtra := &shared.StaticExtra{Hook: "0x3", HookType: "MEV_TAX"}
+		assert.False(t, IsSupported(staticExtra, swapHooksConfig))
+		_, err := New(staticExtra, swapHooksConfig, nil)
+		assert.ErrorIs(t, err, ErrUnsupportedHook)
+
+		assert.True(t, IsSupported(staticExtra, shared.HooksConfig{}))
+		hook, err := New(staticExtra, shared.HooksConfig{}, nil)
+		require.NoError(t, err)
+		assert.IsType(t, &NoOpHook{}, hook)
+	})
+
+	t.Run("hook deployment", func(t *testing.T) {
+		// the subgraph does not report a hook type for the Ethereum stable surge hook
+		staticExtra := &shared.StaticExtra{Hook: "0xB18FA0CB5DE8CECB8899AAE6E38B1B7ED77885DA"}
+		assert.True(t, IsSupported(staticExtra, swapHooksConfig))
+		assert.IsType(t, &StableSurgeHookState{}, NewState(staticExtra))
+
+		hook, err := New(staticExtra, swapHooksConfig, []byte(`{"max":50000000000000000,"thres":20000000000000000}`))
+		require.NoError(t, err)
+		assert.IsType(t, &StableSurgeHook{}, hook)
+	})
+
+	t.Run("hook registered by address", func(t *testing.T) {
+		RegisterAddress("0xABCD", shared.VeBALFeeDiscountHookType)
+		t.Cleanup(func() { delete(hookTypes, "0xabcd") })
+
+		hook, err := New(&shared.StaticExtra{Hook: "0xabcd"}, swapHooksConfig, nil)
+		require.NoError(t, err)
+		assert.IsType(t, &VeBALFeeDiscountHook{}, hook)
+	})
+}
@@ -2,8 +2,11 @@ package hooks
 
 import (
 	"errors"
+	"math/big"
 	"slices"
 
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/holiman/uint256"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/math"
@@ -17,6 +20,38 @@ var (
 	ErrMaxSurgeFeePercentageTooHigh = errors.New("maxSurgeFeePercentage too high")
 )
 
+var _ = Register(shared.StableSurgeHookType, func(state shared.HookState) (IHook, error) {
+	s := state.(*StableSurgeHookState)
+	if s.MaxSurgeFeePercentage == nil || s.SurgeThresholdPercentage == nil {
+		return nil, ErrInvalidHookState
+	}
+	return NewStableSurgeHook(shared.FromBig(s.MaxSurgeFeePercentage, 0), shared.FromBig(s.SurgeThresholdPercentage, 0))
+}, func() shared.HookState { return &StableSurgeHookState{} })
+
+// the StableSurgeHook deployment 20250121-v3-stable-surge-hook on Ethereum
+var _ = RegisterAddress("0xb18fa0cb5de8cecb8899aae6e38b1b7ed77885da", shared.StableSurgeHookType)
+
+// StableSurgeHookState is the surge fee configuration of a pool in the stable surge hook
+type StableSurgeHookState struct {
+	MaxSurgeFeePercentage    *big.Int `json:"max,omitempty"`
+	SurgeThresholdPercentage *big.Int `json:"thres,omitempty"`
+}
+
+func (s *StableSurgeHookState) AddCalls(req *ethrpc.Request, hook, pool string) {
+	params := []any{common.HexToAddress(pool)}
+	req.AddCall(&ethrpc.Call{
+		ABI:    stableSurgeABI,
+		Target: hook,
+		Method: stableSurgeHookMethodGetMaxSurgeFeePercentage,
+		Params: params,
+	}, []any{&s.MaxSurgeFeePercentage}).AddCall(&ethrpc.Call{
+		ABI:    stableSurgeABI,
+		Target: hook,
+		Method: stableSurgeHookMethodGetSurgeThresholdPercentage,
+		Params: params,
+	}, []any{&s.SurgeThresholdPercentage})
+}
+
 type StableSurgeHook struct {
 	NoOpHook
 
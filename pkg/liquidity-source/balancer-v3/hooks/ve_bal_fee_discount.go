@@ -1,5 +1,11 @@
 package hooks
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
+
+var _ = Register(shared.VeBALFeeDiscountHookType, func(shared.HookState) (IHook, error) {
+	return NewVeBALFeeDiscountHook(), nil
+}, nil)
+
 type VeBALFeeDiscountHook struct {
 	NoOpHook
 }
@@ -36,7 +36,7 @@ func NewPoolSimulator(entityPool entity.Pool) (*base.PoolSimulator, error) {
 		lastUpdateTime:    extra.LastUpdateTime,
 		lastInteropTime:   extra.LastInteropTime,
 		maxTradeSizeRatio: staticExtra.MaxTradeSizeRatio,
-	}, nil)
+	})
 }
 
 type PoolSimulator struct {
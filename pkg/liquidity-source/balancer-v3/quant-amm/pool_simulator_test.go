@@ -117,3 +117,8 @@ func TestCalcAmountIn(t *testing.T) {
 	t.Parallel()
 	testutil.TestCalcAmountIn(t, poolSim)
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, poolSim)
+}
@@ -1,9 +1,34 @@
 package shared
 
 import (
+	"math/big"
+
 	"github.com/holiman/uint256"
 )
 
+// ToExtraBuffer converts the ERC4626 state and vault buffer balances of a wrapped token fetched by RPC
+func ToExtraBuffer(v *ExtraBufferRPC, _ int) *ExtraBuffer {
+	if v == nil {
+		return nil
+	}
+	var totalAssets, totalSupply uint256.Int
+	totalAssets.SetFromBig(v.TotalAssets)
+	totalSupply.SetFromBig(v.TotalSupply)
+	return &ExtraBuffer{
+		TotalAssets:       totalAssets.AddUint64(&totalAssets, 1),
+		TotalSupply:       totalSupply.Add(&totalSupply, DecimalsOffsetPow),
+		UnderlyingBalance: fromBigOrNil(v.BufferBalance.UnderlyingBalanceRaw),
+		WrappedBalance:    fromBigOrNil(v.BufferBalance.WrappedBalanceRaw),
+	}
+}
+
+func fromBigOrNil(v *big.Int) *uint256.Int {
+	if v == nil {
+		return nil
+	}
+	return FromBig(v, 0)
+}
+
 func (b *ExtraBuffer) ConvertToShares(assets *uint256.Int) *uint256.Int {
 	assets.MulDivOverflow(assets, b.TotalSupply, b.TotalAssets)
 	return assets
@@ -13,3 +38,63 @@ func (b *ExtraBuffer) ConvertToAssets(shares *uint256.Int) *uint256.Int {
 	shares.MulDivOverflow(shares, b.TotalAssets, b.TotalSupply)
 	return shares
 }
+
+// Wrap returns the buffer after it wraps assets into shares, and whether the buffer served the wrap. The buffer serves
+// it only if it holds more shares than needed; otherwise the vault deposits the assets together with half the
+// underlying surplus of the buffer into the ERC4626 vault, rebalancing the buffer. Buffers without tracked balances are
+// assumed to be sufficient.
+func (b *ExtraBuffer) Wrap(assets, shares *uint256.Int) (*ExtraBuffer, bool) {
+	if b.UnderlyingBalance == nil || b.WrappedBalance == nil {
+		return b, true
+	}
+	next := *b
+	if b.WrappedBalance.Gt(shares) {
+		next.UnderlyingBalance = new(uint256.Int).Add(b.UnderlyingBalance, assets)
+		next.WrappedBalance = new(uint256.Int).Sub(b.WrappedBalance, shares)
+		return &next, true
+	}
+
+	// surplus = (underlying - previewRedeem(wrapped)) / 2, if positive
+	surplus := b.ConvertToAssets(b.WrappedBalance.Clone())
+	if !surplus.Lt(b.UnderlyingBalance) {
+		return b, false
+	}
+	surplus.Sub(b.UnderlyingBalance, surplus).Rsh(surplus, 1)
+	deposited := b.ConvertToShares(new(uint256.Int).Add(assets, surplus))
+	if deposited.Lt(shares) {
+		return b, false
+	}
+	next.UnderlyingBalance = new(uint256.Int).Sub(b.UnderlyingBalance, surplus)
+	next.WrappedBalance = deposited.Sub(deposited, shares).Add(deposited, b.WrappedBalance)
+	return &next, false
+}
+
+// Unwrap returns the buffer after it unwraps shares into assets, and whether the buffer served the unwrap. The buffer
+// serves it only if it holds more assets than needed; otherwise the vault redeems the shares together with half the
+// wrapped surplus of the buffer from the ERC4626 vault, rebalancing the buffer. Buffers without tracked balances are
+// assumed to be sufficient.
+func (b *ExtraBuffer) Unwrap(shares, assets *uint256.Int) (*ExtraBuffer, bool) {
+	if b.UnderlyingBalance == nil || b.WrappedBalance == nil {
+		return b, true
+	}
+	next := *b
+	if b.UnderlyingBalance.Gt(assets) {
+		next.UnderlyingBalance = new(uint256.Int).Sub(b.UnderlyingBalance, assets)
+		next.WrappedBalance = new(uint256.Int).Add(b.WrappedBalance, shares)
+		return &next, true
+	}
+
+	// surplus = (wrapped - previewDeposit(underlying)) / 2, if positive
+	surplus := b.ConvertToShares(b.UnderlyingBalance.Clone())
+	if !surplus.Lt(b.WrappedBalance) {
+		return b, false
+	}
+	surplus.Sub(b.WrappedBalance, surplus).Rsh(surplus, 1)
+	redeemed := b.ConvertToAssets(new(uint256.Int).Add(shares, surplus))
+	if redeemed.Lt(assets) {
+		return b, false
+	}
+	next.WrappedBalance = new(uint256.Int).Sub(b.WrappedBalance, surplus)
+	next.UnderlyingBalance = redeemed.Sub(redeemed, assets).Add(redeemed, b.UnderlyingBalance)
+	return &next, false
+}
@@ -28,6 +28,7 @@ const (
 	VaultMethodIsVaultPaused        = "isVaultPaused"
 	VaultMethodIsPoolPaused         = "isPoolPaused"
 	VaultMethodIsPoolInRecoveryMode = "isPoolInRecoveryMode"
+	VaultMethodGetBufferBalance     = "getBufferBalance"
 
 	ERC4626MethodTotalAssets = "totalAssets"
 	ERC4626MethodTotalSupply = "totalSupply"
@@ -1,5 +1,7 @@
 package shared
 
+import "github.com/KyberNetwork/ethrpc"
+
 type HookType string
 
 const (
@@ -9,18 +11,8 @@ const (
 	VeBALFeeDiscountHookType HookType = "VEBAL_DISCOUNT"
 )
 
-// Define a map of supported hooks
-var hooksMap = map[HookType]bool{
-	DirectionalFeeHookType:   true,
-	FeeTakingHookType:        true,
-	StableSurgeHookType:      true,
-	VeBALFeeDiscountHookType: true,
-}
-
-func IsHookSupported(hook HookType) bool {
-	if hook == "" {
-		return true
-	}
-	_, ok := hooksMap[hook]
-	return ok
+// HookState is the on-chain state of a hook, fetched by the pool trackers and used to create the hook
+type HookState interface {
+	// AddCalls adds the calls fetching the state of the hook for the pool to req
+	AddCalls(req *ethrpc.Request, hook, pool string)
 }
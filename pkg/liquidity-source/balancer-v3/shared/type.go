@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
 )
 
@@ -15,12 +16,13 @@ type StaticExtra struct {
 
 type Extra struct {
 	HooksConfig                `json:"hook"`
-	StaticSwapFeePercentage    *uint256.Int   `json:"fee,omitempty"`
-	AggregateSwapFeePercentage *uint256.Int   `json:"aggrFee,omitempty"`
-	BalancesLiveScaled18       []*uint256.Int `json:"balsE18,omitempty"`
-	DecimalScalingFactors      []*uint256.Int `json:"decs,omitempty"`
-	TokenRates                 []*uint256.Int `json:"rates,omitempty"`
-	Buffers                    []*ExtraBuffer `json:"buffs,omitempty"`
+	StaticSwapFeePercentage    *uint256.Int    `json:"fee,omitempty"`
+	AggregateSwapFeePercentage *uint256.Int    `json:"aggrFee,omitempty"`
+	BalancesLiveScaled18       []*uint256.Int  `json:"balsE18,omitempty"`
+	DecimalScalingFactors      []*uint256.Int  `json:"decs,omitempty"`
+	TokenRates                 []*uint256.Int  `json:"rates,omitempty"`
+	Buffers                    []*ExtraBuffer  `json:"buffs,omitempty"`
+	HookState                  json.RawMessage `json:"hookS,omitempty"`
 }
 
 type RpcResult struct {
@@ -29,6 +31,7 @@ type RpcResult struct {
 	AggregateFeePercentageRPC
 	PoolDataRPC
 	Buffers        []*ExtraBufferRPC
+	HookState      HookState
 	IsPoolDisabled bool
 	BlockNumber    uint64
 }
@@ -36,6 +39,9 @@ type RpcResult struct {
 type ExtraBuffer struct {
 	TotalAssets *uint256.Int `json:"tA,omitempty"`
 	TotalSupply *uint256.Int `json:"tS,omitempty"`
+	// UnderlyingBalance and WrappedBalance are the liquidity of the vault buffer of the wrapped token
+	UnderlyingBalance *uint256.Int `json:"uB,omitempty"`
+	WrappedBalance    *uint256.Int `json:"wB,omitempty"`
 }
 
 type PoolDataRPC struct {
@@ -51,8 +57,14 @@ type PoolDataRPC struct {
 }
 
 type ExtraBufferRPC struct {
-	TotalAssets *big.Int
-	TotalSupply *big.Int
+	TotalAssets   *big.Int
+	TotalSupply   *big.Int
+	BufferBalance BufferBalanceRPC
+}
+
+type BufferBalanceRPC struct {
+	UnderlyingBalanceRaw *big.Int
+	WrappedBalanceRaw    *big.Int
 }
 
 type HooksConfig struct {
@@ -79,6 +91,7 @@ type HooksConfigRPC struct {
 }
 
 type SwapInfo struct {
+	// Buffers are the vault buffers updated by the swap, by token index
 	Buffers      []*ExtraBuffer
 	AggregateFee *big.Int
 }
@@ -7,8 +7,5 @@ const (
 
 	poolMethodGetAmplificationParameter = "getAmplificationParameter"
 
-	stableSurgeHookMethodGetMaxSurgeFeePercentage    = "getMaxSurgeFeePercentage"
-	stableSurgeHookMethodGetSurgeThresholdPercentage = "getSurgeThresholdPercentage"
-
 	baseGas = 237494
 )
@@ -1,7 +1,6 @@
 package stable
 
 import (
-	"github.com/KyberNetwork/logger"
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
 	"github.com/samber/lo"
@@ -27,25 +26,21 @@ func NewPoolSimulator(entityPool entity.Pool) (*base.PoolSimulator, error) {
 		return nil, err
 	}
 
-	var hook hooks.IHook
-	var err error
-	switch staticExtra.HookType {
-	case shared.StableSurgeHookType:
-		hook, err = hooks.NewStableSurgeHook(extra.MaxSurgeFeePercentage, extra.SurgeThresholdPercentage)
-	}
-
-	if err != nil {
-		logger.WithFields(logger.Fields{
-			"poolID":      entityPool.Address,
-			"hookType":    staticExtra.HookType,
-			"hooksConfig": extra.HooksConfig,
-		}).Errorf("failed to create hook: %v", err)
-		return nil, err
+	if surge := extra.SurgePercentages; extra.Extra != nil && len(extra.HookState) == 0 && surge != nil &&
+		surge.MaxSurgeFeePercentage != nil && surge.SurgeThresholdPercentage != nil {
+		hookState, err := json.Marshal(hooks.StableSurgeHookState{
+			MaxSurgeFeePercentage:    surge.MaxSurgeFeePercentage.ToBig(),
+			SurgeThresholdPercentage: surge.SurgeThresholdPercentage.ToBig(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		extra.HookState = hookState
 	}
 
 	return base.NewPoolSimulator(entityPool, extra.Extra, &staticExtra, &PoolSimulator{
 		currentAmp: extra.AmplificationParameter,
-	}, hook)
+	})
 }
 
 type PoolSimulator struct {
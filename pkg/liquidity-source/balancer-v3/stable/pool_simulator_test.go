@@ -2,6 +2,7 @@ package stable
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/goccy/go-json"
@@ -9,6 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/base"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/hooks"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/shared"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/vault"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
@@ -133,6 +137,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 		s, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, s)
 
 		amountIn, _ := new(big.Int).SetString("1189123158260799643", 10)
 
@@ -300,3 +305,115 @@ func TestCalcAmountIn(t *testing.T) {
 		assert.Equal(t, expectedSwapFee, result.Fee.Amount.String())
 	})
 }
+
+func TestCalcAmountOut_BufferBalances(t *testing.T) {
+	t.Parallel()
+
+	tokenIn, tokenOut := entityPool.Tokens[0].Address, entityPool.Tokens[1].Address
+	newPoolSim := func(buffers string) *base.PoolSimulator {
+		p := entityPool
+		p.Extra = strings.Replace(p.Extra,
+			`{"tA":"1000000000000000000000","tS":"1000000000000000000000"},{"tA":"2444444444444444444444","tS":"2444444444444444444444"}`,
+			buffers, 1)
+		return lo.Must(NewPoolSimulator(p))
+	}
+	calcAmountOut := func(s *base.PoolSimulator) *poolpkg.CalcAmountOutResult {
+		return lo.Must(s.CalcAmountOut(poolpkg.CalcAmountOutParams{
+			TokenAmountIn: poolpkg.TokenAmount{Token: tokenIn, Amount: big.NewInt(1e18)},
+			TokenOut:      tokenOut,
+		}))
+	}
+
+	untracked := calcAmountOut(poolSim)
+	assert.Nil(t, untracked.SwapInfo.(shared.SwapInfo).Buffers)
+
+	t.Run("sufficient buffers", func(t *testing.T) {
+		s := newPoolSim(`{"tA":"1000000000000000000000","tS":"1000000000000000000000",` +
+			`"uB":"5000000000000000000","wB":"5000000000000000000"},{"tA":"2444444444444444444444",` +
+			`"tS":"2444444444444444444444","uB":"5000000000000000000","wB":"5000000000000000000"}`)
+		result := calcAmountOut(s)
+		assert.Equal(t, untracked.TokenAmountOut.Amount, result.TokenAmountOut.Amount)
+		assert.Equal(t, untracked.Gas, result.Gas)
+
+		buffers := result.SwapInfo.(shared.SwapInfo).Buffers
+		assert.Equal(t, "6000000000000000000", buffers[0].UnderlyingBalance.Dec())
+		assert.Equal(t, "4000000000000000000", buffers[0].WrappedBalance.Dec())
+		assert.Equal(t, new(big.Int).Sub(big.NewInt(5e18), result.TokenAmountOut.Amount).String(),
+			buffers[1].UnderlyingBalance.Dec())
+
+		s.UpdateBalance(poolpkg.UpdateBalanceParams{
+			TokenAmountIn:  poolpkg.TokenAmount{Token: tokenIn, Amount: big.NewInt(1e18)},
+			TokenAmountOut: *result.TokenAmountOut,
+			Fee:            *result.Fee,
+			SwapInfo:       result.SwapInfo,
+		})
+		assert.Equal(t, "7000000000000000000",
+			calcAmountOut(s).SwapInfo.(shared.SwapInfo).Buffers[0].UnderlyingBalance.Dec())
+	})
+
+	t.Run("insufficient buffer", func(t *testing.T) {
+		s := newPoolSim(`{"tA":"1000000000000000000000","tS":"1000000000000000000000",` +
+			`"uB":"5000000000000000000","wB":"1000000000000000"},{"tA":"2444444444444444444444",` +
+			`"tS":"2444444444444444444444"}`)
+		result := calcAmountOut(s)
+		assert.Equal(t, untracked.TokenAmountOut.Amount, result.TokenAmountOut.Amount)
+		assert.Greater(t, result.Gas, untracked.Gas)
+
+		// the vault deposits half the underlying surplus of the buffer along with the swap
+		buffers := result.SwapInfo.(shared.SwapInfo).Buffers
+		assert.Equal(t, "2500500000000000000", buffers[0].UnderlyingBalance.Dec())
+		assert.Equal(t, "2500500000000000000", buffers[0].WrappedBalance.Dec())
+		assert.Nil(t, buffers[1])
+	})
+
+	t.Run("buffer equal to the amount", func(t *testing.T) {
+		// the buffer only serves the wrap when it holds strictly more shares than needed
+		s := newPoolSim(`{"tA":"1000000000000000000000","tS":"1000000000000000000000",` +
+			`"uB":"5000000000000000000","wB":"1000000000000000000"},{"tA":"2444444444444444444444",` +
+			`"tS":"2444444444444444444444"}`)
+		result := calcAmountOut(s)
+		assert.Equal(t, untracked.TokenAmountOut.Amount, result.TokenAmountOut.Amount)
+		assert.Greater(t, result.Gas, untracked.Gas)
+
+		buffers := result.SwapInfo.(shared.SwapInfo).Buffers
+		assert.Equal(t, "3000000000000000000", buffers[0].UnderlyingBalance.Dec())
+		assert.Equal(t, "3000000000000000000", buffers[0].WrappedBalance.Dec())
+	})
+}
+
+func TestNewPoolSimulator_LegacySurge(t *testing.T) {
+	t.Parallel()
+
+	p := entityPool
+	p.StaticExtra = `{"hook":"0xb18fa0cb5de8cecb8899aae6e38b1b7ed77885da","hookT":"STABLE_SURGE"}`
+	p.Extra = strings.Replace(p.Extra, `"hook":{}`, `"hook":{"dynFee":true}`, 1)
+	_, err := NewPoolSimulator(p)
+	assert.ErrorIs(t, err, hooks.ErrInvalidHookState)
+
+	legacy := p
+	legacy.Extra = strings.Replace(p.Extra, `"surge":{}`,
+		`"surge":{"max":"50000000000000000","thres":"20000000000000000"}`, 1)
+	legacySim, err := NewPoolSimulator(legacy)
+	assert.Nil(t, err)
+
+	current := p
+	current.Extra = strings.Replace(p.Extra, `"surge":{}`, `"hookS":{"max":50000000000000000,"thres":20000000000000000}`, 1)
+	currentSim, err := NewPoolSimulator(current)
+	assert.Nil(t, err)
+
+	params := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{Token: p.Tokens[1].Address, Amount: big.NewInt(9e18)},
+		TokenOut:      p.Tokens[0].Address,
+	}
+	legacyResult, err := legacySim.CalcAmountOut(params)
+	assert.Nil(t, err)
+	currentResult, err := currentSim.CalcAmountOut(params)
+	assert.Nil(t, err)
+	assert.Equal(t, currentResult.TokenAmountOut.Amount, legacyResult.TokenAmountOut.Amount)
+	assert.Equal(t, currentResult.Fee.Amount, legacyResult.Fee.Amount)
+
+	// the surge fee applies
+	result, err := poolSim.CalcAmountOut(params)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, legacyResult.Fee.Amount.Cmp(result.Fee.Amount))
+}
@@ -10,8 +10,9 @@ import (
 
 type Extra struct {
 	*shared.Extra
-	SurgePercentages       `json:"surge"`
 	AmplificationParameter *uint256.Int `json:"ampParam,omitempty"`
+	// SurgePercentages is the stable surge hook state of pools tracked before it moved to shared.Extra.HookState
+	SurgePercentages *SurgePercentages `json:"surge,omitempty"`
 }
 
 type SurgePercentages struct {
@@ -21,15 +22,9 @@ type SurgePercentages struct {
 
 type RpcResult struct {
 	shared.RpcResult
-	SurgePercentagesRpc
 	AmplificationParameterRpc
 }
 
-type SurgePercentagesRpc struct {
-	MaxSurgeFeePercentage    *big.Int
-	SurgeThresholdPercentage *big.Int
-}
-
 type AmplificationParameterRpc struct {
 	Value      *big.Int
 	IsUpdating bool
@@ -27,7 +27,7 @@ func NewPoolSimulator(entityPool entity.Pool) (*base.PoolSimulator, error) {
 
 	return base.NewPoolSimulator(entityPool, extra.Extra, &staticExtra, &PoolSimulator{
 		normalizedWeights: extra.NormalizedWeights,
-	}, nil)
+	})
 }
 
 type PoolSimulator struct {
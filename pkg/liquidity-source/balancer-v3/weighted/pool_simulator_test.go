@@ -167,6 +167,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 		s, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, s)
 
 		amountIn, _ := new(big.Int).SetString("1000000000000000000", 10)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 //go:embed sample_pool_data.txt
@@ -34,6 +35,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 	}
 	poolSim, err := NewPoolSimulator(pool)
 	assert.Nil(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
 
 	t.Run("Test rateByPath success calculate", func(t *testing.T) {
 		eth := strings.ToLower("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
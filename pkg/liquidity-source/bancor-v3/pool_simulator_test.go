@@ -93,6 +93,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		// calculation
 		simulator, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 		amountIn, _ := new(big.Int).SetString("1663885565640917213", 10)
 		result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 			return simulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
@@ -9,6 +9,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/swaplimit"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 var (
@@ -45,6 +46,7 @@ func TestPoolSimulator_NewPool(t *testing.T) {
 	t.Parallel()
 	poolSimulator, err := NewPoolSimulator(entityPool1)
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSimulator)
 	assert.Equal(t, "0x0d500b1d8e8ef31e21c99d1db9a6444d3adf1270", poolSimulator.Token0.Address)
 	assert.Equal(t, "0xc2132d05d31c914a87c6611c10748aeb04b58e8f", poolSimulator.Token1.Address)
 	assert.NotNil(t, poolSimulator.ZeroToOnePriceLevels)
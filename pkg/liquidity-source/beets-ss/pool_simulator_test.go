@@ -9,6 +9,7 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	utils "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
 
@@ -68,6 +69,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, &tc.poolSimulator)
+
 			assert.Equal(t, tc.poolSimulator.CanSwapFrom(tc.tokenAmountIn.Token), []string{Beets_Staked_Sonic_Address})
 			assert.Equal(t, tc.poolSimulator.CanSwapFrom(tc.tokenOut), []string{})
 
@@ -0,0 +1,28 @@
+package brownfi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x3b6ae1a0a8d95bba80a8b2b2dd7e4a4bc8a5c7f2",
+		Exchange: "brownfi",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"844393591061170837668", "1055599299877346666213"},
+		Tokens: []*entity.PoolToken{
+			{Address: "0x6969696969696969696969696969696969696969", Decimals: 18},
+			{Address: "0xfcbd14dc51f0a4d49d5e53c2e0950e0bc26d0dce", Decimals: 18},
+		},
+		Extra: `{"fee":15,"feePrecision":10000,"kappa":"340282366920938463463374607431768211",` +
+			`"oPrice":"1355831955198352353063685248472321152625"}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
+}
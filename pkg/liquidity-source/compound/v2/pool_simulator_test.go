@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	cUSDC = "0x39aa39c021dfbae8fac545936693ac917d5e7563"
+	usdc  = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  cUSDC,
+		Exchange: "compound-v2",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"1000000000000000", "1000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: cUSDC, Decimals: 8}, {Address: usdc, Decimals: 6}},
+		Extra:    `{"exchangeRateStored":232000000000000}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
+
+	result, err := poolSim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: cUSDC, Amount: big.NewInt(1e8)},
+		TokenOut:      usdc,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(23200), result.TokenAmountOut.Amount)
+}
@@ -0,0 +1,43 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	cUSDCv3 = "0xc3d688b66703497daa19211eedff47f25384cdc3"
+	usdc    = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+)
+
+func newPoolSimulator(t *testing.T, extra string) *PoolSimulator {
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  cUSDCv3,
+		Exchange: "compound-v3",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"1000000000000", "1000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: cUSDCv3, Decimals: 6}, {Address: usdc, Decimals: 6}},
+		Extra:    extra,
+	})
+	require.NoError(t, err)
+	return poolSim
+}
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, newPoolSimulator(t, `{}`))
+}
+
+func TestPoolSimulator_CanSwap(t *testing.T) {
+	t.Parallel()
+	poolSim := newPoolSimulator(t, `{"isSupplyPaused":true}`)
+	assert.Equal(t, []string{usdc}, poolSim.CanSwapFrom(cUSDCv3))
+	assert.Empty(t, poolSim.CanSwapFrom(usdc))
+	assert.Equal(t, []string{cUSDCv3}, poolSim.CanSwapTo(usdc))
+	assert.Empty(t, poolSim.CanSwapTo(cUSDCv3))
+}
@@ -8,7 +8,10 @@ import (
 
 var (
 	CurveControllerFactoryABI abi.ABI
+	CurveCryptoPoolABI        abi.ABI
 	CurveLlammaABI            abi.ABI
+	CurvePriceOracleABI       abi.ABI
+	CurveTwocryptoPoolABI     abi.ABI
 )
 
 func init() {
@@ -17,7 +20,10 @@ func init() {
 		data []byte
 	}{
 		{&CurveControllerFactoryABI, curveControllerFactoryABIBytes},
+		{&CurveCryptoPoolABI, curveCryptoPoolABIBytes},
 		{&CurveLlammaABI, curveLlammaABIBytes},
+		{&CurvePriceOracleABI, curvePriceOracleABIBytes},
+		{&CurveTwocryptoPoolABI, curveTwocryptoPoolABIBytes},
 	}
 
 	for _, b := range build {
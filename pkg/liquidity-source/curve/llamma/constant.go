@@ -2,8 +2,11 @@ package llamma
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/KyberNetwork/blockchain-toolkit/number"
+	"github.com/KyberNetwork/int256"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/holiman/uint256"
 
 	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
@@ -16,22 +19,45 @@ const (
 	factoryMethodCollaterals  = "collaterals"
 	factoryMethodAmms         = "amms"
 
-	LlammaMethodA            = "A"
-	llammaMethodGetBasePrice = "get_base_price"
-	llammaMethodFee          = "fee"
-	llammaMethodAdminFee     = "admin_fee"
-	llammaMethodAdminFeesX   = "admin_fees_x"
-	llammaMethodAdminFeesY   = "admin_fees_y"
-	llammaMethodPriceOracle  = "price_oracle"
-	llammaMethodActiveBand   = "active_band"
-	llammaMethodMinBand      = "min_band"
-	llammaMethodMaxBand      = "max_band"
-	llammaMethodBandsX       = "bands_x"
-	llammaMethodBandsY       = "bands_y"
+	LlammaMethodA                   = "A"
+	llammaMethodGetBasePrice        = "get_base_price"
+	llammaMethodFee                 = "fee"
+	llammaMethodAdminFee            = "admin_fee"
+	llammaMethodAdminFeesX          = "admin_fees_x"
+	llammaMethodAdminFeesY          = "admin_fees_y"
+	llammaMethodPriceOracle         = "price_oracle"
+	llammaMethodDynamicFee          = "dynamic_fee"
+	llammaMethodPriceOracleContract = "price_oracle_contract"
+	llammaMethodActiveBand          = "active_band"
+	llammaMethodMinBand             = "min_band"
+	llammaMethodMaxBand             = "max_band"
+	llammaMethodBandsX              = "bands_x"
+	llammaMethodBandsY              = "bands_y"
+
+	priceOracleMethodPrice        = "price"
+	priceOracleMethodPool         = "POOL"
+	priceOracleMethodCollateralIx = "COLLATERAL_IX"
+	priceOracleMethodBorrowedIx   = "BORROWED_IX"
+	priceOracleMethodNoArgument   = "NO_ARGUMENT"
+
+	cryptoPoolMethodMaTime      = "ma_time"
+	cryptoPoolMethodPriceOracle = "price_oracle"
+	cryptoPoolMethodLastPrices  = "last_prices"
 
 	maxTicksUnit int64 = 50
 	maxTicks     int64 = 50
 	maxSkipTicks int64 = 1024
+
+	// prevPoDelay is PREV_P_O_DELAY, the time over which the fee raised by an oracle price move decays.
+	prevPoDelay int64 = 2 * 60
+)
+
+// Storage slots of the AMM, in the declaration order of AMM.vy. old_p_o, old_dfee and prev_p_o_time have no getter.
+var (
+	slotFee        = common.BigToHash(big.NewInt(1))
+	slotOldPo      = common.BigToHash(big.NewInt(12))
+	slotOldDfee    = common.BigToHash(big.NewInt(13))
+	slotPrevPoTime = common.BigToHash(big.NewInt(14))
 )
 
 var (
@@ -39,8 +65,13 @@ var (
 
 	Number_1e36 = big256.TenPow(36)
 
+	tenPow18Int    = int256.NewInt(1e18)
 	tenPow18Minus1 = new(uint256.Int).Sub(number.Number_1e18, number.Number_1)
 	tenPow18Div4   = new(uint256.Int).Div(number.Number_1e18, number.Number_4)
+
+	// maxPoChg is MAX_P_O_CHG, the maximum relative change of the oracle price within prevPoDelay.
+	maxPoChg      = uint256.NewInt(12500e14)
+	minPoChgRatio = new(uint256.Int).Div(Number_1e36, maxPoChg)
 )
 
 var (
@@ -49,4 +80,6 @@ var (
 	ErrZeroSwapAmount      = errors.New("zero swap amount")
 	ErrWadExpOverflow      = errors.New("wad_exp overflow")
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrInvalidBandRange    = errors.New("invalid band range")
+	ErrInvalidOracleState  = errors.New("invalid oracle state")
 )
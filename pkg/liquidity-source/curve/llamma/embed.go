@@ -5,5 +5,14 @@ import _ "embed"
 //go:embed abi/CurveControllerFactory.json
 var curveControllerFactoryABIBytes []byte
 
+//go:embed abi/CurveCryptoPool.json
+var curveCryptoPoolABIBytes []byte
+
 //go:embed abi/CurveLlamma.json
 var curveLlammaABIBytes []byte
+
+//go:embed abi/CurvePriceOracle.json
+var curvePriceOracleABIBytes []byte
+
+//go:embed abi/CurveTwocryptoPool.json
+var curveTwocryptoPoolABIBytes []byte
@@ -2,6 +2,8 @@ package llamma
 
 import (
 	"math/big"
+	"slices"
+	"time"
 
 	"github.com/KyberNetwork/blockchain-toolkit/number"
 	"github.com/KyberNetwork/int256"
@@ -38,6 +40,16 @@ type PoolSimulator struct {
 	BandsX     map[int64]*uint256.Int
 	BandsY     map[int64]*uint256.Int
 
+	DynamicFee *uint256.Int
+	Timestamp  int64
+	RawPrice   *uint256.Int
+	PrevPo     *uint256.Int
+	PrevDfee   *uint256.Int
+	PrevPoTime int64
+
+	RawPriceSpot   *uint256.Int
+	RawPriceMaTime int64
+
 	collateralPrecision *uint256.Int
 	borrowedPrecision   *uint256.Int
 
@@ -46,6 +58,9 @@ type PoolSimulator struct {
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
 
+// blockTimestamp is the timestamp of the block the swap is expected to land in
+var blockTimestamp = func() int64 { return time.Now().Unix() }
+
 func NewPoolSimulator(ep entity.Pool) (*PoolSimulator, error) {
 	var (
 		staticExtra StaticExtra
@@ -101,6 +116,16 @@ func NewPoolSimulator(ep entity.Pool) (*PoolSimulator, error) {
 		BandsX:     lo.SliceToMap(extra.Bands, func(e Band) (int64, *uint256.Int) { return e.Index, e.BandX }),
 		BandsY:     lo.SliceToMap(extra.Bands, func(e Band) (int64, *uint256.Int) { return e.Index, e.BandY }),
 
+		DynamicFee: extra.DynamicFee,
+		Timestamp:  ep.Timestamp,
+		RawPrice:   extra.RawPrice,
+		PrevPo:     extra.PrevPo,
+		PrevDfee:   extra.PrevDfee,
+		PrevPoTime: extra.PrevPoTime,
+
+		RawPriceSpot:   extra.RawPriceSpot,
+		RawPriceMaTime: extra.RawPriceMaTime,
+
 		borrowedPrecision:   big256.TenPow(18 - ep.Tokens[0].Decimals),
 		collateralPrecision: big256.TenPow(18 - ep.Tokens[1].Decimals),
 
@@ -163,12 +188,14 @@ func (t *PoolSimulator) exchange(i, j int, amount *uint256.Int, calcAmountOut bo
 		inPrecision, outPrecision = outPrecision, inPrecision
 	}
 
+	po, fee := t.priceOracle(max(blockTimestamp(), t.Timestamp))
+
 	out := &DetailedTrade{}
 	var err error
 	if calcAmountOut {
-		out, err = t.calcSwapOut(i, new(uint256.Int).Mul(amount, inPrecision), t.Po, inPrecision, outPrecision)
+		out, err = t.calcSwapOut(i, new(uint256.Int).Mul(amount, inPrecision), po, fee, inPrecision, outPrecision)
 	} else {
-		out, err = t.calcSwapIn(i, new(uint256.Int).Mul(amount, outPrecision), t.Po, inPrecision, outPrecision)
+		out, err = t.calcSwapIn(i, new(uint256.Int).Mul(amount, outPrecision), po, fee, inPrecision, outPrecision)
 	}
 	if err != nil {
 		return nil, err
@@ -185,7 +212,7 @@ func (t *PoolSimulator) exchange(i, j int, amount *uint256.Int, calcAmountOut bo
 }
 
 func (t *PoolSimulator) calcSwapOut(
-	inIdx int, inAmount, po, inPrecision, outPrecision *uint256.Int,
+	inIdx int, inAmount, po, fee, inPrecision, outPrecision *uint256.Int,
 ) (*DetailedTrade, error) {
 	pump := inIdx == t.getBorrowedIndex()
 	minBand := t.MinBand
@@ -205,14 +232,14 @@ func (t *PoolSimulator) calcSwapOut(
 	if !t.UseDynamicFee {
 		antifee.Div(
 			Number_1e36,
-			temp.Sub(number.Number_1e18, minUint256(t.Fee, tenPow18Minus1)),
+			temp.Sub(number.Number_1e18, minUint256(fee, tenPow18Minus1)),
 		)
 	}
 
 	j := maxTicksUnit
 	for i := range maxTicks + maxSkipTicks {
 		var y0, f, g, inv, dynamicFee uint256.Int
-		dynamicFee.Set(t.Fee)
+		dynamicFee.Set(fee)
 
 		if x.Sign() > 0 || y.Sign() > 0 {
 			if j == maxTicksUnit {
@@ -224,7 +251,7 @@ func (t *PoolSimulator) calcSwapOut(
 			g.Mul(t.Aminus1, &y0).Mul(&g, poUp).Div(&g, po)
 			inv.Add(&f, &x).Mul(&inv, temp.Add(&g, &y))
 			if t.UseDynamicFee {
-				dynamicFee.Set(maxUint256(t.getDynamicFee(po, poUp), t.Fee))
+				dynamicFee.Set(maxUint256(t.getDynamicFee(po, poUp), fee))
 			}
 		}
 
@@ -374,7 +401,7 @@ func (t *PoolSimulator) calcSwapOut(
 }
 
 func (t *PoolSimulator) calcSwapIn(
-	inIdx int, outAmount, po, inPrecision, outPrecision *uint256.Int,
+	inIdx int, outAmount, po, fee, inPrecision, outPrecision *uint256.Int,
 ) (*DetailedTrade, error) {
 	pump := inIdx == t.getBorrowedIndex()
 	minBand := t.MinBand
@@ -394,14 +421,14 @@ func (t *PoolSimulator) calcSwapIn(
 	if !t.UseDynamicFee {
 		antifee.Div(
 			Number_1e36,
-			temp.Sub(number.Number_1e18, minUint256(t.Fee, tenPow18Minus1)),
+			temp.Sub(number.Number_1e18, minUint256(fee, tenPow18Minus1)),
 		)
 	}
 
 	j := maxTicksUnit
 	for i := range maxTicks + maxSkipTicks {
 		var y0, f, g, inv, dynamicFee uint256.Int
-		dynamicFee.Set(t.Fee)
+		dynamicFee.Set(fee)
 
 		if x.Sign() > 0 || y.Sign() > 0 {
 			if j == maxTicksUnit {
@@ -414,7 +441,7 @@ func (t *PoolSimulator) calcSwapIn(
 			g.Mul(t.Aminus1, &y0).Mul(&g, poUp).Div(&g, po)
 			inv.Add(&f, &x).Mul(&inv, temp.Add(&g, &y))
 			if t.UseDynamicFee {
-				dynamicFee.Set(maxUint256(t.getDynamicFee(po, poUp), t.Fee))
+				dynamicFee.Set(maxUint256(t.getDynamicFee(po, poUp), fee))
 			}
 		}
 
@@ -596,6 +623,114 @@ func (t *PoolSimulator) getDynamicFee(po, poUp *uint256.Int) *uint256.Int {
 	return &ret
 }
 
+// priceOracle returns the price oracle and the swap fee at timestamp, like _price_oracle_ro: limit_p_o is applied to the
+// raw oracle price at timestamp against the oracle state of the last swap. Pools tracked without the oracle state use the
+// tracked price_oracle() and dynamic_fee() as is.
+func (t *PoolSimulator) priceOracle(timestamp int64) (*uint256.Int, *uint256.Int) {
+	if t.RawPrice == nil || t.PrevPo == nil || t.PrevDfee == nil {
+		if t.DynamicFee != nil && t.DynamicFee.Gt(t.Fee) {
+			return t.Po, t.DynamicFee
+		}
+		return t.Po, t.Fee
+	}
+
+	p, ratio := limitPo(t.rawPrice(timestamp), t.PrevPo, t.PrevDfee, timestamp-t.PrevPoTime)
+	return p, maxUint256(t.Fee, ratio)
+}
+
+// rawPrice returns the raw oracle price at timestamp. When the oracle prices from the EMA of a Curve pool, the EMA is
+// moved from its value at the tracked block toward the last spot price of the pool, like price_oracle() of the pool.
+// Trades of the pool after the tracked block are not known, and other oracles are held at their tracked price.
+func (t *PoolSimulator) rawPrice(timestamp int64) *uint256.Int {
+	if t.RawPriceSpot == nil || t.RawPriceMaTime <= 0 || timestamp <= t.Timestamp {
+		return t.RawPrice
+	}
+
+	// alpha = exp(-dt * 10**18 / ma_time)
+	var power int256.Int
+	power.SetInt64(t.Timestamp-timestamp).Mul(&power, tenPow18Int).Quo(&power, new(int256.Int).SetInt64(t.RawPriceMaTime))
+	alpha, err := wadExp(&power)
+	if err != nil {
+		return t.RawPrice
+	}
+
+	// p = (spot * (10**18 - alpha) + raw_price * alpha) / 10**18
+	var p, temp uint256.Int
+	p.Sub(number.Number_1e18, alpha).Mul(&p, t.RawPriceSpot)
+	return p.Add(&p, temp.Mul(t.RawPrice, alpha)).Div(&p, number.Number_1e18)
+}
+
+// limitPo is limit_p_o: it caps the move of the oracle price p away from oldPo at MAX_P_O_CHG and returns the capped
+// price with the fee ratio raised by the move, which decays over PREV_P_O_DELAY after the last oracle update.
+func limitPo(p, oldPo, oldRatio *uint256.Int, elapsed int64) (*uint256.Int, *uint256.Int) {
+	var pNew, ratio uint256.Int
+	pNew.Set(p)
+	dt := prevPoDelay - min(prevPoDelay, elapsed)
+	if dt == 0 {
+		return &pNew, &ratio
+	}
+
+	// ratio = p_o_min / p_o_max, limited to 1 / MAX_P_O_CHG
+	if p.Gt(oldPo) {
+		ratio.Mul(oldPo, number.Number_1e18).Div(&ratio, p)
+		if ratio.Lt(minPoChgRatio) {
+			pNew.Mul(oldPo, maxPoChg).Div(&pNew, number.Number_1e18)
+			ratio.Set(minPoChgRatio)
+		}
+	} else {
+		ratio.Mul(p, number.Number_1e18).Div(&ratio, oldPo)
+		if ratio.Lt(minPoChgRatio) {
+			pNew.Mul(oldPo, number.Number_1e18).Div(&pNew, maxPoChg)
+			ratio.Set(minPoChgRatio)
+		}
+	}
+
+	// ratio = min((1 + old_ratio - ratio**3) * dt / PREV_P_O_DELAY, 1 - 1e-18)
+	var cube uint256.Int
+	cube.Exp(&ratio, number.Number_3).Div(&cube, Number_1e36)
+	ratio.Add(number.Number_1e18, oldRatio).Sub(&ratio, &cube).
+		Mul(&ratio, uint256.NewInt(uint64(dt))).Div(&ratio, uint256.NewInt(uint64(prevPoDelay)))
+	return &pNew, minUint256(&ratio, tenPow18Minus1)
+}
+
+// GetBandsLiquidity returns the liquidity of the non-empty bands within [minBand, maxBand] in ascending band order,
+// with amounts in token units. Bands below the active band hold borrowed token only, bands above it collateral only.
+func (t *PoolSimulator) GetBandsLiquidity(minBand, maxBand int64) ([]BandLiquidity, error) {
+	if minBand > maxBand {
+		return nil, ErrInvalidBandRange
+	}
+	indexes := lo.Uniq(append(lo.Keys(t.BandsX), lo.Keys(t.BandsY)...))
+	slices.Sort(indexes)
+
+	bands := make([]BandLiquidity, 0, len(indexes))
+	for _, n := range indexes {
+		if n < minBand || n > maxBand {
+			continue
+		}
+		x, y := t.getBandX(n), t.getBandY(n)
+		if x.IsZero() && y.IsZero() {
+			continue
+		}
+		pUp, err := t.pOracleUp(n)
+		if err != nil {
+			return nil, err
+		}
+		pDown, err := t.pOracleUp(n + 1)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, BandLiquidity{
+			Index:     n,
+			X:         new(uint256.Int).Div(x, t.borrowedPrecision),
+			Y:         new(uint256.Int).Div(y, t.collateralPrecision),
+			PriceUp:   pUp,
+			PriceDown: pDown,
+		})
+	}
+
+	return bands, nil
+}
+
 func (t *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	out := params.SwapInfo.(*DetailedTrade)
 	tokenInIndex := t.GetTokenIndex(params.TokenAmountIn.Token)
@@ -632,6 +767,13 @@ func (t *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	}
 
 	t.ActiveBand = out.N2
+
+	// the swap writes the oracle state, like _price_oracle_w
+	if t.RawPrice != nil && t.PrevPo != nil && t.PrevDfee != nil {
+		timestamp := max(blockTimestamp(), t.Timestamp)
+		t.PrevPo, t.PrevDfee = limitPo(t.rawPrice(timestamp), t.PrevPo, t.PrevDfee, timestamp-t.PrevPoTime)
+		t.PrevPoTime = timestamp
+	}
 }
 
 func (t *PoolSimulator) GetMetaInfo(tokenIn string, _ string) interface{} {
@@ -2,8 +2,12 @@ package llamma
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"math/big"
 	"testing"
 
+	"github.com/KyberNetwork/blockchain-toolkit/number"
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
 	"github.com/samber/lo"
@@ -13,12 +17,15 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
+var _ = func() bool { blockTimestamp = func() int64 { return 1742000000 }; return true }()
+
 func TestStatefullCalcAmountOut(t *testing.T) {
 	t.Parallel()
 	// Check using Python code from the repository: https://github.com/0xreviews/crvusdsim
-	poolStr := "{\"address\":\"0xfa96ad0a9e64261db86950e2da362f5572c5c6fd\",\"exchange\":\"curve-llamma\",\"type\":\"curve-llamma\",\"timestamp\":0,\"reserves\":[\"0\",\"1001000000000150100000\"],\"tokens\":[{\"address\":\"0xf939e0a03fb07f59a73314e73794be0e57ac1b4e\",\"decimals\":18,\"swappable\":true},{\"address\":\"0xac3e018457b222d93114458476f3e3416abbe38f\",\"decimals\":18,\"swappable\":true}],\"extra\":\"{\\\"BasePrice\\\":\\\"2500000000000000000000\\\",\\\"Fee\\\":\\\"10000000000000000\\\",\\\"AdminFeesX\\\":\\\"0\\\",\\\"AdminFeesY\\\":\\\"0\\\",\\\"AdminFee\\\":\\\"0\\\",\\\"dynamicFee\\\":\\\"10000000000000000\\\",\\\"priceOracle\\\":\\\"2500000000000000000000\\\",\\\"ActiveBand\\\":0,\\\"MinBand\\\":0,\\\"MaxBand\\\":39,\\\"bands\\\":null}\",\"staticExtra\":\"{\\\"A\\\":\\\"100\\\",\\\"useDynamicFee\\\":true}\",\"blockNumber\":0}"
+	poolStr := "{\"address\":\"0xfa96ad0a9e64261db86950e2da362f5572c5c6fd\",\"exchange\":\"curve-llamma\",\"type\":\"curve-llamma\",\"timestamp\":0,\"reserves\":[\"0\",\"1001000000000150100000\"],\"tokens\":[{\"address\":\"0xf939e0a03fb07f59a73314e73794be0e57ac1b4e\",\"decimals\":18,\"swappable\":true},{\"address\":\"0xac3e018457b222d93114458476f3e3416abbe38f\",\"decimals\":18,\"swappable\":true}],\"extra\":\"{\\\"basePrice\\\":\\\"2500000000000000000000\\\",\\\"fee\\\":\\\"10000000000000000\\\",\\\"adminFeesX\\\":\\\"0\\\",\\\"adminFeesY\\\":\\\"0\\\",\\\"adminFee\\\":\\\"0\\\",\\\"dynamicFee\\\":\\\"10000000000000000\\\",\\\"priceOracle\\\":\\\"2500000000000000000000\\\",\\\"activeBand\\\":0,\\\"minBand\\\":0,\\\"maxBand\\\":39,\\\"bands\\\":null}\",\"staticExtra\":\"{\\\"A\\\":\\\"100\\\",\\\"useDynamicFee\\\":true}\",\"blockNumber\":0}"
 
 	var ep entity.Pool
 	err := json.Unmarshal([]byte(poolStr), &ep)
@@ -26,6 +33,7 @@ func TestStatefullCalcAmountOut(t *testing.T) {
 
 	sim, err := NewPoolSimulator(ep)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, sim)
 	require.NotNil(t, sim)
 
 	sim.BandsX = map[int64]*uint256.Int{}
@@ -1698,3 +1706,152 @@ func TestCalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPriceOracle(t *testing.T) {
+	t.Parallel()
+	var ep entity.Pool
+	err := json.Unmarshal([]byte("{\"address\":\"0x9a2e6bb3114b1eeb5492d97188a3ecb09e39fac8\",\"exchange\":\"curve-llamma\",\"type\":\"curve-llamma\",\"timestamp\":0,\"reserves\":[\"62206732003586843\",\"590230\"],\"tokens\":[{\"address\":\"0xf939e0a03fb07f59a73314e73794be0e57ac1b4e\",\"symbol\":\"crvUSD\",\"decimals\":18,\"swappable\":true},{\"address\":\"0x8236a87084f8b84306f72007f36f2618a5634494\",\"symbol\":\"LBTC\",\"decimals\":8,\"swappable\":true}],\"extra\":\"{\\\"basePrice\\\":\\\"79079007039151920966803\\\",\\\"priceOracle\\\":\\\"86220588569640038709821\\\",\\\"fee\\\":\\\"6000000000000000\\\",\\\"adminFee\\\":\\\"0\\\",\\\"adminFeesX\\\":\\\"0\\\",\\\"adminFeesY\\\":\\\"0\\\",\\\"activeBand\\\":-3,\\\"minBand\\\":-3,\\\"maxBand\\\":6,\\\"bands\\\":[{\\\"i\\\":-3,\\\"x\\\":\\\"62206732003586843\\\",\\\"y\\\":\\\"550659637527530\\\"},{\\\"i\\\":-2,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"578808445342571\\\"},{\\\"i\\\":-1,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":0,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":1,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":2,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":3,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":4,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":5,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":6,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"}],\\\"availableBalances\\\":[\\\"62206732003586843\\\",\\\"590228\\\"]}\",\"staticExtra\":\"{\\\"A\\\":\\\"75\\\",\\\"useDynamicFee\\\":true}\",\"blockNumber\":22084346}"), &ep)
+	require.Nil(t, err)
+	ep.Timestamp = 1742000000 - 60
+
+	sim, err := NewPoolSimulator(ep)
+	require.Nil(t, err)
+	sim.DynamicFee = uint256.MustFromDecimal("10000000000000000")
+
+	t.Run("without oracle state", func(t *testing.T) {
+		po, fee := sim.priceOracle(ep.Timestamp + prevPoDelay)
+		assert.Equal(t, sim.Po, po)
+		assert.Equal(t, sim.DynamicFee, fee)
+	})
+
+	// withMove sets the oracle state of a swap elapsed seconds before the tracked block, at sim.Po, with the raw price
+	// moved by num/den since
+	withMove := func(num, den uint64, elapsed int64) *PoolSimulator {
+		s := *sim
+		s.PrevPo, s.PrevDfee, s.PrevPoTime = sim.Po, uint256.NewInt(0), ep.Timestamp-elapsed
+		s.RawPrice = new(uint256.Int).Mul(sim.Po, uint256.NewInt(num))
+		s.RawPrice.Div(s.RawPrice, uint256.NewInt(den))
+		return &s
+	}
+	// feeOf is the fee ratio of limit_p_o for a price ratio r after dt seconds
+	feeOf := func(r float64, dt int64) float64 {
+		return (1 - r*r*r) * float64(prevPoDelay-dt) / float64(prevPoDelay)
+	}
+
+	t.Run("small move", func(t *testing.T) {
+		s := withMove(101, 100, 10)
+		po, fee := s.priceOracle(ep.Timestamp)
+		assert.Equal(t, s.RawPrice, po)
+		assert.InDelta(t, feeOf(100./101, 10), fee.Float64()/1e18, 1e-9)
+
+		// the fee decays until PREV_P_O_DELAY after the swap
+		po, fee = s.priceOracle(ep.Timestamp + 60)
+		assert.Equal(t, s.RawPrice, po)
+		assert.InDelta(t, feeOf(100./101, 70), fee.Float64()/1e18, 1e-9)
+
+		po, fee = s.priceOracle(ep.Timestamp + prevPoDelay)
+		assert.Equal(t, s.RawPrice, po)
+		assert.Equal(t, s.Fee, fee)
+	})
+
+	t.Run("limited move", func(t *testing.T) {
+		s := withMove(2, 1, 10)
+		po, fee := s.priceOracle(ep.Timestamp)
+		assert.Equal(t, new(uint256.Int).Div(new(uint256.Int).Mul(sim.Po, maxPoChg), number.Number_1e18), po)
+		assert.InDelta(t, feeOf(0.8, 10), fee.Float64()/1e18, 1e-9)
+
+		s = withMove(1, 2, 10)
+		po, _ = s.priceOracle(ep.Timestamp)
+		assert.Equal(t, new(uint256.Int).Div(new(uint256.Int).Mul(sim.Po, number.Number_1e18), maxPoChg), po)
+
+		po, fee = s.priceOracle(ep.Timestamp + prevPoDelay)
+		assert.Equal(t, s.RawPrice, po)
+		assert.Equal(t, s.Fee, fee)
+	})
+
+	t.Run("ema", func(t *testing.T) {
+		// a swap at the tracked block, with the pool of the oracle traded at twice its EMA
+		s := withMove(1, 1, 0)
+		s.RawPriceSpot, s.RawPriceMaTime = new(uint256.Int).Mul(sim.Po, number.Number_2), 60
+		rawOf := func(dt int64) float64 {
+			return sim.Po.Float64() * (2 - math.Exp(-float64(dt)/float64(s.RawPriceMaTime)))
+		}
+
+		po, fee := s.priceOracle(ep.Timestamp)
+		assert.Equal(t, s.RawPrice, po)
+		assert.Equal(t, s.Fee, fee)
+
+		// the raw price moves toward the spot price, limited at MAX_P_O_CHG within PREV_P_O_DELAY
+		po, fee = s.priceOracle(ep.Timestamp + 10)
+		assert.InEpsilon(t, rawOf(10), po.Float64(), 1e-12)
+		assert.InDelta(t, feeOf(sim.Po.Float64()/rawOf(10), 10), fee.Float64()/1e18, 1e-9)
+
+		po, fee = s.priceOracle(ep.Timestamp + 60)
+		assert.Equal(t, new(uint256.Int).Div(new(uint256.Int).Mul(sim.Po, maxPoChg), number.Number_1e18), po)
+		assert.InDelta(t, feeOf(0.8, 60), fee.Float64()/1e18, 1e-9)
+
+		po, fee = s.priceOracle(ep.Timestamp + prevPoDelay)
+		assert.InEpsilon(t, rawOf(prevPoDelay), po.Float64(), 1e-12)
+		assert.Equal(t, s.Fee, fee)
+
+		// the tracked raw price is held without the spot price
+		s.RawPriceSpot = nil
+		po, _ = s.priceOracle(ep.Timestamp + prevPoDelay)
+		assert.Equal(t, s.RawPrice, po)
+	})
+
+	t.Run("quote time", func(t *testing.T) {
+		// the pinned block timestamp is within PREV_P_O_DELAY of a recent swap, but not of an old one
+		recent, old := withMove(1, 2, 10), withMove(1, 2, prevPoDelay)
+		params := pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: sim.Info.Tokens[0], Amount: big.NewInt(1e15)},
+			TokenOut:      sim.Info.Tokens[1],
+		}
+		// the collateral is cheaper once the raw price is no longer limited and the fee has decayed
+		recentRes, err := recent.CalcAmountOut(params)
+		require.Nil(t, err)
+		oldRes, err := old.CalcAmountOut(params)
+		require.Nil(t, err)
+		assert.True(t, oldRes.TokenAmountOut.Amount.Cmp(recentRes.TokenAmountOut.Amount) > 0)
+
+		// the swap writes the oracle state
+		po, _ := recent.priceOracle(blockTimestamp())
+		recent.BandsX, recent.BandsY = maps.Clone(sim.BandsX), maps.Clone(sim.BandsY)
+		recent.UpdateBalance(pool.UpdateBalanceParams{TokenAmountIn: params.TokenAmountIn,
+			TokenAmountOut: *recentRes.TokenAmountOut, SwapInfo: recentRes.SwapInfo})
+		assert.Equal(t, po, recent.PrevPo)
+		assert.Equal(t, blockTimestamp(), recent.PrevPoTime)
+		assert.True(t, recent.PrevDfee.Sign() > 0)
+	})
+}
+
+func TestGetBandsLiquidity(t *testing.T) {
+	t.Parallel()
+	var ep entity.Pool
+	err := json.Unmarshal([]byte("{\"address\":\"0x9a2e6bb3114b1eeb5492d97188a3ecb09e39fac8\",\"exchange\":\"curve-llamma\",\"type\":\"curve-llamma\",\"timestamp\":0,\"reserves\":[\"62206732003586843\",\"590230\"],\"tokens\":[{\"address\":\"0xf939e0a03fb07f59a73314e73794be0e57ac1b4e\",\"symbol\":\"crvUSD\",\"decimals\":18,\"swappable\":true},{\"address\":\"0x8236a87084f8b84306f72007f36f2618a5634494\",\"symbol\":\"LBTC\",\"decimals\":8,\"swappable\":true}],\"extra\":\"{\\\"basePrice\\\":\\\"79079007039151920966803\\\",\\\"priceOracle\\\":\\\"86220588569640038709821\\\",\\\"fee\\\":\\\"6000000000000000\\\",\\\"adminFee\\\":\\\"0\\\",\\\"adminFeesX\\\":\\\"0\\\",\\\"adminFeesY\\\":\\\"0\\\",\\\"activeBand\\\":-3,\\\"minBand\\\":-3,\\\"maxBand\\\":6,\\\"bands\\\":[{\\\"i\\\":-3,\\\"x\\\":\\\"62206732003586843\\\",\\\"y\\\":\\\"550659637527530\\\"},{\\\"i\\\":-2,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"578808445342571\\\"},{\\\"i\\\":-1,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":0,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":1,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":2,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":3,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":4,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":5,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"},{\\\"i\\\":6,\\\"x\\\":\\\"0\\\",\\\"y\\\":\\\"596602000000000\\\"}],\\\"availableBalances\\\":[\\\"62206732003586843\\\",\\\"590228\\\"]}\",\"staticExtra\":\"{\\\"A\\\":\\\"75\\\",\\\"useDynamicFee\\\":true}\",\"blockNumber\":22084346}"), &ep)
+	require.Nil(t, err)
+
+	sim, err := NewPoolSimulator(ep)
+	require.Nil(t, err)
+
+	bands, err := sim.GetBandsLiquidity(-3, 6)
+	require.Nil(t, err)
+	require.Len(t, bands, 10)
+	assert.Equal(t, int64(-3), bands[0].Index)
+	assert.Equal(t, "62206732003586843", bands[0].X.Dec())
+	assert.Equal(t, "55065", bands[0].Y.Dec())
+	assert.Equal(t, "59660", bands[9].Y.Dec())
+	for i := range bands {
+		assert.True(t, bands[i].PriceUp.Gt(bands[i].PriceDown))
+		if i > 0 {
+			assert.Equal(t, bands[i-1].PriceDown, bands[i].PriceUp)
+		}
+	}
+
+	bands, err = sim.GetBandsLiquidity(0, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 1}, lo.Map(bands, func(b BandLiquidity, _ int) int64 { return b.Index }))
+
+	_, err = sim.GetBandsLiquidity(1, 0)
+	assert.ErrorIs(t, err, ErrInvalidBandRange)
+}
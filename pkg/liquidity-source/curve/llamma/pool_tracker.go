@@ -3,19 +3,21 @@ package llamma
 import (
 	"context"
 	"math/big"
-	"time"
 
 	"github.com/KyberNetwork/ethrpc"
 	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
+	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/curve/shared"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
 type PoolTracker struct {
@@ -63,6 +65,8 @@ func (t *PoolTracker) GetNewPoolState(
 		activeBand  *big.Int
 		minBand     *big.Int
 		maxBand     *big.Int
+		dynamicFee  *big.Int
+		oracle      common.Address
 
 		balances = make([]*big.Int, 2)
 	)
@@ -113,6 +117,16 @@ func (t *PoolTracker) GetNewPoolState(
 		Target: p.Address,
 		Method: llammaMethodMaxBand,
 	}, []any{&maxBand})
+	calls.AddCall(&ethrpc.Call{
+		ABI:    CurveLlammaABI,
+		Target: p.Address,
+		Method: llammaMethodDynamicFee,
+	}, []any{&dynamicFee})
+	calls.AddCall(&ethrpc.Call{
+		ABI:    CurveLlammaABI,
+		Target: p.Address,
+		Method: llammaMethodPriceOracleContract,
+	}, []any{&oracle})
 	calls.AddCall(&ethrpc.Call{
 		ABI:    shared.ERC20ABI,
 		Target: p.Tokens[0].Address,
@@ -130,6 +144,17 @@ func (t *PoolTracker) GetNewPoolState(
 		return p, err
 	}
 
+	blockTimestamp, err := t.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(resp.BlockNumber).
+		GetCurrentBlockTimestamp()
+	if err != nil {
+		return p, err
+	}
+
+	oracleState, err := t.getOracleState(ctx, p.Address, oracle, resp.BlockNumber, fee)
+	if err != nil {
+		return p, err
+	}
+
 	bands, err := t.getBands(ctx, p.Address, activeBand.Int64(), minBand.Int64(), maxBand.Int64(), t.config.MaxBandLimit)
 	if err != nil {
 		return p, err
@@ -148,6 +173,11 @@ func (t *PoolTracker) GetNewPoolState(
 		MaxBand:           maxBand.Int64(),
 		Bands:             bands,
 		AvailableBalances: availableBalances,
+		DynamicFee:        uint256.MustFromBig(dynamicFee),
+		RawPrice:          oracleState.RawPrice,
+		PrevPo:            oracleState.PrevPo,
+		PrevDfee:          oracleState.PrevDfee,
+		PrevPoTime:        oracleState.PrevPoTime,
 	})
 	if err != nil {
 		lg.WithFields(logger.Fields{
@@ -157,7 +187,7 @@ func (t *PoolTracker) GetNewPoolState(
 	}
 
 	p.Extra = string(extraBytes)
-	p.Timestamp = time.Now().Unix()
+	p.Timestamp = int64(blockTimestamp)
 	p.Reserves = entity.PoolReserves{
 		new(big.Int).Sub(balances[0], adminFeesX).String(),
 		new(big.Int).Sub(balances[1], adminFeesY).String(),
@@ -174,6 +204,140 @@ func (t *PoolTracker) GetNewPoolState(
 	return p, nil
 }
 
+// getOracleState fetches the raw price of the price oracle contract and the oracle state written by the last swap,
+// which is only in storage. The state is left empty if the storage layout doesn't match, checked against fee.
+func (t *PoolTracker) getOracleState(
+	ctx context.Context,
+	poolAddress string, oracle common.Address, blockNumber, fee *big.Int,
+) (Extra, error) {
+	var rawPrice *big.Int
+	if _, err := t.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber).AddCall(&ethrpc.Call{
+		ABI:    CurvePriceOracleABI,
+		Target: oracle.Hex(),
+		Method: priceOracleMethodPrice,
+	}, []any{&rawPrice}).Call(); err != nil {
+		return Extra{}, err
+	}
+
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	args := abi.Arguments{{Name: "value", Type: uint256Type}}
+	slots := []common.Hash{slotFee, slotOldPo, slotOldDfee, slotPrevPoTime}
+	values := make([]*big.Int, len(slots))
+	for i, slot := range slots {
+		resp, err := t.ethrpcClient.NewRequest().SetContext(ctx).GetStorageAt(common.HexToAddress(poolAddress), slot, args)
+		if err != nil {
+			return Extra{}, err
+		}
+		var ok bool
+		if len(resp) == 1 {
+			values[i], ok = resp[0].(*big.Int)
+		}
+		if !ok {
+			return Extra{}, ErrInvalidOracleState
+		}
+	}
+	if values[0].Cmp(fee) != 0 {
+		t.logger.WithFields(logger.Fields{"poolAddress": poolAddress}).Warn("unexpected storage layout")
+		return Extra{}, nil
+	}
+
+	rawPriceSpot, maTime, err := t.getRawPriceSpot(ctx, oracle, blockNumber, rawPrice)
+	if err != nil {
+		return Extra{}, err
+	}
+
+	return Extra{
+		RawPrice:       uint256.MustFromBig(rawPrice),
+		PrevPo:         uint256.MustFromBig(values[1]),
+		PrevDfee:       uint256.MustFromBig(values[2]),
+		PrevPoTime:     values[3].Int64(),
+		RawPriceSpot:   rawPriceSpot,
+		RawPriceMaTime: maTime,
+	}, nil
+}
+
+// getRawPriceSpot returns the price the raw price of a CryptoFromPool oracle moves toward, the raw price scaled by the
+// last prices of its pool over their EMA, together with ma_time of the pool. Both are left empty for other oracles.
+func (t *PoolTracker) getRawPriceSpot(
+	ctx context.Context,
+	oracle common.Address, blockNumber, rawPrice *big.Int,
+) (*uint256.Int, int64, error) {
+	var (
+		cryptoPool               common.Address
+		collateralIx, borrowedIx *big.Int
+		noArgument               bool
+	)
+	resp, err := t.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber).AddCall(&ethrpc.Call{
+		ABI:    CurvePriceOracleABI,
+		Target: oracle.Hex(),
+		Method: priceOracleMethodPool,
+	}, []any{&cryptoPool}).AddCall(&ethrpc.Call{
+		ABI:    CurvePriceOracleABI,
+		Target: oracle.Hex(),
+		Method: priceOracleMethodCollateralIx,
+	}, []any{&collateralIx}).AddCall(&ethrpc.Call{
+		ABI:    CurvePriceOracleABI,
+		Target: oracle.Hex(),
+		Method: priceOracleMethodBorrowedIx,
+	}, []any{&borrowedIx}).AddCall(&ethrpc.Call{
+		ABI:    CurvePriceOracleABI,
+		Target: oracle.Hex(),
+		Method: priceOracleMethodNoArgument,
+	}, []any{&noArgument}).TryAggregate()
+	if err != nil {
+		return nil, 0, err
+	} else if lo.Contains(resp.Result, false) {
+		return nil, 0, nil
+	}
+
+	// _raw_price() = p_collateral * 10**18 / p_borrowed, with the price_oracle() of the pool for the non-zero indexes
+	var (
+		maTime   *big.Int
+		po, last [2]*big.Int
+		indexes  = [2]*big.Int{collateralIx, borrowedIx}
+		calls    = t.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber)
+		poolABI  = CurveCryptoPoolABI
+	)
+	if noArgument {
+		poolABI = CurveTwocryptoPoolABI
+	}
+	calls.AddCall(&ethrpc.Call{
+		ABI:    poolABI,
+		Target: cryptoPool.Hex(),
+		Method: cryptoPoolMethodMaTime,
+	}, []any{&maTime})
+	for i, ix := range indexes {
+		if ix.Sign() == 0 {
+			po[i], last[i] = bignumber.BONE, bignumber.BONE
+			continue
+		}
+		var params []any
+		if !noArgument {
+			params = []any{new(big.Int).Sub(ix, bignumber.One)}
+		}
+		calls.AddCall(&ethrpc.Call{
+			ABI:    poolABI,
+			Target: cryptoPool.Hex(),
+			Method: cryptoPoolMethodPriceOracle,
+			Params: params,
+		}, []any{&po[i]}).AddCall(&ethrpc.Call{
+			ABI:    poolABI,
+			Target: cryptoPool.Hex(),
+			Method: cryptoPoolMethodLastPrices,
+			Params: params,
+		}, []any{&last[i]})
+	}
+	if resp, err = calls.TryAggregate(); err != nil {
+		return nil, 0, err
+	} else if lo.Contains(resp.Result, false) || maTime.Sign() == 0 || po[0].Sign() == 0 || last[1].Sign() == 0 {
+		return nil, 0, nil
+	}
+
+	var spot big.Int
+	spot.Mul(rawPrice, last[0]).Mul(&spot, po[1]).Quo(&spot, po[0]).Quo(&spot, last[1])
+	return uint256.MustFromBig(&spot), maTime.Int64(), nil
+}
+
 func (t *PoolTracker) getBands(
 	ctx context.Context,
 	poolAddress string, activeBand, minBand, maxBand, bandLimit int64,
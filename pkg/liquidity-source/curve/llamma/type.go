@@ -23,6 +23,22 @@ type (
 		Bands       []Band       `json:"bands"`
 
 		AvailableBalances []*uint256.Int `json:"availableBalances"`
+
+		// DynamicFee is dynamic_fee() at the tracked block, the fee floor raised by recent price oracle moves.
+		DynamicFee *uint256.Int `json:"dynamicFee,omitempty"`
+
+		// RawPrice is price() of the price oracle contract at the tracked block, before limit_p_o.
+		RawPrice *uint256.Int `json:"rawPrice,omitempty"`
+		// PrevPo, PrevDfee and PrevPoTime are old_p_o, old_dfee and prev_p_o_time, the oracle state written by the last
+		// swap, which limit_p_o limits the raw price against.
+		PrevPo     *uint256.Int `json:"prevPo,omitempty"`
+		PrevDfee   *uint256.Int `json:"prevDfee,omitempty"`
+		PrevPoTime int64        `json:"prevPoTime,omitempty"`
+
+		// RawPriceSpot and RawPriceMaTime are set when the price oracle prices from the EMA of a Curve pool: the raw price
+		// scaled by the last spot price of the pool over its EMA, and ma_time of the pool.
+		RawPriceSpot   *uint256.Int `json:"rawPriceSpot,omitempty"`
+		RawPriceMaTime int64        `json:"rawPriceMaTime,omitempty"`
 	}
 
 	Meta struct {
@@ -36,6 +52,16 @@ type (
 		BandY *uint256.Int `json:"y"`
 	}
 
+	// BandLiquidity is the soft-liquidation liquidity of a band in token units, together with the oracle price range
+	// [PriceDown, PriceUp] over which the band converts collateral (Y) into borrowed token (X).
+	BandLiquidity struct {
+		Index     int64        `json:"i"`
+		X         *uint256.Int `json:"x"`
+		Y         *uint256.Int `json:"y"`
+		PriceUp   *uint256.Int `json:"pUp"`
+		PriceDown *uint256.Int `json:"pDown"`
+	}
+
 	DetailedTrade struct {
 		InAmount  uint256.Int   `json:"inAmount"`
 		OutAmount uint256.Int   `json:"outAmount"`
@@ -586,7 +586,13 @@ func (t *PoolSimulator) CalculateTokenAmountU256(
 			balances1[i].Sub(&balances1[i], fee)
 		}
 		_ = t.get_D_mem(t.extra.RateMultipliers, balances1[:t.numTokens], a, &d2)
-		mintAmount.Div(number.Mul(&totalSupply, number.Sub(&d2, &d0)), &d0)
+		var diff uint256.Int
+		if deposit {
+			diff.Sub(&d2, &d0)
+		} else {
+			diff.Sub(&d0, &d2)
+		}
+		mintAmount.Div(number.Mul(&totalSupply, &diff), &d0)
 	} else {
 		mintAmount.Set(&d1)
 	}
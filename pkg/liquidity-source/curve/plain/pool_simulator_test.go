@@ -90,6 +90,7 @@ func TestCalcAmountOutPlain(t *testing.T) {
 		require.Nil(t, err)
 		p, err := NewPoolSimulator(poolEntity)
 		require.Nil(t, err)
+		testutil.TestPoolSimulator(t, p)
 		return p
 	})
 
@@ -1,9 +1,14 @@
 package stablemetang
 
 import (
+	"fmt"
+
 	"github.com/KyberNetwork/blockchain-toolkit/number"
-	stableng "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/curve/stable-ng"
 	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/curve/shared"
+	stableng "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/curve/stable-ng"
 )
 
 func (t *PoolSimulator) GetDyUnderlying(
@@ -14,7 +19,17 @@ func (t *PoolSimulator) GetDyUnderlying(
 	addLiquidityInfo *BasePoolAddLiquidityInfo, // in case input is a base coin
 	metaSwapInfo *MetaPoolSwapInfo, // the meta swap component
 	withdrawInfo *BasePoolWithdrawInfo, // in case output is a base coin
-) error {
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if recoveredError, ok := r.(error); ok {
+				err = errors.Wrapf(stableng.ErrExecutionReverted, "recovered error: %v", recoveredError)
+			} else {
+				err = fmt.Errorf("unexpected panic: %v", r)
+			}
+		}
+	}()
+
 	var baseNCoins = len(t.basePool.GetInfo().Tokens)
 	xp := stableng.XpMem(t.Extra.RateMultipliers, t.Reserves)
 
@@ -103,7 +118,7 @@ func (t *PoolSimulator) GetDyUnderlying(
 	}
 
 	// perform normal swap at meta pool
-	err := t.PoolSimulator.GetDyByX(metaSwapInfo.TokenInIndex, metaSwapInfo.TokenOutIndex, x, xp, nil, &metaSwapInfo.AmountOut, &metaSwapInfo.AdminFee)
+	err = t.PoolSimulator.GetDyByX(metaSwapInfo.TokenInIndex, metaSwapInfo.TokenOutIndex, x, xp, nil, &metaSwapInfo.AmountOut, &metaSwapInfo.AdminFee)
 	if err != nil {
 		return err
 	}
@@ -123,3 +138,52 @@ func (t *PoolSimulator) GetDyUnderlying(
 	}
 	return nil
 }
+
+// GetDxUnderlying calculates the input dx of a meta <-> base coin swap given the output dy, like get_dx_underlying of
+// the stableswap-ng meta views contract.
+// https://github.com/curvefi/stableswap-ng/blob/main/contracts/main/CurveStableSwapMetaNGViews.vy
+func (t *PoolSimulator) GetDxUnderlying(i int, j int, _dy *uint256.Int, dx *uint256.Int) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if recoveredError, ok := r.(error); ok {
+				err = errors.Wrapf(stableng.ErrExecutionReverted, "recovered error: %v", recoveredError)
+			} else {
+				err = fmt.Errorf("unexpected panic: %v", r)
+			}
+		}
+	}()
+
+	var base_i = i - MAX_METAPOOL_COIN_INDEX
+	var base_j = j - MAX_METAPOOL_COIN_INDEX
+
+	input_is_base_coin := base_i >= 0
+	output_is_base_coin := base_j >= 0
+	if input_is_base_coin && output_is_base_coin {
+		return ErrAllBasePoolTokens
+	}
+	if !input_is_base_coin && !output_is_base_coin {
+		return ErrAllMetaPoolTokens
+	}
+
+	var adminFee uint256.Int
+	if output_is_base_coin {
+		// lp_amount_burnt = self._base_calc_token_amount(dy, base_j, base_n_coins, BASE_POOL, False)
+		// return self._get_dx(i, 1, lp_amount_burnt, pool, False, N_COINS)
+		var baseNCoins = len(t.basePool.GetInfo().Tokens)
+		var amounts, feeAmounts [shared.MaxTokenCount]uint256.Int
+		amounts[base_j].Set(_dy)
+		var lpAmount uint256.Int
+		if err := t.basePool.CalculateTokenAmountU256(amounts[:baseNCoins], false, &lpAmount, feeAmounts[:baseNCoins]); err != nil {
+			return err
+		}
+		return t.PoolSimulator.GetDx(i, MAX_METAPOOL_COIN_INDEX, &lpAmount, nil, dx, &adminFee)
+	}
+
+	// lp_amount_required = self._get_dx(1, j, dy, pool, False, N_COINS)
+	// return self._base_calc_withdraw_one_coin(lp_amount_required, base_i, BASE_POOL)
+	var lpAmount, dxFee uint256.Int
+	if err := t.PoolSimulator.GetDx(MAX_METAPOOL_COIN_INDEX, j, _dy, nil, &lpAmount, &adminFee); err != nil {
+		return err
+	}
+	return t.basePool.CalculateWithdrawOneCoinU256(&lpAmount, base_i, dx, &dxFee)
+}
@@ -120,6 +120,8 @@ func TestCalcAmountOut(t *testing.T) {
 		if poolEntity.Exchange == stableng.DexType {
 			p, err := stableng.NewPoolSimulator(poolEntity)
 			require.Nil(t, err)
+			// get_dy rounding decreases once the output saturates at the pool balance, as in stable-ng
+			testutil.TestPoolSimulator(t, p, testutil.CheckMonotonic)
 			baseSimsByAddress[poolEntity.Address] = p
 		} else if poolEntity.Exchange == plain.DexType {
 			p, err := plain.NewPoolSimulator(poolEntity)
@@ -170,6 +172,99 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
+func TestCalcAmountIn(t *testing.T) {
+	t.Parallel()
+	basePools := []string{
+		// base pool is NG https://etherscan.io/address/0x383e6b4437b59fff47b619cba855ca29342a8559
+		"{\"address\":\"0x383e6b4437b59fff47b619cba855ca29342a8559\",\"exchange\":\"curve-stable-ng\",\"type\":\"curve-stable-ng\",\"timestamp\":1710325214,\"reserves\":[\"20645714947000\",\"16619279610257\",\"37260809758180318203561662\"],\"tokens\":[{\"address\":\"0x6c3ea9036406852006290770bedfcaba0e23a0e8\",\"symbol\":\"PYUSD\",\"decimals\":6,\"swappable\":true},{\"address\":\"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48\",\"symbol\":\"USDC\",\"decimals\":6,\"swappable\":true}],\"extra\":\"{\\\"InitialA\\\":\\\"15000\\\",\\\"FutureA\\\":\\\"15000\\\",\\\"InitialATime\\\":0,\\\"FutureATime\\\":0,\\\"SwapFee\\\":\\\"1000000\\\",\\\"AdminFee\\\":\\\"5000000000\\\",\\\"RateMultipliers\\\":[\\\"1000000000000000000000000000000\\\",\\\"1000000000000000000000000000000\\\"]}\",\"staticExtra\":\"{\\\"APrecision\\\":\\\"100\\\",\\\"OffpegFeeMultiplier\\\":\\\"50000000000\\\",\\\"IsNativeCoins\\\":[false,false]}\",\"blockNumber\":19425514}",
+
+		// base pool is plain https://etherscan.io/address/0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7
+		"{\"address\":\"0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7\",\"exchange\":\"curve-stable-plain\",\"type\":\"curve-stable-plain\",\"timestamp\":1710405854,\"reserves\":[\"74882317978601283428112533\",\"76066551886323\",\"32115318520985\",\"177637651221630809031052488\"],\"tokens\":[{\"address\":\"0x6b175474e89094c44da98b954eedeac495271d0f\",\"symbol\":\"DAI\",\"decimals\":18,\"swappable\":true},{\"address\":\"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48\",\"symbol\":\"USDC\",\"decimals\":6,\"swappable\":true},{\"address\":\"0xdac17f958d2ee523a2206206994597c13d831ec7\",\"symbol\":\"USDT\",\"decimals\":6,\"swappable\":true}],\"extra\":\"{\\\"InitialA\\\":\\\"5000\\\",\\\"FutureA\\\":\\\"2000\\\",\\\"InitialATime\\\":1653559305,\\\"FutureATime\\\":1654158027,\\\"SwapFee\\\":\\\"1000000\\\",\\\"AdminFee\\\":\\\"5000000000\\\"}\",\"staticExtra\":\"{\\\"APrecision\\\":\\\"1\\\",\\\"LpToken\\\":\\\"0x6c3F90f043a72FA612cbac8115EE7e52BDe6E490\\\",\\\"IsNativeCoin\\\":[false,false,false]}\",\"blockNumber\":19432140}",
+	}
+
+	pools := []string{
+		// https://etherscan.io/address/0x9e10f9fb6f0d32b350cee2618662243d4f24c64a
+		"{\"address\":\"0x9e10f9fb6f0d32b350cee2618662243d4f24c64a\",\"exchange\":\"curve-stable-meta-ng\",\"type\":\"curve-stable-meta-ng\",\"timestamp\":1710325225,\"reserves\":[\"1400402037639032709376918\",\"389831262966377525851519\",\"1786431867672163347040320\"],\"tokens\":[{\"address\":\"0x4591dbff62656e7859afe5e45f6f47d3669fbb28\",\"symbol\":\"mkUSD\",\"decimals\":18,\"swappable\":true},{\"address\":\"0x383e6b4437b59fff47b619cba855ca29342a8559\",\"symbol\":\"PYUSDUSDC\",\"decimals\":18,\"swappable\":true}],\"extra\":\"{\\\"InitialA\\\":\\\"15000\\\",\\\"FutureA\\\":\\\"15000\\\",\\\"InitialATime\\\":0,\\\"FutureATime\\\":0,\\\"SwapFee\\\":\\\"4000000\\\",\\\"AdminFee\\\":\\\"5000000000\\\",\\\"RateMultipliers\\\":[\\\"1000000000000000000\\\",\\\"1000073197173325044\\\"]}\",\"staticExtra\":\"{\\\"APrecision\\\":\\\"100\\\",\\\"OffpegFeeMultiplier\\\":\\\"20000000000\\\",\\\"IsNativeCoins\\\":[false,false],\\\"BasePool\\\":\\\"0x383e6b4437b59fff47b619cba855ca29342a8559\\\"}\",\"blockNumber\":19425514}",
+
+		// https://etherscan.io/address/0x2482dfb5a65d901d137742ab1095f26374509352
+		"{\"address\":\"0x2482dfb5a65d901d137742ab1095f26374509352\",\"exchange\":\"curve-stable-meta-ng\",\"type\":\"curve-stable-meta-ng\",\"timestamp\":1710405853,\"reserves\":[\"4556837199510378636842480\",\"113547535917173130561003\",\"4650797641270672114959944\"],\"tokens\":[{\"address\":\"0x466a756e9a7401b5e2444a3fcb3c2c12fbea0a54\",\"symbol\":\"PUSd\",\"decimals\":18,\"swappable\":true},{\"address\":\"0x6c3f90f043a72fa612cbac8115ee7e52bde6e490\",\"symbol\":\"3Crv\",\"decimals\":18,\"swappable\":true}],\"extra\":\"{\\\"InitialA\\\":\\\"100000\\\",\\\"FutureA\\\":\\\"100000\\\",\\\"InitialATime\\\":0,\\\"FutureATime\\\":0,\\\"SwapFee\\\":\\\"4000000\\\",\\\"AdminFee\\\":\\\"5000000000\\\",\\\"RateMultipliers\\\":[\\\"1000000000000000000\\\",\\\"1030506792713195533\\\"]}\",\"staticExtra\":\"{\\\"APrecision\\\":\\\"100\\\",\\\"OffpegFeeMultiplier\\\":\\\"20000000000\\\",\\\"IsNativeCoins\\\":[false,false],\\\"BasePool\\\":\\\"0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7\\\"}\",\"blockNumber\":19432140}",
+	}
+
+	baseSimsByAddress := make(map[string]pool.IPoolSimulator, len(basePools))
+	for _, basePool := range basePools {
+		var poolEntity entity.Pool
+		err := json.Unmarshal([]byte(basePool), &poolEntity)
+		require.Nil(t, err)
+
+		if poolEntity.Exchange == stableng.DexType {
+			p, err := stableng.NewPoolSimulator(poolEntity)
+			require.Nil(t, err)
+			baseSimsByAddress[poolEntity.Address] = p
+		} else if poolEntity.Exchange == plain.DexType {
+			p, err := plain.NewPoolSimulator(poolEntity)
+			require.Nil(t, err)
+			baseSimsByAddress[poolEntity.Address] = p
+		}
+	}
+
+	sims := make([]*PoolSimulator, 0, len(pools))
+	for _, poolRedis := range pools {
+		var poolEntity entity.Pool
+		err := json.Unmarshal([]byte(poolRedis), &poolEntity)
+		require.Nil(t, err)
+
+		p, err := NewPoolSimulator(poolEntity, baseSimsByAddress)
+		require.Nil(t, err)
+
+		testutil.TestCalcAmountIn(t, p)
+		sims = append(sims, p)
+	}
+
+	testcases := []struct {
+		poolIdx   int
+		in        string
+		out       string
+		amountOut string
+		err       error
+	}{
+		// mkUSD -> USDC, PYUSD -> mkUSD
+		{0, "0x4591dbff62656e7859afe5e45f6f47d3669fbb28", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "1000000000", nil},
+		{0, "0x6c3ea9036406852006290770bedfcaba0e23a0e8", "0x4591dbff62656e7859afe5e45f6f47d3669fbb28", "1000000000000000000000", nil},
+		// PUSd -> USDT, DAI -> PUSd
+		{1, "0x466a756e9a7401b5e2444a3fcb3c2c12fbea0a54", "0xdac17f958d2ee523a2206206994597c13d831ec7", "1000000000", nil},
+		{1, "0x6b175474e89094c44da98b954eedeac495271d0f", "0x466a756e9a7401b5e2444a3fcb3c2c12fbea0a54", "1000000000000000000000", nil},
+		// PYUSD -> USDC, DAI -> USDT
+		{0, "0x6c3ea9036406852006290770bedfcaba0e23a0e8", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "1000000000", ErrAllBasePoolTokens},
+		{1, "0x6b175474e89094c44da98b954eedeac495271d0f", "0xdac17f958d2ee523a2206206994597c13d831ec7", "1000000000", ErrAllBasePoolTokens},
+	}
+	for idx, tc := range testcases {
+		t.Run(fmt.Sprintf("underlying %d", idx), func(t *testing.T) {
+			p := sims[tc.poolIdx]
+			amountOut := bignumber.NewBig10(tc.amountOut)
+			in, err := p.CalcAmountIn(pool.CalcAmountInParams{
+				TokenAmountOut: pool.TokenAmount{Token: tc.out, Amount: amountOut},
+				TokenIn:        tc.in,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// get_dx_underlying leaves out the base pool fee like the on-chain view does, so the round trip may fall
+			// short of the requested amount by up to twice that fee (0.01%)
+			out, err := p.CalcAmountOut(pool.CalcAmountOutParams{
+				TokenAmountIn: *in.TokenAmountIn,
+				TokenOut:      tc.out,
+			})
+			require.NoError(t, err)
+			expected, _ := amountOut.Float64()
+			actual, _ := out.TokenAmountOut.Amount.Float64()
+			assert.InEpsilon(t, expected, actual, 2e-4)
+		})
+	}
+}
+
 func TestUpdateBalance(t *testing.T) {
 	t.Parallel()
 	basePools := []string{
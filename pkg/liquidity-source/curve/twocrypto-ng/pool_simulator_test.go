@@ -68,6 +68,7 @@ func TestCalcAmountOut(t *testing.T) {
 		require.Nil(t, err)
 		p, err := NewPoolSimulator(poolEntity)
 		require.Nil(t, err)
+		testutil.TestPoolSimulator(t, p)
 		return p
 	})
 
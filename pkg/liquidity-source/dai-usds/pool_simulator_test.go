@@ -74,6 +74,7 @@ func TestGetAmountOut(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool, err := NewPoolSimulator(tc.entityPool)
 			assert.Nil(t, err)
+			testutil.TestPoolSimulator(t, pool)
 			calcAmountOutResult, err := testutil.MustConcurrentSafe(t, func() (*poolPkg.CalcAmountOutResult, error) {
 				return pool.CalcAmountOut(poolPkg.CalcAmountOutParams{
 					TokenAmountIn: tc.tokenAmountIn,
@@ -11,6 +11,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	utils "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func Test_calcEMA(t *testing.T) {
@@ -474,6 +475,7 @@ func TestPoolSimulator(t *testing.T) {
 				lastTradeLiquiditySum:    tt.fields.lastTradeLiquiditySum,
 				lastTradeBlockNumber:     tt.fields.lastTradeBlockNumber,
 			}
+			testutil.TestPoolSimulator(t, s)
 
 			indexIn, indexOut := s.GetTokenIndex(tt.calcAmountOutParams.TokenAmountIn.Token), s.GetTokenIndex(tt.calcAmountOutParams.TokenOut)
 			assert.GreaterOrEqual(t, indexIn, 0)
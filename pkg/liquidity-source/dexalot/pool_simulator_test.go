@@ -8,6 +8,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 /*
@@ -72,6 +73,7 @@ func TestPoolSimulator_NewPool(t *testing.T) {
 	t.Parallel()
 	poolSimulator, err := NewPoolSimulator(entityPool)
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSimulator)
 	assert.Equal(t, "0x49d5c2bdffac6ce2bfdb6640f4f80f226bc10bab", poolSimulator.Token0.Address)
 	assert.Equal(t, "0xb97ef9ef8734c71904d8002f8b6bc66dd9c48a6e", poolSimulator.Token1.Address)
 	assert.NotNil(t, poolSimulator.ZeroToOnePriceLevels)
@@ -144,6 +144,9 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		require.Nil(t, err)
 		return p
 	})
+	for _, p := range sims {
+		testutil.TestPoolSimulator(t, p)
+	}
 
 	for idx, tc := range testcases {
 		t.Run(fmt.Sprintf("test %d", idx+1), func(t *testing.T) {
@@ -154,6 +154,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		require.Nil(t, err)
 		p, err := NewPoolSimulator(poolEntity)
 		require.Nil(t, err)
+		testutil.TestPoolSimulator(t, p)
 		return p
 	})
 
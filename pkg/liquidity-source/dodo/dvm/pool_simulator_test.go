@@ -130,6 +130,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		require.Nil(t, err)
 		p, err := NewPoolSimulator(poolEntity)
 		require.Nil(t, err)
+		testutil.TestPoolSimulator(t, p)
 		return p
 	})
 
@@ -98,6 +98,7 @@ func TestBasePool(t *testing.T) {
 	)
 	poolSim, err := NewPoolSimulator(*entityPool)
 	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
 
 	expectedToken0Amount := big.NewInt(2436479431)
 
@@ -3,14 +3,45 @@ package erc4626
 type Config struct {
 	DexId  string              `json:"dexId"`
 	Vaults map[string]VaultCfg `json:"vaults"`
+
+	// Tokens are candidate vaults that are probed and listed if they turn out to be ERC4626 vaults
+	Tokens []string `json:"tokens"`
+	// Factories are the factories whose creation logs list new vaults
+	Factories []FactoryCfg `json:"factories"`
+	// DefaultVault is the config of the vaults discovered from Tokens or Factories
+	DefaultVault VaultCfg `json:"defaultVault"`
 }
 
 type VaultCfg struct {
 	Gas       GasCfg   `json:"gas"`
 	SwapTypes SwapType `json:"swapTypes"`
+	// AccrueRate is set for vaults whose share price grows continuously, so that its growth is estimated between
+	// tracker runs and projected to the time being quoted. Generic vaults expose no growth parameters, so the growth is
+	// the per-second growth of the share price between the last two tracker runs (see accrual.Estimate), and quotes do
+	// not match previewDeposit/previewRedeem to the wei. It is only projected if it is within 10% of the growth of the
+	// run before, and for at most one tracker interval, so as long as the vault keeps growing like that, the projected
+	// share price is off by at most 10% of its growth over one interval, e.g. 1e-8 of it at 5% APR and 1-minute runs.
+	// Vaults exposing their growth parameters, such as sDAI or sUSDe, have their own sources quoting them exactly.
+	AccrueRate bool `json:"accrueRate"`
 }
 
 type GasCfg struct {
 	Deposit uint64 `json:"deposit"`
 	Redeem  uint64 `json:"redeem"`
 }
+
+type FactoryCfg struct {
+	Address string `json:"address"`
+	// EventId is the topic0 of the event the factory emits when creating a vault
+	EventId string `json:"eventId"`
+	// VaultTopic is the index of the topic of the event holding the address of the created vault
+	VaultTopic int `json:"vaultTopic"`
+}
+
+// vaultCfg returns the config of a vault, falling back to DefaultVault for discovered vaults
+func (c *Config) vaultCfg(vaultAddr string) VaultCfg {
+	if vaultCfg, ok := c.Vaults[vaultAddr]; ok {
+		return vaultCfg
+	}
+	return c.DefaultVault
+}
@@ -18,6 +18,10 @@ const (
 	erc4626MethodExitFeeBasisPoints    = "exitFeeBasisPoints"
 	erc4626MethodGetExitFeeBasisPoints = "getExitFeeBasisPoints"
 	erc4626MethodMinRedeemRatio        = "minRedeemRatio"
+	erc4626MethodConvertToShares       = "convertToShares"
+	erc4626MethodConvertToAssets       = "convertToAssets"
+	erc4626MethodPreviewDeposit        = "previewDeposit"
+	erc4626MethodPreviewRedeem         = "previewRedeem"
 
 	Bps            = 10000
 	RatioPrecision = 1e18
@@ -27,6 +31,7 @@ var (
 	AddrDummy = common.HexToAddress("0x1371783000000000000000000000000001371760")
 
 	ErrInvalidToken              = errors.New("invalid token")
+	ErrInvalidVault              = errors.New("invalid vault")
 	ErrUnsupportedSwap           = errors.New("unsupported swap")
 	ErrERC4626DepositMoreThanMax = errors.New("ERC4626: deposit more than max")
 	ErrERC4626RedeemMoreThanMax  = errors.New("ERC4626: redeem more than max")
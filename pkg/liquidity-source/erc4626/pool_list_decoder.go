@@ -0,0 +1,74 @@
+package erc4626
+
+import (
+	"context"
+	"strings"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+)
+
+// PoolsListDecoder discovers vaults from the creation logs of the configured factories, probing each of them as the
+// pools list updater does.
+type PoolsListDecoder struct {
+	cfg          *Config
+	ethrpcClient *ethrpc.Client
+}
+
+var _ = pooldecode.RegisterListFactoryCE(DexType, NewPoolsListDecoder)
+
+func NewPoolsListDecoder(cfg *Config, ethrpcClient *ethrpc.Client) *PoolsListDecoder {
+	return &PoolsListDecoder{
+		cfg:          cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+func (d *PoolsListDecoder) GetKeys(_ context.Context) ([]string, error) {
+	return lo.Map(d.cfg.Factories, func(factory FactoryCfg, _ int) string {
+		return strings.ToLower(factory.Address)
+	}), nil
+}
+
+func (d *PoolsListDecoder) Decode(_ context.Context, logs []types.Log) (map[string][]types.Log, error) {
+	addressLogs := make(map[string][]types.Log)
+	for _, factory := range d.cfg.Factories {
+		for _, log := range pooldecode.FilterLogs(logs, factory.Address, common.HexToHash(factory.EventId)) {
+			if vault, ok := vaultOf(factory, log); ok {
+				addressLogs[vault] = append(addressLogs[vault], log)
+			}
+		}
+	}
+	return addressLogs, nil
+}
+
+func (d *PoolsListDecoder) DecodeNewPools(ctx context.Context, logs []types.Log) ([]entity.Pool, error) {
+	addressLogs, _ := d.Decode(ctx, logs)
+	pools := make([]entity.Pool, 0, len(addressLogs))
+	for vault := range addressLogs {
+		pool, err := newPool(ctx, d.ethrpcClient, d.cfg.DexId, vault, d.cfg.vaultCfg(vault))
+		if errors.Is(err, ErrInvalidVault) {
+			logger.WithFields(logger.Fields{"dexId": d.cfg.DexId, "vault": vault}).Warn("skip invalid vault")
+			continue
+		} else if err != nil {
+			return nil, errors.WithMessage(err, vault)
+		}
+		pools = append(pools, *pool)
+	}
+	return pools, nil
+}
+
+// vaultOf returns the address of the vault created by a log of a factory
+func vaultOf(factory FactoryCfg, log types.Log) (string, bool) {
+	if factory.VaultTopic <= 0 || factory.VaultTopic >= len(log.Topics) {
+		return "", false
+	}
+	return strings.ToLower(common.BytesToAddress(log.Topics[factory.VaultTopic].Bytes()).Hex()), true
+}
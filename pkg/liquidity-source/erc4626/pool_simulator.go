@@ -2,6 +2,7 @@ package erc4626
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/KyberNetwork/blockchain-toolkit/number"
 	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
 	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
 	bignum "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
@@ -28,12 +30,17 @@ type (
 		EntryFeeBasisPoints uint64
 		ExitFeeBasisPoints  uint64
 
-		gas Gas
+		virtualShares *uint256.Int
+		virtualAssets *uint256.Int
+		growth        *accrual.Linear
+		gas           Gas
 	}
 )
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
 
+var now = time.Now
+
 func NewPoolSimulator(p entity.Pool) (*PoolSimulator, error) {
 	tokens := lo.Map(p.Tokens, func(e *entity.PoolToken, _ int) string { return e.Address })
 	reserves := lo.Map(p.Reserves, func(e string, _ int) *big.Int { return bignum.NewBig(e) })
@@ -42,6 +49,13 @@ func NewPoolSimulator(p entity.Pool) (*PoolSimulator, error) {
 	if err := json.Unmarshal([]byte(p.Extra), &extra); err != nil {
 		return nil, err
 	}
+	var staticExtra StaticExtra
+	if p.StaticExtra != "" {
+		if err := json.Unmarshal([]byte(p.StaticExtra), &staticExtra); err != nil {
+			return nil, err
+		}
+	}
+	staticExtra = extra.quirks(staticExtra)
 
 	return &PoolSimulator{
 		Pool: pool.Pool{Info: pool.PoolInfo{
@@ -57,12 +71,30 @@ func NewPoolSimulator(p entity.Pool) (*PoolSimulator, error) {
 		TotalAssets:         uint256.MustFromDecimal(p.Reserves[1]),
 		MaxDeposit:          extra.MaxDeposit,
 		MaxRedeem:           extra.MaxRedeem,
-		EntryFeeBasisPoints: extra.EntryFeeBps,
-		ExitFeeBasisPoints:  extra.ExitFeeBps,
+		EntryFeeBasisPoints: staticExtra.EntryFeeBps,
+		ExitFeeBasisPoints:  staticExtra.ExitFeeBps,
+		virtualShares:       staticExtra.VirtualShares,
+		virtualAssets:       staticExtra.VirtualAssets,
+		growth:              extra.Growth,
 		gas:                 extra.Gas,
 	}, nil
 }
 
+// quirks returns the quirks of staticExtra with the fees detected again by the tracker, falling back to the quirks of
+// pools tracked before StaticExtra
+func (e *Extra) quirks(staticExtra StaticExtra) StaticExtra {
+	if e.EntryFeeBps != nil {
+		staticExtra.EntryFeeBps = *e.EntryFeeBps
+	}
+	if e.ExitFeeBps != nil {
+		staticExtra.ExitFeeBps = *e.ExitFeeBps
+	}
+	if staticExtra.VirtualShares == nil {
+		staticExtra.VirtualShares, staticExtra.VirtualAssets = e.VirtualShares, e.VirtualAssets
+	}
+	return staticExtra
+}
+
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
 	tokenIn, tokenOut := params.TokenAmountIn.Token, params.TokenOut
 
@@ -151,11 +183,15 @@ func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 		assetsWithoutFee = uint256.MustFromBig(tokenAmountIn.Amount)
 	}
 
+	timestamp := uint64(now().Unix())
+	totalAssets := s.totalAssets(timestamp)
+	s.growth = s.growth.At(timestamp)
+
 	if swapType == Deposit {
-		s.TotalAssets = new(uint256.Int).Add(s.TotalAssets, assetsWithoutFee)
+		s.TotalAssets = new(uint256.Int).Add(totalAssets, assetsWithoutFee)
 		s.TotalSupply = new(uint256.Int).Add(s.TotalSupply, uint256.MustFromBig(tokenAmountOut.Amount))
 	} else {
-		s.TotalAssets = new(uint256.Int).Sub(s.TotalAssets, assetsWithoutFee)
+		s.TotalAssets = new(uint256.Int).Sub(totalAssets, assetsWithoutFee)
 		s.TotalSupply = new(uint256.Int).Sub(s.TotalSupply, uint256.MustFromBig(tokenAmountIn.Amount))
 	}
 }
@@ -171,7 +207,8 @@ func (s *PoolSimulator) deposit(assets *uint256.Int, feeBps int64, roundUp bool)
 func (s *PoolSimulator) previewDeposit(assets *uint256.Int, feeBps int64, roundUp bool) (*uint256.Int, *uint256.Int,
 	error) {
 	assets = deductFee(assets, feeBps)
-	shares, err := lo.Ternary(roundUp, v3Utils.MulDivRoundingUp, v3Utils.MulDiv)(assets, s.TotalSupply, s.TotalAssets)
+	totalSupply, totalAssets := s.virtualTotals(uint64(now().Unix()))
+	shares, err := lo.Ternary(roundUp, v3Utils.MulDivRoundingUp, v3Utils.MulDiv)(assets, totalSupply, totalAssets)
 	return shares, assets, err
 }
 
@@ -193,7 +230,8 @@ func (s *PoolSimulator) redeem(shares *uint256.Int, feeBps int64, roundUp bool)
 
 func (s *PoolSimulator) previewRedeem(shares *uint256.Int, feeBps int64, roundUp bool) (*uint256.Int, *uint256.Int,
 	error) {
-	assets, err := lo.Ternary(roundUp, v3Utils.MulDivRoundingUp, v3Utils.MulDiv)(shares, s.TotalAssets, s.TotalSupply)
+	totalSupply, totalAssets := s.virtualTotals(uint64(now().Unix()))
+	assets, err := lo.Ternary(roundUp, v3Utils.MulDivRoundingUp, v3Utils.MulDiv)(shares, totalAssets, totalSupply)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -209,6 +247,24 @@ func (s *PoolSimulator) maxRedeem() *uint256.Int {
 	return s.MaxRedeem
 }
 
+// totalAssets returns the total assets at timestamp, projected by the growth of the share price since it was fetched
+func (s *PoolSimulator) totalAssets(timestamp uint64) *uint256.Int {
+	return s.growth.Project(s.TotalAssets, timestamp)
+}
+
+// virtualTotals returns the total supply and assets at timestamp to convert between shares and assets with, including
+// the virtual shares and assets of the vault
+func (s *PoolSimulator) virtualTotals(timestamp uint64) (*uint256.Int, *uint256.Int) {
+	totalSupply, totalAssets := s.TotalSupply, s.totalAssets(timestamp)
+	if s.virtualShares != nil {
+		totalSupply = new(uint256.Int).Add(totalSupply, s.virtualShares)
+	}
+	if s.virtualAssets != nil {
+		totalAssets = new(uint256.Int).Add(totalAssets, s.virtualAssets)
+	}
+	return totalSupply, totalAssets
+}
+
 func deductFee(assets *uint256.Int, feeBps int64) *uint256.Int {
 	if feeBps != 0 {
 		var tmp uint256.Int
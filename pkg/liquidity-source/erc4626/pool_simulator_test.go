@@ -8,6 +8,7 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	bignum "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
@@ -170,6 +171,7 @@ func TestCalcAmountOut(t *testing.T) {
 				ExitFeeBasisPoints:  tt.exitFee,
 				supportedSwapType:   Both,
 			}
+			testutil.TestPoolSimulator(t, sim)
 
 			for i, param := range tt.params {
 				t.Run(tt.name+"#"+strconv.Itoa(i), func(t *testing.T) {
@@ -261,3 +263,43 @@ func TestCalcAmountIn(t *testing.T) {
 		})
 	}
 }
+
+func TestGrowth(t *testing.T) {
+	t.Parallel()
+	p := entity.Pool{
+		Reserves: entity.PoolReserves{"1000000000000000000000", "1100000000000000000000"},
+		Extra:    `{"g":{},"aR":{"t":1700000000,"r":"10500000000"}}`,
+	}
+	state := &PoolState{
+		TotalSupply: bignum.NewBig("1000000000000000000000"),
+		TotalAssets: bignum.NewBig("1100001100000000000000"),
+	}
+
+	growth := estimateGrowth(p, state, 1700000100)
+	assert.Equal(t, uint64(1700000100), growth.Timestamp)
+	assert.Equal(t, "10000000000", growth.PerSecond.Dec())
+	assert.Equal(t, uint64(100), growth.Horizon)
+	// a state tracked at the same block keeps the growth
+	p.Extra = `{"aR":{"t":1700000100,"r":"10000000000","h":100}}`
+	assert.Equal(t, growth, estimateGrowth(p, state, 1700000100))
+	// a step that does not match the previous one is not projected
+	p.Extra = `{"aR":{"t":1700000000}}`
+	assert.Zero(t, estimateGrowth(p, state, 1700000100).Horizon)
+	p.Extra = `{"aR":{"t":1700000000,"r":"20000000000"}}`
+	assert.Zero(t, estimateGrowth(p, state, 1700000100).Horizon)
+	p.Extra = `{}`
+	assert.Nil(t, estimateGrowth(p, state, 1700000100).PerSecond)
+
+	s := &PoolSimulator{
+		Pool:              pool.Pool{Info: pool.PoolInfo{Tokens: []string{"A", "B"}}},
+		TotalSupply:       uint256.MustFromBig(state.TotalSupply),
+		TotalAssets:       uint256.MustFromBig(state.TotalAssets),
+		supportedSwapType: Both,
+		growth:            growth,
+	}
+	assert.Equal(t, "1100001100000000000000", s.totalAssets(1700000000).Dec())
+	assert.Equal(t, "1100001100000000000000", s.totalAssets(1700000100).Dec())
+	assert.Equal(t, "1100002200001100000000", s.totalAssets(1700000200).Dec())
+	// the projection stops at the sampling interval
+	assert.Equal(t, "1100002200001100000000", s.totalAssets(1700000300).Dec())
+}
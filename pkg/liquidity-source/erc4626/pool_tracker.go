@@ -14,6 +14,8 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
+	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
 )
 
 type PoolTracker struct {
@@ -69,7 +71,7 @@ func (t *PoolTracker) getNewPoolState(
 	}()
 
 	vaultAddr := p.Tokens[0].Address
-	vaultCfg := t.cfg.Vaults[vaultAddr]
+	vaultCfg := t.cfg.vaultCfg(vaultAddr)
 	_, state, err := fetchAssetAndState(ctx, t.ethrpcClient, vaultAddr, vaultCfg, false, overrides)
 	if err != nil {
 		lg.WithFields(logger.Fields{
@@ -79,14 +81,39 @@ func (t *PoolTracker) getNewPoolState(
 		return p, err
 	}
 
-	extraBytes, err := json.Marshal(Extra{
-		Gas:         Gas(vaultCfg.Gas),
-		SwapTypes:   vaultCfg.SwapTypes,
-		MaxDeposit:  uint256.MustFromBig(state.MaxDeposit),
-		MaxRedeem:   uint256.MustFromBig(state.MaxRedeem),
-		EntryFeeBps: state.EntryFeeBps,
-		ExitFeeBps:  state.ExitFeeBps,
-	})
+	var growth *accrual.Linear
+	if vaultCfg.AccrueRate {
+		blockTimestamp, err := t.ethrpcClient.NewRequest().SetContext(ctx).SetOverrides(overrides).
+			GetCurrentBlockTimestamp()
+		if err != nil {
+			lg.WithFields(logger.Fields{
+				"error": err,
+			}).Errorf("failed to fetch block timestamp")
+			return p, err
+		}
+		growth = estimateGrowth(p, state, blockTimestamp)
+	}
+
+	var prevExtra Extra
+	var staticExtra StaticExtra
+	_ = json.Unmarshal([]byte(p.Extra), &prevExtra)
+	if p.StaticExtra != "" {
+		_ = json.Unmarshal([]byte(p.StaticExtra), &staticExtra)
+	}
+	state.redetectFees(prevExtra.quirks(staticExtra))
+
+	extra := newExtra(vaultCfg, state)
+	extra.Growth = growth
+	if state.EntryFeeBps != staticExtra.EntryFeeBps {
+		extra.EntryFeeBps = &state.EntryFeeBps
+	}
+	if state.ExitFeeBps != staticExtra.ExitFeeBps {
+		extra.ExitFeeBps = &state.ExitFeeBps
+	}
+	if staticExtra.VirtualShares == nil {
+		extra.VirtualShares, extra.VirtualAssets = prevExtra.VirtualShares, prevExtra.VirtualAssets
+	}
+	extraBytes, err := json.Marshal(extra)
 	if err != nil {
 		lg.WithFields(logger.Fields{
 			"error": err,
@@ -101,3 +128,25 @@ func (t *PoolTracker) getNewPoolState(
 
 	return p, nil
 }
+
+// estimateGrowth estimates the growth of the share price of a vault from its previously tracked state
+func estimateGrowth(p entity.Pool, state *PoolState, blockTimestamp uint64) *accrual.Linear {
+	var extra Extra
+	if err := json.Unmarshal([]byte(p.Extra), &extra); err != nil || extra.Growth == nil || len(p.Reserves) != 2 {
+		return accrual.Estimate(nil, nil, nil, blockTimestamp)
+	}
+
+	prevSharePrice := sharePrice(uint256.MustFromDecimal(p.Reserves[0]), uint256.MustFromDecimal(p.Reserves[1]))
+	return accrual.Estimate(extra.Growth, prevSharePrice,
+		sharePrice(uint256.MustFromBig(state.TotalSupply), uint256.MustFromBig(state.TotalAssets)), blockTimestamp)
+}
+
+// sharePrice returns the assets per share in 1e36, which is precise enough for the growth of vaults of assets with
+// fewer decimals than their shares
+func sharePrice(totalSupply, totalAssets *uint256.Int) *uint256.Int {
+	if totalSupply.IsZero() {
+		return nil
+	}
+	price, _ := new(uint256.Int).MulDivOverflow(totalAssets, big256.TenPow(36), totalSupply)
+	return price
+}
@@ -65,6 +65,21 @@ func (u *PoolsListUpdater) GetNewPools(ctx context.Context, metadataBytes []byte
 		}
 	}
 
+	// candidate tokens that are not ERC4626 vaults are skipped
+	for _, token := range u.cfg.Tokens {
+		if _, ok := u.cfg.Vaults[token]; ok {
+			continue
+		}
+		pool, err := u.getNewPool(ctx, token, u.cfg.DefaultVault)
+		if errors.Is(err, ErrInvalidVault) {
+			continue
+		} else if err != nil {
+			errs = append(errs, errors.WithMessage(err, token))
+		} else {
+			pools = append(pools, *pool)
+		}
+	}
+
 	if len(errs) > 0 {
 		return nil, metadataBytes, errors.Errorf("failed to get new pools: %v", errs)
 	}
@@ -75,26 +90,30 @@ func (u *PoolsListUpdater) GetNewPools(ctx context.Context, metadataBytes []byte
 }
 
 func (u *PoolsListUpdater) getNewPool(ctx context.Context, vaultAddr string, vaultCfg VaultCfg) (*entity.Pool, error) {
-	assetToken, state, err := fetchAssetAndState(ctx, u.ethrpcClient, vaultAddr, vaultCfg, true, nil)
+	pool, err := newPool(ctx, u.ethrpcClient, u.cfg.DexId, vaultAddr, vaultCfg)
 	if err != nil {
 		u.logger.WithFields(logger.Fields{
+			"vault": vaultAddr,
 			"error": err,
 		}).Error("failed to fetchAssetAndState")
+	}
+	return pool, err
+}
+
+// newPool probes a vault and returns its pool
+func newPool(ctx context.Context, ethrpcClient *ethrpc.Client, dexId string, vaultAddr string,
+	vaultCfg VaultCfg) (*entity.Pool, error) {
+	assetToken, state, err := fetchAssetAndState(ctx, ethrpcClient, vaultAddr, vaultCfg, true, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	extraBytes, _ := json.Marshal(Extra{
-		Gas:         Gas(vaultCfg.Gas),
-		SwapTypes:   vaultCfg.SwapTypes,
-		MaxDeposit:  uint256.MustFromBig(state.MaxDeposit),
-		MaxRedeem:   uint256.MustFromBig(state.MaxRedeem),
-		EntryFeeBps: state.EntryFeeBps,
-		ExitFeeBps:  state.ExitFeeBps,
-	})
+	extraBytes, _ := json.Marshal(newExtra(vaultCfg, state))
+	staticExtraBytes, _ := json.Marshal(newStaticExtra(state))
 
 	return &entity.Pool{
 		Address:   strings.ToLower(vaultAddr),
-		Exchange:  u.cfg.DexId,
+		Exchange:  dexId,
 		Type:      DexType,
 		Timestamp: time.Now().Unix(),
 		Reserves:  entity.PoolReserves{state.TotalSupply.String(), state.TotalAssets.String()},
@@ -103,17 +122,40 @@ func (u *PoolsListUpdater) getNewPool(ctx context.Context, vaultAddr string, vau
 			{Address: hexutil.Encode(assetToken[:]), Swappable: true},
 		},
 		Extra:       string(extraBytes),
+		StaticExtra: string(staticExtraBytes),
 		BlockNumber: state.blockNumber,
 	}, nil
 }
 
+// newExtra returns the extra of a vault from its config and state, leaving out its paused swaps
+func newExtra(vaultCfg VaultCfg, state *PoolState) Extra {
+	return Extra{
+		Gas:        Gas(vaultCfg.Gas),
+		SwapTypes:  vaultCfg.SwapTypes &^ state.PausedSwapTypes,
+		MaxDeposit: uint256.MustFromBig(state.MaxDeposit),
+		MaxRedeem:  uint256.MustFromBig(state.MaxRedeem),
+	}
+}
+
+// newStaticExtra returns the static extra of a vault from the quirks detected in its state
+func newStaticExtra(state *PoolState) StaticExtra {
+	return StaticExtra{
+		EntryFeeBps:   state.EntryFeeBps,
+		ExitFeeBps:    state.ExitFeeBps,
+		VirtualShares: uint256.MustFromBig(state.VirtualShares),
+		VirtualAssets: uint256.MustFromBig(state.VirtualAssets),
+	}
+}
+
+// fetchAssetAndState fetches the state of a vault. A new vault is also probed for its asset and quirks, which are
+// detected once, while only the previews telling whether its swaps are paused are probed again when it is tracked.
 func fetchAssetAndState(ctx context.Context, ethrpcClient *ethrpc.Client, vaultAddr string, vaultCfg VaultCfg,
-	fetchAsset bool, overrides map[common.Address]gethclient.OverrideAccount) (common.Address, *PoolState, error) {
+	isNew bool, overrides map[common.Address]gethclient.OverrideAccount) (common.Address, *PoolState, error) {
 	var assetToken common.Address
 	var poolState PoolState
 
 	req := ethrpcClient.NewRequest().SetContext(ctx).SetOverrides(overrides)
-	if fetchAsset {
+	if isNew {
 		req = req.AddCall(&ethrpc.Call{
 			ABI:    ABI,
 			Target: vaultAddr,
@@ -130,17 +172,39 @@ func fetchAssetAndState(ctx context.Context, ethrpcClient *ethrpc.Client, vaultA
 		Method: erc4626MethodTotalAssets,
 	}, []any{&poolState.TotalAssets})
 
+	poolState.probes = make([]Probe, len(probeAmounts))
 	if vaultCfg.SwapTypes == Both || vaultCfg.SwapTypes == Deposit {
 		req.AddCall(&ethrpc.Call{
 			ABI:    ABI,
 			Target: vaultAddr,
 			Method: erc4626MethodMaxDeposit,
 			Params: []any{AddrDummy},
-		}, []any{&poolState.MaxDeposit}).AddCall(&ethrpc.Call{
-			ABI:    ABI,
-			Target: vaultAddr,
-			Method: erc4626MethodEntryFeeBasisPoints,
-		}, []any{&poolState.EntryFeeBps})
+		}, []any{&poolState.MaxDeposit})
+		if isNew {
+			req.AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodEntryFeeBasisPoints,
+			}, []any{&poolState.EntryFeeBps})
+		}
+		for i, amount := range probeAmounts {
+			probe := &poolState.probes[i]
+			probe.Amount = amount
+			if isNew {
+				req.AddCall(&ethrpc.Call{
+					ABI:    ABI,
+					Target: vaultAddr,
+					Method: erc4626MethodConvertToShares,
+					Params: []any{amount},
+				}, []any{&probe.ConvertToShares})
+			}
+			req.AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodPreviewDeposit,
+				Params: []any{amount},
+			}, []any{&probe.PreviewDeposit})
+		}
 	}
 	var minRedeemRatio uint64
 	if vaultCfg.SwapTypes == Both || vaultCfg.SwapTypes == Redeem {
@@ -149,24 +213,47 @@ func fetchAssetAndState(ctx context.Context, ethrpcClient *ethrpc.Client, vaultA
 			Target: vaultAddr,
 			Method: erc4626MethodMaxRedeem,
 			Params: []any{AddrDummy},
-		}, []any{&poolState.MaxRedeem}).AddCall(&ethrpc.Call{
-			ABI:    ABI,
-			Target: vaultAddr,
-			Method: erc4626MethodExitFeeBasisPoints,
-		}, []any{&poolState.ExitFeeBps}).AddCall(&ethrpc.Call{
-			ABI:    ABI,
-			Target: vaultAddr,
-			Method: erc4626MethodGetExitFeeBasisPoints,
-		}, []any{&poolState.ExitFeeBps}).AddCall(&ethrpc.Call{
-			ABI:    ABI,
-			Target: vaultAddr,
-			Method: erc4626MethodMinRedeemRatio,
-		}, []any{&minRedeemRatio})
+		}, []any{&poolState.MaxRedeem})
+		if isNew {
+			req.AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodExitFeeBasisPoints,
+			}, []any{&poolState.ExitFeeBps}).AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodGetExitFeeBasisPoints,
+			}, []any{&poolState.ExitFeeBps}).AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodMinRedeemRatio,
+			}, []any{&minRedeemRatio})
+		}
+		for i, amount := range probeAmounts {
+			probe := &poolState.probes[i]
+			probe.Amount = amount
+			if isNew {
+				req.AddCall(&ethrpc.Call{
+					ABI:    ABI,
+					Target: vaultAddr,
+					Method: erc4626MethodConvertToAssets,
+					Params: []any{amount},
+				}, []any{&probe.ConvertToAssets})
+			}
+			req.AddCall(&ethrpc.Call{
+				ABI:    ABI,
+				Target: vaultAddr,
+				Method: erc4626MethodPreviewRedeem,
+				Params: []any{amount},
+			}, []any{&probe.PreviewRedeem})
+		}
 	}
 
 	resp, err := req.TryAggregate()
 	if err != nil {
 		return assetToken, nil, err
+	} else if isNew && assetToken == (common.Address{}) {
+		return assetToken, nil, ErrInvalidVault
 	}
 
 	if poolState.TotalSupply == nil {
@@ -186,6 +273,12 @@ func fetchAssetAndState(ctx context.Context, ethrpcClient *ethrpc.Client, vaultA
 			Bps - (Bps-float64(poolState.ExitFeeBps))*float64(minRedeemRatio)/RatioPrecision))
 	}
 
+	if isNew {
+		poolState.detectQuirks(vaultCfg.SwapTypes)
+	} else {
+		poolState.detectPausedSwapTypes(vaultCfg.SwapTypes)
+	}
+
 	if resp.BlockNumber != nil {
 		poolState.blockNumber = resp.BlockNumber.Uint64()
 	}
@@ -0,0 +1,174 @@
+package erc4626
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/blockchain-toolkit/number"
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
+
+	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+var (
+	// probeAmounts are the amounts of assets and shares vaults are probed with, uneven and large enough for the
+	// rounding of their virtual shares and fees to show
+	probeAmounts = []*big.Int{
+		bignumber.NewBig("1234567891234567891"),
+		bignumber.NewBig("1234567891234567891234567"),
+	}
+
+	// virtualOffsets are the candidate virtual shares and assets of vaults: none, or 10^decimalsOffset shares for 1
+	// asset as in OpenZeppelin's ERC4626
+	virtualOffsets = append([][2]*uint256.Int{{nil, nil}},
+		lo.Map(lo.Range(19), func(decimalsOffset int, _ int) [2]*uint256.Int {
+			return [2]*uint256.Int{big256.TenPow(uint8(decimalsOffset)), number.Number_1}
+		})...)
+)
+
+// detectPausedSwapTypes detects the swaps of a vault that are paused, whose previews revert for all probes.
+func (s *PoolState) detectPausedSwapTypes(swapTypes SwapType) {
+	if swapTypes&Deposit != 0 && !lo.SomeBy(s.probes, hasPreviewDeposit) {
+		s.PausedSwapTypes |= Deposit
+	}
+	if swapTypes&Redeem != 0 && !lo.SomeBy(s.probes, hasPreviewRedeem) {
+		s.PausedSwapTypes |= Redeem
+	}
+}
+
+func hasPreviewDeposit(p Probe) bool { return p.PreviewDeposit != nil }
+
+func hasPreviewRedeem(p Probe) bool { return p.PreviewRedeem != nil }
+
+// detectQuirks detects from the probes of a vault how it deviates from the standard conversions: its virtual shares and
+// assets, the fees of its previews and its paused swaps, so that the simulator reproduces its previews. Fees are only
+// overridden when the previews tell them apart.
+func (s *PoolState) detectQuirks(swapTypes SwapType) {
+	if len(s.probes) == 0 {
+		return
+	}
+
+	s.detectPausedSwapTypes(swapTypes)
+
+	sim := &PoolSimulator{
+		TotalSupply: uint256.MustFromBig(s.TotalSupply),
+		TotalAssets: uint256.MustFromBig(s.TotalAssets),
+	}
+	for _, offset := range virtualOffsets {
+		sim.virtualShares, sim.virtualAssets = offset[0], offset[1]
+		if matchesDeposits(sim, s.probes, false, 0) && matchesRedeems(sim, s.probes, false, 0) {
+			break
+		}
+		sim.virtualShares, sim.virtualAssets = nil, nil
+	}
+	if sim.virtualShares != nil {
+		s.VirtualShares, s.VirtualAssets = sim.virtualShares.ToBig(), sim.virtualAssets.ToBig()
+	}
+
+	s.detectFees(sim, s.EntryFeeBps, s.ExitFeeBps)
+}
+
+// detectFees detects the fees of the previews of a vault converting as sim, falling back to the given fees
+func (s *PoolState) detectFees(sim *PoolSimulator, entryFeeBps, exitFeeBps uint64) {
+	s.EntryFeeBps, s.ExitFeeBps = entryFeeBps, exitFeeBps
+	if lo.SomeBy(s.probes, hasPreviewDeposit) {
+		s.EntryFeeBps = detectFee(entryFeeBps, s.probes, func(p Probe) (*big.Int, *big.Int) {
+			if p.PreviewDeposit == nil {
+				return nil, nil
+			}
+			shares, _, _ := sim.previewDeposit(uint256.MustFromBig(p.Amount), 0, false)
+			return shares.ToBig(), p.PreviewDeposit
+		}, func(feeBps int64) bool {
+			return matchesDeposits(sim, s.probes, true, feeBps)
+		})
+	}
+	if lo.SomeBy(s.probes, hasPreviewRedeem) {
+		s.ExitFeeBps = detectFee(exitFeeBps, s.probes, func(p Probe) (*big.Int, *big.Int) {
+			if p.PreviewRedeem == nil {
+				return nil, nil
+			}
+			assets, _, _ := sim.previewRedeem(uint256.MustFromBig(p.Amount), 0, false)
+			return assets.ToBig(), p.PreviewRedeem
+		}, func(feeBps int64) bool {
+			return matchesRedeems(sim, s.probes, true, feeBps)
+		})
+	}
+}
+
+// redetectFees detects the fees of a tracked vault with the given quirks again if its previews no longer match them
+func (s *PoolState) redetectFees(quirks StaticExtra) {
+	sim := &PoolSimulator{
+		TotalSupply:   uint256.MustFromBig(s.TotalSupply),
+		TotalAssets:   uint256.MustFromBig(s.TotalAssets),
+		virtualShares: quirks.VirtualShares,
+		virtualAssets: quirks.VirtualAssets,
+	}
+	s.EntryFeeBps, s.ExitFeeBps = quirks.EntryFeeBps, quirks.ExitFeeBps
+	if !matchesDeposits(sim, s.probes, true, int64(quirks.EntryFeeBps)) ||
+		!matchesRedeems(sim, s.probes, true, int64(quirks.ExitFeeBps)) {
+		s.detectFees(sim, quirks.EntryFeeBps, quirks.ExitFeeBps)
+	}
+}
+
+// matchesDeposits tells whether sim converts (or previews with feeBps) the probed deposits as the vault does, ignoring
+// the reverted calls
+func matchesDeposits(sim *PoolSimulator, probes []Probe, preview bool, feeBps int64) bool {
+	for _, p := range probes {
+		expected := lo.Ternary(preview, p.PreviewDeposit, p.ConvertToShares)
+		if expected == nil {
+			continue
+		}
+		shares, _, err := sim.previewDeposit(uint256.MustFromBig(p.Amount), feeBps, false)
+		if err != nil || shares.ToBig().Cmp(expected) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRedeems tells whether sim converts (or previews with feeBps) the probed redeems as the vault does, ignoring
+// the reverted calls
+func matchesRedeems(sim *PoolSimulator, probes []Probe, preview bool, feeBps int64) bool {
+	for _, p := range probes {
+		expected := lo.Ternary(preview, p.PreviewRedeem, p.ConvertToAssets)
+		if expected == nil {
+			continue
+		}
+		assets, _, err := sim.previewRedeem(uint256.MustFromBig(p.Amount), feeBps, false)
+		if err != nil || assets.ToBig().Cmp(expected) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// detectFee returns the fee in basis points that makes the simulator preview as the vault, estimated from the largest
+// probe by how much its preview falls short of its fee-free output, or fallback if no fee around the estimate matches
+func detectFee(fallback uint64, probes []Probe, outputs func(Probe) (free, preview *big.Int),
+	matches func(feeBps int64) bool) uint64 {
+	if matches(0) {
+		return 0
+	}
+
+	for i := len(probes) - 1; i >= 0; i-- {
+		free, preview := outputs(probes[i])
+		if free == nil || free.Sign() == 0 {
+			continue
+		}
+
+		// estimate = (free - preview) * Bps / free
+		estimate := new(big.Int).Sub(free, preview)
+		estimate.Mul(estimate, big.NewInt(Bps)).Div(estimate, free)
+		if estimate.Sign() < 0 || estimate.Cmp(big.NewInt(Bps)) >= 0 {
+			return fallback
+		}
+		for feeBps := max(estimate.Int64()-1, 0); feeBps <= min(estimate.Int64()+1, Bps-1); feeBps++ {
+			if matches(feeBps) {
+				return uint64(feeBps)
+			}
+		}
+		break
+	}
+	return fallback
+}
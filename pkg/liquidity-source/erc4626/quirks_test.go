@@ -0,0 +1,144 @@
+package erc4626
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	bignum "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+func TestDetectQuirks(t *testing.T) {
+	t.Parallel()
+	newState := func() *PoolState {
+		// a vault with a decimals offset of 6, an entry fee of 10 bps and an exit fee of 25 bps
+		return &PoolState{
+			TotalSupply: bignum.NewBig("1000000000000000000000000000"),
+			TotalAssets: bignum.NewBig("1050000000123"),
+			probes: []Probe{
+				{
+					Amount:          probeAmounts[0],
+					ConvertToShares: bignum.NewBig("1175778943894067906627019188066175"),
+					PreviewDeposit:  bignum.NewBig("1174603164950173838616582645080844"),
+					ConvertToAssets: bignum.NewBig("1296"),
+					PreviewRedeem:   bignum.NewBig("1292"),
+				},
+				{
+					Amount:          probeAmounts[1],
+					ConvertToShares: bignum.NewBig("1175778943894067906850416330896936136057"),
+					PreviewDeposit:  bignum.NewBig("1174603164950173838943565502185086867668"),
+					ConvertToAssets: bignum.NewBig("1296296285"),
+					PreviewRedeem:   bignum.NewBig("1293055544"),
+				},
+			},
+		}
+	}
+
+	t.Run("virtual shares and fees", func(t *testing.T) {
+		state := newState()
+		state.detectQuirks(Both)
+		assert.Equal(t, "1000000", state.VirtualShares.String())
+		assert.Equal(t, "1", state.VirtualAssets.String())
+		assert.EqualValues(t, 10, state.EntryFeeBps)
+		assert.EqualValues(t, 25, state.ExitFeeBps)
+		assert.Equal(t, None, state.PausedSwapTypes)
+
+		extraBytes, err := json.Marshal(newExtra(VaultCfg{SwapTypes: Both}, state))
+		require.NoError(t, err)
+		staticExtraBytes, err := json.Marshal(newStaticExtra(state))
+		require.NoError(t, err)
+		s, err := NewPoolSimulator(entity.Pool{
+			Tokens:      []*entity.PoolToken{{Address: "A"}, {Address: "B"}},
+			Reserves:    entity.PoolReserves{state.TotalSupply.String(), state.TotalAssets.String()},
+			Extra:       string(extraBytes),
+			StaticExtra: string(staticExtraBytes),
+		})
+		require.NoError(t, err)
+		for _, probe := range state.probes {
+			assert.True(t, matchesDeposits(s, []Probe{probe}, true, int64(s.EntryFeeBasisPoints)))
+			assert.True(t, matchesRedeems(s, []Probe{probe}, true, int64(s.ExitFeeBasisPoints)))
+		}
+	})
+
+	t.Run("tracked vault", func(t *testing.T) {
+		// a tracked vault is only probed with previews, which tell whether its swaps are paused
+		state := newState()
+		for i := range state.probes {
+			state.probes[i].ConvertToShares, state.probes[i].ConvertToAssets = nil, nil
+			state.probes[i].PreviewDeposit = nil
+		}
+		state.detectPausedSwapTypes(Both)
+		assert.Equal(t, Deposit, state.PausedSwapTypes)
+		assert.Nil(t, state.VirtualShares)
+		assert.Zero(t, state.EntryFeeBps)
+	})
+
+	t.Run("legacy extra", func(t *testing.T) {
+		// pools tracked before StaticExtra keep their quirks in Extra
+		state := newState()
+		s, err := NewPoolSimulator(entity.Pool{
+			Tokens:   []*entity.PoolToken{{Address: "A"}, {Address: "B"}},
+			Reserves: entity.PoolReserves{state.TotalSupply.String(), state.TotalAssets.String()},
+			Extra:    `{"g":{},"sT":3,"dF":10,"rF":25,"vS":"1000000","vA":"1"}`,
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, s.EntryFeeBasisPoints)
+		assert.EqualValues(t, 25, s.ExitFeeBasisPoints)
+		assert.True(t, matchesDeposits(s, state.probes, true, int64(s.EntryFeeBasisPoints)))
+		assert.True(t, matchesRedeems(s, state.probes, true, int64(s.ExitFeeBasisPoints)))
+	})
+
+	t.Run("changed fees", func(t *testing.T) {
+		// a tracked vault whose previews no longer match the fees detected when it was listed
+		quirks := StaticExtra{EntryFeeBps: 10, ExitFeeBps: 20, VirtualShares: uint256.NewInt(1e6),
+			VirtualAssets: uint256.NewInt(1)}
+		state := newState()
+		state.redetectFees(quirks)
+		assert.EqualValues(t, 10, state.EntryFeeBps)
+		assert.EqualValues(t, 25, state.ExitFeeBps)
+
+		quirks.ExitFeeBps = 25
+		state = newState()
+		state.probes[0].PreviewDeposit, state.probes[1].PreviewDeposit = nil, nil
+		state.redetectFees(quirks)
+		assert.EqualValues(t, 10, state.EntryFeeBps, "fees matching the previews should be kept")
+		assert.EqualValues(t, 25, state.ExitFeeBps)
+	})
+
+	t.Run("paused redeems", func(t *testing.T) {
+		state := newState()
+		state.ExitFeeBps = 30
+		for i := range state.probes {
+			state.probes[i].PreviewRedeem = nil
+		}
+		state.detectQuirks(Both)
+		assert.Equal(t, Redeem, state.PausedSwapTypes)
+		assert.Equal(t, Deposit, newExtra(VaultCfg{SwapTypes: Both}, state).SwapTypes)
+		assert.EqualValues(t, 30, state.ExitFeeBps, "fees not told apart by previews should be kept")
+	})
+
+	t.Run("standard vault", func(t *testing.T) {
+		state := newState()
+		state.TotalSupply = bignum.NewBig("3000000000000000000000")
+		state.TotalAssets = bignum.NewBig("3300000000000000000000")
+		for i := range state.probes {
+			probe := &state.probes[i]
+			probe.ConvertToShares = new(big.Int).Mul(probe.Amount, state.TotalSupply)
+			probe.ConvertToShares.Div(probe.ConvertToShares, state.TotalAssets)
+			probe.PreviewDeposit = probe.ConvertToShares
+			probe.ConvertToAssets = new(big.Int).Mul(probe.Amount, state.TotalAssets)
+			probe.ConvertToAssets.Div(probe.ConvertToAssets, state.TotalSupply)
+			probe.PreviewRedeem = probe.ConvertToAssets
+		}
+		state.detectQuirks(Both)
+		assert.Nil(t, state.VirtualShares)
+		assert.Nil(t, state.VirtualAssets)
+		assert.Zero(t, state.EntryFeeBps)
+		assert.Zero(t, state.ExitFeeBps)
+	})
+}
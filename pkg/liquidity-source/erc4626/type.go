@@ -4,6 +4,8 @@ import (
 	"math/big"
 
 	"github.com/holiman/uint256"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
 )
 
 type SwapType uint8
@@ -22,12 +24,28 @@ type (
 	}
 
 	Extra struct {
-		Gas         Gas          `json:"g"`
-		SwapTypes   SwapType     `json:"sT,omitempty"`
-		MaxDeposit  *uint256.Int `json:"mD,omitempty"`
-		MaxRedeem   *uint256.Int `json:"mR,omitempty"`
-		EntryFeeBps uint64       `json:"dF,omitempty"`
-		ExitFeeBps  uint64       `json:"rF,omitempty"`
+		Gas        Gas             `json:"g"`
+		SwapTypes  SwapType        `json:"sT,omitempty"`
+		MaxDeposit *uint256.Int    `json:"mD,omitempty"`
+		MaxRedeem  *uint256.Int    `json:"mR,omitempty"`
+		Growth     *accrual.Linear `json:"aR,omitempty"`
+		// EntryFeeBps and ExitFeeBps override the fees of StaticExtra once the tracker detects them again, as the
+		// previews of the vault no longer match them. They are also the fees of pools tracked before StaticExtra.
+		EntryFeeBps *uint64 `json:"dF,omitempty"`
+		ExitFeeBps  *uint64 `json:"rF,omitempty"`
+		// VirtualShares and VirtualAssets are the virtual shares and assets of pools tracked before StaticExtra
+		VirtualShares *uint256.Int `json:"vS,omitempty"`
+		VirtualAssets *uint256.Int `json:"vA,omitempty"`
+	}
+
+	// StaticExtra holds the quirks of a vault, which are detected once when it is listed
+	StaticExtra struct {
+		EntryFeeBps uint64 `json:"dF,omitempty"`
+		ExitFeeBps  uint64 `json:"rF,omitempty"`
+		// VirtualShares and VirtualAssets are added to the total supply and assets when converting between them, as
+		// done by vaults with virtual shares against inflation attacks
+		VirtualShares *uint256.Int `json:"vS,omitempty"`
+		VirtualAssets *uint256.Int `json:"vA,omitempty"`
 	}
 
 	SwapInfo struct {
@@ -46,6 +64,21 @@ type (
 		EntryFeeBps uint64
 		ExitFeeBps  uint64
 
+		VirtualShares   *big.Int
+		VirtualAssets   *big.Int
+		PausedSwapTypes SwapType
+
+		probes      []Probe
 		blockNumber uint64
 	}
+
+	// Probe is the on-chain output of the conversions and previews of a vault for Amount of assets or shares,
+	// nil for the calls that reverted
+	Probe struct {
+		Amount          *big.Int
+		ConvertToShares *big.Int
+		PreviewDeposit  *big.Int
+		ConvertToAssets *big.Int
+		PreviewRedeem   *big.Int
+	}
 )
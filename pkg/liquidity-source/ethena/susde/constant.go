@@ -13,6 +13,12 @@ const (
 	stakedUSDeV2MethodAsset       = "asset"
 	stakedUSDeV2MethodTotalSupply = "totalSupply"
 	stakedUSDeV2MethodTotalAssets = "totalAssets"
+
+	stakedUSDeV2MethodVestingAmount             = "vestingAmount"
+	stakedUSDeV2MethodLastDistributionTimestamp = "lastDistributionTimestamp"
+
+	// vestingPeriod is the VESTING_PERIOD over which StakedUSDeV2 vests the rewards transferred in
+	vestingPeriod = 8 * 60 * 60
 )
 
 var (
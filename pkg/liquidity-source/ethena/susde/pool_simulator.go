@@ -2,8 +2,10 @@ package susde
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/KyberNetwork/blockchain-toolkit/number"
+	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
@@ -17,6 +19,18 @@ type (
 
 		totalAssets *uint256.Int
 		totalSupply *uint256.Int
+
+		blockTimestamp            uint64
+		vestingAmount             *uint256.Int
+		lastDistributionTimestamp uint64
+	}
+
+	// Extra is the vesting schedule of the rewards of StakedUSDeV2, which keep adding to its total assets until
+	// they are fully vested.
+	Extra struct {
+		BlockTimestamp            uint64       `json:"blockTimestamp"`
+		VestingAmount             *uint256.Int `json:"vestingAmount"`
+		LastDistributionTimestamp uint64       `json:"lastDistributionTimestamp"`
 	}
 
 	Gas struct {
@@ -30,6 +44,8 @@ type (
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
 
+var now = time.Now
+
 func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 	var (
 		tokens   = make([]string, len(entityPool.Tokens))
@@ -64,10 +80,20 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		return nil, ErrOverflow
 	}
 
+	var extra Extra
+	if entityPool.Extra != "" {
+		if err := json.Unmarshal([]byte(entityPool.Extra), &extra); err != nil {
+			return nil, err
+		}
+	}
+
 	return &PoolSimulator{
-		Pool:        pool.Pool{Info: poolInfo},
-		totalAssets: totalAssets,
-		totalSupply: totalSupply,
+		Pool:                      pool.Pool{Info: poolInfo},
+		totalAssets:               totalAssets,
+		totalSupply:               totalSupply,
+		blockTimestamp:            extra.BlockTimestamp,
+		vestingAmount:             extra.VestingAmount,
+		lastDistributionTimestamp: extra.LastDistributionTimestamp,
 	}, nil
 }
 
@@ -87,7 +113,7 @@ func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 	shares, _ := new(uint256.Int).MulDivOverflow(
 		amountIn,
 		new(uint256.Int).Add(s.totalSupply, number.Number_1),
-		new(uint256.Int).Add(s.totalAssets, number.Number_1),
+		new(uint256.Int).Add(s.getTotalAssets(uint64(now().Unix())), number.Number_1),
 	)
 
 	return &pool.CalcAmountOutResult{
@@ -123,6 +149,30 @@ func (s *PoolSimulator) GetMetaInfo(_, _ string) interface{} {
 	}
 }
 
+// getTotalAssets returns the total assets at timestamp, which grow as the rewards vest from the ones fetched:
+// totalAssets(timestamp) = totalAssets(blockTimestamp) + unvested(blockTimestamp) - unvested(timestamp).
+func (s *PoolSimulator) getTotalAssets(timestamp uint64) *uint256.Int {
+	if timestamp <= s.blockTimestamp || s.vestingAmount == nil {
+		return s.totalAssets
+	}
+
+	var totalAssets uint256.Int
+	totalAssets.Add(s.totalAssets, s.getUnvestedAmount(s.blockTimestamp))
+	return totalAssets.Sub(&totalAssets, s.getUnvestedAmount(timestamp))
+}
+
+// getUnvestedAmount is StakedUSDeV2.getUnvestedAmount at timestamp
+func (s *PoolSimulator) getUnvestedAmount(timestamp uint64) *uint256.Int {
+	timeSinceLastDistribution := timestamp - min(timestamp, s.lastDistributionTimestamp)
+	if timeSinceLastDistribution >= vestingPeriod {
+		return number.Zero
+	}
+
+	var unvested uint256.Int
+	unvested.Mul(uint256.NewInt(vestingPeriod-timeSinceLastDistribution), s.vestingAmount)
+	return unvested.Div(&unvested, uint256.NewInt(vestingPeriod))
+}
+
 func (s *PoolSimulator) validate(tokenIn string, tokenOut string) error {
 	if tokenIn != s.Info.Tokens[0] || tokenOut != s.Info.Tokens[1] {
 		return ErrInvalidToken
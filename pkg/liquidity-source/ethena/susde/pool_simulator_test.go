@@ -10,6 +10,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	utils "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -112,6 +113,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 				totalAssets: tt.fields.totalAssets,
 				totalSupply: tt.fields.totalSupply,
 			}
+			testutil.TestPoolSimulator(t, s)
 
 			for i, param := range tt.params {
 				got, err := s.CalcAmountOut(param)
@@ -132,3 +134,23 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_getTotalAssets(t *testing.T) {
+	t.Parallel()
+	s := &PoolSimulator{
+		totalAssets:               uint256.MustFromDecimal("1000000000000000000000"),
+		totalSupply:               uint256.MustFromDecimal("900000000000000000000"),
+		blockTimestamp:            1700003600,
+		vestingAmount:             uint256.MustFromDecimal("8000000000000000000"),
+		lastDistributionTimestamp: 1700000000,
+	}
+
+	require.Equal(t, "1000000000000000000000", s.getTotalAssets(1700000000).Dec())
+	require.Equal(t, "1000000000000000000000", s.getTotalAssets(1700003600).Dec())
+	require.Equal(t, "1001000000000000000000", s.getTotalAssets(1700007200).Dec())
+	require.Equal(t, "1007000000000000000000", s.getTotalAssets(1700028800).Dec())
+	require.Equal(t, "1007000000000000000000", s.getTotalAssets(1800000000).Dec())
+
+	s.vestingAmount = nil
+	require.Equal(t, "1000000000000000000000", s.getTotalAssets(1700007200).Dec())
+}
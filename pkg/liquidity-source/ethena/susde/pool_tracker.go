@@ -9,6 +9,8 @@ import (
 	"github.com/KyberNetwork/logger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
@@ -60,8 +62,10 @@ func (t *PoolTracker) getNewPoolState(
 	}()
 
 	var (
-		totalAssets *big.Int
-		totalSupply *big.Int
+		totalAssets               *big.Int
+		totalSupply               *big.Int
+		vestingAmount             *big.Int
+		lastDistributionTimestamp *big.Int
 	)
 
 	req := t.ethrpcClient.R().SetContext(ctx)
@@ -80,6 +84,18 @@ func (t *PoolTracker) getNewPoolState(
 		Method: stakedUSDeV2MethodTotalAssets,
 	}, []interface{}{&totalAssets})
 
+	req.AddCall(&ethrpc.Call{
+		ABI:    stakedUSDeV2ABI,
+		Target: StakedUSDeV2,
+		Method: stakedUSDeV2MethodVestingAmount,
+	}, []interface{}{&vestingAmount})
+
+	req.AddCall(&ethrpc.Call{
+		ABI:    stakedUSDeV2ABI,
+		Target: StakedUSDeV2,
+		Method: stakedUSDeV2MethodLastDistributionTimestamp,
+	}, []interface{}{&lastDistributionTimestamp})
+
 	result, err := req.Aggregate()
 	if err != nil {
 		logger.WithFields(logger.Fields{
@@ -89,10 +105,30 @@ func (t *PoolTracker) getNewPoolState(
 		return p, err
 	}
 
+	req = t.ethrpcClient.R().SetContext(ctx)
+	if overrides != nil {
+		req.SetOverrides(overrides)
+	}
+
+	blockTimestamp, err := req.GetCurrentBlockTimestamp()
+	if err != nil {
+		return p, err
+	}
+
+	extraBytes, err := json.Marshal(Extra{
+		BlockTimestamp:            blockTimestamp,
+		VestingAmount:             uint256.MustFromBig(vestingAmount),
+		LastDistributionTimestamp: lastDistributionTimestamp.Uint64(),
+	})
+	if err != nil {
+		return p, err
+	}
+
 	p.Reserves = entity.PoolReserves{
 		totalAssets.String(),
 		totalSupply.String(),
 	}
+	p.Extra = string(extraBytes)
 	p.Timestamp = time.Now().Unix()
 	p.BlockNumber = result.BlockNumber.Uint64()
 
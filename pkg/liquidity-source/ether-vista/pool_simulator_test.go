@@ -0,0 +1,29 @@
+package ethervista
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x6a5a8a2b8e4f12e5a5b1d5c0c6b3c2a0e8d0f7a1",
+		Exchange: "ether-vista",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"50000000000000000000000000", "20000000000000000000"},
+		Tokens: []*entity.PoolToken{
+			{Address: "0xc9bca88b04581699fab5aa276ccaff7df957cbbf", Decimals: 18},
+			{Address: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Decimals: 18},
+		},
+		Extra: `{"routerAddress":"0xceb2b31453bf1e5e3a9e5f1b8e4b2e66a60ca3da","buyTotalFee":5,"sellTotalFee":5,` +
+			`"usdcToETHBuyTotalFee":1500000000000000,"usdcToETHSellTotalFee":1500000000000000}`,
+	}, valueobject.ChainIDEthereum)
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
+}
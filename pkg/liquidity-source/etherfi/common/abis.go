@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	EETHABI          abi.ABI
-	LiquidityPoolABI abi.ABI
+	EETHABI              abi.ABI
+	LiquidityPoolABI     abi.ABI
+	RedemptionManagerABI abi.ABI
 )
 
 func init() {
@@ -22,6 +23,9 @@ func init() {
 		{
 			&LiquidityPoolABI, liquidityPoolABIJson,
 		},
+		{
+			&RedemptionManagerABI, redemptionManagerABIJson,
+		},
 	}
 
 	for _, b := range builder {
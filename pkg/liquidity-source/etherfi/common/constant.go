@@ -1,15 +1,19 @@
 package common
 
 const (
-	LiquidityPool = "0x308861a430be4cce5502d0a12724771fc6daf216"
-	WETH          = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
-	EETH          = "0x35fa164735182de50811e8e2e824cfb9b6118ac2"
-	WEETH         = "0xcd5fe23c85820f7b72d0926fc9b05b43e359b7ee"
-	STETH         = "0xae7ab96520de3a18e5e111b5eaab095312d7fe84"
-	WSTETH        = "0x7f39c581f595b53c5cb19bd0b3f8da6c935e2ca0"
+	LiquidityPool     = "0x308861a430be4cce5502d0a12724771fc6daf216"
+	RedemptionManager = "0xdadef1ffbfeaab4f68a9fd181395f68b4e4e7ae0"
+	WETH              = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	EETH              = "0x35fa164735182de50811e8e2e824cfb9b6118ac2"
+	WEETH             = "0xcd5fe23c85820f7b72d0926fc9b05b43e359b7ee"
+	STETH             = "0xae7ab96520de3a18e5e111b5eaab095312d7fe84"
+	WSTETH            = "0x7f39c581f595b53c5cb19bd0b3f8da6c935e2ca0"
 )
 
 const (
 	EETHMethodTotalShares                  = "totalShares"
 	LiquidityPoolMethodGetTotalPooledEther = "getTotalPooledEther"
+
+	RedemptionManagerMethodExitFeeInBps          = "exitFeeInBps"
+	RedemptionManagerMethodTotalRedeemableAmount = "totalRedeemableAmount"
 )
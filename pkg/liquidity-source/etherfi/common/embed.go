@@ -7,3 +7,6 @@ var eETHABIJson []byte
 
 //go:embed abis/LiquidityPool.json
 var liquidityPoolABIJson []byte
+
+//go:embed abis/EtherFiRedemptionManager.json
+var redemptionManagerABIJson []byte
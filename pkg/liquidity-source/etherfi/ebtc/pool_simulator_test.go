@@ -10,6 +10,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 var entityPoolStrData = "{\"address\":\"0x6ee3aaccf9f2321e49063c4f8da775ddbd407268\",\"exchange\":\"etherfi-ebtc\"," +
@@ -56,6 +57,7 @@ func TestNewPoolSimulator(t *testing.T) {
 		StaticExtra: "{\"accountant\":\"0x1b293dc39f94157fa0d1d36d7e0090c8b8b8c13f\",\"base\":\"0x2260fac5e5542a773aa44fbcfedf7c193bc2c599\",\"decimals\":8}",
 	})
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, p)
 	assert.NotNil(t, p)
 
 	assert.Equal(t, []string{"0x8236a87084f8b84306f72007f36f2618a5634494", "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599", "0xcbb7c0000ab88b473b1f5afd9ef808440eed33bf"}, p.CanSwapTo("0x657e8c867d8b37dcc18fa4caead9c45eb088c642"))
@@ -1,7 +1,15 @@
 package eeth
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+
 const (
 	DexType = "etherfi-eeth"
+
+	// Redemption of eETH is instant through the redemption manager, paid out of the liquidity pool within its rate
+	// limit.
+	Redemption = lst.Instant
+
+	bps = 10000
 )
 
 const (
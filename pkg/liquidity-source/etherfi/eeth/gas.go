@@ -2,8 +2,9 @@ package eeth
 
 type Gas struct {
 	Deposit int64
+	Redeem  int64
 }
 
 var (
-	defaultGas = Gas{Deposit: 70000}
+	defaultGas = Gas{Deposit: 70000, Redeem: 150000}
 )
@@ -11,6 +11,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/etherfi/common"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 var uint128Max = new(big.Int).Sub(
@@ -23,13 +24,19 @@ var (
 	ErrInvalidAmount   = errors.New("invalid amount")
 )
 
-// PoolSimulator only support deposits ETH and get eETH
+// PoolSimulator supports depositing ETH to get eETH and instantly redeeming eETH for ETH
 type PoolSimulator struct {
 	pool.Pool
 
 	totalPooledEther *big.Int
 	totalShares      *big.Int
 
+	// exitFeeInBps: RedemptionManager.exitFeeInBps
+	exitFeeInBps uint16
+
+	// totalRedeemableAmount: RedemptionManager.totalRedeemableAmount
+	totalRedeemableAmount *big.Int
+
 	gas Gas
 }
 
@@ -52,25 +59,36 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		}},
 		totalPooledEther: extra.TotalPooledEther,
 		totalShares:      extra.TotalShares,
-		gas:              defaultGas,
+
+		exitFeeInBps:          extra.ExitFeeInBps,
+		totalRedeemableAmount: extra.TotalRedeemableAmount,
+
+		gas: defaultGas,
 	}, nil
 }
 
 func (s *PoolSimulator) CanSwapTo(token string) []string {
-	if token == common.EETH {
+	switch token {
+	case common.EETH:
 		return []string{common.WETH}
+	case common.WETH:
+		return []string{common.EETH}
 	}
 	return []string{}
 }
 
 func (s *PoolSimulator) CanSwapFrom(token string) []string {
-	if token == common.WETH {
-		return []string{common.EETH}
-	}
-	return []string{}
+	return s.CanSwapTo(token)
 }
 
 func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if param.TokenAmountIn.Token == s.Pool.Info.Tokens[1] && param.TokenOut == s.Pool.Info.Tokens[0] {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+		return s.redeem(param.TokenAmountIn.Amount)
+	}
+
 	// NOTE: only support tokenIn is WETH and tokenOut is eETH
 	if param.TokenAmountIn.Token != s.Pool.Info.Tokens[0] || param.TokenOut != s.Pool.Info.Tokens[1] {
 		return nil, ErrUnsupportedSwap
@@ -93,16 +111,61 @@ func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 }
 
 func (s *PoolSimulator) UpdateBalance(param pool.UpdateBalanceParams) {
+	if swapInfo, ok := param.SwapInfo.(SwapInfo); ok && swapInfo.IsRedeem {
+		// the shares of the exit fee go to the treasury, so only the ETH paid out leaves the pool
+		burnt := new(big.Int).Sub(swapInfo.shares, s.sharesForAmount(new(big.Int).Sub(swapInfo.ethAmount,
+			param.TokenAmountOut.Amount)))
+		s.totalShares = new(big.Int).Sub(s.totalShares, burnt)
+		s.totalPooledEther = new(big.Int).Sub(s.totalPooledEther, param.TokenAmountOut.Amount)
+		s.totalRedeemableAmount = lst.Drain(s.totalRedeemableAmount, swapInfo.ethAmount)
+		return
+	}
+
 	s.totalPooledEther.Add(s.totalPooledEther, param.TokenAmountIn.Amount)
 	s.totalShares.Add(s.totalShares, param.TokenAmountOut.Amount)
 }
 
-func (s *PoolSimulator) GetMetaInfo(_ string, _ string) interface{} {
+func (s *PoolSimulator) GetMetaInfo(tokenIn, _ string) interface{} {
 	return PoolMeta{
-		BlockNumber: s.Pool.Info.BlockNumber,
+		BlockNumber:     s.Pool.Info.BlockNumber,
+		ApprovalAddress: lo.Ternary(tokenIn == common.EETH, common.RedemptionManager, ""),
 	}
 }
 
+// redeem eETH for ETH through the redemption manager, as RedemptionManager.redeemEEth
+func (s *PoolSimulator) redeem(amount *big.Int) (*pool.CalcAmountOutResult, error) {
+	shares := s.sharesForAmount(amount)
+	if amount.Sign() <= 0 || shares.Sign() == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	ethAmount := new(big.Int).Div(new(big.Int).Mul(shares, s.totalPooledEther), s.totalShares)
+	if err := lst.CheckBuffer(s.totalRedeemableAmount, ethAmount); err != nil {
+		return nil, err
+	}
+
+	fee := new(big.Int).Div(new(big.Int).Mul(ethAmount, big.NewInt(int64(s.exitFeeInBps))), big.NewInt(bps))
+
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: &pool.TokenAmount{Token: s.Pool.Info.Tokens[0], Amount: new(big.Int).Sub(ethAmount, fee)},
+		Fee:            &pool.TokenAmount{Token: s.Pool.Info.Tokens[0], Amount: fee},
+		Gas:            s.gas.Redeem,
+		SwapInfo: SwapInfo{
+			IsRedeem:  true,
+			shares:    shares,
+			ethAmount: ethAmount,
+		},
+	}, nil
+}
+
+func (s *PoolSimulator) sharesForAmount(amount *big.Int) *big.Int {
+	if s.totalPooledEther.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Div(new(big.Int).Mul(amount, s.totalShares), s.totalPooledEther)
+}
+
 func (s *PoolSimulator) sharesForDepositAmount(depositAmount *big.Int) *big.Int {
 	if s.totalPooledEther.Cmp(bignumber.ZeroBI) == 0 {
 		return depositAmount
@@ -8,6 +8,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -44,6 +46,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -85,3 +89,55 @@ func TestPoolSimulator_UpdateBalance(t *testing.T) {
 		assert.Zero(t, poolSimulator.totalShares.Cmp(bignumber.NewBig("463434537433105211004818")))
 	})
 }
+
+func TestPoolSimulator_Redeem(t *testing.T) {
+	t.Parallel()
+	newPoolSimulator := func() *PoolSimulator {
+		return &PoolSimulator{
+			Pool: poolpkg.Pool{
+				Info: poolpkg.PoolInfo{
+					Tokens: []string{"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0x35fa164735182de50811e8e2e824cfb9b6118ac2"},
+				},
+			},
+			totalPooledEther:      bignumber.NewBig("478349632983976798301885"),
+			totalShares:           bignumber.NewBig("463434527744908632824686"),
+			exitFeeInBps:          30,
+			totalRedeemableAmount: bignumber.NewBig("10000000000000000000"),
+		}
+	}
+	param := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{
+			Amount: bignumber.NewBig("1000000000000000000"),
+			Token:  "0x35fa164735182de50811e8e2e824cfb9b6118ac2",
+		},
+		TokenOut: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+	}
+
+	t.Run("it should redeem net of the exit fee and drain the redeemable amount", func(t *testing.T) {
+		s := newPoolSimulator()
+		result, err := s.CalcAmountOut(param)
+		assert.NoError(t, err)
+		assert.Equal(t, "996999999999999999", result.TokenAmountOut.Amount.String())
+		assert.Equal(t, "2999999999999999", result.Fee.Amount.String())
+
+		s.UpdateBalance(poolpkg.UpdateBalanceParams{
+			TokenAmountIn:  param.TokenAmountIn,
+			TokenAmountOut: *result.TokenAmountOut,
+			SwapInfo:       result.SwapInfo,
+		})
+		assert.Equal(t, "478348635983976798301886", s.totalPooledEther.String())
+		assert.Equal(t, "463433561831709788265461", s.totalShares.String())
+		assert.Equal(t, "9000000000000000002", s.totalRedeemableAmount.String())
+	})
+
+	t.Run("it should be capped by the redeemable amount", func(t *testing.T) {
+		s := newPoolSimulator()
+		s.totalRedeemableAmount = bignumber.NewBig("999999999999999997")
+		_, err := s.CalcAmountOut(param)
+		assert.ErrorIs(t, err, lst.ErrInsufficientBuffer)
+
+		s.totalRedeemableAmount = nil
+		_, err = s.CalcAmountOut(param)
+		assert.ErrorIs(t, err, lst.ErrInsufficientBuffer)
+	})
+}
@@ -73,6 +73,9 @@ func (t *PoolTracker) getExtra(ctx context.Context, overrides map[gethcommon.Add
 	var (
 		totalShares      *big.Int
 		totalPooledEther *big.Int
+
+		exitFeeInBps          uint16
+		totalRedeemableAmount *big.Int
 	)
 
 	getPoolStateRequest := t.ethrpcClient.NewRequest().SetContext(ctx)
@@ -93,6 +96,20 @@ func (t *PoolTracker) getExtra(ctx context.Context, overrides map[gethcommon.Add
 		Params: []interface{}{},
 	}, []interface{}{&totalShares})
 
+	getPoolStateRequest.AddCall(&ethrpc.Call{
+		ABI:    common.RedemptionManagerABI,
+		Target: common.RedemptionManager,
+		Method: common.RedemptionManagerMethodExitFeeInBps,
+		Params: []interface{}{},
+	}, []interface{}{&exitFeeInBps})
+
+	getPoolStateRequest.AddCall(&ethrpc.Call{
+		ABI:    common.RedemptionManagerABI,
+		Target: common.RedemptionManager,
+		Method: common.RedemptionManagerMethodTotalRedeemableAmount,
+		Params: []interface{}{},
+	}, []interface{}{&totalRedeemableAmount})
+
 	resp, err := getPoolStateRequest.TryAggregate()
 	if err != nil {
 		return PoolExtra{}, 0, err
@@ -104,5 +121,8 @@ func (t *PoolTracker) getExtra(ctx context.Context, overrides map[gethcommon.Add
 	return PoolExtra{
 		TotalPooledEther: totalPooledEther,
 		TotalShares:      totalShares,
+
+		ExitFeeInBps:          exitFeeInBps,
+		TotalRedeemableAmount: totalRedeemableAmount,
 	}, resp.BlockNumber.Uint64(), nil
 }
@@ -3,10 +3,23 @@ package eeth
 import "math/big"
 
 type PoolMeta struct {
-	BlockNumber uint64 `json:"blockNumber"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	ApprovalAddress string `json:"approvalAddress,omitempty"`
 }
 
 type PoolExtra struct {
 	TotalPooledEther *big.Int `json:"totalPooledEther"`
 	TotalShares      *big.Int `json:"totalShares"`
+
+	// ExitFeeInBps and TotalRedeemableAmount (what the liquidity pool can pay out within the rate limit of the
+	// redemption manager) bound instant redemptions of eETH
+	ExitFeeInBps          uint16   `json:"exitFeeInBps,omitempty"`
+	TotalRedeemableAmount *big.Int `json:"totalRedeemableAmount,omitempty"`
+}
+
+type SwapInfo struct {
+	IsRedeem bool `json:"isRedeem"`
+
+	shares    *big.Int // eETH shares redeemed
+	ethAmount *big.Int // ETH redeemed before the exit fee
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/etherfi/common"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator(t *testing.T) {
@@ -22,6 +23,7 @@ func TestPoolSimulator(t *testing.T) {
 
 	simulator, err := NewPoolSimulator(entity)
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, simulator)
 
 	// Swap stETH -> eETH
 	amount := big.NewInt(1000000000000000000)
@@ -22,6 +22,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 	s, err := NewPoolSimulator(pool)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, s)
 
 	t.Run("swap USDC -> WETH", func(t *testing.T) {
 		amountIn, _ := new(big.Int).SetString("1000000", 10)
@@ -105,24 +105,19 @@ func (s *PoolSimulator) GetApprovalAddress(tokenIn, _ string) string {
 	return lo.Ternary(valueobject.IsNative(tokenIn), "", s.GetAddress())
 }
 
+// CanSwapFrom only allows liquidating from the swap path's tokenIn (token 0) to its tokenOut (token 1).
 func (s *PoolSimulator) CanSwapFrom(address string) []string {
-	return s.CanSwapTo(address)
+	if !strings.EqualFold(address, s.Info.Tokens[0]) {
+		return []string{}
+	}
+	return []string{s.Info.Tokens[1]}
 }
 
 func (s *PoolSimulator) CanSwapTo(address string) []string {
-	if strings.EqualFold(address, s.Info.Tokens[1]) {
+	if !strings.EqualFold(address, s.Info.Tokens[1]) {
 		return []string{}
 	}
-
-	result := make([]string, 0, len(s.Info.Tokens))
-	var tokenIndex = s.GetTokenIndex(address)
-	for i := 0; i < len(s.Info.Tokens); i++ {
-		if i != tokenIndex {
-			result = append(result, s.Info.Tokens[i])
-		}
-	}
-
-	return result
+	return []string{s.Info.Tokens[0]}
 }
 
 // Helper function to get reserve for a specific token
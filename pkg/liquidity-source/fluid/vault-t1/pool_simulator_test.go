@@ -8,6 +8,7 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -73,6 +74,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -85,3 +88,17 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_CanSwapTo(t *testing.T) {
+	t.Parallel()
+	wstETH, weETH := "0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0", "0xCd5fE23C85820F7B72D0926FC9b05b43E359b7ee"
+	poolSimulator := &PoolSimulator{
+		Pool: poolpkg.Pool{Info: poolpkg.PoolInfo{Tokens: []string{wstETH, weETH}}},
+	}
+
+	// liquidations only swap from the swap path's tokenIn (token 0) to its tokenOut (token 1)
+	assert.Equal(t, []string{wstETH}, poolSimulator.CanSwapTo(weETH))
+	assert.Empty(t, poolSimulator.CanSwapTo(wstETH))
+	assert.Equal(t, []string{weETH}, poolSimulator.CanSwapFrom(wstETH))
+	assert.Empty(t, poolSimulator.CanSwapFrom(weETH))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	utils "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -224,6 +225,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 				totalAssets: tt.fields.totalAssets,
 				totalSupply: tt.fields.totalSupply,
 			}
+			testutil.TestPoolSimulator(t, s)
 
 			for i, param := range tt.params {
 				got, err := s.CalcAmountOut(param)
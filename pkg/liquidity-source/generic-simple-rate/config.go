@@ -1,6 +1,12 @@
 package generic_simple_rate
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
+)
 
 type Config struct {
 	DexID           string   `json:"dexID"`
@@ -11,9 +17,124 @@ type Config struct {
 	RateUnit        *big.Int `json:"rateUnit"`
 	IsRateInversed  bool     `json:"isRateInversed"`
 	IsRateUpdatable bool     `json:"isRateUpdatable"`
+	IsRateAccruing  bool     `json:"isRateAccruing"`
 	IsBidirectional bool     `json:"isBidirectional"`
 	DefaultGas      *big.Int `json:"defaultGas"`
 
 	// If IsRateInversed = true, amountToken0 = amountToken1 * rateUnit / rate
 	// If IsRateInversed = false, amountToken0 = amountToken1 * rate / rateUnit
+	// If IsRateAccruing = true, the rate grows continuously, so its growth is estimated between tracker runs and
+	// projected to the time being quoted. The growth is the per-second growth of the rate between the last two tracker
+	// runs (see accrual.Estimate), not read from the contract, so quotes are not exact to the wei. It is only projected
+	// if it is within 10% of the growth of the run before, and for at most one tracker interval, so as long as the rate
+	// keeps growing like that, the projected rate is off by at most 10% of its growth over one interval.
+
+	// Rate, if set, is read instead of RateMethod and replaces both the rate and the rate unit
+	Rate *RateExpr `json:"rate,omitempty"`
+
+	// Paused, if set, pauses swaps when it reads a non-zero value, e.g. a bool read as a uint256 from another contract
+	Paused *Getter `json:"paused,omitempty"`
+
+	// MinAmountIn, MaxAmountIn and DepositCap bound deposits (token0 -> token1)
+	MinAmountIn *Getter     `json:"minAmountIn,omitempty"`
+	MaxAmountIn *Getter     `json:"maxAmountIn,omitempty"`
+	DepositCap  *DepositCap `json:"depositCap,omitempty"`
+
+	// DepositFee and RedeemFee are charged on the amount in, in FeeUnit (basis points by default)
+	DepositFee *Getter  `json:"depositFee,omitempty"`
+	RedeemFee  *Getter  `json:"redeemFee,omitempty"`
+	FeeUnit    *big.Int `json:"feeUnit,omitempty"`
+
+	// ActiveFrom and ActiveUntil pause swaps before and from the timestamps they read
+	ActiveFrom  *Getter `json:"activeFrom,omitempty"`
+	ActiveUntil *Getter `json:"activeUntil,omitempty"`
+}
+
+// readsRateMethod tells whether the rate is read from RateMethod rather than from Rate
+func (c *Config) readsRateMethod() bool {
+	return c.IsRateUpdatable && c.Rate == nil
+}
+
+// addGetterCalls adds the calls of the getters of the config for a pool to req
+func (c *Config) addGetterCalls(req *ethrpc.Request, poolAddress string) (getterResults, error) {
+	results := getterResults{}
+	for _, g := range c.getters() {
+		if err := results.addCall(req, g, poolAddress); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// updateExtra sets into extra what the getters of the config read, keeping the previous values of the ones that failed
+func (c *Config) updateExtra(extra *PoolExtra, results getterResults) {
+	if c.Rate != nil {
+		rate, rateUnit := toUint256(results.product(c.Rate.Numerators)), toUint256(results.product(c.Rate.Denominators))
+		if rate != nil && rateUnit != nil && !rateUnit.IsZero() {
+			extra.Rate, extra.RateUnit = rate, rateUnit
+		}
+	}
+
+	if v := results.value(c.Paused); v != nil {
+		extra.Paused = v.Sign() != 0
+	}
+
+	if v := toUint256(results.value(c.MinAmountIn)); v != nil {
+		extra.MinAmountIn = v
+	}
+	if v := toUint256(results.value(c.MaxAmountIn)); v != nil {
+		extra.MaxAmountIn = v
+	}
+	if c.DepositCap != nil {
+		capacity, used := results.value(&c.DepositCap.Cap), results.value(&c.DepositCap.Used)
+		if capacity != nil && used != nil {
+			extra.DepositCap = toUint256(lo.Ternary(capacity.Cmp(used) > 0, new(big.Int).Sub(capacity, used), new(big.Int)))
+			extra.IsCapInTokenOut = c.DepositCap.InTokenOut
+		}
+	}
+
+	if v := toUint256(results.value(c.DepositFee)); v != nil {
+		extra.DepositFee = v
+	}
+	if v := toUint256(results.value(c.RedeemFee)); v != nil {
+		extra.RedeemFee = v
+	}
+	if extra.DepositFee != nil || extra.RedeemFee != nil {
+		extra.FeeUnit = toUint256(lo.Ternary(c.FeeUnit != nil, c.FeeUnit, big.NewInt(defaultFeeUnit)))
+	}
+
+	if v := toUint256(results.value(c.ActiveFrom)); v != nil && v.IsUint64() {
+		extra.ActiveFrom = v.Uint64()
+	}
+	if v := toUint256(results.value(c.ActiveUntil)); v != nil && v.IsUint64() {
+		extra.ActiveUntil = v.Uint64()
+	}
+}
+
+func (c *Config) getters() []*Getter {
+	var getters []*Getter
+	if c.Rate != nil {
+		for i := range c.Rate.Numerators {
+			getters = append(getters, &c.Rate.Numerators[i])
+		}
+		for i := range c.Rate.Denominators {
+			getters = append(getters, &c.Rate.Denominators[i])
+		}
+	}
+	if c.DepositCap != nil {
+		getters = append(getters, &c.DepositCap.Cap, &c.DepositCap.Used)
+	}
+	return append(getters, c.Paused, c.MinAmountIn, c.MaxAmountIn, c.DepositFee, c.RedeemFee, c.ActiveFrom, c.ActiveUntil)
+}
+
+// toUint256 converts v, returning nil if it is nil, negative or overflows
+func toUint256(v *big.Int) *uint256.Int {
+	if v == nil || v.Sign() < 0 {
+		return nil
+	}
+	u, overflow := uint256.FromBig(v)
+	if overflow {
+		return nil
+	}
+	return u
 }
@@ -10,9 +10,13 @@ const (
 	defaultTokenWeight       = 1
 	defaultReserves          = "100000000000000000000000000"
 	DefaultGas         int64 = 60000
+
+	defaultFeeUnit = 10000
 )
 
 var (
-	ErrPoolPaused = errors.New("pool is paused")
-	ErrOverflow   = errors.New("overflow")
+	ErrPoolPaused         = errors.New("pool is paused")
+	ErrOverflow           = errors.New("overflow")
+	ErrInvalidAmountIn    = errors.New("amount in out of the bounds of the pool")
+	ErrDepositCapExceeded = errors.New("deposit cap exceeded")
 )
@@ -0,0 +1,113 @@
+package generic_simple_rate
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+)
+
+// Getter is a value read from a view method without arguments, or the constant Value if Method is empty.
+type Getter struct {
+	// Target is the contract called, the pool if empty
+	Target string `json:"target,omitempty"`
+	Method string `json:"method,omitempty"`
+	// Outputs are the types returned by Method, a single uint256 if empty. Only integers wider than 64 bits are
+	// supported, as they are all read into big.Int.
+	Outputs []string `json:"outputs,omitempty"`
+	// Output is the index of the output picked
+	Output int `json:"output,omitempty"`
+
+	Value *big.Int `json:"value,omitempty"`
+}
+
+// RateExpr expresses a rate as the product of its Numerators over the product of its Denominators, e.g. the ratio of
+// two calls, or an oracle price times a rate over the oracle unit. The products are kept apart as the rate and rate unit
+// of the pool, so that amounts are converted with a single multiplication and division as the contracts do.
+type RateExpr struct {
+	Numerators   []Getter `json:"numerators"`
+	Denominators []Getter `json:"denominators"`
+}
+
+// DepositCap bounds deposits (token0 -> token1) by Cap - Used, in token1 amounts if InTokenOut (e.g. a maximum supply)
+// or else in token0 amounts.
+type DepositCap struct {
+	Cap        Getter `json:"cap"`
+	Used       Getter `json:"used"`
+	InTokenOut bool   `json:"inTokenOut,omitempty"`
+}
+
+// getterResults holds the values read by the calls of getters, nil for the calls that failed
+type getterResults map[*Getter][]*big.Int
+
+// addCall adds the call of g, if any, to req
+func (r getterResults) addCall(req *ethrpc.Request, g *Getter, poolAddress string) error {
+	if g == nil || g.Method == "" {
+		return nil
+	}
+
+	outputs := lo.Ternary(len(g.Outputs) == 0, []string{"uint256"}, g.Outputs)
+	if g.Output < 0 || g.Output >= len(outputs) {
+		return fmt.Errorf("output %d out of range of %s", g.Output, g.Method)
+	}
+	getterABI, err := getterABI(g.Method, outputs)
+	if err != nil {
+		return err
+	}
+
+	values := make([]*big.Int, len(outputs))
+	r[g] = values
+	req.AddCall(&ethrpc.Call{
+		ABI:    getterABI,
+		Target: lo.Ternary(g.Target == "", poolAddress, g.Target),
+		Method: g.Method,
+		Params: []interface{}{},
+	}, []interface{}{lo.Ternary[any](len(outputs) == 1, &values[0], &values)})
+	return nil
+}
+
+// value returns the value of g, nil if g is nil or its call failed
+func (r getterResults) value(g *Getter) *big.Int {
+	if g == nil {
+		return nil
+	} else if g.Method == "" {
+		return g.Value
+	} else if values := r[g]; g.Output < len(values) {
+		return values[g.Output]
+	}
+	return nil
+}
+
+// product returns the product of the values of getters, 1 if there is none, or nil if any of them is missing
+func (r getterResults) product(getters []Getter) *big.Int {
+	product := big.NewInt(1)
+	for i := range getters {
+		value := r.value(&getters[i])
+		if value == nil {
+			return nil
+		}
+		product.Mul(product, value)
+	}
+	return product
+}
+
+// getterABI builds the ABI of a view method without arguments returning outputs
+func getterABI(method string, outputs []string) (abi.ABI, error) {
+	methodABI, err := json.Marshal([]map[string]any{{
+		"type":            "function",
+		"name":            method,
+		"stateMutability": "view",
+		"inputs":          []any{},
+		"outputs": lo.Map(outputs, func(output string, _ int) map[string]string {
+			return map[string]string{"type": output}
+		}),
+	}})
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	return abi.JSON(strings.NewReader(string(methodABI)))
+}
@@ -0,0 +1,30 @@
+package generic_simple_rate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetterABI(t *testing.T) {
+	t.Parallel()
+	getterABI, err := getterABI("latestRoundData", []string{"uint80", "int256", "uint256", "uint256", "uint80"})
+	require.NoError(t, err)
+	data, err := getterABI.Methods["latestRoundData"].Outputs.Pack(big.NewInt(1), big.NewInt(-2), big.NewInt(3),
+		big.NewInt(4), big.NewInt(5))
+	require.NoError(t, err)
+
+	g := &Getter{Method: "latestRoundData", Output: 1}
+	results := getterResults{g: make([]*big.Int, 5)}
+	values := results[g]
+	require.NoError(t, getterABI.UnpackIntoInterface(&values, "latestRoundData", data))
+	assert.Equal(t, int64(-2), results.value(g).Int64())
+	assert.Nil(t, toUint256(results.value(g)), "negative values should be dropped")
+
+	constant := Getter{Value: big.NewInt(7)}
+	assert.Equal(t, int64(7), results.value(&constant).Int64())
+	assert.Equal(t, int64(49), results.product([]Getter{constant, constant}).Int64())
+	assert.Nil(t, results.product([]Getter{constant, {Method: "failed"}}))
+}
@@ -0,0 +1,303 @@
+package generic_simple_rate
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/bedrock/unieth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/frax/sfrxeth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/mantle/meth"
+	ondo_usdy "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/ondo-usdy"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/staderethx"
+	swellcommon "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/swell/common"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/swell/rsweth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/swell/sweth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/usd0pp"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// TestParity checks that config entries of generic-simple-rate quote deposits, and redeems where the bespoke package
+// supports them, exactly as the bespoke packages they can replace, given the values their getters read. Not covered:
+//   - wbeth has no bespoke simulator, it is already a generic-simple-rate entry reading exchangeRate.
+//   - primeeth bounds deposits by getTotalAssetDeposits(asset) and depositLimitByAsset(asset), and getters can't pass
+//     arguments.
+func TestParity(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Unix()
+
+	testCases := []struct {
+		name      string
+		bespoke   entity.Pool
+		newPool   func(entity.Pool) (pool.IPoolSimulator, error)
+		config    string
+		rate      string // read from rateMethod
+		read      func(cfg *Config) getterResults
+		amountsIn []string
+		// redeemAmountsIn are quoted from token1 to token0
+		redeemAmountsIn []string
+	}{
+		{
+			name: "swell-sweth",
+			bespoke: entity.Pool{
+				Tokens: tokens(swellcommon.WETH, swellcommon.SWETH),
+				Extra:  `{"paused":false,"swETHToETHRate":1056161260917865806}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return sweth.NewPoolSimulator(p) },
+			config: `{"dexID":"swell-sweth","rateMethod":"swETHToETHRate","rateUnit":1000000000000000000,
+				"isRateInversed":true,"isRateUpdatable":true,
+				"paused":{"target":"0x625087d72c762254a72cb22cc2eca40da6b95eac","method":"coreMethodsPaused"}}`,
+			rate: "1056161260917865806",
+			read: func(cfg *Config) getterResults {
+				return getterResults{cfg.Paused: values("0")}
+			},
+			amountsIn: []string{"1", "300000000000000000", "1234567891234567891234"},
+		},
+		{
+			name: "swell-rsweth",
+			bespoke: entity.Pool{
+				Tokens: tokens(swellcommon.WETH, swellcommon.RSWETH),
+				Extra:  `{"paused":false,"ethToRswETHRate":986513562370148113}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return rsweth.NewPoolSimulator(p) },
+			config: `{"dexID":"swell-rsweth","rateMethod":"ethToRswETHRate","rateUnit":1000000000000000000,
+				"isRateUpdatable":true,
+				"paused":{"target":"0x625087d72c762254a72cb22cc2eca40da6b95eac","method":"coreMethodsPaused"}}`,
+			rate: "986513562370148113",
+			read: func(cfg *Config) getterResults {
+				return getterResults{cfg.Paused: values("0")}
+			},
+			amountsIn: []string{"1", "300000000000000000", "1234567891234567891234"},
+		},
+		{
+			name: "frax-sfrxeth",
+			bespoke: entity.Pool{
+				Address:  "0xbafa44efe7901e04e39dad13167d089c559c1138",
+				Tokens:   tokens(swellcommon.WETH, "0xac3e018457b222d93114458476f3e3416abbe38f"),
+				Reserves: entity.PoolReserves{"229818454138218939562513", "207232958254286473627581"},
+				Extra:    `{"submitPaused":false}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return sfrxeth.NewPoolSimulator(p) },
+			config: `{"dexID":"frax-sfrxeth","isRateInversed":true,
+				"rate":{"numerators":[{"target":"0xac3e018457b222d93114458476f3e3416abbe38f","method":"totalAssets"}],
+					"denominators":[{"target":"0xac3e018457b222d93114458476f3e3416abbe38f","method":"totalSupply"}]},
+				"paused":{"target":"0xbafa44efe7901e04e39dad13167d089c559c1138","method":"submitPaused"}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					&cfg.Rate.Numerators[0]:   values("229818454138218939562513"),
+					&cfg.Rate.Denominators[0]: values("207232958254286473627581"),
+					cfg.Paused:                values("0"),
+				}
+			},
+			amountsIn: []string{"1", "300000000000000000", "1234567891234567891234"},
+		},
+		{
+			// except for unwrapping less than 1e-4 USDY shares or more than the total shares, which only ondo-usdy rejects
+			name: "ondo-usdy",
+			bespoke: entity.Pool{
+				Address: "0xaf37c1167910ebc994e266949387d2c7c326b879",
+				Tokens:  tokens("0x96f6ef951840721adbf46ac996b59e0235cb985c", "0xaf37c1167910ebc994e266949387d2c7c326b879"),
+				Extra: `{"paused":false,"totalShares":"3027418520846316530960551290000",` +
+					`"oraclePrice":"1093046271000000000","priceTimeStamp":1742000000}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return ondo_usdy.NewPoolSimulator(p) },
+			config: `{"dexID":"ondo-usdy","isBidirectional":true,
+				"rate":{
+					"numerators":[{"target":"0xa0219aa5b31e65bc920b5b6dfb8edf0988121de0","method":"getPriceData",
+						"outputs":["uint256","uint256"]}],
+					"denominators":[{"value":1000000000000000000}]},
+				"paused":{"method":"paused"}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					&cfg.Rate.Numerators[0]: values("1093046271000000000", "1742000000"),
+					cfg.Paused:              values("0"),
+				}
+			},
+			amountsIn:       []string{"1", "300000000000000000", "1234567891234567891234"},
+			redeemAmountsIn: []string{"1000000000000000", "300000000000000000", "1234567891234567891234"},
+		},
+		{
+			name: "bedrock-unieth",
+			bespoke: entity.Pool{
+				Tokens: tokens(unieth.WETH, unieth.UNIETH),
+				Extra:  `{"paused":false,"totalSupply":40654517980271452478787,"currentReserve":43102498463014375406128}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return unieth.NewPoolSimulator(p) },
+			config: `{"dexID":"bedrock-unieth","isRateInversed":true,
+				"rate":{"numerators":[{"target":"0x4befa2aa9c305238aa3e0b5d17eb20c045269e9d","method":"currentReserve"}],
+					"denominators":[{"target":"0xf1376bcef0f78459c0ed0ba5ddce976f1ddf51f4","method":"totalSupply"}]},
+				"paused":{"target":"0x4befa2aa9c305238aa3e0b5d17eb20c045269e9d","method":"paused","outputs":["uint256"]}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					&cfg.Rate.Numerators[0]:   values("43102498463014375406128"),
+					&cfg.Rate.Denominators[0]: values("40654517980271452478787"),
+					cfg.Paused:                values("0"),
+				}
+			},
+			amountsIn: []string{"1", "106100000000000000", "1234567891234567891234"},
+		},
+		{
+			name: "staderethx",
+			bespoke: entity.Pool{
+				Tokens: tokens(staderethx.WETH, staderethx.ETHx),
+				Extra: `{"paused":false,"minDeposit":"100000000000000","maxDeposit":"10000000000000000000000",` +
+					`"totalETHBalance":"123620470619443769071059","totalETHXSupply":"118600315516947203976686"}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return staderethx.NewPoolSimulator(p) },
+			config: `{"dexID":"staderethx","isRateInversed":true,
+				"rate":{
+					"numerators":[{"target":"0xf64bae65f6f2a5277571143a24faafdfc0c2a737","method":"exchangeRate",
+						"outputs":["uint256","uint256","uint256"],"output":1}],
+					"denominators":[{"target":"0xf64bae65f6f2a5277571143a24faafdfc0c2a737","method":"exchangeRate",
+						"outputs":["uint256","uint256","uint256"],"output":2}]},
+				"paused":{"target":"0xcf5ea1b38380f6af39068375516daf40ed70d299","method":"paused"},
+				"minAmountIn":{"target":"0xcf5ea1b38380f6af39068375516daf40ed70d299","method":"minDeposit"},
+				"maxAmountIn":{"target":"0xcf5ea1b38380f6af39068375516daf40ed70d299","method":"maxDeposit"}}`,
+			read: func(cfg *Config) getterResults {
+				exchangeRate := values("20000000", "123620470619443769071059", "118600315516947203976686")
+				return getterResults{
+					&cfg.Rate.Numerators[0]:   exchangeRate,
+					&cfg.Rate.Denominators[0]: exchangeRate,
+					cfg.Paused:                values("0"),
+					cfg.MinAmountIn:           values("100000000000000"),
+					cfg.MaxAmountIn:           values("10000000000000000000000"),
+				}
+			},
+			amountsIn: []string{"99999999999999", "100000000000000", "10000000023132132100", "10000000000000000000000",
+				"10000000000000000000001"},
+		},
+		{
+			// only while the exchange adjustment rate of mETH is zero, as it is not a product of getters
+			name: "meth",
+			bespoke: entity.Pool{
+				Tokens: tokens(meth.WETH, meth.METH),
+				Extra: `{"isStakingPaused":false,"minimumStakeBound":"20000000000000000",` +
+					`"maximumMETHSupply":"469453183427363384875942","totalControlled":"491321321208383495845117",` +
+					`"exchangeAdjustmentRate":0,"mETHTotalSupply":"469448183427363384875942"}`,
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return meth.NewPoolSimulator(p) },
+			config: `{"dexID":"meth","isRateInversed":true,
+				"rate":{"numerators":[{"target":"0xe3cbd06d7dadb3f4e6557bab7edd924cd1489e8f","method":"totalControlled"}],
+					"denominators":[{"target":"0xd5f7838f5c461feff7fe49ea5ebaf7728bb0adfa","method":"totalSupply"}]},
+				"paused":{"target":"0x29ab878aed032e2e2c86ff4a9a9b05e3276cf1f8","method":"isStakingPaused"},
+				"minAmountIn":{"target":"0xe3cbd06d7dadb3f4e6557bab7edd924cd1489e8f","method":"minimumStakeBound"},
+				"depositCap":{
+					"cap":{"target":"0xe3cbd06d7dadb3f4e6557bab7edd924cd1489e8f","method":"maximumMETHSupply"},
+					"used":{"target":"0xd5f7838f5c461feff7fe49ea5ebaf7728bb0adfa","method":"totalSupply"},
+					"inTokenOut":true}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					&cfg.Rate.Numerators[0]:   values("491321321208383495845117"),
+					&cfg.Rate.Denominators[0]: values("469448183427363384875942"),
+					cfg.Paused:                values("0"),
+					cfg.MinAmountIn:           values("20000000000000000"),
+					&cfg.DepositCap.Cap:       values("469453183427363384875942"),
+					&cfg.DepositCap.Used:      values("469448183427363384875942"),
+				}
+			},
+			amountsIn: []string{"19999999999999999", "20000000000000000", "1234567891234567891",
+				"5232966475888009164", "5232966475888009165", "6000000000000000000"},
+		},
+		{
+			name: "usd0pp",
+			bespoke: entity.Pool{
+				Tokens: tokens(usd0pp.USD0, usd0pp.USD0PP),
+				Extra:  fmt.Sprintf(`{"paused":false,"startTime":%d,"endTime":%d}`, now-3600, now+3600),
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return usd0pp.NewPoolSimulator(p) },
+			config: `{"dexID":"usd0pp","defaultRate":1,"rateUnit":1,"isRateInversed":true,"pausedMethod":"paused",
+				"activeFrom":{"method":"getStartTime"},"activeUntil":{"method":"getEndTime"}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					cfg.ActiveFrom:  values(fmt.Sprint(now - 3600)),
+					cfg.ActiveUntil: values(fmt.Sprint(now + 3600)),
+				}
+			},
+			amountsIn: []string{"1", "1234567891234567891234"},
+		},
+		{
+			name: "usd0pp ended",
+			bespoke: entity.Pool{
+				Tokens: tokens(usd0pp.USD0, usd0pp.USD0PP),
+				Extra:  fmt.Sprintf(`{"paused":false,"startTime":%d,"endTime":%d}`, now-7200, now-3600),
+			},
+			newPool: func(p entity.Pool) (pool.IPoolSimulator, error) { return usd0pp.NewPoolSimulator(p) },
+			config: `{"dexID":"usd0pp","defaultRate":1,"rateUnit":1,"isRateInversed":true,"pausedMethod":"paused",
+				"activeFrom":{"method":"getStartTime"},"activeUntil":{"method":"getEndTime"}}`,
+			read: func(cfg *Config) getterResults {
+				return getterResults{
+					cfg.ActiveFrom:  values(fmt.Sprint(now - 7200)),
+					cfg.ActiveUntil: values(fmt.Sprint(now - 3600)),
+				}
+			},
+			amountsIn: []string{"1234567891234567891234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.bespoke.Reserves == nil {
+				tc.bespoke.Reserves = entity.PoolReserves{defaultReserves, defaultReserves}
+			}
+			bespoke, err := tc.newPool(tc.bespoke)
+			require.NoError(t, err)
+
+			var cfg Config
+			require.NoError(t, json.Unmarshal([]byte(tc.config), &cfg))
+			extra := PoolExtra{
+				Rate:            uint256.MustFromBig(lo.Ternary(tc.rate != "", bignumber.NewBig(tc.rate), cfg.DefaultRate)),
+				RateUnit:        uint256.MustFromBig(cfg.RateUnit),
+				IsRateInversed:  cfg.IsRateInversed,
+				IsBidirectional: cfg.IsBidirectional,
+				DefaultGas:      DefaultGas,
+			}
+			cfg.updateExtra(&extra, tc.read(&cfg))
+			extraBytes, err := json.Marshal(extra)
+			require.NoError(t, err)
+			generic, err := NewPoolSimulator(entity.Pool{
+				Exchange: cfg.DexID,
+				Type:     DexType,
+				Reserves: tc.bespoke.Reserves,
+				Tokens:   tc.bespoke.Tokens,
+				Extra:    string(extraBytes),
+			})
+			require.NoError(t, err)
+
+			assertParity := func(tokenIn, tokenOut string, amountsIn []string) {
+				for _, amountIn := range amountsIn {
+					params := pool.CalcAmountOutParams{
+						TokenAmountIn: pool.TokenAmount{Token: tokenIn, Amount: bignumber.NewBig(amountIn)},
+						TokenOut:      tokenOut,
+					}
+					expected, expectedErr := bespoke.CalcAmountOut(params)
+					result, err := generic.CalcAmountOut(params)
+					if expectedErr != nil {
+						assert.Error(t, err, "amountIn %s", amountIn)
+						continue
+					}
+					require.NoError(t, err, "amountIn %s", amountIn)
+					assert.Equal(t, expected.TokenAmountOut.Amount, result.TokenAmountOut.Amount, "amountIn %s", amountIn)
+				}
+			}
+			assertParity(tc.bespoke.Tokens[0].Address, tc.bespoke.Tokens[1].Address, tc.amountsIn)
+			assertParity(tc.bespoke.Tokens[1].Address, tc.bespoke.Tokens[0].Address, tc.redeemAmountsIn)
+		})
+	}
+}
+
+func tokens(token0, token1 string) []*entity.PoolToken {
+	return []*entity.PoolToken{{Address: token0, Swappable: true}, {Address: token1, Swappable: true}}
+}
+
+func values(values ...string) []*big.Int {
+	return lo.Map(values, func(value string, _ int) *big.Int { return bignumber.NewBig(value) })
+}
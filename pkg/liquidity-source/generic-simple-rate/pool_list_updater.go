@@ -128,7 +128,7 @@ func (d *PoolsListUpdater) getNewPool(pool *PoolItem) (entity.Pool, error) {
 		}, []interface{}{&paused})
 	}
 
-	if d.config.IsRateUpdatable {
+	if d.config.readsRateMethod() {
 		req.AddCall(&ethrpc.Call{
 			ABI:    GetABI(d.config.DexID),
 			Target: pool.ID,
@@ -139,6 +139,11 @@ func (d *PoolsListUpdater) getNewPool(pool *PoolItem) (entity.Pool, error) {
 		rate = d.config.DefaultRate
 	}
 
+	getterResults, err := d.config.addGetterCalls(req, pool.ID)
+	if err != nil {
+		return entity.Pool{}, err
+	}
+
 	if len(req.Calls) > 0 {
 		_, err := req.Aggregate()
 		if err != nil {
@@ -151,14 +156,17 @@ func (d *PoolsListUpdater) getNewPool(pool *PoolItem) (entity.Pool, error) {
 		defaultGas = d.config.DefaultGas.Int64()
 	}
 
-	poolExtraBytes, err := json.Marshal(PoolExtra{
+	poolExtra := PoolExtra{
 		Paused:          paused,
 		Rate:            uint256.MustFromBig(rate),
 		RateUnit:        uint256.MustFromBig(d.config.RateUnit),
 		IsRateInversed:  d.config.IsRateInversed,
 		IsBidirectional: d.config.IsBidirectional,
 		DefaultGas:      defaultGas,
-	})
+	}
+	d.config.updateExtra(&poolExtra, getterResults)
+
+	poolExtraBytes, err := json.Marshal(poolExtra)
 	if err != nil {
 		return entity.Pool{}, err
 	}
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
+	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
@@ -18,13 +21,26 @@ type PoolSimulator struct {
 	paused          bool
 	gas             int64
 	rate            *uint256.Int
+	rateGrowth      *accrual.Linear
 	rateUnit        *uint256.Int
 	isRateInversed  bool
 	isBidirectional bool
+
+	minAmountIn     *uint256.Int
+	maxAmountIn     *uint256.Int
+	depositCap      *uint256.Int
+	isCapInTokenOut bool
+	depositFee      *uint256.Int
+	redeemFee       *uint256.Int
+	feeUnit         *uint256.Int
+	activeFrom      uint64
+	activeUntil     uint64
 }
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
 
+var now = time.Now
+
 func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 	numTokens := len(entityPool.Tokens)
 	if numTokens != 2 {
@@ -58,15 +74,25 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		},
 		paused:          poolExtra.Paused,
 		rate:            poolExtra.Rate,
+		rateGrowth:      poolExtra.RateGrowth,
 		rateUnit:        poolExtra.RateUnit,
 		isRateInversed:  poolExtra.IsRateInversed,
 		isBidirectional: poolExtra.IsBidirectional,
 		gas:             poolExtra.DefaultGas,
+		minAmountIn:     poolExtra.MinAmountIn,
+		maxAmountIn:     poolExtra.MaxAmountIn,
+		depositCap:      poolExtra.DepositCap,
+		isCapInTokenOut: poolExtra.IsCapInTokenOut,
+		depositFee:      poolExtra.DepositFee,
+		redeemFee:       poolExtra.RedeemFee,
+		feeUnit:         poolExtra.FeeUnit,
+		activeFrom:      poolExtra.ActiveFrom,
+		activeUntil:     poolExtra.ActiveUntil,
 	}, nil
 }
 
 func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
-	if p.paused {
+	if p.paused || !p.isActive(uint64(now().Unix())) {
 		return nil, ErrPoolPaused
 	}
 
@@ -80,11 +106,31 @@ func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 		return &pool.CalcAmountOutResult{}, fmt.Errorf("tokenInIndex: %v or tokenOutIndex: %v is not correct", tokenInIndex, tokenOutIndex)
 	}
 
-	amountOut, err := p.calcAmountOut(tokenInIndex, tokenAmountIn.Amount)
+	amountIn, overflow := uint256.FromBig(tokenAmountIn.Amount)
+	if overflow {
+		return nil, ErrOverflow
+	}
+
+	isDeposit := tokenInIndex == 0
+	if isDeposit {
+		if p.minAmountIn != nil && amountIn.Lt(p.minAmountIn) || p.maxAmountIn != nil && amountIn.Gt(p.maxAmountIn) {
+			return nil, ErrInvalidAmountIn
+		}
+		if p.depositCap != nil && !p.isCapInTokenOut && amountIn.Gt(p.depositCap) {
+			return nil, ErrDepositCapExceeded
+		}
+	}
+
+	fee := p.fee(amountIn, lo.Ternary(isDeposit, p.depositFee, p.redeemFee))
+	amountOut, err := p.calcAmountOut(tokenInIndex, new(uint256.Int).Sub(amountIn, fee))
 	if err != nil {
 		return nil, err
 	}
 
+	if isDeposit && p.depositCap != nil && p.isCapInTokenOut && amountOut.Gt(p.depositCap) {
+		return nil, ErrDepositCapExceeded
+	}
+
 	return &pool.CalcAmountOutResult{
 		TokenAmountOut: &pool.TokenAmount{
 			Token:  tokenOut,
@@ -92,13 +138,29 @@ func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 		},
 		Fee: &pool.TokenAmount{
 			Token:  tokenAmountIn.Token,
-			Amount: bignumber.ZeroBI,
+			Amount: fee.ToBig(),
 		},
 		Gas: p.gas,
 	}, nil
 }
 
-func (p *PoolSimulator) UpdateBalance(_ pool.UpdateBalanceParams) {
+func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := *p
+	return &cloned
+}
+
+func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
+	if p.depositCap == nil || p.GetTokenIndex(params.TokenAmountIn.Token) != 0 {
+		return
+	}
+
+	deposited := uint256.MustFromBig(lo.Ternary(p.isCapInTokenOut, params.TokenAmountOut.Amount,
+		params.TokenAmountIn.Amount))
+	if deposited.Gt(p.depositCap) {
+		p.depositCap = new(uint256.Int)
+	} else {
+		p.depositCap = new(uint256.Int).Sub(p.depositCap, deposited)
+	}
 }
 
 func (p *PoolSimulator) CanSwapTo(address string) []string {
@@ -125,15 +187,31 @@ func (p *PoolSimulator) GetMetaInfo(_ string, _ string) interface{} {
 	return nil
 }
 
-func (p *PoolSimulator) calcAmountOut(tokenInIndex int, amountIn *big.Int) (*uint256.Int, error) {
-	amountOut := new(uint256.Int)
-	if amountOut.SetFromBig(amountIn) {
-		return nil, ErrOverflow
-	}
+func (p *PoolSimulator) calcAmountOut(tokenInIndex int, amountIn *uint256.Int) (*uint256.Int, error) {
+	rate := p.rateGrowth.Project(p.rate, uint64(now().Unix()))
+	numerator, denominator := rate, p.rateUnit
 	if p.isRateInversed == (tokenInIndex == 0) {
-		amountOut = amountOut.Mul(amountOut, p.rateUnit).Div(amountOut, p.rate)
-	} else {
-		amountOut = amountOut.Div(amountOut, p.rateUnit).Mul(amountOut, p.rate)
+		numerator, denominator = p.rateUnit, rate
+	}
+	amountOut, overflow := new(uint256.Int).MulDivOverflow(amountIn, numerator, denominator)
+	if overflow {
+		return nil, ErrOverflow
 	}
 	return amountOut, nil
 }
+
+// isActive tells whether swaps are not paused by the time window of the pool at timestamp
+func (p *PoolSimulator) isActive(timestamp uint64) bool {
+	return timestamp >= p.activeFrom && (p.activeUntil == 0 || timestamp < p.activeUntil)
+}
+
+// fee returns the fee charged on amountIn at feeRate
+func (p *PoolSimulator) fee(amountIn, feeRate *uint256.Int) *uint256.Int {
+	if feeRate == nil || feeRate.IsZero() || p.feeUnit == nil || p.feeUnit.IsZero() {
+		return new(uint256.Int)
+	}
+	if fee, overflow := new(uint256.Int).MulDivOverflow(amountIn, feeRate, p.feeUnit); !overflow && fee.Lt(amountIn) {
+		return fee
+	}
+	return new(uint256.Int).Set(amountIn)
+}
@@ -75,6 +75,7 @@ func TestGetAmountOut(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool, err := NewPoolSimulator(tc.entityPool)
 			assert.Nil(t, err)
+			testutil.TestPoolSimulator(t, pool)
 			calcAmountOutResult, err := testutil.MustConcurrentSafe(t, func() (*poolPkg.CalcAmountOutResult, error) {
 				return pool.CalcAmountOut(poolPkg.CalcAmountOutParams{
 					TokenAmountIn: tc.tokenAmountIn,
@@ -88,3 +89,66 @@ func TestGetAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestBoundsAndFees(t *testing.T) {
+	t.Parallel()
+	newPool := func(extra string) *PoolSimulator {
+		entityPool := testPool
+		entityPool.Extra = extra
+		s, err := NewPoolSimulator(entityPool)
+		assert.NoError(t, err)
+		return s
+	}
+	token0, token1 := testPool.Tokens[0].Address, testPool.Tokens[1].Address
+	deposit := func(amount string) poolPkg.CalcAmountOutParams {
+		return poolPkg.CalcAmountOutParams{
+			TokenAmountIn: poolPkg.TokenAmount{Token: token0, Amount: bignumber.NewBig(amount)},
+			TokenOut:      token1,
+		}
+	}
+
+	t.Run("fees are charged on the amount in", func(t *testing.T) {
+		s := newPool(`{"rate":2,"rateUnit":1,"isRateInversed":true,"depositFee":30,"redeemFee":10,"feeUnit":10000}`)
+		result, err := s.CalcAmountOut(deposit("10000"))
+		assert.NoError(t, err)
+		assert.Equal(t, "4985", result.TokenAmountOut.Amount.String())
+		assert.Equal(t, "30", result.Fee.Amount.String())
+
+		result, err = s.CalcAmountOut(poolPkg.CalcAmountOutParams{
+			TokenAmountIn: poolPkg.TokenAmount{Token: token1, Amount: bignumber.NewBig("10000")},
+			TokenOut:      token0,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "19980", result.TokenAmountOut.Amount.String())
+	})
+
+	t.Run("deposits are bounded and drain the cap", func(t *testing.T) {
+		s := newPool(`{"rate":2,"rateUnit":1,"isRateInversed":true,"minAmountIn":10,"maxAmountIn":1000,` +
+			`"depositCap":150,"isCapInTokenOut":true}`)
+		_, err := s.CalcAmountOut(deposit("9"))
+		assert.ErrorIs(t, err, ErrInvalidAmountIn)
+		_, err = s.CalcAmountOut(deposit("1001"))
+		assert.ErrorIs(t, err, ErrInvalidAmountIn)
+		_, err = s.CalcAmountOut(deposit("302"))
+		assert.ErrorIs(t, err, ErrDepositCapExceeded)
+
+		params := deposit("200")
+		result, err := s.CalcAmountOut(params)
+		assert.NoError(t, err)
+		cloned := s.CloneState()
+		s.UpdateBalance(poolPkg.UpdateBalanceParams{TokenAmountIn: params.TokenAmountIn, TokenAmountOut: *result.TokenAmountOut})
+		_, err = s.CalcAmountOut(params)
+		assert.ErrorIs(t, err, ErrDepositCapExceeded)
+		_, err = cloned.CalcAmountOut(params)
+		assert.NoError(t, err)
+	})
+
+	t.Run("time window", func(t *testing.T) {
+		s := newPool(`{"rate":2,"rateUnit":1,"activeFrom":1000,"activeUntil":2000}`)
+		assert.False(t, s.isActive(999))
+		assert.True(t, s.isActive(1000))
+		assert.False(t, s.isActive(2000))
+		s.activeUntil = 0
+		assert.True(t, s.isActive(3000))
+	})
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
+	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
 )
 
 type PoolTracker struct {
@@ -84,7 +86,7 @@ func (t *PoolTracker) getNewPoolState(
 		}, []interface{}{&paused})
 	}
 
-	if t.config.IsRateUpdatable {
+	if t.config.readsRateMethod() {
 		calls.AddCall(&ethrpc.Call{
 			ABI:    ABI,
 			Target: p.Address,
@@ -93,6 +95,11 @@ func (t *PoolTracker) getNewPoolState(
 		}, []interface{}{&rate})
 	}
 
+	getterResults, err := t.config.addGetterCalls(calls, p.Address)
+	if err != nil {
+		return p, err
+	}
+
 	if len(calls.Calls) == 0 {
 		return p, nil
 	}
@@ -107,9 +114,25 @@ func (t *PoolTracker) getNewPoolState(
 		poolExtra.Paused = paused
 	}
 
-	if t.config.IsRateUpdatable {
+	prevRate := unitRate(&poolExtra)
+	if t.config.readsRateMethod() {
 		poolExtra.Rate = uint256.MustFromBig(rate)
 	}
+	t.config.updateExtra(&poolExtra, getterResults)
+
+	if t.config.IsRateAccruing {
+		req := t.ethrpcClient.NewRequest().SetContext(ctx)
+		if overrides != nil {
+			req.SetOverrides(overrides)
+		}
+		blockTimestamp, err := req.GetCurrentBlockTimestamp()
+		if err != nil {
+			logger.WithFields(logger.Fields{"dex_id": t.config.DexID, "pool_id": p.Address}).
+				Error("Failed to get block timestamp")
+			return p, err
+		}
+		poolExtra.RateGrowth = accrual.Estimate(poolExtra.RateGrowth, prevRate, unitRate(&poolExtra), blockTimestamp)
+	}
 
 	extraBytes, err := json.Marshal(poolExtra)
 	if err != nil {
@@ -135,3 +158,16 @@ func (t *PoolTracker) getNewPoolState(
 
 	return p, nil
 }
+
+// unitRate returns the rate of extra per 1e18 of its rate unit, so that rates read by a RateExpr, whose rate unit changes
+// too, can be compared between tracker runs
+func unitRate(extra *PoolExtra) *uint256.Int {
+	if extra.Rate == nil || extra.RateUnit == nil || extra.RateUnit.IsZero() {
+		return nil
+	}
+	rate, overflow := new(uint256.Int).MulDivOverflow(extra.Rate, big256.BONE, extra.RateUnit)
+	if overflow {
+		return nil
+	}
+	return rate
+}
@@ -1 +1,24 @@
 package generic_simple_rate
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
+)
+
+func TestUnitRate(t *testing.T) {
+	t.Parallel()
+	// a RateExpr of totalAssets over totalSupply grows although both change
+	prev := unitRate(&PoolExtra{Rate: uint256.NewInt(1100), RateUnit: uint256.NewInt(1000)})
+	rate := unitRate(&PoolExtra{Rate: uint256.NewInt(2211), RateUnit: uint256.NewInt(2000)})
+	assert.Equal(t, "1100000000000000000", prev.Dec())
+	assert.Equal(t, "1105500000000000000", rate.Dec())
+
+	growth := accrual.Estimate(accrual.Estimate(nil, nil, prev, 1000), prev, rate, 1100)
+	assert.Equal(t, "50000000000000", growth.PerSecond.Dec())
+
+	assert.Nil(t, unitRate(&PoolExtra{Rate: uint256.NewInt(1100), RateUnit: new(uint256.Int)}))
+}
@@ -2,6 +2,7 @@ package generic_simple_rate
 
 import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/accrual"
 	"github.com/holiman/uint256"
 )
 
@@ -13,10 +14,21 @@ type PoolItem struct {
 }
 
 type PoolExtra struct {
-	Paused          bool         `json:"paused"`
-	Rate            *uint256.Int `json:"rate"`
-	RateUnit        *uint256.Int `json:"rateUnit"`
-	IsRateInversed  bool         `json:"isRateInversed"`
-	IsBidirectional bool         `json:"isBidirectional"`
-	DefaultGas      int64        `json:"defaultGas"`
+	Paused          bool            `json:"paused"`
+	Rate            *uint256.Int    `json:"rate"`
+	RateGrowth      *accrual.Linear `json:"rateGrowth,omitempty"`
+	RateUnit        *uint256.Int    `json:"rateUnit"`
+	IsRateInversed  bool            `json:"isRateInversed"`
+	IsBidirectional bool            `json:"isBidirectional"`
+	DefaultGas      int64           `json:"defaultGas"`
+
+	MinAmountIn     *uint256.Int `json:"minAmountIn,omitempty"`
+	MaxAmountIn     *uint256.Int `json:"maxAmountIn,omitempty"`
+	DepositCap      *uint256.Int `json:"depositCap,omitempty"` // what is left of the cap
+	IsCapInTokenOut bool         `json:"isCapInTokenOut,omitempty"`
+	DepositFee      *uint256.Int `json:"depositFee,omitempty"`
+	RedeemFee       *uint256.Int `json:"redeemFee,omitempty"`
+	FeeUnit         *uint256.Int `json:"feeUnit,omitempty"`
+	ActiveFrom      uint64       `json:"activeFrom,omitempty"`
+	ActiveUntil     uint64       `json:"activeUntil,omitempty"`
 }
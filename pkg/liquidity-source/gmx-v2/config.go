@@ -0,0 +1,12 @@
+package gmxv2
+
+import (
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/pulloracle"
+)
+
+type Config struct {
+	DexID            string `json:"dexID"`
+	DataStoreAddress string `json:"dataStoreAddress"`
+	// DataStreams is the Data Streams API the tracker fetches the reports of the markets from, if set
+	DataStreams *pulloracle.StreamsConfig `json:"dataStreams,omitempty"`
+}
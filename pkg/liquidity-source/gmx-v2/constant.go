@@ -0,0 +1,33 @@
+package gmxv2
+
+import (
+	"errors"
+)
+
+const (
+	DexType = "gmx-v2"
+
+	defaultGas int64 = 300000
+
+	dataStoreMethodGetAddress         = "getAddress"
+	dataStoreMethodGetAddressCount    = "getAddressCount"
+	dataStoreMethodGetAddressValuesAt = "getAddressValuesAt"
+	dataStoreMethodGetBool            = "getBool"
+	dataStoreMethodGetBytes32         = "getBytes32"
+	dataStoreMethodGetUint            = "getUint"
+
+	batchSize = 100
+)
+
+var (
+	ErrInvalidToken                   = errors.New("invalid token")
+	ErrInvalidAmountIn                = errors.New("invalid amount in")
+	ErrMarketDisabled                 = errors.New("market is disabled")
+	ErrPriceNotAvailable              = errors.New("oracle price is not available")
+	ErrUsdDeltaExceedsPoolValue       = errors.New("usd delta exceeds pool value")
+	ErrSwapPriceImpactExceedsAmountIn = errors.New("swap price impact exceeds amount in")
+	ErrInsufficientPoolAmount         = errors.New("insufficient pool amount")
+	ErrMaxPoolAmountExceeded          = errors.New("max pool amount exceeded")
+	ErrInsufficientReserve            = errors.New("insufficient reserve")
+	ErrExponentOverflow               = errors.New("impact exponent overflow")
+)
@@ -0,0 +1,6 @@
+package gmxv2
+
+import _ "embed"
+
+//go:embed abis/DataStore.json
+var dataStoreABIJson []byte
@@ -0,0 +1,146 @@
+package gmxv2
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The DataStore keys read by the list updater and the tracker, as named by Keys.sol and MarketStoreUtils.sol:
+// https://github.com/gmx-io/gmx-synthetics/blob/main/contracts/data/Keys.sol
+var (
+	keyMarketList = hashString("MARKET_LIST")
+
+	keyIndexToken = hashString("INDEX_TOKEN")
+	keyLongToken  = hashString("LONG_TOKEN")
+	keyShortToken = hashString("SHORT_TOKEN")
+
+	keyIsMarketDisabled           = hashString("IS_MARKET_DISABLED")
+	keyPoolAmount                 = hashString("POOL_AMOUNT")
+	keySwapImpactPoolAmount       = hashString("SWAP_IMPACT_POOL_AMOUNT")
+	keyMaxPoolAmount              = hashString("MAX_POOL_AMOUNT")
+	keySwapImpactFactor           = hashString("SWAP_IMPACT_FACTOR")
+	keySwapImpactExponentFactor   = hashString("SWAP_IMPACT_EXPONENT_FACTOR")
+	keySwapFeeFactor              = hashString("SWAP_FEE_FACTOR")
+	keySwapFeeReceiverFactor      = hashString("SWAP_FEE_RECEIVER_FACTOR")
+	keyReserveFactor              = hashString("RESERVE_FACTOR")
+	keyOpenInterest               = hashString("OPEN_INTEREST")
+	keyOpenInterestInTokens       = hashString("OPEN_INTEREST_IN_TOKENS")
+	keyVirtualMarketID            = hashString("VIRTUAL_MARKET_ID")
+	keyVirtualInventoryForSwaps   = hashString("VIRTUAL_INVENTORY_FOR_SWAPS")
+	keyPriceFeed                  = hashString("PRICE_FEED")
+	keyPriceFeedMultiplier        = hashString("PRICE_FEED_MULTIPLIER")
+	keyPriceFeedHeartbeatDuration = hashString("PRICE_FEED_HEARTBEAT_DURATION")
+	keyStablePrice                = hashString("STABLE_PRICE")
+	keyDataStreamID               = hashString("DATA_STREAM_ID")
+	keyDataStreamMultiplier       = hashString("DATA_STREAM_MULTIPLIER")
+	keyMaxOraclePriceAge          = hashString("MAX_ORACLE_PRICE_AGE")
+)
+
+// hashString is keccak256(abi.encode(s)), the hash Keys.sol names its keys with.
+func hashString(s string) common.Hash {
+	data := make([]byte, 64, 64+(len(s)+31)/32*32)
+	big.NewInt(32).FillBytes(data[:32])
+	big.NewInt(int64(len(s))).FillBytes(data[32:64])
+	data = append(data, common.RightPadBytes([]byte(s), (len(s)+31)/32*32)...)
+	return crypto.Keccak256Hash(data)
+}
+
+// hashData is keccak256(abi.encode(values...)) for the static types keys are derived from: bytes32, address and bool.
+func hashData(values ...any) common.Hash {
+	data := make([]byte, 0, 32*len(values))
+	for _, value := range values {
+		var word common.Hash
+		switch v := value.(type) {
+		case common.Hash:
+			word = v
+		case common.Address:
+			word = common.BytesToHash(v.Bytes())
+		case bool:
+			if v {
+				word[31] = 1
+			}
+		default:
+			panic("gmxv2: unsupported key type")
+		}
+		data = append(data, word.Bytes()...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+func marketPropKey(market common.Address, prop common.Hash) common.Hash {
+	return hashData(market, prop)
+}
+
+func isMarketDisabledKey(market common.Address) common.Hash {
+	return hashData(keyIsMarketDisabled, market)
+}
+
+func poolAmountKey(market, token common.Address) common.Hash {
+	return hashData(keyPoolAmount, market, token)
+}
+
+func swapImpactPoolAmountKey(market, token common.Address) common.Hash {
+	return hashData(keySwapImpactPoolAmount, market, token)
+}
+
+func maxPoolAmountKey(market, token common.Address) common.Hash {
+	return hashData(keyMaxPoolAmount, market, token)
+}
+
+func swapImpactFactorKey(market common.Address, isPositive bool) common.Hash {
+	return hashData(keySwapImpactFactor, market, isPositive)
+}
+
+func swapImpactExponentFactorKey(market common.Address) common.Hash {
+	return hashData(keySwapImpactExponentFactor, market)
+}
+
+func swapFeeFactorKey(market common.Address, forPositiveImpact bool) common.Hash {
+	return hashData(keySwapFeeFactor, market, forPositiveImpact)
+}
+
+func reserveFactorKey(market common.Address, isLong bool) common.Hash {
+	return hashData(keyReserveFactor, market, isLong)
+}
+
+func openInterestKey(market, collateralToken common.Address, isLong bool) common.Hash {
+	return hashData(keyOpenInterest, market, collateralToken, isLong)
+}
+
+func openInterestInTokensKey(market, collateralToken common.Address, isLong bool) common.Hash {
+	return hashData(keyOpenInterestInTokens, market, collateralToken, isLong)
+}
+
+func virtualMarketIDKey(market common.Address) common.Hash {
+	return hashData(keyVirtualMarketID, market)
+}
+
+func virtualInventoryForSwapsKey(virtualMarketID common.Hash, isLongToken bool) common.Hash {
+	return hashData(keyVirtualInventoryForSwaps, virtualMarketID, isLongToken)
+}
+
+func priceFeedKey(token common.Address) common.Hash {
+	return hashData(keyPriceFeed, token)
+}
+
+func priceFeedMultiplierKey(token common.Address) common.Hash {
+	return hashData(keyPriceFeedMultiplier, token)
+}
+
+func priceFeedHeartbeatDurationKey(token common.Address) common.Hash {
+	return hashData(keyPriceFeedHeartbeatDuration, token)
+}
+
+func stablePriceKey(token common.Address) common.Hash {
+	return hashData(keyStablePrice, token)
+}
+
+func dataStreamIDKey(token common.Address) common.Hash {
+	return hashData(keyDataStreamID, token)
+}
+
+func dataStreamMultiplierKey(token common.Address) common.Hash {
+	return hashData(keyDataStreamMultiplier, token)
+}
@@ -0,0 +1,118 @@
+package gmxv2
+
+import (
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	velocoremath "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/velocore-v2/math"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+var (
+	floatPrecision  = bignumber.TenPowInt(30)
+	floatToWeiScale = bignumber.TenPowInt(12)
+
+	wadScale       = uint256.NewInt(1e18)
+	wadHalfScale   = uint256.NewInt(5e17)
+	wadDoubleScale = uint256.NewInt(2e18)
+	wadExp2MaxIn   = new(uint256.Int).Mul(uint256.NewInt(192), wadScale)
+)
+
+// applyFactor is Precision.applyFactor, value * factor / FLOAT_PRECISION.
+func applyFactor(value, factor *big.Int) *big.Int {
+	result := new(big.Int).Mul(value, factor)
+	return result.Quo(result, floatPrecision)
+}
+
+// applyImpactFactor is PricingUtils.applyImpactFactor, the impact of a pool imbalance of diffUsd.
+func applyImpactFactor(diffUsd, impactFactor, exponentFactor *big.Int) (*big.Int, error) {
+	exponentValue, err := applyExponentFactor(diffUsd, exponentFactor)
+	if err != nil {
+		return nil, err
+	}
+	return applyFactor(exponentValue, impactFactor), nil
+}
+
+// applyExponentFactor is Precision.applyExponentFactor, floatValue ^ exponentFactor computed with PRBMathUD60x18.pow
+// at 18 decimals, which the price impact has to match to the wei.
+func applyExponentFactor(floatValue, exponentFactor *big.Int) (*big.Int, error) {
+	if floatValue.Cmp(floatPrecision) < 0 {
+		return bignumber.ZeroBI, nil
+	} else if exponentFactor.Cmp(floatPrecision) == 0 {
+		return floatValue, nil
+	}
+
+	x, overflow := uint256.FromBig(new(big.Int).Quo(floatValue, floatToWeiScale))
+	if overflow {
+		return nil, ErrExponentOverflow
+	}
+	y, overflow := uint256.FromBig(new(big.Int).Quo(exponentFactor, floatToWeiScale))
+	if overflow {
+		return nil, ErrExponentOverflow
+	}
+
+	result, err := powUD60x18(x, y)
+	if err != nil {
+		return nil, err
+	}
+	floatResult := result.ToBig()
+	return floatResult.Mul(floatResult, floatToWeiScale), nil
+}
+
+// powUD60x18 is PRBMathUD60x18.pow (prb-math v2) for x >= 1, exp2(log2(x) * y).
+func powUD60x18(x, y *uint256.Int) (*uint256.Int, error) {
+	exponent, overflow := mulUD60x18(log2UD60x18(x), y)
+	if overflow || !exponent.Lt(wadExp2MaxIn) {
+		return nil, ErrExponentOverflow
+	}
+
+	x192x64 := new(uint256.Int).Lsh(exponent, 64)
+	return velocoremath.Common.Exp2(x192x64.Div(x192x64, wadScale)), nil
+}
+
+// log2UD60x18 is PRBMathUD60x18.log2 for x >= 1, the integer part from the most significant bit and the fractional
+// part by iterative squaring.
+func log2UD60x18(x *uint256.Int) *uint256.Int {
+	n := uint(new(uint256.Int).Div(x, wadScale).BitLen() - 1)
+	result := new(uint256.Int).Mul(uint256.NewInt(uint64(n)), wadScale)
+
+	y := new(uint256.Int).Rsh(x, n)
+	if y.Eq(wadScale) {
+		return result
+	}
+
+	for delta := new(uint256.Int).Set(wadHalfScale); !delta.IsZero(); delta.Rsh(delta, 1) {
+		y.Div(y.Mul(y, y), wadScale)
+		if !y.Lt(wadDoubleScale) {
+			result.Add(result, delta)
+			y.Rsh(y, 1)
+		}
+	}
+	return result
+}
+
+// mulUD60x18 is PRBMathUD60x18.mul, x * y / 1e18 rounded half up.
+func mulUD60x18(x, y *uint256.Int) (*uint256.Int, bool) {
+	product, overflow := new(uint256.Int).MulOverflow(x, y)
+	if overflow {
+		return nil, true
+	}
+
+	var remainder uint256.Int
+	result, _ := new(uint256.Int).DivMod(product, wadScale, &remainder)
+	if !remainder.Lt(wadHalfScale) {
+		result.AddUint64(result, 1)
+	}
+	return result, false
+}
+
+// roundUpMagnitudeDivision is Calc.roundUpMagnitudeDivision, a / b rounded away from zero.
+func roundUpMagnitudeDivision(a, b *big.Int) *big.Int {
+	result := new(big.Int).Abs(a)
+	result.Add(result, b).Sub(result, bignumber.One).Quo(result, b)
+	if a.Sign() < 0 {
+		result.Neg(result)
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package gmxv2
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	uniswapv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/uniswap-v2"
+	poollist "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/list"
+)
+
+type PoolsListUpdater struct {
+	config       *Config
+	ethrpcClient *ethrpc.Client
+}
+
+var _ = poollist.RegisterFactoryCE(DexType, NewPoolsListUpdater)
+
+func NewPoolsListUpdater(
+	cfg *Config,
+	ethrpcClient *ethrpc.Client,
+) *PoolsListUpdater {
+	return &PoolsListUpdater{
+		config:       cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+// GetNewPools lists the markets of the DataStore market list. Markets whose long and short tokens are the same cannot
+// swap and are skipped.
+func (u *PoolsListUpdater) GetNewPools(ctx context.Context, metadataBytes []byte) ([]entity.Pool, []byte, error) {
+	var (
+		dexID     = u.config.DexID
+		startTime = time.Now()
+	)
+
+	logger.WithFields(logger.Fields{"dex_id": dexID}).Info("Started getting new pools")
+
+	offset, err := u.getOffset(metadataBytes)
+	if err != nil {
+		logger.
+			WithFields(logger.Fields{"dex_id": dexID, "err": err}).
+			Warn("getOffset failed")
+	}
+
+	marketCount, err := u.getMarketCount(ctx)
+	if err != nil {
+		logger.
+			WithFields(logger.Fields{"dex_id": dexID, "err": err}).
+			Error("getMarketCount failed")
+
+		return nil, metadataBytes, err
+	}
+
+	if offset >= marketCount {
+		return nil, metadataBytes, nil
+	}
+
+	end := min(offset+batchSize, marketCount)
+	markets, err := u.listMarkets(ctx, offset, end)
+	if err != nil {
+		logger.
+			WithFields(logger.Fields{"dex_id": dexID, "err": err}).
+			Error("listMarkets failed")
+
+		return nil, metadataBytes, err
+	}
+
+	pools, err := u.initPools(ctx, markets)
+	if err != nil {
+		logger.
+			WithFields(logger.Fields{"dex_id": dexID, "err": err}).
+			Error("initPools failed")
+
+		return nil, metadataBytes, err
+	}
+
+	newMetadataBytes, err := json.Marshal(uniswapv2.PoolsListUpdaterMetadata{Offset: end})
+	if err != nil {
+		return nil, metadataBytes, err
+	}
+
+	logger.
+		WithFields(
+			logger.Fields{
+				"dex_id":      dexID,
+				"pools_len":   len(pools),
+				"duration_ms": time.Since(startTime).Milliseconds(),
+			},
+		).
+		Info("Finished getting new pools")
+
+	return pools, newMetadataBytes, nil
+}
+
+func (u *PoolsListUpdater) getMarketCount(ctx context.Context) (int, error) {
+	var count *big.Int
+
+	req := u.ethrpcClient.NewRequest().SetContext(ctx)
+	req.AddCall(&ethrpc.Call{
+		ABI:    dataStoreABI,
+		Target: u.config.DataStoreAddress,
+		Method: dataStoreMethodGetAddressCount,
+		Params: []any{keyMarketList},
+	}, []any{&count})
+
+	if _, err := req.Call(); err != nil {
+		return 0, err
+	}
+
+	return int(count.Int64()), nil
+}
+
+func (u *PoolsListUpdater) listMarkets(ctx context.Context, start, end int) ([]common.Address, error) {
+	var markets []common.Address
+
+	req := u.ethrpcClient.NewRequest().SetContext(ctx)
+	req.AddCall(&ethrpc.Call{
+		ABI:    dataStoreABI,
+		Target: u.config.DataStoreAddress,
+		Method: dataStoreMethodGetAddressValuesAt,
+		Params: []any{keyMarketList, big.NewInt(int64(start)), big.NewInt(int64(end))},
+	}, []any{&markets})
+
+	if _, err := req.Call(); err != nil {
+		return nil, err
+	}
+
+	return markets, nil
+}
+
+// initPools reads the tokens of markets, which MarketStoreUtils keeps in the DataStore.
+func (u *PoolsListUpdater) initPools(ctx context.Context, markets []common.Address) ([]entity.Pool, error) {
+	marketTokens := make([][3]common.Address, len(markets))
+
+	req := u.ethrpcClient.NewRequest().SetContext(ctx)
+	for i, market := range markets {
+		for j, prop := range []common.Hash{keyIndexToken, keyLongToken, keyShortToken} {
+			req.AddCall(&ethrpc.Call{
+				ABI:    dataStoreABI,
+				Target: u.config.DataStoreAddress,
+				Method: dataStoreMethodGetAddress,
+				Params: []any{marketPropKey(market, prop)},
+			}, []any{&marketTokens[i][j]})
+		}
+	}
+
+	if _, err := req.Aggregate(); err != nil {
+		return nil, err
+	}
+
+	pools := make([]entity.Pool, 0, len(markets))
+	for i, market := range markets {
+		indexToken, longToken, shortToken := marketTokens[i][0], marketTokens[i][1], marketTokens[i][2]
+		if longToken == shortToken {
+			continue
+		}
+
+		staticExtraBytes, err := json.Marshal(StaticExtra{IndexToken: strings.ToLower(indexToken.Hex())})
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, entity.Pool{
+			Address:   strings.ToLower(market.Hex()),
+			Exchange:  u.config.DexID,
+			Type:      DexType,
+			Timestamp: time.Now().Unix(),
+			Reserves:  entity.PoolReserves{"0", "0"},
+			Tokens: []*entity.PoolToken{
+				{Address: strings.ToLower(longToken.Hex()), Swappable: true},
+				{Address: strings.ToLower(shortToken.Hex()), Swappable: true},
+			},
+			StaticExtra: string(staticExtraBytes),
+		})
+	}
+
+	return pools, nil
+}
+
+func (u *PoolsListUpdater) getOffset(metadataBytes []byte) (int, error) {
+	if len(metadataBytes) == 0 {
+		return 0, nil
+	}
+
+	var metadata uniswapv2.PoolsListUpdaterMetadata
+	if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+		return 0, err
+	}
+
+	return metadata.Offset, nil
+}
@@ -0,0 +1,367 @@
+package gmxv2
+
+import (
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/pulloracle"
+)
+
+// PoolSimulator quotes market swaps of a GMX V2 GM pool between its long and short tokens, as SwapUtils executes
+// them: swap fees and price impact (with the virtual inventory) are taken from the amount in, and positive impact is
+// paid from the swap impact pool. Tokens are priced with their attached Data Streams reports, else with their price
+// feeds. The max PnL check of swaps is not modelled.
+type PoolSimulator struct {
+	pool.Pool
+	indexToken string
+	extra      Extra
+}
+
+var (
+	_ = pool.RegisterFactory0(DexType, NewPoolSimulator)
+
+	_ pulloracle.Consumer = (*PoolSimulator)(nil)
+)
+
+func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
+	var staticExtra StaticExtra
+	if err := json.Unmarshal([]byte(entityPool.StaticExtra), &staticExtra); err != nil {
+		return nil, err
+	}
+
+	var extra Extra
+	if err := json.Unmarshal([]byte(entityPool.Extra), &extra); err != nil {
+		return nil, err
+	}
+
+	return &PoolSimulator{
+		Pool: pool.Pool{Info: pool.PoolInfo{
+			Address:     entityPool.Address,
+			Exchange:    entityPool.Exchange,
+			Type:        entityPool.Type,
+			Tokens:      lo.Map(entityPool.Tokens, func(item *entity.PoolToken, _ int) string { return item.Address }),
+			Reserves:    lo.Map(entityPool.Reserves, func(item string, _ int) *big.Int { return bignumber.NewBig(item) }),
+			BlockNumber: entityPool.BlockNumber,
+		}},
+		indexToken: staticExtra.IndexToken,
+		extra:      extra,
+	}, nil
+}
+
+func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	indexIn, indexOut := p.GetTokenIndex(param.TokenAmountIn.Token), p.GetTokenIndex(param.TokenOut)
+	if indexIn < 0 || indexOut < 0 || indexIn == indexOut {
+		return nil, ErrInvalidToken
+	}
+	amountIn := param.TokenAmountIn.Amount
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, ErrInvalidAmountIn
+	}
+
+	amountOut, feeAmount, swapInfo, err := p.swap(indexIn, indexOut, amountIn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: &pool.TokenAmount{Token: param.TokenOut, Amount: amountOut},
+		Fee:            &pool.TokenAmount{Token: param.TokenAmountIn.Token, Amount: feeAmount},
+		Gas:            defaultGas,
+		SwapInfo:       swapInfo,
+	}, nil
+}
+
+func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
+	cloned := *p
+	cloned.Info.Reserves = slices.Clone(p.Info.Reserves)
+	if p.extra.VirtualInventory != nil {
+		virtualInventory := *p.extra.VirtualInventory
+		cloned.extra.VirtualInventory = &virtualInventory
+	}
+	return &cloned
+}
+
+func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
+	swapInfo, ok := params.SwapInfo.(*SwapInfo)
+	if !ok {
+		return
+	}
+
+	for i := range swapInfo.PoolAmountDeltas {
+		p.Info.Reserves[i] = new(big.Int).Add(p.Info.Reserves[i], swapInfo.PoolAmountDeltas[i])
+		p.extra.SwapImpactPoolAmounts[i] = new(big.Int).Add(p.extra.SwapImpactPoolAmounts[i],
+			swapInfo.SwapImpactPoolAmountDeltas[i])
+		// MarketUtils.applyDeltaToVirtualInventoryForSwaps, bounded at zero
+		if p.extra.VirtualInventory != nil {
+			virtualInventory := new(big.Int).Add(p.extra.VirtualInventory[i], swapInfo.PoolAmountDeltas[i])
+			p.extra.VirtualInventory[i] = lo.Ternary(virtualInventory.Sign() < 0, bignumber.ZeroBI, virtualInventory)
+		}
+	}
+}
+
+// GetMetaInfo returns the reports the market is priced with, which the swap has to submit for their prices to be
+// used.
+func (p *PoolSimulator) GetMetaInfo(_, _ string) any {
+	var metaInfo MetaInfo
+	now := uint64(time.Now().Unix())
+	for _, token := range p.priceTokens() {
+		if report := p.getReport(token, now); report != nil {
+			metaInfo.Tokens = append(metaInfo.Tokens, token)
+			metaInfo.Reports = append(metaInfo.Reports, report.Payload)
+		}
+	}
+	return metaInfo
+}
+
+// FeedIDs returns the Data Streams feeds of the tokens of the market.
+func (p *PoolSimulator) FeedIDs() []string {
+	feedIDs := make([]string, 0, len(p.extra.DataStreams))
+	for _, token := range p.priceTokens() {
+		if dataStream := p.extra.DataStreams[token]; dataStream != nil {
+			feedIDs = append(feedIDs, dataStream.FeedID)
+		}
+	}
+	return lo.Uniq(feedIDs)
+}
+
+// SetReports attaches Data Streams reports to the market, which then prices its tokens with them.
+func (p *PoolSimulator) SetReports(reports pulloracle.Reports) {
+	p.extra.Reports = p.extra.Reports.With(reports)
+}
+
+// priceTokens returns the tokens the market is priced with, the long, short and index tokens.
+func (p *PoolSimulator) priceTokens() []string {
+	return lo.Uniq(lo.Compact([]string{p.Info.Tokens[0], p.Info.Tokens[1], p.indexToken}))
+}
+
+// getReport returns the report of the Data Streams feed of a token if it is attached, can be verified at now and is
+// not older than the max oracle price age.
+func (p *PoolSimulator) getReport(token string, now uint64) *pulloracle.Report {
+	dataStream := p.extra.DataStreams[token]
+	if dataStream == nil {
+		return nil
+	}
+	report, err := p.extra.Reports.Get(dataStream.FeedID, now, p.extra.MaxOraclePriceAge)
+	if err != nil {
+		return nil
+	}
+	return report
+}
+
+// getPrice is the price of a token from its report as ChainlinkDataStreamProvider.getOraclePrice adjusts it, else
+// from its price feeds.
+func (p *PoolSimulator) getPrice(token string, feedPrice *Price, now uint64) *Price {
+	report := p.getReport(token, now)
+	if report == nil {
+		return feedPrice
+	}
+	multiplier := p.extra.DataStreams[token].Multiplier
+	return &Price{Min: applyFactor(report.Bid, multiplier), Max: applyFactor(report.Ask, multiplier)}
+}
+
+// swap is SwapUtils._swap, returning the amount out, the swap fee in the token in and the deltas applied to the market.
+// https://github.com/gmx-io/gmx-synthetics/blob/main/contracts/swap/SwapUtils.sol
+func (p *PoolSimulator) swap(indexIn, indexOut int, amountIn *big.Int) (*big.Int, *big.Int, *SwapInfo, error) {
+	if p.extra.IsDisabled {
+		return nil, nil, nil, ErrMarketDisabled
+	}
+	now := uint64(time.Now().Unix())
+	priceIn := p.getPrice(p.Info.Tokens[indexIn], p.extra.Prices[indexIn], now)
+	priceOut := p.getPrice(p.Info.Tokens[indexOut], p.extra.Prices[indexOut], now)
+	if priceIn == nil || priceOut == nil {
+		return nil, nil, nil, ErrPriceNotAvailable
+	}
+
+	usdDelta := new(big.Int).Mul(amountIn, priceIn.midPrice())
+	priceImpactUsd, err := p.getPriceImpactUsd(indexIn, indexOut, priceIn.midPrice(), priceOut.midPrice(), usdDelta)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// SwapPricingUtils.getSwapFees
+	feeFactor := lo.Ternary(priceImpactUsd.Sign() > 0, p.extra.PositiveSwapFeeFactor, p.extra.NegativeSwapFeeFactor)
+	feeAmount := applyFactor(amountIn, feeFactor)
+	feeAmountForPool := new(big.Int).Sub(feeAmount, applyFactor(feeAmount, p.extra.SwapFeeReceiverFactor))
+	amountAfterFees := new(big.Int).Sub(amountIn, feeAmount)
+
+	var amountOut, poolAmountOut *big.Int
+	swapImpactPoolAmountDeltas := [2]*big.Int{bignumber.ZeroBI, bignumber.ZeroBI}
+	if priceImpactUsd.Sign() > 0 {
+		// positive impact is paid from the swap impact pool of the token out, then of the token in once it is empty
+		poolAmountOut = p.convert(amountAfterFees, priceIn, priceOut)
+		impactAmount, cappedDiffUsd := p.getSwapImpactAmountWithCap(indexOut, priceOut, priceImpactUsd)
+		if cappedDiffUsd.Sign() != 0 {
+			impactAmountIn, _ := p.getSwapImpactAmountWithCap(indexIn, priceIn, cappedDiffUsd)
+			amountAfterFees.Add(amountAfterFees, impactAmountIn)
+			poolAmountOut = p.convert(amountAfterFees, priceIn, priceOut)
+			swapImpactPoolAmountDeltas[indexIn] = new(big.Int).Neg(impactAmountIn)
+		}
+		amountOut = new(big.Int).Add(poolAmountOut, impactAmount)
+		swapImpactPoolAmountDeltas[indexOut] = new(big.Int).Neg(impactAmount)
+	} else {
+		impactAmount, _ := p.getSwapImpactAmountWithCap(indexIn, priceIn, priceImpactUsd)
+		if amountAfterFees.CmpAbs(impactAmount) <= 0 {
+			return nil, nil, nil, ErrSwapPriceImpactExceedsAmountIn
+		}
+		amountAfterFees.Add(amountAfterFees, impactAmount)
+		poolAmountOut = p.convert(amountAfterFees, priceIn, priceOut)
+		amountOut = poolAmountOut
+		swapImpactPoolAmountDeltas[indexIn] = new(big.Int).Neg(impactAmount)
+	}
+
+	poolAmountIn := new(big.Int).Add(amountAfterFees, feeAmountForPool)
+	indexTokenPrice := p.getPrice(p.indexToken, p.extra.IndexTokenPrice, now)
+	if err = p.validatePoolAmounts(indexIn, indexOut, poolAmountIn, poolAmountOut, priceOut,
+		indexTokenPrice); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var poolAmountDeltas [2]*big.Int
+	poolAmountDeltas[indexIn], poolAmountDeltas[indexOut] = poolAmountIn, new(big.Int).Neg(poolAmountOut)
+	return amountOut, feeAmount, &SwapInfo{
+		PoolAmountDeltas:           poolAmountDeltas,
+		SwapImpactPoolAmountDeltas: swapImpactPoolAmountDeltas,
+	}, nil
+}
+
+// convert converts amountIn of the token in to the token out at the prices favouring the pool.
+func (p *PoolSimulator) convert(amountIn *big.Int, priceIn, priceOut *Price) *big.Int {
+	amountOut := new(big.Int).Mul(amountIn, priceIn.Min)
+	return amountOut.Quo(amountOut, priceOut.Max)
+}
+
+// getPriceImpactUsd is SwapPricingUtils.getPriceImpactUsd. A negative impact is the worse of the impacts on the pool
+// and on the virtual inventory.
+func (p *PoolSimulator) getPriceImpactUsd(indexIn, indexOut int, priceIn, priceOut, usdDelta *big.Int) (*big.Int,
+	error) {
+	priceImpactUsd, err := p.getPriceImpactUsdFor(p.Info.Reserves[indexIn], p.Info.Reserves[indexOut], priceIn, priceOut,
+		usdDelta)
+	if err != nil || priceImpactUsd.Sign() >= 0 || p.extra.VirtualInventory == nil {
+		return priceImpactUsd, err
+	}
+
+	virtualInventory := p.extra.VirtualInventory
+	virtualPriceImpactUsd, err := p.getPriceImpactUsdFor(virtualInventory[indexIn], virtualInventory[indexOut], priceIn,
+		priceOut, usdDelta)
+	if err != nil {
+		return nil, err
+	}
+	if virtualPriceImpactUsd.Cmp(priceImpactUsd) < 0 {
+		return virtualPriceImpactUsd, nil
+	}
+	return priceImpactUsd, nil
+}
+
+// getPriceImpactUsdFor is SwapPricingUtils._getPriceImpactUsd for the pool amounts of the tokens, after
+// getNextPoolAmountsParams moves usdDelta from the token out to the token in.
+func (p *PoolSimulator) getPriceImpactUsdFor(amountIn, amountOut, priceIn, priceOut, usdDelta *big.Int) (*big.Int,
+	error) {
+	poolUsdIn := new(big.Int).Mul(amountIn, priceIn)
+	poolUsdOut := new(big.Int).Mul(amountOut, priceOut)
+	if usdDelta.Cmp(poolUsdOut) > 0 {
+		return nil, ErrUsdDeltaExceedsPoolValue
+	}
+	nextPoolUsdIn := new(big.Int).Add(poolUsdIn, usdDelta)
+	nextPoolUsdOut := new(big.Int).Sub(poolUsdOut, usdDelta)
+
+	initialDiffUsd := new(big.Int).Sub(poolUsdIn, poolUsdOut)
+	initialDiffUsd.Abs(initialDiffUsd)
+	nextDiffUsd := new(big.Int).Sub(nextPoolUsdIn, nextPoolUsdOut)
+	nextDiffUsd.Abs(nextDiffUsd)
+
+	// MarketUtils.getAdjustedSwapImpactFactors: the positive impact factor may not exceed the negative one
+	positiveImpactFactor, negativeImpactFactor := p.extra.PositiveSwapImpactFactor, p.extra.NegativeSwapImpactFactor
+	if positiveImpactFactor.Cmp(negativeImpactFactor) > 0 {
+		positiveImpactFactor = negativeImpactFactor
+	}
+
+	exponentFactor := p.extra.SwapImpactExponentFactor
+	isSameSideRebalance := (poolUsdIn.Cmp(poolUsdOut) <= 0) == (nextPoolUsdIn.Cmp(nextPoolUsdOut) <= 0)
+	if isSameSideRebalance {
+		// PricingUtils.getPriceImpactUsdForSameSideRebalance
+		hasPositiveImpact := nextDiffUsd.Cmp(initialDiffUsd) < 0
+		impactFactor := lo.Ternary(hasPositiveImpact, positiveImpactFactor, negativeImpactFactor)
+		initialImpactUsd, err := applyImpactFactor(initialDiffUsd, impactFactor, exponentFactor)
+		if err != nil {
+			return nil, err
+		}
+		nextImpactUsd, err := applyImpactFactor(nextDiffUsd, impactFactor, exponentFactor)
+		if err != nil {
+			return nil, err
+		}
+		deltaDiffUsd := initialImpactUsd.Sub(initialImpactUsd, nextImpactUsd).Abs(initialImpactUsd)
+		if !hasPositiveImpact {
+			deltaDiffUsd.Neg(deltaDiffUsd)
+		}
+		return deltaDiffUsd, nil
+	}
+
+	// PricingUtils.getPriceImpactUsdForCrossoverRebalance
+	positiveImpactUsd, err := applyImpactFactor(initialDiffUsd, positiveImpactFactor, exponentFactor)
+	if err != nil {
+		return nil, err
+	}
+	negativeImpactUsd, err := applyImpactFactor(nextDiffUsd, negativeImpactFactor, exponentFactor)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(positiveImpactUsd, negativeImpactUsd), nil
+}
+
+// getSwapImpactAmountWithCap is MarketUtils.getSwapImpactAmountWithCap: a positive impact is capped by the swap
+// impact pool of the token, the USD it is capped by being returned, and a negative impact rounds away from zero.
+func (p *PoolSimulator) getSwapImpactAmountWithCap(index int, price *Price, priceImpactUsd *big.Int) (*big.Int,
+	*big.Int) {
+	if priceImpactUsd.Sign() <= 0 {
+		return roundUpMagnitudeDivision(priceImpactUsd, price.Min), bignumber.ZeroBI
+	}
+
+	impactAmount := new(big.Int).Quo(priceImpactUsd, price.Max)
+	maxImpactAmount := p.extra.SwapImpactPoolAmounts[index]
+	if impactAmount.Cmp(maxImpactAmount) > 0 {
+		cappedDiffUsd := new(big.Int).Sub(impactAmount, maxImpactAmount)
+		return maxImpactAmount, cappedDiffUsd.Mul(cappedDiffUsd, price.Max)
+	}
+	return impactAmount, bignumber.ZeroBI
+}
+
+// validatePoolAmounts checks the pool amounts after the swap as MarketUtils.applyDeltaToPoolAmount,
+// validatePoolAmount and validateReserve do.
+func (p *PoolSimulator) validatePoolAmounts(indexIn, indexOut int, poolAmountIn, poolAmountOut *big.Int,
+	priceOut, indexTokenPrice *Price) error {
+	nextPoolAmountOut := new(big.Int).Sub(p.Info.Reserves[indexOut], poolAmountOut)
+	if nextPoolAmountOut.Sign() < 0 {
+		return ErrInsufficientPoolAmount
+	}
+
+	nextPoolAmountIn := new(big.Int).Add(p.Info.Reserves[indexIn], poolAmountIn)
+	if nextPoolAmountIn.Cmp(p.extra.MaxPoolAmounts[indexIn]) > 0 {
+		return ErrMaxPoolAmountExceeded
+	}
+
+	isLong := indexOut == 0
+	var reservedUsd *big.Int
+	if isLong {
+		if p.extra.LongOpenInterestInTokens.Sign() == 0 {
+			return nil
+		} else if indexTokenPrice == nil {
+			return ErrPriceNotAvailable
+		}
+		reservedUsd = new(big.Int).Mul(p.extra.LongOpenInterestInTokens, indexTokenPrice.Max)
+	} else {
+		reservedUsd = p.extra.ShortOpenInterest
+	}
+
+	poolUsd := nextPoolAmountOut.Mul(nextPoolAmountOut, priceOut.Min)
+	if reservedUsd.Cmp(applyFactor(poolUsd, p.extra.ReserveFactors[indexOut])) > 0 {
+		return ErrInsufficientReserve
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package gmxv2
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/pulloracle"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	testMarket = "0x70d95587d40a2caf56bd97485ab3eec10bee6336"
+	testWeth   = "0x82af49447d8a07e3bd95bd0d56f35241523fbab1"
+	testUsdc   = "0xaf88d065e77c8cc2239327c5edb3a432268e5831"
+)
+
+// testExtra is the ETH/USD [WETH-USDC] market with WETH at 2000 and USDC at 1 (1e24 per wei of its 6 decimals),
+// linear swap impact and no virtual inventory. The pool amounts are set by newTestPool.
+func testExtra() Extra {
+	wethPrice := &Price{Min: bignumber.NewBig10("2000000000000000"), Max: bignumber.NewBig10("2000000000000000")}
+	return Extra{
+		SwapImpactPoolAmounts: [2]*big.Int{bignumber.NewBig10("1000000000000000000"), big.NewInt(1e9)},
+		MaxPoolAmounts: [2]*big.Int{bignumber.NewBig10("10000000000000000000000"),
+			bignumber.NewBig10("20000000000000")},
+
+		PositiveSwapImpactFactor: bignumber.NewBig10("5000000000000000000000000"),
+		NegativeSwapImpactFactor: bignumber.NewBig10("10000000000000000000000000"),
+		SwapImpactExponentFactor: floatPrecision,
+		PositiveSwapFeeFactor:    bignumber.NewBig10("500000000000000000000000000"),
+		NegativeSwapFeeFactor:    bignumber.NewBig10("700000000000000000000000000"),
+		SwapFeeReceiverFactor:    bignumber.ZeroBI,
+
+		ReserveFactors:           [2]*big.Int{floatPrecision, floatPrecision},
+		LongOpenInterestInTokens: bignumber.ZeroBI,
+		ShortOpenInterest:        bignumber.ZeroBI,
+
+		Prices:          [2]*Price{wethPrice, {Min: bignumber.TenPowInt(24), Max: bignumber.TenPowInt(24)}},
+		IndexTokenPrice: wethPrice,
+	}
+}
+
+func newTestPool(t *testing.T, wethAmount, usdcAmount string, extra Extra) *PoolSimulator {
+	t.Helper()
+
+	extraBytes, err := json.Marshal(extra)
+	require.NoError(t, err)
+
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:     testMarket,
+		Exchange:    DexType,
+		Type:        DexType,
+		Reserves:    entity.PoolReserves{wethAmount, usdcAmount},
+		Tokens:      []*entity.PoolToken{{Address: testWeth, Swappable: true}, {Address: testUsdc, Swappable: true}},
+		Extra:       string(extraBytes),
+		StaticExtra: `{"indexToken":"` + testWeth + `"}`,
+	})
+	require.NoError(t, err)
+	return poolSim
+}
+
+func calcAmountOut(poolSim *PoolSimulator, tokenIn, tokenOut, amountIn string) (*pool.CalcAmountOutResult, error) {
+	return poolSim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: tokenIn, Amount: bignumber.NewBig10(amountIn)},
+		TokenOut:      tokenOut,
+	})
+}
+
+// TestPoolSimulator_CalcAmountOut checks the fee and impact arithmetic on synthetic markets. The expected amounts are
+// worked out from the SwapUtils formulas in the comments of the cases, they are not amounts out of the contract.
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+
+	withVirtualInventory := testExtra()
+	withVirtualInventory.VirtualInventory = &[2]*big.Int{
+		bignumber.NewBig10("1000000000000000000000"), bignumber.NewBig10("1000000000000"),
+	}
+	emptyImpactPool := testExtra()
+	emptyImpactPool.SwapImpactPoolAmounts[0] = big.NewInt(4e12)
+	// the impact exponent of the GM markets on Arbitrum, with an impact factor of 1e-10 per USD squared
+	squareImpact := testExtra()
+	squareImpact.SwapImpactExponentFactor = bignumber.NewBig10("2000000000000000000000000000000")
+	squareImpact.PositiveSwapImpactFactor = bignumber.NewBig10("50000000000000000000")
+	squareImpact.NegativeSwapImpactFactor = bignumber.NewBig10("100000000000000000000")
+
+	tests := []struct {
+		name                   string
+		wethAmount, usdcAmount string
+		extra                  Extra
+		tokenIn, tokenOut      string
+		amountIn               string
+		expectedAmountOut      string
+		expectedFee            string
+	}{
+		{
+			// a crossover from a balanced pool: 0.07% fee and a $0.04 impact, 1e-5 of the $4000 diff, in WETH
+			name:       "negative impact",
+			wethAmount: "1000000000000000000000", usdcAmount: "2000000000000",
+			extra:   testExtra(),
+			tokenIn: testWeth, tokenOut: testUsdc, amountIn: "1000000000000000000",
+			expectedAmountOut: "1998560000", expectedFee: "700000000000000",
+		},
+		{
+			// the $1M diff shrinking by $4000: 0.05% fee and a $0.02 impact, 5e-6 of the $4000, paid in WETH
+			name:       "positive impact",
+			wethAmount: "1000000000000000000000", usdcAmount: "1000000000000",
+			extra:   testExtra(),
+			tokenIn: testUsdc, tokenOut: testWeth, amountIn: "2000000000",
+			expectedAmountOut: "999510000000000000", expectedFee: "1000000",
+		},
+		{
+			// the WETH impact pool only covers 4e12 of the 1e13, the remaining $0.012 is paid in USDC
+			name:       "positive impact capped by the impact pool",
+			wethAmount: "1000000000000000000000", usdcAmount: "1000000000000",
+			extra:   emptyImpactPool,
+			tokenIn: testUsdc, tokenOut: testWeth, amountIn: "2000000000",
+			expectedAmountOut: "999510000000000000", expectedFee: "1000000",
+		},
+		{
+			// the crossover of the pool is a $0.01 impact, $0.02 less $0.01 for the $2000 diff
+			name:       "pool impact without virtual inventory",
+			wethAmount: "999000000000000000000", usdcAmount: "2000000000000",
+			extra:   testExtra(),
+			tokenIn: testWeth, tokenOut: testUsdc, amountIn: "1000000000000000000",
+			expectedAmountOut: "1998590000", expectedFee: "700000000000000",
+		},
+		{
+			// the virtual inventory is $1M imbalanced towards WETH, its $0.04 impact is the worse one
+			name:       "virtual inventory impact",
+			wethAmount: "999000000000000000000", usdcAmount: "2000000000000",
+			extra:   withVirtualInventory,
+			tokenIn: testWeth, tokenOut: testUsdc, amountIn: "1000000000000000000",
+			expectedAmountOut: "1998560000", expectedFee: "700000000000000",
+		},
+		{
+			// the $1M diff growing by $4000 with exponent 2: 1e-10 * (1004000^2 - 1000000^2) = $0.8016 of impact,
+			// 0.0004008 WETH, on top of the 0.07% fee
+			name:       "negative impact with exponent",
+			wethAmount: "1000000000000000000000", usdcAmount: "1000000000000",
+			extra:   squareImpact,
+			tokenIn: testWeth, tokenOut: testUsdc, amountIn: "1000000000000000000",
+			expectedAmountOut: "1997798400", expectedFee: "700000000000000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			poolSim := newTestPool(t, tc.wethAmount, tc.usdcAmount, tc.extra)
+			result, err := calcAmountOut(poolSim, tc.tokenIn, tc.tokenOut, tc.amountIn)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAmountOut, result.TokenAmountOut.Amount.String())
+			assert.Equal(t, tc.expectedFee, result.Fee.Amount.String())
+			assert.Equal(t, tc.tokenIn, result.Fee.Token)
+		})
+	}
+}
+
+func TestPoolSimulator_CalcAmountOut_Errors(t *testing.T) {
+	t.Parallel()
+
+	disabled := testExtra()
+	disabled.IsDisabled = true
+	noPrice := testExtra()
+	noPrice.Prices[1] = nil
+	maxPoolAmount := testExtra()
+	maxPoolAmount.MaxPoolAmounts[0] = bignumber.NewBig10("1000500000000000000000")
+	shortReserved := testExtra()
+	shortReserved.ReserveFactors[1] = bignumber.NewBig10("500000000000000000000000000000")
+	shortReserved.ShortOpenInterest = bignumber.NewBig10("1000000000000000000000000000000000000")
+	longReserved := testExtra()
+	longReserved.LongOpenInterestInTokens = bignumber.NewBig10("1000000000000000000000")
+	longNoIndexPrice := testExtra()
+	longNoIndexPrice.LongOpenInterestInTokens = bignumber.One
+	longNoIndexPrice.IndexTokenPrice = nil
+
+	tests := []struct {
+		name              string
+		extra             Extra
+		tokenIn, tokenOut string
+		amountIn          string
+		expectedErr       error
+	}{
+		{"same token", testExtra(), testWeth, testWeth, "1", ErrInvalidToken},
+		{"zero amount", testExtra(), testWeth, testUsdc, "0", ErrInvalidAmountIn},
+		{"market disabled", disabled, testWeth, testUsdc, "1000000000000000000", ErrMarketDisabled},
+		{"price not available", noPrice, testWeth, testUsdc, "1000000000000000000", ErrPriceNotAvailable},
+		{"usd delta exceeds pool", testExtra(), testWeth, testUsdc, "1001000000000000000000",
+			ErrUsdDeltaExceedsPoolValue},
+		{"max pool amount", maxPoolAmount, testWeth, testUsdc, "1000000000000000000", ErrMaxPoolAmountExceeded},
+		{"short reserve", shortReserved, testWeth, testUsdc, "1000000000000000000", ErrInsufficientReserve},
+		{"long reserve", longReserved, testUsdc, testWeth, "1000000", ErrInsufficientReserve},
+		{"long reserve without index price", longNoIndexPrice, testUsdc, testWeth, "1000000",
+			ErrPriceNotAvailable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			poolSim := newTestPool(t, "1000000000000000000000", "2000000000000", tc.extra)
+			_, err := calcAmountOut(poolSim, tc.tokenIn, tc.tokenOut, tc.amountIn)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestPoolSimulator_UpdateBalance(t *testing.T) {
+	t.Parallel()
+
+	extra := testExtra()
+	extra.VirtualInventory = &[2]*big.Int{bignumber.ZeroBI, bignumber.NewBig10("1000000000000")}
+	poolSim := newTestPool(t, "1000000000000000000000", "2000000000000", extra)
+	cloned := poolSim.CloneState().(*PoolSimulator)
+
+	result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	require.NoError(t, err)
+	poolSim.UpdateBalance(pool.UpdateBalanceParams{SwapInfo: result.SwapInfo})
+
+	// the pool keeps the amount in less the impact, with the fee, and the impact pool gets the impact
+	assert.Equal(t, "1000999980000000000000", poolSim.Info.Reserves[0].String())
+	assert.Equal(t, "1998001440000", poolSim.Info.Reserves[1].String())
+	assert.Equal(t, "1000020000000000000", poolSim.extra.SwapImpactPoolAmounts[0].String())
+	assert.Equal(t, "999980000000000000", poolSim.extra.VirtualInventory[0].String())
+	assert.Equal(t, "998001440000", poolSim.extra.VirtualInventory[1].String())
+
+	assert.Equal(t, "1000000000000000000000", cloned.Info.Reserves[0].String())
+	assert.Equal(t, "1000000000000000000", cloned.extra.SwapImpactPoolAmounts[0].String())
+	assert.Equal(t, "0", cloned.extra.VirtualInventory[0].String())
+}
+
+type testReportProvider pulloracle.Reports
+
+func (r testReportProvider) LatestReports(_ context.Context, feedIDs []string) (pulloracle.Reports, error) {
+	return lo.PickByKeys(pulloracle.Reports(r), feedIDs), nil
+}
+
+func TestPoolSimulator_Reports(t *testing.T) {
+	t.Parallel()
+
+	const testFeedID = "0x000362205e10b3a147d02792eccee483dca6c7b44ecce7012cb8c6e0b68b3ae9"
+	// the WETH price feed is stale, so the market can only be priced with a report
+	extra := testExtra()
+	extra.Prices[0] = nil
+	extra.DataStreams = map[string]*DataStream{
+		testWeth: {FeedID: testFeedID, Multiplier: bignumber.TenPowInt(24)},
+	}
+	extra.MaxOraclePriceAge = 300
+	poolSim := newTestPool(t, "1000000000000000000000", "2000000000000", extra)
+	cloned := poolSim.CloneState().(*PoolSimulator)
+	assert.Equal(t, []string{testFeedID}, poolSim.FeedIDs())
+
+	_, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	assert.ErrorIs(t, err, ErrPriceNotAvailable)
+	assert.Equal(t, MetaInfo{}, poolSim.GetMetaInfo(testWeth, testUsdc))
+
+	// WETH at 2000 with 18 decimals, adjusted by the 1e24 multiplier to the 2000e12 per wei of the price feed
+	report := &pulloracle.Report{
+		FeedID:     testFeedID,
+		Bid:        bignumber.NewBig10("2000000000000000000000"),
+		Ask:        bignumber.NewBig10("2000000000000000000000"),
+		ObservedAt: uint64(time.Now().Add(-time.Minute).Unix()),
+		ExpiresAt:  uint64(time.Now().Add(time.Minute).Unix()),
+		Payload:    hexutil.MustDecode("0x1234"),
+	}
+	require.NoError(t, pulloracle.Refresh(context.Background(), testReportProvider{testFeedID: report}, poolSim))
+
+	result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, "1998560000", result.TokenAmountOut.Amount.String())
+	assert.Equal(t, MetaInfo{Tokens: []string{testWeth}, Reports: []hexutil.Bytes{report.Payload}},
+		poolSim.GetMetaInfo(testWeth, testUsdc))
+
+	_, err = calcAmountOut(cloned, testWeth, testUsdc, "1000000000000000000")
+	assert.ErrorIs(t, err, ErrPriceNotAvailable, "reports must not be attached to clones")
+
+	expiredReport := *report
+	expiredReport.ExpiresAt = uint64(time.Now().Add(-time.Minute).Unix())
+	poolSim.SetReports(pulloracle.Reports{testFeedID: &expiredReport})
+	_, err = calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	assert.ErrorIs(t, err, ErrPriceNotAvailable)
+
+	// the Oracle rejects prices older than MAX_ORACLE_PRICE_AGE, even if the report can still be verified
+	oldReport := *report
+	oldReport.ObservedAt = uint64(time.Now().Add(-10 * time.Minute).Unix())
+	poolSim.SetReports(pulloracle.Reports{testFeedID: &oldReport})
+	_, err = calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	assert.ErrorIs(t, err, ErrPriceNotAvailable)
+}
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+
+	extra := testExtra()
+	extra.SwapImpactExponentFactor = bignumber.NewBig10("2000000000000000000000000000000")
+	extra.PositiveSwapImpactFactor = bignumber.NewBig10("500000000000000000000")
+	extra.NegativeSwapImpactFactor = bignumber.NewBig10("1000000000000000000000")
+	testutil.TestPoolSimulator(t, newTestPool(t, "1000000000000000000000", "1500000000000", extra))
+}
+
+func TestApplyExponentFactor(t *testing.T) {
+	t.Parallel()
+
+	// the expected values are PRBMathUD60x18.pow of the values scaled to 18 decimals, step by step: log2 by iterative
+	// squaring, the half-up rounded mul and exp2 with the 2^(2^-k) table, which is why the squares are not exact
+	tests := []struct {
+		value, exponent, expected string
+	}{
+		{"1000000000000000000000000000000", "2000000000000000000000000000000", "1000000000000000000000000000000"},
+		{"1999999999999999999999999999999", "2000000000000000000000000000000", "3999999999999999866000000000000"},
+		{"1234567890000000000000000000000000", "2000000000000000000000000000000",
+			"1524157875019052068991493000000000000"},
+		{"4000000000000000000000000000000000000", "2000000000000000000000000000000",
+			"15999999999999999728886912227622000000000000"},
+		{"2500000000000000000000000000000000", "1750000000000000000000000000000",
+			"883883476483184391363175000000000000"},
+		{"2000000000000000000000000000000", "1500000000000000000000000000000", "2828427124746190097000000000000"},
+		{"123456789000000000000000000000000", "1000000000000000000000000000000",
+			"123456789000000000000000000000000"},
+		{"999999999999999999999999999999", "2000000000000000000000000000000", "0"},
+	}
+	for _, tc := range tests {
+		result, err := applyExponentFactor(bignumber.NewBig10(tc.value), bignumber.NewBig10(tc.exponent))
+		require.NoError(t, err)
+		assert.Equalf(t, tc.expected, result.String(), "%s ^ %s", tc.value, tc.exponent)
+	}
+
+	// the same-side impact of the exponent case of TestPoolSimulator_CalcAmountOut, off the exact $0.8016 by the pow
+	poolSim := newTestPool(t, "1000000000000000000000", "1000000000000", Extra{
+		PositiveSwapImpactFactor: bignumber.NewBig10("50000000000000000000"),
+		NegativeSwapImpactFactor: bignumber.NewBig10("100000000000000000000"),
+		SwapImpactExponentFactor: bignumber.NewBig10("2000000000000000000000000000000"),
+	})
+	priceImpactUsd, err := poolSim.getPriceImpactUsdFor(poolSim.Info.Reserves[0], poolSim.Info.Reserves[1],
+		big.NewInt(2e15), bignumber.TenPowInt(24), bignumber.NewBig10("2000000000000000000000000000000000"))
+	require.NoError(t, err)
+	assert.Equal(t, "-801599999999999512962903116600", priceImpactUsd.String())
+}
+
+func TestHashString(t *testing.T) {
+	t.Parallel()
+
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	for _, s := range []string{"MARKET_LIST", "VIRTUAL_INVENTORY_FOR_SWAPS", "A_KEY_LONGER_THAN_THIRTY_TWO_BYTES"} {
+		encoded, err := abi.Arguments{{Type: stringType}}.Pack(s)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.Keccak256Hash(encoded), hashString(s))
+	}
+}
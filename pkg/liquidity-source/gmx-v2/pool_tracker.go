@@ -0,0 +1,266 @@
+package gmxv2
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/pulloracle"
+)
+
+type PoolTracker struct {
+	config         *Config
+	ethrpcClient   *ethrpc.Client
+	reportProvider pulloracle.Provider
+}
+
+var _ = pooltrack.RegisterFactoryCE0(DexType, NewPoolTracker)
+
+func NewPoolTracker(
+	config *Config,
+	ethrpcClient *ethrpc.Client,
+) *PoolTracker {
+	tracker := &PoolTracker{
+		config:       config,
+		ethrpcClient: ethrpcClient,
+	}
+	if config.DataStreams != nil {
+		tracker.reportProvider = pulloracle.NewStreamsClient(config.DataStreams)
+	}
+	return tracker
+}
+
+// priceFeedConfig is the on-chain price feed and the Data Streams feed of a token configured in the DataStore.
+type priceFeedConfig struct {
+	feed       common.Address
+	multiplier *big.Int
+	heartbeat  *big.Int
+	stable     *big.Int
+	round      oracle.RoundData
+
+	dataStreamID         common.Hash
+	dataStreamMultiplier *big.Int
+}
+
+func (d *PoolTracker) GetNewPoolState(
+	ctx context.Context,
+	p entity.Pool,
+	_ pool.GetNewPoolStateParams,
+) (entity.Pool, error) {
+	logger.WithFields(logger.Fields{
+		"address": p.Address,
+	}).Infof("[%s] Start getting new state of pool", p.Type)
+
+	var staticExtra StaticExtra
+	if err := json.Unmarshal([]byte(p.StaticExtra), &staticExtra); err != nil {
+		return p, err
+	}
+
+	var (
+		market     = common.HexToAddress(p.Address)
+		tokens     = [2]common.Address{common.HexToAddress(p.Tokens[0].Address), common.HexToAddress(p.Tokens[1].Address)}
+		indexToken = common.HexToAddress(staticExtra.IndexToken)
+
+		extra                Extra
+		poolAmounts          [2]*big.Int
+		openInterestInTokens [2]*big.Int
+		shortOpenInterests   [2]*big.Int
+		virtualMarketID      common.Hash
+		maxOraclePriceAge    *big.Int
+		priceFeeds           = map[common.Address]*priceFeedConfig{}
+		priceTokens          = lo.Uniq(lo.Filter([]common.Address{tokens[0], tokens[1], indexToken},
+			func(token common.Address, _ int) bool { return token != (common.Address{}) }))
+	)
+
+	req := d.ethrpcClient.NewRequest().SetContext(ctx)
+	addUint := func(key common.Hash, out **big.Int) {
+		req.AddCall(&ethrpc.Call{
+			ABI:    dataStoreABI,
+			Target: d.config.DataStoreAddress,
+			Method: dataStoreMethodGetUint,
+			Params: []any{key},
+		}, []any{out})
+	}
+
+	req.AddCall(&ethrpc.Call{
+		ABI:    dataStoreABI,
+		Target: d.config.DataStoreAddress,
+		Method: dataStoreMethodGetBool,
+		Params: []any{isMarketDisabledKey(market)},
+	}, []any{&extra.IsDisabled})
+	for i, token := range tokens {
+		isLong := i == 0
+		addUint(poolAmountKey(market, token), &poolAmounts[i])
+		addUint(swapImpactPoolAmountKey(market, token), &extra.SwapImpactPoolAmounts[i])
+		addUint(maxPoolAmountKey(market, token), &extra.MaxPoolAmounts[i])
+		addUint(reserveFactorKey(market, isLong), &extra.ReserveFactors[i])
+		addUint(openInterestInTokensKey(market, token, true), &openInterestInTokens[i])
+		addUint(openInterestKey(market, token, false), &shortOpenInterests[i])
+	}
+	addUint(swapImpactFactorKey(market, true), &extra.PositiveSwapImpactFactor)
+	addUint(swapImpactFactorKey(market, false), &extra.NegativeSwapImpactFactor)
+	addUint(swapImpactExponentFactorKey(market), &extra.SwapImpactExponentFactor)
+	addUint(swapFeeFactorKey(market, true), &extra.PositiveSwapFeeFactor)
+	addUint(swapFeeFactorKey(market, false), &extra.NegativeSwapFeeFactor)
+	addUint(keySwapFeeReceiverFactor, &extra.SwapFeeReceiverFactor)
+	addUint(keyMaxOraclePriceAge, &maxOraclePriceAge)
+	req.AddCall(&ethrpc.Call{
+		ABI:    dataStoreABI,
+		Target: d.config.DataStoreAddress,
+		Method: dataStoreMethodGetBytes32,
+		Params: []any{virtualMarketIDKey(market)},
+	}, []any{&virtualMarketID})
+	for _, token := range priceTokens {
+		priceFeed := &priceFeedConfig{}
+		priceFeeds[token] = priceFeed
+		req.AddCall(&ethrpc.Call{
+			ABI:    dataStoreABI,
+			Target: d.config.DataStoreAddress,
+			Method: dataStoreMethodGetAddress,
+			Params: []any{priceFeedKey(token)},
+		}, []any{&priceFeed.feed})
+		addUint(priceFeedMultiplierKey(token), &priceFeed.multiplier)
+		addUint(priceFeedHeartbeatDurationKey(token), &priceFeed.heartbeat)
+		addUint(stablePriceKey(token), &priceFeed.stable)
+		req.AddCall(&ethrpc.Call{
+			ABI:    dataStoreABI,
+			Target: d.config.DataStoreAddress,
+			Method: dataStoreMethodGetBytes32,
+			Params: []any{dataStreamIDKey(token)},
+		}, []any{&priceFeed.dataStreamID})
+		addUint(dataStreamMultiplierKey(token), &priceFeed.dataStreamMultiplier)
+	}
+
+	resp, err := req.Aggregate()
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"address": p.Address,
+			"error":   err,
+		}).Errorf("failed to get state of the market")
+		return p, err
+	}
+
+	var virtualInventory [2]*big.Int
+	req = d.ethrpcClient.NewRequest().SetContext(ctx)
+	if resp.BlockNumber != nil {
+		req.SetBlockNumber(resp.BlockNumber)
+	}
+	if virtualMarketID != (common.Hash{}) {
+		for i := range tokens {
+			addUint(virtualInventoryForSwapsKey(virtualMarketID, i == 0), &virtualInventory[i])
+		}
+		extra.VirtualInventory = &virtualInventory
+	}
+	for _, priceFeed := range priceFeeds {
+		if priceFeed.feed != (common.Address{}) {
+			oracle.AddLatestRoundDataCall(req, priceFeed.feed.Hex(), &priceFeed.round)
+		}
+	}
+	if len(req.Calls) > 0 {
+		if _, err = req.Aggregate(); err != nil {
+			logger.WithFields(logger.Fields{
+				"address": p.Address,
+				"error":   err,
+			}).Errorf("failed to get prices of the market")
+			return p, err
+		}
+	}
+
+	extra.LongOpenInterestInTokens = new(big.Int).Add(openInterestInTokens[0], openInterestInTokens[1])
+	extra.ShortOpenInterest = new(big.Int).Add(shortOpenInterests[0], shortOpenInterests[1])
+	extra.MaxOraclePriceAge = maxOraclePriceAge.Uint64()
+	now := uint64(time.Now().Unix())
+	for i, token := range tokens {
+		extra.Prices[i] = priceFeeds[token].price(now)
+	}
+	if indexToken != (common.Address{}) {
+		extra.IndexTokenPrice = priceFeeds[indexToken].price(now)
+	}
+	d.attachReports(ctx, p.Address, &extra, priceFeeds)
+
+	extraBytes, err := json.Marshal(extra)
+	if err != nil {
+		return p, err
+	}
+
+	p.Reserves = entity.PoolReserves{poolAmounts[0].String(), poolAmounts[1].String()}
+	p.Extra = string(extraBytes)
+	p.Timestamp = time.Now().Unix()
+	if resp.BlockNumber != nil {
+		p.BlockNumber = resp.BlockNumber.Uint64()
+	}
+
+	logger.WithFields(logger.Fields{
+		"address": p.Address,
+	}).Infof("[%s] Finish getting new state of pool", p.Type)
+
+	return p, nil
+}
+
+// attachReports attaches the latest reports of the Data Streams feeds of the tokens, if the tracker has a report
+// provider. The market is still priced with its price feeds if they cannot be fetched.
+func (d *PoolTracker) attachReports(ctx context.Context, address string, extra *Extra,
+	priceFeeds map[common.Address]*priceFeedConfig) {
+	for token, priceFeed := range priceFeeds {
+		if priceFeed.dataStreamID == (common.Hash{}) {
+			continue
+		}
+		if extra.DataStreams == nil {
+			extra.DataStreams = map[string]*DataStream{}
+		}
+		extra.DataStreams[strings.ToLower(token.Hex())] = &DataStream{
+			FeedID:     pulloracle.NormalizeFeedID(priceFeed.dataStreamID.Hex()),
+			Multiplier: priceFeed.dataStreamMultiplier,
+		}
+	}
+	if d.reportProvider == nil || len(extra.DataStreams) == 0 {
+		return
+	}
+
+	feedIDs := lo.Uniq(lo.MapToSlice(extra.DataStreams, func(_ string, dataStream *DataStream) string {
+		return dataStream.FeedID
+	}))
+	reports, err := d.reportProvider.LatestReports(ctx, feedIDs)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"address": address,
+			"error":   err,
+		}).Warnf("failed to get reports of the market")
+		return
+	}
+	extra.Reports = reports
+}
+
+// price is ChainlinkPriceFeedUtils.getPriceFeedPrice, the answer of the feed scaled by its multiplier, widened towards
+// the stable price of the token as Oracle._setPricesFromPriceFeeds does. It is nil if the token has no feed or its
+// feed is stale.
+func (c *priceFeedConfig) price(now uint64) *Price {
+	if c.feed == (common.Address{}) || c.multiplier == nil {
+		return nil
+	}
+
+	feed := c.round.Feed()
+	answer, err := feed.Price(c.heartbeat.Uint64(), now)
+	if err != nil {
+		return nil
+	}
+
+	price := applyFactor(answer, c.multiplier)
+	if c.stable == nil || c.stable.Sign() == 0 {
+		return &Price{Min: price, Max: price}
+	} else if price.Cmp(c.stable) < 0 {
+		return &Price{Min: price, Max: c.stable}
+	}
+	return &Price{Min: c.stable, Max: price}
+}
@@ -0,0 +1,77 @@
+package gmxv2
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/pulloracle"
+)
+
+type StaticExtra struct {
+	IndexToken string `json:"indexToken"`
+}
+
+// Extra is the state of a market that swaps read, besides its pool amounts kept as reserves. Pairs are indexed as the
+// pool tokens: the long token first, then the short token.
+type Extra struct {
+	IsDisabled bool `json:"isDisabled,omitempty"`
+
+	SwapImpactPoolAmounts [2]*big.Int `json:"swapImpactPoolAmounts"`
+	MaxPoolAmounts        [2]*big.Int `json:"maxPoolAmounts"`
+	// VirtualInventory is the virtual inventory for swaps of the tokens, nil if the market has no virtual market id
+	VirtualInventory *[2]*big.Int `json:"virtualInventory,omitempty"`
+
+	PositiveSwapImpactFactor *big.Int `json:"positiveSwapImpactFactor"`
+	NegativeSwapImpactFactor *big.Int `json:"negativeSwapImpactFactor"`
+	SwapImpactExponentFactor *big.Int `json:"swapImpactExponentFactor"`
+	PositiveSwapFeeFactor    *big.Int `json:"positiveSwapFeeFactor"`
+	NegativeSwapFeeFactor    *big.Int `json:"negativeSwapFeeFactor"`
+	SwapFeeReceiverFactor    *big.Int `json:"swapFeeReceiverFactor"`
+
+	ReserveFactors [2]*big.Int `json:"reserveFactors"`
+	// LongOpenInterestInTokens and ShortOpenInterest are what long and short positions reserve of the pool
+	LongOpenInterestInTokens *big.Int `json:"longOpenInterestInTokens"`
+	ShortOpenInterest        *big.Int `json:"shortOpenInterest"`
+
+	// Prices of the tokens and of the index token from their price feeds, nil when the feeds have no price for them
+	Prices          [2]*Price `json:"prices"`
+	IndexTokenPrice *Price    `json:"indexTokenPrice,omitempty"`
+
+	// DataStreams are the Data Streams feeds of the tokens by address, whose attached reports take precedence over
+	// the price feeds
+	DataStreams map[string]*DataStream `json:"dataStreams,omitempty"`
+	Reports     pulloracle.Reports     `json:"reports,omitempty"`
+	// MaxOraclePriceAge is how long after they are observed the Oracle accepts prices, in seconds
+	MaxOraclePriceAge uint64 `json:"maxOraclePriceAge,omitempty"`
+}
+
+// DataStream is the Data Streams feed ChainlinkDataStreamProvider prices a token with.
+type DataStream struct {
+	FeedID     string   `json:"feedId"`
+	Multiplier *big.Int `json:"multiplier"`
+}
+
+// Price is the min and max price of a token, in USD with 30 decimals per unit of the token.
+type Price struct {
+	Min *big.Int `json:"min"`
+	Max *big.Int `json:"max"`
+}
+
+func (p *Price) midPrice() *big.Int {
+	mid := new(big.Int).Add(p.Min, p.Max)
+	return mid.Rsh(mid, 1)
+}
+
+// MetaInfo holds the tokens of the market priced with reports and the signed reports, to be submitted as the oracle
+// price data of the swap.
+type MetaInfo struct {
+	Tokens  []string        `json:"tokens"`
+	Reports []hexutil.Bytes `json:"reports"`
+}
+
+// SwapInfo holds the deltas a swap applies to the market, indexed as the pool tokens.
+type SwapInfo struct {
+	PoolAmountDeltas           [2]*big.Int `json:"poolAmountDeltas"`
+	SwapImpactPoolAmountDeltas [2]*big.Int `json:"swapImpactPoolAmountDeltas"`
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestCalcAmountOut(t *testing.T) {
@@ -31,6 +32,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 		s, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, s)
 
 		// expected
 		expectedAmountOut := "1488513423284045013413"
@@ -9,6 +9,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestCalcAmoutOut(t *testing.T) {
@@ -30,6 +31,7 @@ func TestCalcAmoutOut(t *testing.T) {
 
 		s, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, s)
 
 		// expected
 		expected := "10429133523081407408"
@@ -9,6 +9,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestCalcAmountOut(t *testing.T) {
@@ -50,6 +51,7 @@ func TestCalcAmountOut(t *testing.T) {
 		// calculation
 		simulator, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 		amountIn, _ := new(big.Int).SetString("2237821990898965", 10)
 		result, err := simulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
 			TokenAmountIn: poolpkg.TokenAmount{
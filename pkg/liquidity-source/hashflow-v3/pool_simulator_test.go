@@ -11,6 +11,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 var (
@@ -32,6 +33,7 @@ func TestPoolSimulator_NewPool(t *testing.T) {
 	t.Parallel()
 	poolSimulator, err := NewPoolSimulator(entityPool)
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSimulator)
 	assert.Equal(t, tokenOMG.Address, poolSimulator.Token0.Address)
 	assert.Equal(t, tokenUSDT.Address, poolSimulator.Token1.Address)
 	assert.Equal(t, "mm22", poolSimulator.MarketMaker)
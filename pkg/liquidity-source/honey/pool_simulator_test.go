@@ -0,0 +1,29 @@
+package honey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	usdce := "0x549943e04f40284185054145c6e4e9568c1d3241"
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  honeyToken,
+		Exchange: "honey",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{defaultReserves, defaultReserves},
+		Tokens:   []*entity.PoolToken{{Address: honeyToken, Decimals: 18}, {Address: usdce, Decimals: 6}},
+		Extra: `{"registeredAssets":["` + usdce + `"],"isPegged":[true],"isBadCollateral":[false],` +
+			`"mintRates":["1000000000000000000"],"redeemRates":["999500000000000000"],` +
+			`"vaults":["0x90bc07408f5b5eac4de38af76ea6069e1fcee363"],"vaultsDecimals":[18],` +
+			`"vaultsMaxRedeems":["5000000000000000000000000"],"assetsDecimals":[6],` +
+			`"polFeeCollectorFeeRate":"1000000000000000000"}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim, testutil.WithLiquidityErrors(ErrMaxRedeemAmountExceeded))
+}
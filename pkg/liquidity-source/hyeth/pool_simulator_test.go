@@ -10,6 +10,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func getPool() *PoolSimulator {
@@ -55,3 +56,8 @@ func TestPoolSimulator_redeem(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, big.NewInt(1270365070930608945), amountOut.TokenAmountOut.Amount)
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, getPool())
+}
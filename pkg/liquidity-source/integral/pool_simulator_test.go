@@ -73,6 +73,7 @@ func TestCalcAmountOut(t *testing.T) {
 	t.Run("1. should return OK for token0 to token1 swap", func(t *testing.T) {
 		simulator, err := NewPoolSimulator(pool)
 		require.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 
 		result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 			return simulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
@@ -1,7 +1,12 @@
 package rseth
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+
 const (
 	DexType = "kelp-rseth"
+
+	// Redemption of rsETH is queue-only, through the withdrawal manager of Kelp.
+	Redemption = lst.QueueOnly
 )
 
 const (
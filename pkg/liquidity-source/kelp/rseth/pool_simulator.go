@@ -66,6 +66,12 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 }
 
 func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if param.TokenAmountIn.Token == s.Info.Tokens[0] {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
+
 	if param.TokenOut != s.Info.Tokens[0] {
 		return nil, ErrInvalidTokenOut
 	}
@@ -82,6 +88,21 @@ func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	}, nil
 }
 
+// CanSwapTo only lists deposits into rsETH, as its redemption is queue-only
+func (s *PoolSimulator) CanSwapTo(token string) []string {
+	if token != s.Info.Tokens[0] {
+		return []string{}
+	}
+	return s.Info.Tokens[1:]
+}
+
+func (s *PoolSimulator) CanSwapFrom(token string) []string {
+	if token == s.Info.Tokens[0] || s.GetTokenIndex(token) < 0 {
+		return []string{}
+	}
+	return []string{s.Info.Tokens[0]}
+}
+
 func (s *PoolSimulator) UpdateBalance(param pool.UpdateBalanceParams) {
 	totalDeposit := s.totalDepositByAsset[param.TokenAmountIn.Token]
 
@@ -8,6 +8,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -51,6 +53,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -63,3 +67,21 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_QueueOnlyRedemption(t *testing.T) {
+	t.Parallel()
+	rsETH, ethx, weth := "0xa1290d69c65a6fe4df752f95823fae25cb99e5a7", "0xa35b1b31ce002fbf2058d22f30f95d405200a15b",
+		"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	s := &PoolSimulator{Pool: poolpkg.Pool{Info: poolpkg.PoolInfo{Tokens: []string{rsETH, ethx, weth}}}}
+
+	assert.Equal(t, []string{ethx, weth}, s.CanSwapTo(rsETH))
+	assert.Empty(t, s.CanSwapTo(ethx))
+	assert.Equal(t, []string{rsETH}, s.CanSwapFrom(weth))
+	assert.Empty(t, s.CanSwapFrom(rsETH))
+
+	_, err := s.CalcAmountOut(poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{Token: rsETH, Amount: bignumber.NewBig("1000000000000000000")},
+		TokenOut:      weth,
+	})
+	assert.ErrorIs(t, err, lst.ErrQueueOnly)
+}
@@ -0,0 +1,28 @@
+package litepsm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0xf6e72db5454dd049d0788e411b06cfaf16853042",
+		Exchange: "lite-psm",
+		Type:     DexTypeLitePSM,
+		Reserves: entity.PoolReserves{"1000000000000000000000000", "1000000000000"},
+		Tokens: []*entity.PoolToken{
+			{Address: "0x6b175474e89094c44da98b954eedeac495271d0f", Decimals: 18},
+			{Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Decimals: 6},
+		},
+		Extra: `{"litePSM":{"tIn":"0","tOut":"1000000000000000"}}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim,
+		testutil.WithLiquidityErrors(ErrInsufficientDAIBalance, ErrInsufficientGemBalance))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/swaplimit"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut_RealPool(t *testing.T) {
@@ -322,6 +323,7 @@ func TestPoolSimulator_CalcAmountOut_RealPool(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			p, err := NewPoolSimulator(tt.fields.poolEntity)
 			assert.NoError(t, err)
+			testutil.TestPoolSimulator(t, p)
 
 			got, err := p.CalcAmountOut(tt.args.param)
 			if err != nil {
@@ -31,10 +31,12 @@ func rpow(x, n, base *uint256.Int) (*uint256.Int, error) {
 
 	if temp.Mod(n, number.Number_2).IsZero() {
 		z.Set(base)
+	} else {
+		z.Set(x)
 	}
 
 	half := new(uint256.Int).Div(base, number.Number_2)
-	for ; i.Gt(number.Zero); i.Div(&i, number.Number_2) {
+	for i.Div(n, number.Number_2); i.Gt(number.Zero); i.Div(&i, number.Number_2) {
 		xx.Mul(x, x)
 
 		if !temp.Div(&xx, x).Eq(x) {
@@ -2,6 +2,7 @@ package savingsdai
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/holiman/uint256"
@@ -25,6 +26,7 @@ type (
 
 	SwapInfo struct {
 		chi       *uint256.Int
+		rho       *uint256.Int
 		IsDeposit bool `json:"isDeposit"`
 	}
 
@@ -41,6 +43,8 @@ type (
 
 var _ = pool.RegisterFactory0(DexType, NewPoolSimulator)
 
+var now = time.Now
+
 func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 	tokens := lo.Map(entityPool.Tokens, func(token *entity.PoolToken, _ int) string {
 		return token.Address
@@ -87,7 +91,8 @@ func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 		return nil, ErrOverflow
 	}
 
-	chi, err := s._chi()
+	timestamp := s.timestamp()
+	chi, err := s._chi(timestamp)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +117,7 @@ func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.Ca
 		Gas: s.estimateGas(isDeposit),
 		SwapInfo: SwapInfo{
 			chi:       chi,
+			rho:       timestamp,
 			IsDeposit: isDeposit,
 		},
 	}, nil
@@ -123,7 +129,7 @@ func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 		return
 	}
 	s.chi = swapInfo.chi
-	s.rho = s.now
+	s.rho = swapInfo.rho
 }
 
 func (s *PoolSimulator) GetMetaInfo(tokenIn, tokenOut string) interface{} {
@@ -147,15 +153,24 @@ func (s *PoolSimulator) redeem(shares, chi *uint256.Int) *uint256.Int {
 	return assets.Mul(shares, chi).Div(&assets, RAY)
 }
 
-func (s *PoolSimulator) _chi() (*uint256.Int, error) {
-	if s.now.Gt(s.rho) {
-		return s.drip()
+// timestamp returns the block timestamp to quote at, which is the current time but no earlier than the timestamp of
+// the block the state was fetched at.
+func (s *PoolSimulator) timestamp() *uint256.Int {
+	if timestamp := uint256.NewInt(uint64(now().Unix())); timestamp.Gt(s.now) {
+		return timestamp
+	}
+	return s.now
+}
+
+func (s *PoolSimulator) _chi(timestamp *uint256.Int) (*uint256.Int, error) {
+	if timestamp.Gt(s.rho) {
+		return s.drip(timestamp)
 	}
 	return s.chi, nil
 }
 
-func (s *PoolSimulator) drip() (*uint256.Int, error) {
-	x, err := rpow(s.savingsRate, new(uint256.Int).Sub(s.now, s.rho), RAY)
+func (s *PoolSimulator) drip(timestamp *uint256.Int) (*uint256.Int, error) {
+	x, err := rpow(s.savingsRate, new(uint256.Int).Sub(timestamp, s.rho), RAY)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,107 @@
+package savingsdai
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	dai  = "0x6b175474e89094c44da98b954eedeac495271d0f"
+	sDAI = "0x83f20f44975d03b1b09e64809b757c47f942beea"
+)
+
+func TestRpow(t *testing.T) {
+	savingsRate := uint256.MustFromDecimal("1000000001547125957863212448")
+	for n, expected := range map[uint64]string{
+		0:    "1000000000000000000000000000",
+		1:    "1000000001547125957863212448",
+		7:    "1000000010829881755308060584",
+		3600: "1000005569668954547626342464",
+	} {
+		z, err := rpow(savingsRate, uint256.NewInt(n), RAY)
+		require.NoError(t, err)
+		assert.Equal(t, expected, z.Dec(), "n = %d", n)
+	}
+}
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+
+	newPoolSimulator := func() *PoolSimulator {
+		s, err := NewPoolSimulator(entity.Pool{
+			Address:  sDAI,
+			Exchange: DexType,
+			Type:     DexType,
+			Reserves: entity.PoolReserves{"1000000000000000000000000", "869565217391304347826086"},
+			Tokens:   []*entity.PoolToken{{Address: dai, Decimals: 18}, {Address: sDAI, Decimals: 18}},
+			Extra: `{"blockTimestamp":"1700000012","rho":"1700000000","chi":"1150000000000000000000000000",` +
+				`"savingsRate":"1000000001547125957863212448"}`,
+		})
+		require.NoError(t, err)
+		return s
+	}
+	deposit := pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: dai, Amount: big.NewInt(1e18)},
+		TokenOut:      sDAI,
+	}
+	redeem := pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: sDAI, Amount: big.NewInt(1e18)},
+		TokenOut:      dai,
+	}
+
+	t.Run("conformance", func(t *testing.T) {
+		testutil.TestPoolSimulator(t, newPoolSimulator())
+	})
+
+	t.Run("chi drips to the time being quoted", func(t *testing.T) {
+		now = func() time.Time { return time.Unix(1700003600, 0) }
+		s := newPoolSimulator()
+
+		result, err := s.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: dai, Amount: bignumber.NewBig10("1000000000000000000000")},
+			TokenOut:      sDAI,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "869560374227883905965", result.TokenAmountOut.Amount.String())
+
+		result, err = s.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: sDAI, Amount: bignumber.NewBig10("1000000000000000000000")},
+			TokenOut:      dai,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1150006405119297729770", result.TokenAmountOut.Amount.String())
+
+		s.UpdateBalance(pool.UpdateBalanceParams{SwapInfo: result.SwapInfo})
+		assert.Equal(t, "1150006405119297729770293833", s.chi.Dec())
+		assert.Equal(t, uint64(1700003600), s.rho.Uint64())
+	})
+
+	t.Run("quotes no earlier than the block after the fetched one", func(t *testing.T) {
+		now = func() time.Time { return time.Unix(1699999000, 0) }
+		s := newPoolSimulator()
+
+		depositResult, err := s.CalcAmountOut(deposit)
+		require.NoError(t, err)
+		redeemResult, err := s.CalcAmountOut(redeem)
+		require.NoError(t, err)
+
+		now = func() time.Time { return time.Unix(1700000012, 0) }
+		expectedDeposit, err := s.CalcAmountOut(deposit)
+		require.NoError(t, err)
+		expectedRedeem, err := s.CalcAmountOut(redeem)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedDeposit.TokenAmountOut, depositResult.TokenAmountOut)
+		assert.Equal(t, expectedRedeem.TokenAmountOut, redeemResult.TokenAmountOut)
+	})
+}
@@ -136,3 +136,8 @@ func TestPoolSimulator_CalcAmountIn(t *testing.T) {
 	t.Parallel()
 	testutil.TestCalcAmountIn(t, poolSim)
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, poolSim)
+}
@@ -2,11 +2,16 @@ package meth
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 const (
 	DexType = "meth"
 
+	// Redemption of mETH is queue-only, through the unstake requests of Mantle.
+	Redemption = lst.QueueOnly
+
 	MantleLSPStaking = "0xe3cbd06d7dadb3f4e6557bab7edd924cd1489e8f"
 	MantlePauser     = "0x29Ab878aEd032e2e2c86FF4A9a9B05e3276cf1f8"
 	METH             = "0xd5f7838f5c461feff7fe49ea5ebaf7728bb0adfa"
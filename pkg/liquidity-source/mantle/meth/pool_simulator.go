@@ -55,6 +55,12 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 }
 
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if params.TokenAmountIn.Token == METH && params.TokenOut == WETH {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.isStakingPaused {
 		return nil, ErrStakingPaused
 	}
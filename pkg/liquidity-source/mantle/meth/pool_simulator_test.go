@@ -9,6 +9,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -105,7 +107,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 			expectedAmountOut: bignumber.NewBig("47852783468734432213"),
 		},
 		{
-			name: "it should return error when token in is invalid",
+			name: "it should return error when redeeming mETH, which is queue-only",
 			poolSimulator: &PoolSimulator{
 				Pool: poolpkg.Pool{
 					Info: poolpkg.PoolInfo{
@@ -125,7 +127,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 				},
 				TokenOut: WETH,
 			},
-			expectedError: ErrorInvalidTokenIn,
+			expectedError: lst.ErrQueueOnly,
 		},
 		{
 			name: "it should return error when amount in is less than minimum stake bound",
@@ -158,6 +160,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -162,6 +162,7 @@ func TestUpdateBalance(t *testing.T) {
 
 	sim, err := NewPoolSimulator(poolEnt)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, sim)
 
 	assert.Equal(t, int16(-1), sim.state.minBinMapIndex)
 	assert.Equal(t, int16(0), sim.state.maxBinMapIndex)
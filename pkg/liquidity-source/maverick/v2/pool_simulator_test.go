@@ -50,6 +50,7 @@ func TestSimpleSwaps_USDC_USDT(t *testing.T) {
 
 	poolSim, err := NewPoolSimulator(poolEntity)
 	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
 
 	// Perform a swap to get swap info
 	amountIn := big.NewInt(10000000) // 10 USDC
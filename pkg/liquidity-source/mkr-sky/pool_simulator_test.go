@@ -63,6 +63,7 @@ func TestGetAmountOut(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool, err := NewPoolSimulator(tc.entityPool)
 			assert.Nil(t, err)
+			testutil.TestPoolSimulator(t, pool)
 
 			assert.Contains(t, pool.CanSwapTo(tc.tokenOut), tc.tokenAmountIn.Token)
 
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -90,6 +91,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
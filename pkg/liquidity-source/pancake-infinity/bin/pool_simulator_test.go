@@ -41,6 +41,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 	pSim, err := NewPoolSimulator(poolEnt, valueobject.ChainID(chainID))
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, pSim)
 
 	tests := []struct {
 		name            string
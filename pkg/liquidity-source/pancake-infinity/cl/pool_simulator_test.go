@@ -42,6 +42,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 	pSim, err := NewPoolSimulator(poolEnt, valueobject.ChainID(chainID))
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, pSim)
 
 	got, err := pSim.CalcAmountOut(pool.CalcAmountOutParams{
 		TokenAmountIn: pool.TokenAmount{
@@ -0,0 +1,30 @@
+package pandafun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	// reserves sit on the curve at sqrtP = 5e16, between sqrtPa = 1e16 and sqrtPb = 1e18
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x1a9f0f1cd7b9cf1e3e1d2a2a3b0a1f67b54d3c02",
+		Exchange: "panda-fun",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"400000000000000000000", "190000000000000000000000"},
+		Tokens: []*entity.PoolToken{
+			{Address: "0x6969696969696969696969696969696969696969", Decimals: 18},
+			{Address: "0x4a0f5ae7f1b2fd7d8b0f3f7c92a4dd0e8b0e7b6f", Decimals: 18},
+		},
+		Extra: `{"minTradeSize":1000000000000000,"amountInBuyRemainingTokens":9500000000000000000000,` +
+			`"liquidity":10000000000000000000000000000000000000000,"buyFee":100,"sellFee":100,` +
+			`"sqrtPa":10000000000000000,"sqrtPb":1000000000000000000}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim, testutil.WithLiquidityErrors(ErrInsufficientLiquidity))
+}
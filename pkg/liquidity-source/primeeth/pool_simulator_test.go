@@ -0,0 +1,25 @@
+package primeeth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  primeZapper,
+		Exchange: "primeeth",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{defaultReserves, defaultReserves},
+		Tokens:   []*entity.PoolToken{{Address: WETH, Decimals: 18}, {Address: PrimeETH, Decimals: 18}},
+		Extra: `{"totalAssetDeposit":1200000000000000000000,"depositLimitByAsset":100000000000000000000000,` +
+			`"minAmountToDeposit":1000000000000000,"primeETHPrice":1005000000000000000}`,
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim, testutil.WithLiquidityErrors(ErrMaximumDepositLimitReached))
+}
@@ -2,10 +2,18 @@ package pufeth
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 const (
 	DexType = "puffer-pufeth"
+
+	// Redemption of pufETH is instant, paid in WETH out of the exit buffer of the vault within its daily withdrawal
+	// limit.
+	Redemption = lst.Instant
+
+	bps = 10000
 )
 
 var (
@@ -13,11 +21,15 @@ var (
 	PUFETH          = "0xd9a442856c234a39a81a089c06451ebaa4306a72"
 	STETH           = "0xae7ab96520de3a18e5e111b5eaab095312d7fe84"
 	WSTETH          = "0x7f39c581f595b53c5cb19bd0b3f8da6c935e2ca0"
+	WETH            = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
 )
 
 var (
 	PufferVaultMethodTotalSupply  = "totalSupply"
 	PufferVaultMethodTotalAssets  = "totalAssets"
+	PufferVaultMethodExitFee      = "getExitFeeBasisPoints"
+	PufferVaultMethodDailyLimit   = "getRemainingAssetsDailyWithdrawalLimit"
+	ERC20MethodBalanceOf          = "balanceOf"
 	LidoMethodGetTotalPooledEther = "getTotalPooledEther"
 	LidoMethodGetTotalShares      = "getTotalShares"
 )
@@ -25,6 +37,7 @@ var (
 var defaultGas = Gas{
 	depositStETH:  250000,
 	depositWstETH: 280000,
+	redeem:        120000,
 }
 
 const (
@@ -54,7 +54,7 @@ func (u *PoolListUpdater) GetNewPools(ctx context.Context, metadataBytes []byte)
 			Exchange:  string(valueobject.ExchangePufferPufETH),
 			Type:      DexType,
 			Timestamp: time.Now().Unix(),
-			Reserves:  []string{reserves, reserves, reserves},
+			Reserves:  []string{reserves, reserves, reserves, reserves},
 			Tokens: []*entity.PoolToken{
 				{
 					Address:   strings.ToLower(PUFETH),
@@ -74,6 +74,12 @@ func (u *PoolListUpdater) GetNewPools(ctx context.Context, metadataBytes []byte)
 					Decimals:  18,
 					Swappable: true,
 				},
+				{
+					Address:   strings.ToLower(WETH),
+					Symbol:    "WETH",
+					Decimals:  18,
+					Swappable: true,
+				},
 			},
 			BlockNumber: blockNumber,
 			Extra:       string(extraBytes),
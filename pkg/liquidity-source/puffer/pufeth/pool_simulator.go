@@ -11,6 +11,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 // Depositor: https://etherscan.io/address/0x4aa799c5dfc01ee7d790e3bf1a7c2257ce1dceff
@@ -30,6 +31,15 @@ type PoolSimulator struct {
 	// totalShares: LidoMethodGetTotalShares
 	totalShares *uint256.Int
 
+	// exitFeeBasisPoints: PufferVaultMethodExitFee
+	exitFeeBasisPoints *uint256.Int
+
+	// remainingDailyWithdrawalLimit: PufferVaultMethodDailyLimit
+	remainingDailyWithdrawalLimit *uint256.Int
+
+	// buffer: the WETH and ETH held by the vault
+	buffer *uint256.Int
+
 	gas Gas
 }
 
@@ -54,11 +64,23 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		totalAssets:      extra.TotalAssets,
 		totalPooledEther: extra.TotalPooledEther,
 		totalShares:      extra.TotalShares,
-		gas:              defaultGas,
+
+		exitFeeBasisPoints:            extra.ExitFeeBasisPoints,
+		remainingDailyWithdrawalLimit: extra.RemainingDailyWithdrawalLimit,
+		buffer:                        extra.Buffer,
+
+		gas: defaultGas,
 	}, nil
 }
 
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if s.isRedeem(params.TokenAmountIn.Token, params.TokenOut) {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+		return s.calcRedeem(params)
+	}
+
 	// NOTE: only support tokenIn = stETH, wstETH and tokenOut is pufETH
 	if !((params.TokenAmountIn.Token == s.Info.Tokens[1] || params.TokenAmountIn.Token == s.Info.Tokens[2]) && params.TokenOut == s.Info.Tokens[0]) {
 		return nil, ErrUnsupportedSwap
@@ -96,6 +118,15 @@ func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	amountIn, _ := uint256.FromBig(params.TokenAmountIn.Amount)
 	amountOut, _ := uint256.FromBig(params.TokenAmountOut.Amount)
 
+	if swapInfo, ok := params.SwapInfo.(SwapExtra); ok && swapInfo.IsRedeem {
+		// the exit fee stays in the vault
+		s.totalSupply = subFloor(s.totalSupply, amountIn)
+		s.totalAssets = subFloor(s.totalAssets, amountOut)
+		s.remainingDailyWithdrawalLimit = subFloor(s.remainingDailyWithdrawalLimit, amountOut)
+		s.buffer = subFloor(s.buffer, amountOut)
+		return
+	}
+
 	s.totalSupply = new(uint256.Int).Add(s.totalSupply, amountOut)
 
 	if params.TokenAmountIn.Token == s.Info.Tokens[2] {
@@ -105,11 +136,14 @@ func (s *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	}
 }
 
-// NOTE: only support tokenIn = stETH, wstETH and tokenOut is pufETH
+// NOTE: only support tokenIn = stETH, wstETH and tokenOut is pufETH, or redeeming pufETH for WETH
 func (s *PoolSimulator) CanSwapTo(token string) []string {
 	if token == PUFETH {
 		return []string{STETH, WSTETH}
 	}
+	if s.isRedeem(PUFETH, token) {
+		return []string{PUFETH}
+	}
 	return []string{}
 }
 
@@ -117,6 +151,9 @@ func (s *PoolSimulator) CanSwapFrom(token string) []string {
 	if token == STETH || token == WSTETH {
 		return []string{PUFETH}
 	}
+	if s.isRedeem(token, WETH) {
+		return []string{WETH}
+	}
 	return []string{}
 }
 
@@ -136,3 +173,66 @@ func (s *PoolSimulator) unwrap(amount *uint256.Int) *uint256.Int {
 func (s *PoolSimulator) convertToShares(amount *uint256.Int) (*uint256.Int, error) {
 	return Math.MulDivF(amount, new(uint256.Int).Add(s.totalSupply, number.Number_1), new(uint256.Int).Add(s.totalAssets, number.Number_1))
 }
+
+// isRedeem tells whether tokenIn -> tokenOut redeems pufETH for WETH, which pools listed before WETH was added to
+// their tokens do not support.
+func (s *PoolSimulator) isRedeem(tokenIn, tokenOut string) bool {
+	return len(s.Info.Tokens) > 3 && tokenIn == s.Info.Tokens[0] && tokenOut == s.Info.Tokens[3]
+}
+
+// calcRedeem redeems pufETH for WETH out of the exit buffer of the vault, as PufferVaultV2.redeem
+func (s *PoolSimulator) calcRedeem(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	shares, overflow := uint256.FromBig(params.TokenAmountIn.Amount)
+	if overflow {
+		return nil, ErrInvalidAmountIn
+	}
+
+	assets, fee, err := s.previewRedeem(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.remainingDailyWithdrawalLimit == nil || s.buffer == nil ||
+		assets.Gt(s.remainingDailyWithdrawalLimit) || assets.Gt(s.buffer) {
+		return nil, lst.ErrInsufficientBuffer
+	}
+
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: &pool.TokenAmount{Token: params.TokenOut, Amount: assets.ToBig()},
+		Fee:            &pool.TokenAmount{Token: params.TokenOut, Amount: fee.ToBig()},
+		Gas:            s.gas.redeem,
+		SwapInfo: SwapExtra{
+			IsRedeem: true,
+		},
+	}, nil
+}
+
+// previewRedeem returns the assets paid for shares net of the exit fee, which is charged on the total and rounded up
+func (s *PoolSimulator) previewRedeem(shares *uint256.Int) (*uint256.Int, *uint256.Int, error) {
+	assets, err := Math.MulDivF(shares, new(uint256.Int).Add(s.totalAssets, number.Number_1), new(uint256.Int).Add(s.totalSupply, number.Number_1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fee := new(uint256.Int)
+	if s.exitFeeBasisPoints != nil && !s.exitFeeBasisPoints.IsZero() {
+		numerator, overflow := new(uint256.Int).MulOverflow(assets, s.exitFeeBasisPoints)
+		if overflow {
+			return nil, nil, ErrOverflow
+		}
+		remainder := new(uint256.Int)
+		fee.DivMod(numerator, new(uint256.Int).AddUint64(s.exitFeeBasisPoints, bps), remainder)
+		if !remainder.IsZero() {
+			fee.AddUint64(fee, 1)
+		}
+	}
+
+	return assets.Sub(assets, fee), fee, nil
+}
+
+func subFloor(x, y *uint256.Int) *uint256.Int {
+	if x == nil || x.Lt(y) {
+		return new(uint256.Int)
+	}
+	return new(uint256.Int).Sub(x, y)
+}
@@ -9,6 +9,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -68,6 +70,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -80,3 +84,61 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_Redeem(t *testing.T) {
+	t.Parallel()
+	newPoolSimulator := func() *PoolSimulator {
+		return &PoolSimulator{
+			Pool: poolpkg.Pool{
+				Info: poolpkg.PoolInfo{
+					Tokens: []string{PUFETH, STETH, WSTETH, WETH},
+				},
+			},
+			totalSupply:                   number.NewUint256("379989503452489947895013"),
+			totalAssets:                   number.NewUint256("382649667359278267721330"),
+			exitFeeBasisPoints:            number.NewUint256("100"),
+			remainingDailyWithdrawalLimit: number.NewUint256("1500000000000000000"),
+			buffer:                        number.NewUint256("100000000000000000000"),
+		}
+	}
+	redeem := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{Amount: bignumber.NewBig("1000000000000000000"), Token: PUFETH},
+		TokenOut:      WETH,
+	}
+
+	t.Run("it should charge the exit fee on the total", func(t *testing.T) {
+		s := newPoolSimulator()
+		assert.Equal(t, []string{PUFETH}, s.CanSwapTo(WETH))
+		assert.Equal(t, []string{WETH}, s.CanSwapFrom(PUFETH))
+
+		result, err := s.CalcAmountOut(redeem)
+		assert.NoError(t, err)
+		assert.Equal(t, "997030321493429314", result.TokenAmountOut.Amount.String())
+		assert.Equal(t, "9970303214934294", result.Fee.Amount.String())
+
+		s.UpdateBalance(poolpkg.UpdateBalanceParams{
+			TokenAmountIn:  redeem.TokenAmountIn,
+			TokenAmountOut: *result.TokenAmountOut,
+			SwapInfo:       result.SwapInfo,
+		})
+		assert.Equal(t, "502969678506570686", s.remainingDailyWithdrawalLimit.Dec())
+
+		_, err = s.CalcAmountOut(redeem)
+		assert.ErrorIs(t, err, lst.ErrInsufficientBuffer)
+	})
+
+	t.Run("it should be capped by the buffer", func(t *testing.T) {
+		s := newPoolSimulator()
+		s.buffer = number.NewUint256("900000000000000000")
+		_, err := s.CalcAmountOut(redeem)
+		assert.ErrorIs(t, err, lst.ErrInsufficientBuffer)
+	})
+
+	t.Run("it should not redeem in pools without WETH", func(t *testing.T) {
+		s := newPoolSimulator()
+		s.Info.Tokens = s.Info.Tokens[:3]
+		assert.Empty(t, s.CanSwapFrom(PUFETH))
+		_, err := s.CalcAmountOut(redeem)
+		assert.ErrorIs(t, err, ErrUnsupportedSwap)
+	})
+}
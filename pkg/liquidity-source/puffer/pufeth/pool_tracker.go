@@ -76,8 +76,17 @@ func (t *PoolTracker) getExtra(
 		totalAssets      *big.Int
 		totalShares      *big.Int
 		totalPooledEther *big.Int
+
+		exitFeeBasisPoints            *big.Int
+		remainingDailyWithdrawalLimit *big.Int
+		wethBalance                   *big.Int
 	)
 
+	ethBalance, err := t.ethrpcClient.BalanceAt(ctx, common.HexToAddress(PUFETH), nil)
+	if err != nil {
+		return PoolExtra{}, 0, err
+	}
+
 	getPoolStateRequest := t.ethrpcClient.NewRequest().SetContext(ctx)
 	if overrides != nil {
 		getPoolStateRequest.SetOverrides(overrides)
@@ -111,6 +120,28 @@ func (t *PoolTracker) getExtra(
 		Params: []interface{}{},
 	}, []interface{}{&totalPooledEther})
 
+	// the exit buffer is only tracked from the vault upgrade that added instant redemptions, so these calls may revert
+	getPoolStateRequest.AddCall(&ethrpc.Call{
+		ABI:    pufferVaultABI,
+		Target: PUFETH,
+		Method: PufferVaultMethodExitFee,
+		Params: []interface{}{},
+	}, []interface{}{&exitFeeBasisPoints})
+
+	getPoolStateRequest.AddCall(&ethrpc.Call{
+		ABI:    pufferVaultABI,
+		Target: PUFETH,
+		Method: PufferVaultMethodDailyLimit,
+		Params: []interface{}{},
+	}, []interface{}{&remainingDailyWithdrawalLimit})
+
+	getPoolStateRequest.AddCall(&ethrpc.Call{
+		ABI:    pufferVaultABI,
+		Target: WETH,
+		Method: ERC20MethodBalanceOf,
+		Params: []interface{}{common.HexToAddress(PUFETH)},
+	}, []interface{}{&wethBalance})
+
 	resp, err := getPoolStateRequest.TryAggregate()
 	if err != nil {
 		return PoolExtra{}, 0, err
@@ -120,10 +151,19 @@ func (t *PoolTracker) getExtra(
 		resp.BlockNumber = big.NewInt(0)
 	}
 
+	var buffer *uint256.Int
+	if exitFeeBasisPoints != nil && remainingDailyWithdrawalLimit != nil && wethBalance != nil {
+		buffer = uint256.MustFromBig(new(big.Int).Add(wethBalance, ethBalance))
+	}
+
 	return PoolExtra{
 		TotalSupply:      uint256.MustFromBig(totalSupply),
 		TotalAssets:      uint256.MustFromBig(totalAssets),
 		TotalPooledEther: uint256.MustFromBig(totalPooledEther),
 		TotalShares:      uint256.MustFromBig(totalShares),
+
+		ExitFeeBasisPoints:            uint256.MustFromBig(exitFeeBasisPoints),
+		RemainingDailyWithdrawalLimit: uint256.MustFromBig(remainingDailyWithdrawalLimit),
+		Buffer:                        buffer,
 	}, resp.BlockNumber.Uint64(), nil
 }
@@ -7,10 +7,17 @@ type PoolExtra struct {
 	TotalAssets      *uint256.Int `json:"totalAssets"`
 	TotalPooledEther *uint256.Int `json:"totalPooledEther"`
 	TotalShares      *uint256.Int `json:"totalShares"`
+
+	// ExitFeeBasisPoints, RemainingDailyWithdrawalLimit and Buffer (the WETH and ETH held by the vault) bound instant
+	// redemptions of pufETH
+	ExitFeeBasisPoints            *uint256.Int `json:"exitFeeBasisPoints,omitempty"`
+	RemainingDailyWithdrawalLimit *uint256.Int `json:"remainingDailyWithdrawalLimit,omitempty"`
+	Buffer                        *uint256.Int `json:"buffer,omitempty"`
 }
 
 type SwapExtra struct {
-	IsStETH bool `json:"isStETH"`
+	IsStETH  bool `json:"isStETH"`
+	IsRedeem bool `json:"isRedeem,omitempty"`
 }
 
 type PoolMeta struct {
@@ -20,4 +27,5 @@ type PoolMeta struct {
 type Gas struct {
 	depositStETH  int64 // 250000
 	depositWstETH int64 // 280000
+	redeem        int64 // 120000
 }
@@ -13,7 +13,6 @@ var (
 	PriceFeedABI         abi.ABI
 	StrategyManagerABI   abi.ABI
 	OperatorDelegatorABI abi.ABI
-	TokenOracleABI       abi.ABI
 )
 
 func init() {
@@ -39,9 +38,6 @@ func init() {
 		{
 			&OperatorDelegatorABI, operatorDelegatorABIJson,
 		},
-		{
-			&TokenOracleABI, tokenOracleABIJson,
-		},
 	}
 
 	for _, b := range builder {
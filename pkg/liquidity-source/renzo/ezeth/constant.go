@@ -3,11 +3,17 @@ package ezeth
 import (
 	"errors"
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 const (
 	DexType = "renzo-ezeth"
 
+	// Redemption of ezETH is queue-only, through the withdraw queue of Renzo.
+	Redemption = lst.QueueOnly
+
 	RestakeManager  = "0x74a09653a083691711cf8215a6ab074bb4e99ef5"
 	EzEthToken      = "0xbf5495efe5db9ce00f80364c8b423567e58d2110"
 	WETH            = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
@@ -31,7 +37,6 @@ const (
 	RestakeManagerMethodRenzoOracle               = "renzoOracle"
 
 	RenzoOracleMethodTokenOracleLookUp = "tokenOracleLookup"
-	TokenOracleMethodLatestRoundData   = "latestRoundData"
 
 	RestakeManagerMethodGetOperatorDelegatorsLength  = "getOperatorDelegatorsLength"
 	RestakeManagerMethodOperatorDelegators           = "operatorDelegators"
@@ -49,8 +54,8 @@ var (
 	ErrMaxTokenTVLReached     = errors.New("max token tvl reached")
 	ErrInvalidTokenAmount     = errors.New("invalid tokenAmount")
 	ErrOracleNotFound         = errors.New("oracle not found")
-	ErrOracleExpired          = errors.New("oracle expired")
-	ErrInvalidOraclePrice     = errors.New("invalid oracle price")
+	ErrOracleExpired          = oracle.ErrStalePrice
+	ErrInvalidOraclePrice     = oracle.ErrInvalidPrice
 	ErrPoolPaused             = errors.New("pool paused")
 	ErrStrategyManagerPaused  = errors.New("strategy manager paused")
 	ErrRevertNotFound         = errors.New("revert not found")
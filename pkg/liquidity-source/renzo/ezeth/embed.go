@@ -19,6 +19,3 @@ var strategyManagerABIJson []byte
 
 //go:embed abis/OperatorDelegator.json
 var operatorDelegatorABIJson []byte
-
-//go:embed abis/TokenOracle.json
-var tokenOracleABIJson []byte
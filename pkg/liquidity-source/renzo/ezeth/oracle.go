@@ -1,15 +1,6 @@
 package ezeth
 
-import "math/big"
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 
-type Oracle struct {
-	RoundId         *big.Int `json:"-"`
-	Answer          *big.Int `json:"answer"`
-	StartedAt       *big.Int `json:"-"`
-	UpdatedAt       *big.Int `json:"updatedAt"`
-	AnsweredInRound *big.Int `json:"-"`
-}
-
-func (o *Oracle) LatestRoundData() (*big.Int, *big.Int) {
-	return o.Answer, o.UpdatedAt
-}
+// Oracle is the latest answer of a token oracle of renzoOracle.tokenOracleLookup
+type Oracle = oracle.Feed
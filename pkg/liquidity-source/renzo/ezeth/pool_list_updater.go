@@ -16,6 +16,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poollist "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/list"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
 
@@ -249,7 +250,7 @@ func getExtra(
 	var (
 		operatorDelegatorAllocations = make([]*big.Int, operatorDelegatorsLen)
 		tokenStrategies              = make([][]common.Address, operatorDelegatorsLen)
-		oracleInfo                   = make([]Oracle, len(tokenOracleAddresses))
+		oracleInfo                   = make([]oracle.RoundData, len(tokenOracleAddresses))
 	)
 	operatorDelegatorInfoRequest := ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(resp.BlockNumber)
 	if overrides != nil {
@@ -278,12 +279,7 @@ func getExtra(
 	}
 
 	for i := 0; i < len(tokenOracleAddresses); i++ {
-		operatorDelegatorInfoRequest.AddCall(&ethrpc.Call{
-			ABI:    TokenOracleABI,
-			Target: tokenOracleAddresses[i].String(),
-			Method: TokenOracleMethodLatestRoundData,
-			Params: []interface{}{},
-		}, []interface{}{&oracleInfo[i]})
+		oracle.AddLatestRoundDataCall(operatorDelegatorInfoRequest, tokenOracleAddresses[i].String(), &oracleInfo[i])
 	}
 
 	resp, err = operatorDelegatorInfoRequest.TryAggregate()
@@ -310,7 +306,7 @@ func getExtra(
 	for i := 0; i < len(collaterals); i++ {
 		address := strings.ToLower(collaterals[i].Hex())
 		collateralTokenIndex[address] = i
-		tokenOracleLookup[address] = oracleInfo[i]
+		tokenOracleLookup[address] = oracleInfo[i].Feed()
 		collateralTokenTvlLimitsMap[address] = collateralTokenTvlLimits[i]
 	}
 
@@ -79,6 +79,12 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 }
 
 func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if param.TokenAmountIn.Token == s.Info.Tokens[0] {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.paused {
 		return nil, ErrPoolPaused
 	}
@@ -227,19 +233,14 @@ func (s *PoolSimulator) lookupTokenValue(
 	token string,
 	value *big.Int,
 ) (*big.Int, error) {
-	oracle, ok := s.tokenOracleLookup[token]
+	tokenOracle, ok := s.tokenOracleLookup[token]
 	if !ok {
 		return nil, ErrOracleNotFound
 	}
 
-	price, timestamp := oracle.LatestRoundData()
-
-	if timestamp.Int64() < time.Now().Unix()-MAX_TIME_WINDOW {
-		return nil, ErrOracleExpired
-	}
-
-	if price.Cmp(bignumber.ZeroBI) <= 0 {
-		return nil, ErrInvalidOraclePrice
+	price, err := tokenOracle.Price(uint64(MAX_TIME_WINDOW), uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, err
 	}
 
 	return new(big.Int).Div(new(big.Int).Mul(value, price), SCALE_FACTOR), nil
@@ -7,6 +7,7 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -29,6 +30,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 			totalSupply:   bignumber.NewBig("839310921147858962585526"),
 			maxDepositTVL: bignumber.ZeroBI,
 		}
+		testutil.TestPoolSimulator(t, &poolSimulator)
 
 		params := poolpkg.CalcAmountOutParams{
 			TokenAmountIn: poolpkg.TokenAmount{
@@ -269,6 +269,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, &tc.poolSimulator)
+
 			result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 				return tc.poolSimulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
 					TokenAmountIn: tc.tokenAmountIn,
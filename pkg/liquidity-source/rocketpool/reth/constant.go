@@ -1,5 +1,10 @@
 package reth
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+
+// Redemption of rETH is instant, paid out of the ETH held by rETH and the excess balance of the deposit pool.
+const Redemption = lst.Instant
+
 const (
 	DexType  = "rocketpool-reth"
 	reserves = "10000000000000000000"
@@ -10,6 +10,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 var (
@@ -19,7 +20,7 @@ var (
 	ErrDepositMoreThanMaximum                   = errors.New("the deposit pool size after depositing exceeds the maximum size")
 	ErrZeroNetworkBalance                       = errors.New("cannot calculate rETH token amount while total network balance is zero")
 
-	ErrInsufficientETHBalance = errors.New("insufficient ETH balance for exchange")
+	ErrInsufficientETHBalance = lst.ErrInsufficientBuffer
 )
 
 var calcBase = new(big.Int).Set(bignumber.BONE)
@@ -102,15 +103,29 @@ func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	}
 
 	// rETH -> ETH
+	if err := Redemption.CheckRedeem(); err != nil {
+		return nil, err
+	}
 	return s.burn(param.TokenAmountIn.Amount)
 }
 
 func (s *PoolSimulator) UpdateBalance(param pool.UpdateBalanceParams) {
 	if param.TokenAmountIn.Token == s.Info.Tokens[0] && param.TokenAmountOut.Token == s.Info.Tokens[1] {
-
+		s.balance = new(big.Int).Add(s.balance, param.TokenAmountIn.Amount)
+		s.excessBalance = s.getExcessBalance()
 		return
 	}
 
+	// burns are paid out of the ETH held by rETH first, then out of the excess balance of the deposit pool
+	ethAmount := param.TokenAmountOut.Amount
+	if s.rETHBalance.Cmp(ethAmount) >= 0 {
+		s.rETHBalance = new(big.Int).Sub(s.rETHBalance, ethAmount)
+		return
+	}
+	fromDepositPool := new(big.Int).Sub(ethAmount, s.rETHBalance)
+	s.rETHBalance = new(big.Int)
+	s.excessBalance = lst.Drain(s.excessBalance, fromDepositPool)
+	s.balance = lst.Drain(s.balance, fromDepositPool)
 }
 
 func (s *PoolSimulator) GetMetaInfo(tokenIn, tokenOut string) interface{} {
@@ -176,8 +191,8 @@ func (s *PoolSimulator) burn(amount *big.Int) (*pool.CalcAmountOutResult, error)
 	ethAmount := s.getEthValue(amount)
 	ethBalance := new(big.Int).Add(s.excessBalance, s.rETHBalance)
 
-	if ethBalance.Cmp(ethAmount) < 0 {
-		return nil, ErrInsufficientETHBalance
+	if err := lst.CheckBuffer(ethBalance, ethAmount); err != nil {
+		return nil, err
 	}
 
 	return &pool.CalcAmountOutResult{
@@ -197,3 +212,13 @@ func (s *PoolSimulator) getEthValue(rethAmount *big.Int) *big.Int {
 		s.totalRETHSupply,
 	)
 }
+
+// getExcessBalance is RocketDepositPool.getExcessBalance, the balance of the deposit pool beyond the effective capacity
+// of the minipool queue, which burns can be paid out of
+func (s *PoolSimulator) getExcessBalance() *big.Int {
+	capacity := lo.Ternary(s.effectiveCapacity == nil, bignumber.ZeroBI, s.effectiveCapacity)
+	if capacity.Cmp(s.balance) >= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(s.balance, capacity)
+}
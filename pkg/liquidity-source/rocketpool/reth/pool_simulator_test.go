@@ -8,6 +8,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -72,6 +74,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			if tc.expectedError != nil {
@@ -84,3 +88,94 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_UpdateBalance(t *testing.T) {
+	t.Parallel()
+	s := &PoolSimulator{
+		Pool: poolpkg.Pool{
+			Info: poolpkg.PoolInfo{
+				Tokens: []string{"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0xae78736cd615f374d3085123a210448e74fc6393"},
+			},
+		},
+		balance:         bignumber.NewBig("20000000000000000000"),
+		totalETHBalance: bignumber.NewBig("612577958207564412422016"),
+		totalRETHSupply: bignumber.NewBig("557175055422211468874658"),
+		excessBalance:   bignumber.NewBig("10000000000000000000"),
+		rETHBalance:     bignumber.NewBig("4000000000000000000"),
+	}
+	burn := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{
+			Amount: bignumber.NewBig("8000000000000000000"),
+			Token:  "0xae78736cd615f374d3085123a210448e74fc6393",
+		},
+		TokenOut: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+	}
+
+	result, err := s.CalcAmountOut(burn)
+	assert.NoError(t, err)
+	assert.Equal(t, "8795482888132817700", result.TokenAmountOut.Amount.String())
+
+	s.UpdateBalance(poolpkg.UpdateBalanceParams{
+		TokenAmountIn:  burn.TokenAmountIn,
+		TokenAmountOut: *result.TokenAmountOut,
+	})
+	assert.Zero(t, s.rETHBalance.Sign())
+	assert.Equal(t, "5204517111867182300", s.excessBalance.String())
+	assert.Equal(t, "15204517111867182300", s.balance.String())
+
+	_, err = s.CalcAmountOut(burn)
+	assert.ErrorIs(t, err, ErrInsufficientETHBalance)
+	assert.ErrorIs(t, err, lst.ErrInsufficientBuffer)
+}
+
+func TestPoolSimulator_UpdateBalance_Deposit(t *testing.T) {
+	t.Parallel()
+	s := &PoolSimulator{
+		Pool: poolpkg.Pool{
+			Info: poolpkg.PoolInfo{
+				Tokens: []string{"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", "0xae78736cd615f374d3085123a210448e74fc6393"},
+			},
+		},
+		depositEnabled:         true,
+		minimumDeposit:         bignumber.NewBig("10000000000000000"),
+		maximumDepositPoolSize: bignumber.NewBig("18000000000000000000000"),
+		depositFee:             bignumber.NewBig("500000000000000"),
+		balance:                bignumber.NewBig("20000000000000000000"),
+		effectiveCapacity:      bignumber.NewBig("16000000000000000000"),
+		totalETHBalance:        bignumber.NewBig("612577958207564412422016"),
+		totalRETHSupply:        bignumber.NewBig("557175055422211468874658"),
+		excessBalance:          bignumber.NewBig("4000000000000000000"),
+		rETHBalance:            bignumber.NewBig("1000000000000000000"),
+	}
+	deposit := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{
+			Amount: bignumber.NewBig("10000000000000000000"),
+			Token:  "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+		},
+		TokenOut: "0xae78736cd615f374d3085123a210448e74fc6393",
+	}
+	burn := poolpkg.CalcAmountOutParams{
+		TokenAmountIn: poolpkg.TokenAmount{
+			Amount: bignumber.NewBig("8000000000000000000"),
+			Token:  "0xae78736cd615f374d3085123a210448e74fc6393",
+		},
+		TokenOut: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+	}
+
+	_, err := s.CalcAmountOut(burn)
+	assert.ErrorIs(t, err, ErrInsufficientETHBalance)
+
+	result, err := s.CalcAmountOut(deposit)
+	assert.NoError(t, err)
+	s.UpdateBalance(poolpkg.UpdateBalanceParams{
+		TokenAmountIn:  deposit.TokenAmountIn,
+		TokenAmountOut: *result.TokenAmountOut,
+	})
+	assert.Equal(t, "30000000000000000000", s.balance.String())
+	assert.Equal(t, "14000000000000000000", s.excessBalance.String())
+
+	// the deposit is in the excess balance the next burn is paid out of
+	result, err = s.CalcAmountOut(burn)
+	assert.NoError(t, err)
+	assert.Equal(t, "8795482888132817700", result.TokenAmountOut.Amount.String())
+}
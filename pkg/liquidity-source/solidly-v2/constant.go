@@ -18,6 +18,7 @@ const (
 	factoryMethodAllPairsLength = "allPairsLength"
 	factoryMethodStableFees     = "stableFees"
 	factoryMethodVolatileFees   = "volatileFees"
+	factoryEventPairCreated     = "PairCreated"
 
 	poolMethodMetadata    = "metadata"
 	poolMethodGetReserves = "getReserves"
@@ -0,0 +1,165 @@
+package solidlyv2
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	velodromev2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/velodrome-v2"
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// PoolsListDecoder discovers pools from the PairCreated logs of the factory, fetching only the fees of the factory and
+// the decimals of their tokens.
+type PoolsListDecoder struct {
+	config       *Config
+	ethrpcClient *ethrpc.Client
+	updater      *PoolsListUpdater
+}
+
+// PairCreatedEvent is the PairCreated event of the factory
+type PairCreatedEvent struct {
+	Token0 common.Address
+	Token1 common.Address
+	Stable bool
+	Pair   common.Address
+	Arg4   *big.Int
+}
+
+var _ = pooldecode.RegisterListFactoryCE(DexType, NewPoolsListDecoder)
+
+func NewPoolsListDecoder(cfg *Config, ethrpcClient *ethrpc.Client) *PoolsListDecoder {
+	return &PoolsListDecoder{
+		config:       cfg,
+		ethrpcClient: ethrpcClient,
+		updater:      NewPoolsListUpdater(cfg, ethrpcClient),
+	}
+}
+
+func (d *PoolsListDecoder) GetKeys(_ context.Context) ([]string, error) {
+	return []string{strings.ToLower(d.config.FactoryAddress)}, nil
+}
+
+func (d *PoolsListDecoder) Decode(_ context.Context, logs []types.Log) (map[string][]types.Log, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil {
+		return nil, err
+	}
+	addressLogs := make(map[string][]types.Log, len(events))
+	for i, event := range events {
+		pair := strings.ToLower(event.Pair.Hex())
+		addressLogs[pair] = append(addressLogs[pair], filtered[i])
+	}
+	return addressLogs, nil
+}
+
+func (d *PoolsListDecoder) DecodeNewPools(ctx context.Context, logs []types.Log) ([]entity.Pool, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	switch d.config.DexID {
+	case string(valueobject.ExchangeMemeBox), string(valueobject.ExchangeShadowLegacy):
+		// these pools have their own fees and layouts, so they are fetched like the pools list updater does
+		return d.updater.initPools(ctx, lo.Map(events, func(event PairCreatedEvent, _ int) common.Address {
+			return event.Pair
+		}), velodromev2.PoolFactoryData{})
+	}
+
+	stableFee, volatileFee, err := d.getFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]string, 0, 2*len(events))
+	for _, event := range events {
+		tokens = append(tokens, event.Token0.Hex(), event.Token1.Hex())
+	}
+	decimals, err := pooldecode.FetchDecimals(ctx, d.ethrpcClient, lo.Uniq(tokens))
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]entity.Pool, 0, len(events))
+	for i, event := range events {
+		address := strings.ToLower(event.Pair.Hex())
+		token0, token1 := strings.ToLower(event.Token0.Hex()), strings.ToLower(event.Token1.Hex())
+		if !pooldecode.HasDecimals(decimals, token0, token1) {
+			logger.WithFields(logger.Fields{"dexId": d.config.DexID, "pool": address}).
+				Warn("skip pool of tokens without decimals")
+			continue
+		}
+		extraBytes, err := d.updater.newExtra(false, lo.Ternary(event.Stable, stableFee, volatileFee))
+		if err != nil {
+			return nil, err
+		}
+		staticExtraBytes, err := json.Marshal(velodromev2.PoolStaticExtra{
+			FeePrecision: d.config.FeePrecision,
+			Decimal0:     new(uint256.Int).Exp(uint256.NewInt(10), uint256.NewInt(uint64(decimals[token0]))),
+			Decimal1:     new(uint256.Int).Exp(uint256.NewInt(10), uint256.NewInt(uint64(decimals[token1]))),
+			Stable:       event.Stable,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, entity.Pool{
+			Address:     address,
+			Exchange:    d.config.DexID,
+			Type:        DexType,
+			Timestamp:   time.Now().Unix(),
+			BlockNumber: filtered[i].BlockNumber,
+			Reserves:    []string{"0", "0"},
+			Tokens: []*entity.PoolToken{
+				{Address: token0, Swappable: true},
+				{Address: token1, Swappable: true},
+			},
+			Extra:       string(extraBytes),
+			StaticExtra: string(staticExtraBytes),
+		})
+	}
+	return pools, nil
+}
+
+// getFees fetches the fees of stable and volatile pools from the factory
+func (d *PoolsListDecoder) getFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	var stableFee, volatileFee *big.Int
+	req := d.ethrpcClient.NewRequest().SetContext(ctx)
+	req.AddCall(&ethrpc.Call{
+		ABI:    factoryABI,
+		Target: d.config.FactoryAddress,
+		Method: factoryMethodStableFees,
+	}, []any{&stableFee})
+	req.AddCall(&ethrpc.Call{
+		ABI:    factoryABI,
+		Target: d.config.FactoryAddress,
+		Method: factoryMethodVolatileFees,
+	}, []any{&volatileFee})
+	if _, err := req.Aggregate(); err != nil {
+		return nil, nil, err
+	}
+	return stableFee, volatileFee, nil
+}
+
+// decodeEvents returns the PairCreated events of the factory among logs along with their logs
+func (d *PoolsListDecoder) decodeEvents(logs []types.Log) ([]PairCreatedEvent, []types.Log, error) {
+	filtered := pooldecode.FilterLogs(logs, d.config.FactoryAddress, factoryABI.Events[factoryEventPairCreated].ID)
+	events := make([]PairCreatedEvent, len(filtered))
+	for i, log := range filtered {
+		if err := pooldecode.UnpackLog(factoryABI, factoryEventPairCreated, &events[i], log); err != nil {
+			return nil, nil, err
+		}
+	}
+	return events, filtered, nil
+}
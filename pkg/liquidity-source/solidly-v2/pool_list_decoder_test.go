@@ -0,0 +1,96 @@
+package solidlyv2
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	velodromev2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/velodrome-v2"
+	abipkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/abi"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolsListDecoder(t *testing.T) {
+	var (
+		factory = common.HexToAddress("0x777de5Fe8117cAAA7B44f396E93a401Cf5c9D4d6")
+		usdc    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		weth    = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+		broken  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		pair    = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		skipped = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	)
+	event := factoryABI.Events[factoryEventPairCreated]
+	newLog := func(token0, token1, pair common.Address, stable bool) types.Log {
+		data, err := event.Inputs.NonIndexed().Pack(stable, pair, big.NewInt(1))
+		require.NoError(t, err)
+		return types.Log{
+			Address:     factory,
+			Topics:      []common.Hash{event.ID, common.BytesToHash(token0[:]), common.BytesToHash(token1[:])},
+			Data:        data,
+			BlockNumber: 17000000,
+		}
+	}
+	logs := []types.Log{
+		newLog(usdc, weth, pair, false),
+		newLog(broken, weth, skipped, true),
+		{Address: pair, Topics: []common.Hash{event.ID}},
+	}
+
+	ethrpcClient := testutil.NewEthrpcClient(t, func(target common.Address, data []byte) ([]byte, bool) {
+		var out []byte
+		var err error
+		switch {
+		case target == factory && string(data[:4]) == string(factoryABI.Methods[factoryMethodStableFees].ID):
+			out, err = factoryABI.Methods[factoryMethodStableFees].Outputs.Pack(big.NewInt(4))
+		case target == factory && string(data[:4]) == string(factoryABI.Methods[factoryMethodVolatileFees].ID):
+			out, err = factoryABI.Methods[factoryMethodVolatileFees].Outputs.Pack(big.NewInt(30))
+		case target == usdc:
+			out, err = abipkg.Erc20ABI.Methods[abipkg.Erc20DecimalsMethod].Outputs.Pack(uint8(6))
+		case target == weth:
+			out, err = abipkg.Erc20ABI.Methods[abipkg.Erc20DecimalsMethod].Outputs.Pack(uint8(18))
+		default:
+			return nil, false
+		}
+		require.NoError(t, err)
+		return out, true
+	})
+	d := NewPoolsListDecoder(&Config{
+		DexID:          "solidly-v2",
+		FeePrecision:   10000,
+		FactoryAddress: factory.Hex(),
+	}, ethrpcClient)
+
+	addressLogs, err := d.Decode(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]types.Log{
+		"0x00000000000000000000000000000000000000aa": logs[:1],
+		"0x00000000000000000000000000000000000000cc": logs[1:2],
+	}, addressLogs)
+
+	// the pool of the token without decimals is skipped rather than given guessed decimals
+	pools, err := d.DecodeNewPools(context.Background(), logs)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, "0x00000000000000000000000000000000000000aa", pools[0].Address)
+	assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", pools[0].Tokens[0].Address)
+	assert.Equal(t, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", pools[0].Tokens[1].Address)
+	assert.EqualValues(t, 17000000, pools[0].BlockNumber)
+
+	var extra velodromev2.PoolExtra
+	require.NoError(t, json.Unmarshal([]byte(pools[0].Extra), &extra))
+	assert.EqualValues(t, 30, extra.Fee)
+	var staticExtra velodromev2.PoolStaticExtra
+	require.NoError(t, json.Unmarshal([]byte(pools[0].StaticExtra), &staticExtra))
+	assert.Equal(t, velodromev2.PoolStaticExtra{
+		FeePrecision: 10000,
+		Decimal0:     uint256.NewInt(1e6),
+		Decimal1:     uint256.NewInt(1e18),
+	}, staticExtra)
+}
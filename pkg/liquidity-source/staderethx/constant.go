@@ -2,11 +2,16 @@ package staderethx
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 const (
 	DexType = "staderethx"
 
+	// Redemption of ETHx is queue-only, through the user withdrawal manager of Stader.
+	Redemption = lst.QueueOnly
+
 	staderStakePoolsManager = "0xcf5ea1b38380f6af39068375516daf40ed70d299"
 	staderOracle            = "0xf64bae65f6f2a5277571143a24faafdfc0c2a737"
 
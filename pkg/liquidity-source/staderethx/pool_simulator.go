@@ -52,6 +52,12 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 }
 
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if params.TokenAmountIn.Token == s.Info.Tokens[1] && params.TokenOut == s.Info.Tokens[0] {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.paused {
 		return nil, ErrPoolPaused
 	}
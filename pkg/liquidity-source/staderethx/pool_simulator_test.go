@@ -9,6 +9,8 @@ import (
 
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 )
 
 func TestPoolSimulator_CalcAmountOut(t *testing.T) {
@@ -117,7 +119,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 			expectedError: ErrInvalidDepositAmount,
 		},
 		{
-			name: "it should return ErrInvalidTokenIn",
+			name: "it should return ErrQueueOnly when redeeming ETHx",
 			poolSimulator: &PoolSimulator{
 				Pool: poolpkg.Pool{
 					Info: poolpkg.PoolInfo{
@@ -138,7 +140,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 				},
 				TokenOut: WETH,
 			},
-			expectedError: ErrInvalidTokenIn,
+			expectedError: lst.ErrQueueOnly,
 		},
 		{
 			name: "it should return ErrInvalidTokenOut",
@@ -168,6 +170,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, tc.poolSimulator)
+
 			result, err := tc.poolSimulator.CalcAmountOut(tc.param)
 
 			assert.Equal(t, tc.poolSimulator.CanSwapTo(tc.poolSimulator.Info.Tokens[0]), []string{})
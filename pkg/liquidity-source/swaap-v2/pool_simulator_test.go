@@ -77,6 +77,7 @@ func TestCalcAmountOut(t *testing.T) {
 		// calculation
 		simulator, err := NewPoolSimulator(pool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 		amountIn, _ := new(big.Int).SetString("334332318571851640", 10)
 		result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 			return simulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
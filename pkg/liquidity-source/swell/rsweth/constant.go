@@ -2,10 +2,15 @@ package rsweth
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 const (
 	DexType = "swell-rsweth"
+
+	// Redemption of rswETH is queue-only, through the withdrawal queue of Swell.
+	Redemption = lst.QueueOnly
 )
 
 const (
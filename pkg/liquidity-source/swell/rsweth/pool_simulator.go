@@ -47,6 +47,12 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 }
 
 func (s *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if param.TokenAmountIn.Token == s.Pool.Info.Tokens[1] && param.TokenOut == s.Pool.Info.Tokens[0] {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
+
 	// NOTE: only support tokenIn is WETH and tokenOut is rswETH
 	if param.TokenAmountIn.Token != s.Pool.Info.Tokens[0] || param.TokenOut != s.Pool.Info.Tokens[1] {
 		return nil, ErrUnsupportedSwap
@@ -2,10 +2,15 @@ package sweth
 
 import (
 	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
 )
 
 const (
 	DexType = "swell-sweth"
+
+	// Redemption of swETH is queue-only, through the withdrawal queue of Swell.
+	Redemption = lst.QueueOnly
 )
 
 const (
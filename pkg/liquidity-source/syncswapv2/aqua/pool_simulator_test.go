@@ -33,6 +33,7 @@ func TestCalcAmountOut(t *testing.T) {
 		Extra:    "{\"swapFee0To1Min\":800,\"swapFee0To1Max\":1000,\"swapFee0To1Gamma\":230000000000000,\"swapFee1To0Min\":800,\"swapFee1To0Max\":1000,\"swapFee1To0Gamma\":230000000000000,\"token0PrecisionMultiplier\":1,\"token1PrecisionMultiplier\":1000000000,\"vaultAddress\":\"0x621425a1Ef6abE91058E9712575dcc4258F8d091\",\"priceScale\":54451990779514461,\"a\":4000000,\"d\":18973521177677971086,\"gamma\":1450000000000000,\"futureTime\":1709616182,\"lastPrices\":49576568810461066,\"priceOracle\":49663786937733135,\"lastPricesTimestamp\":1716279641,\"lpSupply\":37758794556622160853,\"xcpProfit\":1152938294335819254,\"virtualPrice\":1076695600534779561,\"allowedExtraProfit\":2000000000000,\"adjustmentStep\":146000000000000,\"maHalfTime\":600}",
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, 0, len(p.CanSwapTo("LP")))
 	assert.Equal(t, []string{"B"}, p.CanSwapTo("A"))
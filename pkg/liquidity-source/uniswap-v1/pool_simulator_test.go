@@ -71,6 +71,8 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, &tc.poolSimulator)
+
 			result, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 				return tc.poolSimulator.CalcAmountOut(pool.CalcAmountOutParams{
 					TokenAmountIn: tc.tokenAmountIn,
@@ -87,3 +89,30 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolSimulator_CloneState(t *testing.T) {
+	t.Parallel()
+	usdc, usdt := "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	poolSimulator := &PoolSimulator{
+		Pool: pool.Pool{Info: pool.PoolInfo{Tokens: []string{usdc, usdt}}},
+		reserves: []*uint256.Int{uint256.MustFromDecimal("10089138480746"),
+			uint256.MustFromDecimal("10066716097576")},
+	}
+	calcAmountOut := func(poolSim pool.IPoolSimulator) *big.Int {
+		result, err := poolSim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: usdc, Amount: bignumber.NewBig("125224746")},
+			TokenOut:      usdt,
+		})
+		assert.NoError(t, err)
+		return result.TokenAmountOut.Amount
+	}
+
+	cloned := poolSimulator.CloneState()
+	cloned.UpdateBalance(pool.UpdateBalanceParams{
+		TokenAmountIn:  pool.TokenAmount{Token: usdc, Amount: bignumber.NewBig("1000000000000")},
+		TokenAmountOut: pool.TokenAmount{Token: usdt, Amount: bignumber.NewBig("900000000000")},
+	})
+
+	assert.Equal(t, bignumber.NewBig("124570062"), calcAmountOut(poolSimulator))
+	assert.NotEqual(t, bignumber.NewBig("124570062"), calcAmountOut(cloned))
+}
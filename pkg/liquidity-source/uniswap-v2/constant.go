@@ -10,9 +10,12 @@ const (
 	DexType = "uniswap-v2"
 
 	defaultGas = 76562
+	// calldataSize is 6 words: pool, recipient, amount, tokenIn, fee and fee precision
+	calldataSize = 6 * 32
 
 	factoryMethodGetPair        = "allPairs"
 	factoryMethodAllPairsLength = "allPairsLength"
+	factoryEventPairCreated     = "PairCreated"
 
 	pairMethodToken0      = "token0"
 	pairMethodToken1      = "token1"
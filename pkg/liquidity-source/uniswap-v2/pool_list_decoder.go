@@ -0,0 +1,102 @@
+package uniswapv2
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+)
+
+// PoolsListDecoder discovers pairs from the PairCreated logs of the factory, which have all the fields of new pairs.
+type PoolsListDecoder struct {
+	config       *Config
+	ethrpcClient *ethrpc.Client
+}
+
+// PairCreatedEvent is the PairCreated event of the factory
+type PairCreatedEvent struct {
+	Token0 common.Address
+	Token1 common.Address
+	Pair   common.Address
+	Arg3   *big.Int
+}
+
+var _ = pooldecode.RegisterListFactoryCE(DexType, NewPoolsListDecoder)
+
+func NewPoolsListDecoder(cfg *Config, ethrpcClient *ethrpc.Client) *PoolsListDecoder {
+	return &PoolsListDecoder{
+		config:       cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+func (d *PoolsListDecoder) GetKeys(_ context.Context) ([]string, error) {
+	return []string{strings.ToLower(d.config.FactoryAddress)}, nil
+}
+
+func (d *PoolsListDecoder) Decode(_ context.Context, logs []types.Log) (map[string][]types.Log, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil {
+		return nil, err
+	}
+	addressLogs := make(map[string][]types.Log, len(events))
+	for i, event := range events {
+		pair := strings.ToLower(event.Pair.Hex())
+		addressLogs[pair] = append(addressLogs[pair], filtered[i])
+	}
+	return addressLogs, nil
+}
+
+func (d *PoolsListDecoder) DecodeNewPools(_ context.Context, logs []types.Log) ([]entity.Pool, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	extra, err := json.Marshal(Extra{
+		Fee:          d.config.Fee,
+		FeePrecision: d.config.FeePrecision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]entity.Pool, 0, len(events))
+	for i, event := range events {
+		pools = append(pools, entity.Pool{
+			Address:     strings.ToLower(event.Pair.Hex()),
+			Exchange:    d.config.DexID,
+			Type:        DexType,
+			Timestamp:   time.Now().Unix(),
+			BlockNumber: filtered[i].BlockNumber,
+			Reserves:    []string{"0", "0"},
+			Tokens: []*entity.PoolToken{
+				{Address: strings.ToLower(event.Token0.Hex()), Swappable: true},
+				{Address: strings.ToLower(event.Token1.Hex()), Swappable: true},
+			},
+			Extra: string(extra),
+		})
+	}
+	return pools, nil
+}
+
+// decodeEvents returns the PairCreated events of the factory among logs along with their logs
+func (d *PoolsListDecoder) decodeEvents(logs []types.Log) ([]PairCreatedEvent, []types.Log, error) {
+	filtered := pooldecode.FilterLogs(logs, d.config.FactoryAddress,
+		uniswapV2FactoryABI.Events[factoryEventPairCreated].ID)
+	events := make([]PairCreatedEvent, len(filtered))
+	for i, log := range filtered {
+		if err := pooldecode.UnpackLog(uniswapV2FactoryABI, factoryEventPairCreated, &events[i], log); err != nil {
+			return nil, nil, err
+		}
+	}
+	return events, filtered, nil
+}
@@ -0,0 +1,61 @@
+package uniswapv2
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolsListDecoder(t *testing.T) {
+	var (
+		factory = common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+		token0  = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		token1  = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+		pair    = common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	)
+	event := uniswapV2FactoryABI.Events[factoryEventPairCreated]
+	data, err := event.Inputs.NonIndexed().Pack(pair, big.NewInt(1))
+	require.NoError(t, err)
+	logs := []types.Log{
+		{
+			Address:     factory,
+			Topics:      []common.Hash{event.ID, common.BytesToHash(token0[:]), common.BytesToHash(token1[:])},
+			Data:        data,
+			BlockNumber: 10008355,
+		},
+		{Address: pair, Topics: []common.Hash{uniswapV2PairABI.Events["Sync"].ID}},
+	}
+
+	d := NewPoolsListDecoder(&Config{
+		DexID:          "uniswap-v2",
+		FactoryAddress: factory.Hex(),
+		Fee:            3,
+		FeePrecision:   1000,
+	}, nil)
+
+	keys, err := d.GetKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f"}, keys)
+
+	addressLogs, err := d.Decode(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]types.Log{"0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc": logs[:1]}, addressLogs)
+
+	pools, err := d.DecodeNewPools(context.Background(), logs)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc", pools[0].Address)
+	assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", pools[0].Tokens[0].Address)
+	assert.Equal(t, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", pools[0].Tokens[1].Address)
+	assert.EqualValues(t, 10008355, pools[0].BlockNumber)
+	assert.JSONEq(t, `{"fee":3,"feePrecision":1000}`, pools[0].Extra)
+
+	sim, err := NewPoolSimulator(pools[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{pools[0].Tokens[0].Address, pools[0].Tokens[1].Address}, sim.GetTokens())
+}
@@ -145,6 +145,10 @@ func (s *PoolSimulator) GetMetaInfo(_ string, _ string) interface{} {
 	}
 }
 
+func (s *PoolSimulator) GetCalldataSize(_, _ string) int {
+	return calldataSize
+}
+
 func (s *PoolSimulator) getAmountOut(amountIn, reserveIn, reserveOut *uint256.Int) *uint256.Int {
 	var numerator, denominator uint256.Int
 	amountInWithFee := numerator.Mul(amountIn, numerator.Sub(s.feePrecision, s.fee))
@@ -469,3 +469,8 @@ func BenchmarkPoolSimulatorCalcAmountIn(b *testing.B) {
 		})
 	}
 }
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, poolSim)
+}
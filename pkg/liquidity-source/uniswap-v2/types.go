@@ -25,3 +25,15 @@ type PoolMetaGeneric struct {
 	ApprovalAddress string `json:"approvalAddress,omitempty"`
 	NoFOT           bool   `json:"noFOT,omitempty"`
 }
+
+// SupportsTaxedTokens returns whether the pool can swap fee-on-transfer tokens, which pairs do with the router
+// functions supporting them by swapping the balance received
+func (m PoolMetaGeneric) SupportsTaxedTokens() bool {
+	return !m.NoFOT
+}
+
+// SupportsRebasingTokens returns false as pairs cache their reserves, so that rebases break their invariant until
+// they are synced
+func (m PoolMetaGeneric) SupportsRebasingTokens() bool {
+	return false
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
-var stateViewABI abi.ABI
+var (
+	stateViewABI   abi.ABI
+	poolManagerABI abi.ABI
+)
 
 func init() {
 	builder := []struct {
@@ -14,6 +17,7 @@ func init() {
 		data []byte
 	}{
 		{&stateViewABI, stateViewABIJson},
+		{&poolManagerABI, poolManagerABIJson},
 	}
 
 	for _, b := range builder {
@@ -8,6 +8,7 @@ type Config struct {
 	Permit2Address         string `json:"permit2Address"`
 	Multicall3Address      string `json:"multicall3Address"`
 	StateViewAddress       string `json:"stateViewAddress"`
+	PoolManagerAddress     string `json:"poolManagerAddress"` // to discover pools from its logs
 	NewPoolLimit           int    `json:"newPoolLimit"`
 	AllowSubgraphError     bool   `json:"allowSubgraphError"`
 
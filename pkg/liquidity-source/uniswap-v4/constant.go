@@ -16,6 +16,11 @@ const (
 	graphFirstLimit = 1000
 
 	maxChangedTicks = 10
+
+	poolManagerEventInitialize      = "Initialize"
+	poolManagerEventSwap            = "Swap"
+	poolManagerEventModifyLiquidity = "ModifyLiquidity"
+	poolManagerEventDonate          = "Donate"
 )
 
 var (
@@ -0,0 +1,143 @@
+package uniswapv4
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// PoolsListDecoder discovers pools from the Initialize logs of the PoolManager, fetching only the decimals of their
+// tokens, and skipping the pools of tokens whose decimals can not be fetched. As the PoolManager emits the logs of all pools, it also groups their Swap, ModifyLiquidity and Donate logs.
+type PoolsListDecoder struct {
+	config       *Config
+	ethrpcClient *ethrpc.Client
+}
+
+// InitializeEvent is the Initialize event of the PoolManager
+type InitializeEvent struct {
+	Id           common.Hash
+	Currency0    common.Address
+	Currency1    common.Address
+	Fee          *big.Int
+	TickSpacing  *big.Int
+	Hooks        common.Address
+	SqrtPriceX96 *big.Int
+	Tick         *big.Int
+}
+
+var _ = pooldecode.RegisterListFactoryCE(DexType, NewPoolsListDecoder)
+
+func NewPoolsListDecoder(cfg *Config, ethrpcClient *ethrpc.Client) *PoolsListDecoder {
+	return &PoolsListDecoder{
+		config:       cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+func (d *PoolsListDecoder) GetKeys(_ context.Context) ([]string, error) {
+	return []string{strings.ToLower(d.config.PoolManagerAddress)}, nil
+}
+
+func (d *PoolsListDecoder) Decode(_ context.Context, logs []types.Log) (map[string][]types.Log, error) {
+	addressLogs := make(map[string][]types.Log)
+	for _, log := range pooldecode.FilterLogs(logs, d.config.PoolManagerAddress,
+		poolManagerABI.Events[poolManagerEventInitialize].ID,
+		poolManagerABI.Events[poolManagerEventSwap].ID,
+		poolManagerABI.Events[poolManagerEventModifyLiquidity].ID,
+		poolManagerABI.Events[poolManagerEventDonate].ID,
+	) {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		poolId := log.Topics[1].Hex() // all these events have the pool id in topic1
+		addressLogs[poolId] = append(addressLogs[poolId], log)
+	}
+	return addressLogs, nil
+}
+
+func (d *PoolsListDecoder) DecodeNewPools(ctx context.Context, logs []types.Log) ([]entity.Pool, error) {
+	filtered := pooldecode.FilterLogs(logs, d.config.PoolManagerAddress,
+		poolManagerABI.Events[poolManagerEventInitialize].ID)
+	if len(filtered) == 0 {
+		return nil, nil
+	}
+	events := make([]InitializeEvent, len(filtered))
+	var currencies []string
+	for i, log := range filtered {
+		if err := pooldecode.UnpackLog(poolManagerABI, poolManagerEventInitialize, &events[i], log); err != nil {
+			return nil, err
+		}
+		for _, currency := range []common.Address{events[i].Currency0, events[i].Currency1} {
+			if currency != NativeTokenAddress {
+				currencies = append(currencies, currency.Hex())
+			}
+		}
+	}
+	decimals, err := pooldecode.FetchDecimals(ctx, d.ethrpcClient, lo.Uniq(currencies))
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedNative := strings.ToLower(valueobject.WrappedNativeMap[valueobject.ChainID(d.config.ChainID)])
+	pools := make([]entity.Pool, 0, len(events))
+	for i, event := range events {
+		isNative := [2]bool{event.Currency0 == NativeTokenAddress, event.Currency1 == NativeTokenAddress}
+		tokens := make([]*entity.PoolToken, 2)
+		hasDecimals := true
+		for j, currency := range []common.Address{event.Currency0, event.Currency1} {
+			if isNative[j] {
+				tokens[j] = &entity.PoolToken{Address: wrappedNative, Decimals: 18, Swappable: true}
+				continue
+			}
+			token := strings.ToLower(currency.Hex())
+			tokens[j] = &entity.PoolToken{Address: token, Decimals: decimals[token], Swappable: true}
+			hasDecimals = hasDecimals && pooldecode.HasDecimals(decimals, token)
+		}
+		if !hasDecimals {
+			logger.WithFields(logger.Fields{"dexId": d.config.DexID, "pool": event.Id.Hex()}).
+				Warn("skip pool of tokens without decimals")
+			continue
+		}
+
+		fee := uint32(event.Fee.Uint64())
+		staticExtraBytes, err := json.Marshal(StaticExtra{
+			IsNative:               isNative,
+			Fee:                    fee,
+			TickSpacing:            int32(event.TickSpacing.Int64()),
+			HooksAddress:           event.Hooks,
+			UniversalRouterAddress: common.HexToAddress(d.config.UniversalRouterAddress),
+			Permit2Address:         common.HexToAddress(d.config.Permit2Address),
+			Multicall3Address:      common.HexToAddress(d.config.Multicall3Address),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		hook, _ := GetHook(event.Hooks)
+		pools = append(pools, entity.Pool{
+			Address:     event.Id.Hex(),
+			SwapFee:     float64(fee),
+			Exchange:    hook.GetExchange(),
+			Type:        DexType,
+			Timestamp:   time.Now().Unix(),
+			BlockNumber: filtered[i].BlockNumber,
+			Reserves:    entity.PoolReserves{"0", "0"},
+			Tokens:      tokens,
+			Extra:       "{}",
+			StaticExtra: string(staticExtraBytes),
+		})
+	}
+	return pools, nil
+}
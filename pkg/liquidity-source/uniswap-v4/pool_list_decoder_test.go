@@ -0,0 +1,75 @@
+package uniswapv4
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+	abipkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/abi"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolsListDecoder_Decode(t *testing.T) {
+	var (
+		poolManager = common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90")
+		poolId      = common.HexToHash("0x21c67e77068de97969ba93d4aab21826d33ca12bb9f565d8496e8fda8a82ca27")
+		currency1   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		sender      = common.HexToAddress("0x66a9893cC07D91D95644AEDD05D03f95e1dBA8Af")
+	)
+	initialize := poolManagerABI.Events[poolManagerEventInitialize]
+	initializeData, err := initialize.Inputs.NonIndexed().Pack(big.NewInt(500), big.NewInt(10), common.Address{},
+		new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(-200000))
+	require.NoError(t, err)
+	swap := poolManagerABI.Events[poolManagerEventSwap]
+	logs := []types.Log{
+		{
+			Address: poolManager,
+			Topics: []common.Hash{initialize.ID, poolId, common.BytesToHash(NativeTokenAddress[:]),
+				common.BytesToHash(currency1[:])},
+			Data: initializeData,
+		},
+		{Address: poolManager, Topics: []common.Hash{swap.ID, poolId, common.BytesToHash(sender[:])}},
+		{Address: sender, Topics: []common.Hash{swap.ID, poolId, common.BytesToHash(sender[:])}},
+	}
+
+	d := NewPoolsListDecoder(&Config{PoolManagerAddress: poolManager.Hex()}, nil)
+	addressLogs, err := d.Decode(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]types.Log{poolId.Hex(): logs[:2]}, addressLogs)
+
+	var event InitializeEvent
+	require.NoError(t, pooldecode.UnpackLog(poolManagerABI, poolManagerEventInitialize, &event, logs[0]))
+	assert.Equal(t, poolId, event.Id)
+	assert.Equal(t, NativeTokenAddress, event.Currency0)
+	assert.Equal(t, currency1, event.Currency1)
+	assert.EqualValues(t, 500, event.Fee.Int64())
+	assert.EqualValues(t, 10, event.TickSpacing.Int64())
+	assert.EqualValues(t, -200000, event.Tick.Int64())
+
+	// the pool of the currency without decimals is skipped rather than given no decimals
+	broken := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	skipped := logs[0]
+	skipped.Topics = []common.Hash{initialize.ID, common.HexToHash("0xcc"), common.BytesToHash(NativeTokenAddress[:]),
+		common.BytesToHash(broken[:])}
+	d = NewPoolsListDecoder(&Config{ChainID: 1, PoolManagerAddress: poolManager.Hex()},
+		testutil.NewEthrpcClient(t, func(target common.Address, _ []byte) ([]byte, bool) {
+			if target != currency1 {
+				return nil, false
+			}
+			data, err := abipkg.Erc20ABI.Methods[abipkg.Erc20DecimalsMethod].Outputs.Pack(uint8(6))
+			require.NoError(t, err)
+			return data, true
+		}))
+	pools, err := d.DecodeNewPools(context.Background(), []types.Log{logs[0], skipped})
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, poolId.Hex(), pools[0].Address)
+	assert.EqualValues(t, 18, pools[0].Tokens[0].Decimals)
+	assert.EqualValues(t, 6, pools[0].Tokens[1].Decimals)
+}
@@ -16,6 +16,9 @@ import (
 
 var (
 	defaultGas = uniswapv3.Gas{BaseGas: 75000, CrossInitTickGas: 21000}
+	// defaultCalldataSize is 12 words: the pool key (5 words), router, permit2, price limit, amount, recipient and
+	// the hook data offset and length
+	defaultCalldataSize = 12 * 32
 )
 
 type PoolSimulator struct {
@@ -65,6 +68,10 @@ func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
 	return &cloned
 }
 
+func (p *PoolSimulator) GetCalldataSize(_, _ string) int {
+	return defaultCalldataSize
+}
+
 // GetMetaInfo
 // adapt from https://github.com/KyberNetwork/kyberswap-dex-lib-private/blob/c1877a8c19759faeb7d82b6902ed335f0657ce3e/pkg/liquidity-source/uniswap-v4/pool_simulator.go#L201
 func (p *PoolSimulator) GetMetaInfo(tokenIn string, tokenOut string) interface{} {
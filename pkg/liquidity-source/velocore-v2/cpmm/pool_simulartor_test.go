@@ -36,6 +36,7 @@ func TestCalcAmountOut(t *testing.T) {
 
 		simulator, err := NewPoolSimulator(entityPool)
 		assert.Nil(t, err)
+		testutil.TestPoolSimulator(t, simulator)
 
 		result, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 			return simulator.CalcAmountOut(pool.CalcAmountOutParams{
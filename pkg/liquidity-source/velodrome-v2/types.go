@@ -25,6 +25,16 @@ type PoolMeta struct {
 	BlockNumber  uint64 `json:"blockNumber"`
 }
 
+// SupportsTaxedTokens returns true as volatile and stable pools both swap the balance received over their reserves
+func (PoolMeta) SupportsTaxedTokens() bool {
+	return true
+}
+
+// SupportsRebasingTokens returns false as pools price swaps off reserves that only sync on interactions
+func (PoolMeta) SupportsRebasingTokens() bool {
+	return false
+}
+
 type PoolMetadata struct {
 	Dec0 *big.Int
 	Dec1 *big.Int
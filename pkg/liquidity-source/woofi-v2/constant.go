@@ -18,8 +18,6 @@ const (
 	wooracleMethodBound         = "bound"
 	wooracleMethodStaleDuration = "staleDuration"
 
-	cloracleMethodLatestRoundData = "latestRoundData"
-
 	erc20MethodDecimals = "decimals"
 
 	zeroString = "0"
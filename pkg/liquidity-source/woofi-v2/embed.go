@@ -11,8 +11,5 @@ var IntegrationHelperABIBytes []byte
 //go:embed abi/WooracleV2.json
 var WooracleV2ABIBytes []byte
 
-//go:embed abi/Cloracle.json
-var CloracleABIBytes []byte
-
 //go:embed abi/ERC20.json
 var Erc20ABIBytes []byte
@@ -12,6 +12,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 var (
@@ -28,6 +29,9 @@ var (
 )
 
 type (
+	// PoolSimulator quotes WooPPV2 swaps at the WooracleV2 state, bounded by the Chainlink prices of the tokens. The
+	// oracle state is posted on chain by WOO's keepers and the swap takes no price data, so the pool can't be priced
+	// with pulloracle reports.
 	PoolSimulator struct {
 		pool.Pool
 		quoteToken string
@@ -35,6 +39,7 @@ type (
 		decimals   map[string]uint8
 		wooracle   Wooracle
 		cloracle   map[string]Cloracle
+		sequencer  *oracle.Sequencer
 
 		gas Gas
 	}
@@ -88,12 +93,17 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		decimals:   decimals,
 		wooracle:   extra.Wooracle,
 		cloracle:   extra.Cloracle,
+		sequencer:  extra.Sequencer,
 
 		gas: DefaultGas,
 	}, nil
 }
 
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	if err := s.sequencer.Check(uint64(time.Now().Unix())); err != nil {
+		return nil, err
+	}
+
 	tokenAmountIn := params.TokenAmountIn
 	tokenOut := params.TokenOut
 	tokenInIndex := s.GetTokenIndex(tokenAmountIn.Token)
@@ -490,24 +500,9 @@ func (s *PoolSimulator) _wooracleV2Price(base string) (*uint256.Int, bool) {
 
 	cloPrice, _ := s._wooracleCloPriceInQuote(base, s.quoteToken)
 
-	woFeasible := !woPrice.Eq(number.Zero) && time.Now().Unix() <= s.wooracle.Timestamp+s.wooracle.StaleDuration
-
-	bound := uint256.NewInt(s.wooracle.Bound)
-	priceLowerBound := new(uint256.Int).Div(
-		new(uint256.Int).Mul(
-			cloPrice,
-			new(uint256.Int).Sub(number.Number_1e18, bound),
-		),
-		number.Number_1e18,
-	)
-	priceUpperBound := new(uint256.Int).Div(
-		new(uint256.Int).Mul(
-			cloPrice,
-			new(uint256.Int).Add(number.Number_1e18, bound),
-		),
-		number.Number_1e18,
-	)
-	woPriceInbound := cloPrice.Eq(number.Zero) || (priceLowerBound.Cmp(woPrice) <= 0 && woPrice.Cmp(priceUpperBound) <= 0)
+	woFeasible := !woPrice.Eq(number.Zero) &&
+		!oracle.IsStale(uint64(s.wooracle.Timestamp), uint64(s.wooracle.StaleDuration), uint64(time.Now().Unix()))
+	woPriceInbound := oracle.InBounds(woPrice, cloPrice, uint256.NewInt(s.wooracle.Bound), number.Number_1e18)
 
 	if woFeasible {
 		return woPrice, woPriceInbound
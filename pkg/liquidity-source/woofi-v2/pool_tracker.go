@@ -17,6 +17,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type PoolTracker struct {
@@ -156,27 +157,20 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
-	// Call ChainLink Oracle to get lastestRoundData
-	latestRoundData := make([]struct {
-		RoundId         *big.Int `json:"roundId" abi:"roundId"`
-		Answer          *big.Int `json:"answer" abi:"answer"`
-		StartedAt       *big.Int `json:"startedAt" abi:"startedAt"`
-		UpdatedAt       *big.Int `json:"updatedAt" abi:"updatedAt"`
-		AnsweredInRound *big.Int `json:"answeredInRound" abi:"answeredInRound"`
-	}, len(p.Tokens))
+	// Call ChainLink Oracle to get lastestRoundData, along with the sequencer uptime feed
+	latestRoundData := make([]oracle.RoundData, len(p.Tokens))
+	var sequencerRound oracle.RoundData
 
+	cloracleCalls := d.config.AddCall(d.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber),
+		&sequencerRound)
 	if _, ok := lo.Find(clOracles, func(clOracle clOracleResp) bool {
 		return clOracle.Oracle.Cmp(zeroAddress) == 0
 	}); !ok {
-		cloracleCalls := d.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber)
 		for i := range p.Tokens {
-			cloracleCalls.AddCall(&ethrpc.Call{
-				ABI:    CloracleABI,
-				Target: clOracles[i].Oracle.Hex(),
-				Method: cloracleMethodLatestRoundData,
-				Params: nil,
-			}, []interface{}{&latestRoundData[i]})
+			oracle.AddLatestRoundDataCall(cloracleCalls, clOracles[i].Oracle.Hex(), &latestRoundData[i])
 		}
+	}
+	if len(cloracleCalls.Calls) > 0 {
 		if _, err := cloracleCalls.TryBlockAndAggregate(); err != nil {
 			logger.WithFields(logger.Fields{
 				"poolAddress": p.Address,
@@ -240,7 +234,8 @@ func (d *PoolTracker) GetNewPoolState(
 			StaleDuration: staleDuration.Int64(),
 			Bound:         bound,
 		},
-		Cloracle: poolCloracle,
+		Cloracle:  poolCloracle,
+		Sequencer: d.config.Sequencer(&sequencerRound),
 	})
 
 	if err != nil {
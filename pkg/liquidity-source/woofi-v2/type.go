@@ -3,6 +3,8 @@ package woofiv2
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/holiman/uint256"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type (
@@ -11,6 +13,7 @@ type (
 		TokenInfos map[string]TokenInfo `json:"tokenInfos"`
 		Wooracle   Wooracle             `json:"wooracle"`
 		Cloracle   map[string]Cloracle  `json:"cloracle"`
+		Sequencer  *oracle.Sequencer    `json:"sequencer,omitempty"`
 	}
 
 	Wooracle struct {
@@ -10,7 +10,6 @@ var (
 	WooPPV2ABI           abi.ABI
 	IntegrationHelperABI abi.ABI
 	WooracleV2ABI        abi.ABI
-	CloracleABI          abi.ABI
 	Erc20ABI             abi.ABI
 )
 
@@ -22,7 +21,6 @@ func init() {
 		{&WooPPV2ABI, WooPPV2ABIBytes},
 		{&IntegrationHelperABI, IntegrationHelperABIBytes},
 		{&WooracleV2ABI, WooracleV2ABIBytes},
-		{&CloracleABI, CloracleABIBytes},
 		{&Erc20ABI, Erc20ABIBytes},
 	}
 
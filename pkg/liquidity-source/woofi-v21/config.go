@@ -1,7 +1,12 @@
 package woofiv21
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+
 type Config struct {
 	DexID                    string `json:"dexID"`
 	WooPPV2Address           string `json:"wooPPV2Address"`
 	IntegrationHelperAddress string `json:"integrationHelperAddress"`
+	// SequencerConfig is the uptime feed of the sequencer on L2s. WooPPV2 does not check it, but while the sequencer is
+	// down or in its grace period the Wooracle and Chainlink prices are not updated, so the pool is not quoted.
+	oracle.SequencerConfig
 }
@@ -21,8 +21,6 @@ const (
 	wooracleMethodBound         = "bound"
 	wooracleMethodStaleDuration = "staleDuration"
 
-	cloracleMethodLatestRoundData = "latestRoundData"
-
 	erc20MethodDecimals = "decimals"
 )
 
@@ -14,6 +14,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/eth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 var (
@@ -29,6 +30,8 @@ var (
 	ErrPoolIsPaused                = errors.New("pool is paused")
 )
 
+// PoolSimulator quotes WooPPV2 swaps on the prices WOO's keepers post to the Wooracle. WooPPV2.swap reads them from
+// storage and has no argument to submit signed prices with, so the pool is not a pulloracle.Consumer.
 type PoolSimulator struct {
 	pool.Pool
 	quoteToken string
@@ -36,6 +39,7 @@ type PoolSimulator struct {
 	decimals   map[string]uint8
 	wooracle   Wooracle
 	cloracle   map[string]Cloracle
+	sequencer  *oracle.Sequencer
 	isPaused   bool
 
 	gas Gas
@@ -71,6 +75,7 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 		decimals:   decimals,
 		wooracle:   extra.Wooracle,
 		cloracle:   extra.Cloracle,
+		sequencer:  extra.Sequencer,
 		isPaused:   extra.IsPaused,
 
 		gas: DefaultGas,
@@ -80,6 +85,8 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 func (s *PoolSimulator) CalcAmountOut(params pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
 	if s.isPaused {
 		return nil, ErrPoolIsPaused
+	} else if err := s.sequencer.Check(uint64(time.Now().Unix())); err != nil {
+		return nil, err
 	}
 
 	tokenAmountIn := params.TokenAmountIn
@@ -479,29 +486,9 @@ func (s *PoolSimulator) _wooracleV2Price(base string) (*uint256.Int, bool) {
 
 	cloPrice, _ := s._wooracleCloPriceInQuote(base, s.quoteToken)
 
-	// Calculate the stale time
-	staleTime := s.wooracle.Timestamp + s.wooracle.StaleDuration
-
-	woFeasible := woPrice.Sign() != 0 && time.Now().Unix() <= staleTime
-
-	bound := uint256.NewInt(s.wooracle.Bound)
-	priceLowerBound := new(uint256.Int)
-	priceLowerBound = priceLowerBound.Div(
-		priceLowerBound.Mul(
-			cloPrice,
-			priceLowerBound.Sub(number.Number_1e18, bound),
-		),
-		number.Number_1e18,
-	)
-	priceUpperBound := new(uint256.Int)
-	priceUpperBound = priceUpperBound.Div(
-		priceUpperBound.Mul(
-			cloPrice,
-			priceUpperBound.Add(number.Number_1e18, bound),
-		),
-		number.Number_1e18,
-	)
-	woPriceInbound := cloPrice.Sign() == 0 || (priceLowerBound.Cmp(woPrice) <= 0 && woPrice.Cmp(priceUpperBound) <= 0)
+	woFeasible := woPrice.Sign() != 0 &&
+		!oracle.IsStale(uint64(s.wooracle.Timestamp), uint64(s.wooracle.StaleDuration), uint64(time.Now().Unix()))
+	woPriceInbound := oracle.InBounds(woPrice, cloPrice, uint256.NewInt(s.wooracle.Bound), number.Number_1e18)
 
 	if woFeasible {
 		return woPrice, woPriceInbound
@@ -1,6 +1,7 @@
 package woofiv21
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
@@ -77,6 +79,7 @@ func TestPoolSimulator_NewPool(t *testing.T) {
 
 	pool, err := NewPoolSimulator(entityPool)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, pool)
 
 	pool.wooracle.Timestamp = time.Now().Unix()
 	pool.wooracle.StaleDuration = 300
@@ -92,6 +95,19 @@ func TestPoolSimulator_NewPool(t *testing.T) {
 
 	require.Nil(t, err)
 	require.Equal(t, "420800752", result.TokenAmountOut.Amount.String())
+
+	// the pool is not quoted while the sequencer is down or in its grace period
+	now := time.Now().Unix()
+	pool.sequencer = &oracle.Sequencer{Answer: big.NewInt(1), StartedAt: big.NewInt(now - 7200), GracePeriod: 3600}
+	_, err = pool.CalcAmountOut(params)
+	require.ErrorIs(t, err, oracle.ErrSequencerDown)
+	pool.sequencer = &oracle.Sequencer{Answer: big.NewInt(0), StartedAt: big.NewInt(now - 60), GracePeriod: 3600}
+	_, err = pool.CalcAmountOut(params)
+	require.ErrorIs(t, err, oracle.ErrGracePeriodNotOver)
+	pool.sequencer.StartedAt = big.NewInt(now - 7200)
+	result, err = pool.CalcAmountOut(params)
+	require.NoError(t, err)
+	require.Equal(t, "420800752", result.TokenAmountOut.Amount.String())
 }
 
 func TestPoolSimulator_CalcAmountOut_Nil_Oracle(t *testing.T) {
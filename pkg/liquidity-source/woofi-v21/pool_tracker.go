@@ -16,6 +16,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type PoolTracker struct {
@@ -163,23 +164,14 @@ func (d *PoolTracker) GetNewPoolState(
 		return p, err
 	}
 
-	// Call ChainLink Oracle to get latestRoundData
-	latestRoundData := make([]struct {
-		RoundId         *big.Int `json:"roundId" abi:"roundId"`
-		Answer          *big.Int `json:"answer" abi:"answer"`
-		StartedAt       *big.Int `json:"startedAt" abi:"startedAt"`
-		UpdatedAt       *big.Int `json:"updatedAt" abi:"updatedAt"`
-		AnsweredInRound *big.Int `json:"answeredInRound" abi:"answeredInRound"`
-	}, len(p.Tokens))
+	// Call ChainLink Oracle to get latestRoundData, along with the sequencer uptime feed
+	latestRoundData := make([]oracle.RoundData, len(p.Tokens))
+	var sequencerRound oracle.RoundData
 
-	cloracleCalls := d.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber)
+	cloracleCalls := d.config.AddCall(d.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(blockNumber),
+		&sequencerRound)
 	for i := range p.Tokens {
-		cloracleCalls.AddCall(&ethrpc.Call{
-			ABI:    CloracleABI,
-			Target: clOracles[i].Oracle.Hex(),
-			Method: cloracleMethodLatestRoundData,
-			Params: nil,
-		}, []any{&latestRoundData[i]})
+		oracle.AddLatestRoundDataCall(cloracleCalls, clOracles[i].Oracle.Hex(), &latestRoundData[i])
 	}
 	if _, err := cloracleCalls.TryBlockAndAggregate(); err != nil {
 		logger.WithFields(logger.Fields{
@@ -261,8 +253,9 @@ func (d *PoolTracker) GetNewPoolState(
 			StaleDuration: staleDuration.Int64(),
 			Bound:         bound,
 		},
-		Cloracle: poolCloracle,
-		IsPaused: isPaused,
+		Cloracle:  poolCloracle,
+		Sequencer: d.config.Sequencer(&sequencerRound),
+		IsPaused:  isPaused,
 	})
 
 	if err != nil {
@@ -2,11 +2,14 @@ package msgpack
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/msgpack/v5"
+	"github.com/KyberNetwork/msgpack/v5/msgpcode"
 	"github.com/klauspost/compress/snappy"
 )
 
@@ -29,14 +32,198 @@ func PutDecoder(en *msgpack.Decoder) {
 	decoderPool.Put(en)
 }
 
-// DecodePoolSimulatorsMap decodes an encoded and Snappy compressed map from pool ID to IPoolSimulator
+// DecodePoolSimulatorsMap decodes an encoded and Snappy compressed map from pool ID to IPoolSimulator.
+// Pools that cannot be decoded, e.g. because their schema differs from this binary's, are skipped.
 func DecodePoolSimulatorsMap(encoded []byte) (map[string]pool.IPoolSimulator, error) {
-	poolsMap := make(map[string]pool.IPoolSimulator)
+	poolsMap, _, _, err := DecodePoolSimulatorsMapWithState(encoded)
+	return poolsMap, err
+}
+
+// DecodePoolSimulatorsMapWithSkipped is DecodePoolSimulatorsMap that also reports the skipped pools.
+func DecodePoolSimulatorsMapWithSkipped(encoded []byte) (map[string]pool.IPoolSimulator, []SkippedPool, error) {
+	poolsMap, _, skipped, err := DecodePoolSimulatorsMapWithState(encoded)
+	return poolsMap, skipped, err
+}
+
+// DecodePoolSimulatorsMapWithState is DecodePoolSimulatorsMapWithSkipped that also returns the state of the snapshot,
+// to apply deltas against it with ApplyPoolSimulatorsMapDelta.
+// Indexed snapshots are decoded eagerly too. Snapshots encoded before schema fingerprints were introduced are decoded as a whole without any checks, and have
+// no state.
+func DecodePoolSimulatorsMapWithState(encoded []byte) (map[string]pool.IPoolSimulator, *SnapshotState, []SkippedPool,
+	error) {
 	zw := snappy.NewReader(bytes.NewReader(encoded))
 	de := NewDecoder(zw)
 	defer PutDecoder(de)
-	if err := de.Decode(&poolsMap); err != nil {
+
+	code, err := de.PeekCode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if msgpcode.IsFixedMap(code) || code == msgpcode.Map16 || code == msgpcode.Map32 {
+		poolsMap := make(map[string]pool.IPoolSimulator)
+		if err := de.Decode(&poolsMap); err != nil {
+			return nil, nil, nil, err
+		}
+		return poolsMap, nil, nil, nil
+	}
+
+	var snap snapshot
+	if kind, err := decodeSnapshotKind(de); err != nil {
+		return nil, nil, nil, err
+	} else if kind == snapshotKindIndexed {
+		var indexed snapshotIndexed
+		if err := de.Decode(&indexed); err != nil {
+			return nil, nil, nil, err
+		}
+		if snap, err = indexed.toSnapshot(); err != nil {
+			return nil, nil, nil, err
+		}
+	} else if kind != snapshotKindFull {
+		return nil, nil, nil, fmt.Errorf("%w: %d, expected %d", ErrUnexpectedSnapshotKind, kind, snapshotKindFull)
+	} else if err := de.Decode(&snap); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		poolsMap = make(map[string]pool.IPoolSimulator, len(snap.Pools))
+		state    = &SnapshotState{digests: make(map[string]uint64, len(snap.Pools))}
+		skipped  []SkippedPool
+		poolDe   = NewDecoder(nil)
+	)
+	defer PutDecoder(poolDe)
+	for poolID, p := range snap.Pools {
+		state.set(poolID, p.digest(poolID))
+		poolSim, err := decodeSnapshotPool(poolDe, snap.Schemas, p)
+		if err != nil {
+			skipped = append(skipped, SkippedPool{PoolID: poolID, PoolType: p.Type, Err: err})
+			continue
+		}
+		poolsMap[poolID] = poolSim
+	}
+	if state.ID != snap.ID {
+		return nil, nil, nil, fmt.Errorf("%w: snapshot ID 0x%016x, computed 0x%016x", ErrSnapshotIntegrity, snap.ID,
+			state.ID)
+	}
+	return poolsMap, state, skipped, nil
+}
+
+// ApplyPoolSimulatorsMapDelta patches poolsMap in place with a delta encoded by EncodePoolSimulatorsMapDelta, and
+// advances state accordingly. poolsMap and state must come from the delta's base snapshot, i.e. from
+// DecodePoolSimulatorsMapWithState or a previous ApplyPoolSimulatorsMapDelta. Neither is modified if the delta fails
+// its integrity checks. Pools that cannot be decoded are removed from poolsMap, since their old state is stale, and
+// reported as skipped.
+func ApplyPoolSimulatorsMapDelta(poolsMap map[string]pool.IPoolSimulator, state *SnapshotState,
+	encodedDelta []byte) ([]SkippedPool, error) {
+	zw := snappy.NewReader(bytes.NewReader(encodedDelta))
+	de := NewDecoder(zw)
+	defer PutDecoder(de)
+
+	var delta snapshotDelta
+	if err := decodeSnapshot(de, snapshotKindDelta, &delta); err != nil {
+		return nil, err
+	}
+	if delta.BaseID != state.ID {
+		return nil, fmt.Errorf("%w: delta base 0x%016x, current 0x%016x", ErrDeltaBaseMismatch, delta.BaseID, state.ID)
+	}
+
+	// verify the resulting state before touching anything
+	id, poolCount := state.ID, len(state.digests)
+	for _, poolID := range delta.Removed {
+		digest, ok := state.digests[poolID]
+		if !ok {
+			return nil, fmt.Errorf("%w: removed pool %s not in base", ErrSnapshotIntegrity, poolID)
+		}
+		id -= digest
+		poolCount--
+	}
+	digests := make(map[string]uint64, len(delta.Upserts))
+	for poolID, p := range delta.Upserts {
+		digest, ok := state.digests[poolID]
+		if !ok {
+			poolCount++
+		}
+		digests[poolID] = p.digest(poolID)
+		id += digests[poolID] - digest
+	}
+	if id != delta.ID || poolCount != delta.PoolCount {
+		return nil, fmt.Errorf("%w: expected ID 0x%016x with %d pools, got ID 0x%016x with %d pools",
+			ErrSnapshotIntegrity, delta.ID, delta.PoolCount, id, poolCount)
+	}
+
+	var (
+		upserts = make(map[string]pool.IPoolSimulator, len(delta.Upserts))
+		skipped []SkippedPool
+		poolDe  = NewDecoder(nil)
+	)
+	defer PutDecoder(poolDe)
+	for poolID, p := range delta.Upserts {
+		poolSim, err := decodeSnapshotPool(poolDe, delta.Schemas, p)
+		if err != nil {
+			skipped = append(skipped, SkippedPool{PoolID: poolID, PoolType: p.Type, Err: err})
+			continue
+		}
+		upserts[poolID] = poolSim
+	}
+
+	for _, poolID := range delta.Removed {
+		delete(poolsMap, poolID)
+		state.remove(poolID)
+	}
+	for _, s := range skipped {
+		delete(poolsMap, s.PoolID)
+	}
+	for poolID, poolSim := range upserts {
+		poolsMap[poolID] = poolSim
+	}
+	for poolID, digest := range digests {
+		state.set(poolID, digest)
+	}
+	return skipped, nil
+}
+
+// decodeSnapshot decodes [snapshotVersion, kind, body] into body
+func decodeSnapshot(de *msgpack.Decoder, kind uint8, body any) error {
+	if actualKind, err := decodeSnapshotKind(de); err != nil {
+		return err
+	} else if actualKind != kind {
+		return fmt.Errorf("%w: %d, expected %d", ErrUnexpectedSnapshotKind, actualKind, kind)
+	}
+	return de.Decode(body)
+}
+
+// decodeSnapshotKind decodes the [snapshotVersion, kind] prefix of a snapshot and leaves its body to be decoded
+func decodeSnapshotKind(de *msgpack.Decoder) (uint8, error) {
+	if n, err := de.DecodeArrayLen(); err != nil {
+		return 0, err
+	} else if n != 3 {
+		return 0, fmt.Errorf("%w: array length %d", ErrInvalidSnapshot, n)
+	}
+	if version, err := de.DecodeUint8(); err != nil {
+		return 0, err
+	} else if version != snapshotVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, version)
+	}
+	return de.DecodeUint8()
+}
+
+func decodeSnapshotPool(de *msgpack.Decoder, schemas map[string]uint64, p snapshotPool) (pool.IPoolSimulator, error) {
+	typ, ok := poolTypeByTag[p.Type]
+	if !ok {
+		return nil, ErrUnregisteredPoolType
+	}
+	if fingerprint := poolSchemaFingerprint(p.Type, typ); schemas[p.Type] != fingerprint {
+		return nil, fmt.Errorf("%w: encoded 0x%016x, expected 0x%016x", ErrSchemaMismatch, schemas[p.Type],
+			fingerprint)
+	}
+
+	v := reflect.New(typ)
+	de.ResetReader(bytes.NewReader(p.Data))
+	if err := de.DecodeValue(v); err != nil {
 		return nil, err
 	}
-	return poolsMap, nil
+	poolSim, ok := v.Interface().(pool.IPoolSimulator)
+	if !ok {
+		return nil, fmt.Errorf("%s does not implement IPoolSimulator", p.Type)
+	}
+	return poolSim, nil
 }
@@ -2,7 +2,11 @@ package msgpack
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"maps"
+	"math"
+	"reflect"
 	"sync"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
@@ -29,15 +33,181 @@ func PutEncoder(en *msgpack.Encoder) {
 	encoderPool.Put(en)
 }
 
-// EncodePoolSimulatorsMap encode a map from pool ID to IPoolSimulator with Snappy compression
+// EncodePoolSimulatorsMap encode a map from pool ID to IPoolSimulator with Snappy compression.
+// Each pool is encoded separately together with the schema fingerprint of its type, so that a decoder built from a
+// different version of this library can detect and skip pools whose layout has changed.
 func EncodePoolSimulatorsMap(poolsMap map[string]pool.IPoolSimulator) ([]byte, error) {
+	encoded, _, err := EncodePoolSimulatorsMapWithState(poolsMap)
+	return encoded, err
+}
+
+// EncodePoolSimulatorsMapWithState is EncodePoolSimulatorsMap that also returns the state of the snapshot, to encode
+// later snapshots as deltas against it with EncodePoolSimulatorsMapDelta.
+func EncodePoolSimulatorsMapWithState(poolsMap map[string]pool.IPoolSimulator) ([]byte, *SnapshotState, error) {
+	pe := newPoolEncoder()
+	defer pe.close()
+
+	snap := &snapshot{
+		Schemas: pe.schemas,
+		Pools:   make(map[string]snapshotPool, len(poolsMap)),
+	}
+	state := &SnapshotState{digests: make(map[string]uint64, len(poolsMap))}
+	for poolID, poolSim := range poolsMap {
+		p, err := pe.encode(poolID, poolSim)
+		if err != nil {
+			return nil, nil, err
+		}
+		snap.Pools[poolID] = p
+		state.set(poolID, p.digest(poolID))
+	}
+	snap.ID = state.ID
+
+	encoded, err := encodeSnappy(snapshotKindFull, snap)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encoded, state, nil
+}
+
+// EncodePoolSimulatorsMapIndexed encodes a map from pool ID to IPoolSimulator like EncodePoolSimulatorsMap, but with
+// an index from pool ID to the location of its encoded data, so that NewLazyPoolSimulators can decode pools on demand.
+func EncodePoolSimulatorsMapIndexed(poolsMap map[string]pool.IPoolSimulator) ([]byte, error) {
+	pe := newPoolEncoder()
+	defer pe.close()
+
+	snap := &snapshotIndexed{
+		Schemas: pe.schemas,
+		Index:   make(map[string]snapshotIndexEntry, len(poolsMap)),
+	}
+	state := &SnapshotState{digests: make(map[string]uint64, len(poolsMap))}
+	var data bytes.Buffer
+	for poolID, poolSim := range poolsMap {
+		p, err := pe.encode(poolID, poolSim)
+		if err != nil {
+			return nil, err
+		}
+		if data.Len()+len(p.Data) > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: indexed snapshot exceeds 4GiB", ErrInvalidSnapshot)
+		}
+		snap.Index[poolID] = snapshotIndexEntry{
+			Type:   p.Type,
+			Offset: uint32(data.Len()),
+			Length: uint32(len(p.Data)),
+		}
+		data.Write(p.Data)
+		state.set(poolID, p.digest(poolID))
+	}
+	snap.ID, snap.Data = state.ID, data.Bytes()
+
+	return encodeSnappy(snapshotKindIndexed, snap)
+}
+
+// EncodePoolSimulatorsMapDelta encodes only the pools that were added, changed or removed in poolsMap relative to the
+// snapshot described by state, and advances state to poolsMap. Pools are compared by their encoded bytes, in which
+// map entries are written in key order (see registerSortedMapEncoders), so unchanged pools are left out.
+func EncodePoolSimulatorsMapDelta(state *SnapshotState, poolsMap map[string]pool.IPoolSimulator) ([]byte, error) {
+	pe := newPoolEncoder()
+	defer pe.close()
+
+	delta := &snapshotDelta{
+		BaseID:  state.ID,
+		Schemas: pe.schemas,
+		Upserts: make(map[string]snapshotPool),
+	}
+	digests := make(map[string]uint64, len(poolsMap))
+	for poolID, poolSim := range poolsMap {
+		p, err := pe.encode(poolID, poolSim)
+		if err != nil {
+			return nil, err
+		}
+		digest := p.digest(poolID)
+		if baseDigest, ok := state.digests[poolID]; !ok || baseDigest != digest {
+			delta.Upserts[poolID] = p
+		}
+		digests[poolID] = digest
+	}
+	// state is only advanced once the delta is encoded, so that a failed delta leaves it at the base snapshot
+	next := &SnapshotState{ID: state.ID, digests: maps.Clone(state.digests)}
+	for poolID := range state.digests {
+		if _, ok := poolsMap[poolID]; !ok {
+			delta.Removed = append(delta.Removed, poolID)
+			next.remove(poolID)
+		}
+	}
+	for poolID := range delta.Upserts {
+		next.set(poolID, digests[poolID])
+	}
+	delta.ID, delta.PoolCount = next.ID, len(next.digests)
+
+	encoded, err := encodeSnappy(snapshotKindDelta, delta)
+	if err != nil {
+		return nil, err
+	}
+	*state = *next
+	return encoded, nil
+}
+
+// poolEncoder encodes pools one by one into snapshot entries and collects the schemas of their types.
+type poolEncoder struct {
+	buf     bytes.Buffer
+	en      *msgpack.Encoder
+	schemas map[string]uint64
+}
+
+func newPoolEncoder() *poolEncoder {
+	pe := &poolEncoder{schemas: make(map[string]uint64)}
+	pe.en = NewEncoder(&pe.buf)
+	// for unchanged pools to be encoded identically across snapshots; maps of pool types are sorted by the encoders
+	// registered with them, this also sorts those only reachable through interface fields
+	pe.en.SetSortMapKeys(true)
+	return pe
+}
+
+func (pe *poolEncoder) close() {
+	PutEncoder(pe.en)
+}
+
+func (pe *poolEncoder) encode(poolID string, poolSim pool.IPoolSimulator) (snapshotPool, error) {
+	typ := reflect.TypeOf(poolSim)
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	tag := poolTypeTag(typ)
+	if _, ok := poolTypeByTag[tag]; !ok {
+		return snapshotPool{}, fmt.Errorf("%w: %s", ErrUnregisteredPoolType, tag)
+	}
+	if _, ok := pe.schemas[tag]; !ok {
+		pe.schemas[tag] = poolSchemaFingerprint(tag, typ)
+	}
+
+	pe.buf.Reset()
+	if err := pe.en.Encode(poolSim); err != nil {
+		return snapshotPool{}, fmt.Errorf("encode pool %s: %w", poolID, err)
+	}
+	return snapshotPool{
+		Type: tag,
+		Data: bytes.Clone(pe.buf.Bytes()),
+	}, nil
+}
+
+// encodeSnappy encodes [snapshotVersion, kind, body] with Snappy compression
+func encodeSnappy(kind uint8, body any) ([]byte, error) {
 	var (
 		buf bytes.Buffer
 		zw  = snappy.NewBufferedWriter(&buf)
 	)
 	en := NewEncoder(zw)
 	defer PutEncoder(en)
-	if err := en.Encode(poolsMap); err != nil {
+	if err := en.EncodeArrayLen(3); err != nil {
+		return nil, err
+	}
+	if err := en.EncodeUint8(snapshotVersion); err != nil {
+		return nil, err
+	}
+	if err := en.EncodeUint8(kind); err != nil {
+		return nil, err
+	}
+	if err := en.Encode(body); err != nil {
 		return nil, err
 	}
 	if err := zw.Close(); err != nil {
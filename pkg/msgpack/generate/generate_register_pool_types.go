@@ -60,7 +60,7 @@ func main() {
 		if name, ok := irregularPoolSimNameByPackageName[pkgName]; ok {
 			poolSimName = name
 		}
-		emitf(outFileBuf, "\tmsgpack.RegisterConcreteType(&%s.%s{})\n", pkgName, poolSimName)
+		emitf(outFileBuf, "\tRegisterPoolType(&%s.%s{})\n", pkgName, poolSimName)
 	}
 	emitf(outFileBuf, "}\n")
 }
@@ -71,11 +71,10 @@ func emitImports(outFileBuf io.Writer, pkgNames, importPaths []string) {
 
 	emitf(outFileBuf, "// Code generated by %s/pkg/msgpack/generate DO NOT EDIT.\n", moduleName)
 	emitf(outFileBuf, "//go:generate go run ./generate\n")
+	emitf(outFileBuf, "//go:generate go run ./generate/fingerprint\n")
 	emitf(outFileBuf, "\n")
 
 	emitf(outFileBuf, "import (\n")
-	emitf(outFileBuf, "\t\"github.com/KyberNetwork/msgpack/v5\"\n")
-	emitf(outFileBuf, "\n")
 	for i, dexName := range pkgNames {
 		emitf(outFileBuf, "\t%s \"%s\"\n", dexName, importPaths[i])
 	}
@@ -0,0 +1,127 @@
+package msgpack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
+
+// LazyPoolSimulators is a pool simulators map decoded on demand from a snapshot. The encoded data is kept in memory
+// and each pool is decoded on first access, then cached. It is safe for concurrent use. Returned pool simulators are
+// shared between callers and must be cloned before being updated, as pool.RFQWithPoolState does.
+type LazyPoolSimulators struct {
+	id      uint64
+	schemas map[string]uint64
+	pools   map[string]snapshotPool // Data of indexed snapshots are sub-slices of a single buffer
+
+	cache sync.Map // pool ID -> lazyPool
+}
+
+type lazyPool struct {
+	poolSim pool.IPoolSimulator
+	err     error
+}
+
+var _ pool.IPoolManager = (*LazyPoolSimulators)(nil)
+
+// NewLazyPoolSimulators indexes a snapshot encoded by EncodePoolSimulatorsMapIndexed or EncodePoolSimulatorsMap
+// without decoding any pool.
+func NewLazyPoolSimulators(encoded []byte) (*LazyPoolSimulators, error) {
+	zw := snappy.NewReader(bytes.NewReader(encoded))
+	de := NewDecoder(zw)
+	defer PutDecoder(de)
+
+	kind, err := decodeSnapshotKind(de)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case snapshotKindIndexed:
+		var indexed snapshotIndexed
+		if err := de.Decode(&indexed); err != nil {
+			return nil, err
+		}
+		snap, err := indexed.toSnapshot()
+		if err != nil {
+			return nil, err
+		}
+		return &LazyPoolSimulators{id: snap.ID, schemas: snap.Schemas, pools: snap.Pools}, nil
+
+	case snapshotKindFull:
+		var snap snapshot
+		if err := de.Decode(&snap); err != nil {
+			return nil, err
+		}
+		return &LazyPoolSimulators{id: snap.ID, schemas: snap.Schemas, pools: snap.Pools}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedSnapshotKind, kind)
+	}
+}
+
+// ID returns the ID of the snapshot, see SnapshotState
+func (l *LazyPoolSimulators) ID() uint64 {
+	return l.id
+}
+
+// Len returns the number of pools in the snapshot, including pools that cannot be decoded
+func (l *LazyPoolSimulators) Len() int {
+	return len(l.pools)
+}
+
+// PoolIDs returns the IDs of all pools in the snapshot in lexical order
+func (l *LazyPoolSimulators) PoolIDs() []string {
+	poolIDs := make([]string, 0, len(l.pools))
+	for poolID := range l.pools {
+		poolIDs = append(poolIDs, poolID)
+	}
+	slices.Sort(poolIDs)
+	return poolIDs
+}
+
+// Get decodes the pool simulator with the given ID, or returns it from cache. It returns false if the snapshot does
+// not contain the pool, and an error if the pool cannot be decoded.
+func (l *LazyPoolSimulators) Get(poolID string) (pool.IPoolSimulator, bool, error) {
+	if cached, ok := l.cache.Load(poolID); ok {
+		lp := cached.(*lazyPool)
+		return lp.poolSim, true, lp.err
+	}
+	p, ok := l.pools[poolID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	de := NewDecoder(nil)
+	poolSim, err := decodeSnapshotPool(de, l.schemas, p)
+	PutDecoder(de)
+
+	// concurrent decoders of the same pool agree on the first stored result
+	cached, _ := l.cache.LoadOrStore(poolID, &lazyPool{poolSim: poolSim, err: err})
+	lp := cached.(*lazyPool)
+	return lp.poolSim, true, lp.err
+}
+
+// GetStateByPoolAddresses decodes the requested pools, keeping only those of the given dexes if any. Pools that are
+// missing or cannot be decoded are left out.
+func (l *LazyPoolSimulators) GetStateByPoolAddresses(_ context.Context, poolAddresses, dex []string,
+	_ pool.PoolManagerExtraData) (*pool.FindRouteState, error) {
+	pools := make(map[string]pool.IPoolSimulator, len(poolAddresses))
+	for _, poolAddress := range poolAddresses {
+		poolSim, ok, err := l.Get(poolAddress)
+		if !ok || err != nil {
+			continue
+		}
+		if len(dex) > 0 && !slices.Contains(dex, poolSim.GetExchange()) {
+			continue
+		}
+		pools[poolAddress] = poolSim
+	}
+	return &pool.FindRouteState{Pools: pools}, nil
+}
@@ -0,0 +1,90 @@
+package msgpack
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
+
+func TestLazyPoolSimulators(t *testing.T) {
+	poolsMap := newTestPoolsMap(t)
+	indexed, err := EncodePoolSimulatorsMapIndexed(poolsMap)
+	require.NoError(t, err)
+	full, state, err := EncodePoolSimulatorsMapWithState(poolsMap)
+	require.NoError(t, err)
+
+	for name, encoded := range map[string][]byte{"indexed": indexed, "full": full} {
+		t.Run(name, func(t *testing.T) {
+			lazy, err := NewLazyPoolSimulators(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, len(poolsMap), lazy.Len())
+			assert.Equal(t, state.ID, lazy.ID())
+			assert.Len(t, lazy.PoolIDs(), len(poolsMap))
+
+			var wg sync.WaitGroup
+			decoded := make([]pool.IPoolSimulator, 8)
+			for i := range decoded {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					decoded[i], _, _ = lazy.Get("0x9eb0bc7a207f77811ee365729d00152622a745b7")
+				}()
+			}
+			wg.Wait()
+			for _, poolSim := range decoded {
+				assert.Same(t, decoded[0], poolSim, "pool should be decoded once and cached")
+			}
+
+			_, ok, err := lazy.Get("0xmissing")
+			assert.False(t, ok)
+			assert.NoError(t, err)
+
+			state, err := lazy.GetStateByPoolAddresses(context.Background(), lazy.PoolIDs(), nil,
+				pool.PoolManagerExtraData{})
+			require.NoError(t, err)
+			assertSameQuotes(t, poolsMap, state.Pools)
+
+			state, err = lazy.GetStateByPoolAddresses(context.Background(), lazy.PoolIDs(), []string{"uniswap"},
+				pool.PoolManagerExtraData{})
+			require.NoError(t, err)
+			assert.Empty(t, state.Pools)
+		})
+	}
+
+	decoded, err := DecodePoolSimulatorsMap(indexed)
+	require.NoError(t, err)
+	assertSameQuotes(t, poolsMap, decoded)
+}
+
+func TestLazyPoolSimulators_Nested(t *testing.T) {
+	poolsMap := newTestPoolsMapNested(t)
+	indexed, err := EncodePoolSimulatorsMapIndexed(poolsMap)
+	require.NoError(t, err)
+	full, err := EncodePoolSimulatorsMap(poolsMap)
+	require.NoError(t, err)
+
+	for name, encoded := range map[string][]byte{"indexed": indexed, "full": full} {
+		t.Run(name, func(t *testing.T) {
+			lazy, err := NewLazyPoolSimulators(encoded)
+			require.NoError(t, err)
+
+			// the uniswapv3 pool alone, whose ticks are behind an interface field
+			poolSim, ok, err := lazy.Get("0x1d42064fc4beb5f8aaf85f4617ae8b3b5b8bd801")
+			require.True(t, ok)
+			require.NoError(t, err)
+			assertSameQuotes(t,
+				map[string]pool.IPoolSimulator{poolSim.GetAddress(): poolsMap[poolSim.GetAddress()]},
+				map[string]pool.IPoolSimulator{poolSim.GetAddress(): poolSim})
+
+			state, err := lazy.GetStateByPoolAddresses(context.Background(), lazy.PoolIDs(), nil,
+				pool.PoolManagerExtraData{})
+			require.NoError(t, err)
+			assertSameQuotes(t, poolsMap, state.Pools)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package msgpacktest registers the snapshot encoding of pkg/msgpack as the msgpack round-trip of
+// testutil.TestPoolSimulator. Pool packages can't import it since pkg/msgpack imports them, so its tests round-trip a
+// fixture pool of every pool type instead.
+package msgpacktest
+
+import (
+	"fmt"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/msgpack"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func init() {
+	testutil.RegisterMsgpackRoundTrip(roundTrip)
+}
+
+// roundTrip encodes poolsMap to a snapshot and decodes it back, failing if any pool is skipped
+func roundTrip(poolsMap map[string]pool.IPoolSimulator) (map[string]pool.IPoolSimulator, error) {
+	encoded, err := msgpack.EncodePoolSimulatorsMap(poolsMap)
+	if err != nil {
+		return nil, err
+	}
+	decoded, skipped, err := msgpack.DecodePoolSimulatorsMapWithSkipped(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		return nil, fmt.Errorf("decode pool %s of type %s: %w", skipped[0].PoolID, skipped[0].PoolType, skipped[0].Err)
+	}
+	return decoded, nil
+}
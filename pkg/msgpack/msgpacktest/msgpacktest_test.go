@@ -0,0 +1,69 @@
+package msgpacktest
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+const fixturesDir = "testdata/pools"
+
+// fixtureChainIDs are the chains of fixtures not on Ethereum
+var fixtureChainIDs = map[string]valueobject.ChainID{
+	"source/platypus": valueobject.ChainIDAvalancheCChain,
+}
+
+// TestPoolSimulator_Msgpack round-trips a fixture pool of every pool package through a snapshot. Each fixture is
+// an entity.Pool at testdata/pools/<package path under pkg>.json, built with the factory of its pool type.
+//
+// Packages without a fixture:
+//   - fot wraps the simulators of other pools and is round-tripped by the snapshot tests of pkg/msgpack
+//   - source/gmxcore has no pool type, its simulator is round-tripped as gmx and its forks
+//   - source/clcore is not an IPoolSimulator, it is embedded by uniswapv3, pancakev3 and the other v3 forks
+//   - liquidity-source/balancer-v3/base has no pool type, it is the simulator of the balancer-v3 pools
+func TestPoolSimulator_Msgpack(t *testing.T) {
+	t.Parallel()
+
+	var fixtures []string
+	require.NoError(t, filepath.WalkDir(fixturesDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(fixturesDir, path)
+			fixtures = append(fixtures, strings.TrimSuffix(filepath.ToSlash(rel), ".json"))
+		}
+		return err
+	}))
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(filepath.Join(fixturesDir, fixture+".json"))
+			require.NoError(t, err)
+			var entityPool entity.Pool
+			require.NoError(t, json.Unmarshal(data, &entityPool))
+
+			chainID, ok := fixtureChainIDs[fixture]
+			if !ok {
+				chainID = valueobject.ChainIDEthereum
+			}
+			factory := pool.Factory(entityPool.Type)
+			require.NotNilf(t, factory, "no factory for pool type %s", entityPool.Type)
+			poolSim, err := factory(pool.FactoryParams{EntityPool: entityPool, ChainID: chainID})
+			require.NoError(t, err)
+
+			testutil.TestPoolSimulator(t, poolSim, testutil.CheckTokens, testutil.CheckMonotonic,
+				testutil.CheckCloneState, testutil.CheckUpdateBalance)
+		})
+	}
+}
@@ -2,10 +2,10 @@ package msgpack
 
 // Code generated by github.com/KyberNetwork/kyberswap-dex-lib/pkg/msgpack/generate DO NOT EDIT.
 //go:generate go run ./generate
+//go:generate go run ./generate/fingerprint
 
 import (
-	"github.com/KyberNetwork/msgpack/v5"
-
+	pkg_fot "github.com/KyberNetwork/kyberswap-dex-lib/pkg/fot"
 	pkg_liquiditysource_aavev3 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/aave-v3"
 	pkg_liquiditysource_algebra_integral "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra/integral"
 	pkg_liquiditysource_algebra_v1 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra/v1"
@@ -54,6 +54,7 @@ import (
 	pkg_liquiditysource_frax_sfrxeth "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/frax/sfrxeth"
 	pkg_liquiditysource_frax_sfrxethconvertor "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/frax/sfrxeth-convertor"
 	pkg_liquiditysource_genericsimplerate "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/generic-simple-rate"
+	pkg_liquiditysource_gmxv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gmx-v2"
 	pkg_liquiditysource_gyroscope_2clp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/2clp"
 	pkg_liquiditysource_gyroscope_3clp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/3clp"
 	pkg_liquiditysource_gyroscope_eclp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/eclp"
@@ -104,6 +105,7 @@ import (
 	pkg_liquiditysource_woofiv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/woofi-v2"
 	pkg_liquiditysource_woofiv21 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/woofi-v21"
 	pkg_source_camelot "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/camelot"
+	pkg_source_clcore "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	pkg_source_curve_aave "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/curve/aave"
 	pkg_source_curve_base "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/curve/base"
 	pkg_source_curve_compound "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/curve/compound"
@@ -119,6 +121,7 @@ import (
 	pkg_source_fxdx "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/fxdx"
 	pkg_source_gmx "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx"
 	pkg_source_gmxglp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx-glp"
+	pkg_source_gmxcore "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	pkg_source_iziswap "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/iziswap"
 	pkg_source_kokonutcrypto "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/kokonut-crypto"
 	pkg_source_lido "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/lido"
@@ -155,150 +158,154 @@ import (
 )
 
 func init() {
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_aavev3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_algebra_integral.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_algebra_v1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv2_composablestable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv2_stable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv2_weighted.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv3_base.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv3_eclp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv3_quantamm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv3_stable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_balancerv3_weighted.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_bancorv21.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_bancorv3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_bebop.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_bedrock_unieth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_beetsss.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_brownfi.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_clipper.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_compound_v2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_compound_v3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_llamma.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_plain.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_stablemetang.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_stableng.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_tricryptong.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_curve_twocryptong.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_daiusds.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_deltaswapv1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_dexalot.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_dodo_classical.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_dodo_dpp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_dodo_dsp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_dodo_dvm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_ekubo.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_erc4626.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_ethena_susde.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_ethervista.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_etherfi_ebtc.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_etherfi_eeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_etherfi_vampire.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_etherfi_weeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_eulerswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_fluid_dext1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_fluid_vaultt1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_frax_sfrxeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_frax_sfrxethconvertor.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_genericsimplerate.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_gyroscope_2clp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_gyroscope_3clp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_gyroscope_eclp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_hashflowv3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_honey.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_hyeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_integral.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_kelp_rseth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_litepsm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_lo1inch.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_maker_savingsdai.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_maker_skypsm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_mantle_meth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_maverick_v1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_maverick_v2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_mkrsky.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_native_v1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_native_v3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_nomiswap_nomiswapstable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_ondousdy.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_overnightusdp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_pancakeinfinity_bin.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_pancakeinfinity_cl.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_pandafun.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_primeeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_puffer_pufeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_renzo_ezeth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_ringswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_rocketpool_reth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_solidlyv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_staderethx.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_swaapv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_swell_rsweth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_swell_sweth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_syncswapv2_aqua.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_syncswapv2_classic.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_syncswapv2_stable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_uniswaplo.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_uniswapv1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_uniswapv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_uniswapv4.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_usd0pp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_velocorev2_cpmm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_velocorev2_wombatstable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_velodromev1.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_velodromev2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_virtualfun.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_woofiv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_liquiditysource_woofiv21.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_camelot.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_aave.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_base.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_compound.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_meta.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_plainoracle.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_tricrypto.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_curve_two.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_dmm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_elastic.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_equalizer.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_fraxswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_fulcrom.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_fxdx.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_gmx.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_gmxglp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_iziswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_kokonutcrypto.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_lido.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_lidosteth.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_limitorder.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_liquiditybookv20.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_liquiditybookv21.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_madmex.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_makerpsm.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_mantisswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_metavault.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_nuriv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_pancakev3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_platypus.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_polmatic.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_quickperps.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_ramsesv2.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_saddle.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_slipstream.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_smardex.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_solidlyv3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_swapbasedperp.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_syncswap_syncswapclassic.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_syncswap_syncswapstable.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_synthetix.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_uniswap.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_uniswapv3.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_usdfi.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_velocimeter.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_vooi.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_wombat_wombatlsd.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_wombat_wombatmain.PoolSimulator{})
-	msgpack.RegisterConcreteType(&pkg_source_zkerafinance.PoolSimulator{})
+	RegisterPoolType(&pkg_fot.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_aavev3.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_algebra_integral.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_algebra_v1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv2_composablestable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv2_stable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv2_weighted.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv3_base.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv3_eclp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv3_quantamm.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv3_stable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_balancerv3_weighted.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_bancorv21.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_bancorv3.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_bebop.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_bedrock_unieth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_beetsss.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_brownfi.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_clipper.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_compound_v2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_compound_v3.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_llamma.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_plain.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_stablemetang.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_stableng.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_tricryptong.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_curve_twocryptong.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_daiusds.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_deltaswapv1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_dexalot.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_dodo_classical.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_dodo_dpp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_dodo_dsp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_dodo_dvm.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_ekubo.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_erc4626.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_ethena_susde.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_ethervista.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_etherfi_ebtc.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_etherfi_eeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_etherfi_vampire.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_etherfi_weeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_eulerswap.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_fluid_dext1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_fluid_vaultt1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_frax_sfrxeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_frax_sfrxethconvertor.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_genericsimplerate.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_gmxv2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_gyroscope_2clp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_gyroscope_3clp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_gyroscope_eclp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_hashflowv3.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_honey.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_hyeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_integral.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_kelp_rseth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_litepsm.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_lo1inch.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_maker_savingsdai.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_maker_skypsm.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_mantle_meth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_maverick_v1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_maverick_v2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_mkrsky.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_native_v1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_native_v3.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_nomiswap_nomiswapstable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_ondousdy.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_overnightusdp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_pancakeinfinity_bin.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_pancakeinfinity_cl.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_pandafun.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_primeeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_puffer_pufeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_renzo_ezeth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_ringswap.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_rocketpool_reth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_solidlyv2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_staderethx.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_swaapv2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_swell_rsweth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_swell_sweth.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_syncswapv2_aqua.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_syncswapv2_classic.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_syncswapv2_stable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_uniswaplo.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_uniswapv1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_uniswapv2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_uniswapv4.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_usd0pp.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_velocorev2_cpmm.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_velocorev2_wombatstable.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_velodromev1.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_velodromev2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_virtualfun.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_woofiv2.PoolSimulator{})
+	RegisterPoolType(&pkg_liquiditysource_woofiv21.PoolSimulator{})
+	RegisterPoolType(&pkg_source_camelot.PoolSimulator{})
+	RegisterPoolType(&pkg_source_clcore.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_aave.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_base.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_compound.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_meta.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_plainoracle.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_tricrypto.PoolSimulator{})
+	RegisterPoolType(&pkg_source_curve_two.PoolSimulator{})
+	RegisterPoolType(&pkg_source_dmm.PoolSimulator{})
+	RegisterPoolType(&pkg_source_elastic.PoolSimulator{})
+	RegisterPoolType(&pkg_source_equalizer.PoolSimulator{})
+	RegisterPoolType(&pkg_source_fraxswap.PoolSimulator{})
+	RegisterPoolType(&pkg_source_fulcrom.PoolSimulator{})
+	RegisterPoolType(&pkg_source_fxdx.PoolSimulator{})
+	RegisterPoolType(&pkg_source_gmx.PoolSimulator{})
+	RegisterPoolType(&pkg_source_gmxglp.PoolSimulator{})
+	RegisterPoolType(&pkg_source_gmxcore.PoolSimulator{})
+	RegisterPoolType(&pkg_source_iziswap.PoolSimulator{})
+	RegisterPoolType(&pkg_source_kokonutcrypto.PoolSimulator{})
+	RegisterPoolType(&pkg_source_lido.PoolSimulator{})
+	RegisterPoolType(&pkg_source_lidosteth.PoolSimulator{})
+	RegisterPoolType(&pkg_source_limitorder.PoolSimulator{})
+	RegisterPoolType(&pkg_source_liquiditybookv20.PoolSimulator{})
+	RegisterPoolType(&pkg_source_liquiditybookv21.PoolSimulator{})
+	RegisterPoolType(&pkg_source_madmex.PoolSimulator{})
+	RegisterPoolType(&pkg_source_makerpsm.PoolSimulator{})
+	RegisterPoolType(&pkg_source_mantisswap.PoolSimulator{})
+	RegisterPoolType(&pkg_source_metavault.PoolSimulator{})
+	RegisterPoolType(&pkg_source_nuriv2.PoolSimulator{})
+	RegisterPoolType(&pkg_source_pancakev3.PoolSimulator{})
+	RegisterPoolType(&pkg_source_platypus.PoolSimulator{})
+	RegisterPoolType(&pkg_source_polmatic.PoolSimulator{})
+	RegisterPoolType(&pkg_source_quickperps.PoolSimulator{})
+	RegisterPoolType(&pkg_source_ramsesv2.PoolSimulator{})
+	RegisterPoolType(&pkg_source_saddle.PoolSimulator{})
+	RegisterPoolType(&pkg_source_slipstream.PoolSimulator{})
+	RegisterPoolType(&pkg_source_smardex.PoolSimulator{})
+	RegisterPoolType(&pkg_source_solidlyv3.PoolSimulator{})
+	RegisterPoolType(&pkg_source_swapbasedperp.PoolSimulator{})
+	RegisterPoolType(&pkg_source_syncswap_syncswapclassic.PoolSimulator{})
+	RegisterPoolType(&pkg_source_syncswap_syncswapstable.PoolSimulator{})
+	RegisterPoolType(&pkg_source_synthetix.PoolSimulator{})
+	RegisterPoolType(&pkg_source_uniswap.PoolSimulator{})
+	RegisterPoolType(&pkg_source_uniswapv3.PoolSimulator{})
+	RegisterPoolType(&pkg_source_usdfi.PoolSimulator{})
+	RegisterPoolType(&pkg_source_velocimeter.PoolSimulator{})
+	RegisterPoolType(&pkg_source_vooi.PoolSimulator{})
+	RegisterPoolType(&pkg_source_wombat_wombatlsd.PoolSimulator{})
+	RegisterPoolType(&pkg_source_wombat_wombatmain.PoolSimulator{})
+	RegisterPoolType(&pkg_source_zkerafinance.PoolSimulator{})
 }
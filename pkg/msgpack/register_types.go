@@ -1,18 +1,15 @@
 package msgpack
 
 import (
-	"github.com/KyberNetwork/msgpack/v5"
 	pancakev3_entities "github.com/KyberNetwork/pancake-v3-sdk/entities"
 	uniswapv3uint256_entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
 	uniswapv3_entities "github.com/daoleno/uniswapv3-sdk/entities"
 
-	pkg_source_gmx "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx"
-	pkg_source_gmxglp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx-glp"
-	pkg_source_madmex "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/madmex"
-	pkg_source_metavault "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/metavault"
-	pkg_source_quickperps "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/quickperps"
-	pkg_source_swapbasedperp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/swapbased-perp"
-	pkg_source_zkerafinance "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/zkera-finance"
+	pkg_liquiditysource_algebra_integral "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra/integral"
+	pkg_liquiditysource_balancerv3_hooks "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/hooks"
+	pkg_liquiditysource_ekubo_pools "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/ekubo/pools"
+	pkg_source_clcore "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	pkg_source_gmxcore "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 )
 
 func mustNotError(err error) {
@@ -22,33 +19,32 @@ func mustNotError(err error) {
 }
 
 func init() {
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_gmx.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_gmx.FastPriceFeedV2{}))
+	registerConcreteType(&pkg_source_clcore.DirectionalFee{})
 
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_gmxglp.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_gmxglp.FastPriceFeedV2{}))
+	registerConcreteType(&pkg_source_gmxcore.FastPriceFeedV1{})
+	registerConcreteType(&pkg_source_gmxcore.FastPriceFeedV2{})
 
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_madmex.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_madmex.FastPriceFeedV2{}))
+	registerConcreteType(pkg_liquiditysource_algebra_integral.BasePlugin{})
+	registerConcreteType(pkg_liquiditysource_algebra_integral.SlidingFeePlugin{})
+	registerConcreteType(pkg_liquiditysource_algebra_integral.FarmingPlugin{})
+	registerConcreteType(&pkg_liquiditysource_algebra_integral.SecurityPlugin{})
+	registerConcreteType(&pkg_liquiditysource_algebra_integral.LimitOrderPlugin{})
 
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_metavault.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_metavault.FastPriceFeedV2{}))
+	registerConcreteType(&pkg_liquiditysource_balancerv3_hooks.NoOpHook{})
+	registerConcreteType(&pkg_liquiditysource_balancerv3_hooks.DirectionalFeeHook{})
+	registerConcreteType(&pkg_liquiditysource_balancerv3_hooks.FeeTakingHook{})
+	registerConcreteType(&pkg_liquiditysource_balancerv3_hooks.StableSurgeHook{})
+	registerConcreteType(&pkg_liquiditysource_balancerv3_hooks.VeBALFeeDiscountHook{})
 
-	mustNotError(msgpack.RegisterConcreteType(&pancakev3_entities.TickListDataProvider{}))
+	registerConcreteType(&pkg_liquiditysource_ekubo_pools.BasePool{})
+	registerConcreteType(&pkg_liquiditysource_ekubo_pools.FullRangePool{})
+	registerConcreteType(&pkg_liquiditysource_ekubo_pools.OraclePool{})
+	registerConcreteType(&pkg_liquiditysource_ekubo_pools.TwammPool{})
+	registerConcreteType(&pkg_liquiditysource_ekubo_pools.MevResistPool{})
 
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_metavault.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_metavault.FastPriceFeedV2{}))
+	registerConcreteType(&pancakev3_entities.TickListDataProvider{})
 
-	mustNotError(msgpack.RegisterConcreteType(&uniswapv3_entities.TickListDataProvider{}))
+	registerConcreteType(&uniswapv3_entities.TickListDataProvider{})
 
-	mustNotError(msgpack.RegisterConcreteType(&uniswapv3uint256_entities.TickListDataProvider{}))
-
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_quickperps.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_quickperps.FastPriceFeedV2{}))
-
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_swapbasedperp.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_swapbasedperp.FastPriceFeedV2{}))
-
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_zkerafinance.FastPriceFeedV1{}))
-	mustNotError(msgpack.RegisterConcreteType(&pkg_source_zkerafinance.FastPriceFeedV2{}))
+	registerConcreteType(&uniswapv3uint256_entities.TickListDataProvider{})
 }
@@ -0,0 +1,40 @@
+package msgpack
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/hooks"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/ekubo"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/ekubo/pools"
+)
+
+// TestRegisteredConcreteTypes checks that interface fields of pool simulators decode to the concrete types they were
+// encoded from
+func TestRegisteredConcreteTypes(t *testing.T) {
+	type interfaces struct {
+		Hook hooks.IHook
+		Pool ekubo.Pool
+	}
+	for name, value := range map[string]interfaces{
+		"balancer-v3 hook": {Hook: &hooks.StableSurgeHook{}},
+		"ekubo pool":       {Pool: &pools.TwammPool{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			en := NewEncoder(&buf)
+			defer PutEncoder(en)
+			require.NoError(t, en.Encode(value))
+
+			var decoded interfaces
+			de := NewDecoder(&buf)
+			defer PutDecoder(de)
+			require.NoError(t, de.Decode(&decoded))
+			assert.IsType(t, value.Hook, decoded.Hook)
+			assert.IsType(t, value.Pool, decoded.Pool)
+		})
+	}
+}
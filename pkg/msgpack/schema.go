@@ -0,0 +1,160 @@
+package msgpack
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/KyberNetwork/msgpack/v5"
+)
+
+var (
+	poolTypes     []reflect.Type
+	poolTypeByTag = map[string]reflect.Type{}
+	// concreteTypes are the types registered to be decoded from interface fields, by their tag
+	concreteTypes = map[string]reflect.Type{}
+
+	customEncoderType = reflect.TypeOf((*msgpack.CustomEncoder)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*msgpack.Marshaler)(nil)).Elem()
+)
+
+// RegisterPoolType registers a pool simulator type (pointer to struct) so that it can be encoded to and decoded from
+// snapshots. Pool simulators in this module are registered by register_pool_types.gen.go.
+func RegisterPoolType(v any) {
+	registerConcreteType(v)
+	typ := indirect(reflect.TypeOf(v))
+	tag := poolTypeTag(typ)
+	if _, ok := poolTypeByTag[tag]; ok {
+		return
+	}
+	poolTypes = append(poolTypes, typ)
+	poolTypeByTag[tag] = typ
+}
+
+// ComputeSchemaFingerprints computes the schema fingerprints of all registered pool types from their current field
+// layout. It is used by ./generate/fingerprint to generate schema_fingerprints.gen.go.
+func ComputeSchemaFingerprints() map[string]uint64 {
+	fingerprints := make(map[string]uint64, len(poolTypes))
+	for _, typ := range poolTypes {
+		fingerprints[poolTypeTag(typ)] = SchemaFingerprint(typ)
+	}
+	return fingerprints
+}
+
+// SchemaFingerprint hashes the msgpack layout of a type as encoded with SetForceAsArray(true) and
+// IncludeUnexported(true): the order, names and types of all (nested) fields. Any change that would make a snapshot
+// produced by an older binary undecodable changes the fingerprint.
+func SchemaFingerprint(typ reflect.Type) uint64 {
+	var sb strings.Builder
+	writeSchema(&sb, typ, map[reflect.Type]bool{})
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(sb.String()))
+	return h.Sum64()
+}
+
+// poolSchemaFingerprint returns the generated schema fingerprint of a pool type, falling back to computing it for
+// pool types registered outside this module.
+func poolSchemaFingerprint(tag string, typ reflect.Type) uint64 {
+	if fingerprint, ok := poolSchemaFingerprints[tag]; ok {
+		return fingerprint
+	}
+	return SchemaFingerprint(typ)
+}
+
+// registerConcreteType registers a concrete type of an interface field of pool simulators
+func registerConcreteType(v any) {
+	mustNotError(msgpack.RegisterConcreteType(v))
+	typ := reflect.TypeOf(v)
+	registerSortedMapEncoders(typ, map[reflect.Type]bool{})
+	tag := poolTypeTag(indirect(typ))
+	if typ.Kind() == reflect.Pointer {
+		tag = "*" + tag
+	}
+	concreteTypes[tag] = typ
+}
+
+// implementations returns the registered concrete types that can be decoded into an interface type, in tag order.
+func implementations(iface reflect.Type) []reflect.Type {
+	var types []reflect.Type
+	for _, tag := range slices.Sorted(maps.Keys(concreteTypes)) {
+		if typ := concreteTypes[tag]; typ.Implements(iface) {
+			types = append(types, typ)
+		}
+	}
+	return types
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Pointer {
+		return typ.Elem()
+	}
+	return typ
+}
+
+func poolTypeTag(typ reflect.Type) string {
+	return typ.PkgPath() + "." + typ.Name()
+}
+
+func writeSchema(sb *strings.Builder, typ reflect.Type, visiting map[reflect.Type]bool) {
+	if typ.PkgPath() != "" {
+		_, _ = fmt.Fprintf(sb, "%s.%s", typ.PkgPath(), typ.Name())
+		if isOpaqueType(typ) || visiting[typ] {
+			return
+		}
+		visiting[typ] = true
+		defer delete(visiting, typ)
+		sb.WriteString("=")
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		sb.WriteString("{")
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			tag := field.Tag.Get("msgpack")
+			if tag == "-" {
+				continue
+			}
+			_, _ = fmt.Fprintf(sb, "%s %q ", field.Name, tag)
+			writeSchema(sb, field.Type, visiting)
+			sb.WriteString(";")
+		}
+		sb.WriteString("}")
+	case reflect.Pointer:
+		sb.WriteString("*")
+		writeSchema(sb, typ.Elem(), visiting)
+	case reflect.Slice:
+		sb.WriteString("[]")
+		writeSchema(sb, typ.Elem(), visiting)
+	case reflect.Array:
+		_, _ = fmt.Fprintf(sb, "[%d]", typ.Len())
+		writeSchema(sb, typ.Elem(), visiting)
+	case reflect.Map:
+		sb.WriteString("map[")
+		writeSchema(sb, typ.Key(), visiting)
+		sb.WriteString("]")
+		writeSchema(sb, typ.Elem(), visiting)
+	case reflect.Interface:
+		// concrete types of interface fields are tagged in the encoded data, so the layouts of the registered ones
+		// that can be stored in the field are part of its schema
+		sb.WriteString("interface{")
+		for _, concrete := range implementations(typ) {
+			writeSchema(sb, concrete, visiting)
+			sb.WriteString(";")
+		}
+		sb.WriteString("}")
+	default:
+		sb.WriteString(typ.Kind().String())
+	}
+}
+
+// isOpaqueType reports whether a named type is identified by its name only: types with custom msgpack encoding and
+// standard library types, whose layout depends on the Go version rather than on this module.
+func isOpaqueType(typ reflect.Type) bool {
+	return typ.Implements(customEncoderType) || typ.Implements(marshalerType) ||
+		reflect.PointerTo(typ).Implements(customEncoderType) || reflect.PointerTo(typ).Implements(marshalerType) ||
+		!strings.Contains(strings.Split(typ.PkgPath(), "/")[0], ".")
+}
@@ -0,0 +1,127 @@
+package msgpack
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// snapshotVersion is the version of the snapshot container format, not of the pool schemas. Version 2 stores pools as
+// msgpack bin instead of raw msgpack values.
+const snapshotVersion uint8 = 2
+
+const (
+	snapshotKindFull uint8 = iota + 1
+	snapshotKindDelta
+	snapshotKindIndexed
+)
+
+var (
+	ErrUnregisteredPoolType       = errors.New("unregistered pool type")
+	ErrSchemaMismatch             = errors.New("pool schema mismatch")
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrUnexpectedSnapshotKind     = errors.New("unexpected snapshot kind")
+	ErrInvalidSnapshot            = errors.New("invalid snapshot")
+	ErrDeltaBaseMismatch          = errors.New("delta base snapshot mismatch")
+	ErrSnapshotIntegrity          = errors.New("snapshot integrity check failed")
+)
+
+// Snapshots and deltas are encoded as [snapshotVersion, kind, body] where body is a snapshot or a snapshotDelta.
+
+// snapshot is the encoded form of a pool simulators map
+type snapshot struct {
+	// ID identifies the snapshot content, see SnapshotState
+	ID uint64
+	// Schemas maps each pool type in the snapshot to its schema fingerprint in the encoding binary
+	Schemas map[string]uint64
+	Pools   map[string]snapshotPool
+}
+
+// snapshotDelta is the encoded difference between two snapshots
+type snapshotDelta struct {
+	// ID of the snapshot the delta applies to
+	BaseID uint64
+	// ID and PoolCount of the snapshot after applying the delta
+	ID        uint64
+	PoolCount int
+	Schemas   map[string]uint64
+	Upserts   map[string]snapshotPool
+	Removed   []string
+}
+
+// snapshotIndexed is the encoded form of a pool simulators map where all pools are concatenated in Data and located
+// through Index, so that they can be decoded individually without copying
+type snapshotIndexed struct {
+	ID      uint64
+	Schemas map[string]uint64
+	Index   map[string]snapshotIndexEntry
+	Data    []byte
+}
+
+type snapshotIndexEntry struct {
+	Type   string
+	Offset uint32
+	Length uint32
+}
+
+// toSnapshot converts the index to snapshot pools whose Data are sub-slices of s.Data
+func (s *snapshotIndexed) toSnapshot() (snapshot, error) {
+	pools := make(map[string]snapshotPool, len(s.Index))
+	for poolID, entry := range s.Index {
+		end := uint64(entry.Offset) + uint64(entry.Length)
+		if end > uint64(len(s.Data)) {
+			return snapshot{}, fmt.Errorf("%w: pool %s out of bounds", ErrInvalidSnapshot, poolID)
+		}
+		pools[poolID] = snapshotPool{
+			Type: entry.Type,
+			Data: s.Data[entry.Offset:end:end],
+		}
+	}
+	return snapshot{ID: s.ID, Schemas: s.Schemas, Pools: pools}, nil
+}
+
+type snapshotPool struct {
+	Type string
+	// Data is the encoded pool, stored as bin so that it is read back byte for byte. Capturing it as a raw msgpack
+	// value cuts it short after the concrete type extension of interface fields.
+	Data []byte
+}
+
+func (p snapshotPool) digest(poolID string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(poolID))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(p.Type))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(p.Data)
+	return h.Sum64()
+}
+
+// SkippedPool is a pool that could not be decoded from a snapshot
+type SkippedPool struct {
+	PoolID   string
+	PoolType string
+	Err      error
+}
+
+// SnapshotState holds the digests of the encoded pools of a snapshot. Its ID is the wrapping sum of all digests, so
+// that it can be updated per pool when a delta is encoded or applied.
+type SnapshotState struct {
+	ID      uint64
+	digests map[string]uint64
+}
+
+// PoolCount returns the number of pools in the snapshot
+func (s *SnapshotState) PoolCount() int {
+	return len(s.digests)
+}
+
+func (s *SnapshotState) set(poolID string, digest uint64) {
+	s.ID += digest - s.digests[poolID]
+	s.digests[poolID] = digest
+}
+
+func (s *SnapshotState) remove(poolID string) {
+	s.ID -= s.digests[poolID]
+	delete(s.digests, poolID)
+}
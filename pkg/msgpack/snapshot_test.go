@@ -0,0 +1,438 @@
+package msgpack
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/fot"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/balancer-v3/weighted"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/ekubo"
+	uniswapv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/uniswap-v2"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/uniswapv3"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+func newTestPoolsMap(t *testing.T) map[string]pool.IPoolSimulator {
+	poolsMap := make(map[string]pool.IPoolSimulator)
+	for _, address := range []string{"0x9eb0bc7a207f77811ee365729d00152622a745b7", "0x0eD7e52944161450477ee417DE9Cd3a859b14fD0"} {
+		poolSim, err := uniswapv2.NewPoolSimulator(entity.Pool{
+			Address:  address,
+			Exchange: "pancake",
+			Type:     "uniswap-v2",
+			Reserves: entity.PoolReserves{"5789592094546501478373016", "793623036600773033475"},
+			Tokens: []*entity.PoolToken{{Address: "0x6d5ad1592ed9d6d1df9b93c793ab759573ed6714"},
+				{Address: "0xbb4cdb9cbd36b01bd1cbaebf2de08d9173bc095c"}},
+			Extra: `{"fee":25,"feePrecision":10000}`,
+		})
+		require.NoError(t, err)
+		poolsMap[address] = poolSim
+	}
+	return poolsMap
+}
+
+// testRegistry taxes the first token of the uniswap-v2 test pools, which are wrapped by fot in newTestPoolsMapNested
+var testRegistry = func() *fot.Registry {
+	registry := fot.NewRegistry()
+	registry.Set("0x6d5ad1592ed9d6d1df9b93c793ab759573ed6714", fot.Behavior{BuyTax: 500, SellTax: 1000})
+	return registry
+}()
+
+// newTestPoolsMapNested returns pools with registered interface fields, whose concrete types are encoded as msgpack
+// extensions: tick data providers, balancer-v3 hooks, ekubo pools, gmx price feeds and the fot wrapper.
+func newTestPoolsMapNested(t *testing.T) map[string]pool.IPoolSimulator {
+	poolsMap := make(map[string]pool.IPoolSimulator)
+	add := func(poolSim pool.IPoolSimulator, err error) {
+		require.NoError(t, err)
+		poolsMap[poolSim.GetAddress()] = poolSim
+	}
+	add(uniswapv3.NewPoolSimulator(loadTestPool(t, "uniswapv3.json"), valueobject.ChainIDEthereum))
+	add(weighted.NewPoolSimulator(loadTestPool(t, "balancer-v3-weighted.json")))
+	add(ekubo.NewPoolSimulator(loadTestPool(t, "ekubo.json")))
+	add(gmx.NewPoolSimulator(loadTestPool(t, "gmx.json")))
+	for _, poolSim := range newTestPoolsMap(t) {
+		poolsMap["fot-"+poolSim.GetAddress()] = fot.NewPoolSimulator(poolSim, testRegistry)
+	}
+	return poolsMap
+}
+
+func loadTestPool(t *testing.T, name string) entity.Pool {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	var entityPool entity.Pool
+	require.NoError(t, json.Unmarshal(data, &entityPool))
+	return entityPool
+}
+
+// assertSameQuotes checks that actual quotes like expected between all tokens, and that each pool gives at least one
+// valid quote
+func assertSameQuotes(t *testing.T, expected, actual map[string]pool.IPoolSimulator) {
+	require.Len(t, actual, len(expected))
+	for poolID, expectedSim := range expected {
+		require.Contains(t, actual, poolID)
+		// the registry of fot pools is left out of snapshots and set again by their consumers
+		if fotSim, ok := actual[poolID].(*fot.PoolSimulator); ok {
+			fotSim.SetRegistry(testRegistry)
+		}
+		quoted := false
+		for _, tokenIn := range expectedSim.GetTokens() {
+			for _, tokenOut := range expectedSim.CanSwapFrom(tokenIn) {
+				tokenAmountIn := pool.TokenAmount{Token: tokenIn, Amount: big.NewInt(1e18)}
+				expectedRes, expectedErr := expectedSim.CalcAmountOut(pool.CalcAmountOutParams{
+					TokenAmountIn: tokenAmountIn, TokenOut: tokenOut})
+				actualRes, actualErr := actual[poolID].CalcAmountOut(pool.CalcAmountOutParams{
+					TokenAmountIn: tokenAmountIn, TokenOut: tokenOut})
+				if expectedErr != nil {
+					assert.Equal(t, expectedErr, actualErr)
+					continue
+				}
+				require.NoError(t, actualErr)
+				assert.Equal(t, expectedRes.TokenAmountOut.Amount, actualRes.TokenAmountOut.Amount)
+				quoted = true
+			}
+		}
+		assert.Truef(t, quoted, "pool %s has no valid quote", poolID)
+	}
+}
+
+func TestSchemaFingerprintsUpToDate(t *testing.T) {
+	assert.Equal(t, ComputeSchemaFingerprints(), poolSchemaFingerprints,
+		"pool schemas changed, please run `go generate ./pkg/msgpack`")
+}
+
+type testFee interface{ fee() uint64 }
+
+type testFlatFee struct{ Fee uint64 }
+
+func (f *testFlatFee) fee() uint64 { return f.Fee }
+
+type testTieredFee struct{ Fees []uint64 }
+
+func (f *testTieredFee) fee() uint64 { return f.Fees[0] }
+
+type testFeePool struct{ Fee testFee }
+
+func TestSchemaFingerprint_Interface(t *testing.T) {
+	registered := maps.Clone(concreteTypes)
+	t.Cleanup(func() { concreteTypes = registered })
+
+	typ := reflect.TypeOf(testFeePool{})
+	registerConcreteType(&testFlatFee{})
+	fingerprint := SchemaFingerprint(typ)
+	assert.Equal(t, fingerprint, SchemaFingerprint(typ))
+
+	// snapshots holding a concrete type unknown to an older binary must not be decoded by it
+	registerConcreteType(&testTieredFee{})
+	assert.NotEqual(t, fingerprint, SchemaFingerprint(typ))
+}
+
+func TestEncodeDecodePoolSimulatorsMap(t *testing.T) {
+	poolsMap := newTestPoolsMap(t)
+	encoded, err := EncodePoolSimulatorsMap(poolsMap)
+	require.NoError(t, err)
+
+	decoded, skipped, err := DecodePoolSimulatorsMapWithSkipped(encoded)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	assertSameQuotes(t, poolsMap, decoded)
+}
+
+func TestEncodeDecodePoolSimulatorsMap_Nested(t *testing.T) {
+	poolsMap := newTestPoolsMapNested(t)
+	encoded, err := EncodePoolSimulatorsMap(poolsMap)
+	require.NoError(t, err)
+
+	decoded, skipped, err := DecodePoolSimulatorsMapWithSkipped(encoded)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	fotSim := decoded["fot-0x9eb0bc7a207f77811ee365729d00152622a745b7"]
+	tokens := fotSim.GetTokens()
+	_, err = fotSim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: tokens[0], Amount: big.NewInt(1e18)},
+		TokenOut:      tokens[1],
+	})
+	assert.ErrorIs(t, err, fot.ErrNoRegistry)
+
+	assertSameQuotes(t, poolsMap, decoded)
+}
+
+func TestDecodePoolSimulatorsMap_SchemaMismatch(t *testing.T) {
+	poolsMap := newTestPoolsMap(t)
+	encoded, err := EncodePoolSimulatorsMap(poolsMap)
+	require.NoError(t, err)
+
+	var snap snapshot
+	de := NewDecoder(snappy.NewReader(bytes.NewReader(encoded)))
+	defer PutDecoder(de)
+	require.NoError(t, decodeSnapshot(de, snapshotKindFull, &snap))
+	for tag := range snap.Schemas {
+		snap.Schemas[tag]++ // simulate a snapshot from a binary with a different layout
+	}
+	encoded, err = encodeSnappy(snapshotKindFull, &snap)
+	require.NoError(t, err)
+
+	decoded, skipped, err := DecodePoolSimulatorsMapWithSkipped(encoded)
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+	require.Len(t, skipped, len(poolsMap))
+	for _, s := range skipped {
+		assert.Contains(t, poolsMap, s.PoolID)
+		assert.ErrorIs(t, s.Err, ErrSchemaMismatch)
+	}
+}
+
+func TestDecodePoolSimulatorsMap_Legacy(t *testing.T) {
+	poolsMap := newTestPoolsMap(t)
+
+	var buf bytes.Buffer
+	zw := snappy.NewBufferedWriter(&buf)
+	en := NewEncoder(zw)
+	defer PutEncoder(en)
+	require.NoError(t, en.Encode(poolsMap))
+	require.NoError(t, zw.Close())
+
+	decoded, err := DecodePoolSimulatorsMap(buf.Bytes())
+	require.NoError(t, err)
+	assertSameQuotes(t, poolsMap, decoded)
+}
+
+func TestEncodePoolSimulatorsMap_Unregistered(t *testing.T) {
+	_, err := EncodePoolSimulatorsMap(map[string]pool.IPoolSimulator{"x": &unregisteredPool{}})
+	assert.ErrorIs(t, err, ErrUnregisteredPoolType)
+}
+
+type unregisteredPool struct {
+	uniswapv2.PoolSimulator
+}
+
+func TestApplyPoolSimulatorsMapDelta(t *testing.T) {
+	producerMap := newTestPoolsMap(t)
+	encoded, producerState, err := EncodePoolSimulatorsMapWithState(producerMap)
+	require.NoError(t, err)
+
+	consumerMap, consumerState, skipped, err := DecodePoolSimulatorsMapWithState(encoded)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+	assert.Equal(t, producerState.ID, consumerState.ID)
+
+	// change, add and remove a pool
+	changed := producerMap["0x9eb0bc7a207f77811ee365729d00152622a745b7"]
+	tokens := changed.GetTokens()
+	res, err := changed.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: tokens[0], Amount: big.NewInt(1e18)},
+		TokenOut:      tokens[1],
+	})
+	require.NoError(t, err)
+	changed.UpdateBalance(pool.UpdateBalanceParams{
+		TokenAmountIn:  pool.TokenAmount{Token: tokens[0], Amount: big.NewInt(1e18)},
+		TokenAmountOut: *res.TokenAmountOut,
+		Fee:            *res.Fee,
+		SwapInfo:       res.SwapInfo,
+	})
+	added := newTestPoolsMap(t)["0x0eD7e52944161450477ee417DE9Cd3a859b14fD0"]
+	producerMap["0xadded"] = added
+	delete(producerMap, "0x0eD7e52944161450477ee417DE9Cd3a859b14fD0")
+
+	encodedDelta, err := EncodePoolSimulatorsMapDelta(producerState, producerMap)
+	require.NoError(t, err)
+
+	var delta snapshotDelta
+	de := NewDecoder(snappy.NewReader(bytes.NewReader(encodedDelta)))
+	defer PutDecoder(de)
+	require.NoError(t, decodeSnapshot(de, snapshotKindDelta, &delta))
+	assert.Len(t, delta.Upserts, 2)
+	assert.Equal(t, []string{"0x0eD7e52944161450477ee417DE9Cd3a859b14fD0"}, delta.Removed)
+
+	skipped, err = ApplyPoolSimulatorsMapDelta(consumerMap, consumerState, encodedDelta)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	assert.Equal(t, producerState.ID, consumerState.ID)
+	assertSameQuotes(t, producerMap, consumerMap)
+
+	// an unchanged map gives an empty delta
+	emptyDelta, err := EncodePoolSimulatorsMapDelta(producerState, producerMap)
+	require.NoError(t, err)
+	skipped, err = ApplyPoolSimulatorsMapDelta(consumerMap, consumerState, emptyDelta)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	assertSameQuotes(t, producerMap, consumerMap)
+
+	t.Run("base mismatch", func(t *testing.T) {
+		_, err := ApplyPoolSimulatorsMapDelta(consumerMap, consumerState, encodedDelta)
+		assert.ErrorIs(t, err, ErrDeltaBaseMismatch)
+	})
+
+	t.Run("failed delta", func(t *testing.T) {
+		id, poolCount := producerState.ID, producerState.PoolCount()
+		_, err := EncodePoolSimulatorsMapDelta(producerState, map[string]pool.IPoolSimulator{"x": &unregisteredPool{}})
+		assert.ErrorIs(t, err, ErrUnregisteredPoolType)
+		assert.Equal(t, id, producerState.ID)
+		assert.Equal(t, poolCount, producerState.PoolCount())
+	})
+
+	t.Run("integrity", func(t *testing.T) {
+		encoded, state, err := EncodePoolSimulatorsMapWithState(newTestPoolsMap(t))
+		require.NoError(t, err)
+		poolsMap, baseState, _, err := DecodePoolSimulatorsMapWithState(encoded)
+		require.NoError(t, err)
+		baseID := baseState.ID
+
+		encodedDelta, err := EncodePoolSimulatorsMapDelta(state, map[string]pool.IPoolSimulator{})
+		require.NoError(t, err)
+		var delta snapshotDelta
+		de := NewDecoder(snappy.NewReader(bytes.NewReader(encodedDelta)))
+		defer PutDecoder(de)
+		require.NoError(t, decodeSnapshot(de, snapshotKindDelta, &delta))
+		delta.PoolCount++
+		encodedDelta, err = encodeSnappy(snapshotKindDelta, &delta)
+		require.NoError(t, err)
+
+		_, err = ApplyPoolSimulatorsMapDelta(poolsMap, baseState, encodedDelta)
+		assert.ErrorIs(t, err, ErrSnapshotIntegrity)
+		assert.Len(t, poolsMap, 2)
+		assert.Equal(t, baseID, baseState.ID)
+
+		_, err = ApplyPoolSimulatorsMapDelta(poolsMap, baseState, encoded)
+		assert.ErrorIs(t, err, ErrUnexpectedSnapshotKind)
+	})
+}
+
+func TestApplyPoolSimulatorsMapDelta_Nested(t *testing.T) {
+	producerMap := newTestPoolsMapNested(t)
+	encoded, producerState, err := EncodePoolSimulatorsMapWithState(producerMap)
+	require.NoError(t, err)
+	consumerMap, consumerState, skipped, err := DecodePoolSimulatorsMapWithState(encoded)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+
+	// swap through the uniswapv3 pool, which moves its price
+	changed := producerMap["0x1d42064fc4beb5f8aaf85f4617ae8b3b5b8bd801"]
+	tokens := changed.GetTokens()
+	tokenAmountIn := pool.TokenAmount{Token: tokens[0], Amount: big.NewInt(1e18)}
+	res, err := changed.CalcAmountOut(pool.CalcAmountOutParams{TokenAmountIn: tokenAmountIn, TokenOut: tokens[1]})
+	require.NoError(t, err)
+	changed.UpdateBalance(pool.UpdateBalanceParams{
+		TokenAmountIn:  tokenAmountIn,
+		TokenAmountOut: *res.TokenAmountOut,
+		Fee:            *res.Fee,
+		SwapInfo:       res.SwapInfo,
+	})
+
+	encodedDelta, err := EncodePoolSimulatorsMapDelta(producerState, producerMap)
+	require.NoError(t, err)
+
+	var delta snapshotDelta
+	de := NewDecoder(snappy.NewReader(bytes.NewReader(encodedDelta)))
+	defer PutDecoder(de)
+	require.NoError(t, decodeSnapshot(de, snapshotKindDelta, &delta))
+	assert.Equal(t, []string{"0x1d42064fc4beb5f8aaf85f4617ae8b3b5b8bd801"}, slices.Collect(maps.Keys(delta.Upserts)))
+
+	skipped, err = ApplyPoolSimulatorsMapDelta(consumerMap, consumerState, encodedDelta)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	assert.Equal(t, producerState.ID, consumerState.ID)
+	assertSameQuotes(t, producerMap, consumerMap)
+}
+
+// TestEncodePoolSimulatorsMap_Deterministic fills every registered pool type with maps of several entries and checks
+// that it is encoded to the same bytes every time, so that unchanged pools are left out of deltas.
+func TestEncodePoolSimulatorsMap_Deterministic(t *testing.T) {
+	for _, typ := range poolTypes {
+		t.Run(poolTypeTag(typ), func(t *testing.T) {
+			poolSim := reflect.New(typ)
+			fillValue(poolSim.Elem(), 0, map[reflect.Type]bool{})
+
+			pe := newPoolEncoder()
+			defer pe.close()
+			encode := func() []byte {
+				pe.buf.Reset()
+				require.NoError(t, pe.en.Encode(poolSim.Interface()))
+				return bytes.Clone(pe.buf.Bytes())
+			}
+			first := encode()
+			for range 10 {
+				require.Equal(t, first, encode())
+			}
+		})
+	}
+}
+
+// fillValue sets every field reachable from v to a non-zero value, with 4 entries in maps and 2 elements in slices.
+// Pointers to types with custom encoders and interfaces are left nil as they may not encode arbitrary states.
+func fillValue(v reflect.Value, seed int, visiting map[reflect.Type]bool) {
+	typ := v.Type()
+	switch {
+	case typ == reflect.TypeOf(big.Int{}):
+		v.Addr().Interface().(*big.Int).SetInt64(int64(seed) + 1)
+		return
+	case typ.PkgPath() != "" && !strings.Contains(strings.Split(typ.PkgPath(), "/")[0], "."):
+		return // leave standard library types such as sync.Mutex and time.Time alone
+	case visiting[typ]:
+		return
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(seed) + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(seed) + 1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(seed) + 1)
+	case reflect.String:
+		v.SetString(fmt.Sprintf("s%d", seed))
+	case reflect.Array:
+		for i := range v.Len() {
+			fillValue(v.Index(i), seed+i, visiting)
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(typ, 2, 2))
+		for i := range v.Len() {
+			fillValue(v.Index(i), seed+i, visiting)
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(typ))
+		for i := range 4 {
+			key, elem := reflect.New(typ.Key()).Elem(), reflect.New(typ.Elem()).Elem()
+			fillValue(key, seed+i, visiting)
+			fillValue(elem, seed+i, visiting)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Pointer:
+		if isOpaqueType(typ.Elem()) && typ.Elem() != reflect.TypeOf(big.Int{}) {
+			return
+		}
+		v.Set(reflect.New(typ.Elem()))
+		fillValue(v.Elem(), seed, visiting)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if typ.Field(i).Tag.Get("msgpack") == "-" {
+				continue
+			}
+			field := v.Field(i)
+			fillValue(reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), seed+i, visiting)
+		}
+	default:
+	}
+}
@@ -0,0 +1,108 @@
+package msgpack
+
+import (
+	"bytes"
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/KyberNetwork/msgpack/v5"
+)
+
+// sortedMapTypes holds the map types that registerSortedMapEncoders registered an encoder for
+var sortedMapTypes = map[reflect.Type]bool{}
+
+// registerSortedMapEncoders registers an encoder that writes map entries in increasing key order for every map type
+// reachable from typ. Encoder.SetSortMapKeys only sorts map[string]string, map[string]bool and
+// map[string]interface{}, so without these a pool holding e.g. a map[string]*big.Int would be encoded differently
+// every time, and unchanged pools could not be recognized by their encoded bytes.
+func registerSortedMapEncoders(typ reflect.Type, visiting map[reflect.Type]bool) {
+	if typ.PkgPath() != "" {
+		if !strings.Contains(strings.Split(typ.PkgPath(), "/")[0], ".") || visiting[typ] {
+			return // standard library types are opaque
+		}
+		visiting[typ] = true
+		defer delete(visiting, typ)
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if field := typ.Field(i); field.Tag.Get("msgpack") != "-" {
+				registerSortedMapEncoders(field.Type, visiting)
+			}
+		}
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		registerSortedMapEncoders(typ.Elem(), visiting)
+	case reflect.Map:
+		registerSortedMapEncoders(typ.Key(), visiting)
+		registerSortedMapEncoders(typ.Elem(), visiting)
+		if _, ok := mapKeyCompare(typ.Key()); ok && !sortedMapTypes[typ] {
+			sortedMapTypes[typ] = true
+			msgpack.Register(reflect.Zero(typ).Interface(), encodeSortedMapValue, nil)
+		}
+	default:
+	}
+}
+
+func encodeSortedMapValue(e *msgpack.Encoder, v reflect.Value) error {
+	if v.IsNil() {
+		return e.EncodeNil()
+	}
+	if err := e.EncodeMapLen(v.Len()); err != nil {
+		return err
+	}
+
+	keys := v.MapKeys()
+	compare, _ := mapKeyCompare(v.Type().Key())
+	slices.SortFunc(keys, compare)
+	for _, key := range keys {
+		if err := e.EncodeValue(key); err != nil {
+			return err
+		}
+		if err := e.EncodeValue(v.MapIndex(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapKeyCompare returns how to order map keys of a type, if they have a natural order
+func mapKeyCompare(typ reflect.Type) (func(a, b reflect.Value) int, bool) {
+	switch typ.Kind() {
+	case reflect.String:
+		return func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) }, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }, true
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }, true
+	case reflect.Bool:
+		return func(a, b reflect.Value) int { return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool())) }, true
+	case reflect.Array:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return nil, false
+		}
+		return func(a, b reflect.Value) int { return bytes.Compare(arrayBytes(a), arrayBytes(b)) }, true
+	default:
+		return nil, false
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// arrayBytes copies a byte array map key such as common.Address, which is not addressable
+func arrayBytes(v reflect.Value) []byte {
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
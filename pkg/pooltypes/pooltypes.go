@@ -50,6 +50,7 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/frax/sfrxeth"
 	sfrxethconvertor "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/frax/sfrxeth-convertor"
 	genericsimplerate "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/generic-simple-rate"
+	gmxv2 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gmx-v2"
 	gyro2clp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/2clp"
 	gyro3clp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/3clp"
 	gyroeclp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/gyroscope/eclp"
@@ -322,6 +323,7 @@ type Types struct {
 	AaveV3                     string
 	CompoundV2                 string
 	CompoundV3                 string
+	GMXV2                      string
 }
 
 var (
@@ -492,5 +494,6 @@ var (
 		AaveV3:                     aavev3.DexType,
 		CompoundV2:                 compoundv2.DexType,
 		CompoundV3:                 compoundv3.DexType,
+		GMXV2:                      gmxv2.DexType,
 	}
 )
@@ -159,6 +159,8 @@ func TestPool_getAmountOut(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			testutil.TestPoolSimulator(t, &tc.pool)
+
 			assert.Equal(t, []string{tc.pool.Info.Tokens[1]}, tc.pool.CanSwapTo(tc.pool.Info.Tokens[0]))
 			assert.Equal(t, []string{tc.pool.Info.Tokens[0]}, tc.pool.CanSwapTo(tc.pool.Info.Tokens[1]))
 			assert.Equal(t, 0, len(tc.pool.CanSwapTo("XXX")))
@@ -46,3 +46,13 @@ type Meta struct {
 	SwapFee      uint32 `json:"swapFee"`
 	FeePrecision uint32 `json:"feePrecision"`
 }
+
+// SupportsTaxedTokens returns true as Camelot pairs, like Uniswap V2 ones, take the amount in from their balances
+func (Meta) SupportsTaxedTokens() bool {
+	return true
+}
+
+// SupportsRebasingTokens returns false as pairs keep reserves that rebases do not update
+func (Meta) SupportsRebasingTokens() bool {
+	return false
+}
@@ -0,0 +1,17 @@
+package clcore
+
+import "errors"
+
+// calldataSize is 6 words: pool, recipient, zeroForOne, amount, sqrt price limit and tokenIn
+const calldataSize = 6 * 32
+
+var (
+	ErrOverflow              = errors.New("bigInt overflow int/uint256")
+	ErrInvalidFeeTier        = errors.New("invalid feeTier")
+	ErrInvalidTickSpacing    = errors.New("invalid tickSpacing")
+	ErrTickNil               = errors.New("tick is nil")
+	ErrV3TicksEmpty          = errors.New("v3Ticks empty")
+	ErrInvalidSqrtPriceLimit = errors.New("invalid sqrt price limit")
+	ErrZeroAmountIn          = errors.New("amountIn is 0")
+	ErrZeroAmountOut         = errors.New("amountOut is 0")
+)
@@ -0,0 +1,179 @@
+package clcore_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra/integral"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/liquidity-source/algebra/v1"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/elastic"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/nuriv2"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pancakev3"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/ramsesv2"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/slipstream"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/solidly-v3"
+	_ "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/uniswapv3"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// forkQuote is a quote recorded with the implementation of a fork before it ran on clcore. Result is empty if the
+// quote failed. Exact output quotes are only recorded for forks that supported them.
+type forkQuote struct {
+	TokenIn   string `json:"tokenIn"`
+	TokenOut  string `json:"tokenOut"`
+	Amount    string `json:"amount"`
+	ExactOut  bool   `json:"exactOut,omitempty"`
+	Result    string `json:"result,omitempty"`
+	Remaining string `json:"remaining,omitempty"`
+	Gas       int64  `json:"gas,omitempty"`
+}
+
+// forkFixtures are the pool fixtures of a fork with their recorded quotes. Quotes are made on the fixture state,
+// while swaps are applied one after the other with UpdateBalance.
+type forkFixtures struct {
+	ChainID valueobject.ChainID `json:"chainId"`
+	Pools   []struct {
+		Pool   entity.Pool `json:"pool"`
+		Quotes []forkQuote `json:"quotes"`
+		Swaps  []forkQuote `json:"swaps"`
+	} `json:"pools"`
+}
+
+// remainingChange is an intended difference in the remaining amount of partial fills on clcore
+type remainingChange int
+
+const (
+	// remainingNegated is for remaining amounts that were reported negated
+	remainingNegated remainingChange = iota + 1
+	// remainingUnreported is for remaining amounts that were not reported at all
+	remainingUnreported
+)
+
+// remainingChanges are the remaining amount changes per fixture and per exact output or not. All other quotes, their
+// amounts and gas (except for crossedTickGas) must be the same as recorded.
+var remainingChanges = map[string]map[bool]remainingChange{
+	"algebrav1": {true: remainingNegated},
+	"solidlyv3": {true: remainingNegated},
+	"nuriv2":    {false: remainingUnreported},
+	"pancakev3": {true: remainingUnreported},
+	"uniswapv3": {true: remainingUnreported},
+}
+
+// crossedTickGas are the forks and exact output or not whose recorded gas was only the base gas, and which now also
+// charge the initialized ticks crossed
+var crossedTickGas = map[string]map[bool]bool{
+	"pancakev3": {true: true},
+}
+
+func replayForkQuote(t *testing.T, fork string, sim pool.IPoolSimulator, q forkQuote) *pool.UpdateBalanceParams {
+	amount, ok := new(big.Int).SetString(q.Amount, 10)
+	require.True(t, ok)
+
+	var result, remaining *pool.TokenAmount
+	var gas int64
+	var params *pool.UpdateBalanceParams
+	if q.ExactOut {
+		res, err := sim.(pool.IPoolExactOutSimulator).CalcAmountIn(pool.CalcAmountInParams{
+			TokenAmountOut: pool.TokenAmount{Token: q.TokenOut, Amount: amount},
+			TokenIn:        q.TokenIn,
+		})
+		if err == nil {
+			result, remaining, gas = res.TokenAmountIn, res.RemainingTokenAmountOut, res.Gas
+			params = &pool.UpdateBalanceParams{TokenAmountIn: *res.TokenAmountIn,
+				TokenAmountOut: pool.TokenAmount{Token: q.TokenOut, Amount: amount}, SwapInfo: res.SwapInfo}
+		}
+	} else {
+		res, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: q.TokenIn, Amount: amount},
+			TokenOut:      q.TokenOut,
+		})
+		if err == nil {
+			result, remaining, gas = res.TokenAmountOut, res.RemainingTokenAmountIn, res.Gas
+			params = &pool.UpdateBalanceParams{TokenAmountIn: pool.TokenAmount{Token: q.TokenIn, Amount: amount},
+				TokenAmountOut: *res.TokenAmountOut, Fee: *res.Fee, SwapInfo: res.SwapInfo}
+		}
+	}
+
+	if q.Result == "" {
+		assert.Nil(t, result, "%+v", q)
+		return nil
+	}
+	if !assert.NotNil(t, result, "%+v", q) {
+		return nil
+	}
+	assert.Equal(t, q.Result, result.Amount.String(), "%+v", q)
+	if crossedTickGas[fork][q.ExactOut] {
+		assert.GreaterOrEqual(t, gas, q.Gas, "%+v", q)
+	} else {
+		assert.Equal(t, q.Gas, gas, "%+v", q)
+	}
+	reported := remaining != nil && remaining.Amount != nil && remaining.Amount.Sign() != 0
+	switch remainingChanges[fork][q.ExactOut] {
+	case remainingNegated:
+		q.Remaining = strings.TrimPrefix(q.Remaining, "-")
+	case remainingUnreported:
+		if q.Remaining == "" && reported {
+			assert.True(t, remaining.Amount.Sign() > 0 && remaining.Amount.Cmp(amount) < 0, "%+v", q)
+			return params
+		}
+	}
+	if q.Remaining == "" {
+		assert.False(t, reported, "%+v", q)
+	} else if assert.True(t, reported, "%+v", q) {
+		assert.Equal(t, q.Remaining, remaining.Amount.String(), "%+v", q)
+	}
+	return params
+}
+
+// TestPoolSimulator_ForkParity replays the fixtures of every fork running on clcore against the quotes recorded with
+// its implementation before the migration, at commit 17d4a5e
+func TestPoolSimulator_ForkParity(t *testing.T) {
+	t.Parallel()
+	files, err := filepath.Glob("testdata/parity/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		fork := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(fork, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var fixtures forkFixtures
+			require.NoError(t, json.Unmarshal(data, &fixtures))
+
+			for i, fixture := range fixtures.Pools {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					newSim := func() pool.IPoolSimulator {
+						factory := pool.Factory(fixture.Pool.Type)
+						require.NotNil(t, factory)
+						sim, err := factory(pool.FactoryParams{EntityPool: fixture.Pool, ChainID: fixtures.ChainID})
+						require.NoError(t, err)
+						return sim
+					}
+
+					sim := newSim()
+					for _, q := range fixture.Quotes {
+						replayForkQuote(t, fork, sim, q)
+					}
+
+					sim = newSim()
+					for _, q := range fixture.Swaps {
+						if params := replayForkQuote(t, fork, sim, q); params != nil {
+							sim.UpdateBalance(*params)
+						}
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+// Package clcore is the concentrated liquidity engine shared by Uniswap V3 forks. Forks embed PoolSimulator and
+// configure their differences (fee policy, tick spacing fallback, price limit and hooks) with Config.
+package clcore
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/KyberNetwork/logger"
+	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
+	coreEntities "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+type PoolSimulator struct {
+	V3Pool *v3Entities.Pool
+	pool.Pool
+	Gas            Gas
+	tickMin        int
+	tickMax        int
+	tickSpacing    int
+	crossLastTick  bool
+	wordBoundaries bool
+	chargeSteps    bool
+	feePolicy      FeePolicy
+	hook           Hook
+}
+
+// NewPoolSimulator builds a pool simulator from a pool entity and its decoded extra, with fee as the pool fee in
+// hundredths of a bip. chainID is only set on the sdk tokens and may be 0.
+func NewPoolSimulator(entityPool entity.Pool, chainID valueobject.ChainID, extra *ExtraTickU256,
+	fee constants.FeeAmount, cfg Config) (*PoolSimulator, error) {
+	if extra.Tick == nil {
+		return nil, ErrTickNil
+	}
+
+	token0 := coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[0].Address),
+		uint(entityPool.Tokens[0].Decimals), entityPool.Tokens[0].Symbol, "")
+	token1 := coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[1].Address),
+		uint(entityPool.Tokens[1].Decimals), entityPool.Tokens[1].Symbol, "")
+
+	tokens := make([]string, 2)
+	reserves := make([]*big.Int, 2)
+	if len(entityPool.Reserves) == 2 && len(entityPool.Tokens) == 2 {
+		tokens[0] = entityPool.Tokens[0].Address
+		reserves[0] = bignumber.NewBig10(entityPool.Reserves[0])
+		tokens[1] = entityPool.Tokens[1].Address
+		reserves[1] = bignumber.NewBig10(entityPool.Reserves[1])
+	}
+
+	v3Ticks := make([]v3Entities.Tick, 0, len(extra.Ticks))
+
+	// Ticks are sorted from the pool service, so we don't have to do it again here
+	// Purpose: to improve the latency
+	for _, t := range extra.Ticks {
+		// LiquidityGross = 0 means that the tick is uninitialized
+		if t.LiquidityGross.IsZero() {
+			continue
+		}
+
+		v3Ticks = append(v3Ticks, v3Entities.Tick{
+			Index:          t.Index,
+			LiquidityGross: t.LiquidityGross,
+			LiquidityNet:   t.LiquidityNet,
+		})
+	}
+
+	// if the tick list is empty, the pool should be ignored
+	if len(v3Ticks) == 0 {
+		return nil, ErrV3TicksEmpty
+	}
+
+	tickSpacing := int(extra.TickSpacing)
+	// For some pools that not yet initialized tickSpacing in their extra,
+	// we will get the tickSpacing through feeTier mapping.
+	if tickSpacing == 0 {
+		if cfg.TickSpacings == nil {
+			return nil, ErrInvalidTickSpacing
+		} else if tickSpacing = cfg.TickSpacings[fee]; tickSpacing == 0 {
+			return nil, ErrInvalidFeeTier
+		}
+	}
+	ticks, err := v3Entities.NewTickListDataProvider(v3Ticks, tickSpacing)
+	if err != nil {
+		return nil, err
+	}
+
+	tickCurrent := *extra.Tick
+	if cfg.StaleTick {
+		if tickCurrent, err = v3Utils.GetTickAtSqrtRatioV2(extra.SqrtPriceX96); err != nil {
+			return nil, err
+		}
+	}
+	v3Pool, err := v3Entities.NewPoolV2(
+		token0,
+		token1,
+		fee,
+		extra.SqrtPriceX96,
+		extra.Liquidity,
+		tickCurrent,
+		ticks,
+	)
+	if err != nil {
+		return nil, err
+	}
+	v3Pool.TickCurrent = *extra.Tick
+
+	var info = pool.PoolInfo{
+		Address:  strings.ToLower(entityPool.Address),
+		SwapFee:  big.NewInt(int64(fee)),
+		Exchange: entityPool.Exchange,
+		Type:     entityPool.Type,
+		Tokens:   tokens,
+		Reserves: reserves,
+	}
+
+	return &PoolSimulator{
+		Pool:           pool.Pool{Info: info},
+		V3Pool:         v3Pool,
+		Gas:            cfg.Gas,
+		tickMin:        v3Ticks[0].Index,
+		tickMax:        v3Ticks[len(v3Ticks)-1].Index,
+		tickSpacing:    tickSpacing,
+		crossLastTick:  cfg.CrossLastTick,
+		wordBoundaries: cfg.WordBoundaries,
+		chargeSteps:    cfg.ChargeInitTickSteps,
+		feePolicy:      cfg.FeePolicy,
+		hook:           cfg.Hook,
+	}, nil
+}
+
+// GetSqrtPriceLimit get the price limit of pool based on the initialized ticks that this pool has
+func (p *PoolSimulator) GetSqrtPriceLimit(zeroForOne bool, result *v3Utils.Uint160) error {
+	tickLimit := lo.Ternary(zeroForOne, p.tickMin, p.tickMax)
+	if err := v3Utils.GetSqrtRatioAtTickV2(tickLimit, result); err != nil {
+		return err
+	}
+	if p.crossLastTick {
+		lo.Ternary(zeroForOne, result.AddUint64, result.SubUint64)(result, 1)
+	}
+	// the contracts require the limit to be strictly within the sqrt ratio bounds
+	if zeroForOne && result.Cmp(v3Utils.MinSqrtRatioU256) <= 0 {
+		result.AddUint64(v3Utils.MinSqrtRatioU256, 1)
+	} else if !zeroForOne && result.Cmp(v3Utils.MaxSqrtRatioU256) >= 0 {
+		result.SubUint64(v3Utils.MaxSqrtRatioU256, 1)
+	}
+	return nil
+}
+
+// TickSpacing returns the tick spacing of the pool
+func (p *PoolSimulator) TickSpacing() int {
+	return p.tickSpacing
+}
+
+// SetTicks replaces the initialized ticks of the pool, e.g. after liquidity is withdrawn by a swap
+func (p *PoolSimulator) SetTicks(ticks []v3Entities.Tick) error {
+	if len(ticks) == 0 {
+		return ErrV3TicksEmpty
+	}
+	tickDataProvider, err := v3Entities.NewTickListDataProvider(ticks, p.tickSpacing)
+	if err != nil {
+		return err
+	}
+	p.V3Pool.TickDataProvider = tickDataProvider
+	p.tickMin, p.tickMax = ticks[0].Index, ticks[len(ticks)-1].Index
+	return nil
+}
+
+func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	return p.CalcAmountOutWith(param, p.swap)
+}
+
+// CalcAmountOutWith quotes an exact input swap with a fork-specific swap function
+func (p *PoolSimulator) CalcAmountOutWith(param pool.CalcAmountOutParams,
+	swap SwapFunc) (*pool.CalcAmountOutResult, error) {
+	tokenAmountIn, tokenOut := param.TokenAmountIn, param.TokenOut
+	tokenIn := tokenAmountIn.Token
+	tokenInIndex, tokenOutIndex := p.GetTokenIndex(tokenIn), p.GetTokenIndex(tokenOut)
+	if tokenInIndex < 0 || tokenOutIndex < 0 {
+		return nil, fmt.Errorf("tokenInIndex %v or tokenOutIndex %v is not correct", tokenInIndex, tokenOutIndex)
+	}
+
+	var amountIn v3Utils.Int256
+	if overflow := amountIn.SetFromBig(tokenAmountIn.Amount); overflow {
+		return nil, ErrOverflow
+	}
+	var priceLimit v3Utils.Uint160
+	if err := p.GetSqrtPriceLimit(tokenInIndex == 0, &priceLimit); err != nil {
+		return nil, err
+	}
+	amountOutResult, err := swap(tokenInIndex == 0, &amountIn, &priceLimit)
+	if err != nil {
+		return nil, fmt.Errorf("can not GetOutputAmount, err: %w", err)
+	}
+
+	remainingTokenAmountIn := &pool.TokenAmount{
+		Token:  tokenIn,
+		Amount: bignumber.ZeroBI,
+	}
+	if amountOutResult.RemainingAmountIn != nil {
+		if amountOutResult.RemainingAmountIn.Sign() == 0 {
+			amountOutResult.RemainingAmountIn = nil
+		} else {
+			remainingTokenAmountIn.Amount = amountOutResult.RemainingAmountIn.ToBig()
+		}
+	}
+	amountOut := amountOutResult.ReturnedAmount
+	if amountOut.Sign() <= 0 {
+		return nil, ErrZeroAmountOut
+	}
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: &pool.TokenAmount{
+			Token:  tokenOut,
+			Amount: amountOut.ToBig(),
+		},
+		RemainingTokenAmountIn: remainingTokenAmountIn,
+		Fee:                    amountOutResult.fee(tokenIn),
+		Gas:                    p.Gas.BaseGas + p.Gas.CrossInitTickGas*int64(amountOutResult.CrossInitTickLoops),
+		SwapInfo: SwapInfo{
+			RemainingAmountIn:     amountOutResult.RemainingAmountIn,
+			NextStateSqrtRatioX96: amountOutResult.SqrtRatioX96,
+			State:                 amountOutResult.State,
+			nextStateLiquidity:    amountOutResult.Liquidity,
+			nextStateTickCurrent:  amountOutResult.CurrentTick,
+		},
+	}, nil
+}
+
+func (p *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
+	return p.CalcAmountInWith(param, p.swap)
+}
+
+// CalcAmountInWith quotes an exact output swap with a fork-specific swap function
+func (p *PoolSimulator) CalcAmountInWith(param pool.CalcAmountInParams,
+	swap SwapFunc) (*pool.CalcAmountInResult, error) {
+	tokenIn, tokenAmountOut := param.TokenIn, param.TokenAmountOut
+	tokenInIndex, tokenOutIndex := p.GetTokenIndex(tokenIn), p.GetTokenIndex(tokenAmountOut.Token)
+	if tokenInIndex < 0 || tokenOutIndex < 0 {
+		return nil, fmt.Errorf("tokenInIndex %v or tokenOutIndex %v is not correct", tokenInIndex, tokenOutIndex)
+	}
+
+	var amountOut v3Utils.Int256
+	if overflow := amountOut.SetFromBig(tokenAmountOut.Amount); overflow {
+		return nil, ErrOverflow
+	}
+	var priceLimit v3Utils.Uint160
+	if err := p.GetSqrtPriceLimit(tokenInIndex == 0, &priceLimit); err != nil {
+		return nil, err
+	}
+	amountInResult, err := swap(tokenInIndex == 0, amountOut.Neg(&amountOut), &priceLimit)
+	if err != nil {
+		return nil, fmt.Errorf("can not GetInputAmount, err: %w", err)
+	} else if amountInResult.ReturnedAmount.Neg(amountInResult.ReturnedAmount).Sign() <= 0 {
+		return nil, ErrZeroAmountIn
+	}
+
+	remainingTokenAmountOut := &pool.TokenAmount{
+		Token:  tokenAmountOut.Token,
+		Amount: bignumber.ZeroBI,
+	}
+	if amountInResult.RemainingAmountIn != nil && amountInResult.RemainingAmountIn.Sign() != 0 {
+		remainingTokenAmountOut.Amount = amountInResult.RemainingAmountIn.Neg(amountInResult.RemainingAmountIn).ToBig()
+	}
+
+	return &pool.CalcAmountInResult{
+		TokenAmountIn: &pool.TokenAmount{
+			Token:  tokenIn,
+			Amount: amountInResult.ReturnedAmount.ToBig(),
+		},
+		RemainingTokenAmountOut: remainingTokenAmountOut,
+		Fee:                     amountInResult.fee(tokenIn),
+		Gas:                     p.Gas.BaseGas + p.Gas.CrossInitTickGas*int64(amountInResult.CrossInitTickLoops),
+		SwapInfo: SwapInfo{
+			NextStateSqrtRatioX96: amountInResult.SqrtRatioX96,
+			State:                 amountInResult.State,
+			nextStateLiquidity:    amountInResult.Liquidity,
+			nextStateTickCurrent:  amountInResult.CurrentTick,
+		},
+	}, nil
+}
+
+// GetCalldataSize returns the calldata size of a swap through the pool, which is the same for all forks
+func (p *PoolSimulator) GetCalldataSize(_, _ string) int {
+	return calldataSize
+}
+
+// swap quotes a swap with the fee of the configured fee policy
+func (p *PoolSimulator) swap(zeroForOne bool, amountSpecified *v3Utils.Int256,
+	priceLimit *v3Utils.Uint160) (*SwapResult, error) {
+	if p.hook != nil {
+		if err := p.hook.BeforeSwap(p, zeroForOne); err != nil {
+			return nil, err
+		}
+	}
+
+	fee := p.V3Pool.Fee
+	if p.feePolicy != nil {
+		var err error
+		if fee, err = p.feePolicy.SwapFee(p, zeroForOne); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := p.Swap(zeroForOne, amountSpecified, priceLimit, fee, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapResult{GetAmountResultV2: result}, nil
+}
+
+// Clone clones the fields of the pool simulator updated by UpdateBalance, for forks to implement CloneState
+func (p *PoolSimulator) Clone() *PoolSimulator {
+	cloned := *p
+	v3Pool := *p.V3Pool
+	v3Pool.SqrtRatioX96 = v3Pool.SqrtRatioX96.Clone()
+	v3Pool.Liquidity = v3Pool.Liquidity.Clone()
+	cloned.V3Pool = &v3Pool
+	return &cloned
+}
+
+func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
+	si, ok := params.SwapInfo.(SwapInfo)
+	if !ok {
+		logger.Warn("failed to UpdateBalance for CL pool, wrong swapInfo type")
+		return
+	}
+	p.V3Pool.SqrtRatioX96 = si.NextStateSqrtRatioX96
+	p.V3Pool.Liquidity = si.nextStateLiquidity
+	p.V3Pool.TickCurrent = si.nextStateTickCurrent
+	if p.hook != nil {
+		p.hook.AfterSwap(p, si)
+	}
+}
+
+func (r *SwapResult) fee(token string) *pool.TokenAmount {
+	fee := &pool.TokenAmount{Token: token}
+	if r.Fee != nil {
+		fee.Amount = r.Fee.ToBig()
+	}
+	return fee
+}
@@ -0,0 +1,267 @@
+package clcore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
+	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
+	coreEntities "github.com/daoleno/uniswap-sdk-core/entities"
+	legacyConstants "github.com/daoleno/uniswapv3-sdk/constants"
+	legacyEntities "github.com/daoleno/uniswapv3-sdk/entities"
+	legacyUtils "github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+var (
+	token0 = "0x912ce59144191c1204e64559fe8253a0e49e6548"
+	token1 = "0xff970a61a04b1ca14834a43f5de4533ebddb5cc8"
+
+	entityPool = entity.Pool{
+		Address:  "0x2760cc828b2e4d04f8ec261a5335426bb22d9291",
+		Exchange: "ramses-v2",
+		Type:     "ramses-v2",
+		SwapFee:  500,
+		Reserves: entity.PoolReserves{"69893656923366160706", "2169623"},
+		Tokens:   []*entity.PoolToken{{Address: token0, Decimals: 18}, {Address: token1, Decimals: 6}},
+		Extra:    "{\"liquidity\":481329773989005,\"sqrtPriceX96\":55312754561266099398800,\"tickSpacing\":10,\"tick\":-283511,\"ticks\":[{\"index\":-887270,\"liquidityGross\":106514621957,\"liquidityNet\":106514621957},{\"index\":-283610,\"liquidityGross\":312504599701008,\"liquidityNet\":312504599701008},{\"index\":-283580,\"liquidityGross\":168718659666040,\"liquidityNet\":168718659666040},{\"index\":-283380,\"liquidityGross\":17166285019404,\"liquidityNet\":17166285019404},{\"index\":-282780,\"liquidityGross\":481223259367048,\"liquidityNet\":-481223259367048},{\"index\":-278550,\"liquidityGross\":7351763429974,\"liquidityNet\":7351763429974},{\"index\":-276330,\"liquidityGross\":7351763429974,\"liquidityNet\":-7351763429974},{\"index\":-276170,\"liquidityGross\":294632869974088,\"liquidityNet\":294632869974088},{\"index\":-275820,\"liquidityGross\":22619085245,\"liquidityNet\":22619085245},{\"index\":-274030,\"liquidityGross\":294632869974088,\"liquidityNet\":-294632869974088},{\"index\":-269510,\"liquidityGross\":17166285019404,\"liquidityNet\":-17166285019404},{\"index\":887270,\"liquidityGross\":129133707202,\"liquidityNet\":-129133707202}]}",
+	}
+)
+
+// quote is the part of a quote compared between implementations
+type quote struct {
+	err          bool
+	amount       string
+	remaining    string
+	sqrtPriceX96 string
+	liquidity    string
+	tick         int
+}
+
+func newPoolSimulator(t *testing.T, cfg Config) *PoolSimulator {
+	var extra ExtraTickU256
+	require.NoError(t, json.Unmarshal([]byte(entityPool.Extra), &extra))
+	p, err := NewPoolSimulator(entityPool, 42161, &extra, constants.FeeAmount(entityPool.SwapFee), cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func engineQuote(p *PoolSimulator, tokenIn, tokenOut string, amount *big.Int, exactIn bool) quote {
+	var res any
+	var err error
+	if exactIn {
+		res, err = p.CalcAmountOut(pool.CalcAmountOutParams{
+			TokenAmountIn: pool.TokenAmount{Token: tokenIn, Amount: amount}, TokenOut: tokenOut})
+	} else {
+		res, err = p.CalcAmountIn(pool.CalcAmountInParams{
+			TokenAmountOut: pool.TokenAmount{Token: tokenOut, Amount: amount}, TokenIn: tokenIn})
+	}
+	if err != nil {
+		return quote{err: true}
+	}
+	var q quote
+	var swapInfo SwapInfo
+	switch res := res.(type) {
+	case *pool.CalcAmountOutResult:
+		q.amount, q.remaining = res.TokenAmountOut.Amount.String(), res.RemainingTokenAmountIn.Amount.String()
+		swapInfo = res.SwapInfo.(SwapInfo)
+	case *pool.CalcAmountInResult:
+		q.amount, q.remaining = res.TokenAmountIn.Amount.String(), res.RemainingTokenAmountOut.Amount.String()
+		swapInfo = res.SwapInfo.(SwapInfo)
+	}
+	q.sqrtPriceX96 = swapInfo.NextStateSqrtRatioX96.Dec()
+	q.liquidity = swapInfo.nextStateLiquidity.Dec()
+	q.tick = swapInfo.nextStateTickCurrent
+	return q
+}
+
+// legacyQuote quotes with the big.Int SDK as ramses-v2, nuri-v2 and solidly-v3 did, with the price limit at the last
+// initialized tick
+func legacyQuote(t *testing.T, tokenIn, tokenOut string, amount *big.Int, exactIn bool) quote {
+	var extra struct {
+		Liquidity    *big.Int `json:"liquidity"`
+		SqrtPriceX96 *big.Int `json:"sqrtPriceX96"`
+		TickSpacing  uint64   `json:"tickSpacing"`
+		Tick         *big.Int `json:"tick"`
+		Ticks        []Tick   `json:"ticks"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(entityPool.Extra), &extra))
+	tokens := lo.Map(entityPool.Tokens, func(token *entity.PoolToken, _ int) *coreEntities.Token {
+		return coreEntities.NewToken(42161, common.HexToAddress(token.Address), uint(token.Decimals), "", "")
+	})
+	v3Ticks := lo.Map(extra.Ticks, func(tick Tick, _ int) legacyEntities.Tick {
+		return legacyEntities.Tick{Index: tick.Index, LiquidityGross: tick.LiquidityGross,
+			LiquidityNet: tick.LiquidityNet}
+	})
+	ticks, err := legacyEntities.NewTickListDataProvider(v3Ticks, int(extra.TickSpacing))
+	require.NoError(t, err)
+	v3Pool, err := legacyEntities.NewPool(tokens[0], tokens[1], legacyConstants.FeeAmount(entityPool.SwapFee),
+		extra.SqrtPriceX96, extra.Liquidity, int(extra.Tick.Int64()), ticks)
+	require.NoError(t, err)
+
+	zeroForOne := tokenIn == token0
+	tickLimit := lo.Ternary(zeroForOne, v3Ticks[0].Index, v3Ticks[len(v3Ticks)-1].Index)
+	priceLimit, err := legacyUtils.GetSqrtRatioAtTick(tickLimit)
+	require.NoError(t, err)
+
+	var q quote
+	var newPool *legacyEntities.Pool
+	if exactIn {
+		res, err := v3Pool.GetOutputAmount(coreEntities.FromRawAmount(tokens[lo.Ternary(zeroForOne, 0, 1)], amount),
+			priceLimit)
+		if err != nil || res.ReturnedAmount.Quotient().Sign() <= 0 {
+			return quote{err: true}
+		}
+		q.amount, q.remaining, newPool = res.ReturnedAmount.Quotient().String(),
+			res.RemainingAmountIn.Quotient().String(), res.NewPoolState
+	} else {
+		res, err := v3Pool.GetInputAmount(coreEntities.FromRawAmount(tokens[lo.Ternary(zeroForOne, 1, 0)], amount),
+			priceLimit)
+		if err != nil || res.ReturnedAmount.Quotient().Sign() <= 0 {
+			return quote{err: true}
+		}
+		// the big.Int SDK reports the remaining amount out as negative
+		q.amount, q.remaining, newPool = res.ReturnedAmount.Quotient().String(),
+			new(big.Int).Abs(res.RemainingAmountOut.Quotient()).String(), res.NewPoolState
+	}
+	q.sqrtPriceX96, q.liquidity, q.tick = newPool.SqrtRatioX96.String(), newPool.Liquidity.String(), newPool.TickCurrent
+	return q
+}
+
+// uniswapV3Quote quotes with the uint256 SDK as uniswapv3 did, with the price limit one wei beyond the last
+// initialized tick
+func uniswapV3Quote(p *PoolSimulator, tokenIn string, amount *big.Int, exactIn bool) quote {
+	zeroForOne := tokenIn == token0
+	var priceLimit v3Utils.Uint160
+	_ = v3Utils.GetSqrtRatioAtTickV2(lo.Ternary(zeroForOne, p.tickMin, p.tickMax), &priceLimit)
+	lo.Ternary(zeroForOne, priceLimit.AddUint64, priceLimit.SubUint64)(&priceLimit, 1)
+
+	if exactIn {
+		var amountIn v3Utils.Int256
+		amountIn.SetFromBig(amount)
+		res, err := p.V3Pool.GetOutputAmountV2(&amountIn, zeroForOne, &priceLimit)
+		if err != nil || res.ReturnedAmount.Sign() <= 0 {
+			return quote{err: true}
+		}
+		return quote{amount: res.ReturnedAmount.Dec(), remaining: res.RemainingAmountIn.Dec(),
+			sqrtPriceX96: res.SqrtRatioX96.Dec(), liquidity: res.Liquidity.Dec(), tick: res.CurrentTick}
+	}
+	amountIn, newPool, err := p.V3Pool.GetInputAmount(coreEntities.FromRawAmount(
+		lo.Ternary(zeroForOne, p.V3Pool.Token1, p.V3Pool.Token0), amount), &priceLimit)
+	if err != nil || amountIn.Quotient().Sign() <= 0 {
+		return quote{err: true}
+	}
+	return quote{amount: amountIn.Quotient().String(), sqrtPriceX96: newPool.SqrtRatioX96.Dec(),
+		liquidity: newPool.Liquidity.Dec(), tick: newPool.TickCurrent}
+}
+
+func parityAmounts() []*big.Int {
+	amounts := make([]*big.Int, 0, 24)
+	for exp := 2; exp <= 24; exp++ {
+		amounts = append(amounts, bignumber.TenPowInt(exp), new(big.Int).Mul(big.NewInt(37), bignumber.TenPowInt(exp)))
+	}
+	return amounts
+}
+
+func TestPoolSimulator_ParityWithLegacySDK(t *testing.T) {
+	t.Parallel()
+	p := newPoolSimulator(t, Config{})
+	for _, exactIn := range []bool{true, false} {
+		for _, tokens := range [][2]string{{token0, token1}, {token1, token0}} {
+			for _, amount := range parityAmounts() {
+				name := fmt.Sprintf("exactIn=%v %s->%s %s", exactIn, tokens[0][:6], tokens[1][:6], amount)
+				t.Run(name, func(t *testing.T) {
+					assert.Equal(t, legacyQuote(t, tokens[0], tokens[1], amount, exactIn),
+						engineQuote(p, tokens[0], tokens[1], amount, exactIn))
+				})
+			}
+		}
+	}
+}
+
+func TestPoolSimulator_ParityWithUniswapV3(t *testing.T) {
+	t.Parallel()
+	p := newPoolSimulator(t, Config{CrossLastTick: true})
+	for _, exactIn := range []bool{true, false} {
+		for _, tokens := range [][2]string{{token0, token1}, {token1, token0}} {
+			for _, amount := range parityAmounts() {
+				name := fmt.Sprintf("exactIn=%v %s->%s %s", exactIn, tokens[0][:6], tokens[1][:6], amount)
+				t.Run(name, func(t *testing.T) {
+					want := uniswapV3Quote(p, tokens[0], amount, exactIn)
+					got := engineQuote(p, tokens[0], tokens[1], amount, exactIn)
+					if !exactIn {
+						// uniswapv3 did not report the remaining amount out of exact output swaps
+						got.remaining = ""
+					}
+					assert.Equal(t, want, got)
+				})
+			}
+		}
+	}
+}
+
+type lockHook struct {
+	locked  bool
+	updates int
+}
+
+var errLocked = errors.New("LOK")
+
+func (h *lockHook) BeforeSwap(*PoolSimulator, bool) error {
+	return lo.Ternary(h.locked, errLocked, nil)
+}
+
+func (h *lockHook) AfterSwap(*PoolSimulator, SwapInfo) {
+	h.updates++
+}
+
+func TestPoolSimulator_FeePolicy(t *testing.T) {
+	t.Parallel()
+	amountIn := bignumber.TenPowInt(18)
+	base := engineQuote(newPoolSimulator(t, Config{}), token0, token1, amountIn, true)
+	p := newPoolSimulator(t, Config{FeePolicy: &DirectionalFee{ZeroForOne: 100, OneForZero: 10000}})
+
+	lowFee := engineQuote(p, token0, token1, amountIn, true)
+	assert.Greater(t, len(lowFee.amount), 0)
+	assert.Equal(t, 1, bignumber.NewBig10(lowFee.amount).Cmp(bignumber.NewBig10(base.amount)),
+		"lower fee should give a better quote")
+	assert.Equal(t, constants.FeeAmount(500), p.V3Pool.Fee, "fee policy must not mutate the pool")
+
+	highFee := engineQuote(p, token1, token0, big.NewInt(1e6), true)
+	baseReverse := engineQuote(newPoolSimulator(t, Config{}), token1, token0, big.NewInt(1e6), true)
+	assert.Equal(t, -1, bignumber.NewBig10(highFee.amount).Cmp(bignumber.NewBig10(baseReverse.amount)))
+}
+
+func TestPoolSimulator_GetCalldataSize(t *testing.T) {
+	t.Parallel()
+	var sizer pool.IPoolCalldataSizer = newPoolSimulator(t, Config{})
+	assert.Equal(t, 192, sizer.GetCalldataSize(token0, token1))
+}
+
+func TestPoolSimulator_Hook(t *testing.T) {
+	t.Parallel()
+	hook := &lockHook{locked: true}
+	p := newPoolSimulator(t, Config{Hook: hook})
+	_, err := p.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: token0, Amount: big.NewInt(1e18)}, TokenOut: token1})
+	assert.ErrorContains(t, err, errLocked.Error())
+
+	hook.locked = false
+	res, err := p.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: token0, Amount: big.NewInt(1e18)}, TokenOut: token1})
+	require.NoError(t, err)
+	cloned := p.Clone()
+	p.UpdateBalance(pool.UpdateBalanceParams{SwapInfo: res.SwapInfo})
+	assert.Equal(t, 1, hook.updates)
+	assert.NotEqual(t, cloned.V3Pool.SqrtRatioX96.Dec(), p.V3Pool.SqrtRatioX96.Dec())
+}
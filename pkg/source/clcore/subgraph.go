@@ -0,0 +1,72 @@
+package clcore
+
+import (
+	"context"
+
+	"github.com/KyberNetwork/logger"
+
+	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
+// TicksPageSize is the number of ticks the subgraph tick queries fetch at a time
+const TicksPageSize = 1000
+
+// TicksQuery builds the subgraph query of the next TicksPageSize initialized ticks of a pool after lastTickIdx, ordered
+// by tick index. The ticks are returned either at the top level or under pool, optionally with _meta.
+type TicksQuery func(allowSubgraphError bool, poolAddress string, lastTickIdx string) string
+
+// GetPoolTicks fetches all the initialized ticks of a pool from a subgraph, page by page. With allowSubgraphError,
+// errors are ignored as long as the subgraph still returns ticks.
+func GetPoolTicks(ctx context.Context, graphqlClient *graphqlpkg.Client, query TicksQuery, dexID, poolAddress string,
+	allowSubgraphError bool) ([]ticklens.TickResp, error) {
+	lastTickIdx := ""
+	var ticks []ticklens.TickResp
+
+	for {
+		req := graphqlpkg.NewRequest(query(allowSubgraphError, poolAddress, lastTickIdx))
+
+		var resp struct {
+			Ticks []ticklens.TickResp `json:"ticks"`
+			Pool  *struct {
+				Ticks []ticklens.TickResp `json:"ticks"`
+			} `json:"pool"`
+			Meta *valueobject.SubgraphMeta `json:"_meta"`
+		}
+
+		if err := graphqlClient.Run(ctx, req, &resp); err != nil {
+			// Workaround at the moment to live with the error subgraph on Arbitrum
+			if !allowSubgraphError || resp.Ticks == nil && resp.Pool == nil {
+				logger.WithFields(logger.Fields{
+					"dexID":              dexID,
+					"poolAddress":        poolAddress,
+					"error":              err,
+					"allowSubgraphError": allowSubgraphError,
+				}).Error("failed to query subgraph")
+
+				return nil, err
+			}
+		}
+
+		resp.Meta.CheckIsLagging(dexID, poolAddress)
+
+		page := resp.Ticks
+		if resp.Pool != nil {
+			page = resp.Pool.Ticks
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		ticks = append(ticks, page...)
+
+		if len(page) < TicksPageSize {
+			break
+		}
+
+		lastTickIdx = page[len(page)-1].TickIdx
+	}
+
+	return ticks, nil
+}
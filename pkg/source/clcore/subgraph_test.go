@@ -0,0 +1,85 @@
+package clcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
+)
+
+// newTicksSubgraph serves numTicks ticks page by page, with the query being the last tick index and whether the ticks
+// are nested in pool. failAfter makes it return an error along with the ticks after the given number of requests.
+func newTicksSubgraph(t *testing.T, numTicks int, nested bool, failAfter int) *httptest.Server {
+	requests := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		lastTickIdx := -1
+		if req.Query != "" {
+			lastTickIdx, _ = strconv.Atoi(req.Query)
+		}
+
+		ticks := make([]string, 0, TicksPageSize)
+		for i := lastTickIdx + 1; i < numTicks && len(ticks) < TicksPageSize; i++ {
+			ticks = append(ticks, fmt.Sprintf(`{"tickIdx":"%d","liquidityNet":"1","liquidityGross":"1"}`, i))
+		}
+		data := fmt.Sprintf(`{"ticks":[%s]}`, strings.Join(ticks, ","))
+		if nested {
+			data = fmt.Sprintf(`{"pool":%s}`, data)
+		}
+		var errs string
+		if requests++; failAfter > 0 && requests > failAfter {
+			errs = `,"errors":[{"message":"indexing error"}]`
+		}
+		_, _ = fmt.Fprintf(w, `{"data":%s%s}`, data, errs)
+	}))
+}
+
+func TestGetPoolTicks(t *testing.T) {
+	t.Parallel()
+	query := func(_ bool, _ string, lastTickIdx string) string { return lastTickIdx }
+
+	for _, tc := range []struct {
+		name               string
+		numTicks           int
+		nested             bool
+		failAfter          int
+		allowSubgraphError bool
+		wantErr            bool
+	}{
+		{name: "ticks", numTicks: 2500},
+		{name: "pool ticks", numTicks: 2500, nested: true},
+		{name: "full last page", numTicks: 2 * TicksPageSize},
+		{name: "no ticks", numTicks: 0, nested: true},
+		{name: "subgraph error", numTicks: 2500, failAfter: 1, wantErr: true},
+		{name: "allowed subgraph error", numTicks: 2500, failAfter: 1, allowSubgraphError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTicksSubgraph(t, tc.numTicks, tc.nested, tc.failAfter)
+			defer server.Close()
+
+			ticks, err := GetPoolTicks(context.Background(), graphqlpkg.NewClient(server.URL), query, "dex",
+				"0xpool", tc.allowSubgraphError)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, ticks, tc.numTicks)
+			for i, tick := range ticks {
+				assert.Equal(t, strconv.Itoa(i), tick.TickIdx)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package clcore
+
+import (
+	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
+)
+
+// Swap runs the swap loop of the pool from its current state with the given fee, calling onStep with the fee amount
+// of each step if not nil. amountSpecified is positive for exact input swaps and negative for exact output swaps.
+// The pool state is not updated.
+func (p *PoolSimulator) Swap(zeroForOne bool, amountSpecified *v3Utils.Int256, priceLimit *v3Utils.Uint160,
+	fee constants.FeeAmount, onStep StepFunc) (*v3Entities.GetAmountResultV2, error) {
+	v3Pool := p.V3Pool
+	if zeroForOne {
+		if priceLimit.Cmp(v3Pool.SqrtRatioX96) >= 0 || priceLimit.Cmp(v3Utils.MinSqrtRatioU256) <= 0 {
+			return nil, ErrInvalidSqrtPriceLimit
+		}
+	} else if priceLimit.Cmp(v3Pool.SqrtRatioX96) <= 0 || priceLimit.Cmp(v3Utils.MaxSqrtRatioU256) >= 0 {
+		return nil, ErrInvalidSqrtPriceLimit
+	}
+
+	exactInput := amountSpecified.Sign() >= 0
+	amountRemaining := new(v3Utils.Int256).Set(amountSpecified)
+	amountCalculated := new(v3Utils.Int256)
+	sqrtPriceX96 := new(v3Utils.Uint160).Set(v3Pool.SqrtRatioX96)
+	tick := v3Pool.TickCurrent
+	liquidity := new(v3Utils.Uint128).Set(v3Pool.Liquidity)
+	// only loops crossing (or with chargeSteps, towards) an initialized tick are counted, as only they use significant
+	// gas
+	crossInitTickLoops := 0
+
+	var sqrtPriceStartX96, sqrtPriceNextX96, sqrtPriceTargetX96, nextSqrtPriceX96 v3Utils.Uint160
+	var amountIn, amountOut, feeAmount, amountInPlusFee v3Utils.Uint256
+	var amountInPlusFeeSigned, amountOutSigned v3Utils.Int256
+	for !amountRemaining.IsZero() && sqrtPriceX96.Cmp(priceLimit) != 0 {
+		sqrtPriceStartX96.Set(sqrtPriceX96)
+
+		var tickNext int
+		var initialized bool
+		var err error
+		if p.wordBoundaries {
+			tickNext, initialized, err = v3Pool.TickDataProvider.NextInitializedTickWithinOneWord(tick, zeroForOne,
+				p.tickSpacing)
+		} else {
+			tickNext, initialized, err = v3Pool.TickDataProvider.NextInitializedTickIndex(tick, zeroForOne)
+		}
+		if err != nil {
+			return nil, err
+		}
+		tickNext = min(max(tickNext, v3Utils.MinTick), v3Utils.MaxTick)
+		if initialized && p.chargeSteps {
+			crossInitTickLoops++
+		}
+
+		if err = v3Utils.GetSqrtRatioAtTickV2(tickNext, &sqrtPriceNextX96); err != nil {
+			return nil, err
+		}
+		if zeroForOne == (sqrtPriceNextX96.Cmp(priceLimit) < 0) {
+			sqrtPriceTargetX96.Set(priceLimit)
+		} else {
+			sqrtPriceTargetX96.Set(&sqrtPriceNextX96)
+		}
+
+		if err = v3Utils.ComputeSwapStep(sqrtPriceX96, &sqrtPriceTargetX96, liquidity, amountRemaining, fee,
+			&nextSqrtPriceX96, &amountIn, &amountOut, &feeAmount); err != nil {
+			return nil, err
+		}
+		sqrtPriceX96.Set(&nextSqrtPriceX96)
+
+		amountInPlusFee.Add(&amountIn, &feeAmount)
+		if err = v3Utils.ToInt256(&amountInPlusFee, &amountInPlusFeeSigned); err != nil {
+			return nil, err
+		}
+		if err = v3Utils.ToInt256(&amountOut, &amountOutSigned); err != nil {
+			return nil, err
+		}
+		if exactInput {
+			amountRemaining.Sub(amountRemaining, &amountInPlusFeeSigned)
+			amountCalculated.Sub(amountCalculated, &amountOutSigned)
+		} else {
+			amountRemaining.Add(amountRemaining, &amountOutSigned)
+			amountCalculated.Add(amountCalculated, &amountInPlusFeeSigned)
+		}
+
+		if onStep != nil {
+			if err = onStep(&feeAmount); err != nil {
+				return nil, err
+			}
+		}
+
+		if sqrtPriceX96.Cmp(&sqrtPriceNextX96) == 0 {
+			// if the tick is initialized, run the tick transition
+			if initialized {
+				tickData, err := v3Pool.TickDataProvider.GetTick(tickNext)
+				if err != nil {
+					return nil, err
+				}
+				liquidityNet := tickData.LiquidityNet
+				// if we're moving leftward, we interpret liquidityNet as the opposite sign
+				if zeroForOne {
+					liquidityNet = new(v3Utils.Int128).Neg(liquidityNet)
+				}
+				if err = v3Utils.AddDeltaInPlace(liquidity, liquidityNet); err != nil {
+					return nil, err
+				}
+				if !p.chargeSteps {
+					crossInitTickLoops++
+				}
+			}
+			if zeroForOne {
+				tick = tickNext - 1
+			} else {
+				tick = tickNext
+			}
+		} else if sqrtPriceX96.Cmp(&sqrtPriceStartX96) != 0 {
+			// recompute unless we're on a lower tick boundary (i.e. already transitioned ticks), and haven't moved
+			if tick, err = v3Utils.GetTickAtSqrtRatioV2(sqrtPriceX96); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &v3Entities.GetAmountResultV2{
+		ReturnedAmount:     amountCalculated.Neg(amountCalculated),
+		RemainingAmountIn:  amountRemaining,
+		SqrtRatioX96:       sqrtPriceX96,
+		Liquidity:          liquidity,
+		CurrentTick:        tick,
+		CrossInitTickLoops: crossInitTickLoops,
+	}, nil
+}
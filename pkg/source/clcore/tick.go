@@ -0,0 +1,52 @@
+package clcore
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/KyberNetwork/logger"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
+)
+
+// TransformTickRespToTick parses a tick fetched from a subgraph or TickLens
+func TransformTickRespToTick(tickResp ticklens.TickResp) (Tick, error) {
+	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
+	if !ok {
+		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
+	}
+
+	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
+	if !ok {
+		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
+	}
+
+	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
+	if err != nil {
+		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
+	}
+
+	return Tick{
+		Index:          tickIdx,
+		LiquidityGross: liquidityGross,
+		LiquidityNet:   liquidityNet,
+	}, nil
+}
+
+// TransformTickResps parses ticks fetched from a subgraph or TickLens, logging and skipping invalid ones
+func TransformTickResps(l logger.Logger, tickResps []ticklens.TickResp) []Tick {
+	var ticks []Tick
+	for _, tickResp := range tickResps {
+		tick, err := TransformTickRespToTick(tickResp)
+		if err != nil {
+			l.WithFields(logger.Fields{
+				"error": err,
+			}).Error("failed to transform tickResp to tick")
+			continue
+		}
+
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
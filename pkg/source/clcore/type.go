@@ -0,0 +1,113 @@
+package clcore
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/int256"
+	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
+	"github.com/holiman/uint256"
+)
+
+type Gas struct {
+	BaseGas          int64
+	CrossInitTickGas int64
+}
+
+// Config is the fork-specific behavior of a concentrated liquidity pool
+type Config struct {
+	Gas Gas
+	// TickSpacings maps fee tiers to tick spacings, for pools whose state does not have a tick spacing. Pools without
+	// tick spacing are rejected if nil.
+	TickSpacings map[constants.FeeAmount]int
+	// CrossLastTick sets the price limit one wei beyond the last initialized tick instead of at it, so that swaps can
+	// cross it
+	CrossLastTick bool
+	// WordBoundaries stops swap steps at every tick bitmap word boundary like the contracts do, instead of going
+	// straight to the next initialized tick. Each step rounds, so quotes differ slightly.
+	WordBoundaries bool
+	// ChargeInitTickSteps charges CrossInitTickGas for every swap step towards an initialized tick, including the last
+	// one which may not be crossed, instead of for crossed initialized ticks only
+	ChargeInitTickSteps bool
+	// StaleTick keeps the tick of the state even if it is not the tick of the price, like the Algebra pools do, instead
+	// of rejecting the state
+	StaleTick bool
+	// FeePolicy overrides the pool fee per swap direction. The pool fee is used if nil.
+	FeePolicy FeePolicy
+	// Hook is called around swaps, e.g. to check that the pool is unlocked or to update an oracle. Optional.
+	Hook Hook
+}
+
+// FeePolicy computes the swap fee of a pool, in hundredths of a bip
+type FeePolicy interface {
+	SwapFee(p *PoolSimulator, zeroForOne bool) (constants.FeeAmount, error)
+}
+
+// DirectionalFee is a fee policy with a fixed fee per swap direction
+type DirectionalFee struct {
+	ZeroForOne constants.FeeAmount
+	OneForZero constants.FeeAmount
+}
+
+func (f *DirectionalFee) SwapFee(_ *PoolSimulator, zeroForOne bool) (constants.FeeAmount, error) {
+	if zeroForOne {
+		return f.ZeroForOne, nil
+	}
+	return f.OneForZero, nil
+}
+
+// Hook is called by PoolSimulator around swaps
+type Hook interface {
+	// BeforeSwap is called before quoting a swap and can reject it
+	BeforeSwap(p *PoolSimulator, zeroForOne bool) error
+	// AfterSwap is called by UpdateBalance after the pool state is updated
+	AfterSwap(p *PoolSimulator, swapInfo SwapInfo)
+}
+
+// SwapInfo is the state of a pool after a swap
+type SwapInfo struct {
+	RemainingAmountIn     *v3Utils.Int256  `json:"rAI,omitempty"`
+	NextStateSqrtRatioX96 *v3Utils.Uint160 `json:"nSqrtRx96"`
+	// State is the fork-specific state after the swap, from SwapResult.State
+	State                any `json:"-"`
+	nextStateLiquidity   *v3Utils.Uint128
+	nextStateTickCurrent int
+}
+
+// SwapFunc quotes a swap of amountSpecified, positive for exact input and negative for exact output, that stops at
+// priceLimit
+type SwapFunc func(zeroForOne bool, amountSpecified *v3Utils.Int256, priceLimit *v3Utils.Uint160) (*SwapResult, error)
+
+// SwapResult is the result of a swap quote
+type SwapResult struct {
+	*v3Entities.GetAmountResultV2
+	// Fee is the fee amount reported in the quote, in the token in. Optional.
+	Fee *v3Utils.Uint256
+	// State is the fork-specific state after the swap, passed to UpdateBalance in SwapInfo.State. Optional.
+	State any
+}
+
+// StepFunc is called with the fee amount of each swap step, e.g. to split it into protocol fees
+type StepFunc func(feeAmount *v3Utils.Uint256) error
+
+type Tick struct {
+	Index          int      `json:"index"`
+	LiquidityGross *big.Int `json:"liquidityGross"`
+	LiquidityNet   *big.Int `json:"liquidityNet"`
+}
+
+type TickU256 struct {
+	Index          int          `json:"index"`
+	LiquidityGross *uint256.Int `json:"liquidityGross"`
+	LiquidityNet   *int256.Int  `json:"liquidityNet"`
+}
+
+// ExtraTickU256 is the state of a concentrated liquidity pool as written by the pool trackers
+type ExtraTickU256 struct {
+	Liquidity    *uint256.Int `json:"liquidity"`
+	SqrtPriceX96 *uint256.Int `json:"sqrtPriceX96"`
+	TickSpacing  uint64       `json:"tickSpacing"`
+	Tick         *int         `json:"tick"`
+	Ticks        []TickU256   `json:"ticks"`
+}
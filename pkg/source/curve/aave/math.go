@@ -14,6 +14,9 @@ var FeeDenominator = utils.NewBig10("10000000000")
 
 const MaxLoopLimit = 256
 
+// dynamicFeeLoopLimit bounds the iterations to find the dynamic fee of an exact output swap
+const dynamicFeeLoopLimit = 8
+
 var APrecision = big.NewInt(100)
 
 /**
@@ -735,3 +738,65 @@ func GetDyUnderlying(
 	var _fee = new(big.Int).Div(new(big.Int).Mul(dynamicFee, dy), FeeDenominator)
 	return new(big.Int).Sub(dy, _fee), _fee, nil
 }
+
+// GetDxUnderlying calculates the input dx given the output dy. The dynamic fee depends on the balances after the
+// swap, so it is recalculated from the previous estimate until it converges.
+func GetDxUnderlying(
+	balances []*big.Int,
+	tokenPrecisionMultipliers []*big.Int,
+	futureATime int64,
+	futureA *big.Int,
+	initialATime int64,
+	initialA *big.Int,
+	swapFee *big.Int,
+	offPegFeeMultiplier *big.Int,
+	tokenIndexFrom int,
+	tokenIndexTo int,
+	dy *big.Int,
+) (*big.Int, *big.Int, error) {
+	xp, err := _xp(balances, tokenPrecisionMultipliers)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dynamicFee = _dynamicFee(xp[tokenIndexFrom], xp[tokenIndexTo], swapFee, offPegFeeMultiplier)
+	var x, dyWithFee *big.Int
+	for k := 0; k < dynamicFeeLoopLimit; k++ {
+		dyWithFee = new(big.Int).Div(
+			new(big.Int).Mul(dy, FeeDenominator),
+			new(big.Int).Sub(FeeDenominator, dynamicFee),
+		)
+		var y = new(big.Int).Sub(xp[tokenIndexTo], new(big.Int).Mul(dyWithFee, tokenPrecisionMultipliers[tokenIndexTo]))
+		if y.Sign() <= 0 {
+			return nil, nil, ErrWithdrawMoreThanAvailable
+		}
+		x, err = getY(
+			futureATime,
+			futureA,
+			initialATime,
+			initialA,
+			tokenIndexTo,
+			tokenIndexFrom,
+			y,
+			xp,
+			nil,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		var fee = _dynamicFee(
+			new(big.Int).Div(new(big.Int).Add(xp[tokenIndexFrom], x), constant.Two),
+			new(big.Int).Div(new(big.Int).Add(xp[tokenIndexTo], y), constant.Two),
+			swapFee,
+			offPegFeeMultiplier,
+		)
+		if fee.Cmp(dynamicFee) == 0 {
+			break
+		}
+		dynamicFee = fee
+	}
+	dx := new(big.Int).Div(
+		new(big.Int).Sub(x, xp[tokenIndexFrom]),
+		tokenPrecisionMultipliers[tokenIndexFrom],
+	)
+	return dx, new(big.Int).Sub(dyWithFee, dy), nil
+}
@@ -118,6 +118,47 @@ func (t *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	return &pool.CalcAmountOutResult{}, fmt.Errorf("tokenIndexFrom or tokenIndexTo is not correct: tokenIndexFrom: %v, tokenIndexTo: %v", tokenIndexFrom, tokenIndexTo)
 }
 
+func (t *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
+	tokenAmountOut := param.TokenAmountOut
+	tokenIn := param.TokenIn
+	var tokenIndexFrom = t.GetTokenIndex(tokenIn)
+	var tokenIndexTo = t.GetTokenIndex(tokenAmountOut.Token)
+	if tokenIndexFrom >= 0 && tokenIndexTo >= 0 {
+		amountIn, fee, err := GetDxUnderlying(
+			t.Info.Reserves,
+			t.Multipliers,
+			t.FutureATime,
+			t.FutureA,
+			t.InitialATime,
+			t.InitialA,
+			t.Info.SwapFee,
+			t.OffpegFeeMultiplier,
+			tokenIndexFrom,
+			tokenIndexTo,
+			tokenAmountOut.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if amountIn.Cmp(bignumber.ZeroBI) <= 0 {
+			return nil, ErrZero
+		}
+
+		return &pool.CalcAmountInResult{
+			TokenAmountIn: &pool.TokenAmount{
+				Token:  tokenIn,
+				Amount: amountIn,
+			},
+			Fee: &pool.TokenAmount{
+				Token:  tokenAmountOut.Token,
+				Amount: fee,
+			},
+			Gas: t.gas.Exchange,
+		}, nil
+	}
+	return &pool.CalcAmountInResult{}, fmt.Errorf("tokenIndexFrom or tokenIndexTo is not correct: tokenIndexFrom: %v, tokenIndexTo: %v", tokenIndexFrom, tokenIndexTo)
+}
+
 func (t *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	input, output := params.TokenAmountIn, params.TokenAmountOut
 	var inputAmount = input.Amount
@@ -44,6 +44,8 @@ func TestCalcAmountOut(t *testing.T) {
 			"Au", "Bu", "Cu"),
 	})
 	require.Nil(t, err)
+	// get_dy rounding decreases once the output saturates at the pool balance, as in stable-ng
+	testutil.TestPoolSimulator(t, p, testutil.CheckMonotonic)
 
 	assert.Equal(t, []string{"Au", "Bu"}, p.CanSwapTo("Cu"))
 	assert.Equal(t, []string{"Au", "Cu"}, p.CanSwapTo("Bu"))
@@ -69,6 +71,27 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
+func TestCalcAmountIn(t *testing.T) {
+	t.Parallel()
+	p, err := NewPoolSimulator(entity.Pool{
+		Exchange: "",
+		Type:     "",
+		Reserves: entity.PoolReserves{"8374598852113385564139023", "8328286891683", "5035549096857"},
+		Tokens:   []*entity.PoolToken{{Address: "A"}, {Address: "B"}, {Address: "C"}},
+		Extra: fmt.Sprintf("{\"offpegFeeMultiplier\": \"%v\", \"swapFee\": \"%v\", \"adminFee\": \"%v\", \"initialA\": \"%v\", \"futureA\": \"%v\"}",
+			"20000000000",
+			"4000000",
+			"5000000000",
+			20000, 200000),
+		StaticExtra: fmt.Sprintf("{\"lpToken\": \"LP\", \"precisionMultipliers\": [\"%v\", \"%v\", \"%v\"], \"underlyingTokens\": [\"%v\", \"%v\", \"%v\"]}",
+			"1", "1000000000000", "1000000000000",
+			"Au", "Bu", "Cu"),
+	})
+	require.Nil(t, err)
+
+	testutil.TestCalcAmountIn(t, p)
+}
+
 func TestAddLiquidity(t *testing.T) {
 	t.Parallel()
 	// https://polygonscan.com/address/0x445FE580eF8d70FF569aB36e80c647af338db351#readContract
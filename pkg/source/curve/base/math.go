@@ -302,6 +302,39 @@ func (t *PoolSimulator) GetDy(
 	return dy, fee, nil
 }
 
+// GetDx calculates the input dx given the output dy, like get_dx of the Y pool
+// https://github.com/curvefi/curve-contract/blob/master/contracts/pools/y/StableSwapY.vy
+func (t *PoolSimulator) GetDx(
+	i int,
+	j int,
+	dy *big.Int,
+	dCached *big.Int,
+) (*big.Int, *big.Int, error) {
+	var xp = t._xp()
+	// dy_with_fee: uint256 = dy * FEE_DENOMINATOR / (FEE_DENOMINATOR - self.fee)
+	var dyWithFee = new(big.Int).Div(
+		new(big.Int).Mul(dy, FeeDenominator),
+		new(big.Int).Sub(FeeDenominator, t.Info.SwapFee),
+	)
+
+	// y: uint256 = xp[j] - dy_with_fee * rates[j] / PRECISION
+	var y = new(big.Int).Sub(xp[j], new(big.Int).Div(new(big.Int).Mul(dyWithFee, t.Rates[j]), Precision))
+	if y.Sign() <= 0 {
+		return nil, nil, ErrWithdrawMoreThanAvailable
+	}
+
+	// x: uint256 = self.get_y(j, i, y, xp)
+	var x, err = t.getY(j, i, y, xp, dCached)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// dx: uint256 = (x - xp[i]) * PRECISION / rates[i]
+	var dx = new(big.Int).Div(new(big.Int).Mul(new(big.Int).Sub(x, xp[i]), Precision), t.Rates[i])
+
+	return dx, new(big.Int).Sub(dyWithFee, dy), nil
+}
+
 func (t *PoolSimulator) getYD(
 	a *big.Int,
 	tokenIndex int,
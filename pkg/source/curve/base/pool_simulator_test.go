@@ -48,6 +48,7 @@ func TestCalcAmountOut(t *testing.T) {
 			"1000000000000000000000000000000", "1000000000000000000000000000000"),
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 	assert.Equal(t, []string{"B"}, p.CanSwapTo("A"))
 	assert.Equal(t, []string{"A"}, p.CanSwapTo("B"))
 	assert.Equal(t, 0, len(p.CanSwapTo("LP")))
@@ -69,6 +70,27 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
+func TestCalcAmountIn(t *testing.T) {
+	t.Parallel()
+	p, err := NewPoolSimulator(entity.Pool{
+		Exchange: "",
+		Type:     "",
+		Reserves: entity.PoolReserves{"101940884", "107546110", "208092128367874420986"},
+		Tokens:   []*entity.PoolToken{{Address: "A"}, {Address: "B"}},
+		Extra: fmt.Sprintf("{\"swapFee\": \"%v\", \"adminFee\": \"%v\", \"initialA\": \"%v\", \"futureA\": \"%v\"}",
+			"3000000",    // 0.0003
+			"5000000000", // 0.5
+			150000, 150000),
+		StaticExtra: fmt.Sprintf("{\"lpToken\": \"LP\", \"aPrecision\": \"%v\", \"precisionMultipliers\": [\"%v\", \"%v\"], \"rates\": [\"%v\", \"%v\"]}",
+			"100",
+			"1000000000000", "1000000000000",
+			"1000000000000000000000000000000", "1000000000000000000000000000000"),
+	})
+	require.Nil(t, err)
+
+	testutil.TestCalcAmountIn(t, p)
+}
+
 func TestCalcAmountOut_interpolate_from_initialA_and_futureA(t *testing.T) {
 	t.Parallel()
 	// if A is getting ramped up then it should interpolate A correctly
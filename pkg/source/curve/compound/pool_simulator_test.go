@@ -73,6 +73,7 @@ func TestCalcAmountOut(t *testing.T) {
 			"Au", "Bu"),
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 	assert.Equal(t, []string{"Bu"}, p.CanSwapTo("Au"))
 	assert.Equal(t, []string{"Au"}, p.CanSwapTo("Bu"))
 	assert.Equal(t, 0, len(p.CanSwapTo("A")))
@@ -176,4 +177,7 @@ func TestCalcAmountIn(t *testing.T) {
 			assert.Equalf(t, tc.expectedFee, amountIn.Fee.Amount, "expected: %v, got: %v", tc.expectedFee.String(), amountIn.Fee.Amount.String())
 		})
 	}
+	for _, p := range sims {
+		testutil.TestCalcAmountIn(t, p)
+	}
 }
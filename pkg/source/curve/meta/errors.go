@@ -11,4 +11,6 @@ var (
 	ErrBasePoolExchangeNotSupported  = errors.New("not support exchange in base pool")
 	ErrTokenToUnderLyingNotSupported = errors.New("not support exchange from base pool token to its underlying")
 	ErrDenominatorZero               = errors.New("denominator should not be 0")
+	ErrWithdrawMoreThanAvailable     = errors.New("cannot withdraw more than available")
+	ErrZero                          = errors.New("zero")
 )
@@ -262,6 +262,83 @@ func (t *PoolSimulator) GetDyUnderlying(i int, j int, _dx *big.Int) (*big.Int, *
 	return dy, dy_fee, err
 }
 
+func (t *PoolSimulator) _get_dx_mem(i int, j int, _dy *big.Int, _balances []*big.Int) (*big.Int, *big.Int, error) {
+	vPrice, _, err := t.basePool.GetVirtualPrice()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rates = []*big.Int{t.RateMultiplier, vPrice}
+	xp, err := t._xp_mem(_balances, vPrice)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dy_with_fee = new(big.Int).Div(
+		new(big.Int).Mul(_dy, FeeDenominator),
+		new(big.Int).Sub(FeeDenominator, t.GetInfo().SwapFee),
+	)
+	var y = new(big.Int).Sub(xp[j], new(big.Int).Div(new(big.Int).Mul(dy_with_fee, rates[j]), Precision))
+	if y.Sign() <= 0 {
+		return nil, nil, ErrWithdrawMoreThanAvailable
+	}
+	x, err := t._get_y(j, i, y, xp)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dx = new(big.Int).Div(new(big.Int).Mul(new(big.Int).Sub(x, xp[i]), Precision), rates[i])
+	return dx, new(big.Int).Sub(dy_with_fee, _dy), nil
+}
+
+// GetDx calculates the input dx given the output dy
+func (t *PoolSimulator) GetDx(
+	i int,
+	j int,
+	dy *big.Int,
+) (*big.Int, *big.Int, error) {
+	return t._get_dx_mem(i, j, dy, t.Info.Reserves)
+}
+
+// GetDxUnderlying calculates the input dx given the output dy like get_dx_underlying of the stableswap-ng meta views
+// contract. The base pool part is approximated: the LP amount to burn for a base coin output ignores the withdrawal
+// fee, and the base coin amount to mint LP for a base coin input is estimated by withdrawing that LP amount.
+// https://github.com/curvefi/stableswap-ng/blob/main/contracts/main/CurveStableSwapMetaNGViews.vy
+func (t *PoolSimulator) GetDxUnderlying(i int, j int, _dy *big.Int) (*big.Int, *big.Int, error) {
+	var maxCoin = len(t.Info.Tokens) - 1
+	var base_i = i - maxCoin
+	var base_j = j - maxCoin
+	if base_i >= 0 && base_j >= 0 {
+		return nil, nil, ErrBasePoolExchangeNotSupported
+	}
+	if base_i < 0 && base_j < 0 {
+		return t.GetDx(i, j, _dy)
+	}
+
+	if base_j >= 0 {
+		// lp_amount_burnt = self._base_calc_token_amount(dy, j - 1, BASE_N_COINS, BASE_POOL, False)
+		var baseNCoins = len(t.basePool.GetInfo().Tokens)
+		var base_inputs = make([]*big.Int, baseNCoins)
+		for k := 0; k < baseNCoins; k += 1 {
+			base_inputs[k] = constant.ZeroBI
+		}
+		base_inputs[base_j] = _dy
+		lpAmount, err := t.basePool.CalculateTokenAmount(base_inputs, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return t.GetDx(i, maxCoin, lpAmount)
+	}
+
+	// lp_amount_required = self._get_dx(1, 0, dy, pool, False, N_COINS)
+	lpAmount, fee, err := t.GetDx(maxCoin, j, _dy)
+	if err != nil {
+		return nil, nil, err
+	}
+	dx, _, err := t.basePool.CalculateWithdrawOneCoin(lpAmount, base_i)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dx, fee, nil
+}
+
 func (t *PoolSimulator) Exchange(i int, j int, dx *big.Int) (*big.Int, error) {
 	var nCoins = len(t.Info.Tokens)
 	vPrice, _, err := t.basePool.GetVirtualPrice()
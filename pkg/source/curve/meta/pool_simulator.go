@@ -193,6 +193,62 @@ func (t *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	}, fmt.Errorf("tokenIndexFrom %v or tokenIndexTo %v is not correct", tokenIndexFrom, tokenIndexTo)
 }
 
+func (t *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
+	tokenAmountOut := param.TokenAmountOut
+	tokenIn := param.TokenIn
+	// swap from token to token
+	var tokenIndexFrom = t.Info.GetTokenIndex(tokenIn)
+	var tokenIndexTo = t.Info.GetTokenIndex(tokenAmountOut.Token)
+
+	if (tokenIndexFrom == len(t.Info.Tokens)-1 && tokenIndexTo < 0) || (tokenIndexTo == len(t.Info.Tokens)-1 && tokenIndexFrom < 0) {
+		return &pool.CalcAmountInResult{}, ErrTokenToUnderLyingNotSupported
+	}
+
+	var gas = t.gas.Exchange
+	if tokenIndexFrom < 0 || tokenIndexTo < 0 {
+		// check exchange_underlying
+		var baseInputIndex = t.basePool.GetTokenIndex(tokenIn)
+		var baseOutputIndex = t.basePool.GetTokenIndex(tokenAmountOut.Token)
+		var maxCoin = len(t.Info.Tokens) - 1
+		if tokenIndexFrom < 0 && baseInputIndex >= 0 {
+			tokenIndexFrom = maxCoin + baseInputIndex
+		}
+		if tokenIndexTo < 0 && baseOutputIndex >= 0 {
+			tokenIndexTo = maxCoin + baseOutputIndex
+		}
+		gas = t.gas.ExchangeUnderlying
+	}
+	if tokenIndexFrom >= 0 && tokenIndexTo >= 0 {
+		// get_dx_underlying
+		amountIn, fee, err := t.GetDxUnderlying(
+			tokenIndexFrom,
+			tokenIndexTo,
+			tokenAmountOut.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if amountIn.Cmp(constant.ZeroBI) <= 0 {
+			return nil, ErrZero
+		}
+
+		return &pool.CalcAmountInResult{
+			TokenAmountIn: &pool.TokenAmount{
+				Token:  tokenIn,
+				Amount: amountIn,
+			},
+			Fee: &pool.TokenAmount{
+				Token:  tokenAmountOut.Token,
+				Amount: fee,
+			},
+			Gas: gas,
+		}, nil
+	}
+	return &pool.CalcAmountInResult{
+		Gas: t.gas.ExchangeUnderlying,
+	}, fmt.Errorf("tokenIndexFrom %v or tokenIndexTo %v is not correct", tokenIndexFrom, tokenIndexTo)
+}
+
 func (t *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	input, output := params.TokenAmountIn, params.TokenAmountOut
 	var inputAmount = input.Amount
@@ -56,6 +56,7 @@ func TestCalcAmountOut(t *testing.T) {
 		StaticExtra: `{"lpToken":"LPBase","aPrecision":"1","precisionMultipliers":["1","1000000000000","1000000000000"],"rates":["1000000000000000000","1000000000000000000000000000000","1000000000000000000000000000000"]}`,
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, basePool)
 	basePoolMap := map[string]pool.IPoolSimulator{"0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7": basePool}
 
 	p, err := NewPoolSimulator(entity.Pool{
@@ -89,6 +90,67 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
+func TestCalcAmountIn(t *testing.T) {
+	t.Parallel()
+	basePool, err := base.NewPoolSimulator(entity.Pool{
+		Exchange: "",
+		Type:     "",
+		Reserves: entity.PoolReserves{"93649867132724477811796755", "92440712316473", "175421309630243",
+			"352290453972395231054279357"},
+		Tokens:      []*entity.PoolToken{{Address: "A"}, {Address: "B"}, {Address: "C"}},
+		Extra:       `{"initialA":"5000","futureA":"2000","initialATime":1653559305,"futureATime":1654158027,"swapFee":"1000000","adminFee":"5000000000"}`,
+		StaticExtra: `{"lpToken":"LPBase","aPrecision":"1","precisionMultipliers":["1","1000000000000","1000000000000"],"rates":["1000000000000000000","1000000000000000000000000000000","1000000000000000000000000000000"]}`,
+	})
+	require.Nil(t, err)
+	basePoolMap := map[string]pool.IPoolSimulator{"0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7": basePool}
+
+	p, err := NewPoolSimulator(entity.Pool{
+		Exchange:    "",
+		Type:        "",
+		Reserves:    entity.PoolReserves{"4763102571534863472313821", "15272752439110430673281", "0"},
+		Tokens:      []*entity.PoolToken{{Address: "Am"}, {Address: "Bm"}},
+		Extra:       `{"initialA":"10000","futureA":"25000","initialATime":1649327847,"futureATime":1649925962,"swapFee":"4000000","adminFee":"0"}`,
+		StaticExtra: `{"lpToken":"LPMeta","basePool":"0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7","rateMultiplier":"1000000000000000000","aPrecision":"100","underlyingTokens":["0x674c6ad92fd080e4004b2312b45f796a192d27a0","0x6b175474e89094c44da98b954eedeac495271d0f","0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","0xdac17f958d2ee523a2206206994597c13d831ec7"],"precisionMultipliers":["1","1"],"rates":["",""]}`,
+	}, basePoolMap)
+	require.Nil(t, err)
+
+	testutil.TestCalcAmountIn(t, p)
+
+	testcases := []struct {
+		in        string
+		out       string
+		amountOut int64
+		err       error
+	}{
+		{"Am", "A", 1e18, nil},
+		{"Am", "B", 1e6, nil},
+		{"C", "Am", 1e18, nil},
+		{"A", "B", 1e6, ErrBasePoolExchangeNotSupported},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s -> %s", tc.in, tc.out), func(t *testing.T) {
+			in, err := p.CalcAmountIn(pool.CalcAmountInParams{
+				TokenAmountOut: pool.TokenAmount{Token: tc.out, Amount: big.NewInt(tc.amountOut)},
+				TokenIn:        tc.in,
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// get_dx_underlying leaves out the base pool fee like the on-chain view does, so the round trip may fall
+			// short of the requested amount by up to twice that fee (0.01%)
+			out, err := p.CalcAmountOut(pool.CalcAmountOutParams{
+				TokenAmountIn: *in.TokenAmountIn,
+				TokenOut:      tc.out,
+			})
+			require.NoError(t, err)
+			assert.InEpsilon(t, tc.amountOut, out.TokenAmountOut.Amount.Int64(), 2e-4)
+		})
+	}
+}
+
 func TestCalcAmountOut_Underflow(t *testing.T) {
 	t.Parallel()
 	// test data from 0xf07d553b195080f84f582e88ecdd54baa122b279
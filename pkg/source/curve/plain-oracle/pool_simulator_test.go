@@ -43,6 +43,7 @@ func TestCalcAmountOut(t *testing.T) {
 			"0xe59EBa0D492cA53C6f46015EEa00517F2707dc77"),
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, 0, len(p.CanSwapTo("LP")))
 	assert.Equal(t, []string{"B"}, p.CanSwapTo("A"))
@@ -64,6 +65,28 @@ func TestCalcAmountOut(t *testing.T) {
 	}
 }
 
+func TestCalcAmountIn(t *testing.T) {
+	t.Parallel()
+	p, err := NewPoolSimulator(entity.Pool{
+		Exchange: "",
+		Type:     "",
+		Reserves: entity.PoolReserves{"4929038393526761949570", "4622174777771844922336", "9849021650836480441313"},
+		Tokens:   []*entity.PoolToken{{Address: "A"}, {Address: "B"}},
+		Extra: fmt.Sprintf("{\"swapFee\": \"%v\", \"adminFee\": \"%v\", \"initialA\": \"%v\", \"futureA\": \"%v\", \"rates\": [%v, %v]}",
+			"4000000",
+			"5000000000",
+			5000, 5000,
+			"1000000000000000000", "1128972205632615487"),
+		StaticExtra: fmt.Sprintf("{\"lpToken\": \"LP\", \"aPrecision\": \"%v\", \"precisionMultipliers\": [\"%v\", \"%v\"], \"oracle\": \"%v\"}",
+			"100",
+			"1", "1",
+			"0xe59EBa0D492cA53C6f46015EEa00517F2707dc77"),
+	})
+	require.Nil(t, err)
+
+	testutil.TestCalcAmountIn(t, p)
+}
+
 func TestGetDyVirtualPrice(t *testing.T) {
 	t.Parallel()
 	// test data from https://optimistic.etherscan.io/address/0xb90b9b1f91a01ea22a182cd84c1e22222e39b415#readContract
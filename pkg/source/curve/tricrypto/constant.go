@@ -6,6 +6,9 @@ import (
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
+// getDxLoopLimit is the number of iterations to estimate the fee of an exact output swap
+const getDxLoopLimit = 5
+
 var (
 	DefaultGas       = Gas{Exchange: 240000}
 	MinGamma         = bignumber.NewBig10("10000000000")
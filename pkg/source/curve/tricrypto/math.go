@@ -442,6 +442,47 @@ func (t *PoolSimulator) GetDy(i int, j int, dx *big.Int) (*big.Int, *big.Int, er
 	return dy, fee, nil
 }
 
+// GetDx calculates the input dx given the output dy like get_dx of the tricrypto-ng views contract. The fee depends
+// on the balances after the swap, so it is estimated from the previous iteration.
+// https://github.com/curvefi/tricrypto-ng/blob/main/contracts/main/CurveCryptoViews3Optimized.vy
+func (t *PoolSimulator) GetDx(i int, j int, dy *big.Int) (*big.Int, *big.Int, error) {
+	var price_scale = make([]*big.Int, 2)
+	for k := 0; k < 2; k += 1 {
+		price_scale[k] = t.price_scale(uint(k))
+	}
+	var dx, fee *big.Int
+	var _dy = dy
+	for loop := 0; loop < getDxLoopLimit; loop += 1 {
+		var xp = make([]*big.Int, 3)
+		for k := 0; k < 3; k += 1 {
+			xp[k] = t.Pool.Info.Reserves[k]
+		}
+		xp[j] = new(big.Int).Sub(xp[j], _dy)
+		if xp[j].Sign() <= 0 {
+			return nil, nil, ErrWithdrawMoreThanAvailable
+		}
+		xp[0] = new(big.Int).Mul(xp[0], t.Precisions[0])
+		for k := 0; k < 2; k += 1 {
+			xp[k+1] = new(big.Int).Div(new(big.Int).Mul(new(big.Int).Mul(xp[k+1], price_scale[k]), t.Precisions[k+1]), Precision)
+		}
+		var x, err = newtonY(t.A, t.Gamma, xp, t.D, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		dx = new(big.Int).Sub(x, xp[i])
+		xp[i] = x
+		if i > 0 {
+			dx = new(big.Int).Div(new(big.Int).Mul(dx, Precision), price_scale[i-1])
+		}
+		dx = new(big.Int).Div(dx, t.Precisions[i])
+
+		// _dy = dy + fee_calc(xp) * _dy / 10**10 + 1
+		fee = new(big.Int).Div(new(big.Int).Mul(t.FeeCalc(xp), _dy), constant.TenPowInt(10))
+		_dy = new(big.Int).Add(new(big.Int).Add(dy, fee), constant.One)
+	}
+	return dx, fee, nil
+}
+
 func (t *PoolSimulator) Exchange(i int, j int, dx *big.Int) (*big.Int, error) {
 	var nCoins = len(t.Info.Tokens)
 	if i == j {
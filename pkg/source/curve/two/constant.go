@@ -7,6 +7,9 @@ import (
 	utils "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
+// getDxLoopLimit is the number of iterations to estimate the fee of an exact output swap
+const getDxLoopLimit = 5
+
 var (
 	DefaultGas       = Gas{Exchange: 220000}
 	MinGamma         = utils.NewBig10("10000000000")
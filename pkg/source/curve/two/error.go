@@ -3,5 +3,6 @@ package two
 import "errors"
 
 var (
-	ErrDenominatorZero = errors.New("denominator should not be 0")
+	ErrDenominatorZero           = errors.New("denominator should not be 0")
+	ErrWithdrawMoreThanAvailable = errors.New("cannot withdraw more than available")
 )
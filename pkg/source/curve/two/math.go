@@ -449,7 +449,7 @@ func (t *PoolSimulator) GetDy(i int, j int, dx *big.Int) (*big.Int, *big.Int, er
 	if i == j {
 		return nil, nil, fmt.Errorf("tokenIn and tokenOut must not be the same")
 	}
-	if i < 0 || i > len(t.GetTokens()) || j < 0 || j > len(t.GetTokens()) {
+	if i < 0 || i >= len(t.GetTokens()) || j < 0 || j >= len(t.GetTokens()) {
 		return nil, nil, fmt.Errorf("token index is out of range")
 	}
 
@@ -480,6 +480,49 @@ func (t *PoolSimulator) GetDy(i int, j int, dx *big.Int) (*big.Int, *big.Int, er
 	return dy, fee, nil
 }
 
+// GetDx calculates the input dx given the output dy like get_dx of the twocrypto-ng views contract. The fee depends
+// on the balances after the swap, so it is estimated from the previous iteration.
+// https://github.com/curvefi/twocrypto-ng/blob/main/contracts/main/CurveCryptoViews2Optimized.vy
+func (t *PoolSimulator) GetDx(i int, j int, dy *big.Int) (*big.Int, *big.Int, error) {
+	if i == j {
+		return nil, nil, fmt.Errorf("tokenIn and tokenOut must not be the same")
+	}
+	if i < 0 || i >= len(t.GetTokens()) || j < 0 || j >= len(t.GetTokens()) {
+		return nil, nil, fmt.Errorf("token index is out of range")
+	}
+
+	var priceScale = new(big.Int).Mul(t.PriceScalePacked, t.Precisions[1])
+	var aGamma = t.aGamma()
+	var dx, fee *big.Int
+	var _dy = dy
+	for loop := 0; loop < getDxLoopLimit; loop += 1 {
+		var xp = []*big.Int{t.Pool.Info.Reserves[0], t.Pool.Info.Reserves[1]}
+		xp[j] = new(big.Int).Sub(xp[j], _dy)
+		if xp[j].Sign() <= 0 {
+			return nil, nil, ErrWithdrawMoreThanAvailable
+		}
+		xp[0] = new(big.Int).Mul(xp[0], t.Precisions[0])
+		xp[1] = new(big.Int).Div(new(big.Int).Mul(xp[1], priceScale), Precision)
+
+		var x, err = newtonY(aGamma[0], aGamma[1], xp, t.D, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		dx = new(big.Int).Sub(x, xp[i])
+		xp[i] = x
+		if i > 0 {
+			dx = new(big.Int).Div(new(big.Int).Mul(dx, Precision), priceScale)
+		} else {
+			dx = new(big.Int).Div(dx, t.Precisions[0])
+		}
+
+		// _dy = dy + fee_calc(xp) * _dy / 10**10 + 1
+		fee = new(big.Int).Div(new(big.Int).Mul(t.FeeCalc(xp), _dy), constant.TenPowInt(10))
+		_dy = new(big.Int).Add(new(big.Int).Add(dy, fee), constant.One)
+	}
+	return dx, fee, nil
+}
+
 func (t *PoolSimulator) Exchange(i int, j int, dx *big.Int) (*big.Int, error) {
 	var nCoins = len(t.Info.Tokens)
 	if i == j {
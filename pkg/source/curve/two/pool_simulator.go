@@ -151,6 +151,38 @@ func (t *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	)
 }
 
+func (t *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcAmountInResult, error) {
+	tokenAmountOut := param.TokenAmountOut
+	tokenIn := param.TokenIn
+	// swap from token to token
+	var tokenIndexFrom = t.Info.GetTokenIndex(tokenIn)
+	var tokenIndexTo = t.Info.GetTokenIndex(tokenAmountOut.Token)
+	if tokenIndexFrom >= 0 && tokenIndexTo >= 0 {
+		amountIn, fee, err := t.GetDx(
+			tokenIndexFrom,
+			tokenIndexTo,
+			tokenAmountOut.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if amountIn.Cmp(constant.ZeroBI) > 0 {
+			return &pool.CalcAmountInResult{
+				TokenAmountIn: &pool.TokenAmount{
+					Token:  tokenIn,
+					Amount: amountIn,
+				},
+				Fee: &pool.TokenAmount{
+					Token:  tokenAmountOut.Token,
+					Amount: fee,
+				},
+				Gas: t.gas.Exchange,
+			}, nil
+		}
+	}
+	return &pool.CalcAmountInResult{}, fmt.Errorf("tokenIndexFrom %v or tokenIndexTo %v is not correct", tokenIndexFrom, tokenIndexTo)
+}
+
 func (t *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	input, output := params.TokenAmountIn, params.TokenAmountOut
 	_, _, _, _ = t.Swap(input, output.Token)
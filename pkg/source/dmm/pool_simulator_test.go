@@ -78,6 +78,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		t.Run(tt.name, func(t1 *testing.T) {
 			p, err := NewPoolSimulator(tt.fields.entityPool)
 			assert.Nil(t1, err)
+			testutil.TestPoolSimulator(t1, p)
 
 			got, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 				return p.CalcAmountOut(
@@ -2,6 +2,8 @@ package elastic
 
 import (
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 )
 
 const (
@@ -20,5 +22,5 @@ const (
 
 var (
 	zeroBI     = big.NewInt(0)
-	defaultGas = Gas{SwapBase: 100000}
+	defaultGas = clcore.Gas{BaseGas: 100000}
 )
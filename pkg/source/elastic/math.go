@@ -1,8 +0,0 @@
-package elastic
-
-import "math/big"
-
-func NewBig10(s string) (res *big.Int) {
-	res, _ = new(big.Int).SetString(s, 10)
-	return res
-}
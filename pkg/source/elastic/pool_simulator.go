@@ -2,20 +2,23 @@ package elastic
 
 import (
 	"errors"
-	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/KyberNetwork/elastic-go-sdk/v2/constants"
 	elasticEntities "github.com/KyberNetwork/elastic-go-sdk/v2/entities"
-	elasticUtils "github.com/KyberNetwork/elastic-go-sdk/v2/utils"
+	"github.com/KyberNetwork/int256"
 	"github.com/KyberNetwork/logger"
+	v3Constants "github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
+	v3Entities "github.com/KyberNetwork/uniswapv3-sdk-uint256/entities"
+	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
 	coreEntities "github.com/daoleno/uniswap-sdk-core/entities"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/goccy/go-json"
+	"github.com/holiman/uint256"
+	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
@@ -25,12 +28,13 @@ var (
 	ErrElasticTicksEmpty = errors.New("elastic ticks empty")
 )
 
+// PoolSimulator quotes with the elastic SDK, whose reinvestment liquidity the clcore swap loop can't express, and
+// leaves token checks, price limits and results to clcore. clcore.PoolSimulator is not embedded as exact output
+// quotes are not supported.
 type PoolSimulator struct {
 	pool.Pool
+	cl          *clcore.PoolSimulator
 	elasticPool *elasticEntities.Pool
-	gas         Gas
-	tickMin     int
-	tickMax     int
 }
 
 var _ = pool.RegisterFactory1(DexTypeElastic, NewPoolSimulator)
@@ -45,26 +49,14 @@ func NewPoolSimulator(entityPool entity.Pool, chainID valueobject.ChainID) (*Poo
 		return nil, ErrTickNil
 	}
 
-	token0 := coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[0].Address), uint(entityPool.Tokens[0].Decimals), entityPool.Tokens[0].Symbol, "")
-	token1 := coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[1].Address), uint(entityPool.Tokens[1].Decimals), entityPool.Tokens[1].Symbol, "")
-
-	swapFee := big.NewInt(int64(entityPool.SwapFee))
-	tokens := make([]string, 2)
-	reserves := make([]*big.Int, 2)
-	if len(entityPool.Reserves) == 2 && len(entityPool.Tokens) == 2 {
-		tokens[0] = entityPool.Tokens[0].Address
-		reserves[0] = NewBig10(entityPool.Reserves[0])
-		tokens[1] = entityPool.Tokens[1].Address
-		reserves[1] = NewBig10(entityPool.Reserves[1])
-	}
-
 	var elasticTicks []elasticEntities.Tick
+	var ticks []clcore.TickU256
 
 	// Ticks are sorted from the pool service, so we don't have to do it again here
 	// Purpose: to improve the latency
 	for _, t := range extra.Ticks {
 		// LiquidityGross = 0 means that the tick is uninitialized
-		if t.LiquidityGross.Cmp(zeroBI) == 0 {
+		if t.LiquidityGross.Sign() == 0 {
 			continue
 		}
 
@@ -73,6 +65,11 @@ func NewPoolSimulator(entityPool entity.Pool, chainID valueobject.ChainID) (*Poo
 			LiquidityGross: t.LiquidityGross,
 			LiquidityNet:   t.LiquidityNet,
 		})
+		ticks = append(ticks, clcore.TickU256{
+			Index:          t.Index,
+			LiquidityGross: uint256.MustFromBig(t.LiquidityGross),
+			LiquidityNet:   int256.MustFromBig(t.LiquidityNet),
+		})
 	}
 
 	// if the tick list is empty, the pool should be ignored
@@ -80,131 +77,91 @@ func NewPoolSimulator(entityPool entity.Pool, chainID valueobject.ChainID) (*Poo
 		return nil, ErrElasticTicksEmpty
 	}
 
-	ticks, err := elasticEntities.NewTickListDataProvider(elasticTicks, constants.TickSpacings[constants.FeeAmount(entityPool.SwapFee)])
+	tickSpacing := constants.TickSpacings[constants.FeeAmount(entityPool.SwapFee)]
+	elasticTickProvider, err := elasticEntities.NewTickListDataProvider(elasticTicks, tickSpacing)
 	if err != nil {
 		return nil, err
 	}
 
+	tick := int(extra.Tick.Int64())
 	elasticPool, err := elasticEntities.NewPool(
-		token0,
-		token1,
+		coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[0].Address),
+			uint(entityPool.Tokens[0].Decimals), entityPool.Tokens[0].Symbol, ""),
+		coreEntities.NewToken(uint(chainID), common.HexToAddress(entityPool.Tokens[1].Address),
+			uint(entityPool.Tokens[1].Decimals), entityPool.Tokens[1].Symbol, ""),
 		constants.FeeAmount(entityPool.SwapFee),
 		extra.SqrtPriceX96,
 		extra.Liquidity,
 		extra.ReinvestL,
-		int(extra.Tick.Int64()),
-		ticks,
+		tick,
+		elasticTickProvider,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var tickMin, tickMax int
-	if len(elasticTicks) == 0 {
-		tickMin = elasticPool.CurrentTick
-		tickMax = elasticPool.CurrentTick
-	} else {
-		tickMin = elasticTicks[0].Index
-		tickMax = elasticTicks[len(elasticTicks)-1].Index
-	}
-
-	var info = pool.PoolInfo{
-		Address:  strings.ToLower(entityPool.Address),
-		SwapFee:  swapFee,
-		Exchange: entityPool.Exchange,
-		Type:     entityPool.Type,
-		Tokens:   tokens,
-		Reserves: reserves,
+	cl, err := clcore.NewPoolSimulator(entityPool, chainID, &clcore.ExtraTickU256{
+		Liquidity:    uint256.MustFromBig(extra.Liquidity),
+		SqrtPriceX96: uint256.MustFromBig(extra.SqrtPriceX96),
+		TickSpacing:  uint64(tickSpacing),
+		Tick:         &tick,
+		Ticks:        ticks,
+	}, v3Constants.FeeAmount(entityPool.SwapFee), clcore.Config{Gas: defaultGas})
+	if err != nil {
+		return nil, err
 	}
 
 	return &PoolSimulator{
-		Pool:        pool.Pool{Info: info},
+		Pool:        cl.Pool,
+		cl:          cl,
 		elasticPool: elasticPool,
-		gas:         defaultGas,
-		tickMin:     tickMin,
-		tickMax:     tickMax,
 	}, nil
 }
 
-/**
- * getSqrtPriceLimit get the price limit of pool based on the initialized ticks that this pool has
- */
-func (p *PoolSimulator) getSqrtPriceLimit(zeroForOne bool) *big.Int {
-	var tickLimit int
-	if zeroForOne {
-		tickLimit = p.tickMin
-	} else {
-		tickLimit = p.tickMax
-	}
-
-	sqrtPriceX96Limit, err := elasticUtils.GetSqrtRatioAtTick(tickLimit)
-
-	if err != nil {
-		return nil
-	}
-
-	return sqrtPriceX96Limit
-}
-
 func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
-	tokenAmountIn := param.TokenAmountIn
-	tokenOut := param.TokenOut
-	var tokenInIndex = p.GetTokenIndex(tokenAmountIn.Token)
-	var tokenOutIndex = p.GetTokenIndex(tokenOut)
-	var tokenIn *coreEntities.Token
-	var zeroForOne bool
-
-	if tokenInIndex >= 0 && tokenOutIndex >= 0 {
-		if strings.EqualFold(tokenOut, hexutil.Encode(p.elasticPool.Token0.Address[:])) {
-			zeroForOne = false
-			tokenIn = p.elasticPool.Token1
-		} else {
-			tokenIn = p.elasticPool.Token0
-			zeroForOne = true
-		}
-		amountIn := coreEntities.FromRawAmount(tokenIn, tokenAmountIn.Amount)
-		amountOut, newPoolState, err := p.elasticPool.GetOutputAmount(amountIn, p.getSqrtPriceLimit(zeroForOne))
-
-		if err != nil {
-			return &pool.CalcAmountOutResult{}, fmt.Errorf("can not GetOutputAmount, err: %+v", err)
-		}
-
-		var totalGas = p.gas.SwapBase
-
-		if amountOut.Quotient().Cmp(zeroBI) > 0 {
-			return &pool.CalcAmountOutResult{
-				TokenAmountOut: &pool.TokenAmount{
-					Token:  tokenOut,
-					Amount: amountOut.Quotient(),
-				},
-				Fee: &pool.TokenAmount{
-					Token:  tokenAmountIn.Token,
-					Amount: nil,
-				},
-				Gas: totalGas,
-				SwapInfo: KSElasticSwapInfo{
-					nextStateSqrtP:              new(big.Int).Set(newPoolState.SqrtP),
-					nextStateBaseL:              new(big.Int).Set(newPoolState.BaseL),
-					nextStateReinvestL:          new(big.Int).Set(newPoolState.ReinvestL),
-					nextStateCurrentTick:        newPoolState.CurrentTick,
-					nextStateNearestCurrentTick: newPoolState.NearestCurrentTick,
-				},
-			}, nil
-		}
+	return p.cl.CalcAmountOutWith(param, p.swap)
+}
 
-		return nil, errors.New("amountOut is 0")
+// swap quotes an exact input swap with the elastic SDK
+func (p *PoolSimulator) swap(zeroForOne bool, amountSpecified *v3Utils.Int256,
+	priceLimit *v3Utils.Uint160) (*clcore.SwapResult, error) {
+	tokenIn := lo.Ternary(zeroForOne, p.elasticPool.Token0, p.elasticPool.Token1)
+	amountOut, newPoolState, err := p.elasticPool.GetOutputAmount(
+		coreEntities.FromRawAmount(tokenIn, amountSpecified.ToBig()), priceLimit.ToBig())
+	if err != nil {
+		return nil, err
 	}
 
-	return &pool.CalcAmountOutResult{}, fmt.Errorf("tokenInIndex %v or tokenOutIndex %v is not correct", tokenInIndex, tokenOutIndex)
+	return &clcore.SwapResult{
+		GetAmountResultV2: &v3Entities.GetAmountResultV2{
+			ReturnedAmount: int256.MustFromBig(amountOut.Quotient()),
+			SqrtRatioX96:   uint256.MustFromBig(newPoolState.SqrtP),
+			Liquidity:      uint256.MustFromBig(newPoolState.BaseL),
+			CurrentTick:    newPoolState.CurrentTick,
+		},
+		State: KSElasticSwapInfo{
+			nextStateSqrtP:              new(big.Int).Set(newPoolState.SqrtP),
+			nextStateBaseL:              new(big.Int).Set(newPoolState.BaseL),
+			nextStateReinvestL:          new(big.Int).Set(newPoolState.ReinvestL),
+			nextStateCurrentTick:        newPoolState.CurrentTick,
+			nextStateNearestCurrentTick: newPoolState.NearestCurrentTick,
+		},
+	}, nil
 }
 
 func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
-	si, ok := params.SwapInfo.(KSElasticSwapInfo)
+	swapInfo, ok := params.SwapInfo.(clcore.SwapInfo)
+	if !ok {
+		logger.Warn("failed to UpdateBalance for ProMM pool, wrong swapInfo type")
+		return
+	}
+	si, ok := swapInfo.State.(KSElasticSwapInfo)
 	if !ok {
 		logger.Warn("failed to UpdateBalance for ProMM pool, wrong swapInfo type")
 		return
 	}
 
+	p.cl.UpdateBalance(params)
 	p.elasticPool.SqrtP = si.nextStateSqrtP
 	p.elasticPool.BaseL = si.nextStateBaseL
 	p.elasticPool.ReinvestL = si.nextStateReinvestL
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	sourcePool "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
@@ -75,16 +76,7 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
-	var ticks []Tick
-	for _, tickResp := range poolTicks {
-		tick, err := transformTickRespToTick(tickResp)
-		if err != nil {
-			logger.Errorf("failed to transform tickResp to tick for pool: %v, err: %v", p.Address, err)
-			continue
-		}
-
-		ticks = append(ticks, tick)
-	}
+	ticks := clcore.TransformTickResps(logger.WithFields(logger.Fields{"poolAddress": p.Address}), poolTicks)
 
 	extraBytes, err := json.Marshal(Extra{
 		Liquidity:     rpcData.liquidityState.BaseL,
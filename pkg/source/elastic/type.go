@@ -1,15 +1,11 @@
 package elastic
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
-)
 
-type Gas struct {
-	SwapBase    int64
-	SwapNonBase int64
-}
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
+)
 
 // KSElasticSwapInfo store after state of a KSElastic swap
 type KSElasticSwapInfo struct {
@@ -40,11 +36,7 @@ type SubgraphPool struct {
 	Token1             Token  `json:"token1"`
 }
 
-type TickResp struct {
-	TickIdx        string `json:"tickIdx"`
-	LiquidityGross string `json:"liquidityGross"`
-	LiquidityNet   string `json:"liquidityNet"`
-}
+type TickResp = ticklens.TickResp
 
 type SubgraphPoolTicks struct {
 	ID    string     `json:"id"`
@@ -55,11 +47,7 @@ type StaticExtra struct {
 	PoolId string `json:"poolId"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
 type Extra struct {
 	Liquidity     *big.Int `json:"liquidity"`
@@ -89,26 +77,3 @@ type FetchRPCResult struct {
 	reserve0       *big.Int
 	reserve1       *big.Int
 }
-
-func transformTickRespToTick(tickResp TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
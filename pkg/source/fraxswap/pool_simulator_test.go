@@ -79,6 +79,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 				Extra:    fmt.Sprintf("{\"reserve0\": %v, \"reserve1\": %v, \"fee\": %v}", pooldata.reserve[0], pooldata.reserve[1], pooldata.fee),
 			})
 			require.Nil(t, err)
+			testutil.TestPoolSimulator(t, p)
 
 			got, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 				return p.CalcAmountOut(
@@ -29,3 +29,13 @@ type Meta struct {
 	SwapFee      uint32 `json:"swapFee"`
 	FeePrecision uint32 `json:"feePrecision"`
 }
+
+// SupportsTaxedTokens returns true as Fraxswap pairs derive the amount in from their balances like Uniswap V2 pairs
+func (Meta) SupportsTaxedTokens() bool {
+	return true
+}
+
+// SupportsRebasingTokens returns false as pairs, including their TWAMM orders, account with stored reserves
+func (Meta) SupportsRebasingTokens() bool {
+	return false
+}
@@ -97,6 +97,7 @@ func TestCalcAmountOutConcurrentSafe(t *testing.T) {
 
 			poolSim, err := NewPoolSimulator(*poolEntity)
 			require.NoError(t, err)
+			testutil.TestPoolSimulator(t, poolSim)
 
 			result, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 				return poolSim.CalcAmountOut(pool.CalcAmountOutParams{
@@ -1,6 +1,23 @@
 package fxdx
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+
 type Config struct {
 	DexID        string `json:"-"`
 	VaultAddress string `json:"vaultAddress"`
 }
+
+// engineConfig is how the vault differs from GMX
+var engineConfig = gmxcore.Config{
+	DexType:       DexTypeFxdx,
+	UsdgForkName:  "usdf",
+	WhitelistMode: gmxcore.WhitelistModeCount,
+	UseFeeUtils:   true,
+}
+
+func newEngineConfig(cfg *Config) *gmxcore.Config {
+	engineCfg := engineConfig
+	engineCfg.DexID = cfg.DexID
+	engineCfg.VaultAddress = cfg.VaultAddress
+	return &engineCfg
+}
@@ -0,0 +1,3 @@
+package fxdx
+
+const DexTypeFxdx = "fxdx"
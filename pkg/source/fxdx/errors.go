@@ -1,18 +1,18 @@
 package fxdx
 
-import "errors"
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 
 var (
-	ErrVaultSwapsNotEnabled                = errors.New("vault: swaps not enabled")
-	ErrVaultMaxUsdfExceeded                = errors.New("vault: max USDF exceeded") // code: 51
-	ErrVaultPoolAmountExceeded             = errors.New("vault: poolAmount exceeded")
-	ErrVaultReserveExceedsPool             = errors.New("vault: reserve exceeds pool") // code: 50
-	ErrVaultPoolAmountLessThanBufferAmount = errors.New("vault: poolAmount < buffer")
+	ErrVaultSwapsNotEnabled                = gmxcore.ErrVaultSwapsNotEnabled
+	ErrVaultMaxUsdfExceeded                = gmxcore.ErrVaultMaxUsdgExceeded
+	ErrVaultPoolAmountExceeded             = gmxcore.ErrVaultPoolAmountExceeded
+	ErrVaultReserveExceedsPool             = gmxcore.ErrVaultReserveExceedsPool
+	ErrVaultPoolAmountLessThanBufferAmount = gmxcore.ErrVaultPoolAmountLessThanBufferAmount
 
-	ErrVaultPriceFeedInvalidPriceFeed         = errors.New("vaultPriceFeed: invalid price feed")
-	ErrVaultPriceFeedInvalidPrice             = errors.New("vaultPriceFeed: invalid price")
-	ErrVaultPriceFeedCouldNotFetchPrice       = errors.New("vaultPriceFeed: could not fetch price")
-	ErrVaultPriceFeedChainlinkFeedsNotUpdated = errors.New("chainlink feeds are not being updated")
+	ErrVaultPriceFeedInvalidPriceFeed         = gmxcore.ErrVaultPriceFeedInvalidPriceFeed
+	ErrVaultPriceFeedInvalidPrice             = gmxcore.ErrVaultPriceFeedInvalidPrice
+	ErrVaultPriceFeedCouldNotFetchPrice       = gmxcore.ErrVaultPriceFeedCouldNotFetchPrice
+	ErrVaultPriceFeedChainlinkFeedsNotUpdated = gmxcore.ErrVaultPriceFeedChainlinkFeedsNotUpdated
 
-	ErrFeeUtilsV2IsNotInitialized = errors.New("feeUtilsV2: is not initialized")
+	ErrFeeUtilsV2IsNotInitialized = gmxcore.ErrFeeUtilsV2IsNotInitialized
 )
@@ -1,6 +0,0 @@
-package fxdx
-
-type Extra struct {
-	Vault    *Vault      `json:"vault"`
-	FeeUtils *FeeUtilsV2 `json:"feeUtils"`
-}
@@ -7,13 +7,6 @@ import (
 )
 
 var (
-	chainlinkABI         abi.ABI
-	fastPriceFeedV1ABI   abi.ABI
-	fastPriceFeedV2ABI   abi.ABI
-	pancakePairABI       abi.ABI
-	priceFeedABI         abi.ABI
-	vaultABI             abi.ABI
-	vaultPriceFeedABI    abi.ABI
 	erc20ABI             abi.ABI
 	glpManagerABI        abi.ABI
 	yearnTokenVaultABI   abi.ABI
@@ -25,13 +18,6 @@ func init() {
 		ABI  *abi.ABI
 		data []byte
 	}{
-		{&chainlinkABI, chainlinkFlagsJson},
-		{&fastPriceFeedV1ABI, fastPriceFeedV1Json},
-		{&fastPriceFeedV2ABI, fastPriceFeedV2Json},
-		{&pancakePairABI, pancakePairJson},
-		{&priceFeedABI, priceFeedJson},
-		{&vaultABI, vaultJson},
-		{&vaultPriceFeedABI, vaultPriceFeedJson},
 		{&erc20ABI, erc20Json},
 		{&glpManagerABI, glpManagerJson},
 		{&yearnTokenVaultABI, yearnTokenVaultJson},
@@ -1,5 +1,7 @@
 package gmxglp
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+
 type Config struct {
 	DexID                   string `json:"-"`
 	RewardRouterAddress     string `json:"rewardRouterAddress"`
@@ -9,3 +11,13 @@ type Config struct {
 	VaultAddress            string `json:"vaultAddress"`
 	UseSecondaryPriceFeedV1 bool   `json:"useSecondaryPriceFeedV1"`
 }
+
+// newEngineConfig configures the GMX vault that backs GLP
+func newEngineConfig(cfg *Config) *gmxcore.Config {
+	return &gmxcore.Config{
+		DexID:                   cfg.DexID,
+		DexType:                 DexTypeGmxGlp,
+		VaultAddress:            cfg.VaultAddress,
+		UseSecondaryPriceFeedV1: cfg.UseSecondaryPriceFeedV1,
+	}
+}
@@ -1,24 +1,26 @@
 package gmxglp
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+)
 
 var (
-	ErrVaultSwapsNotEnabled                = errors.New("vault: swaps not enabled")
-	ErrVaultMaxUsdgExceeded                = errors.New("vault: max USDG exceeded") // code: 51
-	ErrVaultPoolAmountExceeded             = errors.New("vault: poolAmount exceeded")
-	ErrVaultReserveExceedsPool             = errors.New("vault: reserve exceeds pool") // code: 50
-	ErrVaultPoolAmountLessThanBufferAmount = errors.New("vault: poolAmount < buffer")
+	ErrVaultSwapsNotEnabled                = gmxcore.ErrVaultSwapsNotEnabled
+	ErrVaultMaxUsdgExceeded                = gmxcore.ErrVaultMaxUsdgExceeded
+	ErrVaultPoolAmountExceeded             = gmxcore.ErrVaultPoolAmountExceeded
+	ErrVaultReserveExceedsPool             = gmxcore.ErrVaultReserveExceedsPool
+	ErrVaultPoolAmountLessThanBufferAmount = gmxcore.ErrVaultPoolAmountLessThanBufferAmount
 	ErrVaultNegativeTokenAmount            = errors.New("vault: tokenAmount < 0")      // code: 17
 	ErrVaultNegativeUsdgAmount             = errors.New("vault: usdgAmount < 0")       // code: 18
 	ErrVaultNegativeRedemptionAmount       = errors.New("vault: redemptionAmount < 0") // code: 20
 	ErrVaultNegativeAmountOut              = errors.New("vault: amountOut < 0 ")       // ocde: 22
 
-	ErrVaultPriceFeedInvalidPriceFeed         = errors.New("vaultPriceFeed: invalid price feed")
-	ErrVaultPriceFeedInvalidPrice             = errors.New("vaultPriceFeed: invalid price")
-	ErrVaultPriceFeedCouldNotFetchPrice       = errors.New("vaultPriceFeed: could not fetch price")
-	ErrVaultPriceFeedChainlinkFeedsNotUpdated = errors.New("chainlink feeds are not being updated")
-
-	ErrInvalidSecondaryPriceFeedVersion = errors.New("invalid secondary price feed version")
+	ErrVaultPriceFeedInvalidPriceFeed         = gmxcore.ErrVaultPriceFeedInvalidPriceFeed
+	ErrVaultPriceFeedInvalidPrice             = gmxcore.ErrVaultPriceFeedInvalidPrice
+	ErrVaultPriceFeedCouldNotFetchPrice       = gmxcore.ErrVaultPriceFeedCouldNotFetchPrice
+	ErrVaultPriceFeedChainlinkFeedsNotUpdated = gmxcore.ErrVaultPriceFeedChainlinkFeedsNotUpdated
 
 	ErrRewardRouterInvalidAmount    = errors.New("rewardRouter: invalid amount")
 	ErrRewardRouterInvalidGlpAmount = errors.New("rewardRouter: invalid glpAmount")
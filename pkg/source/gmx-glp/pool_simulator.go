@@ -11,20 +11,17 @@ import (
 	"github.com/goccy/go-json"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 )
 
-type Gas struct {
-	Swap int64
-}
-
 type PoolSimulator struct {
 	pool.Pool
-	vault           *Vault
-	vaultUtils      *VaultUtils
+	vault           *gmxcore.Vault
+	vaultUtils      *gmxcore.VaultUtils
 	glpManager      *GlpManager
 	yearnTokenVault *YearnTokenVault
-	gas             Gas
+	gas             gmxcore.Gas
 }
 
 var _ = pool.RegisterFactory0(DexTypeGmxGlp, NewPoolSimulator)
@@ -63,10 +60,10 @@ func NewPoolSimulator(entityPool entity.Pool) (*PoolSimulator, error) {
 			Info: info,
 		},
 		vault:           extra.Vault,
-		vaultUtils:      NewVaultUtils(extra.Vault),
+		vaultUtils:      gmxcore.NewVaultUtils(extra.Vault),
 		glpManager:      extra.GlpManager,
 		yearnTokenVault: extra.YearnTokenVault,
-		gas:             DefaultGas,
+		gas:             gmxcore.DefaultGas,
 	}, nil
 }
 
@@ -125,6 +122,7 @@ func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
 	vault.USDGAmounts = maps.Clone(p.vault.USDGAmounts)
 	vault.PoolAmounts = maps.Clone(p.vault.PoolAmounts)
 	cloned.vault = &vault
+	cloned.vaultUtils = gmxcore.NewVaultUtils(&vault)
 	yearnTokenVault := *p.yearnTokenVault
 	yearnTokenVault.TotalIdle = new(big.Int).Set(p.yearnTokenVault.TotalIdle)
 	yearnTokenVault.YearnStrategyMap = maps.Clone(p.yearnTokenVault.YearnStrategyMap)
@@ -194,8 +192,6 @@ func (p *PoolSimulator) GetMetaInfo(tokenIn string, tokenOut string) interface{}
 }
 
 func (p *PoolSimulator) AfterMsgpackUnmarshal() error {
-	if p.vaultUtils != nil {
-		p.vaultUtils.vault = p.vault
-	}
+	p.vaultUtils = gmxcore.NewVaultUtils(p.vault)
 	return nil
 }
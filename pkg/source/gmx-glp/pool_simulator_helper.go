@@ -1,8 +1,10 @@
 package gmxglp
 
 import (
-	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
 func (p *PoolSimulator) validateMaxUsdgExceed(token string, amount *big.Int) error {
@@ -38,3 +40,16 @@ func (p *PoolSimulator) validateMinPoolAmount(token string, amount *big.Int) err
 
 	return nil
 }
+
+func collectSwapFees(amount, feeBasisPoints *big.Int) (*big.Int, error) {
+	afterFeeAmount, err := sub(gmxcore.BasisPointsDivisor, feeBasisPoints)
+	if err != nil {
+		return nil, err
+	}
+	afterFeeAmount, err = mul(amount, afterFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return div(afterFeeAmount, gmxcore.BasisPointsDivisor)
+}
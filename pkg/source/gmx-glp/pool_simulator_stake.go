@@ -3,6 +3,7 @@ package gmxglp
 import (
 	"math/big"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
@@ -66,7 +67,7 @@ func (p *PoolSimulator) BuyUSDG(swapInfo *gmxGlpSwapInfo, token string, tokenAmo
 
 	// updateCumulativeFundingRate(_token, _token);
 
-	price, err := p.vault.GetMinPrice(token, useSwapPricing)
+	price, err := p.vault.PriceFeed.GetPrice(token, false, p.vault.IncludeAmmPrice, useSwapPricing)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +76,7 @@ func (p *PoolSimulator) BuyUSDG(swapInfo *gmxGlpSwapInfo, token string, tokenAmo
 	if err != nil {
 		return nil, err
 	}
-	usdgAmount, err = div(usdgAmount, PricePrecision)
+	usdgAmount, err = div(usdgAmount, gmxcore.PricePrecision)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +87,7 @@ func (p *PoolSimulator) BuyUSDG(swapInfo *gmxGlpSwapInfo, token string, tokenAmo
 
 	// getBuyUsdgFeeBasisPoints
 	feeBasicPoints := p.vaultUtils.GetBuyUsdgFeeBasisPoints(token, usdgAmount)
-	amountAfterFees, err := p.vault.CollectSwapFees(token, tokenAmount, feeBasicPoints)
+	amountAfterFees, err := collectSwapFees(tokenAmount, feeBasicPoints)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +95,7 @@ func (p *PoolSimulator) BuyUSDG(swapInfo *gmxGlpSwapInfo, token string, tokenAmo
 	if err != nil {
 		return nil, err
 	}
-	mintAmount, err = div(mintAmount, PricePrecision)
+	mintAmount, err = div(mintAmount, gmxcore.PricePrecision)
 	if err != nil {
 		return nil, err
 	}
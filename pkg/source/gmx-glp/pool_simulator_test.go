@@ -79,6 +79,8 @@ func TestPoolSimulator_MintAndStakeGlp(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			pool, err := NewPoolSimulator(tc.entityPool)
 			assert.Nil(t, err)
+			// the fixture lists only the token it swaps
+			testutil.TestPoolSimulator(t, pool, testutil.CheckTokens)
 
 			_, err = testutil.MustConcurrentSafe(t, func() (*poolPkg.CalcAmountOutResult, error) {
 				return pool.CalcAmountOut(poolPkg.CalcAmountOutParams{
@@ -3,6 +3,7 @@ package gmxglp
 import (
 	"math/big"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
 
@@ -77,7 +78,7 @@ func (p *PoolSimulator) SellUSDG(swapInfo *gmxGlpSwapInfo, token string, usdgAmo
 	// updateTokenBalance(usdg)
 
 	feeBasisPoints := p.vaultUtils.GetSellUsdgFeeBasisPoints(token, usdgAmount)
-	amountOut, err := p.vault.CollectSwapFees(token, redemptionAmount, feeBasisPoints)
+	amountOut, err := collectSwapFees(redemptionAmount, feeBasisPoints)
 	if err != nil {
 		return nil, err
 	}
@@ -89,11 +90,11 @@ func (p *PoolSimulator) SellUSDG(swapInfo *gmxGlpSwapInfo, token string, usdgAmo
 }
 
 func (p *PoolSimulator) getRedemptionAmount(token string, usdgAmount *big.Int, useSwapPricing bool) (*big.Int, error) {
-	price, err := p.vault.GetMaxPrice(token, useSwapPricing)
+	price, err := p.vault.PriceFeed.GetPrice(token, true, p.vault.IncludeAmmPrice, useSwapPricing)
 	if err != nil {
 		return nil, err
 	}
-	redemptionAmount, err := mul(usdgAmount, PricePrecision)
+	redemptionAmount, err := mul(usdgAmount, gmxcore.PricePrecision)
 	if err != nil {
 		return nil, err
 	}
@@ -1,21 +1,18 @@
 package gmxglp
 
-import "math/big"
+import (
+	"math/big"
 
-const (
-	secondaryPriceFeedVersion1 SecondaryPriceFeedVersion = 1
-	secondaryPriceFeedVersion2 SecondaryPriceFeedVersion = 2
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+)
 
+const (
 	calcAmountOutTypeStake   = "stake"
 	calcAmountOutTypeUnStake = "un-stake"
 )
 
-type VaultAddress struct {
-	Vault string `json:"vault"`
-}
-
 type Extra struct {
-	Vault           *Vault           `json:"vault"`
+	Vault           *gmxcore.Vault   `json:"vault"`
 	GlpManager      *GlpManager      `json:"glpManager"`
 	YearnTokenVault *YearnTokenVault `json:"yearnTokenVault"`
 }
@@ -27,10 +24,6 @@ type Meta struct {
 	DirectionFlag uint8  `json:"directionFlag"`
 }
 
-type ChainID uint
-
-type SecondaryPriceFeedVersion int
-
 type gmxGlpSwapInfo struct {
 	calcAmountOutType       string
 	mintAmount              *big.Int
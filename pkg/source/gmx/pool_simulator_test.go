@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
@@ -369,6 +370,7 @@ func TestPool_CalcAmountOut(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			poolSim, _ := NewPoolSimulator(tc.entityPool)
+			testutil.TestPoolSimulator(t, poolSim)
 			cloned := poolSim.CloneState()
 
 			result, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
@@ -515,7 +517,7 @@ func TestPool_UpdateBalance(t *testing.T) {
 			}
 			poolSim.UpdateBalance(params)
 
-			assert.Equal(t, tc.expectedUSDGAmounts, poolSim.vault.USDGAmounts)
+			assert.Equal(t, tc.expectedUSDGAmounts, poolSim.Vault.USDGAmounts)
 		})
 	}
 }
@@ -523,8 +525,8 @@ func TestPool_UpdateBalance(t *testing.T) {
 func TestPool_CanSwapTo(t *testing.T) {
 	t.Parallel()
 	t.Run("it should return correct swappable tokens", func(t *testing.T) {
-		poolSim := PoolSimulator{
-			vault: &Vault{
+		poolSim := PoolSimulator{PoolSimulator: &gmxcore.PoolSimulator{
+			Vault: &gmxcore.Vault{
 				WhitelistedTokens: []string{
 					"0x17fc002b466eec40dae837fc4be5c67993ddbd6f",
 					"0x2f2a2543b76a4166549f7aab2e75bef0aefc5b0f",
@@ -537,7 +539,7 @@ func TestPool_CanSwapTo(t *testing.T) {
 					"0xff970a61a04b1ca14834a43f5de4533ebddb5cc8",
 				},
 			},
-		}
+		}}
 
 		tokens := poolSim.CanSwapTo("0xff970a61a04b1ca14834a43f5de4533ebddb5cc8")
 
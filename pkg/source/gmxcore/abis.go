@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"bytes"
@@ -11,9 +11,15 @@ var (
 	fastPriceFeedV1ABI abi.ABI
 	fastPriceFeedV2ABI abi.ABI
 	pancakePairABI     abi.ABI
-	priceFeedABI       abi.ABI
 	vaultPriceFeedABI  abi.ABI
 	erc20ABI           abi.ABI
+	feeUtilsV2ABI      abi.ABI
+
+	// fork variants of methods whose signature conflicts with the GMX ones
+	vaultIndexedWhitelistABI  abi.ABI
+	vaultPriceFeedGetPriceABI abi.ABI
+	priceFeedProxyABI         abi.ABI
+	priceFeedMinMaxABI        abi.ABI
 )
 
 func init() {
@@ -25,9 +31,13 @@ func init() {
 		{&fastPriceFeedV1ABI, fastPriceFeedV1Json},
 		{&fastPriceFeedV2ABI, fastPriceFeedV2Json},
 		{&pancakePairABI, pancakePairJson},
-		{&priceFeedABI, priceFeedJson},
 		{&vaultPriceFeedABI, vaultPriceFeedJson},
 		{&erc20ABI, erc20Json},
+		{&vaultIndexedWhitelistABI, vaultIndexedWhitelistJson},
+		{&vaultPriceFeedGetPriceABI, vaultPriceFeedGetPriceJson},
+		{&priceFeedProxyABI, priceFeedProxyJson},
+		{&priceFeedMinMaxABI, priceFeedMinMaxJson},
+		{&feeUtilsV2ABI, feeUtilsV2Json},
 	}
 
 	for _, b := range builder {
@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 type ChainlinkFlags struct {
 	Flags map[string]bool `json:"flags,omitempty"`
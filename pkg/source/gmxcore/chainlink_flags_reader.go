@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -20,7 +20,7 @@ func NewChainlinkFlagsReader(ethrpcClient *ethrpc.Client) *ChainlinkFlagsReader
 		abi:          chainlinkABI,
 		ethrpcClient: ethrpcClient,
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": sourceName,
 			"reader":          "ChainlinkFlagsReader",
 		}),
 	}
@@ -0,0 +1,35 @@
+package gmxcore
+
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+
+// Config is the fork-specific behavior of a GMX V1 vault. Forks other than gmx build it from their own config.
+type Config struct {
+	DexID string `json:"dexID"`
+	// DexType is the pool type of new pools and the liquidity source in logs. Set by the fork.
+	DexType                 string        `json:"-"`
+	VaultAddress            string        `json:"vaultAddress"`
+	UseSecondaryPriceFeedV1 bool          `json:"useSecondaryPriceFeedV1"`
+	PriceFeedType           PriceFeedType `json:"priceFeedType"`
+	// UsdgForkName is the name the fork gives to USDG in vault methods, e.g. usdm for usdmAmounts
+	UsdgForkName string `json:"usdgForkName"`
+	// WhitelistMode is how the vault exposes its whitelisted tokens
+	WhitelistMode WhitelistMode `json:"whitelistMode"`
+	// UseFeeUtils reads swap fees from the FeeUtilsV2 contract set on the vault instead of the vault itself
+	UseFeeUtils bool `json:"useFeeUtils"`
+	// SequencerConfig is the uptime feed of the sequencer on L2s such as Arbitrum, whose VaultPriceFeed only checks the
+	// deprecated Chainlink flags. Primary prices are not quoted while the sequencer is down or in its grace period.
+	oracle.SequencerConfig
+}
+
+// WhitelistMode is how a vault exposes its whitelisted tokens
+type WhitelistMode int
+
+const (
+	// WhitelistModeFiltered lists allWhitelistedTokens up to allWhitelistedTokensLength and keeps those still set in
+	// whitelistedTokens
+	WhitelistModeFiltered WhitelistMode = iota
+	// WhitelistModeCount lists allWhitelistedTokens up to whitelistedTokenCount
+	WhitelistModeCount
+	// WhitelistModeIndexed lists whitelistedTokens(uint256) up to whitelistedTokenCount
+	WhitelistModeIndexed
+)
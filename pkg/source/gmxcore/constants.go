@@ -0,0 +1,25 @@
+package gmxcore
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// sourceName is the liquidity source logged by the readers, which are shared by all forks
+const sourceName = "gmxcore"
+
+const FlagArbitrumSeqOffline = "0xa438451d6458044c3c8cd2f6f31c91ac882a6d91"
+
+const PricePrecisionDecimals = 30
+
+// calldataSize is 5 words: vault, tokenIn, tokenOut, amount and recipient
+const calldataSize = 5 * 32
+
+var (
+	DefaultGas         = Gas{Swap: 165000}
+	BasisPointsDivisor = bignumber.BasisPoint
+	PricePrecision     = bignumber.TenPowInt(PricePrecisionDecimals)
+	USDGDecimals       = big.NewInt(18)
+	OneUSD             = PricePrecision
+)
@@ -1,4 +1,4 @@
-package swapbasedperp
+package gmxcore
 
 import _ "embed"
 
@@ -14,9 +14,6 @@ var fastPriceFeedV2Json []byte
 //go:embed abis/PancakePair.json
 var pancakePairJson []byte
 
-//go:embed abis/PriceFeed.json
-var priceFeedJson []byte
-
 //go:embed abis/Vault.json
 var vaultJson []byte
 
@@ -25,3 +22,18 @@ var vaultPriceFeedJson []byte
 
 //go:embed abis/ERC20.json
 var erc20Json []byte
+
+//go:embed abis/VaultIndexedWhitelist.json
+var vaultIndexedWhitelistJson []byte
+
+//go:embed abis/VaultPriceFeedGetPrice.json
+var vaultPriceFeedGetPriceJson []byte
+
+//go:embed abis/PriceFeedProxy.json
+var priceFeedProxyJson []byte
+
+//go:embed abis/PriceFeedMinMax.json
+var priceFeedMinMaxJson []byte
+
+//go:embed abis/FeeUtilsV2.json
+var feeUtilsV2Json []byte
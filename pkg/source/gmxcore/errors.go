@@ -0,0 +1,22 @@
+package gmxcore
+
+import "errors"
+
+var (
+	ErrVaultSwapsNotEnabled                = errors.New("vault: swaps not enabled")
+	ErrVaultMaxUsdgExceeded                = errors.New("vault: max USDG exceeded") // code: 51
+	ErrVaultPoolAmountExceeded             = errors.New("vault: poolAmount exceeded")
+	ErrVaultReserveExceedsPool             = errors.New("vault: reserve exceeds pool") // code: 50
+	ErrVaultPoolAmountLessThanBufferAmount = errors.New("vault: poolAmount < buffer")
+	ErrDivisionByZero                      = errors.New("division by zero")
+
+	ErrVaultPriceFeedInvalidPriceFeed         = errors.New("vaultPriceFeed: invalid price feed")
+	ErrVaultPriceFeedInvalidPrice             = errors.New("vaultPriceFeed: invalid price")
+	ErrVaultPriceFeedCouldNotFetchPrice       = errors.New("vaultPriceFeed: could not fetch price")
+	ErrVaultPriceFeedChainlinkFeedsNotUpdated = errors.New("chainlink feeds are not being updated")
+	ErrVaultPriceFeedExpired                  = errors.New("vaultPriceFeed: expired")
+
+	ErrFeeUtilsV2IsNotInitialized = errors.New("feeUtilsV2: is not initialized")
+
+	ErrInvalidSecondaryPriceFeedVersion = errors.New("invalid secondary price feed version")
+)
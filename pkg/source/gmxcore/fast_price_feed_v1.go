@@ -1,10 +1,12 @@
-package gmx
+package gmxcore
 
 import (
 	"math/big"
 	"time"
 )
 
+// FastPriceFeedV1 is the first version of the keeper-pushed secondary price feed, tracked from chain like
+// FastPriceFeedV2.
 type FastPriceFeedV1 struct {
 	DisableFastPriceVoteCount *big.Int            `json:"disableFastPriceVoteCount,omitempty"`
 	IsSpreadEnabled           bool                `json:"isSpreadEnabled,omitempty"`
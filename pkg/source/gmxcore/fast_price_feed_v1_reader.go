@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -21,7 +21,7 @@ func NewFastPriceFeedV1Reader(ethrpcClient *ethrpc.Client) *FastPriceFeedV1Reade
 		abi:          fastPriceFeedV1ABI,
 		ethrpcClient: ethrpcClient,
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": sourceName,
 			"reader":          "FastPriceFeedV1Reader",
 		}),
 	}
@@ -1,10 +1,13 @@
-package gmx
+package gmxcore
 
 import (
 	"math/big"
 	"time"
 )
 
+// FastPriceFeedV2 is the secondary price feed GMX keepers push prices to with setPricesWithBits. Vault swaps read
+// these prices from storage and carry no signed prices, so they are tracked from chain rather than attached as
+// pulloracle reports.
 type FastPriceFeedV2 struct {
 	DisableFastPriceVoteCount     *big.Int                 `json:"disableFastPriceVoteCount,omitempty"`
 	IsSpreadEnabled               bool                     `json:"isSpreadEnabled,omitempty"`
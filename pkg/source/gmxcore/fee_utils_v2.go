@@ -0,0 +1,107 @@
+package gmxcore
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// FeeUtilsV2 holds the swap fees of vaults that moved them out of the vault into a FeeUtilsV2 contract (fxdx). Fees
+// are set per token and multiplied while the contract is inactive.
+type FeeUtilsV2 struct {
+	Address string `json:"address"`
+
+	IsInitialized           bool     `json:"isInitialized"`
+	IsActive                bool     `json:"isActive"`
+	FeeMultiplierIfInactive *big.Int `json:"feeMultiplierIfInactive"`
+	HasDynamicFees          bool     `json:"hasDynamicFees"`
+
+	TaxBasisPoints     map[string]*big.Int `json:"taxBasisPoints"`
+	SwapFeeBasisPoints map[string]*big.Int `json:"swapFeeBasisPoints"`
+
+	vault *Vault `msgpack:"-"`
+}
+
+func NewFeeUtilsV2() *FeeUtilsV2 {
+	return &FeeUtilsV2{
+		TaxBasisPoints:     make(map[string]*big.Int),
+		SwapFeeBasisPoints: make(map[string]*big.Int),
+	}
+}
+
+const (
+	feeUtilsV2MethodGetStates = "getStates"
+	feeUtilsV2IsInitialized   = "isInitialized"
+)
+
+func (f *FeeUtilsV2) GetSwapFeeBasisPoints(tokenIn string, tokenOut string, usdgAmount *big.Int) (*big.Int, error) {
+	if !f.IsInitialized {
+		return nil, ErrFeeUtilsV2IsNotInitialized
+	}
+
+	feesBasisPoints0 := f.getFeeBasisPoints(tokenIn, usdgAmount, f.SwapFeeBasisPoints[tokenIn],
+		f.TaxBasisPoints[tokenIn], true)
+	feesBasisPoints1 := f.getFeeBasisPoints(tokenOut, usdgAmount, f.SwapFeeBasisPoints[tokenOut],
+		f.TaxBasisPoints[tokenOut], false)
+
+	if feesBasisPoints0.Cmp(feesBasisPoints1) > 0 {
+		return feesBasisPoints0, nil
+	}
+
+	return feesBasisPoints1, nil
+}
+
+func (f *FeeUtilsV2) getFeeBasisPoints(
+	token string,
+	usdgDelta *big.Int,
+	feeBasisPoints *big.Int,
+	taxBasisPoints *big.Int,
+	increment bool,
+) *big.Int {
+	feeBps := feeBasisPoints
+	if !f.IsActive {
+		feeBps = new(big.Int).Mul(feeBasisPoints, f.FeeMultiplierIfInactive)
+	}
+
+	if !f.HasDynamicFees {
+		return feeBps
+	}
+
+	initialAmount := f.vault.USDGAmounts[token]
+	nextAmount := new(big.Int).Add(initialAmount, usdgDelta)
+	if !increment {
+		if usdgDelta.Cmp(initialAmount) > 0 {
+			nextAmount = bignumber.ZeroBI
+		} else {
+			nextAmount = new(big.Int).Sub(initialAmount, usdgDelta)
+		}
+	}
+
+	targetAmount := f.vault.GetTargetUSDGAmount(token)
+	if targetAmount.Sign() == 0 {
+		return feeBps
+	}
+
+	initialDiff := new(big.Int).Sub(initialAmount, targetAmount)
+	initialDiff.Abs(initialDiff)
+	nextDiff := new(big.Int).Sub(nextAmount, targetAmount)
+	nextDiff.Abs(nextDiff)
+
+	if nextDiff.Cmp(initialDiff) < 0 {
+		rebateBps := new(big.Int).Mul(taxBasisPoints, initialDiff)
+		rebateBps.Div(rebateBps, targetAmount)
+		if rebateBps.Cmp(feeBps) > 0 {
+			return bignumber.ZeroBI
+		}
+		return rebateBps.Sub(feeBps, rebateBps)
+	}
+
+	averageDiff := new(big.Int).Add(initialDiff, nextDiff)
+	averageDiff.Div(averageDiff, bignumber.Two)
+	if averageDiff.Cmp(targetAmount) > 0 {
+		averageDiff = targetAmount
+	}
+	taxBps := new(big.Int).Mul(taxBasisPoints, averageDiff)
+	taxBps.Div(taxBps, targetAmount)
+	return taxBps.Add(feeBps, taxBps)
+}
@@ -1,4 +1,4 @@
-package fxdx
+package gmxcore
 
 import (
 	"context"
@@ -17,7 +17,7 @@ type FeeUtilsV2Reader struct {
 
 func NewFeeUtilsV2Reader(ethrpcClient *ethrpc.Client) *FeeUtilsV2Reader {
 	return &FeeUtilsV2Reader{
-		abi:          feeUtilsABI,
+		abi:          feeUtilsV2ABI,
 		ethrpcClient: ethrpcClient,
 	}
 }
@@ -26,7 +26,7 @@ func (r *FeeUtilsV2Reader) Read(ctx context.Context, vault *Vault) (*FeeUtilsV2,
 	feeUtils := NewFeeUtilsV2()
 
 	var (
-		address = vault.FeeUtils.Hex()
+		address = vault.FeeUtilsAddress.Hex()
 		tokens  = make([]common.Address, len(vault.WhitelistedTokens))
 
 		isInitialized bool
@@ -42,7 +42,7 @@ func (r *FeeUtilsV2Reader) Read(ctx context.Context, vault *Vault) (*FeeUtilsV2,
 		tokens[i] = common.HexToAddress(token)
 	}
 
-	request := r.ethrpcClient.R()
+	request := r.ethrpcClient.R().SetContext(ctx)
 
 	request.AddCall(&ethrpc.Call{
 		ABI:    r.abi,
@@ -0,0 +1,141 @@
+package gmxcore_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/fulcrom"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/fxdx"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx"
+	gmxglp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx-glp"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/madmex"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/metavault"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/quickperps"
+	swapbasedperp "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/swapbased-perp"
+	zkerafinance "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/zkera-finance"
+)
+
+// forkQuote is a quote recorded with the implementation of a fork before it ran on gmxcore. Result is empty if the
+// quote failed, and Panicked is set if it panicked: quotes of tokens without price decimals, which now fail with
+// ErrVaultPriceFeedInvalidPriceFeed.
+type forkQuote struct {
+	TokenIn  string `json:"tokenIn"`
+	TokenOut string `json:"tokenOut"`
+	Amount   string `json:"amount"`
+	Result   string `json:"result,omitempty"`
+	Fee      string `json:"fee,omitempty"`
+	FeeToken string `json:"feeToken,omitempty"`
+	Gas      int64  `json:"gas,omitempty"`
+	Panicked bool   `json:"panicked,omitempty"`
+}
+
+// forkFixtures are the pool fixtures of a fork with their recorded quotes. Quotes are made on the fixture state,
+// while swaps are applied one after the other with UpdateBalance. {{now}} in the extra of a pool is replaced with the
+// current time, for fixtures whose prices were recorded as just updated.
+type forkFixtures struct {
+	Pools []struct {
+		Pool   entity.Pool `json:"pool"`
+		Quotes []forkQuote `json:"quotes"`
+		Swaps  []forkQuote `json:"swaps"`
+	} `json:"pools"`
+}
+
+var forkSimulators = map[string]func(entity.Pool) (pool.IPoolSimulator, error){
+	"fulcrom":        func(p entity.Pool) (pool.IPoolSimulator, error) { return fulcrom.NewPoolSimulator(p) },
+	"fxdx":           func(p entity.Pool) (pool.IPoolSimulator, error) { return fxdx.NewPoolSimulator(p) },
+	"gmx":            func(p entity.Pool) (pool.IPoolSimulator, error) { return gmx.NewPoolSimulator(p) },
+	"gmx-glp":        func(p entity.Pool) (pool.IPoolSimulator, error) { return gmxglp.NewPoolSimulator(p) },
+	"madmex":         func(p entity.Pool) (pool.IPoolSimulator, error) { return madmex.NewPoolSimulator(p) },
+	"metavault":      func(p entity.Pool) (pool.IPoolSimulator, error) { return metavault.NewPoolSimulator(p) },
+	"quickperps":     func(p entity.Pool) (pool.IPoolSimulator, error) { return quickperps.NewPoolSimulator(p) },
+	"swapbased-perp": func(p entity.Pool) (pool.IPoolSimulator, error) { return swapbasedperp.NewPoolSimulator(p) },
+	"zkera-finance":  func(p entity.Pool) (pool.IPoolSimulator, error) { return zkerafinance.NewPoolSimulator(p) },
+}
+
+func replayForkQuote(t *testing.T, sim pool.IPoolSimulator, q forkQuote) *pool.UpdateBalanceParams {
+	amount, ok := new(big.Int).SetString(q.Amount, 10)
+	require.True(t, ok)
+
+	res, err := sim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: q.TokenIn, Amount: amount},
+		TokenOut:      q.TokenOut,
+	})
+	if q.Panicked {
+		assert.ErrorIs(t, err, gmxcore.ErrVaultPriceFeedInvalidPriceFeed, "%+v", q)
+		return nil
+	} else if q.Result == "" {
+		assert.Error(t, err, "%+v", q)
+		return nil
+	}
+	if !assert.NoError(t, err, "%+v", q) {
+		return nil
+	}
+	assert.Equal(t, q.Result, res.TokenAmountOut.Amount.String(), "%+v", q)
+	if q.Fee == "" {
+		assert.Nil(t, res.Fee.Amount, "%+v", q)
+	} else {
+		assert.Equal(t, q.Fee, res.Fee.Amount.String(), "%+v", q)
+		assert.Equal(t, q.FeeToken, res.Fee.Token, "%+v", q)
+	}
+	assert.Equal(t, q.Gas, res.Gas, "%+v", q)
+	return &pool.UpdateBalanceParams{TokenAmountIn: pool.TokenAmount{Token: q.TokenIn, Amount: amount},
+		TokenAmountOut: *res.TokenAmountOut, Fee: *res.Fee, SwapInfo: res.SwapInfo}
+}
+
+// TestPoolSimulator_ForkParity replays the fixtures of every fork running on gmxcore against the quotes recorded with
+// its implementation before the migration, at commit 17d4a5e
+func TestPoolSimulator_ForkParity(t *testing.T) {
+	t.Parallel()
+	files, err := filepath.Glob("testdata/parity/*.json")
+	require.NoError(t, err)
+	require.Len(t, files, len(forkSimulators))
+
+	for _, file := range files {
+		fork := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(fork, func(t *testing.T) {
+			newForkSim := forkSimulators[fork]
+			require.NotNil(t, newForkSim)
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var fixtures forkFixtures
+			require.NoError(t, json.Unmarshal(data, &fixtures))
+
+			for i, fixture := range fixtures.Pools {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					newSim := func() pool.IPoolSimulator {
+						entityPool := fixture.Pool
+						entityPool.Extra = strings.ReplaceAll(entityPool.Extra, "{{now}}",
+							strconv.FormatInt(time.Now().Unix(), 10))
+						sim, err := newForkSim(entityPool)
+						require.NoError(t, err)
+						return sim
+					}
+
+					sim := newSim()
+					for _, q := range fixture.Quotes {
+						replayForkQuote(t, sim, q)
+					}
+
+					sim = newSim()
+					for _, q := range fixture.Swaps {
+						if params := replayForkQuote(t, sim, q); params != nil {
+							sim.UpdateBalance(*params)
+						}
+					}
+				})
+			}
+		})
+	}
+}
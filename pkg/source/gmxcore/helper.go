@@ -1,4 +1,4 @@
-package fxdx
+package gmxcore
 
 import (
 	"github.com/KyberNetwork/ethrpc"
@@ -1,7 +1,7 @@
-package madmex
+package gmxcore
 
 // NOTE: we generate mock files in the same package to avoid cycle dependencies
-//go:generate go run go.uber.org/mock/mockgen -destination ./mocks.go -package madmex . IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader
+//go:generate go run go.uber.org/mock/mockgen -destination ./mocks.go -package gmxcore . IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader
 
 import (
 	"context"
@@ -27,9 +27,9 @@ type IFastPriceFeedV2Reader interface {
 	Read(ctx context.Context, address string, tokens []string) (*FastPriceFeedV2, error)
 }
 
-// IPriceFeedReader reads price feed smart contract
+// IPriceFeedReader reads price feed smart contracts
 type IPriceFeedReader interface {
-	Read(ctx context.Context, address string, roundCount int) (*PriceFeed, error)
+	Read(ctx context.Context, addresses []string, roundCount int) ([]*PriceFeed, error)
 }
 
 // IUSDGReader reads usdg smart contract
@@ -51,3 +51,8 @@ type IFastPriceFeed interface {
 	GetVersion() int
 	GetPrice(token string, refPrice *big.Int, maximise bool) *big.Int
 }
+
+// IFeeUtilsV2Reader reads the fee utils smart contract of a vault
+type IFeeUtilsV2Reader interface {
+	Read(ctx context.Context, vault *Vault) (*FeeUtilsV2, error)
+}
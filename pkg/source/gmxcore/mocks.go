@@ -1,13 +1,13 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmx-glp (interfaces: IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader)
+// Source: github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore (interfaces: IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader)
 //
 // Generated by this command:
 //
-//	mockgen -destination ./mocks.go -package gmxglp . IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader
+//	mockgen -destination ./mocks.go -package gmxcore . IVaultReader,IVaultPriceFeedReader,IFastPriceFeedV1Reader,IPriceFeedReader,IUSDGReader,IChainlinkFlagsReader,IPancakePairReader
 //
 
-// Package gmxglp is a generated GoMock package.
-package gmxglp
+// Package gmxcore is a generated GoMock package.
+package gmxcore
 
 import (
 	context "context"
@@ -158,18 +158,18 @@ func (m *MockIPriceFeedReader) EXPECT() *MockIPriceFeedReaderMockRecorder {
 }
 
 // Read mocks base method.
-func (m *MockIPriceFeedReader) Read(ctx context.Context, address string, roundCount int) (*PriceFeed, error) {
+func (m *MockIPriceFeedReader) Read(ctx context.Context, addresses []string, roundCount int) ([]*PriceFeed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Read", ctx, address, roundCount)
-	ret0, _ := ret[0].(*PriceFeed)
+	ret := m.ctrl.Call(m, "Read", ctx, addresses, roundCount)
+	ret0, _ := ret[0].([]*PriceFeed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Read indicates an expected call of Read.
-func (mr *MockIPriceFeedReaderMockRecorder) Read(ctx, address, roundCount any) *gomock.Call {
+func (mr *MockIPriceFeedReaderMockRecorder) Read(ctx, addresses, roundCount any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockIPriceFeedReader)(nil).Read), ctx, address, roundCount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockIPriceFeedReader)(nil).Read), ctx, addresses, roundCount)
 }
 
 // MockIUSDGReader is a mock of IUSDGReader interface.
@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import "math/big"
 
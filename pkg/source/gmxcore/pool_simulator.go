@@ -0,0 +1,301 @@
+// Package gmxcore is the GMX V1 vault engine shared by GMX forks. Forks wrap PoolSimulator, PoolTracker and
+// PoolsListUpdater and configure their differences (price feed type, whitelist listing, fee contract and the name of
+// USDG) with Config.
+package gmxcore
+
+import (
+	"maps"
+	"math/big"
+	"strings"
+
+	"github.com/KyberNetwork/blockchain-toolkit/integer"
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
+
+type Gas struct {
+	Swap int64
+}
+
+type PoolSimulator struct {
+	pool.Pool
+
+	Vault      *Vault
+	vaultUtils *VaultUtils
+	feeUtils   *FeeUtilsV2
+	gas        Gas
+}
+
+// NewPoolSimulator builds a pool simulator from a pool entity. The price feed type of cfg applies to vaults saved
+// before the type was stored in their price feed.
+func NewPoolSimulator(entityPool entity.Pool, cfg Config) (*PoolSimulator, error) {
+	var extra Extra
+	if err := json.Unmarshal([]byte(entityPool.Extra), &extra); err != nil {
+		return nil, err
+	}
+
+	if extra.Vault != nil && extra.Vault.PriceFeed != nil &&
+		extra.Vault.PriceFeed.PriceFeedType == PriceFeedTypeLatestRoundData {
+		extra.Vault.PriceFeed.PriceFeedType = cfg.PriceFeedType
+	}
+	if extra.FeeUtils != nil {
+		extra.FeeUtils.vault = extra.Vault
+	}
+
+	tokens := make([]string, 0, len(entityPool.Tokens))
+	for _, poolToken := range entityPool.Tokens {
+		tokens = append(tokens, poolToken.Address)
+	}
+
+	reserves := make([]*big.Int, len(entityPool.Reserves))
+	for i, reserve := range entityPool.Reserves {
+		reserveBI, ok := new(big.Int).SetString(reserve, 10)
+		if !ok {
+			reserveBI = integer.Zero()
+		}
+
+		reserves[i] = reserveBI
+	}
+
+	info := pool.PoolInfo{
+		Address:  entityPool.Address,
+		Exchange: entityPool.Exchange,
+		Type:     entityPool.Type,
+		Tokens:   tokens,
+		Reserves: reserves,
+	}
+
+	return &PoolSimulator{
+		Pool: pool.Pool{
+			Info: info,
+		},
+		Vault:      extra.Vault,
+		vaultUtils: NewVaultUtils(extra.Vault),
+		feeUtils:   extra.FeeUtils,
+		gas:        DefaultGas,
+	}, nil
+}
+
+func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.CalcAmountOutResult, error) {
+	tokenAmountIn := param.TokenAmountIn
+	tokenOut := param.TokenOut
+	amountOutAfterFees, feeAmount, err := p.getAmountOut(tokenAmountIn.Token, tokenOut, tokenAmountIn.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAmountOut := &pool.TokenAmount{
+		Token:  tokenOut,
+		Amount: amountOutAfterFees,
+	}
+	tokenAmountFee := &pool.TokenAmount{
+		Token:  tokenOut,
+		Amount: feeAmount,
+	}
+
+	return &pool.CalcAmountOutResult{
+		TokenAmountOut: tokenAmountOut,
+		Fee:            tokenAmountFee,
+		Gas:            p.gas.Swap,
+	}, nil
+}
+
+func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
+	return p.Clone()
+}
+
+// Clone copies the vault amounts changed by UpdateBalance, so that forks can clone the embedded engine.
+func (p *PoolSimulator) Clone() *PoolSimulator {
+	cloned := *p
+	vault := *p.Vault
+	vault.USDGAmounts = maps.Clone(p.Vault.USDGAmounts)
+	vault.PoolAmounts = maps.Clone(p.Vault.PoolAmounts)
+	cloned.Vault = &vault
+	cloned.vaultUtils = NewVaultUtils(&vault)
+	if p.feeUtils != nil {
+		feeUtils := *p.feeUtils
+		feeUtils.vault = &vault
+		cloned.feeUtils = &feeUtils
+	}
+	return &cloned
+}
+
+// UpdateBalance update UsdgAmount only
+// https://github.com/gmx-io/gmx-contracts/blob/787d767e033c411f6d083f2725fb54b7fa956f7e/contracts/core/Vault.sol#L547-L548
+func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
+	input, output, fee := params.TokenAmountIn, params.TokenAmountOut, params.Fee
+	priceIn, err := p.Vault.GetMinPrice(input.Token)
+	if err != nil {
+		return
+	}
+
+	usdgAmount := new(big.Int).Mul(input.Amount, priceIn)
+	usdgAmount = usdgAmount.Div(usdgAmount, PricePrecision)
+	usdgAmount = p.Vault.AdjustForDecimals(usdgAmount, input.Token, p.Vault.USDG.Address)
+
+	p.Vault.IncreaseUSDGAmount(input.Token, usdgAmount)
+	p.Vault.DecreaseUSDGAmount(output.Token, usdgAmount)
+	p.Vault.IncreasePoolAmount(input.Token, input.Amount)
+	p.Vault.DecreasePoolAmount(output.Token, new(big.Int).Add(output.Amount, fee.Amount))
+}
+
+func (p *PoolSimulator) CanSwapFrom(address string) []string { return p.CanSwapTo(address) }
+
+func (p *PoolSimulator) CanSwapTo(address string) []string {
+	whitelistedTokens := p.Vault.WhitelistedTokens
+
+	isTokenExists := false
+	for _, token := range whitelistedTokens {
+		if strings.EqualFold(token, address) {
+			isTokenExists = true
+			break
+		}
+	}
+
+	if !isTokenExists {
+		return nil
+	}
+
+	swappableTokens := make([]string, 0, len(whitelistedTokens)-1)
+	for _, token := range whitelistedTokens {
+		tokenAddress := token
+
+		if address == tokenAddress {
+			continue
+		}
+
+		swappableTokens = append(swappableTokens, tokenAddress)
+	}
+
+	return swappableTokens
+}
+
+func (p *PoolSimulator) GetMetaInfo(_ string, _ string) interface{} { return nil }
+
+// GetCalldataSize returns the calldata size of a swap through the vault, which is the same for all forks
+func (p *PoolSimulator) GetCalldataSize(_, _ string) int { return calldataSize }
+
+// getAmountOut returns amountOutAfterFees, feeAmount and error
+func (p *PoolSimulator) getAmountOut(tokenIn string, tokenOut string, amountIn *big.Int) (*big.Int, *big.Int, error) {
+	if !p.Vault.IsSwapEnabled {
+		return nil, nil, ErrVaultSwapsNotEnabled
+	}
+
+	priceIn, err := p.Vault.GetMinPrice(tokenIn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priceOut, err := p.Vault.GetMaxPrice(tokenOut)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if priceOut.Sign() == 0 {
+		return nil, nil, ErrDivisionByZero
+	}
+
+	amountOut := new(big.Int).Mul(amountIn, priceIn)
+	usdgAmount := new(big.Int).Div(amountOut, PricePrecision)
+	usdgAmount = p.Vault.AdjustForDecimals(usdgAmount, tokenIn, p.Vault.USDG.Address)
+	amountOut = amountOut.Div(amountOut, priceOut)
+	amountOut = p.Vault.AdjustForDecimals(amountOut, tokenIn, tokenOut)
+
+	// in smart contract, this validation is implemented inside _increaseUsdgAmount method
+	if err = p.validateMaxUsdgExceed(tokenIn, usdgAmount); err != nil {
+		return nil, nil, err
+	}
+
+	// in smart contract, this validation is implemented inside _decreasePoolAmount method
+	if err = p.validateMinPoolAmount(tokenOut, amountOut); err != nil {
+		return nil, nil, err
+	}
+
+	// in smart contract, this validation is implemented inside _validateBufferAmount method
+	if err = p.validateBufferAmount(tokenOut, amountOut); err != nil {
+		return nil, nil, err
+	}
+
+	feeBasisPoints, err := p.getSwapFeeBasisPoints(tokenIn, tokenOut, usdgAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	amountOutAfterFees := new(big.Int).Div(
+		new(big.Int).Mul(
+			amountOut,
+			new(big.Int).Sub(BasisPointsDivisor, feeBasisPoints),
+		),
+		BasisPointsDivisor,
+	)
+
+	feeAmount := new(big.Int).Sub(amountOut, amountOutAfterFees)
+
+	return amountOutAfterFees, feeAmount, nil
+}
+
+func (p *PoolSimulator) getSwapFeeBasisPoints(tokenIn, tokenOut string, usdgAmount *big.Int) (*big.Int, error) {
+	if p.feeUtils != nil {
+		return p.feeUtils.GetSwapFeeBasisPoints(tokenIn, tokenOut, usdgAmount)
+	}
+
+	return p.vaultUtils.GetSwapFeeBasisPoints(tokenIn, tokenOut, usdgAmount), nil
+}
+
+func (p *PoolSimulator) validateMaxUsdgExceed(token string, amount *big.Int) error {
+	currentUsdgAmount := p.Vault.USDGAmounts[token]
+	newUsdgAmount := new(big.Int).Add(currentUsdgAmount, amount)
+
+	maxUsdgAmount := p.Vault.MaxUSDGAmounts[token]
+
+	if maxUsdgAmount.Sign() == 0 {
+		return nil
+	}
+
+	if newUsdgAmount.Cmp(maxUsdgAmount) < 0 {
+		return nil
+	}
+
+	return ErrVaultMaxUsdgExceeded
+}
+
+func (p *PoolSimulator) validateMinPoolAmount(token string, amount *big.Int) error {
+	currentPoolAmount := p.Vault.PoolAmounts[token]
+
+	if currentPoolAmount.Cmp(amount) < 0 {
+		return ErrVaultPoolAmountExceeded
+	}
+
+	newPoolAmount := new(big.Int).Sub(currentPoolAmount, amount)
+	reservedAmount := p.Vault.ReservedAmounts[token]
+
+	if reservedAmount.Cmp(newPoolAmount) > 0 {
+		return ErrVaultReserveExceedsPool
+	}
+
+	return nil
+}
+
+func (p *PoolSimulator) validateBufferAmount(token string, amount *big.Int) error {
+	currentPoolAmount := p.Vault.PoolAmounts[token]
+	newPoolAmount := new(big.Int).Sub(currentPoolAmount, amount)
+
+	bufferAmount := p.Vault.BufferAmounts[token]
+
+	if newPoolAmount.Cmp(bufferAmount) < 0 {
+		return ErrVaultPoolAmountLessThanBufferAmount
+	}
+
+	return nil
+}
+
+func (p *PoolSimulator) AfterMsgpackUnmarshal() error {
+	if p.vaultUtils != nil {
+		p.vaultUtils.vault = p.Vault
+	}
+	if p.feeUtils != nil {
+		p.feeUtils.vault = p.Vault
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package gmxcore
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+const (
+	testWeth = "0x82af49447d8a07e3bd95bd0d56f35241523fbab1"
+	testUsdc = "0xff970a61a04b1ca14834a43f5de4533ebddb5cc8"
+	testUsdg = "0x45096e7aa921f27590f8f19e457794eb09678141"
+)
+
+// newTestPriceFeed prices WETH at 2000 and USDC at 1 with answers of 8 decimals, as each price feed type stores them
+func newTestPriceFeed(priceFeedType PriceFeedType) *VaultPriceFeed {
+	pf := NewVaultPriceFeed()
+	pf.PriceFeedType = priceFeedType
+	for token, answer := range map[string]*big.Int{
+		testWeth: bignumber.NewBig10("200000000000"),
+		testUsdc: bignumber.NewBig10("100000000"),
+	} {
+		pf.PriceDecimals[token] = big.NewInt(8)
+		pf.SpreadBasisPoints[token] = bignumber.ZeroBI
+		pf.AdjustmentBasisPoints[token] = bignumber.ZeroBI
+		priceFeed := NewPriceFeed()
+		switch priceFeedType {
+		case PriceFeedTypeLatestRoundData:
+			pf.PriceSampleSpace = bignumber.One
+			priceFeed.RoundID = bignumber.One
+			priceFeed.Answer = answer
+		case PriceFeedTypeProxy:
+			pf.ExpireTimeForPriceFeed = big.NewInt(3600)
+			priceFeed.Answer = answer
+			priceFeed.Timestamp = uint32(time.Now().Unix())
+		case PriceFeedTypeLatestAnswerMinMax:
+			priceFeed.Answers["false"] = answer
+			priceFeed.Answers["true"] = answer
+		case PriceFeedTypeDirect, PriceFeedTypeVaultPrice:
+			price := new(big.Int).Mul(answer, bignumber.TenPowInt(PricePrecisionDecimals-8))
+			priceFeed.Answers["false"] = price
+			priceFeed.Answers["true"] = price
+		}
+		pf.PriceFeeds[token] = priceFeed
+	}
+	return pf
+}
+
+func newTestExtra(priceFeed *VaultPriceFeed) Extra {
+	vault := NewVault()
+	vault.IsSwapEnabled = true
+	vault.SwapFeeBasisPoints = big.NewInt(30)
+	vault.TaxBasisPoints = big.NewInt(50)
+	vault.StableSwapFeeBasisPoints = big.NewInt(4)
+	vault.StableTaxBasisPoints = big.NewInt(20)
+	vault.TotalTokenWeights = big.NewInt(100)
+	vault.WhitelistedTokens = []string{testWeth, testUsdc}
+	vault.PoolAmounts = map[string]*big.Int{
+		testWeth: bignumber.NewBig10("1000000000000000000000"),
+		testUsdc: bignumber.NewBig10("2000000000000"),
+	}
+	vault.BufferAmounts = map[string]*big.Int{testWeth: bignumber.ZeroBI, testUsdc: bignumber.ZeroBI}
+	vault.ReservedAmounts = map[string]*big.Int{testWeth: bignumber.ZeroBI, testUsdc: bignumber.ZeroBI}
+	vault.TokenDecimals = map[string]*big.Int{testWeth: big.NewInt(18), testUsdc: big.NewInt(6)}
+	vault.StableTokens = map[string]bool{testUsdc: true}
+	vault.USDGAmounts = map[string]*big.Int{
+		testWeth: bignumber.NewBig10("2000000000000000000000000"),
+		testUsdc: bignumber.NewBig10("2000000000000000000000000"),
+	}
+	vault.MaxUSDGAmounts = map[string]*big.Int{testWeth: bignumber.ZeroBI, testUsdc: bignumber.ZeroBI}
+	vault.TokenWeights = map[string]*big.Int{testWeth: big.NewInt(50), testUsdc: big.NewInt(50)}
+	vault.PriceFeed = priceFeed
+	vault.USDG = &USDG{Address: testUsdg, TotalSupply: bignumber.NewBig10("4000000000000000000000000")}
+	return Extra{Vault: vault}
+}
+
+func newTestPoolSimulator(t *testing.T, extra Extra, cfg Config) *PoolSimulator {
+	extraBytes, err := json.Marshal(extra)
+	require.NoError(t, err)
+
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x489ee077994b6658eafa855c308275ead8097c4a",
+		Exchange: "gmx",
+		Type:     "gmx",
+		Reserves: entity.PoolReserves{"1000000000000000000000", "2000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: testWeth}, {Address: testUsdc}},
+		Extra:    string(extraBytes),
+	}, cfg)
+	require.NoError(t, err)
+	return poolSim
+}
+
+func calcAmountOut(poolSim *PoolSimulator, tokenIn, tokenOut string, amountIn string) (*pool.CalcAmountOutResult,
+	error) {
+	return poolSim.CalcAmountOut(pool.CalcAmountOutParams{
+		TokenAmountIn: pool.TokenAmount{Token: tokenIn, Amount: bignumber.NewBig10(amountIn)},
+		TokenOut:      tokenOut,
+	})
+}
+
+// TestPoolSimulator_PriceFeedTypes checks that every price feed type quotes the same swap when it reports the same
+// prices, so that forks only differ in how their vaults are read.
+func TestPoolSimulator_PriceFeedTypes(t *testing.T) {
+	t.Parallel()
+
+	// 1 WETH = 2000 USDC, less the 30 bps swap fee of a balanced vault
+	const expectedAmountOut = "1994000000"
+
+	for name, priceFeedType := range map[string]PriceFeedType{
+		"latest round data":     PriceFeedTypeLatestRoundData,
+		"latest round answer":   PriceFeedTypeLatestRoundAnswer,
+		"direct":                PriceFeedTypeDirect,
+		"proxy":                 PriceFeedTypeProxy,
+		"latest answer min max": PriceFeedTypeLatestAnswerMinMax,
+		"vault price":           PriceFeedTypeVaultPrice,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			priceFeed := newTestPriceFeed(priceFeedType)
+			if priceFeedType == PriceFeedTypeLatestRoundAnswer {
+				priceFeed = newTestPriceFeed(PriceFeedTypeLatestRoundData)
+				priceFeed.PriceFeedType = PriceFeedTypeLatestRoundAnswer
+			}
+			poolSim := newTestPoolSimulator(t, newTestExtra(priceFeed), Config{})
+
+			result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+			require.NoError(t, err)
+			assert.Equal(t, expectedAmountOut, result.TokenAmountOut.Amount.String())
+
+			testutil.TestPoolSimulator(t, poolSim)
+		})
+	}
+}
+
+// TestPoolSimulator_ConfigPriceFeedType checks that vaults saved before the price feed type was stored take it from
+// the fork config.
+func TestPoolSimulator_ConfigPriceFeedType(t *testing.T) {
+	t.Parallel()
+
+	priceFeed := newTestPriceFeed(PriceFeedTypeLatestAnswerMinMax)
+	priceFeed.PriceFeedType = PriceFeedTypeLatestRoundData
+	poolSim := newTestPoolSimulator(t, newTestExtra(priceFeed),
+		Config{PriceFeedType: PriceFeedTypeLatestAnswerMinMax})
+
+	result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, "1994000000", result.TokenAmountOut.Amount.String())
+}
+
+func TestPoolSimulator_GetCalldataSize(t *testing.T) {
+	t.Parallel()
+	poolSim := newTestPoolSimulator(t, newTestExtra(newTestPriceFeed(PriceFeedTypeLatestRoundData)), Config{})
+	assert.Equal(t, 160, pool.CalldataSize(poolSim, testWeth, testUsdc))
+}
+
+func TestPoolSimulator_PriceFeedErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		priceFeed   func() *VaultPriceFeed
+		expectedErr error
+	}{
+		{
+			name: "it should return error when the proxy answer is expired",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeProxy)
+				pf.PriceFeeds[testWeth].Timestamp = uint32(time.Now().Add(-2 * time.Hour).Unix())
+				return pf
+			},
+			expectedErr: ErrVaultPriceFeedExpired,
+		},
+		{
+			name: "it should return error when the sequencer is down",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeProxy)
+				pf.Sequencer = &oracle.Sequencer{Answer: big.NewInt(1), StartedAt: big.NewInt(0), GracePeriod: 3600}
+				return pf
+			},
+			expectedErr: oracle.ErrSequencerDown,
+		},
+		{
+			name: "it should return error when the sequencer grace period is not over",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeProxy)
+				pf.Sequencer = &oracle.Sequencer{Answer: big.NewInt(0), StartedAt: big.NewInt(time.Now().Unix()),
+					GracePeriod: 3600}
+				return pf
+			},
+			expectedErr: oracle.ErrGracePeriodNotOver,
+		},
+		{
+			name: "it should return error when the min max answer is missing",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeLatestAnswerMinMax)
+				delete(pf.PriceFeeds[testWeth].Answers, "false")
+				return pf
+			},
+			expectedErr: ErrVaultPriceFeedCouldNotFetchPrice,
+		},
+		{
+			name: "it should return error when the vault has no price for the token",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeVaultPrice)
+				delete(pf.PriceFeeds, testWeth)
+				return pf
+			},
+			expectedErr: ErrVaultPriceFeedInvalidPriceFeed,
+		},
+		{
+			name: "it should return error when the price out is zero",
+			priceFeed: func() *VaultPriceFeed {
+				pf := newTestPriceFeed(PriceFeedTypeDirect)
+				delete(pf.PriceFeeds[testUsdc].Answers, "true")
+				return pf
+			},
+			expectedErr: ErrDivisionByZero,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			poolSim := newTestPoolSimulator(t, newTestExtra(tc.priceFeed()), Config{})
+
+			_, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestPoolSimulator_FeeUtils(t *testing.T) {
+	t.Parallel()
+
+	extra := newTestExtra(newTestPriceFeed(PriceFeedTypeLatestRoundData))
+	extra.Vault.HasDynamicFees = true
+	extra.FeeUtils = NewFeeUtilsV2()
+	extra.FeeUtils.IsInitialized = true
+	extra.FeeUtils.FeeMultiplierIfInactive = big.NewInt(2)
+	extra.FeeUtils.HasDynamicFees = true
+	extra.FeeUtils.SwapFeeBasisPoints = map[string]*big.Int{testWeth: big.NewInt(25), testUsdc: big.NewInt(10)}
+	extra.FeeUtils.TaxBasisPoints = map[string]*big.Int{testWeth: big.NewInt(50), testUsdc: big.NewInt(50)}
+
+	t.Run("it should use the fees of the fee contract", func(t *testing.T) {
+		extra.FeeUtils.IsActive = true
+		poolSim := newTestPoolSimulator(t, extra, Config{})
+
+		// 25 bps swap fee of WETH, the tax rounds down to 0 bps for a swap this small
+		result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+		require.NoError(t, err)
+		assert.Equal(t, "1995000000", result.TokenAmountOut.Amount.String())
+
+		testutil.TestPoolSimulator(t, poolSim)
+	})
+
+	t.Run("it should multiply the fees of an inactive fee contract", func(t *testing.T) {
+		extra.FeeUtils.IsActive = false
+		poolSim := newTestPoolSimulator(t, extra, Config{})
+
+		result, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+		require.NoError(t, err)
+		assert.Equal(t, "1990000000", result.TokenAmountOut.Amount.String())
+	})
+
+	t.Run("it should return error when the fee contract is not initialized", func(t *testing.T) {
+		extra.FeeUtils.IsInitialized = false
+		poolSim := newTestPoolSimulator(t, extra, Config{})
+
+		_, err := calcAmountOut(poolSim, testWeth, testUsdc, "1000000000000000000")
+		assert.ErrorIs(t, err, ErrFeeUtilsV2IsNotInitialized)
+	})
+}
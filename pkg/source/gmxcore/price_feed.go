@@ -0,0 +1,64 @@
+package gmxcore
+
+import (
+	"math/big"
+
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+)
+
+type PriceFeed struct {
+	RoundID *big.Int            `json:"roundId,omitempty"`
+	Answer  *big.Int            `json:"answer,omitempty"`
+	Answers map[string]*big.Int `json:"answers,omitempty"`
+	// Timestamp is when Answer was last updated, for PriceFeedTypeProxy
+	Timestamp uint32 `json:"timestamp,omitempty"`
+}
+
+// UnmarshalJSON also accepts the keys written by forks before they moved to gmxcore: price for the answer of a proxy
+// and latestAnswers for the minimised and maximised answers.
+func (pf *PriceFeed) UnmarshalJSON(data []byte) error {
+	type priceFeed PriceFeed
+	var legacy struct {
+		priceFeed
+		Price         *big.Int            `json:"price"`
+		LatestAnswers map[string]*big.Int `json:"latestAnswers"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	*pf = PriceFeed(legacy.priceFeed)
+	if pf.Answer == nil {
+		pf.Answer = legacy.Price
+	}
+	if pf.Answers == nil {
+		pf.Answers = legacy.LatestAnswers
+	}
+	return nil
+}
+
+type RoundData = oracle.RoundData
+
+func NewPriceFeed() *PriceFeed {
+	return &PriceFeed{
+		Answers: make(map[string]*big.Int),
+	}
+}
+
+const minRoundCount = 2
+
+func (pf *PriceFeed) LatestRound() *big.Int {
+	return pf.RoundID
+}
+
+func (pf *PriceFeed) LatestAnswer() *big.Int {
+	return pf.Answer
+}
+
+// GetRoundData returns roundId, answer, startedAt, updatedAt, answeredInRound
+func (pf *PriceFeed) GetRoundData(roundID *big.Int) (*big.Int, *big.Int, *big.Int, *big.Int, *big.Int) {
+	return roundID, pf.Answers[roundID.String()], bignumber.ZeroBI, bignumber.ZeroBI, bignumber.ZeroBI
+}
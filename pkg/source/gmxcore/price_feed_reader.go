@@ -0,0 +1,194 @@
+package gmxcore
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+)
+
+type PriceFeedType int
+
+const (
+	PriceFeedTypeLatestRoundData PriceFeedType = iota
+	PriceFeedTypeLatestRoundAnswer
+	PriceFeedTypeDirect // should prefer direct if VaultPriceFeed exposes getPrimaryPrice method
+	// PriceFeedTypeProxy reads the value and timestamp of price feed proxies with read(). Prices expire after
+	// expireTimeForPriceFeed of the VaultPriceFeed and are not sampled (quickperps).
+	PriceFeedTypeProxy
+	// PriceFeedTypeLatestAnswerMinMax reads the minimised and maximised answers of latestAnswer(bool) (zkera-finance)
+	PriceFeedTypeLatestAnswerMinMax
+	// PriceFeedTypeVaultPrice reads final prices from getPrice(token, maximise) of the VaultPriceFeed, which already
+	// include spreads and adjustments (fulcrom)
+	PriceFeedTypeVaultPrice
+)
+
+const (
+	priceFeedMethodRead         = "read"
+	priceFeedMethodLatestAnswer = "latestAnswer"
+)
+
+type PriceFeedReader struct {
+	ethrpcClient *ethrpc.Client
+	log          logger.Logger
+
+	PriceFeedType PriceFeedType
+}
+
+func NewPriceFeedReader(ethrpcClient *ethrpc.Client) *PriceFeedReader {
+	return NewPriceFeedReaderWithParam(ethrpcClient, PriceFeedTypeLatestRoundData)
+}
+
+func NewPriceFeedReaderWithParam(ethrpcClient *ethrpc.Client, priceFeedType PriceFeedType) *PriceFeedReader {
+	return &PriceFeedReader{
+		ethrpcClient: ethrpcClient,
+		log: logger.WithFields(logger.Fields{
+			"liquiditySource": sourceName,
+			"reader":          "PriceFeedReader",
+		}),
+
+		PriceFeedType: priceFeedType,
+	}
+}
+
+// Read reads the price feeds at addresses in a batch per step rather than per feed, along with their roundCount latest
+// rounds if prices are sampled.
+func (r *PriceFeedReader) Read(ctx context.Context, addresses []string, roundCount int) ([]*PriceFeed, error) {
+	priceFeeds := make([]*PriceFeed, len(addresses))
+	for i := range priceFeeds {
+		priceFeeds[i] = NewPriceFeed()
+	}
+	if len(addresses) == 0 {
+		return priceFeeds, nil
+	}
+
+	if err := r.getLatestRoundData(ctx, addresses, priceFeeds); err != nil {
+		r.log.Errorf("error when get latest round data: %s", err)
+		return nil, err
+	}
+
+	if err := r.getHistoryRoundData(ctx, addresses, priceFeeds, roundCount); err != nil {
+		r.log.Errorf("error when get history round data: %s", err)
+		return nil, err
+	}
+
+	return priceFeeds, nil
+}
+
+func (r *PriceFeedReader) getLatestRoundData(ctx context.Context, addresses []string, priceFeeds []*PriceFeed) error {
+	rpcRequest := r.ethrpcClient.NewRequest().SetContext(ctx)
+
+	switch r.PriceFeedType {
+	case PriceFeedTypeLatestRoundData:
+		latestRoundData := make([]RoundData, len(addresses))
+		for i, address := range addresses {
+			oracle.AddLatestRoundDataCall(rpcRequest, address, &latestRoundData[i])
+		}
+		if _, err := rpcRequest.Aggregate(); err != nil {
+			return err
+		}
+
+		for i, priceFeed := range priceFeeds {
+			priceFeed.RoundID = latestRoundData[i].RoundId
+			priceFeed.Answer = latestRoundData[i].Answer
+			priceFeed.Answers[latestRoundData[i].RoundId.String()] = latestRoundData[i].Answer
+		}
+
+	case PriceFeedTypeLatestRoundAnswer:
+		latestRounds, latestAnswers := make([]*big.Int, len(addresses)), make([]*big.Int, len(addresses))
+		for i, address := range addresses {
+			latestRounds[i], latestAnswers[i] = bignumber.ZeroBI, bignumber.ZeroBI
+			oracle.AddLatestAnswerCalls(rpcRequest, address, &latestRounds[i], &latestAnswers[i])
+		}
+		if _, err := rpcRequest.Aggregate(); err != nil {
+			return err
+		}
+
+		for i, priceFeed := range priceFeeds {
+			priceFeed.RoundID = latestRounds[i]
+			priceFeed.Answer = latestAnswers[i]
+			priceFeed.Answers[latestRounds[i].String()] = latestAnswers[i]
+		}
+
+	case PriceFeedTypeProxy:
+		proxyData := make([]struct {
+			Value     *big.Int `json:"value"`
+			Timestamp uint32   `json:"timestamp"`
+		}, len(addresses))
+		for i, address := range addresses {
+			rpcRequest.AddCall(&ethrpc.Call{
+				ABI:    priceFeedProxyABI,
+				Target: address,
+				Method: priceFeedMethodRead,
+			}, []interface{}{&proxyData[i]})
+		}
+		if _, err := rpcRequest.TryAggregate(); err != nil {
+			return err
+		}
+
+		for i, priceFeed := range priceFeeds {
+			priceFeed.Answer = proxyData[i].Value
+			priceFeed.Timestamp = proxyData[i].Timestamp
+		}
+
+	case PriceFeedTypeLatestAnswerMinMax:
+		minAnswers, maxAnswers := make([]*big.Int, len(addresses)), make([]*big.Int, len(addresses))
+		for i, address := range addresses {
+			rpcRequest.AddCall(&ethrpc.Call{
+				ABI:    priceFeedMinMaxABI,
+				Target: address,
+				Method: priceFeedMethodLatestAnswer,
+				Params: []interface{}{false},
+			}, []interface{}{&minAnswers[i]})
+			rpcRequest.AddCall(&ethrpc.Call{
+				ABI:    priceFeedMinMaxABI,
+				Target: address,
+				Method: priceFeedMethodLatestAnswer,
+				Params: []interface{}{true},
+			}, []interface{}{&maxAnswers[i]})
+		}
+		if _, err := rpcRequest.TryAggregate(); err != nil {
+			return err
+		}
+
+		for i, priceFeed := range priceFeeds {
+			priceFeed.Answers[strconv.FormatBool(false)] = minAnswers[i]
+			priceFeed.Answers[strconv.FormatBool(true)] = maxAnswers[i]
+		}
+
+	case PriceFeedTypeDirect, PriceFeedTypeVaultPrice: // already read directly by VaultPriceFeedReader
+		return nil
+	}
+
+	return nil
+}
+
+func (r *PriceFeedReader) getHistoryRoundData(ctx context.Context, addresses []string, priceFeeds []*PriceFeed,
+	roundCount int) error {
+	if roundCount < minRoundCount || r.PriceFeedType > PriceFeedTypeLatestRoundAnswer {
+		return nil
+	}
+
+	rpcRequest := r.ethrpcClient.NewRequest().SetContext(ctx)
+	roundDataLists := make([][]RoundData, len(addresses))
+	for i, address := range addresses {
+		roundDataLists[i] = oracle.AddGetRoundDataCalls(rpcRequest, address, priceFeeds[i].RoundID, roundCount-1)
+	}
+
+	if _, err := rpcRequest.TryAggregate(); err != nil {
+		return err
+	}
+
+	for i, roundDataList := range roundDataLists {
+		for _, roundData := range roundDataList {
+			priceFeeds[i].Answers[roundData.RoundId.String()] = roundData.Answer
+		}
+	}
+
+	return nil
+}
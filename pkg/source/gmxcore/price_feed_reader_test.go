@@ -0,0 +1,55 @@
+package gmxcore
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPriceFeedReader_Read(t *testing.T) {
+	t.Parallel()
+
+	var (
+		wethFeed = common.HexToAddress("0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612")
+		usdcFeed = common.HexToAddress("0x50834F3163758fcC1Df9973b6e91f0F0F0434aD3")
+		answers  = map[common.Address]int64{wethFeed: 2000e8, usdcFeed: 1e8}
+	)
+	ethrpcClient := testutil.NewEthrpcClient(t, func(target common.Address, data []byte) ([]byte, bool) {
+		method, err := oracle.AggregatorABI.MethodById(data)
+		require.NoError(t, err)
+		roundId := big.NewInt(10)
+		if len(data) > 4 {
+			args, err := method.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			roundId = args[0].(*big.Int)
+		}
+		// each earlier round answers one less
+		answer := new(big.Int).Sub(big.NewInt(answers[target]), new(big.Int).Sub(big.NewInt(10), roundId))
+		out, err := method.Outputs.Pack(roundId, answer, big.NewInt(1), big.NewInt(1), roundId)
+		require.NoError(t, err)
+		return out, true
+	})
+
+	priceFeeds, err := NewPriceFeedReader(ethrpcClient).Read(context.Background(),
+		[]string{wethFeed.Hex(), usdcFeed.Hex()}, 3)
+	require.NoError(t, err)
+	require.Len(t, priceFeeds, 2)
+
+	assert.Equal(t, "10", priceFeeds[0].LatestRound().String())
+	assert.Equal(t, "200000000000", priceFeeds[0].LatestAnswer().String())
+	assert.Equal(t, "199999999999", priceFeeds[0].Answers["9"].String())
+	assert.Equal(t, "199999999998", priceFeeds[0].Answers["8"].String())
+	assert.Equal(t, "100000000", priceFeeds[1].LatestAnswer().String())
+	assert.Equal(t, "99999998", priceFeeds[1].Answers["8"].String())
+
+	priceFeeds, err = NewPriceFeedReader(ethrpcClient).Read(context.Background(), nil, 3)
+	require.NoError(t, err)
+	assert.Empty(t, priceFeeds)
+}
@@ -1,11 +1,12 @@
-package gmx
+package gmxcore
 
 type VaultAddress struct {
 	Vault string `json:"vault"`
 }
 
 type Extra struct {
-	Vault *Vault `json:"vault"`
+	Vault    *Vault      `json:"vault"`
+	FeeUtils *FeeUtilsV2 `json:"feeUtils,omitempty"`
 }
 
 type ChainID uint
@@ -1,4 +1,4 @@
-package gmxglp
+package gmxcore
 
 import (
 	"math/big"
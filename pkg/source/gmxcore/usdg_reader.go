@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -20,7 +20,7 @@ func NewUSDGReader(ethrpcClient *ethrpc.Client) *USDGReader {
 		abi:          erc20ABI,
 		ethrpcClient: ethrpcClient,
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": sourceName,
 			"reader":          "USDGReader",
 		}),
 	}
@@ -1,9 +1,11 @@
-package gmx
+package gmxcore
 
 import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 )
@@ -17,6 +19,7 @@ type Vault struct {
 	SwapFeeBasisPoints       *big.Int `json:"swapFeeBasisPoints,omitempty"`
 	TaxBasisPoints           *big.Int `json:"taxBasisPoints,omitempty"`
 	TotalTokenWeights        *big.Int `json:"totalTokenWeights,omitempty"`
+	MintBurnFeeBasisPoints   *big.Int `json:"mintBurnFeeBasisPoints,omitempty"`
 
 	WhitelistedTokens []string            `json:"whitelistedTokens,omitempty"`
 	PoolAmounts       map[string]*big.Int `json:"poolAmounts,omitempty"`
@@ -36,6 +39,8 @@ type Vault struct {
 
 	WhitelistedTokensCount *big.Int `json:"-"`
 
+	FeeUtilsAddress common.Address `json:"-"`
+
 	UseSwapPricing bool `json:"useSwapPricing,omitempty"` // not used, always false for now
 }
 
@@ -62,8 +67,11 @@ const (
 	vaultMethodSwapFeeBasisPoints       = "swapFeeBasisPoints"
 	vaultMethodTaxBasisPoints           = "taxBasisPoints"
 	vaultMethodTotalTokenWeights        = "totalTokenWeights"
+	vaultMethodMintBurnFeeBasisPoints   = "mintBurnFeeBasisPoints"
+	vaultMethodFeeUtils                 = "feeUtils"
 
 	vaultMethodAllWhitelistedTokensLength = "allWhitelistedTokensLength"
+	vaultMethodWhitelistedTokenCount      = "whitelistedTokenCount"
 	vaultMethodAllWhitelistedTokens       = "allWhitelistedTokens"
 	vaultMethodWhitelistedTokens          = "whitelistedTokens"
 
@@ -75,6 +83,45 @@ const (
 	vaultMethodTokenWeights    = "tokenWeights"
 )
 
+// UnmarshalJSON also accepts the keys written by forks before they moved to gmxcore, which named USDG after their own
+// stablecoin (e.g. usdmAmounts) and misspelled mintBurnFeeBasisPoints.
+func (v *Vault) UnmarshalJSON(data []byte) error {
+	type vault Vault
+	var legacy struct {
+		vault
+		MintBurnFeeBasicPoints *big.Int `json:"mintBurnFeeBasicPoints"`
+
+		USDMAmounts    map[string]*big.Int `json:"usdmAmounts"`
+		MaxUSDMAmounts map[string]*big.Int `json:"maxUsdmAmounts"`
+		USDM           *USDG               `json:"usdm"`
+		USDQAmounts    map[string]*big.Int `json:"usdqAmounts"`
+		MaxUSDQAmounts map[string]*big.Int `json:"maxUsdqAmounts"`
+		USDQ           *USDG               `json:"usdq"`
+		USDBAmounts    map[string]*big.Int `json:"usdbAmounts"`
+		MaxUSDBAmounts map[string]*big.Int `json:"maxUsdbAmounts"`
+		USDB           *USDG               `json:"usdb"`
+		USDFAmounts    map[string]*big.Int `json:"usdfAmounts"`
+		MaxUSDFAmounts map[string]*big.Int `json:"maxUsdfAmounts"`
+		USDF           *USDG               `json:"usdf"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	*v = Vault(legacy.vault)
+	if v.MintBurnFeeBasisPoints == nil {
+		v.MintBurnFeeBasisPoints = legacy.MintBurnFeeBasicPoints
+	}
+	if v.USDG == nil {
+		v.USDG = lo.CoalesceOrEmpty(legacy.USDM, legacy.USDQ, legacy.USDB, legacy.USDF)
+		v.USDGAmounts = lo.CoalesceMapOrEmpty(legacy.USDMAmounts, legacy.USDQAmounts, legacy.USDBAmounts,
+			legacy.USDFAmounts)
+		v.MaxUSDGAmounts = lo.CoalesceMapOrEmpty(legacy.MaxUSDMAmounts, legacy.MaxUSDQAmounts, legacy.MaxUSDBAmounts,
+			legacy.MaxUSDFAmounts)
+	}
+	return nil
+}
+
 func (v *Vault) GetMinPrice(token string) (*big.Int, error) {
 	return v.PriceFeed.GetPrice(token, false, v.IncludeAmmPrice, v.UseSwapPricing)
 }
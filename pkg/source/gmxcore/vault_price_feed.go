@@ -1,14 +1,16 @@
-package gmx
+package gmxcore
 
 import (
 	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 	"github.com/samber/lo"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type VaultPriceFeed struct {
@@ -25,6 +27,7 @@ type VaultPriceFeed struct {
 	SpreadThresholdBasisPoints *big.Int      `json:"spreadThresholdBasisPoints,omitempty"`
 	UseV2Pricing               bool          `json:"useV2Pricing,omitempty"`
 	PriceFeedType              PriceFeedType `json:"priceFeedType,omitempty"`
+	ExpireTimeForPriceFeed     *big.Int      `json:"expireTimeForPriceFeed,omitempty"`
 
 	PriceDecimals         map[string]*big.Int `json:"priceDecimals,omitempty"`
 	SpreadBasisPoints     map[string]*big.Int `json:"spreadBasisPoints,omitempty"`
@@ -44,6 +47,8 @@ type VaultPriceFeed struct {
 	ChainlinkFlagsAddress common.Address  `json:"-"`
 	ChainlinkFlags        *ChainlinkFlags `json:"chainlinkFlags,omitempty"`
 
+	Sequencer *oracle.Sequencer `json:"sequencer,omitempty"`
+
 	SecondaryPriceFeedAddress common.Address `json:"-"`
 	SecondaryPriceFeed        IFastPriceFeed `json:"secondaryPriceFeed,omitempty"`
 	SecondaryPriceFeedVersion int            `json:"secondaryPriceFeedVersion,omitempty"`
@@ -80,6 +85,7 @@ const (
 	vaultPriceFeedMethodSecondaryPriceFeed         = "secondaryPriceFeed"
 	vaultPriceFeedMethodSpreadThresholdBasisPoints = "spreadThresholdBasisPoints"
 	vaultPriceFeedMethodUseV2Pricing               = "useV2Pricing"
+	vaultPriceFeedMethodExpireTimeForPriceFeed     = "expireTimeForPriceFeed"
 
 	vaultPriceFeedMethodPriceFeeds            = "priceFeeds"
 	vaultPriceFeedMethodPriceDecimals         = "priceDecimals"
@@ -88,6 +94,8 @@ const (
 	vaultPriceFeedMethodStrictStableTokens    = "strictStableTokens"
 	vaultPriceFeedMethodIsAdjustmentAdditive  = "isAdjustmentAdditive"
 	vaultPriceFeedMethodGetPrimaryPrice       = "getPrimaryPrice"
+	vaultPriceFeedMethodPriceFeedProxies      = "priceFeedProxies"
+	vaultPriceFeedMethodGetPrice              = "getPrice"
 )
 
 func (pf *VaultPriceFeed) UnmarshalJSON(bytes []byte) error {
@@ -103,6 +111,7 @@ func (pf *VaultPriceFeed) UnmarshalJSON(bytes []byte) error {
 		SpreadThresholdBasisPoints *big.Int              `json:"spreadThresholdBasisPoints"`
 		UseV2Pricing               bool                  `json:"useV2Pricing"`
 		PriceFeedType              PriceFeedType         `json:"priceFeedType"`
+		ExpireTimeForPriceFeed     *big.Int              `json:"expireTimeForPriceFeed"`
 		PriceDecimals              map[string]*big.Int   `json:"priceDecimals"`
 		SpreadBasisPoints          map[string]*big.Int   `json:"spreadBasisPoints"`
 		AdjustmentBasisPoints      map[string]*big.Int   `json:"adjustmentBasisPoints"`
@@ -112,8 +121,13 @@ func (pf *VaultPriceFeed) UnmarshalJSON(bytes []byte) error {
 		BTCBNB                     *PancakePair          `json:"btcBnb"`
 		ETHBNB                     *PancakePair          `json:"ethBnb"`
 		ChainlinkFlags             *ChainlinkFlags       `json:"chainlinkFlags"`
+		Sequencer                  *oracle.Sequencer     `json:"sequencer"`
 		SecondaryPriceFeedVersion  int                   `json:"secondaryPriceFeedVersion"`
 		PriceFeeds                 map[string]*PriceFeed `json:"priceFeeds"`
+
+		// written by fulcrom before it moved to gmxcore
+		MinPrices map[string]*big.Int `json:"minPrices"`
+		MaxPrices map[string]*big.Int `json:"maxPrices"`
 	}
 
 	if err := json.Unmarshal(bytes, &priceFeed); err != nil {
@@ -131,6 +145,7 @@ func (pf *VaultPriceFeed) UnmarshalJSON(bytes []byte) error {
 	pf.SpreadThresholdBasisPoints = priceFeed.SpreadThresholdBasisPoints
 	pf.UseV2Pricing = priceFeed.UseV2Pricing
 	pf.PriceFeedType = priceFeed.PriceFeedType
+	pf.ExpireTimeForPriceFeed = priceFeed.ExpireTimeForPriceFeed
 	pf.PriceDecimals = priceFeed.PriceDecimals
 	pf.SpreadBasisPoints = priceFeed.SpreadBasisPoints
 	pf.AdjustmentBasisPoints = priceFeed.AdjustmentBasisPoints
@@ -140,8 +155,20 @@ func (pf *VaultPriceFeed) UnmarshalJSON(bytes []byte) error {
 	pf.BTCBNB = priceFeed.BTCBNB
 	pf.ETHBNB = priceFeed.ETHBNB
 	pf.ChainlinkFlags = priceFeed.ChainlinkFlags
+	pf.Sequencer = priceFeed.Sequencer
 	pf.SecondaryPriceFeedVersion = priceFeed.SecondaryPriceFeedVersion
 	pf.PriceFeeds = priceFeed.PriceFeeds
+	if pf.PriceFeeds == nil && priceFeed.MaxPrices != nil {
+		pf.PriceFeeds = make(map[string]*PriceFeed, len(priceFeed.MaxPrices))
+		for token, maxPrice := range priceFeed.MaxPrices {
+			pf.PriceFeeds[token] = &PriceFeed{
+				Answers: map[string]*big.Int{
+					strconv.FormatBool(false): priceFeed.MinPrices[token],
+					strconv.FormatBool(true):  maxPrice,
+				},
+			}
+		}
+	}
 
 	if err := pf.UnmarshalJSONSecondaryPriceFeed(bytes); err != nil {
 		return err
@@ -161,8 +188,10 @@ func (pf *VaultPriceFeed) UnmarshalJSONSecondaryPriceFeed(bytes []byte) error {
 			return nil
 		}
 
-		pf.SecondaryPriceFeed = priceFeed.SecondaryPriceFeed
-	case 2:
+		if priceFeed.SecondaryPriceFeed != nil {
+			pf.SecondaryPriceFeed = priceFeed.SecondaryPriceFeed
+		}
+	case 0, 2: // fxdx did not write the version of its V2 fast price feed
 		var priceFeed struct {
 			SecondaryPriceFeed *FastPriceFeedV2 `json:"secondaryPriceFeed"`
 		}
@@ -171,7 +200,10 @@ func (pf *VaultPriceFeed) UnmarshalJSONSecondaryPriceFeed(bytes []byte) error {
 			return nil
 		}
 
-		pf.SecondaryPriceFeed = priceFeed.SecondaryPriceFeed
+		if priceFeed.SecondaryPriceFeed != nil {
+			pf.SecondaryPriceFeed = priceFeed.SecondaryPriceFeed
+			pf.SecondaryPriceFeedVersion = int(secondaryPriceFeedVersion2)
+		}
 	default:
 		return ErrInvalidSecondaryPriceFeedVersion
 	}
@@ -183,7 +215,9 @@ func (pf *VaultPriceFeed) GetPrice(token string, maximise bool, includeAmmPrice
 	var price *big.Int
 	var err error
 
-	if pf.UseV2Pricing {
+	if pf.PriceFeedType == PriceFeedTypeVaultPrice {
+		return pf.getVaultPrice(token, maximise)
+	} else if pf.UseV2Pricing {
 		price, err = pf.getPriceV2(token, maximise, includeAmmPrice)
 		if err != nil {
 			return nil, err
@@ -338,9 +372,29 @@ func (pf *VaultPriceFeed) getPrimaryPrice(token string, maximise bool) (*big.Int
 		}
 	}
 
-	if pf.PriceFeedType == PriceFeedTypeDirect {
+	if err := pf.Sequencer.Check(uint64(time.Now().Unix())); err != nil {
+		return nil, err
+	}
+
+	if pf.PriceFeedType != PriceFeedTypeDirect && pf.PriceDecimals[token] == nil {
+		// the decimals of a token are set along with its price feed
+		return nil, ErrVaultPriceFeedInvalidPriceFeed
+	}
+
+	switch pf.PriceFeedType {
+	case PriceFeedTypeDirect:
 		return lo.CoalesceOrEmpty(priceFeed.Answers[strconv.FormatBool(maximise)], bignumber.ZeroBI), nil
-	} else if pf.PriceSampleSpace == nil {
+	case PriceFeedTypeProxy:
+		return pf.getProxyPrice(token, priceFeed)
+	case PriceFeedTypeLatestAnswerMinMax:
+		price := priceFeed.Answers[strconv.FormatBool(maximise)]
+		if price == nil {
+			return nil, ErrVaultPriceFeedCouldNotFetchPrice
+		}
+		return oracle.Scale(price, uint8(pf.PriceDecimals[token].Uint64()), PricePrecisionDecimals), nil
+	}
+
+	if pf.PriceSampleSpace == nil {
 		return priceFeed.LatestAnswer(), nil
 	}
 
@@ -362,7 +416,7 @@ func (pf *VaultPriceFeed) getPrimaryPrice(token string, maximise bool) (*big.Int
 		} else {
 			_, p, _, _, _ = priceFeed.GetRoundData(new(big.Int).Sub(roundID, i))
 
-			if p.Sign() <= 0 {
+			if p == nil || p.Sign() <= 0 {
 				return nil, ErrVaultPriceFeedInvalidPrice
 			}
 		}
@@ -386,9 +440,31 @@ func (pf *VaultPriceFeed) getPrimaryPrice(token string, maximise bool) (*big.Int
 		return nil, ErrVaultPriceFeedCouldNotFetchPrice
 	}
 
-	priceDecimal := pf.PriceDecimals[token]
-	price = new(big.Int).Mul(price, PricePrecision)
-	return price.Div(price, bignumber.TenPowInt(priceDecimal.Int64())), nil
+	return oracle.Scale(price, uint8(pf.PriceDecimals[token].Uint64()), PricePrecisionDecimals), nil
+}
+
+// getProxyPrice returns the answer of a price feed proxy unless it is older than ExpireTimeForPriceFeed
+func (pf *VaultPriceFeed) getProxyPrice(token string, priceFeed *PriceFeed) (*big.Int, error) {
+	price := priceFeed.Answer
+	if price == nil || price.Sign() <= 0 {
+		return nil, ErrVaultPriceFeedInvalidPrice
+	}
+
+	if int64(priceFeed.Timestamp)+pf.ExpireTimeForPriceFeed.Int64() <= time.Now().Unix() {
+		return nil, ErrVaultPriceFeedExpired
+	}
+
+	return oracle.Scale(price, uint8(pf.PriceDecimals[token].Uint64()), PricePrecisionDecimals), nil
+}
+
+// getVaultPrice returns the price read from getPrice of the VaultPriceFeed as is
+func (pf *VaultPriceFeed) getVaultPrice(token string, maximise bool) (*big.Int, error) {
+	priceFeed, ok := pf.PriceFeeds[token]
+	if !ok {
+		return nil, ErrVaultPriceFeedInvalidPriceFeed
+	}
+
+	return lo.CoalesceOrEmpty(priceFeed.Answers[strconv.FormatBool(maximise)], bignumber.ZeroBI), nil
 }
 
 func (pf *VaultPriceFeed) getSecondaryPrice(token string, referencePrice *big.Int, maximise bool) *big.Int {
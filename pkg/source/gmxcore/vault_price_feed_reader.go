@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -28,7 +28,7 @@ func NewVaultPriceFeedReaderWithParam(ethrpcClient *ethrpc.Client, priceFeedType
 		abi:          vaultPriceFeedABI,
 		ethrpcClient: ethrpcClient,
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": sourceName,
 			"reader":          "VaultPriceFeedReader",
 		}),
 
@@ -65,6 +65,7 @@ func (r *VaultPriceFeedReader) Read(
 // - ChainlinkFlags
 // - ETH
 // - ETHBNB
+// - ExpireTimeForPriceFeed
 // - FavorPrimaryPrice
 // - IsAmmEnabled
 // - IsSecondaryPriceEnabled
@@ -92,6 +93,10 @@ func (r *VaultPriceFeedReader) readData(
 		[]interface{}{&vaultPriceFeed.ChainlinkFlagsAddress})
 	rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodETH, nil), []interface{}{&eth})
 	rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodETHBNB, nil), []interface{}{&vaultPriceFeed.ETHBNBAddress})
+	if r.priceFeedType == PriceFeedTypeProxy {
+		rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodExpireTimeForPriceFeed, nil),
+			[]interface{}{&vaultPriceFeed.ExpireTimeForPriceFeed})
+	}
 	rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodFavorPrimaryPrice, nil),
 		[]interface{}{&vaultPriceFeed.FavorPrimaryPrice})
 	rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodIsAmmEnabled, nil),
@@ -143,26 +148,38 @@ func (r *VaultPriceFeedReader) readTokenData(
 	adjustmentBasisPoints := make([]*big.Int, tokensLen)
 	strictStableTokens := make([]bool, tokensLen)
 	isAdjustmentAdditive := make([]bool, tokensLen)
+	readsPrices := r.priceFeedType == PriceFeedTypeDirect || r.priceFeedType == PriceFeedTypeVaultPrice
 	var prices [][2]*big.Int
-	if r.priceFeedType == PriceFeedTypeDirect {
+	if readsPrices {
 		prices = make([][2]*big.Int, tokensLen)
 	}
 
 	callParamsFactory := CallParamsFactory(r.abi, address)
+	getPriceCallParamsFactory := CallParamsFactory(vaultPriceFeedGetPriceABI, address)
 	rpcRequest := r.ethrpcClient.NewRequest().SetContext(ctx)
 
 	for i, token := range tokens {
 		tokenAddress := common.HexToAddress(token)
 
-		if r.priceFeedType != PriceFeedTypeDirect {
-			rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodPriceFeeds, []interface{}{tokenAddress}),
-				[]interface{}{&priceFeedsAddresses[i]})
-		} else {
+		switch r.priceFeedType {
+		case PriceFeedTypeDirect:
 			priceFeedsAddresses[i] = tokenAddress
 			rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodGetPrimaryPrice, []interface{}{tokenAddress, false}),
 				[]interface{}{&prices[i][0]})
 			rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodGetPrimaryPrice, []interface{}{tokenAddress, true}),
 				[]interface{}{&prices[i][1]})
+		case PriceFeedTypeVaultPrice:
+			priceFeedsAddresses[i] = tokenAddress
+			rpcRequest.AddCall(getPriceCallParamsFactory(vaultPriceFeedMethodGetPrice, []interface{}{tokenAddress, false}),
+				[]interface{}{&prices[i][0]})
+			rpcRequest.AddCall(getPriceCallParamsFactory(vaultPriceFeedMethodGetPrice, []interface{}{tokenAddress, true}),
+				[]interface{}{&prices[i][1]})
+		case PriceFeedTypeProxy:
+			rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodPriceFeedProxies, []interface{}{tokenAddress}),
+				[]interface{}{&priceFeedsAddresses[i]})
+		default:
+			rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodPriceFeeds, []interface{}{tokenAddress}),
+				[]interface{}{&priceFeedsAddresses[i]})
 		}
 		rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodPriceDecimals, []interface{}{tokenAddress}), []interface{}{&priceDecimals[i]})
 		rpcRequest.AddCall(callParamsFactory(vaultPriceFeedMethodSpreadBasisPoints, []interface{}{tokenAddress}), []interface{}{&spreadBasisPoints[i]})
@@ -183,7 +200,7 @@ func (r *VaultPriceFeedReader) readTokenData(
 		vaultPriceFeed.AdjustmentBasisPoints[token] = adjustmentBasisPoints[i]
 		vaultPriceFeed.StrictStableTokens[token] = strictStableTokens[i]
 		vaultPriceFeed.IsAdjustmentAdditive[token] = isAdjustmentAdditive[i]
-		if r.priceFeedType == PriceFeedTypeDirect {
+		if readsPrices {
 			vaultPriceFeed.PriceFeeds[token] = &PriceFeed{
 				Answers: map[string]*big.Int{
 					"false": prices[i][0],
@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -16,12 +16,16 @@ type VaultReader struct {
 	ethrpcClient *ethrpc.Client
 	log          logger.Logger
 
+	whitelistMode WhitelistMode
+	useFeeUtils   bool
+
 	vaultMethodUSDG           string
 	vaultMethodUSDGAmounts    string
 	vaultMethodMaxUSDGAmounts string
 }
 
-func NewVaultReader(ethrpcClient *ethrpc.Client, usdgForkName string) *VaultReader {
+func NewVaultReader(ethrpcClient *ethrpc.Client, config *Config) *VaultReader {
+	usdgForkName := config.UsdgForkName
 	if usdgForkName == "" {
 		usdgForkName = "usdg"
 	}
@@ -33,10 +37,13 @@ func NewVaultReader(ethrpcClient *ethrpc.Client, usdgForkName string) *VaultRead
 		abi:          vaultABI,
 		ethrpcClient: ethrpcClient,
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": sourceName,
 			"reader":          "VaultReader",
 		}),
 
+		whitelistMode: config.WhitelistMode,
+		useFeeUtils:   config.UseFeeUtils,
+
 		vaultMethodUSDG:           replaceUsdgForkName("usdg", usdgForkName),
 		vaultMethodUSDGAmounts:    replaceUsdgForkName("usdgAmounts", usdgForkName),
 		vaultMethodMaxUSDGAmounts: replaceUsdgForkName("maxUsdgAmounts", usdgForkName),
@@ -82,8 +89,10 @@ func (r *VaultReader) Read(ctx context.Context, address string) (*Vault, error)
 //   - SwapFeeBasisPoints
 //   - TaxBasisPoints
 //   - TotalTokenWeights
+//   - MintBurnFeeBasisPoints
 //   - USDGAddress
 //   - WhitelistedTokensCount
+//   - FeeUtilsAddress, if the vault uses FeeUtilsV2
 func (r *VaultReader) readData(ctx context.Context, address string, vault *Vault) error {
 	callParamsFactory := CallParamsFactory(r.abi, address)
 	rpcRequest := r.ethrpcClient.NewRequest().SetContext(ctx)
@@ -99,9 +108,19 @@ func (r *VaultReader) readData(ctx context.Context, address string, vault *Vault
 	rpcRequest.AddCall(callParamsFactory(vaultMethodSwapFeeBasisPoints, nil), []interface{}{&vault.SwapFeeBasisPoints})
 	rpcRequest.AddCall(callParamsFactory(vaultMethodTaxBasisPoints, nil), []interface{}{&vault.TaxBasisPoints})
 	rpcRequest.AddCall(callParamsFactory(vaultMethodTotalTokenWeights, nil), []interface{}{&vault.TotalTokenWeights})
+	rpcRequest.AddCall(callParamsFactory(vaultMethodMintBurnFeeBasisPoints, nil),
+		[]interface{}{&vault.MintBurnFeeBasisPoints})
 	rpcRequest.AddCall(callParamsFactory(r.vaultMethodUSDG, nil), []interface{}{&vault.USDGAddress})
-	rpcRequest.AddCall(callParamsFactory(vaultMethodAllWhitelistedTokensLength, nil),
-		[]interface{}{&vault.WhitelistedTokensCount})
+	if r.whitelistMode == WhitelistModeFiltered {
+		rpcRequest.AddCall(callParamsFactory(vaultMethodAllWhitelistedTokensLength, nil),
+			[]interface{}{&vault.WhitelistedTokensCount})
+	} else {
+		rpcRequest.AddCall(callParamsFactory(vaultMethodWhitelistedTokenCount, nil),
+			[]interface{}{&vault.WhitelistedTokensCount})
+	}
+	if r.useFeeUtils {
+		rpcRequest.AddCall(callParamsFactory(vaultMethodFeeUtils, nil), []interface{}{&vault.FeeUtilsAddress})
+	}
 
 	_, err := rpcRequest.TryAggregate()
 
@@ -119,11 +138,15 @@ func (r *VaultReader) readWhitelistedTokens(
 	tokenList := make([]common.Address, tokensLen)
 	rpcRequest := r.ethrpcClient.NewRequest().SetContext(ctx)
 
+	listABI, listMethod := r.abi, vaultMethodAllWhitelistedTokens
+	if r.whitelistMode == WhitelistModeIndexed {
+		listABI, listMethod = vaultIndexedWhitelistABI, vaultMethodWhitelistedTokens
+	}
 	for i := 0; i < tokensLen; i++ {
 		rpcRequest.AddCall(&ethrpc.Call{
-			ABI:    r.abi,
+			ABI:    listABI,
 			Target: address,
-			Method: vaultMethodAllWhitelistedTokens,
+			Method: listMethod,
 			Params: []interface{}{new(big.Int).SetInt64(int64(i))},
 		}, []interface{}{&tokenList[i]})
 	}
@@ -133,19 +156,25 @@ func (r *VaultReader) readWhitelistedTokens(
 	}
 
 	isWhitelistedTokens := make([]bool, len(tokenList))
-	rpcRequest = r.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(res.BlockNumber)
-
-	for i := 0; i < len(tokenList); i++ {
-		rpcRequest.AddCall(&ethrpc.Call{
-			ABI:    r.abi,
-			Target: address,
-			Method: vaultMethodWhitelistedTokens,
-			Params: []interface{}{tokenList[i]},
-		}, []interface{}{&isWhitelistedTokens[i]})
-	}
-	_, err = rpcRequest.TryAggregate()
-	if err != nil {
-		return err
+	if r.whitelistMode == WhitelistModeFiltered {
+		rpcRequest = r.ethrpcClient.NewRequest().SetContext(ctx).SetBlockNumber(res.BlockNumber)
+
+		for i := 0; i < len(tokenList); i++ {
+			rpcRequest.AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address,
+				Method: vaultMethodWhitelistedTokens,
+				Params: []interface{}{tokenList[i]},
+			}, []interface{}{&isWhitelistedTokens[i]})
+		}
+		_, err = rpcRequest.TryAggregate()
+		if err != nil {
+			return err
+		}
+	} else {
+		for i := range isWhitelistedTokens {
+			isWhitelistedTokens[i] = true
+		}
 	}
 
 	currentWhiteListTokens := make([]string, 0, tokensLen)
@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"context"
@@ -7,10 +7,12 @@ import (
 	"github.com/KyberNetwork/logger"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/eth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type VaultScanner struct {
 	config                *Config
+	ethrpcClient          *ethrpc.Client
 	vaultReader           IVaultReader
 	vaultPriceFeedReader  IVaultPriceFeedReader
 	fastPriceFeedV1Reader IFastPriceFeedV1Reader
@@ -19,6 +21,7 @@ type VaultScanner struct {
 	usdgReader            IUSDGReader
 	chainlinkFlagsReader  IChainlinkFlagsReader
 	pancakePairReader     IPancakePairReader
+	feeUtilsV2Reader      IFeeUtilsV2Reader
 	log                   logger.Logger
 }
 
@@ -28,7 +31,8 @@ func NewVaultScanner(
 ) *VaultScanner {
 	return &VaultScanner{
 		config:                config,
-		vaultReader:           NewVaultReader(ethrpcClient, config.UsdgForkName),
+		ethrpcClient:          ethrpcClient,
+		vaultReader:           NewVaultReader(ethrpcClient, config),
 		vaultPriceFeedReader:  NewVaultPriceFeedReaderWithParam(ethrpcClient, config.PriceFeedType),
 		fastPriceFeedV1Reader: NewFastPriceFeedV1Reader(ethrpcClient),
 		fastPriceFeedV2Reader: NewFastPriceFeedV2Reader(ethrpcClient),
@@ -36,14 +40,33 @@ func NewVaultScanner(
 		usdgReader:            NewUSDGReader(ethrpcClient),
 		chainlinkFlagsReader:  NewChainlinkFlagsReader(ethrpcClient),
 		pancakePairReader:     NewPancakePairReader(ethrpcClient),
+		feeUtilsV2Reader:      NewFeeUtilsV2Reader(ethrpcClient),
 		log: logger.WithFields(logger.Fields{
-			"liquiditySource": DexTypeGmx,
+			"liquiditySource": config.DexType,
 			"scanner":         "VaultScanner",
 		}),
 	}
 }
 
-func (vs *VaultScanner) getVault(ctx context.Context, address string) (*Vault, error) {
+// GetExtra reads the vault at address along with the FeeUtilsV2 it delegates swap fees to, if configured
+func (vs *VaultScanner) GetExtra(ctx context.Context, address string) (Extra, error) {
+	vault, err := vs.GetVault(ctx, address)
+	if err != nil {
+		return Extra{}, err
+	}
+
+	extra := Extra{Vault: vault}
+	if vs.config.UseFeeUtils {
+		if extra.FeeUtils, err = vs.feeUtilsV2Reader.Read(ctx, vault); err != nil {
+			vs.log.Errorf("error when feeUtilsV2Reader read: %s", err)
+			return Extra{}, err
+		}
+	}
+
+	return extra, nil
+}
+
+func (vs *VaultScanner) GetVault(ctx context.Context, address string) (*Vault, error) {
 	vault, err := vs.vaultReader.Read(ctx, address)
 	if err != nil {
 		vs.log.Errorf("error when vaultReader read: %s", err)
@@ -118,19 +141,26 @@ func (vs *VaultScanner) getVaultPriceFeed(ctx context.Context, address string, t
 
 	vaultPriceFeed.SecondaryPriceFeedVersion = int(secondaryPriceFeedVersion)
 
-	fastPriceFeed, err := vs.getFastPriceFeed(
-		ctx,
-		secondaryPriceFeedVersion,
-		vaultPriceFeed.SecondaryPriceFeedAddress.String(),
-		tokens,
-	)
+	if !eth.IsZeroAddress(vaultPriceFeed.SecondaryPriceFeedAddress) {
+		fastPriceFeed, err := vs.getFastPriceFeed(
+			ctx,
+			secondaryPriceFeedVersion,
+			vaultPriceFeed.SecondaryPriceFeedAddress.String(),
+			tokens,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		vaultPriceFeed.SecondaryPriceFeed = fastPriceFeed
+	}
+
+	vaultPriceFeed.PriceFeeds, err = vs.getPriceFeeds(ctx, vaultPriceFeed)
 	if err != nil {
 		return nil, err
 	}
 
-	vaultPriceFeed.SecondaryPriceFeed = fastPriceFeed
-
-	vaultPriceFeed.PriceFeeds, err = vs.getPriceFeeds(ctx, vaultPriceFeed)
+	vaultPriceFeed.Sequencer, err = vs.getSequencer(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +172,7 @@ func (vs *VaultScanner) getPriceFeeds(
 	ctx context.Context,
 	vaultPriceFeed *VaultPriceFeed,
 ) (map[string]*PriceFeed, error) {
-	if vaultPriceFeed.PriceFeedType == PriceFeedTypeDirect {
+	if vaultPriceFeed.PriceFeedType == PriceFeedTypeDirect || vaultPriceFeed.PriceFeedType == PriceFeedTypeVaultPrice {
 		return vaultPriceFeed.PriceFeeds, nil
 	}
 
@@ -150,20 +180,39 @@ func (vs *VaultScanner) getPriceFeeds(
 	if priceSampleSpace := vaultPriceFeed.PriceSampleSpace; priceSampleSpace != nil {
 		roundCount = int(priceSampleSpace.Int64())
 	}
-	priceFeeds := make(map[string]*PriceFeed, len(vaultPriceFeed.PriceFeedsAddresses))
-
+	tokens := make([]string, 0, len(vaultPriceFeed.PriceFeedsAddresses))
+	priceFeedAddresses := make([]string, 0, len(vaultPriceFeed.PriceFeedsAddresses))
 	for tokenAddress, priceFeedAddress := range vaultPriceFeed.PriceFeedsAddresses {
-		priceFeed, err := vs.priceFeedReader.Read(ctx, priceFeedAddress.String(), roundCount)
-		if err != nil {
-			return nil, err
-		}
+		tokens = append(tokens, tokenAddress)
+		priceFeedAddresses = append(priceFeedAddresses, priceFeedAddress.String())
+	}
 
-		priceFeeds[tokenAddress] = priceFeed
+	priceFeedList, err := vs.priceFeedReader.Read(ctx, priceFeedAddresses, roundCount)
+	if err != nil {
+		return nil, err
+	}
+
+	priceFeeds := make(map[string]*PriceFeed, len(tokens))
+	for i, tokenAddress := range tokens {
+		priceFeeds[tokenAddress] = priceFeedList[i]
 	}
 
 	return priceFeeds, nil
 }
 
+// getSequencer reads the sequencer uptime feed of the chain, if configured
+func (vs *VaultScanner) getSequencer(ctx context.Context) (*oracle.Sequencer, error) {
+	var round oracle.RoundData
+	req := vs.config.AddCall(vs.ethrpcClient.NewRequest().SetContext(ctx), &round)
+	if len(req.Calls) == 0 {
+		return nil, nil
+	}
+	if _, err := req.Call(); err != nil {
+		return nil, err
+	}
+	return vs.config.Sequencer(&round), nil
+}
+
 func (vs *VaultScanner) getFastPriceFeed(
 	ctx context.Context,
 	version SecondaryPriceFeedVersion,
@@ -1,4 +1,4 @@
-package gmx
+package gmxcore
 
 import (
 	"math/big"
@@ -45,6 +45,14 @@ func (u *VaultUtils) GetSwapFeeBasisPoints(tokenIn string, tokenOut string, usdg
 	}
 }
 
+func (u *VaultUtils) GetBuyUsdgFeeBasisPoints(token string, usdgAmount *big.Int) *big.Int {
+	return u.GetFeeBasisPoints(token, usdgAmount, u.vault.MintBurnFeeBasisPoints, u.vault.TaxBasisPoints, true)
+}
+
+func (u *VaultUtils) GetSellUsdgFeeBasisPoints(token string, usdgAmount *big.Int) *big.Int {
+	return u.GetFeeBasisPoints(token, usdgAmount, u.vault.MintBurnFeeBasisPoints, u.vault.TaxBasisPoints, false)
+}
+
 func (u *VaultUtils) GetFeeBasisPoints(token string, usdgDelta *big.Int, feeBasisPoints *big.Int, taxBasisPoints *big.Int, increment bool) *big.Int {
 	if !u.vault.HasDynamicFees {
 		return feeBasisPoints
@@ -20,6 +20,7 @@ type Config struct {
 	// a non-positive value will be set to 2000 by default,
 	// so the default range of liquidity/limitOrder distribution
 	// is [currentPrice/1.2, currentPrice * 1.2)
+	// the pool simulator swaps across all points of the snapshot range in both directions
 	PointRange int `mapstructure:"point_range" json:"point_range,omitempty"`
 
 	HTTP iziswapclient.HTTPConfig `mapstructure:"http" json:"http,omitempty"`
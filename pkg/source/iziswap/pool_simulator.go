@@ -64,16 +64,12 @@ func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	}
 
 	x2y := tokenIn < tokenOut
-	ptLimit := p.PoolInfo.CurrentPoint // TODO: not limit swap-range in the future or give a way to modify it
 	swapFn := swap.SwapX2Y
-	if x2y {
-		ptLimit -= SIMULATOR_PT_RANGE
-	} else {
-		ptLimit += SIMULATOR_PT_RANGE
+	if !x2y {
 		swapFn = swap.SwapY2X
 	}
 
-	ret, err := swapFn(tokenAmountInAmount, ptLimit, p.PoolInfo)
+	ret, err := swapFn(tokenAmountInAmount, p.limitPoint(x2y), p.PoolInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -88,14 +84,20 @@ func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 			Token:  tokenOut,
 			Amount: amountOut.ToBig(),
 		},
+		// the swap stops at the limit point, leaving the rest of the amount in
+		RemainingTokenAmountIn: &pool.TokenAmount{
+			Token:  tokenIn,
+			Amount: tokenAmountInAmount.ToBig(),
+		},
 		Fee: &pool.TokenAmount{
 			Token: tokenAmountIn.Token,
 		},
 		Gas: gasBase + gasPerCrossedLiqPt*ret.CrossedPoints,
 		SwapInfo: iZiSwapInfo{
-			nextPoint:      ret.CurrentPoint,
-			nextLiquidity:  ret.Liquidity,
-			nextLiquidityX: ret.LiquidityX,
+			nextPoint:       ret.CurrentPoint,
+			nextLiquidity:   ret.Liquidity,
+			nextLiquidityX:  ret.LiquidityX,
+			nextLimitOrders: ret.LimitOrders,
 		},
 	}, nil
 }
@@ -114,16 +116,12 @@ func (p *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcA
 	}
 
 	x2y := tokenIn < tokenOut
-	ptLimit := p.PoolInfo.CurrentPoint // TODO: not limit swap-range in the future or give a way to modify it
 	swapFn := swap.SwapX2YDesireY
-	if x2y {
-		ptLimit -= SIMULATOR_PT_RANGE
-	} else {
-		ptLimit += SIMULATOR_PT_RANGE
+	if !x2y {
 		swapFn = swap.SwapY2XDesireX
 	}
 
-	ret, err := swapFn(tokenAmountOutAmount, ptLimit, p.PoolInfo)
+	ret, err := swapFn(tokenAmountOutAmount, p.limitPoint(x2y), p.PoolInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -151,9 +149,10 @@ func (p *PoolSimulator) CalcAmountIn(param pool.CalcAmountInParams) (*pool.CalcA
 		},
 		Gas: gasBase + gasPerCrossedLiqPt*ret.CrossedPoints,
 		SwapInfo: iZiSwapInfo{
-			nextPoint:      ret.CurrentPoint,
-			nextLiquidity:  ret.Liquidity,
-			nextLiquidityX: ret.LiquidityX,
+			nextPoint:       ret.CurrentPoint,
+			nextLiquidity:   ret.Liquidity,
+			nextLiquidityX:  ret.LiquidityX,
+			nextLimitOrders: ret.LimitOrders,
 		},
 	}, nil
 }
@@ -165,21 +164,7 @@ func (p *PoolSimulator) CloneState() pool.IPoolSimulator {
 	return &cloned
 }
 
-// UpdateBalance updates pool state
-// we should notice that,
-// unlike liquidity distribution,
-// limit orders may change more frequently, and often changed after each exchange
-// (if any limit order is dealed during that exchange)
-//
-// the function `UpdateBalance` which only change
-// `currentPoint`, `liquidity` and `liquidityX` on currentPoint
-// is not enough to trace actual limit order distribution on the pool in time,
-// since then we may get inaccurate value of `amountOut` in `CalcAmountOut` if there
-// exists too many limit orders (especially around current point) on that pool
-//
-// that means, if there exists some limit orders around the current point of a pool
-// and we still want to get the value of `amountOut` as accurate as possible, we need
-// to call pool_tracker of that pool more frequently to update limit order distribution in time
+// UpdateBalance updates the current point and liquidity of the pool, and the limit orders consumed by the swap.
 func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	si, ok := params.SwapInfo.(iZiSwapInfo)
 	if !ok {
@@ -189,14 +174,26 @@ func (p *PoolSimulator) UpdateBalance(params pool.UpdateBalanceParams) {
 	p.PoolInfo.CurrentPoint = si.nextPoint
 	p.PoolInfo.Liquidity = si.nextLiquidity
 	p.PoolInfo.LiquidityX = si.nextLiquidityX
+	if si.nextLimitOrders != nil {
+		p.PoolInfo.LimitOrders = si.nextLimitOrders
+	}
 }
 
 func (p *PoolSimulator) GetMetaInfo(tokenIn string, tokenOut string) any {
-	limitPoint := p.PoolInfo.CurrentPoint
-	if tokenIn < tokenOut {
-		limitPoint -= SIMULATOR_PT_RANGE
-	} else {
-		limitPoint += SIMULATOR_PT_RANGE
+	return Meta{LimitPoint: p.limitPoint(tokenIn < tokenOut)}
+}
+
+// limitPoint returns the point a swap can go to, which is the bound of the fetched liquidity and limit order snapshot
+// in the swap direction, or SIMULATOR_PT_RANGE points from the current point if the snapshot range is unknown.
+func (p *PoolSimulator) limitPoint(x2y bool) int {
+	if p.PoolInfo.SnapshotRightPt > p.PoolInfo.SnapshotLeftPt {
+		if x2y {
+			return p.PoolInfo.SnapshotLeftPt
+		}
+		return p.PoolInfo.SnapshotRightPt
+	}
+	if x2y {
+		return p.PoolInfo.CurrentPoint - SIMULATOR_PT_RANGE
 	}
-	return Meta{LimitPoint: limitPoint}
+	return p.PoolInfo.CurrentPoint + SIMULATOR_PT_RANGE
 }
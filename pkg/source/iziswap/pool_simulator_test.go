@@ -340,3 +340,58 @@ func TestCalcAmountIn(t *testing.T) {
 
 	testutil.TestCalcAmountIn(t, poolSim)
 }
+
+func TestSwapBeyondPointRange(t *testing.T) {
+	t.Parallel()
+	newPoolSim := func(snapshotLeftPt, snapshotRightPt int) *PoolSimulator {
+		return lo.Must(NewPoolSimulator(entity.Pool{
+			Address:  "0x1",
+			Exchange: "iziswap",
+			Type:     "iziswap",
+			Reserves: entity.PoolReserves{"1000000000000000000000", "1000000000000000000000"},
+			Tokens:   []*entity.PoolToken{{Address: "A", Decimals: 18}, {Address: "B", Decimals: 18}},
+			Extra: fmt.Sprintf("{\"CurrentPoint\":0,\"PointDelta\":10,\"LeftMostPt\":-800000,\"RightMostPt\":800000,"+
+				"\"Fee\":400,\"Liquidity\":1000000000000000000,\"LiquidityX\":500000000000000000,"+
+				"\"Liquidities\":[{\"LiqudityDelta\":1000000000000000000,\"Point\":-3000},"+
+				"{\"LiqudityDelta\":-1000000000000000000,\"Point\":3000}],"+
+				"\"LimitOrders\":[{\"SellingX\":1000000000000000,\"SellingY\":0,\"Point\":100}],"+
+				"\"SnapshotLeftPt\":%d,\"SnapshotRightPt\":%d}", snapshotLeftPt, snapshotRightPt),
+		}))
+	}
+	amountIn := pool.TokenAmount{Token: "B", Amount: bignumber.NewBig10("10000000000000000000000")}
+
+	defaultRange, err := newPoolSim(0, 0).CalcAmountOut(pool.CalcAmountOutParams{TokenAmountIn: amountIn, TokenOut: "A"})
+	require.NoError(t, err)
+	assert.Equal(t, SIMULATOR_PT_RANGE, defaultRange.SwapInfo.(iZiSwapInfo).nextPoint)
+	assert.Positive(t, defaultRange.RemainingTokenAmountIn.Amount.Sign())
+
+	poolSim := newPoolSim(-3000, 3000)
+	assert.Equal(t, Meta{LimitPoint: 3000}, poolSim.GetMetaInfo("B", "A"))
+	assert.Equal(t, Meta{LimitPoint: -3000}, poolSim.GetMetaInfo("A", "B"))
+	fullRange, err := poolSim.CalcAmountOut(pool.CalcAmountOutParams{TokenAmountIn: amountIn, TokenOut: "A"})
+	require.NoError(t, err)
+	assert.Equal(t, 3000, fullRange.SwapInfo.(iZiSwapInfo).nextPoint)
+	assert.Greater(t, fullRange.TokenAmountOut.Amount.Cmp(defaultRange.TokenAmountOut.Amount), 0)
+	assert.Less(t, fullRange.RemainingTokenAmountIn.Amount.Cmp(defaultRange.RemainingTokenAmountIn.Amount), 0)
+
+	// the limit order crossed by the swap is filled and no longer sells after the swap
+	cloned := poolSim.CloneState().(*PoolSimulator)
+	poolSim.UpdateBalance(pool.UpdateBalanceParams{
+		TokenAmountIn:  amountIn,
+		TokenAmountOut: *fullRange.TokenAmountOut,
+		SwapInfo:       fullRange.SwapInfo,
+	})
+	assert.True(t, poolSim.PoolInfo.LimitOrders[0].SellingX.IsZero())
+	assert.Equal(t, "1000000000000000", cloned.PoolInfo.LimitOrders[0].SellingX.Dec())
+
+	backAmountIn := pool.TokenAmount{Token: "A", Amount: bignumber.NewBig10("1000000000000000000")}
+	swapBack, err := poolSim.CalcAmountOut(pool.CalcAmountOutParams{TokenAmountIn: backAmountIn, TokenOut: "B"})
+	require.NoError(t, err)
+	assert.Less(t, swapBack.SwapInfo.(iZiSwapInfo).nextPoint, 3000)
+	assert.Nil(t, swapBack.SwapInfo.(iZiSwapInfo).nextLimitOrders)
+}
+
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, poolSim)
+}
@@ -106,6 +106,7 @@ func (d *PoolTracker) GetNewPoolState(
 	}
 	poolInfo.Liquidities = liquidityPointData
 	poolInfo.LimitOrders = limitOrderPointData
+	poolInfo.SnapshotLeftPt, poolInfo.SnapshotRightPt = d.getSnapshotRange(poolInfo)
 
 	extraBytes, err := json.Marshal(poolInfo)
 	if err != nil {
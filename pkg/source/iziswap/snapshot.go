@@ -16,13 +16,15 @@ func getPointDelta(fee int) int {
 	return pointDeltas[fee]
 }
 
-func (d *PoolTracker) getLiquiditySnapshot(ctx context.Context, pool entity.Pool, poolInfo swap.PoolInfo) ([]swap.LiquidityPoint, error) {
+// getSnapshotRange returns the range [leftPoint, rightPoint) of points whose liquidity and limit orders are fetched,
+// which is PointRange points on both sides of the current point, aligned to the point delta.
+func (d *PoolTracker) getSnapshotRange(poolInfo swap.PoolInfo) (leftPoint, rightPoint int) {
 	ptRange := d.config.PointRange
 	if ptRange <= 0 {
 		ptRange = DEFAULT_PT_RANGE
 	}
 	pointDelta := poolInfo.PointDelta
-	leftPoint := poolInfo.CurrentPoint - ptRange
+	leftPoint = poolInfo.CurrentPoint - ptRange
 	modl := (leftPoint%pointDelta + pointDelta) % pointDelta
 	if modl != 0 {
 		leftPoint = leftPoint - modl
@@ -30,7 +32,7 @@ func (d *PoolTracker) getLiquiditySnapshot(ctx context.Context, pool entity.Pool
 	if leftPoint < poolInfo.LeftMostPt {
 		leftPoint = poolInfo.LeftMostPt
 	}
-	rightPoint := poolInfo.CurrentPoint + ptRange
+	rightPoint = poolInfo.CurrentPoint + ptRange
 	modr := (rightPoint%pointDelta + pointDelta) % pointDelta
 	if modr != 0 {
 		rightPoint = rightPoint + pointDelta - modr
@@ -38,6 +40,12 @@ func (d *PoolTracker) getLiquiditySnapshot(ctx context.Context, pool entity.Pool
 	if rightPoint > poolInfo.RightMostPt {
 		rightPoint = poolInfo.RightMostPt
 	}
+	return leftPoint, rightPoint
+}
+
+func (d *PoolTracker) getLiquiditySnapshot(ctx context.Context, pool entity.Pool, poolInfo swap.PoolInfo) ([]swap.LiquidityPoint, error) {
+	pointDelta := poolInfo.PointDelta
+	leftPoint, rightPoint := d.getSnapshotRange(poolInfo)
 	batchLen := SNAPSHOT_BATCH * poolInfo.PointDelta
 	deltaLiquidities := make([]*big.Int, SNAPSHOT_BATCH)
 	liqudityPointLen := (rightPoint - leftPoint) / pointDelta
@@ -85,27 +93,8 @@ func (d *PoolTracker) getLiquiditySnapshot(ctx context.Context, pool entity.Pool
 }
 
 func (d *PoolTracker) getLimitOrderSnapshot(ctx context.Context, pool entity.Pool, poolInfo swap.PoolInfo) ([]swap.LimitOrderPoint, error) {
-	ptRange := d.config.PointRange
-	if ptRange <= 0 {
-		ptRange = DEFAULT_PT_RANGE
-	}
 	pointDelta := poolInfo.PointDelta
-	leftPoint := poolInfo.CurrentPoint - ptRange
-	modl := (leftPoint%pointDelta + pointDelta) % pointDelta
-	if modl != 0 {
-		leftPoint = leftPoint - modl
-	}
-	if leftPoint < poolInfo.LeftMostPt {
-		leftPoint = poolInfo.LeftMostPt
-	}
-	rightPoint := poolInfo.CurrentPoint + ptRange
-	modr := (rightPoint%pointDelta + pointDelta) % pointDelta
-	if modr != 0 {
-		rightPoint = rightPoint + pointDelta - modr
-	}
-	if rightPoint > poolInfo.RightMostPt {
-		rightPoint = poolInfo.RightMostPt
-	}
+	leftPoint, rightPoint := d.getSnapshotRange(poolInfo)
 	batchLen := SNAPSHOT_BATCH * poolInfo.PointDelta
 	limitOrderDataRaw := make([]LimitOrder, SNAPSHOT_BATCH)
 	limitOrderPointLen := (rightPoint - leftPoint) / pointDelta
@@ -1,6 +1,8 @@
 package swap
 
 import (
+	"slices"
+
 	"github.com/holiman/uint256"
 )
 
@@ -142,14 +144,42 @@ func (orderData *OrderData) MoveX2Y(point, pointDelta int) int {
 	return orderData.findLeftPoint(leftBoundary)
 }
 
-func (orderData *OrderData) ConsumeLimitOrder(isY2X bool) {
+// ConsumeLimitOrder moves past the current limit order, which sold `acquired` of its `selling` amount.
+func (orderData *OrderData) ConsumeLimitOrder(isY2X bool, selling, acquired *uint256.Int) {
 	if isY2X {
 		if orderData.LimitOrderIdx < len(orderData.LimitOrders) {
+			orderData.consume(selling, acquired)
 			orderData.LimitOrderIdx++
 		}
 	} else {
 		if orderData.LimitOrderIdx >= 0 {
+			orderData.consume(selling, acquired)
 			orderData.LimitOrderIdx--
 		}
 	}
 }
+
+func (orderData *OrderData) consume(selling, acquired *uint256.Int) {
+	remaining := new(uint256.Int)
+	if selling.Gt(acquired) {
+		remaining.Sub(selling, acquired)
+	}
+	orderData.consumed = append(orderData.consumed, consumedLimitOrder{idx: orderData.LimitOrderIdx, selling: remaining})
+}
+
+// LimitOrdersAfterSwap returns the limit orders left after the consumed ones sold their amounts, or nil if no limit
+// order was consumed.
+func (orderData *OrderData) LimitOrdersAfterSwap(isY2X bool) []LimitOrderPointU256 {
+	if len(orderData.consumed) == 0 {
+		return nil
+	}
+	limitOrders := slices.Clone(orderData.LimitOrders)
+	for _, c := range orderData.consumed {
+		if isY2X {
+			limitOrders[c.idx].SellingX = c.selling
+		} else {
+			limitOrders[c.idx].SellingY = c.selling
+		}
+	}
+	return limitOrders
+}
@@ -34,6 +34,14 @@ type OrderData struct {
 	LiquidityIdx  int
 	LimitOrders   []LimitOrderPointU256
 	LimitOrderIdx int
+
+	consumed []consumedLimitOrder
+}
+
+// consumedLimitOrder is the amount a limit order still sells after being consumed by a swap
+type consumedLimitOrder struct {
+	idx     int
+	selling *uint256.Int
 }
 
 func (orderData *OrderData) IsLiquidity(point int) bool {
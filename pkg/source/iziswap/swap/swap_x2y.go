@@ -72,7 +72,7 @@ func SwapX2Y(amount *uint256.Int, lowPt int, pool PoolInfoU256) (SwapResult, err
 				amountX.Add(amountX, feeAmount)
 				amountY.Add(amountY, acquireY)
 
-				orderData.ConsumeLimitOrder(false)
+				orderData.ConsumeLimitOrder(false, currY, acquireY)
 			} else {
 				finished = true
 			}
@@ -195,6 +195,7 @@ func SwapX2Y(amount *uint256.Int, lowPt int, pool PoolInfoU256) (SwapResult, err
 		AmountX:       amountX,
 		AmountY:       amountY,
 		CrossedPoints: crossedPoints,
+		LimitOrders:   orderData.LimitOrdersAfterSwap(false),
 	}
 	return swapResult, nil
 }
@@ -247,7 +248,7 @@ func SwapX2YDesireY(desireY *uint256.Int, lowPt int, pool PoolInfoU256) (SwapRes
 			amountX.Add(amountX, feeAmount)
 			amountY.Add(amountY, acquireY)
 
-			orderData.ConsumeLimitOrder(false)
+			orderData.ConsumeLimitOrder(false, currY, acquireY)
 		}
 
 		if finished {
@@ -334,6 +335,7 @@ func SwapX2YDesireY(desireY *uint256.Int, lowPt int, pool PoolInfoU256) (SwapRes
 		AmountX:       amountX,
 		AmountY:       amountY,
 		CrossedPoints: crossedPoints,
+		LimitOrders:   orderData.LimitOrdersAfterSwap(false),
 	}
 	return swapResult, nil
 }
@@ -67,7 +67,7 @@ func SwapY2X(amount *uint256.Int, highPt int, pool PoolInfoU256) (SwapResult, er
 				amount.Sub(amount, new(uint256.Int).Add(costY, feeAmount))
 				amountY.Add(amountY, new(uint256.Int).Add(costY, feeAmount))
 				amountX.Add(amountX, acquireX)
-				orderData.ConsumeLimitOrder(true)
+				orderData.ConsumeLimitOrder(true, currX, acquireX)
 			} else {
 				finished = true
 			}
@@ -146,6 +146,7 @@ func SwapY2X(amount *uint256.Int, highPt int, pool PoolInfoU256) (SwapResult, er
 		AmountX:       amountX,
 		AmountY:       amountY,
 		CrossedPoints: crossedPoints,
+		LimitOrders:   orderData.LimitOrdersAfterSwap(true),
 	}
 	return swapResult, nil
 }
@@ -194,7 +195,7 @@ func SwapY2XDesireX(desireX *uint256.Int, highPt int, pool PoolInfoU256) (SwapRe
 			}
 			amountY.Add(amountY, new(uint256.Int).Add(costY, feeAmount))
 			amountX.Add(amountX, acquireX)
-			orderData.ConsumeLimitOrder(true)
+			orderData.ConsumeLimitOrder(true, currX, acquireX)
 		}
 
 		if finished {
@@ -257,6 +258,7 @@ func SwapY2XDesireX(desireX *uint256.Int, highPt int, pool PoolInfoU256) (SwapRe
 		AmountX:       amountX,
 		AmountY:       amountY,
 		CrossedPoints: crossedPoints,
+		LimitOrders:   orderData.LimitOrdersAfterSwap(true),
 	}
 
 	return swapResult, nil
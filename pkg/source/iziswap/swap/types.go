@@ -13,6 +13,7 @@ type SwapResult struct {
 	Liquidity     *uint256.Int
 	LiquidityX    *uint256.Int
 	CrossedPoints int64
+	LimitOrders   []LimitOrderPointU256 // limit orders left after the swap, nil if it consumed none
 }
 
 type PoolInfo struct {
@@ -25,6 +26,10 @@ type PoolInfo struct {
 	LiquidityX   *big.Int
 	Liquidities  []LiquidityPoint
 	LimitOrders  []LimitOrderPoint
+
+	// liquidity and limit orders are known for points in [SnapshotLeftPt, SnapshotRightPt), an empty range if unknown
+	SnapshotLeftPt  int `json:",omitempty"`
+	SnapshotRightPt int `json:",omitempty"`
 }
 
 type PoolInfoU256 struct {
@@ -37,4 +42,8 @@ type PoolInfoU256 struct {
 	LiquidityX   *uint256.Int
 	Liquidities  []LiquidityPointU256
 	LimitOrders  []LimitOrderPointU256
+
+	// liquidity and limit orders are known for points in [SnapshotLeftPt, SnapshotRightPt), an empty range if unknown
+	SnapshotLeftPt  int `json:",omitempty"`
+	SnapshotRightPt int `json:",omitempty"`
 }
@@ -48,6 +48,8 @@ type iZiSwapInfo struct {
 	nextPoint      int
 	nextLiquidity  *uint256.Int
 	nextLiquidityX *uint256.Int
+
+	nextLimitOrders []swap.LimitOrderPointU256
 }
 
 type Metadata struct {
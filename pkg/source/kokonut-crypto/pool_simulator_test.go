@@ -34,6 +34,7 @@ func TestCalcAmountOut(t *testing.T) {
 	})
 
 	assert.Nil(t, err)
+	testutil.TestPoolSimulator(t, kokonutPool)
 
 	result, err := testutil.MustConcurrentSafe(t, func() (*pool.CalcAmountOutResult, error) {
 		return kokonutPool.CalcAmountOut(pool.CalcAmountOutParams{
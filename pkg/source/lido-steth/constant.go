@@ -1,8 +1,13 @@
 package lido_steth
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/lst"
+
 const (
 	DexTypeLidoStETH = "lido-steth"
 
+	// Redemption of stETH is queue-only, through the withdrawal queue of Lido.
+	Redemption = lst.QueueOnly
+
 	methodTotalPooledEther = "getTotalPooledEther"
 	methodTotalShares      = "getTotalShares"
 
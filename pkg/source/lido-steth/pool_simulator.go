@@ -54,6 +54,11 @@ func (p *PoolSimulator) CalcAmountOut(param pool.CalcAmountOutParams) (*pool.Cal
 	tokenAmountIn := param.TokenAmountIn
 	tokenOut := param.TokenOut
 	stEth := p.Info.Tokens[1]
+	if strings.EqualFold(tokenAmountIn.Token, stEth) {
+		if err := Redemption.CheckRedeem(); err != nil {
+			return nil, err
+		}
+	}
 	// can only swap from ETH to stETH
 	if !valueobject.IsWrappedNative(tokenAmountIn.Token, p.chainID) || !strings.EqualFold(tokenOut, stEth) {
 		return nil, fmt.Errorf("Invalid tokenIn/Out %v %v", tokenAmountIn.Token, tokenOut)
@@ -27,6 +27,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		Tokens:   lo.Map(tokens, func(adr string, _ int) *entity.PoolToken { return &entity.PoolToken{Address: adr} }),
 	}, valueobject.ChainIDEthereum)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, []string{tokens[1]}, p.CanSwapFrom(tokens[0]))
 	assert.Equal(t, 0, len(p.CanSwapFrom(tokens[1])))
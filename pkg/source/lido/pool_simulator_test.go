@@ -36,6 +36,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 		StaticExtra: "{\"lpToken\": \"wstETH\"}",
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, []string{"wstETH"}, p.CanSwapTo("stETH"))
 	assert.Equal(t, []string{"stETH"}, p.CanSwapTo("wstETH"))
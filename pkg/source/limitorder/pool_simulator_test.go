@@ -1423,6 +1423,7 @@ func TestPool_FilterAllowedSenders(t *testing.T) {
 
 	p, err := NewPoolSimulator(poolEnt)
 	assert.NoError(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	// Use all orders if we don't pass swapLimit
 	res, _ := p.CalcAmountOut(pool.CalcAmountOutParams{
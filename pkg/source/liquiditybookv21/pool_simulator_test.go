@@ -22,6 +22,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 	simulator, err := NewPoolSimulator(entityPool)
 	assert.Nil(t, err)
+	testutil.TestPoolSimulator(t, simulator)
 
 	// block 37790183
 
@@ -1,7 +1,43 @@
 package madmex
 
+import (
+	"errors"
+
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
+)
+
 type Config struct {
 	DexID     string `json:"-"`
 	VaultPath string `json:"vaultPath"`
 	ChainID   int    `json:"chainID"`
 }
+
+// engineConfig is how the vault differs from GMX
+var engineConfig = gmxcore.Config{
+	DexType:       DexTypeMadmex,
+	WhitelistMode: gmxcore.WhitelistModeCount,
+}
+
+func newEngineConfig(cfg *Config) *gmxcore.Config {
+	engineCfg := engineConfig
+	engineCfg.DexID = cfg.DexID
+	engineCfg.UseSecondaryPriceFeedV1 = secondaryPriceFeedV1ChainIDs[valueobject.ChainID(cfg.ChainID)]
+	return &engineCfg
+}
+
+func getVaultAddress(vaultPath string) (string, error) {
+	byteValue, ok := bytesByPath[vaultPath]
+	if !ok {
+		return "", errors.New("misconfigured vault")
+	}
+
+	var vaultAddress gmxcore.VaultAddress
+	if err := json.Unmarshal(byteValue, &vaultAddress); err != nil {
+		return "", err
+	}
+
+	return vaultAddress.Vault, nil
+}
@@ -29,6 +29,11 @@ func newPool(t *testing.T, eth *big.Int, tIn *big.Int, tOut *big.Int) *PoolSimul
 	return p
 }
 
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.TestPoolSimulator(t, newPool(t, big.NewInt(1000000), TOLL_ONE_PCT, TOLL_ONE_PCT))
+}
+
 func TestGetAmountOut_sellGemNoFee(t *testing.T) {
 	t.Parallel()
 	// https://github.com/makerdao/dss-psm/blob/master/src/tests/psm.t.sol#L166
@@ -1,6 +1,41 @@
 package metavault
 
+import (
+	"errors"
+
+	"github.com/goccy/go-json"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+)
+
 type Config struct {
 	DexID     string `json:"dexID"`
 	VaultPath string `json:"vaultPath"`
 }
+
+// engineConfig is how the vault differs from GMX
+var engineConfig = gmxcore.Config{
+	DexType:       DexTypeMetavault,
+	UsdgForkName:  "usdm",
+	WhitelistMode: gmxcore.WhitelistModeCount,
+}
+
+func newEngineConfig(cfg *Config) *gmxcore.Config {
+	engineCfg := engineConfig
+	engineCfg.DexID = cfg.DexID
+	return &engineCfg
+}
+
+func getVaultAddress(vaultPath string) (string, error) {
+	byteValue, ok := BytesByPath[vaultPath]
+	if !ok {
+		return "", errors.New("misconfigured vault")
+	}
+
+	var vaultAddress gmxcore.VaultAddress
+	if err := json.Unmarshal(byteValue, &vaultAddress); err != nil {
+		return "", err
+	}
+
+	return vaultAddress.Vault, nil
+}
@@ -1,9 +0,0 @@
-package metavault
-
-type VaultAddress struct {
-	Vault string `json:"vault"`
-}
-
-type Extra struct {
-	Vault *Vault `json:"vault"`
-}
@@ -1,8 +1,9 @@
 package nuriv2
 
 import (
-	"errors"
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 )
 
 const (
@@ -31,6 +32,6 @@ var (
 )
 
 var (
-	ErrTickNil      = errors.New("tick is nil")
-	ErrV3TicksEmpty = errors.New("v3Ticks empty")
+	ErrTickNil      = clcore.ErrTickNil
+	ErrV3TicksEmpty = clcore.ErrV3TicksEmpty
 )
@@ -1,7 +1,7 @@
 package pancakev3
 
 import (
-	"errors"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"math/big"
 
 	"github.com/KyberNetwork/uniswapv3-sdk-uint256/constants"
@@ -32,8 +32,8 @@ var (
 		constants.Fee2500: 50,
 	})
 
-	ErrOverflow       = errors.New("bigInt overflow int/uint256")
-	ErrInvalidFeeTier = errors.New("invalid feeTier")
-	ErrTickNil        = errors.New("tick is nil")
-	ErrV3TicksEmpty   = errors.New("v3Ticks empty")
+	ErrOverflow       = clcore.ErrOverflow
+	ErrInvalidFeeTier = clcore.ErrInvalidFeeTier
+	ErrTickNil        = clcore.ErrTickNil
+	ErrV3TicksEmpty   = clcore.ErrV3TicksEmpty
 )
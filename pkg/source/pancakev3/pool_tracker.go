@@ -12,11 +12,11 @@ import (
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	sourcePool "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
-	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
 
 type PoolTracker struct {
@@ -89,7 +89,8 @@ func (d *PoolTracker) GetNewPoolState(
 			return err
 		}
 
-		poolTicks, err = d.getPoolTicks(ctx, p.Address)
+		poolTicks, err = clcore.GetPoolTicks(ctx, d.graphqlClient, getPoolTicksQuery, d.config.DexID, p.Address,
+			d.config.IsAllowSubgraphError())
 		if err != nil {
 			l.WithFields(logger.Fields{
 				"error": err,
@@ -106,18 +107,7 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
-	var ticks []Tick
-	for _, tickResp := range poolTicks {
-		tick, err := transformTickRespToTick(tickResp)
-		if err != nil {
-			l.WithFields(logger.Fields{
-				"error": err,
-			}).Error("failed to transform tickResp to tick")
-			continue
-		}
-
-		ticks = append(ticks, tick)
-	}
+	ticks := clcore.TransformTickResps(l, poolTicks)
 
 	extraBytes, err := json.Marshal(Extra{
 		Liquidity:    rpcData.Liquidity,
@@ -221,49 +211,3 @@ func (d *PoolTracker) FetchRPCData(ctx context.Context, p *entity.Pool, blockNum
 		Reserve1:    reserve1,
 	}, err
 }
-
-func (d *PoolTracker) getPoolTicks(ctx context.Context, poolAddress string) ([]TickResp, error) {
-	l := logger.WithFields(logger.Fields{
-		"poolAddress": poolAddress,
-		"dexID":       d.config.DexID,
-	})
-
-	allowSubgraphError := d.config.IsAllowSubgraphError()
-	lastTickIdx := ""
-	var ticks []TickResp
-
-	for {
-		req := graphqlpkg.NewRequest(getPoolTicksQuery(allowSubgraphError, poolAddress, lastTickIdx))
-
-		var resp struct {
-			Ticks []TickResp                `json:"ticks"`
-			Meta  *valueobject.SubgraphMeta `json:"_meta"`
-		}
-
-		if err := d.graphqlClient.Run(ctx, req, &resp); err != nil {
-			// Workaround at the moment to live with the error subgraph on Arbitrum
-			if allowSubgraphError && resp.Ticks == nil {
-				l.WithFields(logger.Fields{
-					"error": err,
-				}).Error("failed to query subgraph")
-				return nil, err
-			}
-		}
-
-		resp.Meta.CheckIsLagging(d.config.DexID, poolAddress)
-
-		if len(resp.Ticks) == 0 {
-			break
-		}
-
-		ticks = append(ticks, resp.Ticks...)
-
-		if len(resp.Ticks) < graphFirstLimit {
-			break
-		}
-
-		lastTickIdx = resp.Ticks[len(resp.Ticks)-1].TickIdx
-	}
-
-	return ticks, nil
-}
@@ -1,27 +1,15 @@
 package pancakev3
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
-
-	"github.com/KyberNetwork/int256"
-	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
-	"github.com/holiman/uint256"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
 )
 
-type Gas struct {
-	BaseGas          int64
-	CrossInitTickGas int64
-}
+type Gas = clcore.Gas
 
-type SwapInfo struct {
-	nextStateSqrtRatioX96 *v3Utils.Uint160
-	nextStateLiquidity    *v3Utils.Uint128
-	nextStateTickCurrent  int
-}
+type SwapInfo = clcore.SwapInfo
 
 type Metadata struct {
 	LastCreatedAtTimestamp *big.Int `json:"lastCreatedAtTimestamp"`
@@ -54,17 +42,9 @@ type StaticExtra struct {
 	PoolId string `json:"poolId"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
-type TickU256 struct {
-	Index          int          `json:"index"`
-	LiquidityGross *uint256.Int `json:"liquidityGross"`
-	LiquidityNet   *int256.Int  `json:"liquidityNet"`
-}
+type TickU256 = clcore.TickU256
 
 type Extra struct {
 	Liquidity    *big.Int `json:"liquidity"`
@@ -74,13 +54,7 @@ type Extra struct {
 	Ticks        []Tick   `json:"ticks"`
 }
 
-type ExtraTickU256 struct {
-	Liquidity    *uint256.Int `json:"liquidity"`
-	SqrtPriceX96 *uint256.Int `json:"sqrtPriceX96"`
-	TickSpacing  uint64       `json:"tickSpacing"`
-	Tick         *int         `json:"tick"`
-	Ticks        []TickU256   `json:"ticks"`
-}
+type ExtraTickU256 = clcore.ExtraTickU256
 
 type Slot0 struct {
 	SqrtPriceX96               *big.Int `json:"sqrtPriceX96"`
@@ -104,26 +78,3 @@ type PoolMeta struct {
 	BlockNumber uint64   `json:"blockNumber"`
 	PriceLimit  *big.Int `json:"priceLimit"`
 }
-
-func transformTickRespToTick(tickResp TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
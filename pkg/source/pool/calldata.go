@@ -0,0 +1,24 @@
+package pool
+
+// DefaultCalldataSize is the estimated number of calldata bytes a hop adds to a swap transaction: the pool, tokens,
+// amounts and a few dex-specific words as encoded by the executor. It is used for every source that does not implement
+// IPoolCalldataSizer, so it is an average rather than the exact encoding of any of them: 8 words sit between the
+// 5 to 6 words of simple vault and AMM swaps and the 12 words of uniswap-v4, and sources with larger encodings
+// (RFQ quotes with signatures, multi-pool batches) are underpriced until they implement IPoolCalldataSizer.
+const DefaultCalldataSize = 256
+
+// IPoolCalldataSizer is implemented by pool simulators whose hops encode to more or less calldata than
+// DefaultCalldataSize. On rollups, where the L1 data fee is charged per calldata byte, this can dominate the
+// execution gas.
+type IPoolCalldataSizer interface {
+	// GetCalldataSize returns the estimated number of calldata bytes of a swap from tokenIn to tokenOut
+	GetCalldataSize(tokenIn, tokenOut string) int
+}
+
+// CalldataSize returns the estimated number of calldata bytes of a hop through a pool simulator
+func CalldataSize(poolSim IPoolSimulator, tokenIn, tokenOut string) int {
+	if sizer, ok := poolSim.(IPoolCalldataSizer); ok {
+		return sizer.GetCalldataSize(tokenIn, tokenOut)
+	}
+	return DefaultCalldataSize
+}
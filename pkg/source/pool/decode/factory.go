@@ -1,28 +1,45 @@
 package decode
 
-import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+import (
+	"github.com/KyberNetwork/ethrpc"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+)
 
 type (
 	IPoolDecoder              = pool.IPoolDecoder
+	IPoolsListDecoder         = pool.IPoolsListDecoder
 	PoolsDecoderParams[C any] struct {
 		Cfg *C
+		Dependencies
 	}
 	FactoryParams struct {
 		Properties
+		Dependencies
+	}
+	Dependencies struct {
+		EthrpcClient *ethrpc.Client
 	}
 	Properties map[string]any
 	FactoryFn  func(string, FactoryParams) (IPoolDecoder, error)
 )
 
 var (
-	factoryMap = make(map[string]FactoryFn, 256) // map of pool types to pool lister factory functions
+	factoryMap     = make(map[string]FactoryFn, 256) // map of pool types to pool lister factory functions
+	listFactoryMap = make(map[string]FactoryFn, 32)  // map of pool types to pools list decoder factory functions
 )
 
 func RegisterFactory[C any, P IPoolDecoder](poolType string, factory func(PoolsDecoderParams[C]) (P, error)) bool {
-	if factoryMap[poolType] != nil {
+	return register(factoryMap, poolType, factory)
+}
+
+// register registers a factory function for a pool type in factories
+func register[C any, P IPoolDecoder](factories map[string]FactoryFn, poolType string,
+	factory func(PoolsDecoderParams[C]) (P, error)) bool {
+	if factories[poolType] != nil {
 		panic(poolType + " pool lister factory already registered")
 	}
-	factoryMap[poolType] = func(exchange string, factoryParams FactoryParams) (IPoolDecoder, error) {
+	factories[poolType] = func(exchange string, factoryParams FactoryParams) (IPoolDecoder, error) {
 		var cfg C
 		properties := factoryParams.Properties
 		if properties == nil {
@@ -33,7 +50,8 @@ func RegisterFactory[C any, P IPoolDecoder](poolType string, factory func(PoolsD
 			return nil, err
 		}
 		return factory(PoolsDecoderParams[C]{
-			Cfg: &cfg,
+			Cfg:          &cfg,
+			Dependencies: factoryParams.Dependencies,
 		})
 	}
 	return true
@@ -45,7 +63,27 @@ func RegisterFactoryC[C any, P IPoolDecoder](poolType string, factory func(*C) P
 	})
 }
 
+// RegisterFactoryCE registers a factory function for a pool decoder with config and ethrpcClient
+func RegisterFactoryCE[C any, P IPoolDecoder](poolType string, factory func(*C, *ethrpc.Client) P) bool {
+	return RegisterFactory(poolType, func(params PoolsDecoderParams[C]) (IPoolDecoder, error) {
+		return factory(params.Cfg, params.EthrpcClient), nil
+	})
+}
+
 // Factory returns the factory function for a pool type
 func Factory(poolType string) FactoryFn {
 	return factoryMap[poolType]
 }
+
+// RegisterListFactoryCE registers a factory function for a pools list decoder with config and ethrpcClient. Pools list
+// decoders are kept apart from the pool decoders, so that a pool type can have both.
+func RegisterListFactoryCE[C any, P IPoolsListDecoder](poolType string, factory func(*C, *ethrpc.Client) P) bool {
+	return register(listFactoryMap, poolType, func(params PoolsDecoderParams[C]) (IPoolDecoder, error) {
+		return factory(params.Cfg, params.EthrpcClient), nil
+	})
+}
+
+// ListFactory returns the factory function for the pools list decoder of a pool type
+func ListFactory(poolType string) FactoryFn {
+	return listFactoryMap[poolType]
+}
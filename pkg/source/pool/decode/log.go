@@ -0,0 +1,86 @@
+package decode
+
+import (
+	"context"
+	"strings"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	abipkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/abi"
+)
+
+// FilterLogs returns the logs emitted by address whose first topic is one of eventIDs, skipping removed logs
+func FilterLogs(logs []types.Log, address string, eventIDs ...common.Hash) []types.Log {
+	var filtered []types.Log
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 || !strings.EqualFold(log.Address.Hex(), address) {
+			continue
+		}
+		for _, eventID := range eventIDs {
+			if log.Topics[0] == eventID {
+				filtered = append(filtered, log)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// UnpackLog unpacks the indexed and non-indexed fields of a log of an event into out
+func UnpackLog(contractABI abi.ABI, event string, out any, log types.Log) error {
+	if len(log.Data) > 0 {
+		if err := contractABI.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range contractABI.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	return abi.ParseTopics(out, indexed, log.Topics[1:])
+}
+
+// FetchDecimals fetches the decimals of tokens, which are missing from the creation logs of pools. Tokens whose call
+// fails are left out, see HasDecimals.
+func FetchDecimals(ctx context.Context, ethrpcClient *ethrpc.Client, tokens []string) (map[string]uint8, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	decimals := make([]uint8, len(tokens))
+	req := ethrpcClient.NewRequest().SetContext(ctx)
+	for i, token := range tokens {
+		req.AddCall(&ethrpc.Call{
+			ABI:    abipkg.Erc20ABI,
+			Target: token,
+			Method: abipkg.Erc20DecimalsMethod,
+		}, []any{&decimals[i]})
+	}
+	resp, err := req.TryAggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenDecimals := make(map[string]uint8, len(tokens))
+	for i, token := range tokens {
+		if resp.Result[i] {
+			tokenDecimals[strings.ToLower(token)] = decimals[i]
+		}
+	}
+	return tokenDecimals, nil
+}
+
+// HasDecimals reports whether the decimals of all tokens were fetched. Pools with a token whose decimals are unknown
+// should be skipped rather than given guessed decimals; the pools list updater lists them once the call succeeds.
+func HasDecimals(decimals map[string]uint8, tokens ...string) bool {
+	for _, token := range tokens {
+		if _, ok := decimals[token]; !ok {
+			return false
+		}
+	}
+	return true
+}
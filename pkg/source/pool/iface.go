@@ -130,3 +130,12 @@ type IPoolDecoder interface {
 	Decode(ctx context.Context, logs []types.Log) (addressLogs map[string][]types.Log, err error)
 	GetKeys(ctx context.Context) ([]string, error)
 }
+
+// IPoolsListDecoder is an IPoolDecoder discovering pools from the creation logs of their factories, as an optional
+// alternative to polling the factories with IPoolsListUpdater so that new pools are routable from their creation block.
+// GetKeys returns the factory addresses, and Decode groups the creation logs by the address of the created pool.
+type IPoolsListDecoder interface {
+	IPoolDecoder
+	// DecodeNewPools returns the pools created by logs, filled from the event fields and the fields fetched on chain
+	DecodeNewPools(ctx context.Context, logs []types.Log) ([]entity.Pool, error)
+}
@@ -1,7 +1,25 @@
 package quickperps
 
+import "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+
 type Config struct {
 	DexID                   string `json:"-"`
 	VaultAddress            string `json:"vaultAddress"`
 	UseSecondaryPriceFeedV1 bool   `json:"useSecondaryPriceFeedV1"`
 }
+
+// engineConfig is how the vault differs from GMX
+var engineConfig = gmxcore.Config{
+	DexType:       DexTypeQuickperps,
+	PriceFeedType: gmxcore.PriceFeedTypeProxy,
+	UsdgForkName:  "usdq",
+	WhitelistMode: gmxcore.WhitelistModeCount,
+}
+
+func newEngineConfig(cfg *Config) *gmxcore.Config {
+	engineCfg := engineConfig
+	engineCfg.DexID = cfg.DexID
+	engineCfg.VaultAddress = cfg.VaultAddress
+	engineCfg.UseSecondaryPriceFeedV1 = cfg.UseSecondaryPriceFeedV1
+	return &engineCfg
+}
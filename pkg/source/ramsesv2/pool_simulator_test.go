@@ -37,6 +37,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 			Extra:    "{\"liquidity\":481329773989005,\"sqrtPriceX96\":55312754561266099398800,\"feeTier\":500,\"tickSpacing\":10,\"tick\":-283511,\"ticks\":[{\"index\":-887270,\"liquidityGross\":106514621957,\"liquidityNet\":106514621957},{\"index\":-283610,\"liquidityGross\":312504599701008,\"liquidityNet\":312504599701008},{\"index\":-283580,\"liquidityGross\":168718659666040,\"liquidityNet\":168718659666040},{\"index\":-283380,\"liquidityGross\":17166285019404,\"liquidityNet\":17166285019404},{\"index\":-282780,\"liquidityGross\":481223259367048,\"liquidityNet\":-481223259367048},{\"index\":-278550,\"liquidityGross\":7351763429974,\"liquidityNet\":7351763429974},{\"index\":-276330,\"liquidityGross\":7351763429974,\"liquidityNet\":-7351763429974},{\"index\":-276170,\"liquidityGross\":294632869974088,\"liquidityNet\":294632869974088},{\"index\":-275820,\"liquidityGross\":22619085245,\"liquidityNet\":22619085245},{\"index\":-274030,\"liquidityGross\":294632869974088,\"liquidityNet\":-294632869974088},{\"index\":-269510,\"liquidityGross\":17166285019404,\"liquidityNet\":-17166285019404},{\"index\":887270,\"liquidityGross\":129133707202,\"liquidityNet\":-129133707202}],\"unlocked\":true}",
 		}, 1)
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, []string{token1}, p.CanSwapTo(token0))
 	assert.Equal(t, []string{token0}, p.CanSwapTo(token1))
@@ -1,24 +1,16 @@
 package ramsesv2
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
 )
 
-type Gas struct {
-	BaseGas          int64
-	CrossInitTickGas int64
-}
+type Gas = clcore.Gas
 
 // RamsesV2SwapInfo present the after state of a swap
-type RamsesV2SwapInfo struct {
-	nextStateSqrtRatioX96 *big.Int
-	nextStateLiquidity    *big.Int
-	nextStateTickCurrent  int
-}
+type RamsesV2SwapInfo = clcore.SwapInfo
 
 type Metadata struct {
 	LastCreatedAtTimestamp *big.Int `json:"lastCreatedAtTimestamp"`
@@ -48,11 +40,7 @@ type StaticExtra struct {
 	PoolId string `json:"poolId"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
 type Extra struct {
 	Liquidity    *big.Int `json:"liquidity"`
@@ -64,6 +52,11 @@ type Extra struct {
 	Unlocked     bool     `json:"unlocked"`
 }
 
+type ExtraTickU256 struct {
+	clcore.ExtraTickU256
+	Unlocked bool `json:"unlocked"`
+}
+
 type PoolMeta struct {
 	PriceLimit  *big.Int `json:"priceLimit"`
 	BlockNumber uint64   `json:"blockNumber"`
@@ -88,26 +81,3 @@ type FetchRPCResult struct {
 	Reserve1    *big.Int
 	BlockNumber uint64
 }
-
-func transformTickRespToTick(tickResp ticklens.TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
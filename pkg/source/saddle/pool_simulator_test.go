@@ -46,6 +46,7 @@ func TestCalcAmountOut_Saddle(t *testing.T) {
 		StaticExtra: "{\"lpToken\":\"LP\",\"precisionMultipliers\":[\"1\",\"1\",\"1\"]}",
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p, testutil.CheckTokens) // LP token is swappable but not in GetTokens
 
 	assert.Equal(t, []string{"A", "B", "C"}, p.CanSwapTo("LP"))
 	assert.Equal(t, []string{"B", "C", "LP"}, p.CanSwapTo("A"))
@@ -1,7 +1,7 @@
 package slipstream
 
 import (
-	"errors"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"math/big"
 )
 
@@ -28,8 +28,8 @@ var (
 )
 
 var (
-	ErrOverflow           = errors.New("bigInt overflow int/uint256")
-	ErrInvalidTickSpacing = errors.New("invalid tickSpacing")
-	ErrTickNil            = errors.New("tick is nil")
-	ErrV3TicksEmpty       = errors.New("v3Ticks empty")
+	ErrOverflow           = clcore.ErrOverflow
+	ErrInvalidTickSpacing = clcore.ErrInvalidTickSpacing
+	ErrTickNil            = clcore.ErrTickNil
+	ErrV3TicksEmpty       = clcore.ErrV3TicksEmpty
 )
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	sourcePool "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
@@ -77,7 +78,8 @@ func (d *PoolTracker) GetNewPoolState(
 	})
 	g.Go(func(context.Context) error {
 		var err error
-		poolTicks, err = d.getPoolTicks(ctx, p.Address)
+		poolTicks, err = clcore.GetPoolTicks(ctx, d.graphqlClient, getPoolTicksQuery, d.config.DexID, p.Address,
+			d.config.IsAllowSubgraphError())
 		if err != nil {
 			l.WithFields(logger.Fields{
 				"error": err,
@@ -94,18 +96,7 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
-	var ticks []Tick
-	for _, tickResp := range poolTicks {
-		tick, err := transformTickRespToTick(tickResp)
-		if err != nil {
-			l.WithFields(logger.Fields{
-				"error": err,
-			}).Error("failed to transform tickResp to tick")
-			continue
-		}
-
-		ticks = append(ticks, tick)
-	}
+	ticks := clcore.TransformTickResps(l, poolTicks)
 
 	extraBytes, err := json.Marshal(Extra{
 		Liquidity:    rpcData.Liquidity,
@@ -219,57 +210,3 @@ func (d *PoolTracker) FetchRPCData(ctx context.Context, p *entity.Pool, blockNum
 		Reserve1:    reserve1,
 	}, err
 }
-
-func (d *PoolTracker) getPoolTicks(ctx context.Context, poolAddress string) ([]TickResp, error) {
-	l := logger.WithFields(logger.Fields{
-		"poolAddress": poolAddress,
-		"dexID":       d.config.DexID,
-	})
-
-	allowSubgraphError := d.config.IsAllowSubgraphError()
-	lastTickIdx := ""
-	var ticks []TickResp
-
-	for {
-		req := graphqlpkg.NewRequest(getPoolTicksQuery(allowSubgraphError, poolAddress, lastTickIdx))
-
-		var resp struct {
-			Ticks []TickResp `json:"ticks"`
-		}
-
-		if err := d.graphqlClient.Run(ctx, req, &resp); err != nil {
-			// Workaround at the moment to live with the error subgraph on Arbitrum
-			if allowSubgraphError {
-				if resp.Ticks == nil {
-					l.WithFields(logger.Fields{
-						"error":              err,
-						"allowSubgraphError": allowSubgraphError,
-					}).Error("failed to query subgraph")
-
-					return nil, err
-				}
-			} else {
-				l.WithFields(logger.Fields{
-					"error":              err,
-					"allowSubgraphError": allowSubgraphError,
-				}).Error("failed to query subgraph")
-
-				return nil, err
-			}
-		}
-
-		if len(resp.Ticks) == 0 {
-			break
-		}
-
-		ticks = append(ticks, resp.Ticks...)
-
-		if len(resp.Ticks) < graphFirstLimit {
-			break
-		}
-
-		lastTickIdx = resp.Ticks[len(resp.Ticks)-1].TickIdx
-	}
-
-	return ticks, nil
-}
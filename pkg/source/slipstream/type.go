@@ -1,25 +1,15 @@
 package slipstream
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
 
-	"github.com/KyberNetwork/int256"
-	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
-	"github.com/holiman/uint256"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
 )
 
-type Gas struct {
-	BaseGas          int64
-	CrossInitTickGas int64
-}
+type Gas = clcore.Gas
 
-type SwapInfo struct {
-	nextStateSqrtRatioX96 *v3Utils.Uint160
-	nextStateLiquidity    *v3Utils.Uint128
-	nextStateTickCurrent  int
-}
+type SwapInfo = clcore.SwapInfo
 
 type Metadata struct {
 	LastCreatedAtTimestamp *big.Int `json:"lastCreatedAtTimestamp"`
@@ -40,11 +30,7 @@ type SubgraphPool struct {
 	Token1             Token  `json:"token1"`
 }
 
-type TickResp struct {
-	TickIdx        string `json:"tickIdx"`
-	LiquidityGross string `json:"liquidityGross"`
-	LiquidityNet   string `json:"liquidityNet"`
-}
+type TickResp = ticklens.TickResp
 
 type SubgraphPoolTicks struct {
 	ID    string     `json:"id"`
@@ -55,17 +41,9 @@ type StaticExtra struct {
 	PoolId string `json:"poolId"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
-type TickU256 struct {
-	Index          int          `json:"index"`
-	LiquidityGross *uint256.Int `json:"liquidityGross"`
-	LiquidityNet   *int256.Int  `json:"liquidityNet"`
-}
+type TickU256 = clcore.TickU256
 
 type Extra struct {
 	Liquidity    *big.Int `json:"liquidity"`
@@ -77,12 +55,8 @@ type Extra struct {
 }
 
 type ExtraTickU256 struct {
-	Liquidity    *uint256.Int `json:"liquidity"`
-	SqrtPriceX96 *uint256.Int `json:"sqrtPriceX96"`
-	TickSpacing  uint64       `json:"tickSpacing"`
-	FeeTier      uint64       `json:"feeTier"`
-	Tick         *int         `json:"tick"`
-	Ticks        []TickU256   `json:"ticks"`
+	clcore.ExtraTickU256
+	FeeTier uint64 `json:"feeTier"`
 }
 
 type Slot0 struct {
@@ -107,26 +81,3 @@ type PoolMeta struct {
 	BlockNumber uint64   `json:"blockNumber"`
 	PriceLimit  *big.Int `json:"priceLimit"`
 }
-
-func transformTickRespToTick(tickResp TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
@@ -79,6 +79,7 @@ func TestCalcAmountOut(t *testing.T) {
 		Extra:    string(extraJson),
 	}
 	poolSimulator, _ := NewPoolSimulator(pool)
+	testutil.TestPoolSimulator(t, poolSimulator)
 	result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 		return poolSimulator.CalcAmountOut(
 			poolpkg.CalcAmountOutParams{
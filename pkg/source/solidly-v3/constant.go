@@ -2,6 +2,8 @@ package solidlyv3
 
 import (
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 )
 
 const (
@@ -27,3 +29,8 @@ var (
 	// For now, keep the BaseGas = 125000 (as the previous config), CrossInitTickGas = 0.
 	defaultGas = Gas{BaseGas: 125000, CrossInitTickGas: 0}
 )
+
+var (
+	ErrTickNil      = clcore.ErrTickNil
+	ErrV3TicksEmpty = clcore.ErrV3TicksEmpty
+)
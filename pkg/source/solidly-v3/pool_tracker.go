@@ -12,10 +12,10 @@ import (
 	"github.com/sourcegraph/conc/pool"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	sourcePool "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	pooltrack "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/tracker"
 	graphqlpkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/graphql"
-	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
 
 type PoolTracker struct {
@@ -66,7 +66,8 @@ func (d *PoolTracker) GetNewPoolState(
 	})
 	g.Go(func(context.Context) error {
 		var err error
-		poolTicks, err = d.getPoolTicks(ctx, p.Address)
+		poolTicks, err = clcore.GetPoolTicks(ctx, d.graphqlClient, getPoolTicksQuery, d.config.DexID, p.Address,
+			d.config.IsAllowSubgraphError())
 		if err != nil {
 			logger.WithFields(logger.Fields{
 				"poolAddress": p.Address,
@@ -85,19 +86,7 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
-	var ticks []Tick
-	for _, tickResp := range poolTicks {
-		tick, err := transformTickRespToTick(tickResp)
-		if err != nil {
-			logger.WithFields(logger.Fields{
-				"poolAddress": p.Address,
-				"error":       err,
-			}).Errorf("failed to transform tickResp to tick")
-			continue
-		}
-
-		ticks = append(ticks, tick)
-	}
+	ticks := clcore.TransformTickResps(logger.WithFields(logger.Fields{"poolAddress": p.Address}), poolTicks)
 
 	extraBytes, err := json.Marshal(Extra{
 		Liquidity:    rpcData.Liquidity,
@@ -198,57 +187,3 @@ func (d *PoolTracker) FetchRPCData(ctx context.Context, p *entity.Pool, blockNum
 		Reserve1:    reserve1,
 	}, err
 }
-
-func (d *PoolTracker) getPoolTicks(ctx context.Context, poolAddress string) ([]TickResp, error) {
-	allowSubgraphError := d.config.IsAllowSubgraphError()
-	lastTickIdx := ""
-	var ticks []TickResp
-
-	for {
-		req := graphqlpkg.NewRequest(getPoolTicksQuery(allowSubgraphError, poolAddress, lastTickIdx))
-
-		var resp struct {
-			Pool *SubgraphPoolTicks        `json:"pool"`
-			Meta *valueobject.SubgraphMeta `json:"_meta"`
-		}
-
-		if err := d.graphqlClient.Run(ctx, req, &resp); err != nil {
-			// Workaround at the moment to live with the error subgraph on Arbitrum
-			if allowSubgraphError {
-				if resp.Pool == nil {
-					logger.WithFields(logger.Fields{
-						"poolAddress":        poolAddress,
-						"error":              err,
-						"allowSubgraphError": allowSubgraphError,
-					}).Errorf("failed to query subgraph")
-
-					return nil, err
-				}
-			} else {
-				logger.WithFields(logger.Fields{
-					"poolAddress":        poolAddress,
-					"error":              err,
-					"allowSubgraphError": allowSubgraphError,
-				}).Errorf("failed to query subgraph")
-
-				return nil, err
-			}
-		}
-
-		resp.Meta.CheckIsLagging(d.config.DexID, poolAddress)
-
-		if resp.Pool == nil || len(resp.Pool.Ticks) == 0 {
-			break
-		}
-
-		ticks = append(ticks, resp.Pool.Ticks...)
-
-		if len(resp.Pool.Ticks) < graphFirstLimit {
-			break
-		}
-
-		lastTickIdx = resp.Pool.Ticks[len(resp.Pool.Ticks)-1].TickIdx
-	}
-
-	return ticks, nil
-}
@@ -1,22 +1,16 @@
 package solidlyv3
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
 )
 
-type Gas struct {
-	BaseGas          int64
-	CrossInitTickGas int64
-}
+type Gas = clcore.Gas
 
 // SolidlyV3SwapInfo present the after state of a swap
-type SolidlyV3SwapInfo struct {
-	nextStateSqrtRatioX96 *big.Int
-	nextStateLiquidity    *big.Int
-	nextStateTickCurrent  int
-}
+type SolidlyV3SwapInfo = clcore.SwapInfo
 
 type Metadata struct {
 	LastCreatedAtTimestamp *big.Int `json:"lastCreatedAtTimestamp"`
@@ -38,22 +32,14 @@ type SubgraphPool struct {
 	Token1             Token  `json:"token1"`
 }
 
-type TickResp struct {
-	TickIdx        string `json:"tickIdx"`
-	LiquidityGross string `json:"liquidityGross"`
-	LiquidityNet   string `json:"liquidityNet"`
-}
+type TickResp = ticklens.TickResp
 
 type SubgraphPoolTicks struct {
 	ID    string     `json:"id"`
 	Ticks []TickResp `json:"ticks"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
 type Extra struct {
 	Liquidity    *big.Int `json:"liquidity"`
@@ -63,6 +49,8 @@ type Extra struct {
 	Ticks        []Tick   `json:"ticks"`
 }
 
+type ExtraTickU256 = clcore.ExtraTickU256
+
 type PoolMeta struct {
 	PriceLimit *big.Int `json:"priceLimit"`
 }
@@ -81,26 +69,3 @@ type FetchRPCResult struct {
 	Reserve0    *big.Int
 	Reserve1    *big.Int
 }
-
-func transformTickRespToTick(tickResp TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
 	poolPkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
@@ -365,6 +366,7 @@ func TestPool_CalcAmountOut(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			pool, _ := NewPoolSimulator(tc.entityPool)
+			testutil.TestPoolSimulator(t, pool)
 
 			calcAmountOutResult, err := testutil.MustConcurrentSafe(t, func() (*poolPkg.CalcAmountOutResult, error) {
 				return pool.CalcAmountOut(poolPkg.CalcAmountOutParams{
@@ -492,7 +494,7 @@ func TestPool_UpdateBalance(t *testing.T) {
 			}
 			pool.UpdateBalance(params)
 
-			assert.Equal(t, tc.expectedUSDBAmounts, pool.vault.USDBAmounts)
+			assert.Equal(t, tc.expectedUSDBAmounts, pool.Vault.USDGAmounts)
 		})
 	}
 }
@@ -500,8 +502,8 @@ func TestPool_UpdateBalance(t *testing.T) {
 func TestPool_CanSwapTo(t *testing.T) {
 	t.Parallel()
 	t.Run("it should return correct swappable tokens", func(t *testing.T) {
-		pool := PoolSimulator{
-			vault: &Vault{
+		pool := PoolSimulator{PoolSimulator: &gmxcore.PoolSimulator{
+			Vault: &gmxcore.Vault{
 				WhitelistedTokens: []string{
 					"0x17fc002b466eec40dae837fc4be5c67993ddbd6f",
 					"0x2f2a2543b76a4166549f7aab2e75bef0aefc5b0f",
@@ -514,7 +516,7 @@ func TestPool_CanSwapTo(t *testing.T) {
 					"0xff970a61a04b1ca14834a43f5de4533ebddb5cc8",
 				},
 			},
-		}
+		}}
 
 		tokens := pool.CanSwapTo("0xff970a61a04b1ca14834a43f5de4533ebddb5cc8")
 
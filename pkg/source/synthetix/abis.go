@@ -13,11 +13,13 @@ var (
 	exchangerWithFeeRecAlternatives abi.ABI
 	exchangeRates                   abi.ABI
 	exchangeRatesWithDexPricing     abi.ABI
-	chainlinkDataFeed               abi.ABI
 	dexPriceAggregatorUniswapV3     abi.ABI
 	multiCollateralSynth            abi.ABI
 	erc20                           abi.ABI
 	uniswapV3Pool                   abi.ABI
+	addressResolver                 abi.ABI
+	circuitBreaker                  abi.ABI
+	systemStatus                    abi.ABI
 )
 
 func init() {
@@ -31,11 +33,13 @@ func init() {
 		{&exchangerWithFeeRecAlternatives, exchangerWithFeeRecAlternativesBytes},
 		{&exchangeRates, exchangeRatesBytes},
 		{&exchangeRatesWithDexPricing, exchangeRatesWithDexPricingBytes},
-		{&chainlinkDataFeed, chainlinkDataFeedBytes},
 		{&dexPriceAggregatorUniswapV3, dexPriceAggregatorUniswapV3Bytes},
 		{&multiCollateralSynth, multiCollateralSynthBytes},
 		{&erc20, erc20Bytes},
 		{&uniswapV3Pool, uniswapv3PoolBytes},
+		{&addressResolver, addressResolverBytes},
+		{&circuitBreaker, circuitBreakerBytes},
+		{&systemStatus, systemStatusBytes},
 	}
 
 	for _, b := range builder {
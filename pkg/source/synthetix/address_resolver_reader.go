@@ -0,0 +1,51 @@
+package synthetix
+
+import (
+	"context"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// readResolvedAddress reads the address of a contract by its name from the AddressResolver of ExchangeRates. The
+// AddressResolver keys contracts by their name as a left-aligned bytes32, not by a hex string like currency keys.
+func readResolvedAddress(
+	ctx context.Context,
+	ethrpcClient *ethrpc.Client,
+	poolState *PoolState,
+	contractName string,
+) (common.Address, error) {
+	var (
+		resolver, address common.Address
+		name              [32]byte
+	)
+	copy(name[:], contractName)
+
+	if _, err := ethrpcClient.
+		NewRequest().
+		SetContext(ctx).
+		AddCall(&ethrpc.Call{
+			ABI:    exchangeRates,
+			Target: poolState.Addresses.ExchangeRates,
+			Method: ExchangeRatesMethodResolver,
+			Params: nil,
+		}, []interface{}{&resolver}).
+		Call(); err != nil {
+		return common.Address{}, err
+	}
+
+	if _, err := ethrpcClient.
+		NewRequest().
+		SetContext(ctx).
+		AddCall(&ethrpc.Call{
+			ABI:    addressResolver,
+			Target: resolver.String(),
+			Method: AddressResolverMethodGetAddress,
+			Params: []interface{}{name},
+		}, []interface{}{&address}).
+		Call(); err != nil {
+		return common.Address{}, err
+	}
+
+	return address, nil
+}
@@ -2,22 +2,20 @@ package synthetix
 
 import (
 	"context"
-	"math/big"
 
 	"github.com/KyberNetwork/ethrpc"
 	"github.com/KyberNetwork/logger"
-	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type ChainlinkDataFeedReader struct {
-	abi          abi.ABI
 	cfg          *Config
 	ethrpcClient *ethrpc.Client
 }
 
 func NewChainlinkDataFeedReader(cfg *Config, ethrpcClient *ethrpc.Client) *ChainlinkDataFeedReader {
 	return &ChainlinkDataFeedReader{
-		abi:          chainlinkDataFeed,
 		cfg:          cfg,
 		ethrpcClient: ethrpcClient,
 	}
@@ -48,15 +46,7 @@ func (r *ChainlinkDataFeedReader) Read(ctx context.Context, address string, roun
 func (r *ChainlinkDataFeedReader) getLatestRoundData(ctx context.Context, address string, chainlinkDataFeed *ChainlinkDataFeed) error {
 	var latestRoundData RoundData
 
-	req := r.ethrpcClient.
-		NewRequest().
-		SetContext(ctx).
-		AddCall(&ethrpc.Call{
-			ABI:    r.abi,
-			Target: address,
-			Method: ChainlinkDataFeedMethodLatestRoundData,
-			Params: nil,
-		}, []interface{}{&latestRoundData})
+	req := oracle.AddLatestRoundDataCall(r.ethrpcClient.NewRequest().SetContext(ctx), address, &latestRoundData)
 
 	_, err := req.Call()
 	if err != nil {
@@ -83,19 +73,8 @@ func (r *ChainlinkDataFeedReader) getHistoryRoundData(ctx context.Context, addre
 		return nil
 	}
 
-	roundDataList := make([]RoundData, roundCount-1)
-
 	req := r.ethrpcClient.NewRequest().SetContext(ctx)
-	for i := 1; i < roundCount; i++ {
-		roundID := new(big.Int).Sub(chainlinkDataFeed.RoundID, big.NewInt(int64(i)))
-
-		req.AddCall(&ethrpc.Call{
-			ABI:    r.abi,
-			Target: address,
-			Method: ChainlinkDataFeedMethodGetRoundData,
-			Params: []interface{}{roundID},
-		}, []interface{}{&roundDataList[i-1]})
-	}
+	roundDataList := oracle.AddGetRoundDataCalls(req, address, chainlinkDataFeed.RoundID, roundCount-1)
 
 	_, err := req.Aggregate()
 	if err != nil {
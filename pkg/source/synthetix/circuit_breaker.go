@@ -0,0 +1,48 @@
+package synthetix
+
+import (
+	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// =============================================================================================
+// Implementation of this contract:
+// https://github.com/Synthetixio/synthetix/blob/b04a4d2948f3a575bfe8186e99086e50dc54ef95/contracts/CircuitBreaker.sol
+
+// isInvalid checks if the value of the aggregator of a currency key is invalid, which is the case if its circuit is
+// broken, if it deviates too much from the last probed value, or if it is zero
+func (cb *CircuitBreaker) isInvalid(currencyKey string, value *big.Int) bool {
+	if cb == nil {
+		return false
+	}
+
+	return cb.CircuitBroken[currencyKey] || cb._isRateOutOfBounds(currencyKey, value) || value.Sign() == 0
+}
+
+func (cb *CircuitBreaker) _isRateOutOfBounds(currencyKey string, current *big.Int) bool {
+	last := cb.LastValue[currencyKey]
+
+	// `last == 0` indicates unset/unpopulated value. If we dont have any historical reference,
+	// this check should not trigger
+	if last != nil && last.Cmp(bignumber.ZeroBI) > 0 {
+		return cb._isDeviationAboveThreshold(last, current)
+	}
+
+	return false
+}
+
+func (cb *CircuitBreaker) _isDeviationAboveThreshold(base *big.Int, comparison *big.Int) bool {
+	if base.Cmp(bignumber.ZeroBI) == 0 || comparison.Cmp(bignumber.ZeroBI) == 0 {
+		return true
+	}
+
+	var factor *big.Int
+	if comparison.Cmp(base) > 0 {
+		factor = divideDecimal(comparison, base)
+	} else {
+		factor = divideDecimal(base, comparison)
+	}
+
+	return factor.Cmp(cb.PriceDeviationThresholdFactor) >= 0
+}
@@ -0,0 +1,114 @@
+package synthetix
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type CircuitBreakerReader struct {
+	abi          abi.ABI
+	cfg          *Config
+	ethrpcClient *ethrpc.Client
+}
+
+func NewCircuitBreakerReader(cfg *Config, ethrpcClient *ethrpc.Client) *CircuitBreakerReader {
+	return &CircuitBreakerReader{
+		abi:          circuitBreaker,
+		cfg:          cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+// Read reads the CircuitBreaker used by ExchangeRates, or returns nil if the system has no CircuitBreaker
+func (r *CircuitBreakerReader) Read(ctx context.Context, poolState *PoolState) (*CircuitBreaker, error) {
+	address, err := readResolvedAddress(ctx, r.ethrpcClient, poolState, CircuitBreakerContractName)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": r.cfg.DexID,
+			"error": err,
+		}).Error("can not read address")
+		return nil, err
+	}
+
+	if address == (common.Address{}) {
+		return nil, nil
+	}
+
+	circuitBreaker, err := r.readData(ctx, address.String(), poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": r.cfg.DexID,
+			"error": err,
+		}).Error("can not read data")
+		return nil, err
+	}
+
+	return circuitBreaker, nil
+}
+
+// readData reads data of the aggregators of the currency keys, included:
+// - PriceDeviationThresholdFactor
+// - CircuitBroken
+// - LastValue
+func (r *CircuitBreakerReader) readData(
+	ctx context.Context,
+	address string,
+	poolState *PoolState,
+) (*CircuitBreaker, error) {
+	var (
+		currencyKeys    = poolState.CurrencyKeys
+		currencyKeysLen = len(currencyKeys)
+
+		circuitBroken = make([]bool, currencyKeysLen)
+		lastValues    = make([]*big.Int, currencyKeysLen)
+	)
+
+	cb := NewCircuitBreaker()
+	req := r.ethrpcClient.
+		NewRequest().
+		SetContext(ctx).
+		AddCall(&ethrpc.Call{
+			ABI:    r.abi,
+			Target: address,
+			Method: CircuitBreakerMethodPriceDeviationThresholdFactor,
+			Params: nil,
+		}, []interface{}{&cb.PriceDeviationThresholdFactor})
+
+	for i, key := range currencyKeys {
+		aggregatorAddress := poolState.AggregatorAddresses[key]
+
+		req.
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address,
+				Method: CircuitBreakerMethodCircuitBroken,
+				Params: []interface{}{aggregatorAddress},
+			}, []interface{}{&circuitBroken[i]}).
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address,
+				Method: CircuitBreakerMethodLastValue,
+				Params: []interface{}{aggregatorAddress},
+			}, []interface{}{&lastValues[i]})
+	}
+
+	if _, err := req.Aggregate(); err != nil {
+		return nil, err
+	}
+
+	for i, key := range currencyKeys {
+		if circuitBroken[i] {
+			cb.CircuitBroken[key] = true
+		}
+		if lastValues[i] != nil && lastValues[i].Sign() > 0 {
+			cb.LastValue[key] = lastValues[i]
+		}
+	}
+
+	return cb, nil
+}
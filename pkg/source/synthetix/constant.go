@@ -56,6 +56,8 @@ const (
 	ExchangeRatesMethodAggregators         = "aggregators"
 	ExchangeRatesMethodCurrencyKeyDecimals = "currencyKeyDecimals"
 	ExchangeRatesMethodGetCurrentRoundId   = "getCurrentRoundId"
+	ExchangeRatesMethodRateIsFlagged       = "rateIsFlagged"
+	ExchangeRatesMethodResolver            = "resolver"
 
 	// ExchangeRatesWithDexPricing methods
 
@@ -63,12 +65,25 @@ const (
 	ExchangeRatesWithDexPricingMethodCurrencyKeyDecimals               = "currencyKeyDecimals"
 	ExchangeRatesWithDexPricingMethodDexPriceAggregator                = "dexPriceAggregator"
 	ExchangeRatesWithDexPricingMethodGetCurrentRoundId                 = "getCurrentRoundId"
+	ExchangeRatesWithDexPricingMethodRateIsFlagged                     = "rateIsFlagged"
 	ExchangeRatesWithDexPricingMethodSynthTooVolatileForAtomicExchange = "synthTooVolatileForAtomicExchange"
 
-	// ChainlinkDataFeed methods
+	// AddressResolver methods
 
-	ChainlinkDataFeedMethodLatestRoundData = "latestRoundData"
-	ChainlinkDataFeedMethodGetRoundData    = "getRoundData"
+	AddressResolverMethodGetAddress = "getAddress"
+
+	// CircuitBreaker methods
+
+	CircuitBreakerMethodCircuitBroken                 = "circuitBroken"
+	CircuitBreakerMethodLastValue                     = "lastValue"
+	CircuitBreakerMethodPriceDeviationThresholdFactor = "priceDeviationThresholdFactor"
+
+	// SystemStatus methods
+
+	SystemStatusMethodSystemSuspension        = "systemSuspension"
+	SystemStatusMethodExchangeSuspension      = "exchangeSuspension"
+	SystemStatusMethodSynthExchangeSuspension = "synthExchangeSuspension"
+	SystemStatusMethodSynthSuspension         = "synthSuspension"
 
 	// DexPriceAggregatorUniswapV3 methods
 
@@ -84,6 +99,13 @@ const (
 	UniswapV3PoolMethodObservations = "observations"
 )
 
+const (
+	// CircuitBreakerContractName is the name of the CircuitBreaker contract in the AddressResolver
+	CircuitBreakerContractName = "CircuitBreaker"
+	// SystemStatusContractName is the name of the SystemStatus contract in the AddressResolver
+	SystemStatusContractName = "SystemStatus"
+)
+
 type PoolStateVersion uint
 
 const (
@@ -20,9 +20,6 @@ var exchangeRatesBytes []byte
 //go:embed abis/ExchangeRatesWithDexPricing.json
 var exchangeRatesWithDexPricingBytes []byte
 
-//go:embed abis/ChainlinkDataFeed.json
-var chainlinkDataFeedBytes []byte
-
 //go:embed abis/DexPriceAggregatorUniswapV3.json
 var dexPriceAggregatorUniswapV3Bytes []byte
 
@@ -34,3 +31,12 @@ var erc20Bytes []byte
 
 //go:embed abis/UniswapV3Pool.json
 var uniswapv3PoolBytes []byte
+
+//go:embed abis/AddressResolver.json
+var addressResolverBytes []byte
+
+//go:embed abis/CircuitBreaker.json
+var circuitBreakerBytes []byte
+
+//go:embed abis/SystemStatus.json
+var systemStatusBytes []byte
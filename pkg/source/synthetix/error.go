@@ -25,4 +25,8 @@ var (
 	ErrSurpassedVolumeLimit          = errors.New("surpassed volume limit")
 	ErrInvalidLastAtomicVolume       = errors.New("invalid LastAtomicVolume")
 	ErrNoSwapLimit                   = errors.New("swap limit is required for Synthetix")
+	ErrSystemSuspended               = errors.New("synthetix is suspended")
+	ErrExchangeSuspended             = errors.New("exchange is suspended")
+	ErrSynthExchangeSuspended        = errors.New("synth exchange is suspended")
+	ErrSynthSuspended                = errors.New("synth is suspended")
 )
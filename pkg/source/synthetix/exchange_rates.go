@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 // =============================================================================================
@@ -16,6 +17,8 @@ type ExchangeRates struct {
 	SystemSettings      *SystemSettings               `json:"systemSettings"`
 	Aggregators         map[string]*ChainlinkDataFeed `json:"aggregators"`
 	CurrencyKeyDecimals map[string]uint8              `json:"currencyKeyDecimals"`
+	RateIsFlagged       map[string]bool               `json:"rateIsFlagged,omitempty"`
+	CircuitBreaker      *CircuitBreaker               `json:"circuitBreaker,omitempty"`
 }
 
 func NewExchangeRates(
@@ -24,6 +27,8 @@ func NewExchangeRates(
 	systemSettings *SystemSettings,
 	aggregators map[string]*ChainlinkDataFeed,
 	currencyKeyDecimals map[string]uint8,
+	rateIsFlagged map[string]bool,
+	circuitBreaker *CircuitBreaker,
 ) *ExchangeRates {
 	return &ExchangeRates{
 		BlockTimestamp:      blockTimestamp,
@@ -31,6 +36,8 @@ func NewExchangeRates(
 		SystemSettings:      systemSettings,
 		Aggregators:         aggregators,
 		CurrencyKeyDecimals: currencyKeyDecimals,
+		RateIsFlagged:       rateIsFlagged,
+		CircuitBreaker:      circuitBreaker,
 	}
 }
 
@@ -105,13 +112,18 @@ func (er *ExchangeRates) rateAndInvalid(currencyKey string) (*big.Int, bool) {
 		uint(er.getRateStalePeriod().Int64()),
 		uint(rateAndTime.time.Int64()),
 		uint(er.BlockTimestamp),
-	)
+	) ||
+		er._rateIsFlagged(currencyKey) ||
+		er._rateIsCircuitBroken(currencyKey, rateAndTime.rate)
+}
 
-	// TODO: implement the rest here
-	//return rateAndTime.rate, _rateIsStaleWithTime(uint(er.getRateStalePeriod().Int64()), uint(rateAndTime.time.Int64())) ||
-	//	_rateIsFlagged(currencyKey, FlagsInterface(getAggregatorWarningFlags())) ||
-	//	_rateIsCircuitBroken(currencyKey, rateAndTime.rate)
+// _rateIsFlagged returns the aggregator warning flag of the currency key, which the tracker reads with rateIsFlagged
+func (er *ExchangeRates) _rateIsFlagged(currencyKey string) bool {
+	return er.RateIsFlagged[currencyKey]
+}
 
+func (er *ExchangeRates) _rateIsCircuitBroken(currencyKey string, curRate *big.Int) bool {
+	return er.CircuitBreaker.isInvalid(currencyKey, curRate)
 }
 
 func (er *ExchangeRates) _formatAggregatorAnswer(currencyKey string, rate *big.Int) (*big.Int, error) {
@@ -119,21 +131,12 @@ func (er *ExchangeRates) _formatAggregatorAnswer(currencyKey string, rate *big.I
 		return nil, ErrNegativeRate
 	}
 
-	decimals := er.CurrencyKeyDecimals[currencyKey]
-	result := rate
-	if decimals == 0 || decimals == 18 {
-		// do not convert for 0 (part of implicit interface), and not needed for 18
-	} else if decimals < 18 {
-		// increase precision to 18
-		multiplier := bignumber.TenPowInt(18 - decimals)
-		result = new(big.Int).Mul(result, multiplier)
-	} else if decimals > 18 {
-		// decrease precision to 18
-		divisor := bignumber.TenPowInt(decimals - 18)
-		result = new(big.Int).Div(result, divisor)
+	// do not convert for 0 (part of implicit interface)
+	if decimals := er.CurrencyKeyDecimals[currencyKey]; decimals != 0 {
+		return oracle.Scale(rate, decimals, 18), nil
 	}
 
-	return result, nil
+	return rate, nil
 }
 
 func (er *ExchangeRates) _getRateAndUpdatedTime(currencyKey string) (RateAndUpdatedTime, error) {
@@ -202,7 +205,7 @@ func (er *ExchangeRates) _getRateAndTimestampAtRound(currencyKey string, roundId
 }
 
 func _rateIsStaleWithTime(_rateStalePeriod uint, _time uint, now uint) bool {
-	return _time+_rateStalePeriod < now
+	return oracle.IsStale(uint64(_time), uint64(_rateStalePeriod), uint64(now))
 }
 
 func (er *ExchangeRates) _getRate(currencyKey string) (*big.Int, error) {
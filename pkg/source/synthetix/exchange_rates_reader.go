@@ -43,6 +43,7 @@ func (r *ExchangeRatesReader) Read(ctx context.Context, poolState *PoolState) (*
 // - Aggregators
 // - CurrencyKeyDecimals
 // - CurrentRoundIds
+// - RateIsFlagged
 func (r *ExchangeRatesReader) readCurrencyKeyData(ctx context.Context, poolState *PoolState) error {
 	var (
 		currencyKeys    = poolState.CurrencyKeys
@@ -52,6 +53,7 @@ func (r *ExchangeRatesReader) readCurrencyKeyData(ctx context.Context, poolState
 		aggregatorAddresses = make([]common.Address, currencyKeysLen)
 		currencyKeyDecimals = make([]uint8, currencyKeysLen)
 		currentRoundIds     = make([]*big.Int, currencyKeysLen)
+		rateIsFlagged       = make([]bool, currencyKeysLen)
 	)
 
 	req := r.ethrpcClient.NewRequest().SetContext(ctx)
@@ -76,7 +78,13 @@ func (r *ExchangeRatesReader) readCurrencyKeyData(ctx context.Context, poolState
 				Target: address,
 				Method: ExchangeRatesMethodGetCurrentRoundId,
 				Params: []interface{}{keyByte},
-			}, []interface{}{&currentRoundIds[i]})
+			}, []interface{}{&currentRoundIds[i]}).
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address,
+				Method: ExchangeRatesMethodRateIsFlagged,
+				Params: []interface{}{keyByte},
+			}, []interface{}{&rateIsFlagged[i]})
 	}
 
 	_, err := req.Aggregate()
@@ -92,6 +100,7 @@ func (r *ExchangeRatesReader) readCurrencyKeyData(ctx context.Context, poolState
 		poolState.AggregatorAddresses[key] = aggregatorAddresses[i]
 		poolState.CurrencyKeyDecimals[key] = currencyKeyDecimals[i]
 		poolState.CurrentRoundIds[key] = currentRoundIds[i]
+		poolState.RateIsFlagged[key] = rateIsFlagged[i]
 	}
 
 	return nil
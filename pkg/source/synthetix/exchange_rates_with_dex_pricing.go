@@ -5,6 +5,7 @@ import (
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/eth"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 // =============================================================================================
@@ -17,29 +18,38 @@ type RateAndUpdatedTime struct {
 }
 
 type ExchangeRatesWithDexPricing struct {
+	BlockTimestamp                     uint64                        `json:"blockTimestamp"`
 	SUSDCurrencyKey                    string                        `json:"sUSDCurrencyKey"`
 	SystemSettings                     *SystemSettings               `json:"systemSettings"`
 	Aggregators                        map[string]*ChainlinkDataFeed `json:"aggregators"`
 	CurrencyKeyDecimals                map[string]uint8              `json:"currencyKeyDecimals"`
 	DexPriceAggregator                 *DexPriceAggregatorUniswapV3  `json:"dexPriceAggregator"`
 	SynthTooVolatileForAtomicExchanges map[string]bool               `json:"synthTooVolatileForAtomicExchange,omitempty"`
+	RateIsFlagged                      map[string]bool               `json:"rateIsFlagged,omitempty"`
+	CircuitBreaker                     *CircuitBreaker               `json:"circuitBreaker,omitempty"`
 }
 
 func NewExchangeRatesWithDexPricing(
+	blockTimestamp uint64,
 	sUSDCurrencyKey string,
 	systemSettings *SystemSettings,
 	aggregators map[string]*ChainlinkDataFeed,
 	currencyKeyDecimals map[string]uint8,
 	dexPriceAggregator *DexPriceAggregatorUniswapV3,
 	synthTooVolatileForAtomicExchanges map[string]bool,
+	rateIsFlagged map[string]bool,
+	circuitBreaker *CircuitBreaker,
 ) *ExchangeRatesWithDexPricing {
 	return &ExchangeRatesWithDexPricing{
+		BlockTimestamp:                     blockTimestamp,
 		SUSDCurrencyKey:                    sUSDCurrencyKey,
 		SystemSettings:                     systemSettings,
 		Aggregators:                        aggregators,
 		CurrencyKeyDecimals:                currencyKeyDecimals,
 		DexPriceAggregator:                 dexPriceAggregator,
 		SynthTooVolatileForAtomicExchanges: synthTooVolatileForAtomicExchanges,
+		RateIsFlagged:                      rateIsFlagged,
+		CircuitBreaker:                     circuitBreaker,
 	}
 }
 
@@ -48,21 +58,12 @@ func (er *ExchangeRatesWithDexPricing) _formatAggregatorAnswer(currencyKey strin
 		return nil, ErrNegativeRate
 	}
 
-	decimals := er.CurrencyKeyDecimals[currencyKey]
-	result := rate
-	if decimals == 0 || decimals == 18 {
-		// do not convert for 0 (part of implicit interface), and not needed for 18
-	} else if decimals < 18 {
-		// increase precision to 18
-		multiplier := bignumber.TenPowInt(18 - decimals)
-		result = new(big.Int).Mul(result, multiplier)
-	} else if decimals > 18 {
-		// decrease precision to 18
-		divisor := bignumber.TenPowInt(decimals - 18)
-		result = new(big.Int).Div(result, divisor)
+	// do not convert for 0 (part of implicit interface)
+	if decimals := er.CurrencyKeyDecimals[currencyKey]; decimals != 0 {
+		return oracle.Scale(rate, decimals, 18), nil
 	}
 
-	return result, nil
+	return rate, nil
 }
 
 func (er *ExchangeRatesWithDexPricing) _getRateAndUpdatedTime(currencyKey string) (RateAndUpdatedTime, error) {
@@ -130,6 +131,25 @@ func (er *ExchangeRatesWithDexPricing) _getRateAndUpdatedTime(currencyKey string
 //	return rate, updatedAt, nil
 //}
 
+func (er *ExchangeRatesWithDexPricing) rateAndInvalid(currencyKey string) (*big.Int, bool) {
+	rateAndTime, err := er._getRateAndUpdatedTime(currencyKey)
+	if err != nil {
+		return nil, true
+	}
+
+	if currencyKey == er.SUSDCurrencyKey {
+		return rateAndTime.rate, false
+	}
+
+	return rateAndTime.rate, _rateIsStaleWithTime(
+		uint(er.getRateStalePeriod().Int64()),
+		uint(rateAndTime.time.Int64()),
+		uint(er.BlockTimestamp),
+	) ||
+		er.RateIsFlagged[currencyKey] ||
+		er.CircuitBreaker.isInvalid(currencyKey, rateAndTime.rate)
+}
+
 func (er *ExchangeRatesWithDexPricing) _getRate(currencyKey string) (*big.Int, error) {
 	rateAndUpdatedTime, err := er._getRateAndUpdatedTime(currencyKey)
 	if err != nil {
@@ -169,6 +189,10 @@ func (er *ExchangeRatesWithDexPricing) _effectiveValueAndRates(
 	return
 }
 
+func (er *ExchangeRatesWithDexPricing) getRateStalePeriod() *big.Int {
+	return er.SystemSettings.RateStalePeriod
+}
+
 func (er *ExchangeRatesWithDexPricing) getAtomicTwapWindow() *big.Int {
 	return er.SystemSettings.AtomicTwapWindow
 }
@@ -81,6 +81,7 @@ func (r *ExchangeRatesWithDexPricingReader) readData(
 // - Aggregators
 // - CurrencyKeyDecimals
 // - CurrentRoundIds
+// - RateIsFlagged
 func (r *ExchangeRatesWithDexPricingReader) readCurrencyKeyData(
 	ctx context.Context,
 	poolState *PoolState,
@@ -93,6 +94,7 @@ func (r *ExchangeRatesWithDexPricingReader) readCurrencyKeyData(
 	currencyKeyDecimals := make([]uint8, currencyKeysLen)
 	currentRoundIds := make([]*big.Int, currencyKeysLen)
 	synthTooVolatileForAtomicExchanges := make([]bool, currencyKeysLen)
+	rateIsFlagged := make([]bool, currencyKeysLen)
 
 	req := r.ethrpcClient.NewRequest().SetContext(ctx)
 
@@ -123,7 +125,13 @@ func (r *ExchangeRatesWithDexPricingReader) readCurrencyKeyData(
 				Target: address,
 				Method: ExchangeRatesWithDexPricingMethodSynthTooVolatileForAtomicExchange,
 				Params: []interface{}{keyByte},
-			}, []interface{}{&synthTooVolatileForAtomicExchanges[i]})
+			}, []interface{}{&synthTooVolatileForAtomicExchanges[i]}).
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address,
+				Method: ExchangeRatesWithDexPricingMethodRateIsFlagged,
+				Params: []interface{}{keyByte},
+			}, []interface{}{&rateIsFlagged[i]})
 	}
 
 	_, err := req.Aggregate()
@@ -139,6 +147,7 @@ func (r *ExchangeRatesWithDexPricingReader) readCurrencyKeyData(
 		poolState.AggregatorAddresses[key] = aggregatorAddresses[i]
 		poolState.CurrencyKeyDecimals[key] = currencyKeyDecimals[i]
 		poolState.CurrentRoundIds[key] = currentRoundIds[i]
+		poolState.RateIsFlagged[key] = rateIsFlagged[i]
 		poolState.SynthTooVolatileForAtomicExchanges[key] = synthTooVolatileForAtomicExchanges[i]
 	}
 
@@ -27,6 +27,8 @@ func GetExchanger(poolStateVersion PoolStateVersion, poolState *PoolState) IExch
 				poolState.SystemSettings,
 				poolState.Aggregators,
 				poolState.CurrencyKeyDecimals,
+				poolState.RateIsFlagged,
+				poolState.CircuitBreaker,
 			))
 	}
 
@@ -37,11 +39,14 @@ func GetExchanger(poolStateVersion PoolStateVersion, poolState *PoolState) IExch
 		poolState.AtomicMaxVolumePerBlock,
 		poolState.SystemSettings,
 		NewExchangeRatesWithDexPricing(
+			poolState.BlockTimestamp,
 			poolState.SUSDCurrencyKey,
 			poolState.SystemSettings,
 			poolState.Aggregators,
 			poolState.CurrencyKeyDecimals,
 			poolState.DexPriceAggregator,
 			poolState.SynthTooVolatileForAtomicExchanges,
+			poolState.RateIsFlagged,
+			poolState.CircuitBreaker,
 		))
 }
@@ -64,6 +64,11 @@ func (ex *ExchangerWithFeeRecAlternatives) GetAmountsOut(
 		return nil, nil, nil, err
 	}
 
+	// SIP-65: Decentralized Circuit Breaker (checking current system rates)
+	if err := ex._exchangeRatesCircuitBroken(sourceCurrencyKey, destinationCurrencyKey); err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Determine sUSD value (volume) of exchange
 	var sourceSusdValue *big.Int
 	if sourceCurrencyKey == ex.SUSDCurrencyKey {
@@ -93,6 +98,27 @@ func (ex *ExchangerWithFeeRecAlternatives) GetAmountsOut(
 	return
 }
 
+// _exchangeRatesCircuitBroken checks the rates of both currencies unless they're sUSD, since its rate is never invalid.
+// The contract returns a zero amount instead of reverting, which we report as an error.
+func (ex *ExchangerWithFeeRecAlternatives) _exchangeRatesCircuitBroken(
+	sourceCurrencyKey string,
+	destinationCurrencyKey string,
+) error {
+	if sourceCurrencyKey != ex.SUSDCurrencyKey {
+		if _, invalid := ex.ExchangeRatesWithDexPricing.rateAndInvalid(sourceCurrencyKey); invalid {
+			return ErrInvalidSrcSynth
+		}
+	}
+
+	if destinationCurrencyKey != ex.SUSDCurrencyKey {
+		if _, invalid := ex.ExchangeRatesWithDexPricing.rateAndInvalid(destinationCurrencyKey); invalid {
+			return ErrInvalidDestSynth
+		}
+	}
+
+	return nil
+}
+
 func (ex *ExchangerWithFeeRecAlternatives) _deductFeesFromAmount(
 	destinationAmount *big.Int,
 	exchangeFeeRate *big.Int,
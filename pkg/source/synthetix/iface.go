@@ -31,3 +31,13 @@ type IChainlinkDataFeedReader interface {
 type IDexPriceAggregatorUniswapV3Reader interface {
 	Read(ctx context.Context, poolState *PoolState) (*DexPriceAggregatorUniswapV3, error)
 }
+
+// ICircuitBreakerReader reads CircuitBreaker smart contract
+type ICircuitBreakerReader interface {
+	Read(ctx context.Context, poolState *PoolState) (*CircuitBreaker, error)
+}
+
+// ISystemStatusReader reads SystemStatus smart contract
+type ISystemStatusReader interface {
+	Read(ctx context.Context, poolState *PoolState) (*SystemStatus, error)
+}
@@ -28,6 +28,9 @@ type Meta struct {
 	UseAtomicExchange      bool   `json:"useAtomicExchange"`
 }
 
+// PoolSimulator quotes Synthetix exchanges, which ExchangeRates prices with the on-chain Chainlink aggregators of the
+// synths, and atomic exchanges also with DEX TWAPs. An exchange takes no price update, so the pool can't quote with
+// pulloracle reports.
 type PoolSimulator struct {
 	pool.Pool
 
@@ -194,6 +197,13 @@ func (p *PoolSimulator) getAmountOut(sourceCurrencyKey string, destinationCurren
 		return nil, nil, err
 	}
 
+	if err := p.poolState.SystemStatus.requireExchangeBetweenSynthsAllowed(
+		sourceCurrencyKey,
+		destinationCurrencyKey,
+	); err != nil {
+		return nil, nil, err
+	}
+
 	exchanger := GetExchanger(p.poolStateVersion, p.poolState)
 
 	amountReceived, fee, _, err := exchanger.GetAmountsOut(amountIn, sourceCurrencyKey, destinationCurrencyKey)
@@ -1,20 +1,29 @@
 package synthetix
 
 import (
+	_ "embed"
 	"fmt"
 	"math/big"
 	"strconv"
 	"testing"
 
+	"github.com/goccy/go-json"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
 	poolPkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/swaplimit"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/valueobject"
 )
 
+// ethereumPoolData is the Ethereum pool state of TestPool_CalcAmountOut, at block timestamp 1663645679
+//
+//go:embed sample_pool_ethereum.json
+var ethereumPoolData string
+
 func TestPool_CalcAmountOut(t *testing.T) {
 	t.Parallel()
 
@@ -716,3 +725,171 @@ func TestPool_GetAtomicVolume(t *testing.T) {
 		})
 	}
 }
+
+// TestPool_CalcAmountOut_InvalidRate checks the validation of the rates and of the SystemStatus suspensions, with the
+// tripping values set on the Ethereum pool state, they are not states observed on-chain
+func TestPool_CalcAmountOut_InvalidRate(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sUSD = "0x57ab1ec28d129707052df4df418d58a2d46d5f51"
+		sETH = "0x5e74c9036fb86bd7ecdcb084a0673efc32ea31cb"
+		sBTC = "0xfe18be6b3bd88a2d2a7f928d00292e7a9963cfc6"
+		sEUR = "0xd71ecff9342a5ced620049e616c5035f1db98620"
+
+		sETHKey = "0x7345544800000000000000000000000000000000000000000000000000000000"
+		sBTCKey = "0x7342544300000000000000000000000000000000000000000000000000000000"
+		sEURKey = "0x7345555200000000000000000000000000000000000000000000000000000000"
+	)
+
+	// the circuit of sEUR broken and the last values of sETH and sBTC probed by the circuit breaker at 1000 and 5000
+	breakCircuit := func(poolState *PoolState) {
+		poolState.CircuitBreaker = &CircuitBreaker{
+			PriceDeviationThresholdFactor: bignumber.NewBig10("3000000000000000000"),
+			CircuitBroken:                 map[string]bool{sEURKey: true},
+			LastValue: map[string]*big.Int{
+				sETHKey: bignumber.NewBig10("1000000000000000000000"),
+				sBTCKey: bignumber.NewBig10("5000000000000000000000"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		setState          func(poolState *PoolState)
+		tokenIn           string
+		tokenOut          string
+		expectedAmountOut *big.Int
+		expectedErr       error
+	}{
+		{
+			name: "it should return error when the source rate is stale",
+			// the sETH rate was updated at 1663644023 and the rate stale period is 90000
+			setState:    func(poolState *PoolState) { poolState.BlockTimestamp = 1663644023 + 90001 },
+			tokenIn:     sETH,
+			tokenOut:    sUSD,
+			expectedErr: ErrInvalidSrcSynth,
+		},
+		{
+			name:        "it should return error when the destination rate is stale",
+			setState:    func(poolState *PoolState) { poolState.BlockTimestamp = 1663644023 + 90001 },
+			tokenIn:     sUSD,
+			tokenOut:    sETH,
+			expectedErr: ErrInvalidDestSynth,
+		},
+		{
+			name: "it should return correct amount when the rate is not stale yet",
+			// the same amount as with the circuit breaker, which does not trip for sETH
+			setState:          func(poolState *PoolState) { poolState.BlockTimestamp = 1663644023 + 90000 },
+			tokenIn:           sETH,
+			tokenOut:          sUSD,
+			expectedAmountOut: bignumber.NewBig10("1346874257520000000000"),
+		},
+		{
+			name: "it should return error when the destination synth is suspended",
+			setState: func(poolState *PoolState) {
+				poolState.SystemStatus = NewSystemStatus()
+				poolState.SystemStatus.SynthSuspended[sETHKey] = true
+			},
+			tokenIn:     sUSD,
+			tokenOut:    sETH,
+			expectedErr: ErrSynthSuspended,
+		},
+		{
+			name: "it should return error when the exchange of the source synth is suspended",
+			setState: func(poolState *PoolState) {
+				poolState.SystemStatus = NewSystemStatus()
+				poolState.SystemStatus.SynthExchangeSuspended[sETHKey] = true
+			},
+			tokenIn:     sETH,
+			tokenOut:    sUSD,
+			expectedErr: ErrSynthExchangeSuspended,
+		},
+		{
+			name:        "it should return error when exchanges are suspended",
+			setState:    func(poolState *PoolState) { poolState.SystemStatus = &SystemStatus{ExchangeSuspended: true} },
+			tokenIn:     sETH,
+			tokenOut:    sUSD,
+			expectedErr: ErrExchangeSuspended,
+		},
+		{
+			name:        "it should return error when the system is suspended",
+			setState:    func(poolState *PoolState) { poolState.SystemStatus = &SystemStatus{SystemSuspended: true} },
+			tokenIn:     sETH,
+			tokenOut:    sUSD,
+			expectedErr: ErrSystemSuspended,
+		},
+		{
+			name:        "it should return error when the source rate is flagged",
+			setState:    func(poolState *PoolState) { poolState.RateIsFlagged[sETHKey] = true },
+			tokenIn:     sETH,
+			tokenOut:    sBTC,
+			expectedErr: ErrInvalidSrcSynth,
+		},
+		{
+			name:        "it should return error when the destination rate is flagged",
+			setState:    func(poolState *PoolState) { poolState.RateIsFlagged[sETHKey] = true },
+			tokenIn:     sBTC,
+			tokenOut:    sETH,
+			expectedErr: ErrInvalidDestSynth,
+		},
+		{
+			name:        "it should return error when the source circuit is broken",
+			setState:    breakCircuit,
+			tokenIn:     sEUR,
+			tokenOut:    sETH,
+			expectedErr: ErrInvalidSrcSynth,
+		},
+		{
+			name:        "it should return error when the destination rate deviates above the threshold",
+			setState:    breakCircuit,
+			tokenIn:     sETH,
+			tokenOut:    sBTC,
+			expectedErr: ErrInvalidDestSynth,
+		},
+		{
+			name:              "it should return correct amount when the rate deviates below the threshold",
+			setState:          breakCircuit,
+			tokenIn:           sETH,
+			tokenOut:          sUSD,
+			expectedAmountOut: bignumber.NewBig10("1346874257520000000000"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var entityPool entity.Pool
+			require.NoError(t, json.Unmarshal([]byte(ethereumPoolData), &entityPool))
+			pool, err := NewPoolSimulator(entityPool, valueobject.ChainIDEthereum)
+			require.NoError(t, err)
+			if pool.poolState.RateIsFlagged == nil {
+				pool.poolState.RateIsFlagged = make(map[string]bool)
+			}
+			tc.setState(pool.poolState)
+
+			result, err := pool.CalcAmountOut(poolPkg.CalcAmountOutParams{
+				TokenAmountIn: poolPkg.TokenAmount{Token: tc.tokenIn, Amount: bignumber.TenPowInt(18)},
+				TokenOut:      tc.tokenOut,
+				Limit: swaplimit.NewInventory("", map[string]*big.Int{
+					strconv.FormatUint(pool.poolState.BlockTimestamp, 10): new(big.Int).Set(pool.poolState.AtomicMaxVolumePerBlock),
+				}),
+			})
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAmountOut, result.TokenAmountOut.Amount)
+		})
+	}
+}
+
+// TestPoolSimulator_Conformance only checks tokens, as quotes need the atomic volume limit of the block
+func TestPoolSimulator_Conformance(t *testing.T) {
+	t.Parallel()
+	var entityPool entity.Pool
+	require.NoError(t, json.Unmarshal([]byte(ethereumPoolData), &entityPool))
+	pool, err := NewPoolSimulator(entityPool, valueobject.ChainIDEthereum)
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, pool)
+}
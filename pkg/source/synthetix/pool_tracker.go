@@ -27,6 +27,8 @@ type PoolTracker struct {
 	exchangeRatesReader                   IExchangeRatesReader
 	chainlinkDataFeedReader               IChainlinkDataFeedReader
 	dexPriceAggregatorUniswapV3Reader     IDexPriceAggregatorUniswapV3Reader
+	circuitBreakerReader                  ICircuitBreakerReader
+	systemStatusReader                    ISystemStatusReader
 }
 
 var _ = pooltrack.RegisterFactoryCE0(DexTypeSynthetix, NewPoolTracker)
@@ -41,6 +43,8 @@ func NewPoolTracker(cfg *Config, ethrpcClient *ethrpc.Client) *PoolTracker {
 			systemSettingsReader:    NewSystemSettingsReader(cfg, ethrpcClient),
 			exchangeRatesReader:     NewExchangeRatesReader(cfg, ethrpcClient),
 			chainlinkDataFeedReader: NewChainlinkDataFeedReader(cfg, ethrpcClient),
+			circuitBreakerReader:    NewCircuitBreakerReader(cfg, ethrpcClient),
+			systemStatusReader:      NewSystemStatusReader(cfg, ethrpcClient),
 		}
 	}
 
@@ -52,6 +56,8 @@ func NewPoolTracker(cfg *Config, ethrpcClient *ethrpc.Client) *PoolTracker {
 		exchangeRatesReader:                   NewExchangeRatesWithDexPricingReader(cfg, ethrpcClient),
 		chainlinkDataFeedReader:               NewChainlinkDataFeedReader(cfg, ethrpcClient),
 		dexPriceAggregatorUniswapV3Reader:     NewDexPriceAggregatorUniswapV3Reader(cfg, ethrpcClient),
+		circuitBreakerReader:                  NewCircuitBreakerReader(cfg, ethrpcClient),
+		systemStatusReader:                    NewSystemStatusReader(cfg, ethrpcClient),
 	}
 }
 
@@ -103,6 +109,26 @@ func (d *PoolTracker) GetNewPoolState(
 		return entity.Pool{}, err
 	}
 
+	circuitBreaker, err := d.getCircuitBreaker(ctx, poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": d.cfg.DexID,
+			"error": err,
+		}).Error("can not get circuit breaker")
+		return entity.Pool{}, err
+	}
+	poolState.CircuitBreaker = circuitBreaker
+
+	systemStatus, err := d.getSystemStatus(ctx, poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": d.cfg.DexID,
+			"error": err,
+		}).Error("can not get system status")
+		return entity.Pool{}, err
+	}
+	poolState.SystemStatus = systemStatus
+
 	chainlinkNumRounds := d.getChainlinkNumRounds(poolState.SystemSettings.DynamicFeeConfig.Rounds)
 
 	aggregators, err := d.getChainlinkDataFeeds(
@@ -259,6 +285,32 @@ func (d *PoolTracker) getExchangeRates(ctx context.Context, poolState *PoolState
 
 }
 
+func (d *PoolTracker) getCircuitBreaker(ctx context.Context, poolState *PoolState) (*CircuitBreaker, error) {
+	circuitBreaker, err := d.circuitBreakerReader.Read(ctx, poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": d.cfg.DexID,
+			"error": err,
+		}).Error("can not get circuit breaker")
+		return nil, err
+	}
+
+	return circuitBreaker, nil
+}
+
+func (d *PoolTracker) getSystemStatus(ctx context.Context, poolState *PoolState) (*SystemStatus, error) {
+	systemStatus, err := d.systemStatusReader.Read(ctx, poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": d.cfg.DexID,
+			"error": err,
+		}).Error("can not get system status")
+		return nil, err
+	}
+
+	return systemStatus, nil
+}
+
 func (d *PoolTracker) getExchangerWithFeeRecAlternativesData(ctx context.Context, poolState *PoolState) (*PoolState, error) {
 	poolStateVersion := getPoolStateVersion(valueobject.ChainID(d.cfg.ChainID))
 
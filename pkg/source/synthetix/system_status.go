@@ -0,0 +1,28 @@
+package synthetix
+
+// =============================================================================================
+// Implementation of this contract:
+// https://github.com/Synthetixio/synthetix/blob/b04a4d2948f3a575bfe8186e99086e50dc54ef95/contracts/SystemStatus.sol
+
+// requireExchangeBetweenSynthsAllowed returns the error of the exchangeActive modifier of Synthetix, which checks the
+// suspensions of the system, of exchanges, and of the exchanges and the synths of both currencies
+func (ss *SystemStatus) requireExchangeBetweenSynthsAllowed(sourceCurrencyKey, destinationCurrencyKey string) error {
+	if ss == nil {
+		return nil
+	}
+
+	if ss.SystemSuspended {
+		return ErrSystemSuspended
+	}
+	if ss.ExchangeSuspended {
+		return ErrExchangeSuspended
+	}
+	if ss.SynthExchangeSuspended[sourceCurrencyKey] || ss.SynthExchangeSuspended[destinationCurrencyKey] {
+		return ErrSynthExchangeSuspended
+	}
+	if ss.SynthSuspended[sourceCurrencyKey] || ss.SynthSuspended[destinationCurrencyKey] {
+		return ErrSynthSuspended
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package synthetix
+
+import (
+	"context"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/eth"
+)
+
+type SystemStatusReader struct {
+	abi          abi.ABI
+	cfg          *Config
+	ethrpcClient *ethrpc.Client
+}
+
+func NewSystemStatusReader(cfg *Config, ethrpcClient *ethrpc.Client) *SystemStatusReader {
+	return &SystemStatusReader{
+		abi:          systemStatus,
+		cfg:          cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+// Read reads the suspensions of the SystemStatus that Synthetix checks before exchanges
+func (r *SystemStatusReader) Read(ctx context.Context, poolState *PoolState) (*SystemStatus, error) {
+	address, err := readResolvedAddress(ctx, r.ethrpcClient, poolState, SystemStatusContractName)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": r.cfg.DexID,
+			"error": err,
+		}).Error("can not read address")
+		return nil, err
+	}
+
+	systemStatus, err := r.readData(ctx, address, poolState)
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"dexID": r.cfg.DexID,
+			"error": err,
+		}).Error("can not read data")
+		return nil, err
+	}
+
+	return systemStatus, nil
+}
+
+// readData reads the suspensions of the system, of exchanges, and of the exchanges and the synths of the currency
+// keys
+func (r *SystemStatusReader) readData(
+	ctx context.Context,
+	address common.Address,
+	poolState *PoolState,
+) (*SystemStatus, error) {
+	var (
+		currencyKeys    = poolState.CurrencyKeys
+		currencyKeysLen = len(currencyKeys)
+
+		systemSuspension, exchangeSuspension Suspension
+		synthExchangeSuspensions             = make([]Suspension, currencyKeysLen)
+		synthSuspensions                     = make([]Suspension, currencyKeysLen)
+	)
+
+	req := r.ethrpcClient.
+		NewRequest().
+		SetContext(ctx).
+		AddCall(&ethrpc.Call{
+			ABI:    r.abi,
+			Target: address.String(),
+			Method: SystemStatusMethodSystemSuspension,
+			Params: nil,
+		}, []interface{}{&systemSuspension}).
+		AddCall(&ethrpc.Call{
+			ABI:    r.abi,
+			Target: address.String(),
+			Method: SystemStatusMethodExchangeSuspension,
+			Params: nil,
+		}, []interface{}{&exchangeSuspension})
+
+	for i, key := range currencyKeys {
+		currencyKey := eth.StringToBytes32(key)
+
+		req.
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address.String(),
+				Method: SystemStatusMethodSynthExchangeSuspension,
+				Params: []interface{}{currencyKey},
+			}, []interface{}{&synthExchangeSuspensions[i]}).
+			AddCall(&ethrpc.Call{
+				ABI:    r.abi,
+				Target: address.String(),
+				Method: SystemStatusMethodSynthSuspension,
+				Params: []interface{}{currencyKey},
+			}, []interface{}{&synthSuspensions[i]})
+	}
+
+	if _, err := req.Aggregate(); err != nil {
+		return nil, err
+	}
+
+	ss := NewSystemStatus()
+	ss.SystemSuspended = systemSuspension.Suspended
+	ss.ExchangeSuspended = exchangeSuspension.Suspended
+	for i, key := range currencyKeys {
+		if synthExchangeSuspensions[i].Suspended {
+			ss.SynthExchangeSuspended[key] = true
+		}
+		if synthSuspensions[i].Suspended {
+			ss.SynthSuspended[key] = true
+		}
+	}
+
+	return ss, nil
+}
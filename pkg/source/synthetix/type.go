@@ -4,6 +4,8 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/oracle"
 )
 
 type Addresses struct {
@@ -36,8 +38,15 @@ type PoolState struct {
 	CurrencyKeyDecimals                map[string]uint8          `json:"currencyKeyDecimals"`
 	CurrentRoundIds                    map[string]*big.Int       `json:"currentRoundIds"`
 	SynthTooVolatileForAtomicExchanges map[string]bool           `json:"synthTooVolatileForAtomicExchange,omitempty"`
+	RateIsFlagged                      map[string]bool           `json:"rateIsFlagged,omitempty"`
 	DexPriceAggregatorAddress          common.Address            `json:"dexPriceAggregatorAddress,omitempty"`
 
+	// CircuitBreaker data, will be updated by CircuitBreakerReader
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// SystemStatus data, will be updated by SystemStatusReader
+	SystemStatus *SystemStatus `json:"systemStatus,omitempty"`
+
 	// ChainlinkDataFeed data, will be updated by ChainlinkDataFeedReader
 	Aggregators map[string]*ChainlinkDataFeed `json:"aggregators"`
 
@@ -53,6 +62,7 @@ func NewPoolState() *PoolState {
 		CurrencyKeyDecimals:                make(map[string]uint8),
 		CurrentRoundIds:                    make(map[string]*big.Int),
 		SynthTooVolatileForAtomicExchanges: make(map[string]bool),
+		RateIsFlagged:                      make(map[string]bool),
 		AggregatorAddresses:                make(map[string]common.Address),
 		Aggregators:                        make(map[string]*ChainlinkDataFeed),
 	}
@@ -114,13 +124,7 @@ type ChainlinkDataFeed struct {
 	Answers         map[string]RoundData `json:"answers"`
 }
 
-type RoundData struct {
-	RoundId         *big.Int `json:"roundId"`
-	Answer          *big.Int `json:"answer"`
-	StartedAt       *big.Int `json:"startedAt"`
-	UpdatedAt       *big.Int `json:"updatedAt"`
-	AnsweredInRound *big.Int `json:"answeredInRound"`
-}
+type RoundData = oracle.RoundData
 
 func NewChainlinkDataFeed() *ChainlinkDataFeed {
 	return &ChainlinkDataFeed{
@@ -128,6 +132,42 @@ func NewChainlinkDataFeed() *ChainlinkDataFeed {
 	}
 }
 
+// CircuitBreaker is the state of the CircuitBreaker contract for the aggregators of the currency keys, keyed by
+// currency key instead of aggregator address.
+type CircuitBreaker struct {
+	PriceDeviationThresholdFactor *big.Int            `json:"priceDeviationThresholdFactor"`
+	CircuitBroken                 map[string]bool     `json:"circuitBroken,omitempty"`
+	LastValue                     map[string]*big.Int `json:"lastValue,omitempty"`
+}
+
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		CircuitBroken: make(map[string]bool),
+		LastValue:     make(map[string]*big.Int),
+	}
+}
+
+// SystemStatus is the state of the suspensions of the SystemStatus contract, keyed by currency key
+type SystemStatus struct {
+	SystemSuspended        bool            `json:"systemSuspended,omitempty"`
+	ExchangeSuspended      bool            `json:"exchangeSuspended,omitempty"`
+	SynthExchangeSuspended map[string]bool `json:"synthExchangeSuspended,omitempty"`
+	SynthSuspended         map[string]bool `json:"synthSuspended,omitempty"`
+}
+
+func NewSystemStatus() *SystemStatus {
+	return &SystemStatus{
+		SynthExchangeSuspended: make(map[string]bool),
+		SynthSuspended:         make(map[string]bool),
+	}
+}
+
+// Suspension is a suspension of the SystemStatus contract
+type Suspension struct {
+	Suspended bool
+	Reason    *big.Int
+}
+
 type Slot0 struct {
 	SqrtPriceX96               *big.Int `json:"sqrtPriceX96"`
 	Tick                       *big.Int `json:"tick"`
@@ -22,3 +22,13 @@ type Gas struct {
 type Meta struct {
 	SwapFee string `json:"swapFee"`
 }
+
+// SupportsTaxedTokens returns true as pairs swap the balance they received over their reserves
+func (Meta) SupportsTaxedTokens() bool {
+	return true
+}
+
+// SupportsRebasingTokens returns false as a rebase leaves the cached reserves of a pair stale until it is synced
+func (Meta) SupportsRebasingTokens() bool {
+	return false
+}
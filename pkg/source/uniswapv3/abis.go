@@ -7,8 +7,9 @@ import (
 )
 
 var (
-	uniswapV3PoolABI abi.ABI
-	erc20ABI         abi.ABI
+	uniswapV3PoolABI    abi.ABI
+	uniswapV3FactoryABI abi.ABI
+	erc20ABI            abi.ABI
 )
 
 func init() {
@@ -17,6 +18,7 @@ func init() {
 		data []byte
 	}{
 		{&uniswapV3PoolABI, uniswapV3PoolJson},
+		{&uniswapV3FactoryABI, uniswapV3FactoryJson},
 		{&erc20ABI, erc20Json},
 	}
 
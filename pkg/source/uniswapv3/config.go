@@ -10,6 +10,7 @@ type Config struct {
 	TickLensAddress    string      `json:"tickLensAddress,omitempty"`
 	PreGenesisPoolPath string      `json:"preGenesisPoolPath,omitempty"`
 	AlwaysUseTickLens  bool        `json:"alwaysUseTickLens,omitempty"` // instead of fetching from subgraph
+	FactoryAddress     string      `json:"factoryAddress,omitempty"`    // to discover pools from its logs
 
 	preGenesisPoolIDs []string
 }
@@ -1,8 +1,9 @@
 package uniswapv3
 
 import (
-	"errors"
 	"math/big"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 )
 
 const (
@@ -17,14 +18,16 @@ const (
 	methodGetSlot0       = "slot0"
 	methodTickSpacing    = "tickSpacing"
 	erc20MethodBalanceOf = "balanceOf"
+
+	factoryEventPoolCreated = "PoolCreated"
 )
 
 var (
 	zeroBI     = big.NewInt(0)
 	defaultGas = Gas{BaseGas: 85000, CrossInitTickGas: 24000}
 
-	ErrOverflow       = errors.New("bigInt overflow int/uint256")
-	ErrInvalidFeeTier = errors.New("invalid feeTier")
-	ErrTickNil        = errors.New("tick is nil")
-	ErrV3TicksEmpty   = errors.New("v3Ticks empty")
+	ErrOverflow       = clcore.ErrOverflow
+	ErrInvalidFeeTier = clcore.ErrInvalidFeeTier
+	ErrTickNil        = clcore.ErrTickNil
+	ErrV3TicksEmpty   = clcore.ErrV3TicksEmpty
 )
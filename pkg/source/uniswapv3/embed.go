@@ -7,6 +7,9 @@ import (
 //go:embed abis/UniswapV3Pool.json
 var uniswapV3PoolJson []byte
 
+//go:embed abis/UniswapV3Factory.json
+var uniswapV3FactoryJson []byte
+
 //go:embed abis/ERC20.json
 var erc20Json []byte
 
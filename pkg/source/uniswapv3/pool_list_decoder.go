@@ -0,0 +1,125 @@
+package uniswapv3
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/KyberNetwork/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	pooldecode "github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool/decode"
+)
+
+// PoolsListDecoder discovers pools from the PoolCreated logs of the factory, fetching only the decimals of their
+// tokens. Pools of tokens whose decimals can not be fetched are skipped.
+type PoolsListDecoder struct {
+	config       *Config
+	ethrpcClient *ethrpc.Client
+}
+
+// PoolCreatedEvent is the PoolCreated event of the factory
+type PoolCreatedEvent struct {
+	Token0      common.Address
+	Token1      common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Pool        common.Address
+}
+
+var _ = pooldecode.RegisterListFactoryCE(DexTypeUniswapV3, NewPoolsListDecoder)
+
+func NewPoolsListDecoder(cfg *Config, ethrpcClient *ethrpc.Client) *PoolsListDecoder {
+	return &PoolsListDecoder{
+		config:       cfg,
+		ethrpcClient: ethrpcClient,
+	}
+}
+
+func (d *PoolsListDecoder) GetKeys(_ context.Context) ([]string, error) {
+	return []string{strings.ToLower(d.config.FactoryAddress)}, nil
+}
+
+func (d *PoolsListDecoder) Decode(_ context.Context, logs []types.Log) (map[string][]types.Log, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil {
+		return nil, err
+	}
+	addressLogs := make(map[string][]types.Log, len(events))
+	for i, event := range events {
+		pool := strings.ToLower(event.Pool.Hex())
+		addressLogs[pool] = append(addressLogs[pool], filtered[i])
+	}
+	return addressLogs, nil
+}
+
+func (d *PoolsListDecoder) DecodeNewPools(ctx context.Context, logs []types.Log) ([]entity.Pool, error) {
+	events, filtered, err := d.decodeEvents(logs)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	tokens := make([]string, 0, 2*len(events))
+	for _, event := range events {
+		tokens = append(tokens, event.Token0.Hex(), event.Token1.Hex())
+	}
+	decimals, err := pooldecode.FetchDecimals(ctx, d.ethrpcClient, lo.Uniq(tokens))
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]entity.Pool, 0, len(events))
+	for i, event := range events {
+		address := strings.ToLower(event.Pool.Hex())
+		token0, token1 := strings.ToLower(event.Token0.Hex()), strings.ToLower(event.Token1.Hex())
+		if !pooldecode.HasDecimals(decimals, token0, token1) {
+			logger.WithFields(logger.Fields{"dexId": d.config.DexID, "pool": address}).
+				Warn("skip pool of tokens without decimals")
+			continue
+		}
+		extraBytes, err := json.Marshal(Extra{TickSpacing: event.TickSpacing.Uint64()})
+		if err != nil {
+			return nil, err
+		}
+		staticExtraBytes, err := json.Marshal(StaticExtra{PoolId: address})
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, entity.Pool{
+			Address:     address,
+			SwapFee:     float64(event.Fee.Uint64()),
+			Exchange:    d.config.DexID,
+			Type:        DexTypeUniswapV3,
+			Timestamp:   time.Now().Unix(),
+			BlockNumber: filtered[i].BlockNumber,
+			Reserves:    []string{"0", "0"},
+			Tokens: []*entity.PoolToken{
+				{Address: token0, Decimals: decimals[token0], Swappable: true},
+				{Address: token1, Decimals: decimals[token1], Swappable: true},
+			},
+			Extra:       string(extraBytes),
+			StaticExtra: string(staticExtraBytes),
+		})
+	}
+	return pools, nil
+}
+
+// decodeEvents returns the PoolCreated events of the factory among logs along with their logs
+func (d *PoolsListDecoder) decodeEvents(logs []types.Log) ([]PoolCreatedEvent, []types.Log, error) {
+	filtered := pooldecode.FilterLogs(logs, d.config.FactoryAddress,
+		uniswapV3FactoryABI.Events[factoryEventPoolCreated].ID)
+	events := make([]PoolCreatedEvent, len(filtered))
+	for i, log := range filtered {
+		if err := pooldecode.UnpackLog(uniswapV3FactoryABI, factoryEventPoolCreated, &events[i], log); err != nil {
+			return nil, nil, err
+		}
+	}
+	return events, filtered, nil
+}
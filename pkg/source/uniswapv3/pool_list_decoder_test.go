@@ -0,0 +1,81 @@
+package uniswapv3
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abipkg "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/abi"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolsListDecoder(t *testing.T) {
+	var (
+		factory = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+		usdc    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+		weth    = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+		broken  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		pool    = common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+		skipped = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	)
+	event := uniswapV3FactoryABI.Events[factoryEventPoolCreated]
+	newLog := func(token0, token1, pool common.Address, fee, tickSpacing int64) types.Log {
+		data, err := event.Inputs.NonIndexed().Pack(big.NewInt(tickSpacing), pool)
+		require.NoError(t, err)
+		return types.Log{
+			Address: factory,
+			Topics: []common.Hash{event.ID, common.BytesToHash(token0[:]), common.BytesToHash(token1[:]),
+				common.BigToHash(big.NewInt(fee))},
+			Data:        data,
+			BlockNumber: 12376729,
+		}
+	}
+	logs := []types.Log{
+		newLog(usdc, weth, pool, 500, 10),
+		newLog(broken, weth, skipped, 3000, 60),
+		{Address: pool, Topics: []common.Hash{event.ID}},
+	}
+
+	ethrpcClient := testutil.NewEthrpcClient(t, func(target common.Address, _ []byte) ([]byte, bool) {
+		decimals := map[common.Address]uint8{usdc: 6, weth: 18}[target]
+		if decimals == 0 {
+			return nil, false
+		}
+		data, err := abipkg.Erc20ABI.Methods[abipkg.Erc20DecimalsMethod].Outputs.Pack(decimals)
+		require.NoError(t, err)
+		return data, true
+	})
+	d := NewPoolsListDecoder(&Config{DexID: "uniswap-v3", FactoryAddress: factory.Hex()}, ethrpcClient)
+
+	keys, err := d.GetKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x1f98431c8ad98523631ae4a59f267346ea31f984"}, keys)
+
+	addressLogs, err := d.Decode(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]types.Log{
+		"0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640": logs[:1],
+		"0x00000000000000000000000000000000000000cc": logs[1:2],
+	}, addressLogs)
+
+	// the pool of the token without decimals is skipped rather than given guessed decimals
+	pools, err := d.DecodeNewPools(context.Background(), logs)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640", pools[0].Address)
+	assert.EqualValues(t, 500, pools[0].SwapFee)
+	assert.EqualValues(t, 12376729, pools[0].BlockNumber)
+	assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", pools[0].Tokens[0].Address)
+	assert.EqualValues(t, 6, pools[0].Tokens[0].Decimals)
+	assert.Equal(t, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", pools[0].Tokens[1].Address)
+	assert.EqualValues(t, 18, pools[0].Tokens[1].Decimals)
+	var extra Extra
+	require.NoError(t, json.Unmarshal([]byte(pools[0].Extra), &extra))
+	assert.EqualValues(t, 10, extra.TickSpacing)
+}
@@ -1,28 +1,17 @@
 package uniswapv3
 
 import (
-	"fmt"
 	"math/big"
-	"strconv"
 
-	"github.com/KyberNetwork/int256"
-	v3Utils "github.com/KyberNetwork/uniswapv3-sdk-uint256/utils"
 	"github.com/holiman/uint256"
 
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/clcore"
 	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/ticklens"
 )
 
-type Gas struct {
-	BaseGas          int64
-	CrossInitTickGas int64
-}
+type Gas = clcore.Gas
 
-type SwapInfo struct {
-	RemainingAmountIn     *v3Utils.Int256  `json:"rAI,omitempty"`
-	NextStateSqrtRatioX96 *v3Utils.Uint160 `json:"nSqrtRx96"`
-	nextStateLiquidity    *v3Utils.Uint128
-	nextStateTickCurrent  int
-}
+type SwapInfo = clcore.SwapInfo
 
 type Metadata struct {
 	LastCreatedAtTimestamp *big.Int `json:"lastCreatedAtTimestamp"`
@@ -55,17 +44,9 @@ type StaticExtra struct {
 	PoolId string `json:"poolId"`
 }
 
-type Tick struct {
-	Index          int      `json:"index"`
-	LiquidityGross *big.Int `json:"liquidityGross"`
-	LiquidityNet   *big.Int `json:"liquidityNet"`
-}
+type Tick = clcore.Tick
 
-type TickU256 struct {
-	Index          int          `json:"index"`
-	LiquidityGross *uint256.Int `json:"liquidityGross"`
-	LiquidityNet   *int256.Int  `json:"liquidityNet"`
-}
+type TickU256 = clcore.TickU256
 
 type Extra struct {
 	Liquidity    *big.Int `json:"liquidity"`
@@ -75,13 +56,7 @@ type Extra struct {
 	Ticks        []Tick   `json:"ticks"`
 }
 
-type ExtraTickU256 struct {
-	Liquidity    *uint256.Int `json:"liquidity"`
-	SqrtPriceX96 *uint256.Int `json:"sqrtPriceX96"`
-	TickSpacing  uint64       `json:"tickSpacing"`
-	Tick         *int         `json:"tick"`
-	Ticks        []TickU256   `json:"ticks"`
-}
+type ExtraTickU256 = clcore.ExtraTickU256
 
 type Slot0 struct {
 	SqrtPriceX96 *big.Int
@@ -104,26 +79,3 @@ type PoolMeta struct {
 	SwapFee    uint32       `json:"swapFee"`
 	PriceLimit *uint256.Int `json:"priceLimit"`
 }
-
-func transformTickRespToTick(tickResp TickResp) (Tick, error) {
-	liquidityGross, ok := new(big.Int).SetString(tickResp.LiquidityGross, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityGross string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	liquidityNet, ok := new(big.Int).SetString(tickResp.LiquidityNet, 10)
-	if !ok {
-		return Tick{}, fmt.Errorf("can not convert liquidityNet string to bigInt, tick: %v", tickResp.TickIdx)
-	}
-
-	tickIdx, err := strconv.Atoi(tickResp.TickIdx)
-	if err != nil {
-		return Tick{}, fmt.Errorf("can not convert tickIdx string to int, tick: %v", tickResp.TickIdx)
-	}
-
-	return Tick{
-		Index:          tickIdx,
-		LiquidityGross: liquidityGross,
-		LiquidityNet:   liquidityNet,
-	}, nil
-}
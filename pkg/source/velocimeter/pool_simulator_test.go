@@ -35,6 +35,7 @@ func TestCalcAmountOut(t *testing.T) {
 		StaticExtra: "{\"stable\": false}",
 	})
 	require.Nil(t, err)
+	testutil.TestPoolSimulator(t, p)
 
 	assert.Equal(t, []string{"A"}, p.CanSwapTo("B"))
 	assert.Equal(t, []string{"B"}, p.CanSwapTo("A"))
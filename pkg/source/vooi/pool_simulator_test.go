@@ -91,6 +91,7 @@ func TestPoolSimulator_CalcAmountOut(t *testing.T) {
 
 			gas: defaultGas,
 		}
+		testutil.TestPoolSimulator(t, &poolSimulator)
 
 		result, err := testutil.MustConcurrentSafe(t, func() (*poolpkg.CalcAmountOutResult, error) {
 			return poolSimulator.CalcAmountOut(poolpkg.CalcAmountOutParams{
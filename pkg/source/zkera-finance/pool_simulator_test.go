@@ -0,0 +1,66 @@
+package zkerafinance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/entity"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/gmxcore"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/testutil"
+)
+
+func TestPoolSimulator_CalcAmountOut(t *testing.T) {
+	t.Parallel()
+	weth, usdc := "0x5aea5775959fbc2557cc8789bc1bf90a239d9a91", "0x3355df6d4c9c3035724fd0e3914de96a5a83aaf4"
+
+	priceFeed := gmxcore.NewVaultPriceFeed()
+	priceFeed.PriceFeedType = gmxcore.PriceFeedTypeLatestAnswerMinMax
+	vault := gmxcore.NewVault()
+	for token, answer := range map[string]string{weth: "200000000000", usdc: "100000000"} {
+		priceFeed.PriceDecimals[token] = big.NewInt(8)
+		priceFeed.SpreadBasisPoints[token] = bignumber.ZeroBI
+		priceFeed.AdjustmentBasisPoints[token] = bignumber.ZeroBI
+		feed := gmxcore.NewPriceFeed()
+		feed.Answers["false"] = bignumber.NewBig10(answer)
+		feed.Answers["true"] = bignumber.NewBig10(answer)
+		priceFeed.PriceFeeds[token] = feed
+
+		vault.BufferAmounts[token] = bignumber.ZeroBI
+		vault.ReservedAmounts[token] = bignumber.ZeroBI
+		vault.MaxUSDGAmounts[token] = bignumber.ZeroBI
+		vault.TokenWeights[token] = big.NewInt(50)
+		vault.USDGAmounts[token] = bignumber.NewBig10("2000000000000000000000000")
+	}
+	vault.IsSwapEnabled = true
+	vault.SwapFeeBasisPoints = big.NewInt(30)
+	vault.TaxBasisPoints = big.NewInt(50)
+	vault.StableSwapFeeBasisPoints = big.NewInt(4)
+	vault.StableTaxBasisPoints = big.NewInt(20)
+	vault.TotalTokenWeights = big.NewInt(100)
+	vault.WhitelistedTokens = []string{weth, usdc}
+	vault.PoolAmounts = map[string]*big.Int{
+		weth: bignumber.NewBig10("1000000000000000000000"),
+		usdc: bignumber.NewBig10("2000000000000"),
+	}
+	vault.TokenDecimals = map[string]*big.Int{weth: big.NewInt(18), usdc: big.NewInt(6)}
+	vault.StableTokens = map[string]bool{usdc: true}
+	vault.PriceFeed = priceFeed
+	vault.USDG = &gmxcore.USDG{TotalSupply: bignumber.NewBig10("4000000000000000000000000")}
+	extra, err := json.Marshal(gmxcore.Extra{Vault: vault})
+	require.NoError(t, err)
+
+	poolSim, err := NewPoolSimulator(entity.Pool{
+		Address:  "0x9cc4e8e60a2c9a67ac7d20f54607f98efba38acf",
+		Exchange: "zkera-finance",
+		Type:     DexType,
+		Reserves: entity.PoolReserves{"1000000000000000000000", "2000000000000"},
+		Tokens:   []*entity.PoolToken{{Address: weth}, {Address: usdc}},
+		Extra:    string(extra),
+	})
+	require.NoError(t, err)
+	testutil.TestPoolSimulator(t, poolSim)
+}
@@ -1,14 +0,0 @@
-package zkerafinance
-
-import (
-	"math/big"
-)
-
-type USDG struct {
-	Address     string   `json:"address"`
-	TotalSupply *big.Int `json:"totalSupply"`
-}
-
-const (
-	usdgMethodTotalSupply = "totalSupply"
-)
@@ -0,0 +1,89 @@
+// Package accrual projects conversion rates that keep growing between tracker runs, such as the share price of
+// yield-bearing vaults, to the timestamp being quoted instead of freezing them at the last fetched value.
+package accrual
+
+import (
+	"github.com/holiman/uint256"
+
+	big256 "github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/big256"
+)
+
+// linearTolerance is the inverse of the relative difference allowed between the growths of two consecutive steps for
+// the growth to be considered linear, i.e. 10%.
+const linearTolerance = 10
+
+// Linear is the linear growth of a rate recorded by a tracker at the block timestamp Timestamp.
+type Linear struct {
+	Timestamp uint64       `json:"t"`
+	PerSecond *uint256.Int `json:"r,omitempty"` // growth of the rate per second in 1e18, i.e. the per-second APR
+	Horizon   uint64       `json:"h,omitempty"` // seconds after Timestamp the growth is projected for, 0 if not at all
+}
+
+// Estimate derives the growth of a rate from its previous snapshot prevRate taken along with the growth prev. The
+// growth is only projected, for up to the sampling interval, if it matches the growth observed in the previous step,
+// so that rates that stay flat or jump in steps are not extrapolated. prev itself is kept if timestamp is not after it.
+// The growth is an estimate: as long as the rate keeps growing within linearTolerance of it, the projected rate is off
+// by at most a tenth of its growth over one sampling interval.
+func Estimate(prev *Linear, prevRate, rate *uint256.Int, timestamp uint64) *Linear {
+	if prev != nil && timestamp <= prev.Timestamp {
+		return prev
+	}
+
+	linear := &Linear{Timestamp: timestamp}
+	if prev == nil || prevRate == nil || rate == nil || prevRate.IsZero() || !rate.Gt(prevRate) {
+		return linear
+	}
+
+	// perSecond = (rate - prevRate) * 1e18 / prevRate / elapsed
+	elapsed := timestamp - prev.Timestamp
+	var delta, perSecond uint256.Int
+	if _, overflow := perSecond.MulDivOverflow(delta.Sub(rate, prevRate), big256.BONE, prevRate); overflow {
+		return linear
+	}
+	linear.PerSecond = perSecond.Div(&perSecond, uint256.NewInt(elapsed))
+	if prev.isLinearWith(linear.PerSecond) {
+		linear.Horizon = elapsed
+	}
+	return linear
+}
+
+// isLinearWith returns whether perSecond is within linearTolerance of the growth observed in the previous step.
+func (l *Linear) isLinearWith(perSecond *uint256.Int) bool {
+	if l.PerSecond == nil || l.PerSecond.IsZero() || perSecond.IsZero() {
+		return false
+	}
+	var diff uint256.Int
+	if perSecond.Gt(l.PerSecond) {
+		diff.Sub(perSecond, l.PerSecond)
+	} else {
+		diff.Sub(l.PerSecond, perSecond)
+	}
+	return !diff.Mul(&diff, uint256.NewInt(linearTolerance)).Gt(l.PerSecond)
+}
+
+// At returns the growth rebased to timestamp, e.g. after the projected value is written back at timestamp, keeping
+// the end of its horizon.
+func (l *Linear) At(timestamp uint64) *Linear {
+	if l == nil || timestamp <= l.Timestamp {
+		return l
+	}
+	elapsed := timestamp - l.Timestamp
+	return &Linear{Timestamp: timestamp, PerSecond: l.PerSecond, Horizon: l.Horizon - min(l.Horizon, elapsed)}
+}
+
+// Project returns value grown linearly from Timestamp to timestamp, capped at the horizon, or value itself if it does
+// not grow until then.
+func (l *Linear) Project(value *uint256.Int, timestamp uint64) *uint256.Int {
+	if l == nil || l.PerSecond == nil || l.PerSecond.IsZero() || l.Horizon == 0 || timestamp <= l.Timestamp {
+		return value
+	}
+
+	var ratio, growth uint256.Int
+	if _, overflow := ratio.MulOverflow(l.PerSecond, uint256.NewInt(min(timestamp-l.Timestamp, l.Horizon))); overflow {
+		return value
+	}
+	if _, overflow := growth.MulDivOverflow(value, &ratio, big256.BONE); overflow {
+		return value
+	}
+	return growth.Add(value, &growth)
+}
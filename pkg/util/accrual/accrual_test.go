@@ -0,0 +1,71 @@
+package accrual
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	rate := uint256.NewInt(1e18)
+	grown := uint256.NewInt(1000001e12)
+	prev := &Linear{Timestamp: 1000, PerSecond: uint256.NewInt(11e9)}
+
+	linear := Estimate(prev, rate, grown, 1100)
+	assert.Equal(t, uint64(1100), linear.Timestamp)
+	assert.Equal(t, uint64(1e10), linear.PerSecond.Uint64())
+	assert.Equal(t, uint64(100), linear.Horizon)
+
+	// steps that do not follow the previous growth are recorded but not projected
+	assert.Zero(t, Estimate(&Linear{Timestamp: 1000}, rate, grown, 1100).Horizon)
+	assert.Zero(t, Estimate(&Linear{Timestamp: 1000, PerSecond: uint256.NewInt(2e10)}, rate, grown, 1100).Horizon)
+	assert.Equal(t, uint64(1e10), Estimate(&Linear{Timestamp: 1000}, rate, grown, 1100).PerSecond.Uint64())
+
+	assert.Nil(t, Estimate(nil, nil, grown, 1100).PerSecond)
+	assert.Nil(t, Estimate(prev, grown, rate, 1100).PerSecond)
+	assert.Same(t, prev, Estimate(prev, rate, grown, 1000))
+}
+
+func TestEstimate_ErrorBound(t *testing.T) {
+	prevRate := uint256.NewInt(1e18)
+	rate := uint256.NewInt(1000001e12)
+	linear := Estimate(&Linear{Timestamp: 1000, PerSecond: uint256.NewInt(1e10)}, prevRate, rate, 1100)
+	projected := linear.Project(rate, 1100+linear.Horizon).Uint64()
+
+	// the rate grows by a millionth over the next interval at 1e10 per second, so the projection is off by at most a
+	// tenth of that as long as it keeps growing within 10% of it
+	for _, perSecond := range []uint64{9e9, 1e10, 11e9} {
+		var growth uint256.Int
+		growth.MulDivOverflow(rate, uint256.NewInt(perSecond*linear.Horizon), uint256.NewInt(1e18))
+		actual := rate.Uint64() + growth.Uint64()
+		assert.LessOrEqual(t, max(actual, projected)-min(actual, projected), uint64(1000001e5),
+			"actual %d, projected %d", actual, projected)
+	}
+}
+
+func TestProject(t *testing.T) {
+	value := uint256.NewInt(1e18)
+	linear := &Linear{Timestamp: 1000, PerSecond: uint256.NewInt(1e10), Horizon: 100}
+
+	assert.Equal(t, value, linear.Project(value, 900))
+	assert.Equal(t, value, linear.Project(value, 1000))
+	assert.Equal(t, uint64(1000001e12), linear.Project(value, 1100).Uint64())
+	assert.Equal(t, uint64(1000001e12), linear.Project(value, 1200).Uint64())
+	assert.Equal(t, uint64(1e18), value.Uint64(), "value should not be mutated")
+
+	assert.Equal(t, value, (*Linear)(nil).Project(value, 1100))
+	assert.Equal(t, value, (&Linear{Timestamp: 1000}).Project(value, 1100))
+	assert.Equal(t, value, (&Linear{Timestamp: 1000, PerSecond: uint256.NewInt(1e10)}).Project(value, 1100))
+}
+
+func TestAt(t *testing.T) {
+	value := uint256.NewInt(1e18)
+	linear := &Linear{Timestamp: 1000, PerSecond: uint256.NewInt(1e10), Horizon: 100}
+
+	assert.Same(t, linear, linear.At(1000))
+	assert.Equal(t, uint64(40), linear.At(1060).Horizon)
+	assert.Zero(t, linear.At(1200).Horizon)
+	assert.Equal(t, uint64(1000000400000000000), linear.At(1060).Project(value, 1200).Uint64())
+	assert.Nil(t, (*Linear)(nil).At(1000))
+}
@@ -0,0 +1,55 @@
+// Package lst holds what the liquid staking token sources share about redeeming their tokens back to the staked asset.
+package lst
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrQueueOnly is returned when quoting the redeem direction of a protocol that only redeems through a withdrawal
+	// queue, which cannot be swapped through.
+	ErrQueueOnly = errors.New("redemption is queue-only")
+	// ErrInsufficientBuffer is returned when an instant redemption exceeds the buffer it is paid out of.
+	ErrInsufficientBuffer = errors.New("insufficient buffer for instant redemption")
+)
+
+// Redemption is how a protocol redeems its liquid staking token.
+type Redemption uint8
+
+const (
+	// QueueOnly redemptions wait in a withdrawal queue, so only the deposit direction is swappable.
+	QueueOnly Redemption = iota
+	// Instant redemptions are paid out of a buffer or liquidity pool, capped by its live balance.
+	Instant
+)
+
+// IsInstant tells whether the redeem direction can be swapped through.
+func (r Redemption) IsInstant() bool {
+	return r == Instant
+}
+
+// CheckRedeem returns ErrQueueOnly if the redeem direction cannot be swapped through.
+func (r Redemption) CheckRedeem() error {
+	if !r.IsInstant() {
+		return ErrQueueOnly
+	}
+	return nil
+}
+
+// CheckBuffer returns ErrInsufficientBuffer if an instant redemption of amount cannot be paid out of buffer. A nil
+// buffer, as left by a tracker whose calls reverted, holds nothing.
+func CheckBuffer(buffer, amount *big.Int) error {
+	if buffer == nil || buffer.Cmp(amount) < 0 {
+		return ErrInsufficientBuffer
+	}
+	return nil
+}
+
+// Drain returns what is left of buffer once amount has been paid out of it, floored at zero.
+func Drain(buffer, amount *big.Int) *big.Int {
+	if buffer == nil || buffer.Cmp(amount) <= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(buffer, amount)
+}
@@ -0,0 +1,26 @@
+package lst
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer(t *testing.T) {
+	buffer := big.NewInt(100)
+
+	assert.NoError(t, CheckBuffer(buffer, big.NewInt(100)))
+	assert.ErrorIs(t, CheckBuffer(buffer, big.NewInt(101)), ErrInsufficientBuffer)
+	assert.ErrorIs(t, CheckBuffer(nil, big.NewInt(1)), ErrInsufficientBuffer)
+
+	assert.Equal(t, int64(40), Drain(buffer, big.NewInt(60)).Int64())
+	assert.Zero(t, Drain(buffer, big.NewInt(160)).Sign())
+	assert.Zero(t, Drain(nil, big.NewInt(1)).Sign())
+	assert.Equal(t, int64(100), buffer.Int64(), "buffer should not be mutated")
+
+	assert.True(t, Instant.IsInstant())
+	assert.False(t, QueueOnly.IsInstant())
+	assert.NoError(t, Instant.CheckRedeem())
+	assert.ErrorIs(t, QueueOnly.CheckRedeem(), ErrQueueOnly)
+}
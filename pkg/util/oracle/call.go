@@ -0,0 +1,69 @@
+package oracle
+
+import (
+	"bytes"
+	_ "embed"
+	"math/big"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	methodGetRoundData    = "getRoundData"
+	methodLatestAnswer    = "latestAnswer"
+	methodLatestRound     = "latestRound"
+	methodLatestRoundData = "latestRoundData"
+)
+
+var (
+	//go:embed Aggregator.json
+	aggregatorJson []byte
+	// AggregatorABI is the ABI of the view methods of Chainlink-style aggregators and their proxies.
+	AggregatorABI abi.ABI
+)
+
+func init() {
+	var err error
+	if AggregatorABI, err = abi.JSON(bytes.NewReader(aggregatorJson)); err != nil {
+		panic(err)
+	}
+}
+
+// AddLatestRoundDataCall adds the call of latestRoundData of feed to req, so that the feeds of a source are fetched in
+// the batch of its other calls.
+func AddLatestRoundDataCall(req *ethrpc.Request, feed string, round *RoundData) *ethrpc.Request {
+	return req.AddCall(&ethrpc.Call{
+		ABI:    AggregatorABI,
+		Target: feed,
+		Method: methodLatestRoundData,
+	}, []any{round})
+}
+
+// AddGetRoundDataCalls adds the calls of getRoundData of feed for the count rounds preceding latestRoundId to req,
+// latest first, and returns the rounds they are unpacked into.
+func AddGetRoundDataCalls(req *ethrpc.Request, feed string, latestRoundId *big.Int, count int) []RoundData {
+	rounds := make([]RoundData, count)
+	for i := range rounds {
+		req.AddCall(&ethrpc.Call{
+			ABI:    AggregatorABI,
+			Target: feed,
+			Method: methodGetRoundData,
+			Params: []any{new(big.Int).Sub(latestRoundId, big.NewInt(int64(i+1)))},
+		}, []any{&rounds[i]})
+	}
+	return rounds
+}
+
+// AddLatestAnswerCalls adds the calls of the legacy latestRound and latestAnswer of feed to req.
+func AddLatestAnswerCalls(req *ethrpc.Request, feed string, roundId, answer **big.Int) *ethrpc.Request {
+	return req.AddCall(&ethrpc.Call{
+		ABI:    AggregatorABI,
+		Target: feed,
+		Method: methodLatestRound,
+	}, []any{roundId}).AddCall(&ethrpc.Call{
+		ABI:    AggregatorABI,
+		Target: feed,
+		Method: methodLatestAnswer,
+	}, []any{answer})
+}
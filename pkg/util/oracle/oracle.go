@@ -0,0 +1,128 @@
+// Package oracle holds what the sources reading Chainlink-style price feeds share: their round data, the staleness,
+// sequencer uptime and deviation checks their contracts revert on, and the batched calls fetching them.
+package oracle
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/holiman/uint256"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+var (
+	ErrInvalidPrice       = errors.New("invalid oracle price")
+	ErrStalePrice         = errors.New("stale oracle price")
+	ErrSequencerDown      = errors.New("sequencer is down")
+	ErrGracePeriodNotOver = errors.New("sequencer grace period is not over")
+)
+
+// RoundData is what latestRoundData and getRoundData of an aggregator return.
+type RoundData struct {
+	RoundId         *big.Int `json:"roundId"`
+	Answer          *big.Int `json:"answer"`
+	StartedAt       *big.Int `json:"startedAt"`
+	UpdatedAt       *big.Int `json:"updatedAt"`
+	AnsweredInRound *big.Int `json:"answeredInRound"`
+}
+
+// Feed returns the latest answer of the round, as kept by the simulators.
+func (r *RoundData) Feed() Feed {
+	return Feed{Answer: r.Answer, UpdatedAt: r.UpdatedAt}
+}
+
+// Feed is the latest answer of an aggregator and when it was updated.
+type Feed struct {
+	Answer    *big.Int `json:"answer"`
+	UpdatedAt *big.Int `json:"updatedAt"`
+}
+
+// Price returns the answer of the feed, or the error its consumers revert with if it is older than heartbeat seconds
+// at now, or not positive. A zero heartbeat is not checked.
+func (f *Feed) Price(heartbeat, now uint64) (*big.Int, error) {
+	if heartbeat > 0 && (f.UpdatedAt == nil || IsStale(f.UpdatedAt.Uint64(), heartbeat, now)) {
+		return nil, ErrStalePrice
+	}
+	if f.Answer == nil || f.Answer.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	return f.Answer, nil
+}
+
+// IsStale tells whether an answer updated at updatedAt is older than heartbeat seconds at now.
+func IsStale(updatedAt, heartbeat, now uint64) bool {
+	return updatedAt+heartbeat < now
+}
+
+// Sequencer is the latest round of an L2 sequencer uptime feed, whose answer is 0 while the sequencer is up and 1
+// while it is down, since StartedAt.
+type Sequencer struct {
+	Answer    *big.Int `json:"answer"`
+	StartedAt *big.Int `json:"startedAt"`
+	// GracePeriod is how long after the sequencer is back up its feeds are not trusted yet, in seconds
+	GracePeriod uint64 `json:"gracePeriod,omitempty"`
+}
+
+// Check returns the error consumers of the feeds of an L2 revert with while its sequencer is down, or until more than
+// the grace period has passed since it came back up. A nil sequencer, on chains without one, is always up.
+func (s *Sequencer) Check(now uint64) error {
+	if s == nil {
+		return nil
+	} else if s.Answer == nil || s.Answer.Sign() != 0 {
+		return ErrSequencerDown
+	} else if s.StartedAt == nil || s.StartedAt.Uint64()+s.GracePeriod >= now {
+		return ErrGracePeriodNotOver
+	}
+	return nil
+}
+
+// SequencerConfig is the sequencer uptime feed of the chain of a source, left empty on chains without one.
+type SequencerConfig struct {
+	SequencerUptimeFeed string `json:"sequencerUptimeFeed,omitempty"`
+	// SequencerGracePeriod is the grace period of the Sequencer, in seconds
+	SequencerGracePeriod uint64 `json:"sequencerGracePeriod,omitempty"`
+}
+
+// AddCall adds the call of latestRoundData of the uptime feed to req, if there is one, in the batch of the other feeds.
+func (c *SequencerConfig) AddCall(req *ethrpc.Request, round *RoundData) *ethrpc.Request {
+	if c.SequencerUptimeFeed == "" {
+		return req
+	}
+	return AddLatestRoundDataCall(req, c.SequencerUptimeFeed, round)
+}
+
+// Sequencer returns the sequencer of the round fetched by AddCall, nil without an uptime feed. A round that failed to
+// be fetched is a sequencer that is down.
+func (c *SequencerConfig) Sequencer(round *RoundData) *Sequencer {
+	if c.SequencerUptimeFeed == "" {
+		return nil
+	}
+	return &Sequencer{Answer: round.Answer, StartedAt: round.StartedAt, GracePeriod: c.SequencerGracePeriod}
+}
+
+// Scale converts answer from decimals to targetDecimals, rounding down.
+func Scale(answer *big.Int, decimals, targetDecimals uint8) *big.Int {
+	if decimals < targetDecimals {
+		return new(big.Int).Mul(answer, bignumber.TenPowInt(targetDecimals-decimals))
+	} else if decimals > targetDecimals {
+		return new(big.Int).Quo(answer, bignumber.TenPowInt(decimals-targetDecimals))
+	}
+	return answer
+}
+
+// InBounds tells whether price lies within reference * (unit ∓ bound) / unit, the bounds rounded down as the contracts
+// compute them. A zero reference does not bound price.
+func InBounds(price, reference, bound, unit *uint256.Int) bool {
+	if reference.IsZero() {
+		return true
+	}
+
+	var lower, upper uint256.Int
+	if bound.Lt(unit) {
+		lower.Div(lower.Mul(reference, lower.Sub(unit, bound)), unit)
+	}
+	upper.Div(upper.Mul(reference, upper.Add(unit, bound)), unit)
+	return !price.Lt(&lower) && !price.Gt(&upper)
+}
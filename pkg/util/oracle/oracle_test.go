@@ -0,0 +1,74 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedPrice(t *testing.T) {
+	feed := Feed{Answer: big.NewInt(2000e8), UpdatedAt: big.NewInt(1000)}
+
+	price, err := feed.Price(3600, 4600)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000e8), price.Int64())
+	_, err = feed.Price(3600, 4601)
+	assert.ErrorIs(t, err, ErrStalePrice)
+	_, err = feed.Price(0, 1e9)
+	assert.NoError(t, err, "a zero heartbeat should not be checked")
+
+	_, err = (&Feed{Answer: big.NewInt(0), UpdatedAt: big.NewInt(1000)}).Price(3600, 1000)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	_, err = (&Feed{Answer: big.NewInt(1)}).Price(3600, 1000)
+	assert.ErrorIs(t, err, ErrStalePrice)
+}
+
+func TestRoundData(t *testing.T) {
+	round := RoundData{RoundId: big.NewInt(5), Answer: big.NewInt(1), StartedAt: big.NewInt(900),
+		UpdatedAt: big.NewInt(1000), AnsweredInRound: big.NewInt(5)}
+	assert.Equal(t, Feed{Answer: round.Answer, UpdatedAt: round.UpdatedAt}, round.Feed())
+}
+
+func TestSequencer(t *testing.T) {
+	assert.NoError(t, (*Sequencer)(nil).Check(0))
+	assert.ErrorIs(t, (&Sequencer{Answer: big.NewInt(1), StartedAt: big.NewInt(0), GracePeriod: 3600}).Check(1e9),
+		ErrSequencerDown)
+
+	up := &Sequencer{Answer: big.NewInt(0), StartedAt: big.NewInt(1000), GracePeriod: 3600}
+	assert.ErrorIs(t, up.Check(4599), ErrGracePeriodNotOver)
+	// consumers revert while now - startedAt <= gracePeriod
+	assert.ErrorIs(t, up.Check(4600), ErrGracePeriodNotOver)
+	assert.NoError(t, up.Check(4601))
+
+	t.Run("config", func(t *testing.T) {
+		round := RoundData{Answer: big.NewInt(0), StartedAt: big.NewInt(1000)}
+		assert.Nil(t, (&SequencerConfig{}).Sequencer(&round))
+
+		cfg := SequencerConfig{SequencerUptimeFeed: "0xFdB631F5EE196F0ed6FAa767959853A9F217697D",
+			SequencerGracePeriod: 3600}
+		assert.Equal(t, up, cfg.Sequencer(&round))
+		// a round that failed to be fetched is a sequencer that is down
+		assert.ErrorIs(t, cfg.Sequencer(&RoundData{}).Check(1e9), ErrSequencerDown)
+	})
+}
+
+func TestScale(t *testing.T) {
+	answer := big.NewInt(123456789)
+	assert.Equal(t, "1234567890000000000", Scale(answer, 8, 18).String())
+	assert.Equal(t, "1234", Scale(answer, 8, 3).String())
+	assert.Same(t, answer, Scale(answer, 8, 8))
+}
+
+func TestInBounds(t *testing.T) {
+	unit, bound := uint256.NewInt(1e18), uint256.NewInt(1e16) // 1%
+	reference := uint256.NewInt(1000)
+
+	assert.True(t, InBounds(uint256.NewInt(990), reference, bound, unit))
+	assert.True(t, InBounds(uint256.NewInt(1010), reference, bound, unit))
+	assert.False(t, InBounds(uint256.NewInt(989), reference, bound, unit))
+	assert.False(t, InBounds(uint256.NewInt(1011), reference, bound, unit))
+	assert.True(t, InBounds(uint256.NewInt(1e9), new(uint256.Int), bound, unit))
+	assert.True(t, InBounds(uint256.NewInt(0), reference, uint256.NewInt(2e18), unit))
+}
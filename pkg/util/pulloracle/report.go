@@ -0,0 +1,106 @@
+// Package pulloracle lets oracle-priced sources quote with signed off-chain price reports, which pull-oracle DEXes
+// verify in the same transaction as the swap: a Provider fetches the latest reports of feeds, a tracker or an RFQ step
+// attaches them to the pool state, and the simulator prices with them and passes their payloads on for execution.
+package pulloracle
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	ErrReportNotFound           = errors.New("price report not found")
+	ErrReportExpired            = errors.New("price report expired")
+	ErrReportTooOld             = errors.New("price report too old")
+	ErrInvalidReport            = errors.New("invalid price report")
+	ErrUnsupportedReportVersion = errors.New("unsupported price report version")
+)
+
+// Report is a signed price report of a feed, with the prices it attests decoded from its payload.
+type Report struct {
+	FeedID string `json:"feedId"`
+	// Price is the benchmark price, Bid and Ask the prices to sell and to buy at, with Decimals decimals
+	Price    *big.Int `json:"price"`
+	Bid      *big.Int `json:"bid"`
+	Ask      *big.Int `json:"ask"`
+	Decimals uint8    `json:"decimals"`
+	// ObservedAt is when the prices were observed, and ExpiresAt the last second the report can be verified at
+	ObservedAt uint64 `json:"observedAt"`
+	ExpiresAt  uint64 `json:"expiresAt"`
+	// Payload is the signed report, as submitted on chain
+	Payload hexutil.Bytes `json:"payload"`
+}
+
+// IsExpired tells whether the report can no longer be verified at now. A zero ExpiresAt never expires.
+func (r *Report) IsExpired(now uint64) bool {
+	return r.ExpiresAt != 0 && r.ExpiresAt < now
+}
+
+// Reports are reports by feed id.
+type Reports map[string]*Report
+
+// Get returns the report of a feed if it can still be verified at now, with positive prices. A non-zero maxAge also
+// rejects reports observed more than maxAge seconds before now, as consumers bounding the age of prices do.
+func (r Reports) Get(feedID string, now, maxAge uint64) (*Report, error) {
+	report := r[NormalizeFeedID(feedID)]
+	if report == nil {
+		return nil, ErrReportNotFound
+	} else if report.IsExpired(now) {
+		return nil, ErrReportExpired
+	} else if maxAge != 0 && report.ObservedAt+maxAge < now {
+		return nil, ErrReportTooOld
+	} else if report.Bid == nil || report.Bid.Sign() <= 0 || report.Ask == nil || report.Ask.Cmp(report.Bid) < 0 {
+		return nil, ErrInvalidReport
+	}
+	return report, nil
+}
+
+// With returns the reports with reports added, replacing those of the same feeds, leaving r untouched as it may be
+// shared by cloned pools.
+func (r Reports) With(reports Reports) Reports {
+	merged := make(Reports, len(r)+len(reports))
+	maps.Copy(merged, r)
+	for _, report := range reports {
+		merged[NormalizeFeedID(report.FeedID)] = report
+	}
+	return merged
+}
+
+// NormalizeFeedID returns the lowercase hex of a feed id, reports being keyed by it.
+func NormalizeFeedID(feedID string) string {
+	return strings.ToLower(feedID)
+}
+
+// Provider fetches the latest reports of feeds from a pull oracle.
+type Provider interface {
+	// LatestReports returns the latest reports of feedIDs, by feed id.
+	LatestReports(ctx context.Context, feedIDs []string) (Reports, error)
+}
+
+// Consumer is a pool quoting with reports, which a tracker or an RFQ step refreshes before quoting.
+type Consumer interface {
+	// FeedIDs returns the feeds the pool prices with.
+	FeedIDs() []string
+	// SetReports attaches reports to the pool, replacing the reports of the same feeds.
+	SetReports(reports Reports)
+}
+
+// Refresh attaches the latest reports of the feeds of consumer, fetched from provider.
+func Refresh(ctx context.Context, provider Provider, consumer Consumer) error {
+	feedIDs := consumer.FeedIDs()
+	if len(feedIDs) == 0 {
+		return nil
+	}
+
+	reports, err := provider.LatestReports(ctx, feedIDs)
+	if err != nil {
+		return err
+	}
+	consumer.SetReports(reports)
+	return nil
+}
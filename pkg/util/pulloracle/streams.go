@@ -0,0 +1,130 @@
+package pulloracle
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KyberNetwork/blockchain-toolkit/time/durationjson"
+	"github.com/KyberNetwork/kutils/klog"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util"
+)
+
+const (
+	streamsLatestReportPath = "/api/v1/reports/latest"
+
+	streamsAuthorizationHeaderKey = "Authorization"
+	streamsTimestampHeaderKey     = "X-Authorization-Timestamp"
+	streamsSignatureHeaderKey     = "X-Authorization-Signature-SHA256"
+)
+
+var ErrFetchReportFailed = errors.New("fetch price report failed")
+
+// StreamsConfig is the config of a Chainlink Data Streams REST API client.
+type StreamsConfig struct {
+	BaseURL    string                `mapstructure:"base_url" json:"base_url"`
+	APIKey     string                `mapstructure:"api_key" json:"api_key"`
+	APISecret  string                `mapstructure:"api_secret" json:"api_secret"`
+	Timeout    durationjson.Duration `mapstructure:"timeout" json:"timeout"`
+	RetryCount int                   `mapstructure:"retry_count" json:"retry_count"`
+	Client     *resty.Client         `json:"-"`
+}
+
+// StreamsClient is a Provider of the reports of Chainlink Data Streams.
+type StreamsClient struct {
+	client *resty.Client
+	config *StreamsConfig
+}
+
+var _ Provider = (*StreamsClient)(nil)
+
+func NewStreamsClient(config *StreamsConfig) *StreamsClient {
+	if config.Client == nil {
+		config.Client = resty.New()
+	}
+	config.Client.SetBaseURL(config.BaseURL).
+		SetTimeout(config.Timeout.Duration).
+		SetRetryCount(config.RetryCount)
+
+	return &StreamsClient{
+		client: config.Client,
+		config: config,
+	}
+}
+
+type streamsReportResponse struct {
+	Report struct {
+		FeedID                string        `json:"feedID"`
+		ValidFromTimestamp    uint64        `json:"validFromTimestamp"`
+		ObservationsTimestamp uint64        `json:"observationsTimestamp"`
+		FullReport            hexutil.Bytes `json:"fullReport"`
+	} `json:"report"`
+}
+
+// LatestReports fetches the latest report of each feed.
+func (c *StreamsClient) LatestReports(ctx context.Context, feedIDs []string) (Reports, error) {
+	reports := make(Reports, len(feedIDs))
+	for _, feedID := range feedIDs {
+		report, err := c.latestReport(ctx, feedID)
+		if err != nil {
+			return nil, err
+		}
+		reports[NormalizeFeedID(report.FeedID)] = report
+	}
+	return reports, nil
+}
+
+func (c *StreamsClient) latestReport(ctx context.Context, feedID string) (*Report, error) {
+	path := streamsLatestReportPath + "?feedID=" + feedID
+
+	var result streamsReportResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeaders(c.authHeaders(http.MethodGet, path, time.Now().UnixMilli())).
+		SetResult(&result).
+		Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		klog.WithFields(ctx, klog.Fields{
+			"pulloracle.feed":   feedID,
+			"pulloracle.resp":   util.MaxBytesToString(resp.Body(), 256),
+			"pulloracle.status": resp.StatusCode(),
+		}).Error("fetch streams report failed")
+		return nil, ErrFetchReportFailed
+	}
+
+	report, err := DecodeStreamsReport(result.Report.FullReport)
+	if err != nil {
+		return nil, err
+	} else if NormalizeFeedID(report.FeedID) != NormalizeFeedID(feedID) {
+		return nil, errors.WithMessagef(ErrInvalidReport, "report of feed %s for feed %s", report.FeedID, feedID)
+	}
+	return report, nil
+}
+
+// authHeaders signs a request without body with the HMAC of the Data Streams API:
+// https://docs.chain.link/data-streams/reference/interface-api#authentication
+func (c *StreamsClient) authHeaders(method, path string, timestampMs int64) map[string]string {
+	bodyHash := sha256.Sum256(nil)
+	mac := hmac.New(sha256.New, []byte(c.config.APISecret))
+	_, _ = fmt.Fprintf(mac, "%s %s %s %s %d", method, path, hex.EncodeToString(bodyHash[:]), c.config.APIKey,
+		timestampMs)
+
+	return map[string]string{
+		streamsAuthorizationHeaderKey: c.config.APIKey,
+		streamsTimestampHeaderKey:     strconv.FormatInt(timestampMs, 10),
+		streamsSignatureHeaderKey:     hex.EncodeToString(mac.Sum(nil)),
+	}
+}
@@ -0,0 +1,94 @@
+package pulloracle
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// streamsReportV3 is the report schema of Data Streams crypto feeds:
+// https://docs.chain.link/data-streams/reference/report-schema
+type streamsReportV3 struct {
+	FeedId                [32]byte
+	ValidFromTimestamp    uint32
+	ObservationsTimestamp uint32
+	NativeFee             *big.Int
+	LinkFee               *big.Int
+	ExpiresAt             uint32
+	BenchmarkPrice        *big.Int
+	Bid                   *big.Int
+	Ask                   *big.Int
+}
+
+const streamsReportV3Decimals = 18
+
+var (
+	bytes32Type, _      = abi.NewType("bytes32", "", nil)
+	bytes32ArrayType, _ = abi.NewType("bytes32[]", "", nil)
+	uint32Type, _       = abi.NewType("uint32", "", nil)
+	uint192Type, _      = abi.NewType("uint192", "", nil)
+	int192Type, _       = abi.NewType("int192", "", nil)
+
+	reportContextType, _ = abi.NewType("bytes32[3]", "", nil)
+	bytesType, _         = abi.NewType("bytes", "", nil)
+
+	// streamsFullReportArgs are the signed report, its context, report data and signatures, that verifiers take
+	streamsFullReportArgs = abi.Arguments{
+		{Name: "reportContext", Type: reportContextType},
+		{Name: "reportBlob", Type: bytesType},
+		{Name: "rawRs", Type: bytes32ArrayType},
+		{Name: "rawSs", Type: bytes32ArrayType},
+		{Name: "rawVs", Type: bytes32Type},
+	}
+	streamsReportV3Args = abi.Arguments{
+		{Name: "feedId", Type: bytes32Type},
+		{Name: "validFromTimestamp", Type: uint32Type},
+		{Name: "observationsTimestamp", Type: uint32Type},
+		{Name: "nativeFee", Type: uint192Type},
+		{Name: "linkFee", Type: uint192Type},
+		{Name: "expiresAt", Type: uint32Type},
+		{Name: "benchmarkPrice", Type: int192Type},
+		{Name: "bid", Type: int192Type},
+		{Name: "ask", Type: int192Type},
+	}
+)
+
+// DecodeStreamsReport decodes a full report of Data Streams, whose version is the first 2 bytes of its feed id. Only
+// V3 reports, of crypto feeds, are supported.
+func DecodeStreamsReport(fullReport []byte) (*Report, error) {
+	values, err := streamsFullReportArgs.Unpack(fullReport)
+	if err != nil {
+		return nil, errors.WithMessage(ErrInvalidReport, err.Error())
+	}
+	reportBlob, _ := values[1].([]byte)
+	if len(reportBlob) < 32 {
+		return nil, ErrInvalidReport
+	}
+
+	if version := binary.BigEndian.Uint16(reportBlob[:2]); version != 3 {
+		return nil, errors.WithMessagef(ErrUnsupportedReportVersion, "v%d", version)
+	}
+
+	var report streamsReportV3
+	values, err = streamsReportV3Args.Unpack(reportBlob)
+	if err == nil {
+		err = streamsReportV3Args.Copy(&report, values)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(ErrInvalidReport, err.Error())
+	}
+
+	return &Report{
+		FeedID:     hexutil.Encode(report.FeedId[:]),
+		Price:      report.BenchmarkPrice,
+		Bid:        report.Bid,
+		Ask:        report.Ask,
+		Decimals:   streamsReportV3Decimals,
+		ObservedAt: uint64(report.ObservationsTimestamp),
+		ExpiresAt:  uint64(report.ExpiresAt),
+		Payload:    fullReport,
+	}, nil
+}
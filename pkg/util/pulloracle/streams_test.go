@@ -0,0 +1,173 @@
+package pulloracle
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+const (
+	testAPIKey    = "test-key"
+	testAPISecret = "test-secret"
+)
+
+// newTestFeedID returns a feed id of the report version, which Data Streams keeps in its first 2 bytes.
+func newTestFeedID(version uint16, id byte) common.Hash {
+	var feedID common.Hash
+	binary.BigEndian.PutUint16(feedID[:2], version)
+	feedID[31] = id
+	return feedID
+}
+
+// newTestFullReport packs a V3 report of feedID as Data Streams signs it, with dummy signatures.
+func newTestFullReport(t *testing.T, feedID common.Hash, price, bid, ask *big.Int,
+	observedAt, expiresAt uint32) []byte {
+	t.Helper()
+
+	reportBlob, err := streamsReportV3Args.Pack(feedID, observedAt, observedAt, big.NewInt(1), big.NewInt(2),
+		expiresAt, price, bid, ask)
+	require.NoError(t, err)
+
+	fullReport, err := streamsFullReportArgs.Pack([3][32]byte{{1}, {2}, {3}}, reportBlob, [][32]byte{{4}},
+		[][32]byte{{5}}, [32]byte{6})
+	require.NoError(t, err)
+	return fullReport
+}
+
+// newTestStreamsServer serves the full reports by feed id, checking the requests are signed.
+func newTestStreamsServer(t *testing.T, fullReports map[string][]byte) *httptest.Server {
+	t.Helper()
+
+	client := NewStreamsClient(&StreamsConfig{APIKey: testAPIKey, APISecret: testAPISecret})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestampMs, err := strconv.ParseInt(r.Header.Get(streamsTimestampHeaderKey), 10, 64)
+		expectedHeaders := client.authHeaders(r.Method, r.URL.RequestURI(), timestampMs)
+		if err != nil || r.Header.Get(streamsAuthorizationHeaderKey) != testAPIKey ||
+			r.Header.Get(streamsSignatureHeaderKey) != expectedHeaders[streamsSignatureHeaderKey] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		feedID := r.URL.Query().Get("feedID")
+		fullReport, ok := fullReports[feedID]
+		if r.URL.Path != streamsLatestReportPath || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var resp streamsReportResponse
+		resp.Report.FeedID = feedID
+		resp.Report.FullReport = fullReport
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStreamsClient_LatestReports(t *testing.T) {
+	t.Parallel()
+
+	ethFeedID, btcFeedID := newTestFeedID(3, 1), newTestFeedID(3, 2)
+	ethFullReport := newTestFullReport(t, ethFeedID, big.NewInt(2000e6), big.NewInt(1999e6), big.NewInt(2001e6),
+		1700000000, 1700086400)
+	btcFullReport := newTestFullReport(t, btcFeedID, big.NewInt(60000e6), big.NewInt(59990e6), big.NewInt(60010e6),
+		1700000001, 1700086401)
+	server := newTestStreamsServer(t, map[string][]byte{
+		ethFeedID.Hex(): ethFullReport,
+		btcFeedID.Hex(): btcFullReport,
+	})
+
+	client := NewStreamsClient(&StreamsConfig{BaseURL: server.URL, APIKey: testAPIKey, APISecret: testAPISecret})
+	reports, err := client.LatestReports(context.Background(), []string{ethFeedID.Hex(), btcFeedID.Hex()})
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	// feed ids are matched case-insensitively
+	report, err := reports.Get("0x"+strings.ToUpper(ethFeedID.Hex()[2:]), 1700000000, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		FeedID:     ethFeedID.Hex(),
+		Price:      big.NewInt(2000e6),
+		Bid:        big.NewInt(1999e6),
+		Ask:        big.NewInt(2001e6),
+		Decimals:   18,
+		ObservedAt: 1700000000,
+		ExpiresAt:  1700086400,
+		Payload:    ethFullReport,
+	}, report)
+
+	_, err = reports.Get(btcFeedID.Hex(), 1700086402, 0)
+	assert.ErrorIs(t, err, ErrReportExpired)
+
+	_, err = reports.Get(ethFeedID.Hex(), 1700000060, 60)
+	assert.NoError(t, err)
+	_, err = reports.Get(ethFeedID.Hex(), 1700000061, 60)
+	assert.ErrorIs(t, err, ErrReportTooOld)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client := NewStreamsClient(&StreamsConfig{BaseURL: server.URL, APIKey: testAPIKey, APISecret: "wrong"})
+		_, err := client.LatestReports(context.Background(), []string{ethFeedID.Hex()})
+		assert.ErrorIs(t, err, ErrFetchReportFailed)
+	})
+
+	t.Run("unknown feed", func(t *testing.T) {
+		_, err := client.LatestReports(context.Background(), []string{newTestFeedID(3, 3).Hex()})
+		assert.ErrorIs(t, err, ErrFetchReportFailed)
+	})
+}
+
+func TestDecodeStreamsReport(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeStreamsReport(hexutil.MustDecode("0x1234"))
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	v4FullReport := newTestFullReport(t, newTestFeedID(4, 1), bignumber.One, bignumber.One, bignumber.One, 0, 0)
+	_, err = DecodeStreamsReport(v4FullReport)
+	assert.ErrorIs(t, err, ErrUnsupportedReportVersion)
+}
+
+type testConsumer struct {
+	feedIDs []string
+	reports Reports
+}
+
+func (c *testConsumer) FeedIDs() []string {
+	return c.feedIDs
+}
+
+func (c *testConsumer) SetReports(reports Reports) {
+	c.reports = c.reports.With(reports)
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	feedID := newTestFeedID(3, 1)
+	fullReport := newTestFullReport(t, feedID, big.NewInt(100), big.NewInt(99), big.NewInt(101), 1, 2)
+	server := newTestStreamsServer(t, map[string][]byte{feedID.Hex(): fullReport})
+	client := NewStreamsClient(&StreamsConfig{BaseURL: server.URL, APIKey: testAPIKey, APISecret: testAPISecret})
+
+	staleReports := Reports{feedID.Hex(): {FeedID: feedID.Hex(), Bid: big.NewInt(1), Ask: big.NewInt(1)}}
+	consumer := &testConsumer{feedIDs: []string{feedID.Hex()}, reports: staleReports}
+	require.NoError(t, Refresh(context.Background(), client, consumer))
+
+	report, err := consumer.reports.Get(feedID.Hex(), 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Bytes(fullReport), report.Payload)
+	assert.Equal(t, "1", staleReports[feedID.Hex()].Bid.String(), "attached reports must not be mutated")
+}
@@ -0,0 +1,115 @@
+package testutil
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KyberNetwork/ethrpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// CallFn returns the return data of a call of data to target, or false if the call reverts
+type CallFn func(target common.Address, data []byte) ([]byte, bool)
+
+var multicallABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[{"name":"aggregate","type":"function","inputs":[
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],
+		"outputs":[{"name":"blockNumber","type":"uint256"},{"name":"returnData","type":"bytes[]"}]},
+		{"name":"tryAggregate","type":"function","inputs":[
+		{"name":"requireSuccess","type":"bool"},
+		{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],
+		"outputs":[{"name":"returnData","type":"tuple[]","components":[
+			{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// NewEthrpcClient returns an ethrpc client whose multicall aggregate and tryAggregate requests are answered by call,
+// so that code fetching on-chain state can be tested without an RPC. A reverting call fails a whole aggregate.
+func NewEthrpcClient(t testing.TB, call CallFn) *ethrpc.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		resp := map[string]any{"jsonrpc": "2.0"}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Params) == 0 {
+			resp["error"] = map[string]any{"code": -32600, "message": "invalid request"}
+		} else if result, err := multicall(req.Params[0], call); err != nil {
+			resp["error"] = map[string]any{"code": 3, "message": err.Error()}
+		} else {
+			resp["result"] = hexutil.Bytes(result)
+		}
+		resp["id"] = req.ID
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+	return ethrpc.New(server.URL).SetMulticallContract(common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"))
+}
+
+// multicall answers the eth_call of a multicall aggregate or tryAggregate with call
+func multicall(param json.RawMessage, call CallFn) ([]byte, error) {
+	var msg struct {
+		Data  hexutil.Bytes `json:"data"`
+		Input hexutil.Bytes `json:"input"`
+	}
+	if err := json.Unmarshal(param, &msg); err != nil {
+		return nil, err
+	}
+	data := append(msg.Input, msg.Data...)
+	method, err := multicallABI.MethodById(data)
+	if err != nil {
+		return nil, err
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	var in struct {
+		RequireSuccess bool
+		Calls          []struct {
+			Target   common.Address
+			CallData []byte
+		}
+	}
+	var dst any = &in
+	if method.Name == ethrpc.MethodAggregate {
+		dst = &in.Calls // a single argument is copied as is
+	}
+	if err := method.Inputs.Copy(dst, values); err != nil {
+		return nil, err
+	}
+
+	results := make([]struct {
+		Success    bool
+		ReturnData []byte
+	}, len(in.Calls))
+	for i, c := range in.Calls {
+		results[i].ReturnData, results[i].Success = call(c.Target, c.CallData)
+	}
+	if method.Name != ethrpc.MethodAggregate {
+		return method.Outputs.Pack(results)
+	}
+	returnData := make([][]byte, len(results))
+	for i, result := range results {
+		if !result.Success {
+			return nil, errors.New("execution reverted")
+		}
+		returnData[i] = result.ReturnData
+	}
+	return method.Outputs.Pack(big.NewInt(1), returnData)
+}
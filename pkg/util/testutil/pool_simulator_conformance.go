@@ -0,0 +1,260 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/source/pool"
+	"github.com/KyberNetwork/kyberswap-dex-lib/pkg/util/bignumber"
+)
+
+// ConformanceOption configures TestPoolSimulator
+type ConformanceOption interface {
+	apply(config *conformanceConfig)
+}
+
+type conformanceConfig struct {
+	skips           []ConformanceCheck
+	liquidityErrors []error
+}
+
+// ConformanceCheck is a single invariant checked by TestPoolSimulator. Passed as a ConformanceOption, it is skipped.
+type ConformanceCheck uint8
+
+func (c ConformanceCheck) apply(config *conformanceConfig) {
+	config.skips = append(config.skips, c)
+}
+
+type liquidityErrors []error
+
+func (e liquidityErrors) apply(config *conformanceConfig) {
+	config.liquidityErrors = append(config.liquidityErrors, e...)
+}
+
+// WithLiquidityErrors declares the errors a pool returns when it lacks liquidity for a quote, which it may return when
+// re-quoting after UpdateBalance. Any other error fails the test.
+func WithLiquidityErrors(errs ...error) ConformanceOption {
+	return liquidityErrors(errs)
+}
+
+// MsgpackRoundTrip encodes a pools map to a snapshot and decodes it back
+type MsgpackRoundTrip func(poolsMap map[string]pool.IPoolSimulator) (map[string]pool.IPoolSimulator, error)
+
+var msgpackRoundTrip MsgpackRoundTrip
+
+// RegisterMsgpackRoundTrip sets the round-trip of CheckMsgpack. It is called by pkg/msgpack/msgpacktest with the
+// snapshot encoding of pkg/msgpack, which pool packages cannot import. The check is skipped where it isn't registered,
+// so the round-trip of every pool type is tested once by the fixture table of msgpacktest.
+func RegisterMsgpackRoundTrip(roundTrip MsgpackRoundTrip) {
+	msgpackRoundTrip = roundTrip
+}
+
+const (
+	// CheckTokens checks that CanSwapTo/CanSwapFrom agree with GetTokens and with each other
+	CheckTokens ConformanceCheck = iota
+	// CheckMonotonic checks that amountOut is non-decreasing in amountIn
+	CheckMonotonic
+	// CheckCloneState checks that UpdateBalance on a CloneState result does not affect the original
+	CheckCloneState
+	// CheckUpdateBalance checks that re-quoting after UpdateBalance does not give a better output
+	CheckUpdateBalance
+	// CheckMsgpack checks that a round-trip through a pkg/msgpack snapshot gives identical quotes if one is
+	// registered, see RegisterMsgpackRoundTrip
+	CheckMsgpack
+)
+
+var conformanceExps = []int{3, 4, 6, 9, 13, 17, 21, 25}
+
+// TestPoolSimulator tests the invariants every IPoolSimulator must satisfy with generated sensible inputs.
+// Checks that don't apply to a simulator (e.g. for rate-based pools) can be skipped.
+func TestPoolSimulator[TB interface {
+	testing.TB
+	Run(string, func(TB)) bool
+}](tb TB, poolSim pool.IPoolSimulator, opts ...ConformanceOption) {
+	config := conformanceConfig{liquidityErrors: []error{pool.ErrNotEnoughInventory}}
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+	enabled := func(check ConformanceCheck) bool {
+		return !slices.Contains(config.skips, check)
+	}
+
+	if enabled(CheckTokens) {
+		tb.Run("tokens", func(tb TB) {
+			checkTokens(tb, poolSim)
+		})
+	}
+
+	var roundTripped pool.IPoolSimulator
+	if enabled(CheckMsgpack) {
+		roundTripped = checkMsgpackRoundTrip(tb, poolSim)
+	}
+
+	tokens := poolSim.GetTokens()
+	for inIdx, tokenIn := range tokens {
+		for _, tokenOut := range poolSim.CanSwapFrom(tokenIn) {
+			outIdx := poolSim.GetTokenIndex(tokenOut)
+			amountsIn, amountsOut, unsaturated := quoteLadder(poolSim, tokenIn, tokenOut)
+			if len(amountsIn) == 0 {
+				tb.Logf("token%d -> token%d: no successful quote, skipping", inIdx, outIdx)
+				continue
+			}
+			name := fmt.Sprintf("token%d -> token%d", inIdx, outIdx)
+
+			if enabled(CheckMonotonic) {
+				tb.Run(name+" monotonic", func(tb TB) {
+					for i := 1; i < len(amountsOut); i++ {
+						assert.Truef(tb, amountsOut[i].Cmp(amountsOut[i-1]) >= 0,
+							"amountOut %s for amountIn %s < amountOut %s for amountIn %s",
+							amountsOut[i], amountsIn[i], amountsOut[i-1], amountsIn[i-1])
+					}
+				})
+			}
+
+			// state checks use an amount the pool is not saturated at, where quotes are dominated by rounding
+			amountIn, amountOut := amountsIn[unsaturated/2], amountsOut[unsaturated/2]
+
+			if enabled(CheckCloneState) {
+				tb.Run(name+" clone state", func(tb TB) {
+					checkCloneState(tb, poolSim, tokenIn, tokenOut, amountIn, amountOut)
+				})
+			}
+
+			if enabled(CheckUpdateBalance) {
+				tb.Run(name+" update balance", func(tb TB) {
+					checkUpdateBalance(tb, poolSim, tokenIn, tokenOut, amountIn, config.liquidityErrors)
+				})
+			}
+
+			if roundTripped != nil {
+				tb.Run(name+" msgpack", func(tb TB) {
+					for i, amountIn := range amountsIn {
+						res, err := calcAmountOut(roundTripped, tokenIn, tokenOut, amountIn)
+						require.NoError(tb, err)
+						assert.Equalf(tb, amountsOut[i].String(), res.TokenAmountOut.Amount.String(),
+							"amountOut differs after msgpack round-trip for amountIn %s", amountIn)
+					}
+				})
+			}
+		}
+	}
+}
+
+func checkTokens(tb testing.TB, poolSim pool.IPoolSimulator) {
+	tokens := poolSim.GetTokens()
+	for i, token := range tokens {
+		assert.Equalf(tb, i, poolSim.GetTokenIndex(token), "GetTokenIndex(%s)", token)
+		for _, tokenOut := range poolSim.CanSwapFrom(token) {
+			assert.NotEqualf(tb, token, tokenOut, "CanSwapFrom(%s) contains itself", token)
+			assert.Containsf(tb, tokens, tokenOut, "CanSwapFrom(%s) contains unknown token", token)
+			assert.Containsf(tb, poolSim.CanSwapTo(tokenOut), token,
+				"%s in CanSwapFrom(%s) but not vice versa in CanSwapTo", tokenOut, token)
+		}
+		for _, tokenIn := range poolSim.CanSwapTo(token) {
+			assert.NotEqualf(tb, token, tokenIn, "CanSwapTo(%s) contains itself", token)
+			assert.Containsf(tb, tokens, tokenIn, "CanSwapTo(%s) contains unknown token", token)
+			assert.Containsf(tb, poolSim.CanSwapFrom(tokenIn), token,
+				"%s in CanSwapTo(%s) but not vice versa in CanSwapFrom", tokenIn, token)
+		}
+	}
+	assert.Equal(tb, -1, poolSim.GetTokenIndex("0x000000000000000000000000000000000000dead"))
+}
+
+// quoteLadder quotes increasing amountIn, from the first amount the pool can quote until the first one it cannot.
+// It also returns the number of quotes before the pool saturates, i.e. a 10x amountIn increases amountOut by less
+// than 1%, where rounding of depleted reserves dominates.
+func quoteLadder(poolSim pool.IPoolSimulator, tokenIn, tokenOut string) (amountsIn, amountsOut []*big.Int,
+	unsaturated int) {
+	for _, exp := range conformanceExps {
+		amountIn := bignumber.TenPowInt(exp)
+		res, err := calcAmountOut(poolSim, tokenIn, tokenOut, amountIn)
+		if err != nil || !res.IsValid() {
+			if len(amountsIn) > 0 {
+				break
+			}
+			continue
+		}
+		n := len(amountsOut)
+		if n == unsaturated && (n == 0 || new(big.Int).Mul(res.TokenAmountOut.Amount, big.NewInt(100)).Cmp(
+			new(big.Int).Mul(amountsOut[n-1], big.NewInt(101))) >= 0) {
+			unsaturated++
+		}
+		amountsIn = append(amountsIn, amountIn)
+		amountsOut = append(amountsOut, res.TokenAmountOut.Amount)
+	}
+	return amountsIn, amountsOut, unsaturated
+}
+
+func checkCloneState(tb testing.TB, poolSim pool.IPoolSimulator, tokenIn, tokenOut string, amountIn,
+	amountOut *big.Int) {
+	cloned := poolSim.CloneState()
+	if cloned == nil {
+		tb.Skip("CloneState unimplemented")
+	}
+	res, err := calcAmountOut(cloned, tokenIn, tokenOut, amountIn)
+	require.NoError(tb, err)
+	assert.Equal(tb, amountOut.String(), res.TokenAmountOut.Amount.String(), "cloned pool quotes differently")
+	cloned.UpdateBalance(updateBalanceParams(tokenIn, amountIn, res))
+
+	res, err = calcAmountOut(poolSim, tokenIn, tokenOut, amountIn)
+	require.NoError(tb, err)
+	assert.Equal(tb, amountOut.String(), res.TokenAmountOut.Amount.String(),
+		"UpdateBalance on cloned pool changes quote of original pool")
+}
+
+func checkUpdateBalance(tb testing.TB, poolSim pool.IPoolSimulator, tokenIn, tokenOut string, amountIn *big.Int,
+	liquidityErrs []error) {
+	cloned := poolSim.CloneState()
+	if cloned == nil {
+		tb.Skip("CloneState unimplemented")
+	}
+	before, err := calcAmountOut(cloned, tokenIn, tokenOut, amountIn)
+	require.NoError(tb, err)
+	cloned.UpdateBalance(updateBalanceParams(tokenIn, amountIn, before))
+
+	after, err := calcAmountOut(cloned, tokenIn, tokenOut, amountIn)
+	if err != nil {
+		if !slices.ContainsFunc(liquidityErrs, func(liquidityErr error) bool { return errors.Is(err, liquidityErr) }) {
+			tb.Errorf("re-quote after UpdateBalance failed: %v", err)
+		}
+		return
+	}
+	assert.Truef(tb, after.TokenAmountOut.Amount.Cmp(before.TokenAmountOut.Amount) <= 0,
+		"amountOut increased from %s to %s after UpdateBalance", before.TokenAmountOut.Amount,
+		after.TokenAmountOut.Amount)
+}
+
+func checkMsgpackRoundTrip(tb testing.TB, poolSim pool.IPoolSimulator) pool.IPoolSimulator {
+	if msgpackRoundTrip == nil {
+		tb.Log("msgpack round-trip not registered, skipping")
+		return nil
+	}
+	poolsMap, err := msgpackRoundTrip(map[string]pool.IPoolSimulator{poolSim.GetAddress(): poolSim})
+	require.NoError(tb, err)
+	require.Contains(tb, poolsMap, poolSim.GetAddress())
+	return poolsMap[poolSim.GetAddress()]
+}
+
+func calcAmountOut(poolSim pool.IPoolSimulator, tokenIn, tokenOut string,
+	amountIn *big.Int) (*pool.CalcAmountOutResult, error) {
+	return pool.CalcAmountOut(poolSim, pool.TokenAmount{Token: tokenIn, Amount: new(big.Int).Set(amountIn)},
+		tokenOut, nil)
+}
+
+func updateBalanceParams(tokenIn string, amountIn *big.Int, res *pool.CalcAmountOutResult) pool.UpdateBalanceParams {
+	params := pool.UpdateBalanceParams{
+		TokenAmountIn:  pool.TokenAmount{Token: tokenIn, Amount: new(big.Int).Set(amountIn)},
+		TokenAmountOut: *res.TokenAmountOut,
+		SwapInfo:       res.SwapInfo,
+	}
+	if res.Fee != nil {
+		params.Fee = *res.Fee
+	}
+	return params
+}
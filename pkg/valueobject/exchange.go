@@ -139,6 +139,7 @@ const (
 	ExchangeFvm                        = "fvm"
 	ExchangeFxdx                       = "fxdx"
 	ExchangeGMX                        = "gmx"
+	ExchangeGMXV2                      = "gmx-v2"
 	ExchangeGemKeeper                  = "gemkeeper"
 	ExchangeGravity                    = "gravity"
 	ExchangeGyroscope2CLP              = "gyroscope-2clp"
@@ -545,6 +546,7 @@ var AMMSourceSet = map[Exchange]struct{}{
 	ExchangeFvm:                        {},
 	ExchangeFxdx:                       {},
 	ExchangeGMX:                        {},
+	ExchangeGMXV2:                      {},
 	ExchangeGemKeeper:                  {},
 	ExchangeGravity:                    {},
 	ExchangeGyroscope2CLP:              {},